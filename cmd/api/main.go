@@ -42,10 +42,74 @@ func main() {
 	fmt.Printf("   GET    /api/v1/knowledge/:id       - 获取知识库详情\n")
 	fmt.Printf("   PUT    /api/v1/knowledge/:id       - 更新知识库\n")
 	fmt.Printf("   DELETE /api/v1/knowledge/:id       - 删除知识库\n")
+	fmt.Printf("   PUT    /api/v1/knowledge/:id/status - 变更知识库状态（只读/归档）\n")
+	fmt.Printf("   POST   /api/v1/knowledge/:id/import - 导入 Markdown 压缩包（multipart，字段 file）\n")
+	fmt.Printf("   GET    /api/v1/knowledge/:id/export - 导出知识库（?format=markdown|jsonl|backup）\n")
+	fmt.Printf("   POST   /api/v1/knowledge/restore   - 从备份包恢复知识库（multipart，字段 file）\n")
 	fmt.Printf("   POST   /api/v1/knowledge/merge     - 合并知识库（事务演示）\n")
 	fmt.Printf("   POST   /api/v1/knowledge/:id/documents      - 添加文档\n")
-	fmt.Printf("   GET    /api/v1/knowledge/:id/documents      - 获取文档列表\n")
+	fmt.Printf("   POST   /api/v1/knowledge/:id/documents/batch - 批量添加/更新/删除文档（mode=atomic|best_effort）\n")
+	fmt.Printf("   GET    /api/v1/knowledge/:id/documents      - 获取文档列表（?format=raw|html|text）\n")
+	fmt.Printf("   GET    /api/v1/knowledge/:id/documents/:doc_id - 获取文档（?format=raw|html|text，附带目录）\n")
+	fmt.Printf("   PUT    /api/v1/knowledge/:id/documents/:doc_id - 更新文档\n")
 	fmt.Printf("   DELETE /api/v1/knowledge/:id/documents/:doc_id - 删除文档\n")
+	fmt.Printf("   GET    /api/v1/knowledge/:id/documents/:doc_id/links - 获取文档链接与反向链接\n")
+	fmt.Printf("   GET    /api/v1/knowledge/:id/links/broken   - 失效链接报告\n")
+	fmt.Printf("   POST   /api/v1/knowledge/:id/documents/:doc_id/submit    - 提交审核\n")
+	fmt.Printf("   POST   /api/v1/knowledge/:id/documents/:doc_id/approve   - 审核通过\n")
+	fmt.Printf("   POST   /api/v1/knowledge/:id/documents/:doc_id/reject    - 驳回\n")
+	fmt.Printf("   POST   /api/v1/knowledge/:id/documents/:doc_id/publish   - 发布\n")
+	fmt.Printf("   POST   /api/v1/knowledge/:id/documents/:doc_id/unpublish - 撤回发布\n")
+	fmt.Printf("   PUT    /api/v1/knowledge/:id/documents/:doc_id/schedule  - 定时发布/下线\n")
+	fmt.Printf("   PUT    /api/v1/knowledge/:id/documents/:doc_id/folder    - 移动文档到文件夹\n")
+	fmt.Printf("   GET    /api/v1/knowledge/:id/folders        - 获取文件夹树\n")
+	fmt.Printf("   POST   /api/v1/knowledge/:id/folders        - 创建文件夹\n")
+	fmt.Printf("   PUT    /api/v1/knowledge/:id/folders/:folder_id      - 重命名文件夹\n")
+	fmt.Printf("   PUT    /api/v1/knowledge/:id/folders/:folder_id/move - 移动文件夹\n")
+	fmt.Printf("   DELETE /api/v1/knowledge/:id/folders/:folder_id      - 删除文件夹\n")
+	fmt.Printf("   GET    /api/v1/knowledge/:id/tags           - 标签云\n")
+	fmt.Printf("   POST   /api/v1/knowledge/:id/tags           - 定义标签\n")
+	fmt.Printf("   PUT    /api/v1/knowledge/:id/tags/:tag_id   - 更新标签（父标签/别名）\n")
+	fmt.Printf("   DELETE /api/v1/knowledge/:id/tags/:tag_id   - 删除标签定义\n")
+	fmt.Printf("   POST   /api/v1/knowledge/:id/tags/rename    - 重命名标签\n")
+	fmt.Printf("   POST   /api/v1/knowledge/:id/tags/merge     - 合并标签\n")
+	fmt.Printf("   POST   /api/v1/knowledge/:id/documents/:doc_id/attachments - 上传附件（multipart，字段 file）\n")
+	fmt.Printf("   GET    /api/v1/knowledge/:id/documents/:doc_id/attachments - 列出文档附件\n")
+	fmt.Printf("   GET    /api/v1/knowledge/:id/attachments/:attachment_id    - 下载附件\n")
+	fmt.Printf("   DELETE /api/v1/knowledge/:id/attachments/:attachment_id    - 删除附件\n")
+	fmt.Printf("   GET    /api/v1/knowledge/:id/duplicates     - 重复文档检测（?cross=true&max_distance=8）\n")
+	fmt.Printf("   GET    /api/v1/duplicates           - 所有知识库的重复文档检测（?max_distance=8）\n")
+	fmt.Printf("   POST   /api/v1/duplicates/rebuild   - 重建文档内容指纹\n")
+	fmt.Printf("   GET    /api/v1/knowledge/:id/members          - 列出知识库成员\n")
+	fmt.Printf("   PUT    /api/v1/knowledge/:id/members/:subject - 授予成员角色（owner/editor/viewer）\n")
+	fmt.Printf("   DELETE /api/v1/knowledge/:id/members/:subject - 移除成员\n")
+	fmt.Printf("   POST   /api/v1/api-keys             - 签发 API Key（密钥明文只返回一次）\n")
+	fmt.Printf("   GET    /api/v1/api-keys             - 列出 API Key\n")
+	fmt.Printf("   DELETE /api/v1/api-keys/:key_id     - 吊销 API Key\n")
+	fmt.Printf("   GET    /api/v1/usage                - 查看租户配额用量\n")
+	fmt.Printf("   GET    /api/v1/audit-logs           - 查询审计日志（?knowledge_base_id=&actor=&event_name=&since=&until=&cursor=）\n")
+	fmt.Printf("   GET    /api/v1/audit-logs/export    - 导出审计日志为 CSV\n")
+	fmt.Printf("   GET    /api/v1/trash                - 查看回收站\n")
+	fmt.Printf("   POST   /api/v1/trash/knowledge/:id/restore - 恢复知识库\n")
+	fmt.Printf("   POST   /api/v1/trash/knowledge/:id/documents/:doc_id/restore - 恢复文档\n")
+	fmt.Printf("   POST   /api/v1/trash/purge          - 清理回收站\n")
+	fmt.Printf("   （写请求可携带 Idempotency-Key 请求头，重试时返回首次请求的响应）\n")
+	fmt.Printf("   （服务间调用可携带 X-API-Key 请求头代替访问令牌）\n")
+	fmt.Printf("   （可携带 X-Request-ID 和 traceparent 请求头关联日志，响应头和错误响应中返回请求ID和 trace-id）\n")
+	fmt.Printf("   （可携带 X-Tenant-ID 请求头指定租户，令牌或 API Key 绑定了租户时以其为准）\n")
+	if ctx.App.Auth.Enabled() {
+		fmt.Printf("🔐 已启用 JWT 认证: 请求需携带 Authorization: Bearer <token> 请求头\n")
+		fmt.Printf("   （知识库按成员角色授权，roles 声明包含 admin 的调用方可访问所有知识库）\n")
+	}
+	if ctx.App.RateLimit.Enabled() {
+		fmt.Printf("🚦 已启用限流: 超出时返回 429，Retry-After 响应头为需要等待的秒数\n")
+	}
+	if c.Telemetry.Endpoint != "" && !c.Telemetry.Disabled {
+		fmt.Printf("🔭 已启用链路追踪导出: Batcher=%s, Endpoint=%s\n", c.Telemetry.Batcher, c.Telemetry.Endpoint)
+	}
+	if c.Prometheus.Host != "" {
+		fmt.Printf("📈 监控指标: http://%s:%d%s\n", c.Prometheus.Host, c.Prometheus.Port, c.Prometheus.Path)
+	}
 	fmt.Printf("\n")
 
 	// 优雅关闭
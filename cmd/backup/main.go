@@ -0,0 +1,130 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/zeromicro/go-zero/core/conf"
+
+	"gozero-ddd/internal/application/command"
+	"gozero-ddd/internal/application/dto"
+	"gozero-ddd/internal/application/query"
+	"gozero-ddd/internal/domain/tenant"
+	"gozero-ddd/internal/infrastructure/config"
+	"gozero-ddd/internal/interfaces/api/svc"
+)
+
+var (
+	configFile  = flag.String("f", "etc/knowledge.yaml", "配置文件路径")
+	kbID        = flag.String("id", "", "要导出的知识库ID")
+	format      = flag.String("format", dto.ExportFormatBackup, "导出格式: markdown / jsonl / backup")
+	output      = flag.String("o", "", "导出文件路径，默认使用建议的文件名，- 表示标准输出")
+	restoreFile = flag.String("restore", "", "从备份包恢复知识库（指定时忽略导出参数）")
+	tenantID    = flag.String("tenant", tenant.Default.String(), "知识库所属租户")
+)
+
+// 知识库导出与备份恢复命令行工具
+//
+//	backup -id <知识库ID> [-format backup] [-o 文件] [-tenant 租户]
+//	backup -restore <备份包> [-tenant 租户]
+func main() {
+	flag.Parse()
+
+	if *restoreFile == "" && *kbID == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	tid, err := tenant.Parse(*tenantID)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		os.Exit(2)
+	}
+	ctx := tenant.WithID(context.Background(), tid)
+
+	// 加载配置
+	var c config.Config
+	conf.MustLoad(*configFile, &c)
+
+	// 命令行工具只执行一次操作，不启动后台定时任务
+	c.Trash.EnablePurgeJob = false
+	c.Scheduler.Enabled = false
+	c.Idempotency.EnableCleanupJob = false
+	c.Prometheus.Host = ""
+
+	svcCtx := svc.NewServiceContext(c)
+	defer svcCtx.Close()
+
+	if *restoreFile != "" {
+		err = restore(ctx, svcCtx, *restoreFile)
+	} else {
+		err = export(ctx, svcCtx, *kbID, *format, *output)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		svcCtx.Close()
+		os.Exit(1)
+	}
+}
+
+// export 导出知识库到文件或标准输出
+func export(ctx context.Context, svcCtx *svc.ServiceContext, id, format, output string) error {
+	result, err := svcCtx.App.Queries.ExportKnowledgeBase.Handle(ctx, &query.ExportKnowledgeBaseQuery{
+		KnowledgeBaseID: id,
+		Format:          format,
+	})
+	if err != nil {
+		return err
+	}
+
+	if output == "-" {
+		return result.Write(ctx, os.Stdout)
+	}
+	if output == "" {
+		output = result.FileName
+	}
+
+	f, err := os.Create(output)
+	if err != nil {
+		return err
+	}
+	if err := result.Write(ctx, f); err != nil {
+		f.Close()
+		os.Remove(output)
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(os.Stderr, "✅ 已导出到 %s\n", output)
+	return nil
+}
+
+// restore 从备份包恢复知识库
+func restore(ctx context.Context, svcCtx *svc.ServiceContext, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+
+	kb, err := svcCtx.App.Commands.RestoreBackup.Handle(ctx, &command.RestoreBackupCommand{
+		Archive: io.NewSectionReader(f, 0, info.Size()),
+		Size:    info.Size(),
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(os.Stderr, "✅ 已恢复知识库 %s（%s），共 %d 篇文档\n", kb.Name, kb.ID, kb.DocumentCount)
+	return nil
+}
@@ -13,6 +13,7 @@ import (
 	"google.golang.org/grpc/reflection"
 
 	"gozero-ddd/internal/infrastructure/config"
+	"gozero-ddd/internal/interfaces/rpc/interceptor"
 	"gozero-ddd/internal/interfaces/rpc/pb"
 	"gozero-ddd/internal/interfaces/rpc/server"
 	"gozero-ddd/internal/interfaces/rpc/svc"
@@ -46,12 +47,51 @@ func main() {
 		}
 	})
 
+	// 注册拦截器（按注册顺序执行）：
+	// 1. 接受或生成请求ID（x-request-id）和 W3C traceparent，供日志、领域事件和审计日志关联同一个调用
+	// 2. 启用限流时在认证之前按客户端 IP 限流，认证失败的调用同样计数
+	// 3. 启用认证时校验 metadata 中的 Bearer 访问令牌
+	// 4. 启用限流时按调用方（API Key、用户或客户端 IP）使用令牌桶限流
+	// 5. 按调用方绑定的租户或 metadata 中的 x-tenant-id 确定调用所属的租户
+	// 6. 请求 metadata 携带 idempotency-key 时重放首次调用的结果（只用于写方法）
+	s.AddUnaryInterceptors(
+		interceptor.RequestMeta(),
+		interceptor.IPRateLimit(ctx.App.RateLimit),
+		interceptor.Auth(ctx.App.Auth),
+		interceptor.RateLimit(ctx.App.RateLimit),
+		interceptor.Tenant(),
+		interceptor.Idempotency(ctx.App.Idempotency),
+	)
+	s.AddStreamInterceptors(
+		interceptor.StreamRequestMeta(),
+		interceptor.StreamIPRateLimit(ctx.App.RateLimit),
+		interceptor.StreamAuth(ctx.App.Auth),
+		interceptor.StreamRateLimit(ctx.App.RateLimit),
+		interceptor.StreamTenant(),
+	)
+
 	// 打印启动信息
 	fmt.Printf("🚀 知识库管理系统 gRPC 服务启动成功\n")
 	fmt.Printf("📍 服务地址: %s\n", c.ListenOn)
 	fmt.Printf("📚 gRPC 接口:\n")
 	fmt.Printf("   GetKnowledgeBase    - 获取知识库详情（Query 演示）\n")
 	fmt.Printf("   CreateKnowledgeBase - 创建知识库（Command 演示）\n")
+	fmt.Printf("   （写操作可在 metadata 中携带 idempotency-key，重试时返回首次调用的结果）\n")
+	fmt.Printf("   （服务间调用可在 metadata 中携带 x-api-key 代替访问令牌）\n")
+	fmt.Printf("   （可在 metadata 中携带 x-request-id 和 traceparent 关联日志，header metadata 中返回 x-request-id 和 x-trace-id）\n")
+	fmt.Printf("   （可在 metadata 中携带 x-tenant-id 指定租户，令牌或 API Key 绑定了租户时以其为准）\n")
+	if ctx.App.Auth.Enabled() {
+		fmt.Printf("🔐 已启用 JWT 认证: metadata 中需携带 authorization: Bearer <token>\n")
+	}
+	if ctx.App.RateLimit.Enabled() {
+		fmt.Printf("🚦 已启用限流: 超出时返回 ResourceExhausted，header metadata 中的 retry-after 为需要等待的秒数\n")
+	}
+	if c.Telemetry.Endpoint != "" && !c.Telemetry.Disabled {
+		fmt.Printf("🔭 已启用链路追踪导出: Batcher=%s, Endpoint=%s\n", c.Telemetry.Batcher, c.Telemetry.Endpoint)
+	}
+	if c.Prometheus.Host != "" {
+		fmt.Printf("📈 监控指标: http://%s:%d%s\n", c.Prometheus.Host, c.Prometheus.Port, c.Prometheus.Path)
+	}
 	fmt.Printf("\n")
 	fmt.Printf("💡 测试命令:\n")
 	fmt.Printf("   # 使用 grpcurl 测试（需要先安装 grpcurl）\n")
@@ -0,0 +1,162 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"gozero-ddd/internal/domain"
+	"gozero-ddd/internal/domain/repository"
+	"gozero-ddd/internal/domain/valueobject"
+)
+
+const (
+	// APIKeyPrefix API Key 明文的固定前缀，便于识别和在日志、代码仓库中扫描泄露的密钥
+	APIKeyPrefix = "kbk_"
+
+	// APIKeySubjectPrefix 通过 API Key 认证的调用方标识前缀，完整标识为 "apikey:<ID>"
+	APIKeySubjectPrefix = "apikey:"
+
+	// APIKeyIssuer 通过 API Key 认证的调用方的签发方
+	APIKeyIssuer = "api-key"
+
+	// apiKeyDisplayLength 列表中展示的密钥明文长度（含前缀）
+	apiKeyDisplayLength = 12
+
+	// apiKeyLastUsedInterval 最近使用时间的更新间隔，避免每个请求都写数据库
+	apiKeyLastUsedInterval = time.Minute
+)
+
+// APIKeyAccess 通过 API Key 认证的调用方的访问范围
+// 权限检查器对这类调用方按权限范围和知识库限制判断，不查询知识库成员
+type APIKeyAccess struct {
+	KeyID            string
+	Scopes           []valueobject.APIKeyScope
+	KnowledgeBaseIDs []valueobject.KnowledgeBaseID // 为空表示不限制
+}
+
+// Restricted 是否限制了可访问的知识库
+func (a *APIKeyAccess) Restricted() bool {
+	return len(a.KnowledgeBaseIDs) > 0
+}
+
+// AllowsKnowledgeBase 判断是否可以访问指定知识库
+func (a *APIKeyAccess) AllowsKnowledgeBase(kbID valueobject.KnowledgeBaseID) bool {
+	if !a.Restricted() {
+		return true
+	}
+	for _, id := range a.KnowledgeBaseIDs {
+		if id == kbID {
+			return true
+		}
+	}
+	return false
+}
+
+// Allows 判断是否可以在知识库中执行需要 required 角色的操作
+func (a *APIKeyAccess) Allows(kbID valueobject.KnowledgeBaseID, required valueobject.MemberRole) bool {
+	return a.AllowsKnowledgeBase(kbID) && a.HasRole(required)
+}
+
+// HasRole 判断权限范围是否包含 required 角色的权限
+func (a *APIKeyAccess) HasRole(required valueobject.MemberRole) bool {
+	for _, scope := range a.Scopes {
+		if scope.Role().Includes(required) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsAdmin 是否拥有管理员权限：admin 权限范围且不限制知识库
+func (a *APIKeyAccess) IsAdmin() bool {
+	return !a.Restricted() && a.HasRole(valueobject.MemberRoleOwner)
+}
+
+// GenerateAPIKey 生成新的 API Key 明文
+// 由固定前缀和 32 字节随机数组成，熵足够高，哈希时不需要加盐
+func GenerateAPIKey() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("generate api key: %w", err)
+	}
+	return APIKeyPrefix + base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+// HashAPIKey 计算 API Key 明文的 SHA-256 哈希（十六进制）
+func HashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// APIKeyDisplayPrefix 返回用于展示的密钥前缀
+func APIKeyDisplayPrefix(key string) string {
+	if len(key) <= apiKeyDisplayLength {
+		return key
+	}
+	return key[:apiKeyDisplayLength]
+}
+
+// APIKeyService API Key 校验服务
+// REST 中间件和 gRPC 拦截器共用，校验密钥哈希、吊销状态和有效期，并记录最近使用时间
+type APIKeyService struct {
+	repo repository.APIKeyRepository
+}
+
+// NewAPIKeyService 创建 API Key 校验服务
+func NewAPIKeyService(repo repository.APIKeyRepository) *APIKeyService {
+	return &APIKeyService{repo: repo}
+}
+
+// Authenticate 校验 API Key 并返回其代表的调用方
+// 密钥为空时返回 ErrUnauthenticated；密钥不存在、已吊销或已过期时返回包装了 ErrInvalidAPIKey 的错误
+func (s *APIKeyService) Authenticate(ctx context.Context, key string) (*Principal, error) {
+	key = strings.TrimSpace(key)
+	if key == "" {
+		return nil, domain.ErrUnauthenticated
+	}
+	if !strings.HasPrefix(key, APIKeyPrefix) {
+		return nil, domain.ErrInvalidAPIKey
+	}
+
+	record, err := s.repo.FindByHash(ctx, HashAPIKey(key))
+	if err != nil {
+		return nil, err
+	}
+	if record == nil {
+		return nil, domain.ErrInvalidAPIKey
+	}
+
+	now := time.Now()
+	if record.RevokedAt != nil {
+		return nil, fmt.Errorf("%w: key has been revoked", domain.ErrInvalidAPIKey)
+	}
+	if record.ExpiresAt != nil && !now.Before(*record.ExpiresAt) {
+		return nil, fmt.Errorf("%w: key has expired", domain.ErrInvalidAPIKey)
+	}
+
+	// 记录最近使用时间，失败不影响本次请求
+	if record.LastUsedAt == nil || now.Sub(*record.LastUsedAt) >= apiKeyLastUsedInterval {
+		if err := s.repo.UpdateLastUsed(ctx, record.ID, now); err != nil {
+			log.Printf("⚠️ [APIKey] 更新最近使用时间失败: ID=%s, Error=%v", record.ID, err)
+		}
+	}
+
+	return &Principal{
+		Subject: APIKeySubjectPrefix + record.ID,
+		Name:    record.Name,
+		Issuer:  APIKeyIssuer,
+		Tenant:  record.TenantID,
+		APIKey: &APIKeyAccess{
+			KeyID:            record.ID,
+			Scopes:           record.Scopes,
+			KnowledgeBaseIDs: record.KnowledgeBaseIDs,
+		},
+	}, nil
+}
@@ -0,0 +1,134 @@
+package auth
+
+import (
+	"context"
+	"time"
+
+	"gozero-ddd/internal/domain"
+	"gozero-ddd/internal/domain/repository"
+	"gozero-ddd/internal/domain/valueobject"
+)
+
+// AdminRole 令牌 roles 声明中的管理员角色
+// 管理员可以访问和管理所有知识库，包括尚未设置成员的知识库
+const AdminRole = "admin"
+
+// PermissionChecker 知识库权限检查器
+// 命令和查询处理器在读取知识库聚合之前调用，按调用方在知识库中的角色判断是否允许操作。
+// 上下文中没有调用方（未启用认证，或由后台任务发起）时视为系统调用，不做限制；
+// 通过 API Key 认证的调用方按密钥的权限范围和知识库限制判断
+type PermissionChecker struct {
+	memberRepo repository.KnowledgeBaseMemberRepository
+}
+
+// NewPermissionChecker 创建知识库权限检查器
+func NewPermissionChecker(memberRepo repository.KnowledgeBaseMemberRepository) *PermissionChecker {
+	return &PermissionChecker{memberRepo: memberRepo}
+}
+
+// Check 检查调用方在知识库中是否拥有 required 角色的权限
+// 不是成员或角色不足时返回 ErrPermissionDenied；不是成员时同样返回该错误，不暴露知识库是否存在
+func (c *PermissionChecker) Check(ctx context.Context, kbID valueobject.KnowledgeBaseID, required valueobject.MemberRole) error {
+	principal, ok := PrincipalFromContext(ctx)
+	if !ok || principal.HasRole(AdminRole) {
+		return nil
+	}
+	if principal.APIKey != nil {
+		if principal.APIKey.Allows(kbID, required) {
+			return nil
+		}
+		return domain.ErrPermissionDenied
+	}
+
+	member, err := c.memberRepo.Find(ctx, kbID, principal.Subject)
+	if err != nil {
+		return err
+	}
+	if member == nil || !member.Role.Includes(required) {
+		return domain.ErrPermissionDenied
+	}
+	return nil
+}
+
+// CheckAll 检查调用方在多个知识库中是否都拥有 required 角色的权限
+func (c *PermissionChecker) CheckAll(ctx context.Context, kbIDs []valueobject.KnowledgeBaseID, required valueobject.MemberRole) error {
+	for _, kbID := range kbIDs {
+		if err := c.Check(ctx, kbID, required); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RequireAdmin 检查调用方是否为管理员
+// 用于不属于单个知识库的维护操作（如清理回收站、处理到期的定时文档）
+func (c *PermissionChecker) RequireAdmin(ctx context.Context) error {
+	principal, ok := PrincipalFromContext(ctx)
+	if !ok || principal.HasRole(AdminRole) {
+		return nil
+	}
+	if principal.APIKey != nil && principal.APIKey.IsAdmin() {
+		return nil
+	}
+	return domain.ErrPermissionDenied
+}
+
+// CheckCreate 检查调用方是否可以创建知识库
+// 已认证的用户都可以创建；API Key 需要 write 权限范围且不限制知识库
+func (c *PermissionChecker) CheckCreate(ctx context.Context) error {
+	principal, ok := PrincipalFromContext(ctx)
+	if !ok || principal.APIKey == nil {
+		return nil
+	}
+	if principal.APIKey.Restricted() || !principal.APIKey.HasRole(valueobject.MemberRoleEditor) {
+		return domain.ErrPermissionDenied
+	}
+	return nil
+}
+
+// VisibleKnowledgeBases 返回调用方可以查看的知识库
+// all 为 true 表示可以查看所有知识库（系统调用或管理员），此时 ids 为 nil
+func (c *PermissionChecker) VisibleKnowledgeBases(ctx context.Context) (ids map[valueobject.KnowledgeBaseID]bool, all bool, err error) {
+	principal, ok := PrincipalFromContext(ctx)
+	if !ok || principal.HasRole(AdminRole) {
+		return nil, true, nil
+	}
+	if principal.APIKey != nil {
+		if !principal.APIKey.Restricted() {
+			return nil, true, nil
+		}
+		ids = make(map[valueobject.KnowledgeBaseID]bool, len(principal.APIKey.KnowledgeBaseIDs))
+		for _, id := range principal.APIKey.KnowledgeBaseIDs {
+			ids[id] = true
+		}
+		return ids, false, nil
+	}
+
+	members, err := c.memberRepo.FindBySubject(ctx, principal.Subject)
+	if err != nil {
+		return nil, false, err
+	}
+	ids = make(map[valueobject.KnowledgeBaseID]bool, len(members))
+	for _, m := range members {
+		if m.Role.Includes(valueobject.MemberRoleViewer) {
+			ids[m.KnowledgeBaseID] = true
+		}
+	}
+	return ids, false, nil
+}
+
+// GrantCreator 将创建知识库的调用方设为所有者
+// 在创建或恢复知识库后调用；系统调用没有调用方，API Key 的权限不依赖成员记录，均不做处理
+func (c *PermissionChecker) GrantCreator(ctx context.Context, kbID valueobject.KnowledgeBaseID) error {
+	principal, ok := PrincipalFromContext(ctx)
+	if !ok || principal.APIKey != nil {
+		return nil
+	}
+	return c.memberRepo.Save(ctx, &repository.KnowledgeBaseMember{
+		KnowledgeBaseID: kbID,
+		Subject:         principal.Subject,
+		Role:            valueobject.MemberRoleOwner,
+		GrantedBy:       principal.Subject,
+		GrantedAt:       time.Now(),
+	})
+}
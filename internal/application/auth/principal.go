@@ -0,0 +1,50 @@
+package auth
+
+import "context"
+
+// Principal 已认证的调用方
+// 由接口层的认证中间件和拦截器放入请求上下文，命令处理器通过 PrincipalFromContext 读取
+type Principal struct {
+	Subject string   // 调用方标识（JWT 的 sub）
+	Name    string   // 显示名称（可选）
+	Roles   []string // 角色（可选）
+	Issuer  string   // 令牌签发方
+	Tenant  string   // 调用方所属租户，为空时属于默认租户（可选）
+
+	// APIKey 通过 API Key 认证时的访问范围，通过访问令牌认证时为 nil
+	APIKey *APIKeyAccess
+}
+
+// HasRole 判断调用方是否拥有指定角色
+func (p *Principal) HasRole(role string) bool {
+	for _, r := range p.Roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+// principalKey 上下文键，使用私有类型避免与其他包冲突
+type principalKey struct{}
+
+// WithPrincipal 将已认证的调用方放入上下文
+func WithPrincipal(ctx context.Context, principal *Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, principal)
+}
+
+// PrincipalFromContext 从上下文中取出已认证的调用方
+// 未启用认证或由后台任务发起的调用没有调用方，返回 false
+func PrincipalFromContext(ctx context.Context) (*Principal, bool) {
+	principal, ok := ctx.Value(principalKey{}).(*Principal)
+	return principal, ok && principal != nil
+}
+
+// Actor 返回上下文中调用方的标识，用于在事件和记录中标注操作者
+// 没有调用方时返回空字符串
+func Actor(ctx context.Context) string {
+	if principal, ok := PrincipalFromContext(ctx); ok {
+		return principal.Subject
+	}
+	return ""
+}
@@ -0,0 +1,37 @@
+package auth
+
+import (
+	"context"
+
+	"gozero-ddd/internal/domain/event"
+)
+
+// ActorPublisher 记录事件操作者的事件发布器
+// 包装事件总线，发布前把上下文中调用方的标识写入事件，
+// 命令处理器无需逐个传递操作者
+type ActorPublisher struct {
+	next event.EventPublisher
+}
+
+// NewActorPublisher 创建记录事件操作者的事件发布器
+func NewActorPublisher(next event.EventPublisher) *ActorPublisher {
+	return &ActorPublisher{next: next}
+}
+
+// 确保实现了接口
+var _ event.EventPublisher = (*ActorPublisher)(nil)
+
+// Publish 发布单个事件
+func (p *ActorPublisher) Publish(ctx context.Context, evt event.DomainEvent) error {
+	event.SetActor(evt, Actor(ctx))
+	return p.next.Publish(ctx, evt)
+}
+
+// PublishAll 发布多个事件
+func (p *ActorPublisher) PublishAll(ctx context.Context, events []event.DomainEvent) error {
+	actor := Actor(ctx)
+	for _, evt := range events {
+		event.SetActor(evt, actor)
+	}
+	return p.next.PublishAll(ctx, events)
+}
@@ -0,0 +1,67 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"gozero-ddd/internal/domain"
+)
+
+// TokenVerifier 访问令牌校验器
+// 定义在应用层，由基础设施层实现（如 JWT 校验）
+type TokenVerifier interface {
+	// Verify 校验令牌并返回令牌代表的调用方
+	Verify(ctx context.Context, token string) (*Principal, error)
+}
+
+// Service 认证服务
+// 供接口层的认证中间件和拦截器使用；未配置令牌校验器时认证关闭，
+// 未携带 API Key 的请求都被放行
+type Service struct {
+	verifier TokenVerifier
+	apiKeys  *APIKeyService
+}
+
+// NewService 创建认证服务，verifier 为 nil 表示不启用访问令牌认证
+func NewService(verifier TokenVerifier, apiKeys *APIKeyService) *Service {
+	return &Service{verifier: verifier, apiKeys: apiKeys}
+}
+
+// Enabled 是否启用认证
+func (s *Service) Enabled() bool {
+	return s.verifier != nil
+}
+
+// Authenticate 校验访问令牌
+// 令牌为空时返回 ErrUnauthenticated，校验失败时返回包装了 ErrInvalidAccessToken 的错误
+func (s *Service) Authenticate(ctx context.Context, token string) (*Principal, error) {
+	if token == "" {
+		return nil, domain.ErrUnauthenticated
+	}
+
+	principal, err := s.verifier.Verify(ctx, token)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalidAccessToken, err)
+	}
+	return principal, nil
+}
+
+// AuthenticateAPIKey 校验 API Key
+// 无论是否启用访问令牌认证，携带了 API Key 的请求都需要校验通过
+func (s *Service) AuthenticateAPIKey(ctx context.Context, key string) (*Principal, error) {
+	if s.apiKeys == nil {
+		return nil, domain.ErrInvalidAPIKey
+	}
+	return s.apiKeys.Authenticate(ctx, key)
+}
+
+// BearerToken 从 Authorization 头的值中取出 Bearer 令牌
+// 不是 Bearer 方案时返回空字符串
+func BearerToken(authorization string) string {
+	scheme, token, ok := strings.Cut(strings.TrimSpace(authorization), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return ""
+	}
+	return strings.TrimSpace(token)
+}
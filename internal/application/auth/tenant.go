@@ -0,0 +1,54 @@
+package auth
+
+import (
+	"context"
+	"strings"
+
+	"gozero-ddd/internal/domain"
+	"gozero-ddd/internal/domain/tenant"
+)
+
+// CrossTenantRole 令牌 roles 声明中的跨租户角色
+// 拥有该角色且未绑定租户的调用方可以通过请求指定要访问的租户；
+// 只能由访问令牌授予，API Key 总是限定在所属租户内
+const CrossTenantRole = "cross-tenant-admin"
+
+// ResolveTenant 确定请求所属的租户
+// requested 为请求头或元数据中指定的租户（可选）：
+//   - 调用方的令牌或 API Key 绑定了租户时以其为准
+//   - 调用方未绑定租户时固定使用默认租户，只有拥有 CrossTenantRole 的令牌可以指定其他租户
+//   - 上下文中没有调用方（未启用认证，或由后台任务发起）时使用请求指定的租户
+//
+// 请求指定了不允许访问的租户时返回 ErrTenantMismatch；没有指定时使用默认租户
+func ResolveTenant(ctx context.Context, requested string) (tenant.ID, error) {
+	var id tenant.ID
+	if requested = strings.TrimSpace(requested); requested != "" {
+		parsed, err := tenant.Parse(requested)
+		if err != nil {
+			return "", err
+		}
+		id = parsed
+	}
+
+	if principal, ok := PrincipalFromContext(ctx); ok {
+		bound := tenant.Default
+		if principal.Tenant != "" {
+			parsed, err := tenant.Parse(principal.Tenant)
+			if err != nil {
+				return "", err
+			}
+			bound = parsed
+		} else if principal.APIKey == nil && principal.HasRole(CrossTenantRole) && id != "" {
+			return id, nil
+		}
+		if id != "" && id != bound {
+			return "", domain.ErrTenantMismatch
+		}
+		return bound, nil
+	}
+
+	if id == "" {
+		return tenant.Default, nil
+	}
+	return id, nil
+}
@@ -0,0 +1,60 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"gozero-ddd/internal/domain"
+	"gozero-ddd/internal/domain/tenant"
+)
+
+func TestResolveTenant(t *testing.T) {
+	user := &Principal{Subject: "alice"}
+	admin := &Principal{Subject: "root", Roles: []string{AdminRole}}
+	crossTenant := &Principal{Subject: "ops", Roles: []string{CrossTenantRole}}
+	acmeUser := &Principal{Subject: "bob", Tenant: "acme"}
+	apiKey := &Principal{Subject: APIKeySubjectPrefix + "k1", APIKey: &APIKeyAccess{KeyID: "k1"}}
+	apiKeyWithRole := &Principal{Subject: APIKeySubjectPrefix + "k2", Roles: []string{CrossTenantRole}, APIKey: &APIKeyAccess{KeyID: "k2"}}
+
+	tests := []struct {
+		name      string
+		principal *Principal
+		requested string
+		want      tenant.ID
+		wantErr   error
+	}{
+		{"system call without header", nil, "", tenant.Default, nil},
+		{"system call with header", nil, "acme", "acme", nil},
+		{"untenanted user without header", user, "", tenant.Default, nil},
+		{"untenanted user naming default tenant", user, "default", tenant.Default, nil},
+		{"untenanted user naming other tenant", user, "acme", "", domain.ErrTenantMismatch},
+		{"untenanted admin naming other tenant", admin, "acme", "", domain.ErrTenantMismatch},
+		{"untenanted API key naming other tenant", apiKey, "acme", "", domain.ErrTenantMismatch},
+		{"API key cannot use cross-tenant role", apiKeyWithRole, "acme", "", domain.ErrTenantMismatch},
+		{"cross-tenant role naming other tenant", crossTenant, "acme", "acme", nil},
+		{"cross-tenant role without header", crossTenant, "", tenant.Default, nil},
+		{"bound user without header", acmeUser, "", "acme", nil},
+		{"bound user naming own tenant", acmeUser, "acme", "acme", nil},
+		{"bound user naming other tenant", acmeUser, "globex", "", domain.ErrTenantMismatch},
+		{"bound user naming default tenant", acmeUser, "default", "", domain.ErrTenantMismatch},
+		{"invalid header", user, "Not Valid", "", domain.ErrInvalidTenantID},
+		{"header is trimmed", nil, " acme ", "acme", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.principal != nil {
+				ctx = WithPrincipal(ctx, tt.principal)
+			}
+			got, err := ResolveTenant(ctx, tt.requested)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ResolveTenant(%q) error = %v, want %v", tt.requested, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ResolveTenant(%q) = %q, want %q", tt.requested, got, tt.want)
+			}
+		})
+	}
+}
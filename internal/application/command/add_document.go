@@ -3,11 +3,13 @@ package command
 import (
 	"context"
 
+	"gozero-ddd/internal/application/auth"
 	"gozero-ddd/internal/application/dto"
 	"gozero-ddd/internal/domain"
 	"gozero-ddd/internal/domain/entity"
 	"gozero-ddd/internal/domain/event"
 	"gozero-ddd/internal/domain/repository"
+	"gozero-ddd/internal/domain/service"
 	"gozero-ddd/internal/domain/valueobject"
 )
 
@@ -16,6 +18,7 @@ type AddDocumentCommand struct {
 	KnowledgeBaseID string   `json:"knowledge_base_id"`
 	Title           string   `json:"title"`
 	Content         string   `json:"content"`
+	ContentType     string   `json:"content_type"` // 为空时使用 markdown
 	Tags            []string `json:"tags"`
 }
 
@@ -25,7 +28,10 @@ type AddDocumentHandler struct {
 	unitOfWork     repository.UnitOfWork
 	kbRepo         repository.KnowledgeBaseRepository
 	docRepo        repository.DocumentRepository
-	eventPublisher event.EventPublisher // 事件发布器
+	linkService    *service.LinkService
+	quotaService   *service.QuotaService
+	eventPublisher event.EventPublisher    // 事件发布器
+	permissions    *auth.PermissionChecker // 权限检查器
 }
 
 // NewAddDocumentHandler 创建处理器
@@ -33,13 +39,19 @@ func NewAddDocumentHandler(
 	uow repository.UnitOfWork,
 	kbRepo repository.KnowledgeBaseRepository,
 	docRepo repository.DocumentRepository,
+	linkService *service.LinkService,
+	quotaService *service.QuotaService,
 	ep event.EventPublisher,
+	permissions *auth.PermissionChecker,
 ) *AddDocumentHandler {
 	return &AddDocumentHandler{
 		unitOfWork:     uow,
 		kbRepo:         kbRepo,
 		docRepo:        docRepo,
+		linkService:    linkService,
+		quotaService:   quotaService,
 		eventPublisher: ep,
+		permissions:    permissions,
 	}
 }
 
@@ -53,6 +65,11 @@ func (h *AddDocumentHandler) Handle(ctx context.Context, cmd *AddDocumentCommand
 		return nil, err
 	}
 
+	// 检查调用方权限
+	if err := h.permissions.Check(ctx, kbID, valueobject.MemberRoleEditor); err != nil {
+		return nil, err
+	}
+
 	var result *dto.DocumentDTO
 	var kb *entity.KnowledgeBase // 保存聚合根引用，用于事务后获取事件
 
@@ -68,8 +85,13 @@ func (h *AddDocumentHandler) Handle(ctx context.Context, cmd *AddDocumentCommand
 			return domain.ErrKnowledgeBaseNotFound
 		}
 
+		// 设置租户配额，由聚合根在添加文档时校验
+		if err := h.quotaService.ApplyTo(txCtx, kb); err != nil {
+			return err
+		}
+
 		// 通过聚合根添加文档（此时会收集 DocumentAddedEvent）
-		doc, err := kb.AddDocument(cmd.Title, cmd.Content, cmd.Tags)
+		doc, err := kb.AddDocument(cmd.Title, cmd.Content, valueobject.ContentType(cmd.ContentType), cmd.Tags)
 		if err != nil {
 			return err
 		}
@@ -79,6 +101,16 @@ func (h *AddDocumentHandler) Handle(ctx context.Context, cmd *AddDocumentCommand
 			return err
 		}
 
+		// 记录文档内容中的链接
+		if err := h.linkService.RefreshLinks(txCtx, doc); err != nil {
+			return err
+		}
+
+		// 在同一事务中计入新占用的租户用量
+		if err := h.quotaService.RecordUsed(txCtx, kb); err != nil {
+			return err
+		}
+
 		// 更新知识库
 		if err := h.kbRepo.Save(txCtx, kb); err != nil {
 			return err
@@ -0,0 +1,300 @@
+package command
+
+import (
+	"context"
+	"errors"
+
+	"gozero-ddd/internal/application/auth"
+	"gozero-ddd/internal/application/dto"
+	"gozero-ddd/internal/domain"
+	"gozero-ddd/internal/domain/entity"
+	"gozero-ddd/internal/domain/event"
+	"gozero-ddd/internal/domain/repository"
+	"gozero-ddd/internal/domain/service"
+	"gozero-ddd/internal/domain/valueobject"
+)
+
+// 批量操作类型
+const (
+	BatchOpAdd    = "add"
+	BatchOpUpdate = "update"
+	BatchOpRemove = "remove"
+)
+
+// 批量执行模式
+const (
+	BatchModeAtomic     = "atomic"      // 全部成功才提交，任一操作失败则整体回滚（默认）
+	BatchModeBestEffort = "best_effort" // 失败的操作被跳过，其余操作照常提交
+)
+
+// MaxBatchOperations 单次批量请求允许的最大操作数
+const MaxBatchOperations = 500
+
+// errBatchItemFailed atomic 模式下有操作失败，用于回滚事务
+var errBatchItemFailed = errors.New("batch item failed")
+
+// BatchOperation 批量操作中的单个操作
+// 字段含义与 AddDocumentCommand / UpdateDocumentCommand / RemoveDocumentCommand 一致
+type BatchOperation struct {
+	Op          string   `json:"op"`          // add / update / remove
+	DocumentID  string   `json:"document_id"` // update / remove 时必填
+	Title       string   `json:"title"`
+	Content     string   `json:"content"`
+	ContentType string   `json:"content_type"`
+	Tags        []string `json:"tags"`
+}
+
+// BatchDocumentsCommand 批量文档操作命令
+type BatchDocumentsCommand struct {
+	KnowledgeBaseID string            `json:"knowledge_base_id"`
+	Mode            string            `json:"mode"` // atomic / best_effort，为空时使用 atomic
+	Operations      []*BatchOperation `json:"operations"`
+}
+
+// BatchDocumentsHandler 批量文档操作命令处理器
+// 所有操作在同一个事务中按顺序执行，事务提交后一次性发布全部事件
+//
+// best_effort 模式下，参数校验和聚合根拒绝的操作记为失败并跳过；
+// 持久化错误无法只撤销单个操作，仍会导致整个事务回滚
+type BatchDocumentsHandler struct {
+	unitOfWork     repository.UnitOfWork
+	kbRepo         repository.KnowledgeBaseRepository
+	docRepo        repository.DocumentRepository
+	linkService    *service.LinkService
+	quotaService   *service.QuotaService
+	eventPublisher event.EventPublisher
+	permissions    *auth.PermissionChecker // 权限检查器
+}
+
+// NewBatchDocumentsHandler 创建处理器
+func NewBatchDocumentsHandler(
+	uow repository.UnitOfWork,
+	kbRepo repository.KnowledgeBaseRepository,
+	docRepo repository.DocumentRepository,
+	linkService *service.LinkService,
+	quotaService *service.QuotaService,
+	ep event.EventPublisher,
+	permissions *auth.PermissionChecker,
+) *BatchDocumentsHandler {
+	return &BatchDocumentsHandler{
+		unitOfWork:     uow,
+		kbRepo:         kbRepo,
+		docRepo:        docRepo,
+		linkService:    linkService,
+		quotaService:   quotaService,
+		eventPublisher: ep,
+		permissions:    permissions,
+	}
+}
+
+// Handle 处理批量文档操作命令
+// atomic 模式下有操作失败时返回未提交的结果（Committed 为 false），而不是错误
+func (h *BatchDocumentsHandler) Handle(ctx context.Context, cmd *BatchDocumentsCommand) (*dto.BatchResultDTO, error) {
+	// 验证 ID 格式
+	kbID, err := valueobject.KnowledgeBaseIDFromString(cmd.KnowledgeBaseID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 检查调用方权限
+	if err := h.permissions.Check(ctx, kbID, valueobject.MemberRoleEditor); err != nil {
+		return nil, err
+	}
+
+	mode := cmd.Mode
+	switch mode {
+	case "":
+		mode = BatchModeAtomic
+	case BatchModeAtomic, BatchModeBestEffort:
+	default:
+		return nil, domain.ErrInvalidBatchMode
+	}
+
+	if len(cmd.Operations) == 0 {
+		return nil, domain.ErrBatchEmpty
+	}
+	if len(cmd.Operations) > MaxBatchOperations {
+		return nil, domain.ErrBatchTooLarge
+	}
+
+	result := &dto.BatchResultDTO{
+		KnowledgeBaseID: cmd.KnowledgeBaseID,
+		Mode:            mode,
+		Total:           len(cmd.Operations),
+		Items:           make([]*dto.BatchItemResultDTO, len(cmd.Operations)),
+	}
+	for i, op := range cmd.Operations {
+		item := &dto.BatchItemResultDTO{Index: i, Status: dto.BatchStatusSkipped}
+		if op != nil {
+			item.Op = op.Op
+			item.DocumentID = op.DocumentID
+		}
+		result.Items[i] = item
+	}
+
+	var kb *entity.KnowledgeBase
+	var brokenLinkEvents []event.DomainEvent
+
+	err = h.unitOfWork.Transaction(ctx, func(txCtx context.Context) error {
+		// 查找知识库
+		var err error
+		kb, err = h.kbRepo.FindByID(txCtx, kbID)
+		if err != nil {
+			return err
+		}
+		if kb == nil {
+			return domain.ErrKnowledgeBaseNotFound
+		}
+		if !kb.IsActive() {
+			return domain.ErrKnowledgeBaseNotActive
+		}
+
+		// 设置租户配额，由聚合根在添加、更新文档时校验
+		if err := h.quotaService.ApplyTo(txCtx, kb); err != nil {
+			return err
+		}
+
+		for i, op := range cmd.Operations {
+			item := result.Items[i]
+			events, err := h.apply(txCtx, kb, op, item)
+			if err != nil {
+				// 持久化错误：回滚整个事务
+				return err
+			}
+			if item.Status == dto.BatchStatusFailed {
+				if mode == BatchModeAtomic {
+					return errBatchItemFailed
+				}
+				continue
+			}
+			brokenLinkEvents = append(brokenLinkEvents, events...)
+		}
+
+		// 在同一事务中计入新占用的租户用量
+		if err := h.quotaService.RecordUsed(txCtx, kb); err != nil {
+			return err
+		}
+
+		// 更新知识库
+		return h.kbRepo.Save(txCtx, kb)
+	})
+
+	switch {
+	case errors.Is(err, errBatchItemFailed):
+		// atomic 模式回滚：已执行成功的操作标记为已回滚
+		for _, item := range result.Items {
+			if item.Status == dto.BatchStatusSucceeded {
+				item.Status = dto.BatchStatusRolledBack
+				// 回滚后新文档并不存在
+				if item.Op == BatchOpAdd {
+					item.DocumentID = ""
+				}
+			}
+		}
+	case err != nil:
+		return nil, err
+	default:
+		result.Committed = true
+	}
+
+	for _, item := range result.Items {
+		switch item.Status {
+		case dto.BatchStatusSucceeded:
+			result.Succeeded++
+		case dto.BatchStatusFailed:
+			result.Failed++
+		}
+	}
+
+	// 事务成功后一次性发布所有事件
+	if result.Committed && h.eventPublisher != nil {
+		events := append(kb.PullEvents(), brokenLinkEvents...)
+		if len(events) > 0 {
+			_ = h.eventPublisher.PublishAll(ctx, events)
+		}
+	}
+
+	return result, nil
+}
+
+// apply 在事务中执行单个操作，结果写入 item
+// 参数或聚合根校验失败时 item 记为失败并返回 nil；返回的错误只表示持久化失败
+// 返回值中的事件为聚合根之外收集的事件（删除文档时的失效链接事件）
+func (h *BatchDocumentsHandler) apply(
+	ctx context.Context,
+	kb *entity.KnowledgeBase,
+	op *BatchOperation,
+	item *dto.BatchItemResultDTO,
+) ([]event.DomainEvent, error) {
+	fail := func(err error) ([]event.DomainEvent, error) {
+		item.Status = dto.BatchStatusFailed
+		item.Error = err.Error()
+		return nil, nil
+	}
+
+	if op == nil {
+		return fail(domain.ErrInvalidBatchOperation)
+	}
+
+	switch op.Op {
+	case BatchOpAdd:
+		// 通过聚合根添加文档（此时会收集 DocumentAddedEvent）
+		doc, err := kb.AddDocument(op.Title, op.Content, valueobject.ContentType(op.ContentType), op.Tags)
+		if err != nil {
+			return fail(err)
+		}
+		if err := h.docRepo.Save(ctx, doc); err != nil {
+			return nil, err
+		}
+		if err := h.linkService.RefreshLinks(ctx, doc); err != nil {
+			return nil, err
+		}
+		item.DocumentID = doc.ID().String()
+
+	case BatchOpUpdate:
+		docID, err := valueobject.DocumentIDFromString(op.DocumentID)
+		if err != nil {
+			return fail(err)
+		}
+		// 通过聚合根更新文档（会收集 DocumentUpdatedEvent）
+		doc, err := kb.UpdateDocument(docID, op.Title, op.Content, valueobject.ContentType(op.ContentType), op.Tags)
+		if err != nil {
+			return fail(err)
+		}
+		if err := h.docRepo.Save(ctx, doc); err != nil {
+			return nil, err
+		}
+		if err := h.linkService.RefreshLinks(ctx, doc); err != nil {
+			return nil, err
+		}
+
+	case BatchOpRemove:
+		docID, err := valueobject.DocumentIDFromString(op.DocumentID)
+		if err != nil {
+			return fail(err)
+		}
+		doc, err := kb.GetDocument(docID)
+		if err != nil {
+			return fail(err)
+		}
+		// 通过聚合根删除文档（移入回收站）
+		if err := kb.RemoveDocument(docID); err != nil {
+			return fail(err)
+		}
+		events, err := h.linkService.BrokenLinkEvents(ctx, kb, doc)
+		if err != nil {
+			return nil, err
+		}
+		if err := h.docRepo.Delete(ctx, docID); err != nil {
+			return nil, err
+		}
+		item.Status = dto.BatchStatusSucceeded
+		return events, nil
+
+	default:
+		return fail(domain.ErrInvalidBatchOperation)
+	}
+
+	item.Status = dto.BatchStatusSucceeded
+	return nil, nil
+}
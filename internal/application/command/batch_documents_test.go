@@ -0,0 +1,269 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"gozero-ddd/internal/application/auth"
+	"gozero-ddd/internal/application/dto"
+	"gozero-ddd/internal/domain/entity"
+	"gozero-ddd/internal/domain/event"
+	"gozero-ddd/internal/domain/repository"
+	"gozero-ddd/internal/domain/service"
+	"gozero-ddd/internal/domain/valueobject"
+)
+
+// batchTxKey 上下文中未提交写入的键
+type batchTxKey struct{}
+
+// batchWrites 一个事务中的写入
+type batchWrites struct {
+	savedDocs   []string
+	deletedDocs []string
+	kbSaves     int
+	usage       valueobject.QuotaUsage
+}
+
+// batchStore 批量操作测试使用的存储：写入先记在事务中，提交后才生效
+type batchStore struct {
+	kb        *entity.KnowledgeBase
+	committed batchWrites
+	failSave  int // 第几次保存文档时返回持久化错误，0 表示不出错
+	saves     int
+	published []event.DomainEvent
+}
+
+func (s *batchStore) writes(ctx context.Context) *batchWrites {
+	return ctx.Value(batchTxKey{}).(*batchWrites)
+}
+
+// batchUnitOfWork 事务函数返回错误时丢弃其中的写入
+type batchUnitOfWork struct {
+	repository.UnitOfWork
+	store *batchStore
+}
+
+func (u *batchUnitOfWork) Transaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	w := &batchWrites{}
+	if err := fn(context.WithValue(ctx, batchTxKey{}, w)); err != nil {
+		return err
+	}
+	c := &u.store.committed
+	c.savedDocs = append(c.savedDocs, w.savedDocs...)
+	c.deletedDocs = append(c.deletedDocs, w.deletedDocs...)
+	c.kbSaves += w.kbSaves
+	c.usage = c.usage.Add(w.usage)
+	return nil
+}
+
+type batchKnowledgeBaseRepository struct {
+	repository.KnowledgeBaseRepository
+	store *batchStore
+}
+
+func (r *batchKnowledgeBaseRepository) FindByID(context.Context, valueobject.KnowledgeBaseID) (*entity.KnowledgeBase, error) {
+	return r.store.kb, nil
+}
+
+func (r *batchKnowledgeBaseRepository) Save(ctx context.Context, _ *entity.KnowledgeBase) error {
+	r.store.writes(ctx).kbSaves++
+	return nil
+}
+
+type batchDocumentRepository struct {
+	repository.DocumentRepository
+	store *batchStore
+}
+
+func (r *batchDocumentRepository) Save(ctx context.Context, doc *entity.Document) error {
+	r.store.saves++
+	if r.store.saves == r.store.failSave {
+		return errors.New("connection reset")
+	}
+	w := r.store.writes(ctx)
+	w.savedDocs = append(w.savedDocs, doc.ID().String())
+	return nil
+}
+
+func (r *batchDocumentRepository) Delete(ctx context.Context, id valueobject.DocumentID) error {
+	w := r.store.writes(ctx)
+	w.deletedDocs = append(w.deletedDocs, id.String())
+	return nil
+}
+
+func (r *batchDocumentRepository) CountDeleted(context.Context, valueobject.KnowledgeBaseID) (int, error) {
+	return 0, nil
+}
+
+type batchLinkRepository struct {
+	repository.DocumentLinkRepository
+}
+
+func (batchLinkRepository) ReplaceLinks(context.Context, valueobject.DocumentID, valueobject.KnowledgeBaseID, []valueobject.DocumentLink) error {
+	return nil
+}
+
+func (batchLinkRepository) FindBacklinks(context.Context, valueobject.KnowledgeBaseID, valueobject.DocumentID, string) ([]valueobject.DocumentLinkEdge, error) {
+	return nil, nil
+}
+
+type batchUsageRepository struct {
+	repository.TenantUsageRepository
+	store *batchStore
+}
+
+func (r *batchUsageRepository) FindForUpdate(context.Context) (valueobject.QuotaUsage, error) {
+	return r.store.committed.usage, nil
+}
+
+func (r *batchUsageRepository) Add(ctx context.Context, delta valueobject.QuotaUsage) error {
+	w := r.store.writes(ctx)
+	w.usage = w.usage.Add(delta)
+	return nil
+}
+
+type batchPublisher struct {
+	store *batchStore
+}
+
+func (p *batchPublisher) Publish(ctx context.Context, e event.DomainEvent) error {
+	return p.PublishAll(ctx, []event.DomainEvent{e})
+}
+
+func (p *batchPublisher) PublishAll(_ context.Context, events []event.DomainEvent) error {
+	p.store.published = append(p.store.published, events...)
+	return nil
+}
+
+// newBatchFixture 创建包含一篇文档的知识库和批量操作处理器
+func newBatchFixture(t *testing.T) (*BatchDocumentsHandler, *batchStore, *entity.Document) {
+	t.Helper()
+	kb, err := entity.NewKnowledgeBase("kb", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	existing, err := kb.AddDocument("existing", "content", valueobject.ContentType("markdown"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	kb.PullEvents()
+
+	store := &batchStore{kb: kb}
+	usageRepo := &batchUsageRepository{store: store}
+	docRepo := &batchDocumentRepository{store: store}
+	quota := service.NewQuotaService(usageRepo, docRepo, valueobject.QuotaPolicy{MaxKnowledgeBases: 10}, nil)
+	handler := NewBatchDocumentsHandler(
+		&batchUnitOfWork{store: store},
+		&batchKnowledgeBaseRepository{store: store},
+		docRepo,
+		service.NewLinkService(batchLinkRepository{}),
+		quota,
+		&batchPublisher{store: store},
+		auth.NewPermissionChecker(nil),
+	)
+	return handler, store, existing
+}
+
+func batchStatuses(result *dto.BatchResultDTO) []string {
+	statuses := make([]string, len(result.Items))
+	for i, item := range result.Items {
+		statuses[i] = item.Status
+	}
+	return statuses
+}
+
+func TestBatchDocumentsAtomicRollback(t *testing.T) {
+	handler, store, existing := newBatchFixture(t)
+
+	result, err := handler.Handle(context.Background(), &BatchDocumentsCommand{
+		KnowledgeBaseID: store.kb.ID().String(),
+		Operations: []*BatchOperation{
+			{Op: BatchOpAdd, Title: "new", Content: "new content", ContentType: "markdown"},
+			{Op: BatchOpRemove, DocumentID: existing.ID().String()},
+			{Op: BatchOpUpdate, DocumentID: "not-a-uuid", Title: "x", Content: "x", ContentType: "markdown"},
+			{Op: BatchOpAdd, Title: "never", Content: "never", ContentType: "markdown"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Handle() error = %v", err)
+	}
+
+	if result.Committed {
+		t.Error("Committed = true, want false")
+	}
+	want := []string{dto.BatchStatusRolledBack, dto.BatchStatusRolledBack, dto.BatchStatusFailed, dto.BatchStatusSkipped}
+	if got := batchStatuses(result); !reflect.DeepEqual(got, want) {
+		t.Errorf("statuses = %v, want %v", got, want)
+	}
+	if result.Items[0].DocumentID != "" {
+		t.Errorf("rolled back add reports document %q", result.Items[0].DocumentID)
+	}
+	if result.Succeeded != 0 || result.Failed != 1 {
+		t.Errorf("succeeded = %d, failed = %d, want 0 and 1", result.Succeeded, result.Failed)
+	}
+
+	// 事务回滚：没有写入、没有计入用量、没有发布事件
+	if c := store.committed; len(c.savedDocs) != 0 || len(c.deletedDocs) != 0 || c.kbSaves != 0 || !c.usage.IsZero() {
+		t.Errorf("committed writes = %+v, want none", c)
+	}
+	if len(store.published) != 0 {
+		t.Errorf("published %d events, want 0", len(store.published))
+	}
+}
+
+func TestBatchDocumentsBestEffort(t *testing.T) {
+	handler, store, existing := newBatchFixture(t)
+
+	result, err := handler.Handle(context.Background(), &BatchDocumentsCommand{
+		KnowledgeBaseID: store.kb.ID().String(),
+		Mode:            BatchModeBestEffort,
+		Operations: []*BatchOperation{
+			{Op: BatchOpAdd, Title: "new", Content: "new content", ContentType: "markdown"},
+			{Op: BatchOpUpdate, DocumentID: "not-a-uuid", Title: "x", Content: "x", ContentType: "markdown"},
+			{Op: BatchOpRemove, DocumentID: existing.ID().String()},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Handle() error = %v", err)
+	}
+
+	if !result.Committed {
+		t.Error("Committed = false, want true")
+	}
+	want := []string{dto.BatchStatusSucceeded, dto.BatchStatusFailed, dto.BatchStatusSucceeded}
+	if got := batchStatuses(result); !reflect.DeepEqual(got, want) {
+		t.Errorf("statuses = %v, want %v", got, want)
+	}
+	if c := store.committed; len(c.savedDocs) != 1 || c.savedDocs[0] != result.Items[0].DocumentID || len(c.deletedDocs) != 1 || c.kbSaves != 1 {
+		t.Errorf("committed writes = %+v, want one saved and one deleted document", c)
+	}
+	if len(store.published) != 2 {
+		t.Errorf("published %d events, want 2 (added and removed)", len(store.published))
+	}
+}
+
+func TestBatchDocumentsPersistenceErrorRollsBack(t *testing.T) {
+	handler, store, _ := newBatchFixture(t)
+	store.failSave = 2
+
+	_, err := handler.Handle(context.Background(), &BatchDocumentsCommand{
+		KnowledgeBaseID: store.kb.ID().String(),
+		Mode:            BatchModeBestEffort,
+		Operations: []*BatchOperation{
+			{Op: BatchOpAdd, Title: "a", Content: "a", ContentType: "markdown"},
+			{Op: BatchOpAdd, Title: "b", Content: "b", ContentType: "markdown"},
+		},
+	})
+	if err == nil {
+		t.Fatal("Handle() error = nil, want persistence error")
+	}
+	// 持久化错误无法只撤销单个操作，best_effort 模式下同样整体回滚
+	if c := store.committed; len(c.savedDocs) != 0 || c.kbSaves != 0 {
+		t.Errorf("committed writes = %+v, want none", c)
+	}
+	if len(store.published) != 0 {
+		t.Errorf("published %d events, want 0", len(store.published))
+	}
+}
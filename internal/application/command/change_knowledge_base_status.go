@@ -0,0 +1,89 @@
+package command
+
+import (
+	"context"
+
+	"gozero-ddd/internal/application/auth"
+	"gozero-ddd/internal/application/dto"
+	"gozero-ddd/internal/domain"
+	"gozero-ddd/internal/domain/event"
+	"gozero-ddd/internal/domain/repository"
+	"gozero-ddd/internal/domain/valueobject"
+)
+
+// ChangeKnowledgeBaseStatusCommand 变更知识库生命周期状态命令
+// 用于将知识库设为只读、归档，或恢复为正常状态
+type ChangeKnowledgeBaseStatusCommand struct {
+	ID     string `json:"id"`
+	Status string `json:"status"` // 目标状态：active / read_only / archived
+}
+
+// ChangeKnowledgeBaseStatusHandler 变更知识库状态命令处理器
+type ChangeKnowledgeBaseStatusHandler struct {
+	kbRepo         repository.KnowledgeBaseRepository
+	eventPublisher event.EventPublisher
+	permissions    *auth.PermissionChecker // 权限检查器
+}
+
+// NewChangeKnowledgeBaseStatusHandler 创建处理器
+func NewChangeKnowledgeBaseStatusHandler(
+	kbRepo repository.KnowledgeBaseRepository,
+	ep event.EventPublisher,
+	permissions *auth.PermissionChecker,
+) *ChangeKnowledgeBaseStatusHandler {
+	return &ChangeKnowledgeBaseStatusHandler{
+		kbRepo:         kbRepo,
+		eventPublisher: ep,
+		permissions:    permissions,
+	}
+}
+
+// Handle 处理变更知识库状态命令
+// 状态转换规则由聚合根校验，不允许的转换返回 ErrInvalidStatusTransition
+func (h *ChangeKnowledgeBaseStatusHandler) Handle(ctx context.Context, cmd *ChangeKnowledgeBaseStatusCommand) (*dto.KnowledgeBaseDTO, error) {
+	// 验证 ID 格式
+	kbID, err := valueobject.KnowledgeBaseIDFromString(cmd.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 检查调用方权限
+	if err := h.permissions.Check(ctx, kbID, valueobject.MemberRoleOwner); err != nil {
+		return nil, err
+	}
+
+	// 验证目标状态
+	status, err := valueobject.KnowledgeBaseStatusFromString(cmd.Status)
+	if err != nil {
+		return nil, err
+	}
+
+	// 查找知识库
+	kb, err := h.kbRepo.FindByID(ctx, kbID)
+	if err != nil {
+		return nil, err
+	}
+	if kb == nil {
+		return nil, domain.ErrKnowledgeBaseNotFound
+	}
+
+	// 变更状态（会收集 KnowledgeBaseStatusChangedEvent）
+	if err := kb.ChangeStatus(status); err != nil {
+		return nil, err
+	}
+
+	// 保存
+	if err := h.kbRepo.Save(ctx, kb); err != nil {
+		return nil, err
+	}
+
+	// 发布领域事件
+	if h.eventPublisher != nil {
+		events := kb.PullEvents()
+		if len(events) > 0 {
+			_ = h.eventPublisher.PublishAll(ctx, events)
+		}
+	}
+
+	return dto.KnowledgeBaseFromEntity(kb, false), nil
+}
@@ -0,0 +1,122 @@
+package command
+
+import (
+	"context"
+
+	"gozero-ddd/internal/application/auth"
+	"gozero-ddd/internal/application/dto"
+	"gozero-ddd/internal/domain"
+	"gozero-ddd/internal/domain/entity"
+	"gozero-ddd/internal/domain/event"
+	"gozero-ddd/internal/domain/repository"
+	"gozero-ddd/internal/domain/valueobject"
+)
+
+// CreateFolderCommand 创建文件夹命令
+type CreateFolderCommand struct {
+	KnowledgeBaseID string `json:"knowledge_base_id"`
+	ParentID        string `json:"parent_id"` // 父文件夹ID，为空表示在根目录创建
+	Name            string `json:"name"`
+}
+
+// CreateFolderHandler 创建文件夹命令处理器
+type CreateFolderHandler struct {
+	unitOfWork     repository.UnitOfWork
+	kbRepo         repository.KnowledgeBaseRepository
+	folderRepo     repository.FolderRepository
+	eventPublisher event.EventPublisher
+	permissions    *auth.PermissionChecker // 权限检查器
+}
+
+// NewCreateFolderHandler 创建处理器
+func NewCreateFolderHandler(
+	uow repository.UnitOfWork,
+	kbRepo repository.KnowledgeBaseRepository,
+	folderRepo repository.FolderRepository,
+	ep event.EventPublisher,
+	permissions *auth.PermissionChecker,
+) *CreateFolderHandler {
+	return &CreateFolderHandler{
+		unitOfWork:     uow,
+		kbRepo:         kbRepo,
+		folderRepo:     folderRepo,
+		eventPublisher: ep,
+		permissions:    permissions,
+	}
+}
+
+// Handle 处理创建文件夹命令
+func (h *CreateFolderHandler) Handle(ctx context.Context, cmd *CreateFolderCommand) (*dto.FolderDTO, error) {
+	// 验证 ID 格式
+	kbID, err := valueobject.KnowledgeBaseIDFromString(cmd.KnowledgeBaseID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 检查调用方权限
+	if err := h.permissions.Check(ctx, kbID, valueobject.MemberRoleEditor); err != nil {
+		return nil, err
+	}
+
+	parentID, err := parseOptionalFolderID(cmd.ParentID)
+	if err != nil {
+		return nil, err
+	}
+
+	var kb *entity.KnowledgeBase
+	var result *dto.FolderDTO
+
+	err = h.unitOfWork.Transaction(ctx, func(txCtx context.Context) error {
+		// 查找知识库
+		var err error
+		kb, err = h.kbRepo.FindByID(txCtx, kbID)
+		if err != nil {
+			return err
+		}
+		if kb == nil {
+			return domain.ErrKnowledgeBaseNotFound
+		}
+
+		// 通过聚合根创建文件夹（会校验父文件夹和同级重名，并收集 FolderCreatedEvent）
+		folder, err := kb.CreateFolder(cmd.Name, parentID)
+		if err != nil {
+			return err
+		}
+
+		if err := h.folderRepo.Save(txCtx, folder); err != nil {
+			return err
+		}
+
+		if err := h.kbRepo.Save(txCtx, kb); err != nil {
+			return err
+		}
+
+		result = dto.FolderFromEntity(folder)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// 事务成功后发布事件
+	if h.eventPublisher != nil {
+		events := kb.PullEvents()
+		if len(events) > 0 {
+			_ = h.eventPublisher.PublishAll(ctx, events)
+		}
+	}
+
+	return result, nil
+}
+
+// parseOptionalFolderID 解析可选的文件夹ID，空字符串表示根目录
+func parseOptionalFolderID(s string) (*valueobject.FolderID, error) {
+	if s == "" {
+		return nil, nil
+	}
+	id, err := valueobject.FolderIDFromString(s)
+	if err != nil {
+		return nil, err
+	}
+	return &id, nil
+}
@@ -3,8 +3,11 @@ package command
 import (
 	"context"
 
+	"gozero-ddd/internal/application/auth"
 	"gozero-ddd/internal/application/dto"
+	"gozero-ddd/internal/domain/entity"
 	"gozero-ddd/internal/domain/event"
+	"gozero-ddd/internal/domain/repository"
 	"gozero-ddd/internal/domain/service"
 )
 
@@ -21,18 +24,24 @@ type CreateKnowledgeBaseCommand struct {
 // 3. 在持久化成功后发布领域事件
 // 4. 返回 DTO
 type CreateKnowledgeBaseHandler struct {
+	unitOfWork       repository.UnitOfWork // 工作单元
 	knowledgeService *service.KnowledgeService
-	eventPublisher   event.EventPublisher // 事件发布器
+	eventPublisher   event.EventPublisher    // 事件发布器
+	permissions      *auth.PermissionChecker // 权限检查器
 }
 
 // NewCreateKnowledgeBaseHandler 创建处理器
 func NewCreateKnowledgeBaseHandler(
+	uow repository.UnitOfWork,
 	ks *service.KnowledgeService,
 	ep event.EventPublisher,
+	permissions *auth.PermissionChecker,
 ) *CreateKnowledgeBaseHandler {
 	return &CreateKnowledgeBaseHandler{
+		unitOfWork:       uow,
 		knowledgeService: ks,
 		eventPublisher:   ep,
+		permissions:      permissions,
 	}
 }
 
@@ -40,8 +49,24 @@ func NewCreateKnowledgeBaseHandler(
 // 关键点：先持久化，后发布事件
 // 这确保了只有成功持久化的操作才会触发事件
 func (h *CreateKnowledgeBaseHandler) Handle(ctx context.Context, cmd *CreateKnowledgeBaseCommand) (*dto.KnowledgeBaseDTO, error) {
+	// 检查调用方权限
+	if err := h.permissions.CheckCreate(ctx); err != nil {
+		return nil, err
+	}
+
 	// 1. 调用领域服务创建知识库（包含持久化）
-	kb, err := h.knowledgeService.CreateKnowledgeBase(ctx, cmd.Name, cmd.Description)
+	// 知识库、占用的配额和创建者的所有者身份在同一事务中提交
+	var kb *entity.KnowledgeBase
+	err := h.unitOfWork.Transaction(ctx, func(txCtx context.Context) error {
+		var err error
+		kb, err = h.knowledgeService.CreateKnowledgeBase(txCtx, cmd.Name, cmd.Description)
+		if err != nil {
+			return err
+		}
+
+		// 创建者成为知识库的所有者
+		return h.permissions.GrantCreator(txCtx, kb.ID())
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -62,4 +87,3 @@ func (h *CreateKnowledgeBaseHandler) Handle(ctx context.Context, cmd *CreateKnow
 	// 3. 返回 DTO
 	return dto.KnowledgeBaseFromEntity(kb, false), nil
 }
-
@@ -0,0 +1,158 @@
+package command
+
+import (
+	"context"
+
+	"gozero-ddd/internal/application/auth"
+	"gozero-ddd/internal/application/dto"
+	"gozero-ddd/internal/domain"
+	"gozero-ddd/internal/domain/entity"
+	"gozero-ddd/internal/domain/event"
+	"gozero-ddd/internal/domain/repository"
+	"gozero-ddd/internal/domain/valueobject"
+)
+
+// DefineTagCommand 定义标签命令
+type DefineTagCommand struct {
+	KnowledgeBaseID string   `json:"knowledge_base_id"`
+	Name            string   `json:"name"`
+	ParentID        string   `json:"parent_id"` // 父标签ID，为空表示顶层标签
+	Aliases         []string `json:"aliases"`   // 别名（同义词），已使用别名的文档会改写为规范名称
+}
+
+// DefineTagHandler 定义标签命令处理器
+type DefineTagHandler struct {
+	unitOfWork     repository.UnitOfWork
+	kbRepo         repository.KnowledgeBaseRepository
+	docRepo        repository.DocumentRepository
+	tagRepo        repository.TagRepository
+	eventPublisher event.EventPublisher
+	permissions    *auth.PermissionChecker // 权限检查器
+}
+
+// NewDefineTagHandler 创建处理器
+func NewDefineTagHandler(
+	uow repository.UnitOfWork,
+	kbRepo repository.KnowledgeBaseRepository,
+	docRepo repository.DocumentRepository,
+	tagRepo repository.TagRepository,
+	ep event.EventPublisher,
+	permissions *auth.PermissionChecker,
+) *DefineTagHandler {
+	return &DefineTagHandler{
+		unitOfWork:     uow,
+		kbRepo:         kbRepo,
+		docRepo:        docRepo,
+		tagRepo:        tagRepo,
+		eventPublisher: ep,
+		permissions:    permissions,
+	}
+}
+
+// Handle 处理定义标签命令
+func (h *DefineTagHandler) Handle(ctx context.Context, cmd *DefineTagCommand) (*dto.TagChangeResultDTO, error) {
+	// 验证 ID 格式
+	kbID, err := valueobject.KnowledgeBaseIDFromString(cmd.KnowledgeBaseID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 检查调用方权限
+	if err := h.permissions.Check(ctx, kbID, valueobject.MemberRoleEditor); err != nil {
+		return nil, err
+	}
+
+	parentID, err := parseOptionalTagID(cmd.ParentID)
+	if err != nil {
+		return nil, err
+	}
+
+	var kb *entity.KnowledgeBase
+	var result *dto.TagChangeResultDTO
+
+	err = h.unitOfWork.Transaction(ctx, func(txCtx context.Context) error {
+		// 查找知识库
+		var err error
+		kb, err = h.kbRepo.FindByID(txCtx, kbID)
+		if err != nil {
+			return err
+		}
+		if kb == nil {
+			return domain.ErrKnowledgeBaseNotFound
+		}
+
+		// 通过聚合根定义标签（会收集 TagDefinedEvent）
+		def, docs, err := kb.DefineTag(cmd.Name, parentID, cmd.Aliases)
+		if err != nil {
+			return err
+		}
+
+		if err := saveTagChanges(txCtx, h.docRepo, h.tagRepo, kb, docs); err != nil {
+			return err
+		}
+
+		if err := h.kbRepo.Save(txCtx, kb); err != nil {
+			return err
+		}
+
+		result = tagChangeResult(kb, def, docs)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// 事务成功后发布事件
+	if h.eventPublisher != nil {
+		events := kb.PullEvents()
+		if len(events) > 0 {
+			_ = h.eventPublisher.PublishAll(ctx, events)
+		}
+	}
+
+	return result, nil
+}
+
+// parseOptionalTagID 解析可选的标签ID，空字符串表示顶层标签
+func parseOptionalTagID(s string) (*valueobject.TagID, error) {
+	if s == "" {
+		return nil, nil
+	}
+	id, err := valueobject.TagIDFromString(s)
+	if err != nil {
+		return nil, err
+	}
+	return &id, nil
+}
+
+// saveTagChanges 保存标签被改写的文档，并整体替换知识库的标签注册表
+func saveTagChanges(
+	ctx context.Context,
+	docRepo repository.DocumentRepository,
+	tagRepo repository.TagRepository,
+	kb *entity.KnowledgeBase,
+	docs []*entity.Document,
+) error {
+	for _, doc := range docs {
+		if err := docRepo.Save(ctx, doc); err != nil {
+			return err
+		}
+	}
+	return tagRepo.ReplaceAll(ctx, kb.ID(), kb.TagDefinitions())
+}
+
+// tagChangeResult 构造标签变更结果，def 为 nil 表示标签未在注册表中定义
+func tagChangeResult(kb *entity.KnowledgeBase, def *entity.TagDefinition, docs []*entity.Document) *dto.TagChangeResultDTO {
+	result := &dto.TagChangeResultDTO{
+		KnowledgeBaseID:    kb.ID().String(),
+		UpdatedDocumentIDs: make([]string, len(docs)),
+		UpdatedDocuments:   len(docs),
+	}
+	if def != nil {
+		result.Tag = dto.TagDefinitionFromEntity(def)
+	}
+	for i, doc := range docs {
+		result.UpdatedDocumentIDs[i] = doc.ID().String()
+	}
+	return result
+}
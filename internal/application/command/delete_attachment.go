@@ -0,0 +1,114 @@
+package command
+
+import (
+	"context"
+	"log"
+
+	"gozero-ddd/internal/application/auth"
+	"gozero-ddd/internal/domain"
+	"gozero-ddd/internal/domain/entity"
+	"gozero-ddd/internal/domain/event"
+	"gozero-ddd/internal/domain/repository"
+	"gozero-ddd/internal/domain/service"
+	"gozero-ddd/internal/domain/valueobject"
+)
+
+// DeleteAttachmentCommand 删除附件命令
+type DeleteAttachmentCommand struct {
+	KnowledgeBaseID string `json:"knowledge_base_id"`
+	AttachmentID    string `json:"attachment_id"`
+}
+
+// DeleteAttachmentHandler 删除附件命令处理器
+// 事务提交后，若附件内容不再被其他附件引用，则从 BlobStore 中删除
+type DeleteAttachmentHandler struct {
+	unitOfWork        repository.UnitOfWork
+	kbRepo            repository.KnowledgeBaseRepository
+	attRepo           repository.AttachmentRepository
+	attachmentService *service.AttachmentService
+	eventPublisher    event.EventPublisher
+	permissions       *auth.PermissionChecker // 权限检查器
+}
+
+// NewDeleteAttachmentHandler 创建处理器
+func NewDeleteAttachmentHandler(
+	uow repository.UnitOfWork,
+	kbRepo repository.KnowledgeBaseRepository,
+	attRepo repository.AttachmentRepository,
+	attachmentService *service.AttachmentService,
+	ep event.EventPublisher,
+	permissions *auth.PermissionChecker,
+) *DeleteAttachmentHandler {
+	return &DeleteAttachmentHandler{
+		unitOfWork:        uow,
+		kbRepo:            kbRepo,
+		attRepo:           attRepo,
+		attachmentService: attachmentService,
+		eventPublisher:    ep,
+		permissions:       permissions,
+	}
+}
+
+// Handle 处理删除附件命令
+func (h *DeleteAttachmentHandler) Handle(ctx context.Context, cmd *DeleteAttachmentCommand) error {
+	// 验证 ID 格式
+	kbID, err := valueobject.KnowledgeBaseIDFromString(cmd.KnowledgeBaseID)
+	if err != nil {
+		return err
+	}
+
+	// 检查调用方权限
+	if err := h.permissions.Check(ctx, kbID, valueobject.MemberRoleEditor); err != nil {
+		return err
+	}
+
+	attID, err := valueobject.AttachmentIDFromString(cmd.AttachmentID)
+	if err != nil {
+		return err
+	}
+
+	var kb *entity.KnowledgeBase
+	var removed *entity.Attachment
+
+	err = h.unitOfWork.Transaction(ctx, func(txCtx context.Context) error {
+		// 查找知识库
+		var err error
+		kb, err = h.kbRepo.FindByID(txCtx, kbID)
+		if err != nil {
+			return err
+		}
+		if kb == nil {
+			return domain.ErrKnowledgeBaseNotFound
+		}
+
+		// 通过聚合根删除附件（会收集 AttachmentRemovedEvent）
+		removed, err = kb.RemoveAttachment(attID)
+		if err != nil {
+			return err
+		}
+
+		if err := h.attRepo.Delete(txCtx, attID); err != nil {
+			return err
+		}
+
+		return h.kbRepo.Save(txCtx, kb)
+	})
+	if err != nil {
+		return err
+	}
+
+	// 事务成功后清理不再被引用的内容，失败不影响删除结果
+	if err := h.attachmentService.ReleaseContent(ctx, removed.ContentHash()); err != nil {
+		log.Printf("⚠️ [Attachment] 清理附件内容失败: hash=%s, err=%v", removed.ContentHash(), err)
+	}
+
+	// 事务成功后发布事件
+	if h.eventPublisher != nil {
+		events := kb.PullEvents()
+		if len(events) > 0 {
+			_ = h.eventPublisher.PublishAll(ctx, events)
+		}
+	}
+
+	return nil
+}
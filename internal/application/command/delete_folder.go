@@ -0,0 +1,118 @@
+package command
+
+import (
+	"context"
+
+	"gozero-ddd/internal/application/auth"
+	"gozero-ddd/internal/domain"
+	"gozero-ddd/internal/domain/entity"
+	"gozero-ddd/internal/domain/event"
+	"gozero-ddd/internal/domain/repository"
+	"gozero-ddd/internal/domain/valueobject"
+)
+
+// DeleteFolderCommand 删除文件夹命令
+type DeleteFolderCommand struct {
+	KnowledgeBaseID string `json:"knowledge_base_id"`
+	FolderID        string `json:"folder_id"`
+}
+
+// DeleteFolderHandler 删除文件夹命令处理器
+// 文件夹中的子文件夹和文档会移动到其父文件夹下，不会被删除
+type DeleteFolderHandler struct {
+	unitOfWork     repository.UnitOfWork
+	kbRepo         repository.KnowledgeBaseRepository
+	docRepo        repository.DocumentRepository
+	folderRepo     repository.FolderRepository
+	eventPublisher event.EventPublisher
+	permissions    *auth.PermissionChecker // 权限检查器
+}
+
+// NewDeleteFolderHandler 创建处理器
+func NewDeleteFolderHandler(
+	uow repository.UnitOfWork,
+	kbRepo repository.KnowledgeBaseRepository,
+	docRepo repository.DocumentRepository,
+	folderRepo repository.FolderRepository,
+	ep event.EventPublisher,
+	permissions *auth.PermissionChecker,
+) *DeleteFolderHandler {
+	return &DeleteFolderHandler{
+		unitOfWork:     uow,
+		kbRepo:         kbRepo,
+		docRepo:        docRepo,
+		folderRepo:     folderRepo,
+		eventPublisher: ep,
+		permissions:    permissions,
+	}
+}
+
+// Handle 处理删除文件夹命令
+func (h *DeleteFolderHandler) Handle(ctx context.Context, cmd *DeleteFolderCommand) error {
+	// 验证 ID 格式
+	kbID, err := valueobject.KnowledgeBaseIDFromString(cmd.KnowledgeBaseID)
+	if err != nil {
+		return err
+	}
+
+	// 检查调用方权限
+	if err := h.permissions.Check(ctx, kbID, valueobject.MemberRoleEditor); err != nil {
+		return err
+	}
+
+	folderID, err := valueobject.FolderIDFromString(cmd.FolderID)
+	if err != nil {
+		return err
+	}
+
+	var kb *entity.KnowledgeBase
+
+	err = h.unitOfWork.Transaction(ctx, func(txCtx context.Context) error {
+		// 查找知识库
+		var err error
+		kb, err = h.kbRepo.FindByID(txCtx, kbID)
+		if err != nil {
+			return err
+		}
+		if kb == nil {
+			return domain.ErrKnowledgeBaseNotFound
+		}
+
+		// 通过聚合根删除文件夹（会收集 FolderDeletedEvent）
+		movedDocs, movedFolders, err := kb.DeleteFolder(folderID)
+		if err != nil {
+			return err
+		}
+
+		// 保存上移一级的子文件夹和文档
+		for _, folder := range movedFolders {
+			if err := h.folderRepo.Save(txCtx, folder); err != nil {
+				return err
+			}
+		}
+		for _, doc := range movedDocs {
+			if err := h.docRepo.Save(txCtx, doc); err != nil {
+				return err
+			}
+		}
+
+		if err := h.folderRepo.Delete(txCtx, folderID); err != nil {
+			return err
+		}
+
+		return h.kbRepo.Save(txCtx, kb)
+	})
+	if err != nil {
+		return err
+	}
+
+	// 事务成功后发布事件
+	if h.eventPublisher != nil {
+		events := kb.PullEvents()
+		if len(events) > 0 {
+			_ = h.eventPublisher.PublishAll(ctx, events)
+		}
+	}
+
+	return nil
+}
@@ -3,35 +3,49 @@ package command
 import (
 	"context"
 
+	"gozero-ddd/internal/application/auth"
 	"gozero-ddd/internal/domain"
+	"gozero-ddd/internal/domain/entity"
+	"gozero-ddd/internal/domain/event"
 	"gozero-ddd/internal/domain/repository"
 	"gozero-ddd/internal/domain/service"
 	"gozero-ddd/internal/domain/valueobject"
 )
 
 // DeleteKnowledgeBaseCommand 删除知识库命令
+// 删除为软删除：知识库及其文档移入回收站，可通过 RestoreKnowledgeBaseCommand 恢复
 type DeleteKnowledgeBaseCommand struct {
 	ID string `json:"id"`
 }
 
 // DeleteKnowledgeBaseHandler 删除知识库命令处理器
 type DeleteKnowledgeBaseHandler struct {
+	unitOfWork       repository.UnitOfWork
 	kbRepo           repository.KnowledgeBaseRepository
 	knowledgeService *service.KnowledgeService
+	eventPublisher   event.EventPublisher
+	permissions      *auth.PermissionChecker // 权限检查器
 }
 
 // NewDeleteKnowledgeBaseHandler 创建处理器
 func NewDeleteKnowledgeBaseHandler(
+	uow repository.UnitOfWork,
 	kbRepo repository.KnowledgeBaseRepository,
 	ks *service.KnowledgeService,
+	ep event.EventPublisher,
+	permissions *auth.PermissionChecker,
 ) *DeleteKnowledgeBaseHandler {
 	return &DeleteKnowledgeBaseHandler{
+		unitOfWork:       uow,
 		kbRepo:           kbRepo,
 		knowledgeService: ks,
+		eventPublisher:   ep,
+		permissions:      permissions,
 	}
 }
 
 // Handle 处理删除知识库命令
+// 知识库和文档的软删除在同一个事务中完成
 func (h *DeleteKnowledgeBaseHandler) Handle(ctx context.Context, cmd *DeleteKnowledgeBaseCommand) error {
 	// 验证 ID 格式
 	kbID, err := valueobject.KnowledgeBaseIDFromString(cmd.ID)
@@ -39,15 +53,38 @@ func (h *DeleteKnowledgeBaseHandler) Handle(ctx context.Context, cmd *DeleteKnow
 		return err
 	}
 
-	// 查找知识库
-	kb, err := h.kbRepo.FindByID(ctx, kbID)
+	// 检查调用方权限
+	if err := h.permissions.Check(ctx, kbID, valueobject.MemberRoleOwner); err != nil {
+		return err
+	}
+
+	var kb *entity.KnowledgeBase
+
+	err = h.unitOfWork.Transaction(ctx, func(txCtx context.Context) error {
+		// 查找知识库
+		var err error
+		kb, err = h.kbRepo.FindByID(txCtx, kbID)
+		if err != nil {
+			return err
+		}
+		if kb == nil {
+			return domain.ErrKnowledgeBaseNotFound
+		}
+
+		// 使用领域服务删除（包含删除关联文档的逻辑，会收集 KnowledgeBaseTrashedEvent）
+		return h.knowledgeService.DeleteKnowledgeBase(txCtx, kb)
+	})
 	if err != nil {
 		return err
 	}
-	if kb == nil {
-		return domain.ErrKnowledgeBaseNotFound
+
+	// 事务成功后发布事件
+	if h.eventPublisher != nil {
+		events := kb.PullEvents()
+		if len(events) > 0 {
+			_ = h.eventPublisher.PublishAll(ctx, events)
+		}
 	}
 
-	// 使用领域服务删除（包含删除关联文档的逻辑）
-	return h.knowledgeService.DeleteKnowledgeBase(ctx, kb)
+	return nil
 }
@@ -0,0 +1,102 @@
+package command
+
+import (
+	"context"
+
+	"gozero-ddd/internal/application/auth"
+	"gozero-ddd/internal/domain"
+	"gozero-ddd/internal/domain/entity"
+	"gozero-ddd/internal/domain/event"
+	"gozero-ddd/internal/domain/repository"
+	"gozero-ddd/internal/domain/valueobject"
+)
+
+// DeleteTagCommand 删除标签定义命令
+type DeleteTagCommand struct {
+	KnowledgeBaseID string `json:"knowledge_base_id"`
+	TagID           string `json:"tag_id"`
+}
+
+// DeleteTagHandler 删除标签定义命令处理器
+// 只从注册表中删除定义，文档上的标签保持不变
+type DeleteTagHandler struct {
+	unitOfWork     repository.UnitOfWork
+	kbRepo         repository.KnowledgeBaseRepository
+	tagRepo        repository.TagRepository
+	eventPublisher event.EventPublisher
+	permissions    *auth.PermissionChecker // 权限检查器
+}
+
+// NewDeleteTagHandler 创建处理器
+func NewDeleteTagHandler(
+	uow repository.UnitOfWork,
+	kbRepo repository.KnowledgeBaseRepository,
+	tagRepo repository.TagRepository,
+	ep event.EventPublisher,
+	permissions *auth.PermissionChecker,
+) *DeleteTagHandler {
+	return &DeleteTagHandler{
+		unitOfWork:     uow,
+		kbRepo:         kbRepo,
+		tagRepo:        tagRepo,
+		eventPublisher: ep,
+		permissions:    permissions,
+	}
+}
+
+// Handle 处理删除标签定义命令
+func (h *DeleteTagHandler) Handle(ctx context.Context, cmd *DeleteTagCommand) error {
+	// 验证 ID 格式
+	kbID, err := valueobject.KnowledgeBaseIDFromString(cmd.KnowledgeBaseID)
+	if err != nil {
+		return err
+	}
+
+	// 检查调用方权限
+	if err := h.permissions.Check(ctx, kbID, valueobject.MemberRoleEditor); err != nil {
+		return err
+	}
+
+	tagID, err := valueobject.TagIDFromString(cmd.TagID)
+	if err != nil {
+		return err
+	}
+
+	var kb *entity.KnowledgeBase
+
+	err = h.unitOfWork.Transaction(ctx, func(txCtx context.Context) error {
+		// 查找知识库
+		var err error
+		kb, err = h.kbRepo.FindByID(txCtx, kbID)
+		if err != nil {
+			return err
+		}
+		if kb == nil {
+			return domain.ErrKnowledgeBaseNotFound
+		}
+
+		// 通过聚合根删除标签定义（会收集 TagDeletedEvent）
+		if err := kb.DeleteTag(tagID); err != nil {
+			return err
+		}
+
+		if err := h.tagRepo.ReplaceAll(txCtx, kbID, kb.TagDefinitions()); err != nil {
+			return err
+		}
+
+		return h.kbRepo.Save(txCtx, kb)
+	})
+	if err != nil {
+		return err
+	}
+
+	// 事务成功后发布事件
+	if h.eventPublisher != nil {
+		events := kb.PullEvents()
+		if len(events) > 0 {
+			_ = h.eventPublisher.PublishAll(ctx, events)
+		}
+	}
+
+	return nil
+}
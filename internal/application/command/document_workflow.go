@@ -0,0 +1,147 @@
+package command
+
+import (
+	"context"
+
+	"gozero-ddd/internal/application/auth"
+	"gozero-ddd/internal/application/dto"
+	"gozero-ddd/internal/domain"
+	"gozero-ddd/internal/domain/entity"
+	"gozero-ddd/internal/domain/event"
+	"gozero-ddd/internal/domain/repository"
+	"gozero-ddd/internal/domain/valueobject"
+)
+
+// 文档发布流程动作
+const (
+	DocumentActionSubmit    = "submit"    // 提交审核
+	DocumentActionApprove   = "approve"   // 审核通过
+	DocumentActionReject    = "reject"    // 驳回
+	DocumentActionPublish   = "publish"   // 发布
+	DocumentActionUnpublish = "unpublish" // 撤回发布
+)
+
+// DocumentWorkflowCommand 文档发布流程命令
+// 驱动文档在 draft -> in_review -> approved -> published 之间流转
+type DocumentWorkflowCommand struct {
+	KnowledgeBaseID string `json:"knowledge_base_id"`
+	DocumentID      string `json:"document_id"`
+	Action          string `json:"action"`   // submit / approve / reject / publish / unpublish
+	Reviewer        string `json:"reviewer"` // 审核人（approve / reject 时记录）
+	Comment         string `json:"comment"`  // 评审意见（reject 时必填）
+}
+
+// DocumentWorkflowHandler 文档发布流程命令处理器
+type DocumentWorkflowHandler struct {
+	unitOfWork     repository.UnitOfWork
+	kbRepo         repository.KnowledgeBaseRepository
+	docRepo        repository.DocumentRepository
+	eventPublisher event.EventPublisher
+	permissions    *auth.PermissionChecker // 权限检查器
+}
+
+// NewDocumentWorkflowHandler 创建处理器
+func NewDocumentWorkflowHandler(
+	uow repository.UnitOfWork,
+	kbRepo repository.KnowledgeBaseRepository,
+	docRepo repository.DocumentRepository,
+	ep event.EventPublisher,
+	permissions *auth.PermissionChecker,
+) *DocumentWorkflowHandler {
+	return &DocumentWorkflowHandler{
+		unitOfWork:     uow,
+		kbRepo:         kbRepo,
+		docRepo:        docRepo,
+		eventPublisher: ep,
+		permissions:    permissions,
+	}
+}
+
+// Handle 处理文档发布流程命令
+// 状态转换规则由聚合根校验，不允许的转换返回 ErrInvalidDocumentStatusTransition
+func (h *DocumentWorkflowHandler) Handle(ctx context.Context, cmd *DocumentWorkflowCommand) (*dto.DocumentDTO, error) {
+	// 验证 ID 格式
+	kbID, err := valueobject.KnowledgeBaseIDFromString(cmd.KnowledgeBaseID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 检查调用方权限
+	if err := h.permissions.Check(ctx, kbID, valueobject.MemberRoleEditor); err != nil {
+		return nil, err
+	}
+
+	docID, err := valueobject.DocumentIDFromString(cmd.DocumentID)
+	if err != nil {
+		return nil, err
+	}
+
+	var kb *entity.KnowledgeBase
+	var result *dto.DocumentDTO
+
+	err = h.unitOfWork.Transaction(ctx, func(txCtx context.Context) error {
+		// 查找知识库
+		var err error
+		kb, err = h.kbRepo.FindByID(txCtx, kbID)
+		if err != nil {
+			return err
+		}
+		if kb == nil {
+			return domain.ErrKnowledgeBaseNotFound
+		}
+
+		// 通过聚合根执行状态转换（会收集对应的领域事件）
+		if err := h.apply(kb, docID, cmd); err != nil {
+			return err
+		}
+
+		doc, err := kb.GetDocument(docID)
+		if err != nil {
+			return err
+		}
+
+		// 保存文档
+		if err := h.docRepo.Save(txCtx, doc); err != nil {
+			return err
+		}
+
+		// 更新知识库
+		if err := h.kbRepo.Save(txCtx, kb); err != nil {
+			return err
+		}
+
+		result = dto.DocumentFromEntity(doc)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// 事务成功后发布事件
+	if h.eventPublisher != nil {
+		events := kb.PullEvents()
+		if len(events) > 0 {
+			_ = h.eventPublisher.PublishAll(ctx, events)
+		}
+	}
+
+	return result, nil
+}
+
+// apply 根据动作调用聚合根上对应的方法
+func (h *DocumentWorkflowHandler) apply(kb *entity.KnowledgeBase, docID valueobject.DocumentID, cmd *DocumentWorkflowCommand) error {
+	switch cmd.Action {
+	case DocumentActionSubmit:
+		return kb.SubmitDocument(docID)
+	case DocumentActionApprove:
+		return kb.ApproveDocument(docID, cmd.Reviewer, cmd.Comment)
+	case DocumentActionReject:
+		return kb.RejectDocument(docID, cmd.Reviewer, cmd.Comment)
+	case DocumentActionPublish:
+		return kb.PublishDocument(docID)
+	case DocumentActionUnpublish:
+		return kb.UnpublishDocument(docID)
+	default:
+		return domain.ErrInvalidWorkflowAction
+	}
+}
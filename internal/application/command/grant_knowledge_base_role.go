@@ -0,0 +1,152 @@
+package command
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"gozero-ddd/internal/application/auth"
+	"gozero-ddd/internal/application/dto"
+	"gozero-ddd/internal/domain"
+	"gozero-ddd/internal/domain/event"
+	"gozero-ddd/internal/domain/repository"
+	"gozero-ddd/internal/domain/valueobject"
+)
+
+// GrantKnowledgeBaseRoleCommand 授予知识库角色命令
+// 调用方尚不是成员时加入知识库，已是成员时变更角色
+type GrantKnowledgeBaseRoleCommand struct {
+	KnowledgeBaseID string `json:"knowledge_base_id"`
+	Subject         string `json:"subject"` // 被授权的调用方标识（JWT 的 sub）
+	Role            string `json:"role"`    // owner / editor / viewer
+}
+
+// GrantKnowledgeBaseRoleHandler 授予知识库角色命令处理器
+type GrantKnowledgeBaseRoleHandler struct {
+	unitOfWork     repository.UnitOfWork
+	kbRepo         repository.KnowledgeBaseRepository
+	memberRepo     repository.KnowledgeBaseMemberRepository
+	eventPublisher event.EventPublisher
+	permissions    *auth.PermissionChecker // 权限检查器
+}
+
+// NewGrantKnowledgeBaseRoleHandler 创建处理器
+func NewGrantKnowledgeBaseRoleHandler(
+	uow repository.UnitOfWork,
+	kbRepo repository.KnowledgeBaseRepository,
+	memberRepo repository.KnowledgeBaseMemberRepository,
+	ep event.EventPublisher,
+	permissions *auth.PermissionChecker,
+) *GrantKnowledgeBaseRoleHandler {
+	return &GrantKnowledgeBaseRoleHandler{
+		unitOfWork:     uow,
+		kbRepo:         kbRepo,
+		memberRepo:     memberRepo,
+		eventPublisher: ep,
+		permissions:    permissions,
+	}
+}
+
+// Handle 处理授予知识库角色命令
+// 只有所有者可以管理成员；将最后一个所有者降级时返回 ErrLastKnowledgeBaseOwner
+func (h *GrantKnowledgeBaseRoleHandler) Handle(ctx context.Context, cmd *GrantKnowledgeBaseRoleCommand) (*dto.KnowledgeBaseMemberDTO, error) {
+	// 验证 ID 格式
+	kbID, err := valueobject.KnowledgeBaseIDFromString(cmd.KnowledgeBaseID)
+	if err != nil {
+		return nil, err
+	}
+
+	subject := strings.TrimSpace(cmd.Subject)
+	if subject == "" {
+		return nil, domain.ErrMemberSubjectEmpty
+	}
+
+	role, err := valueobject.MemberRoleFromString(cmd.Role)
+	if err != nil {
+		return nil, err
+	}
+
+	// 检查调用方权限
+	if err := h.permissions.Check(ctx, kbID, valueobject.MemberRoleOwner); err != nil {
+		return nil, err
+	}
+
+	var member *repository.KnowledgeBaseMember
+	var evt event.DomainEvent
+
+	err = h.unitOfWork.Transaction(ctx, func(txCtx context.Context) error {
+		kb, err := h.kbRepo.FindByID(txCtx, kbID)
+		if err != nil {
+			return err
+		}
+		if kb == nil {
+			return domain.ErrKnowledgeBaseNotFound
+		}
+
+		existing, err := h.memberRepo.Find(txCtx, kbID, subject)
+		if err != nil {
+			return err
+		}
+
+		var oldRole valueobject.MemberRole
+		if existing != nil {
+			if existing.Role == role {
+				// 角色未变化
+				member = existing
+				return nil
+			}
+			if existing.Role == valueobject.MemberRoleOwner {
+				if err := ensureOtherOwner(txCtx, h.memberRepo, kbID, subject); err != nil {
+					return err
+				}
+			}
+			oldRole = existing.Role
+		}
+
+		member = &repository.KnowledgeBaseMember{
+			KnowledgeBaseID: kbID,
+			Subject:         subject,
+			Role:            role,
+			GrantedBy:       auth.Actor(ctx),
+			GrantedAt:       time.Now(),
+		}
+		if err := h.memberRepo.Save(txCtx, member); err != nil {
+			return err
+		}
+
+		evt = event.NewKnowledgeBaseMemberGrantedEvent(kbID, subject, oldRole, role)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// 事务成功后发布事件
+	if evt != nil && h.eventPublisher != nil {
+		_ = h.eventPublisher.Publish(ctx, evt)
+	}
+
+	return dto.KnowledgeBaseMemberFromRecord(member), nil
+}
+
+// ensureOtherOwner 确保除 subject 之外知识库还有其他所有者
+// 在移除所有者或将所有者降级之前调用，避免知识库无人管理
+// 检查时锁定知识库的全部成员记录：两个所有者同时移除或降级对方时，
+// 后一个事务会等待前一个提交，并基于提交后的成员重新检查
+func ensureOtherOwner(
+	ctx context.Context,
+	memberRepo repository.KnowledgeBaseMemberRepository,
+	kbID valueobject.KnowledgeBaseID,
+	subject string,
+) error {
+	members, err := memberRepo.FindByKnowledgeBaseIDForUpdate(ctx, kbID)
+	if err != nil {
+		return err
+	}
+	for _, m := range members {
+		if m.Subject != subject && m.Role == valueobject.MemberRoleOwner {
+			return nil
+		}
+	}
+	return domain.ErrLastKnowledgeBaseOwner
+}
@@ -0,0 +1,58 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"gozero-ddd/internal/domain"
+	"gozero-ddd/internal/domain/repository"
+	"gozero-ddd/internal/domain/valueobject"
+)
+
+// lockingMemberRepository 记录成员查询是否加锁的成员仓储
+type lockingMemberRepository struct {
+	repository.KnowledgeBaseMemberRepository
+	members       []*repository.KnowledgeBaseMember
+	lockedQueries int
+}
+
+func (r *lockingMemberRepository) FindByKnowledgeBaseID(context.Context, valueobject.KnowledgeBaseID) ([]*repository.KnowledgeBaseMember, error) {
+	return nil, errors.New("owner check must lock member rows")
+}
+
+func (r *lockingMemberRepository) FindByKnowledgeBaseIDForUpdate(context.Context, valueobject.KnowledgeBaseID) ([]*repository.KnowledgeBaseMember, error) {
+	r.lockedQueries++
+	return r.members, nil
+}
+
+func TestEnsureOtherOwner(t *testing.T) {
+	kbID := valueobject.NewKnowledgeBaseID()
+	member := func(subject string, role valueobject.MemberRole) *repository.KnowledgeBaseMember {
+		return &repository.KnowledgeBaseMember{KnowledgeBaseID: kbID, Subject: subject, Role: role}
+	}
+
+	tests := []struct {
+		name    string
+		members []*repository.KnowledgeBaseMember
+		wantErr error
+	}{
+		{"another owner", []*repository.KnowledgeBaseMember{member("alice", valueobject.MemberRoleOwner), member("bob", valueobject.MemberRoleOwner)}, nil},
+		{"last owner", []*repository.KnowledgeBaseMember{member("alice", valueobject.MemberRoleOwner), member("bob", valueobject.MemberRoleEditor)}, domain.ErrLastKnowledgeBaseOwner},
+		// 另一个所有者已被并发的事务降级，加锁读取到的是提交后的角色
+		{"other owner demoted concurrently", []*repository.KnowledgeBaseMember{member("alice", valueobject.MemberRoleOwner), member("bob", valueobject.MemberRoleViewer)}, domain.ErrLastKnowledgeBaseOwner},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &lockingMemberRepository{members: tt.members}
+			err := ensureOtherOwner(context.Background(), repo, kbID, "alice")
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ensureOtherOwner() error = %v, want %v", err, tt.wantErr)
+			}
+			if repo.lockedQueries != 1 {
+				t.Errorf("locked member queries = %d, want 1", repo.lockedQueries)
+			}
+		})
+	}
+}
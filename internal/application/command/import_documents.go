@@ -0,0 +1,368 @@
+package command
+
+import (
+	"context"
+	"io"
+
+	"gozero-ddd/internal/application/auth"
+	"gozero-ddd/internal/application/dto"
+	"gozero-ddd/internal/domain"
+	"gozero-ddd/internal/domain/entity"
+	"gozero-ddd/internal/domain/event"
+	"gozero-ddd/internal/domain/repository"
+	"gozero-ddd/internal/domain/service"
+	"gozero-ddd/internal/domain/valueobject"
+)
+
+// 目录映射方式
+const (
+	ImportDirectoryAsFolder = "folder" // 目录映射为文件夹（默认）
+	ImportDirectoryAsTag    = "tag"    // 目录的每一级名称作为标签
+	ImportDirectoryIgnore   = "none"   // 忽略目录，文档都放在根目录
+)
+
+const (
+	// DefaultImportBatchSize 默认每个事务导入的文件数
+	DefaultImportBatchSize = 100
+	// MaxImportBatchSize 每个事务导入的最大文件数
+	MaxImportBatchSize = 500
+)
+
+// ImportDocumentsCommand 批量导入 Markdown 文档命令
+// 压缩包中的 Markdown 文件会被逐个导入，YAML front matter 中的 title 和 tags 作为文档标题和标签
+type ImportDocumentsCommand struct {
+	KnowledgeBaseID string      `json:"knowledge_base_id"`
+	Archive         io.ReaderAt `json:"-"`              // zip 压缩包
+	Size            int64       `json:"-"`              // 压缩包大小（字节）
+	DirectoryMode   string      `json:"directory_mode"` // folder / tag / none，为空时使用 folder
+	BatchSize       int         `json:"batch_size"`     // 每个事务导入的文件数，<= 0 时使用默认值
+}
+
+// ImportDocumentsHandler 批量导入 Markdown 文档命令处理器
+// 文件按批次导入，每批使用一个事务；单个文件的解析或校验失败只记录在报告中，不影响其他文件
+// 同一文件夹下已存在同名文档时跳过该文件，因此重复导入同一个压缩包是安全的
+type ImportDocumentsHandler struct {
+	unitOfWork     repository.UnitOfWork
+	kbRepo         repository.KnowledgeBaseRepository
+	docRepo        repository.DocumentRepository
+	folderRepo     repository.FolderRepository
+	linkService    *service.LinkService
+	quotaService   *service.QuotaService
+	eventPublisher event.EventPublisher
+	permissions    *auth.PermissionChecker // 权限检查器
+}
+
+// NewImportDocumentsHandler 创建处理器
+func NewImportDocumentsHandler(
+	uow repository.UnitOfWork,
+	kbRepo repository.KnowledgeBaseRepository,
+	docRepo repository.DocumentRepository,
+	folderRepo repository.FolderRepository,
+	linkService *service.LinkService,
+	quotaService *service.QuotaService,
+	ep event.EventPublisher,
+	permissions *auth.PermissionChecker,
+) *ImportDocumentsHandler {
+	return &ImportDocumentsHandler{
+		unitOfWork:     uow,
+		kbRepo:         kbRepo,
+		docRepo:        docRepo,
+		folderRepo:     folderRepo,
+		linkService:    linkService,
+		quotaService:   quotaService,
+		eventPublisher: ep,
+		permissions:    permissions,
+	}
+}
+
+// Handle 处理批量导入命令
+func (h *ImportDocumentsHandler) Handle(ctx context.Context, cmd *ImportDocumentsCommand) (*dto.ImportReportDTO, error) {
+	// 验证 ID 格式
+	kbID, err := valueobject.KnowledgeBaseIDFromString(cmd.KnowledgeBaseID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 检查调用方权限
+	if err := h.permissions.Check(ctx, kbID, valueobject.MemberRoleEditor); err != nil {
+		return nil, err
+	}
+
+	mode := cmd.DirectoryMode
+	switch mode {
+	case "":
+		mode = ImportDirectoryAsFolder
+	case ImportDirectoryAsFolder, ImportDirectoryAsTag, ImportDirectoryIgnore:
+	default:
+		return nil, domain.ErrInvalidImportDirectoryMode
+	}
+
+	batchSize := cmd.BatchSize
+	if batchSize <= 0 {
+		batchSize = DefaultImportBatchSize
+	}
+	if batchSize > MaxImportBatchSize {
+		batchSize = MaxImportBatchSize
+	}
+
+	// 提前检查知识库，避免逐个文件报告相同的错误
+	kb, err := h.kbRepo.FindByID(ctx, kbID)
+	if err != nil {
+		return nil, err
+	}
+	if kb == nil {
+		return nil, domain.ErrKnowledgeBaseNotFound
+	}
+	if !kb.IsActive() {
+		return nil, domain.ErrKnowledgeBaseNotActive
+	}
+
+	files, err := readImportArchive(cmd.Archive, cmd.Size)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &dto.ImportReportDTO{
+		KnowledgeBaseID: cmd.KnowledgeBaseID,
+		Total:           len(files),
+		Items:           make([]*dto.ImportItemDTO, len(files)),
+	}
+
+	// 路径无效和非 Markdown 文件直接记入报告，其余文件分批读取并导入
+	pending := make([]int, 0, len(files))
+	for i, f := range files {
+		item := &dto.ImportItemDTO{Path: f.path}
+		report.Items[i] = item
+		switch {
+		case f.err != nil:
+			item.Status = dto.ImportStatusFailed
+			item.Reason = f.err.Error()
+		case !f.isMarkdown():
+			item.Status = dto.ImportStatusSkipped
+			item.Reason = "not a markdown file"
+		default:
+			pending = append(pending, i)
+		}
+	}
+
+	budget := newImportBudget()
+	for start := 0; start < len(pending); start += batchSize {
+		end := start + batchSize
+		if end > len(pending) {
+			end = len(pending)
+		}
+		foldersCreated, err := h.importBatch(ctx, kbID, mode, files, report.Items, pending[start:end], budget)
+		if err != nil {
+			// 整批回滚，该批次中本应创建的文件都记为失败，继续处理后续批次
+			for _, i := range pending[start:end] {
+				item := report.Items[i]
+				if item.Status == dto.ImportStatusCreated {
+					*item = dto.ImportItemDTO{Path: item.Path, Status: dto.ImportStatusFailed, Reason: err.Error()}
+				}
+			}
+			continue
+		}
+		report.FoldersCreated += foldersCreated
+	}
+
+	for _, item := range report.Items {
+		switch item.Status {
+		case dto.ImportStatusCreated:
+			report.Created++
+		case dto.ImportStatusSkipped:
+			report.Skipped++
+		case dto.ImportStatusFailed:
+			report.Failed++
+		}
+	}
+
+	return report, nil
+}
+
+// importBatch 在一个事务中导入一批文件，返回新建的文件夹数量
+// 文件内容在此时才从压缩包中读取并扣减解压额度；
+// 逐个文件的结果直接写入 items；事务失败时由调用方修正为失败
+func (h *ImportDocumentsHandler) importBatch(
+	ctx context.Context,
+	kbID valueobject.KnowledgeBaseID,
+	mode string,
+	files []*importFile,
+	items []*dto.ImportItemDTO,
+	batch []int,
+	budget *importBudget,
+) (int, error) {
+	var kb *entity.KnowledgeBase
+	foldersCreated := 0
+
+	err := h.unitOfWork.Transaction(ctx, func(txCtx context.Context) error {
+		// 每批重新加载聚合根，包含之前批次创建的文档和文件夹
+		var err error
+		foldersCreated = 0
+		kb, err = h.kbRepo.FindByID(txCtx, kbID)
+		if err != nil {
+			return err
+		}
+		if kb == nil {
+			return domain.ErrKnowledgeBaseNotFound
+		}
+
+		// 设置租户配额，由聚合根在添加文档时校验
+		if err := h.quotaService.ApplyTo(txCtx, kb); err != nil {
+			return err
+		}
+
+		for _, i := range batch {
+			file, item := files[i], items[i]
+
+			content, err := readImportEntry(file.entry, budget)
+			if err != nil {
+				item.Status = dto.ImportStatusFailed
+				item.Reason = err.Error()
+				continue
+			}
+			parsed, err := parseMarkdownFile(file.name, content)
+			if err != nil {
+				item.Status = dto.ImportStatusFailed
+				item.Reason = err.Error()
+				continue
+			}
+			item.Title = parsed.title
+
+			// 目录映射
+			var folderID *valueobject.FolderID
+			tags := parsed.tags
+			switch mode {
+			case ImportDirectoryAsFolder:
+				var created []*entity.Folder
+				folderID, created, err = ensureFolderPath(kb, file.dirs)
+				// 先保存新建的文件夹，再保存引用它们的文档
+				for _, folder := range created {
+					if err := h.folderRepo.Save(txCtx, folder); err != nil {
+						return err
+					}
+				}
+				foldersCreated += len(created)
+				if err != nil {
+					item.Status = dto.ImportStatusFailed
+					item.Reason = err.Error()
+					continue
+				}
+			case ImportDirectoryAsTag:
+				tags = append(tags, directoryTags(file.dirs)...)
+			}
+
+			if hasDocumentTitled(kb, folderID, parsed.title) {
+				item.Status = dto.ImportStatusSkipped
+				item.Reason = "document with the same title already exists"
+				continue
+			}
+
+			// 通过聚合根添加文档（此时会收集 DocumentAddedEvent）
+			doc, err := kb.AddDocument(parsed.title, parsed.body, valueobject.ContentTypeMarkdown, tags)
+			if err != nil {
+				item.Status = dto.ImportStatusFailed
+				item.Reason = err.Error()
+				continue
+			}
+			if folderID != nil {
+				if err := kb.MoveDocumentToFolder(doc.ID(), folderID); err != nil {
+					return err
+				}
+			}
+
+			if err := h.docRepo.Save(txCtx, doc); err != nil {
+				return err
+			}
+
+			// 记录文档内容中的链接
+			if err := h.linkService.RefreshLinks(txCtx, doc); err != nil {
+				return err
+			}
+
+			item.Status = dto.ImportStatusCreated
+			item.DocumentID = doc.ID().String()
+			item.Tags = doc.Tags()
+			if folderID != nil {
+				item.FolderID = folderID.String()
+			}
+		}
+
+		// 在同一事务中计入新占用的租户用量
+		if err := h.quotaService.RecordUsed(txCtx, kb); err != nil {
+			return err
+		}
+
+		// 更新知识库
+		return h.kbRepo.Save(txCtx, kb)
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	// 事务成功后发布事件
+	if h.eventPublisher != nil {
+		events := kb.PullEvents()
+		if len(events) > 0 {
+			_ = h.eventPublisher.PublishAll(ctx, events)
+		}
+	}
+
+	return foldersCreated, nil
+}
+
+// ensureFolderPath 按目录路径查找文件夹，不存在的各级文件夹会被创建
+// 返回最末级文件夹ID（dirs 为空时为 nil，表示根目录）和新建的文件夹
+func ensureFolderPath(kb *entity.KnowledgeBase, dirs []string) (*valueobject.FolderID, []*entity.Folder, error) {
+	var parentID *valueobject.FolderID
+	created := make([]*entity.Folder, 0)
+
+	for _, name := range dirs {
+		var found *entity.Folder
+		for _, f := range kb.Folders() {
+			if f.Name() == name && sameFolderID(f.ParentID(), parentID) {
+				found = f
+				break
+			}
+		}
+		if found == nil {
+			folder, err := kb.CreateFolder(name, parentID)
+			if err != nil {
+				return nil, created, err
+			}
+			created = append(created, folder)
+			found = folder
+		}
+		id := found.ID()
+		parentID = &id
+	}
+	return parentID, created, nil
+}
+
+// directoryTags 将目录名称转换为标签，无法作为标签的名称被忽略
+func directoryTags(dirs []string) []string {
+	tags := make([]string, 0, len(dirs))
+	for _, dir := range dirs {
+		if tag, err := valueobject.NewTag(dir); err == nil {
+			tags = append(tags, tag.String())
+		}
+	}
+	return tags
+}
+
+// hasDocumentTitled 判断文件夹下是否已存在同名文档
+func hasDocumentTitled(kb *entity.KnowledgeBase, folderID *valueobject.FolderID, title string) bool {
+	for _, doc := range kb.Documents() {
+		if doc.Title() == title && sameFolderID(doc.FolderID(), folderID) {
+			return true
+		}
+	}
+	return false
+}
+
+// sameFolderID 判断两个文件夹ID是否相同（nil 表示根目录）
+func sameFolderID(a, b *valueobject.FolderID) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
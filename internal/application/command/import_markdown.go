@@ -0,0 +1,285 @@
+package command
+
+import (
+	"archive/zip"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"gopkg.in/yaml.v3"
+
+	"gozero-ddd/internal/domain"
+)
+
+const (
+	// MaxImportFiles 单个压缩包中允许的最大文件数
+	MaxImportFiles = 10000
+	// MaxImportFileBytes 单个文件解压后的最大字节数
+	MaxImportFileBytes = 4 << 20
+	// MaxImportTotalBytes 整个压缩包解压后的最大字节数，超出后剩余的文件记为失败
+	MaxImportTotalBytes = 256 << 20
+)
+
+// Markdown 文件扩展名
+var markdownExtensions = map[string]bool{
+	".md":       true,
+	".markdown": true,
+}
+
+// 第一个一级标题，front matter 中没有 title 时用作文档标题
+var firstHeadingPattern = regexp.MustCompile(`(?m)^#\s+(.+?)\s*#*\s*$`)
+
+// importFile 压缩包中的一个文件
+// 只保存压缩包条目，导入时才读取内容，内存占用不超过一个批次
+type importFile struct {
+	path  string   // 去掉公共根目录后的路径
+	dirs  []string // 所在目录的各级名称
+	name  string   // 文件名
+	entry *zip.File
+	err   error // 路径无效等无法导入的原因
+}
+
+// isMarkdown 判断是否为 Markdown 文件
+func (f *importFile) isMarkdown() bool {
+	return markdownExtensions[strings.ToLower(path.Ext(f.name))]
+}
+
+// markdownFile 解析后的 Markdown 文件
+type markdownFile struct {
+	title string
+	tags  []string
+	body  string
+}
+
+// frontMatter 支持的 front matter 字段，其余字段忽略
+type frontMatter struct {
+	Title string      `yaml:"title"`
+	Tags  interface{} `yaml:"tags"` // 列表或逗号分隔的字符串
+}
+
+// readImportArchive 列出 zip 压缩包中的文件，不读取文件内容
+// 目录、系统元数据（__MACOSX、._*）和隐藏文件会被忽略；
+// 所有文件都位于同一个顶层目录下时（直接压缩文件夹的常见情况），去掉该目录
+func readImportArchive(r io.ReaderAt, size int64) ([]*importFile, error) {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalidImportArchive, err)
+	}
+
+	files := make([]*importFile, 0, len(zr.File))
+	for _, entry := range zr.File {
+		if entry.FileInfo().IsDir() || isIgnoredImportPath(entry.Name) {
+			continue
+		}
+		if len(files) >= MaxImportFiles {
+			return nil, domain.ErrImportTooManyFiles
+		}
+
+		file := &importFile{path: entry.Name, entry: entry}
+		cleaned, ok := cleanImportPath(entry.Name)
+		if !ok {
+			file.err = errors.New("invalid file path")
+			files = append(files, file)
+			continue
+		}
+		file.path = cleaned
+		files = append(files, file)
+	}
+
+	stripCommonRoot(files)
+	for _, f := range files {
+		dir, name := path.Split(f.path)
+		f.name = name
+		if dir = strings.TrimSuffix(dir, "/"); dir != "" {
+			f.dirs = strings.Split(dir, "/")
+		}
+	}
+	return files, nil
+}
+
+// importBudget 压缩包解压总字节数的剩余额度
+type importBudget struct {
+	remaining int64
+}
+
+// newImportBudget 创建 MaxImportTotalBytes 的解压额度
+func newImportBudget() *importBudget {
+	return &importBudget{remaining: MaxImportTotalBytes}
+}
+
+// readImportEntry 读取单个文件，按实际解压字节数限制大小并扣减总额度（不信任压缩包头中声明的大小）
+func readImportEntry(entry *zip.File, budget *importBudget) ([]byte, error) {
+	rc, err := entry.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+
+	limit := int64(MaxImportFileBytes)
+	if budget.remaining < limit {
+		limit = budget.remaining
+	}
+	content, err := io.ReadAll(io.LimitReader(rc, limit+1))
+	budget.remaining -= int64(len(content))
+	if budget.remaining < 0 {
+		budget.remaining = 0
+	}
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(content)) > limit {
+		if limit < MaxImportFileBytes {
+			return nil, fmt.Errorf("archive exceeds %d bytes in total", MaxImportTotalBytes)
+		}
+		return nil, fmt.Errorf("file exceeds %d bytes", MaxImportFileBytes)
+	}
+	return content, nil
+}
+
+// isIgnoredImportPath 判断是否为需要忽略的系统元数据或隐藏文件
+func isIgnoredImportPath(name string) bool {
+	for _, segment := range strings.Split(strings.ReplaceAll(name, "\\", "/"), "/") {
+		if segment == "__MACOSX" || (strings.HasPrefix(segment, ".") && segment != "." && segment != "..") {
+			return true
+		}
+	}
+	return false
+}
+
+// cleanImportPath 规范化压缩包中的路径，拒绝绝对路径和指向上级目录的路径
+func cleanImportPath(name string) (string, bool) {
+	name = strings.ReplaceAll(name, "\\", "/")
+	if strings.HasPrefix(name, "/") || !utf8.ValidString(name) {
+		return "", false
+	}
+	cleaned := path.Clean(name)
+	if cleaned == "." || cleaned == ".." || strings.HasPrefix(cleaned, "../") {
+		return "", false
+	}
+	return cleaned, true
+}
+
+// stripCommonRoot 所有文件位于同一个顶层目录下时去掉该目录
+func stripCommonRoot(files []*importFile) {
+	root := ""
+	for _, f := range files {
+		if f.err != nil {
+			continue
+		}
+		idx := strings.Index(f.path, "/")
+		if idx < 0 {
+			return
+		}
+		if root == "" {
+			root = f.path[:idx+1]
+		} else if !strings.HasPrefix(f.path, root) {
+			return
+		}
+	}
+	if root == "" {
+		return
+	}
+	for _, f := range files {
+		if f.err == nil {
+			f.path = strings.TrimPrefix(f.path, root)
+		}
+	}
+}
+
+// parseMarkdownFile 解析 Markdown 文件的 YAML front matter
+// 标题优先取 front matter 中的 title，其次取第一个一级标题，最后使用文件名
+func parseMarkdownFile(name string, content []byte) (*markdownFile, error) {
+	if !utf8.Valid(content) {
+		return nil, errors.New("file is not valid UTF-8")
+	}
+
+	src := strings.TrimPrefix(string(content), "\ufeff")
+	src = strings.ReplaceAll(src, "\r\n", "\n")
+
+	result := &markdownFile{body: src}
+	if front, body, ok := splitFrontMatter(src); ok {
+		var fm frontMatter
+		if err := yaml.Unmarshal([]byte(front), &fm); err != nil {
+			return nil, fmt.Errorf("invalid front matter: %v", err)
+		}
+		tags, err := frontMatterTags(fm.Tags)
+		if err != nil {
+			return nil, err
+		}
+		result.title = strings.TrimSpace(fm.Title)
+		result.tags = tags
+		result.body = body
+	}
+	result.body = strings.TrimLeft(result.body, "\n")
+
+	if result.title == "" {
+		if m := firstHeadingPattern.FindStringSubmatch(result.body); m != nil {
+			result.title = m[1]
+		}
+	}
+	if result.title == "" {
+		result.title = strings.TrimSuffix(name, path.Ext(name))
+	}
+	return result, nil
+}
+
+// splitFrontMatter 拆分以 "---" 开头、以 "---" 或 "..." 结束的 front matter
+func splitFrontMatter(src string) (front, body string, ok bool) {
+	if !strings.HasPrefix(src, "---\n") {
+		return "", src, false
+	}
+	rest := src[len("---\n"):]
+	offset := 0
+	for {
+		end := strings.IndexByte(rest[offset:], '\n')
+		line := rest[offset:]
+		if end >= 0 {
+			line = rest[offset : offset+end]
+		}
+		if trimmed := strings.TrimRight(line, " \t"); trimmed == "---" || trimmed == "..." {
+			if end < 0 {
+				return rest[:offset], "", true
+			}
+			return rest[:offset], rest[offset+end+1:], true
+		}
+		if end < 0 {
+			return "", src, false
+		}
+		offset += end + 1
+	}
+}
+
+// frontMatterTags 读取 front matter 中的标签，支持列表和逗号分隔的字符串
+func frontMatterTags(raw interface{}) ([]string, error) {
+	switch v := raw.(type) {
+	case nil:
+		return nil, nil
+	case string:
+		tags := make([]string, 0)
+		for _, t := range strings.Split(v, ",") {
+			if t = strings.TrimSpace(t); t != "" {
+				tags = append(tags, t)
+			}
+		}
+		return tags, nil
+	case []interface{}:
+		tags := make([]string, 0, len(v))
+		for _, item := range v {
+			switch t := item.(type) {
+			case string:
+				tags = append(tags, t)
+			case int, float64, bool:
+				tags = append(tags, fmt.Sprint(t))
+			default:
+				return nil, errors.New("invalid front matter: tags must be strings")
+			}
+		}
+		return tags, nil
+	default:
+		return nil, errors.New("invalid front matter: tags must be a list or a comma separated string")
+	}
+}
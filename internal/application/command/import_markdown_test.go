@@ -0,0 +1,208 @@
+package command
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"gozero-ddd/internal/domain"
+)
+
+// zipEntry 测试压缩包中的一个条目
+type zipEntry struct {
+	name    string
+	content string
+}
+
+// buildZip 构造内存中的 zip 压缩包
+func buildZip(t *testing.T, entries ...zipEntry) *bytes.Reader {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for _, e := range entries {
+		w, err := zw.Create(e.name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.Write([]byte(e.content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return bytes.NewReader(buf.Bytes())
+}
+
+func TestReadImportArchivePaths(t *testing.T) {
+	tests := []struct {
+		name        string
+		entries     []zipEntry
+		wantPaths   []string
+		wantInvalid []string // 路径无效的原始路径
+	}{
+		{
+			name:      "common root is stripped",
+			entries:   []zipEntry{{"notes/a.md", "a"}, {"notes/sub/b.md", "b"}},
+			wantPaths: []string{"a.md", "sub/b.md"},
+		},
+		{
+			name:      "different roots are kept",
+			entries:   []zipEntry{{"x/a.md", "a"}, {"y/b.md", "b"}},
+			wantPaths: []string{"x/a.md", "y/b.md"},
+		},
+		{
+			name:      "metadata and hidden files are ignored",
+			entries:   []zipEntry{{"a.md", "a"}, {"__MACOSX/._a.md", "x"}, {".git/config", "x"}, {"dir/.hidden.md", "x"}, {"dir/", ""}},
+			wantPaths: []string{"a.md"},
+		},
+		{
+			name:      "backslashes are separators",
+			entries:   []zipEntry{{`dir\a.md`, "a"}, {"b.md", "b"}},
+			wantPaths: []string{"dir/a.md", "b.md"},
+		},
+		{
+			name:        "parent directory traversal is rejected",
+			entries:     []zipEntry{{"../evil.md", "x"}, {"a/../../evil.md", "x"}, {`..\evil.md`, "x"}, {"ok.md", "a"}},
+			wantPaths:   []string{"../evil.md", "a/../../evil.md", `..\evil.md`, "ok.md"},
+			wantInvalid: []string{"../evil.md", "a/../../evil.md", `..\evil.md`},
+		},
+		{
+			name:        "absolute path is rejected",
+			entries:     []zipEntry{{"/etc/passwd.md", "x"}, {`\windows\evil.md`, "x"}, {"ok.md", "a"}},
+			wantPaths:   []string{"/etc/passwd.md", `\windows\evil.md`, "ok.md"},
+			wantInvalid: []string{"/etc/passwd.md", `\windows\evil.md`},
+		},
+		{
+			name:      "inner traversal that stays inside is cleaned",
+			entries:   []zipEntry{{"a/../b.md", "b"}, {"c.md", "c"}},
+			wantPaths: []string{"b.md", "c.md"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := buildZip(t, tt.entries...)
+			files, err := readImportArchive(r, r.Size())
+			if err != nil {
+				t.Fatalf("readImportArchive() error = %v", err)
+			}
+
+			paths := make([]string, 0, len(files))
+			invalid := make([]string, 0)
+			for _, f := range files {
+				paths = append(paths, f.path)
+				if f.err != nil {
+					invalid = append(invalid, f.path)
+				}
+			}
+			if !reflect.DeepEqual(paths, tt.wantPaths) {
+				t.Errorf("paths = %q, want %q", paths, tt.wantPaths)
+			}
+			if len(tt.wantInvalid) == 0 {
+				tt.wantInvalid = []string{}
+			}
+			if !reflect.DeepEqual(invalid, tt.wantInvalid) {
+				t.Errorf("invalid paths = %q, want %q", invalid, tt.wantInvalid)
+			}
+		})
+	}
+}
+
+func TestReadImportArchiveLimits(t *testing.T) {
+	t.Run("not a zip", func(t *testing.T) {
+		r := bytes.NewReader([]byte("not a zip"))
+		if _, err := readImportArchive(r, r.Size()); !errors.Is(err, domain.ErrInvalidImportArchive) {
+			t.Errorf("error = %v, want %v", err, domain.ErrInvalidImportArchive)
+		}
+	})
+
+	t.Run("too many files", func(t *testing.T) {
+		entries := make([]zipEntry, MaxImportFiles+1)
+		for i := range entries {
+			entries[i] = zipEntry{name: fmt.Sprintf("%d.md", i)}
+		}
+		r := buildZip(t, entries...)
+		if _, err := readImportArchive(r, r.Size()); !errors.Is(err, domain.ErrImportTooManyFiles) {
+			t.Errorf("error = %v, want %v", err, domain.ErrImportTooManyFiles)
+		}
+	})
+
+	t.Run("ignored files do not count", func(t *testing.T) {
+		entries := make([]zipEntry, 0, MaxImportFiles+10)
+		for i := 0; i < MaxImportFiles; i++ {
+			entries = append(entries, zipEntry{name: fmt.Sprintf("%d.md", i)})
+		}
+		for i := 0; i < 10; i++ {
+			entries = append(entries, zipEntry{name: fmt.Sprintf("__MACOSX/%d.md", i)})
+		}
+		r := buildZip(t, entries...)
+		if _, err := readImportArchive(r, r.Size()); err != nil {
+			t.Errorf("error = %v, want nil", err)
+		}
+	})
+}
+
+func TestReadImportEntry(t *testing.T) {
+	// 高压缩比的内容，压缩包很小，解压后超过单个文件的上限
+	bomb := strings.Repeat("a", MaxImportFileBytes+1)
+
+	tests := []struct {
+		name      string
+		content   string
+		remaining int64
+		wantErr   string
+		wantLeft  int64
+	}{
+		{"within limits", "hello", 100, "", 95},
+		{"file at limit", strings.Repeat("a", MaxImportFileBytes), MaxImportTotalBytes, "", MaxImportTotalBytes - MaxImportFileBytes},
+		{"file over limit", bomb, MaxImportTotalBytes, "file exceeds", MaxImportTotalBytes - MaxImportFileBytes - 1},
+		{"total budget exceeded", "hello world", 5, "in total", 0},
+		{"total budget exhausted", "a", 0, "in total", 0},
+		{"empty file with exhausted budget", "", 0, "", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := buildZip(t, zipEntry{"a.md", tt.content})
+			zr, err := zip.NewReader(r, r.Size())
+			if err != nil {
+				t.Fatal(err)
+			}
+			budget := &importBudget{remaining: tt.remaining}
+			content, err := readImportEntry(zr.File[0], budget)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("readImportEntry() = %d bytes, %v, want error containing %q", len(content), err, tt.wantErr)
+				}
+			} else if err != nil || string(content) != tt.content {
+				t.Fatalf("readImportEntry() = %d bytes, %v, want %d bytes", len(content), err, len(tt.content))
+			}
+			if budget.remaining != tt.wantLeft {
+				t.Errorf("remaining budget = %d, want %d", budget.remaining, tt.wantLeft)
+			}
+		})
+	}
+}
+
+func TestReadImportEntriesShareBudget(t *testing.T) {
+	// 多个小文件累计超过总额度时，之后的文件读取失败
+	r := buildZip(t, zipEntry{"a.md", "12345"}, zipEntry{"b.md", "12345"}, zipEntry{"c.md", "12345"})
+	files, err := readImportArchive(r, r.Size())
+	if err != nil {
+		t.Fatal(err)
+	}
+	budget := &importBudget{remaining: 12}
+	var errs []bool
+	for _, f := range files {
+		_, err := readImportEntry(f.entry, budget)
+		errs = append(errs, err != nil)
+	}
+	if want := []bool{false, false, true}; !reflect.DeepEqual(errs, want) {
+		t.Errorf("read failures = %v, want %v", errs, want)
+	}
+}
@@ -0,0 +1,146 @@
+package command
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"gozero-ddd/internal/application/auth"
+	"gozero-ddd/internal/application/dto"
+	"gozero-ddd/internal/domain"
+	"gozero-ddd/internal/domain/event"
+	"gozero-ddd/internal/domain/repository"
+	"gozero-ddd/internal/domain/tenant"
+	"gozero-ddd/internal/domain/valueobject"
+)
+
+// IssueAPIKeyCommand 签发 API Key 命令
+type IssueAPIKeyCommand struct {
+	Name             string     `json:"name"`
+	Scopes           []string   `json:"scopes"`             // read / write / admin
+	KnowledgeBaseIDs []string   `json:"knowledge_base_ids"` // 允许访问的知识库，为空表示不限制
+	ExpiresAt        *time.Time `json:"expires_at"`         // 过期时间，nil 表示永不过期
+}
+
+// IssueAPIKeyHandler 签发 API Key 命令处理器
+type IssueAPIKeyHandler struct {
+	apiKeyRepo     repository.APIKeyRepository
+	kbRepo         repository.KnowledgeBaseRepository
+	eventPublisher event.EventPublisher
+	permissions    *auth.PermissionChecker // 权限检查器
+}
+
+// NewIssueAPIKeyHandler 创建处理器
+func NewIssueAPIKeyHandler(
+	apiKeyRepo repository.APIKeyRepository,
+	kbRepo repository.KnowledgeBaseRepository,
+	ep event.EventPublisher,
+	permissions *auth.PermissionChecker,
+) *IssueAPIKeyHandler {
+	return &IssueAPIKeyHandler{
+		apiKeyRepo:     apiKeyRepo,
+		kbRepo:         kbRepo,
+		eventPublisher: ep,
+		permissions:    permissions,
+	}
+}
+
+// Handle 处理签发 API Key 命令
+// 只有管理员可以签发；密钥明文只在返回结果中出现一次，数据库只保存哈希
+func (h *IssueAPIKeyHandler) Handle(ctx context.Context, cmd *IssueAPIKeyCommand) (*dto.IssuedAPIKeyDTO, error) {
+	// 检查调用方权限
+	if err := h.permissions.RequireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	name := strings.TrimSpace(cmd.Name)
+	if name == "" {
+		return nil, domain.ErrAPIKeyNameEmpty
+	}
+
+	scopes, err := parseAPIKeyScopes(cmd.Scopes)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if cmd.ExpiresAt != nil && !cmd.ExpiresAt.After(now) {
+		return nil, domain.ErrAPIKeyExpiryInPast
+	}
+
+	// 限制的知识库必须存在
+	kbIDs := make([]valueobject.KnowledgeBaseID, 0, len(cmd.KnowledgeBaseIDs))
+	seen := make(map[valueobject.KnowledgeBaseID]bool, len(cmd.KnowledgeBaseIDs))
+	for _, raw := range cmd.KnowledgeBaseIDs {
+		kbID, err := valueobject.KnowledgeBaseIDFromString(raw)
+		if err != nil {
+			return nil, err
+		}
+		if seen[kbID] {
+			continue
+		}
+		seen[kbID] = true
+
+		kb, err := h.kbRepo.FindByID(ctx, kbID)
+		if err != nil {
+			return nil, err
+		}
+		if kb == nil {
+			return nil, domain.ErrKnowledgeBaseNotFound
+		}
+		kbIDs = append(kbIDs, kbID)
+	}
+
+	plain, err := auth.GenerateAPIKey()
+	if err != nil {
+		return nil, err
+	}
+
+	key := &repository.APIKey{
+		ID:               uuid.New().String(),
+		Name:             name,
+		Prefix:           auth.APIKeyDisplayPrefix(plain),
+		Hash:             auth.HashAPIKey(plain),
+		Scopes:           scopes,
+		KnowledgeBaseIDs: kbIDs,
+		TenantID:         tenant.FromContext(ctx).String(),
+		CreatedBy:        auth.Actor(ctx),
+		CreatedAt:        now,
+		ExpiresAt:        cmd.ExpiresAt,
+	}
+	if err := h.apiKeyRepo.Save(ctx, key); err != nil {
+		return nil, err
+	}
+
+	// 持久化成功后发布事件
+	if h.eventPublisher != nil {
+		_ = h.eventPublisher.Publish(ctx, event.NewAPIKeyIssuedEvent(key.ID, key.Name, key.Scopes, key.KnowledgeBaseIDs))
+	}
+
+	return &dto.IssuedAPIKeyDTO{
+		APIKeyDTO: dto.APIKeyFromRecord(key, now),
+		Key:       plain,
+	}, nil
+}
+
+// parseAPIKeyScopes 解析并去重权限范围，至少需要一个
+func parseAPIKeyScopes(raw []string) ([]valueobject.APIKeyScope, error) {
+	scopes := make([]valueobject.APIKeyScope, 0, len(raw))
+	seen := make(map[valueobject.APIKeyScope]bool, len(raw))
+	for _, s := range raw {
+		scope, err := valueobject.APIKeyScopeFromString(strings.TrimSpace(s))
+		if err != nil {
+			return nil, err
+		}
+		if !seen[scope] {
+			seen[scope] = true
+			scopes = append(scopes, scope)
+		}
+	}
+	if len(scopes) == 0 {
+		return nil, domain.ErrAPIKeyScopesEmpty
+	}
+	return scopes, nil
+}
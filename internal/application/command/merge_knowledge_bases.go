@@ -3,14 +3,16 @@ package command
 import (
 	"context"
 
+	"gozero-ddd/internal/application/auth"
 	"gozero-ddd/internal/application/dto"
 	"gozero-ddd/internal/domain"
 	"gozero-ddd/internal/domain/repository"
+	"gozero-ddd/internal/domain/service"
 	"gozero-ddd/internal/domain/valueobject"
 )
 
 // MergeKnowledgeBasesCommand 合并知识库命令
-// 将源知识库的所有文档移动到目标知识库，然后删除源知识库
+// 将源知识库的所有文档移动到目标知识库，然后将源知识库移入回收站
 // 这是一个需要事务保证的操作
 type MergeKnowledgeBasesCommand struct {
 	SourceID string `json:"source_id"` // 源知识库ID（将被删除）
@@ -20,9 +22,13 @@ type MergeKnowledgeBasesCommand struct {
 // MergeKnowledgeBasesHandler 合并知识库命令处理器
 // 演示如何在应用层正确使用事务
 type MergeKnowledgeBasesHandler struct {
-	unitOfWork repository.UnitOfWork
-	kbRepo     repository.KnowledgeBaseRepository
-	docRepo    repository.DocumentRepository
+	unitOfWork   repository.UnitOfWork
+	kbRepo       repository.KnowledgeBaseRepository
+	docRepo      repository.DocumentRepository
+	attRepo      repository.AttachmentRepository
+	linkService  *service.LinkService
+	quotaService *service.QuotaService
+	permissions  *auth.PermissionChecker // 权限检查器
 }
 
 // NewMergeKnowledgeBasesHandler 创建处理器
@@ -30,11 +36,19 @@ func NewMergeKnowledgeBasesHandler(
 	uow repository.UnitOfWork,
 	kbRepo repository.KnowledgeBaseRepository,
 	docRepo repository.DocumentRepository,
+	attRepo repository.AttachmentRepository,
+	linkService *service.LinkService,
+	quotaService *service.QuotaService,
+	permissions *auth.PermissionChecker,
 ) *MergeKnowledgeBasesHandler {
 	return &MergeKnowledgeBasesHandler{
-		unitOfWork: uow,
-		kbRepo:     kbRepo,
-		docRepo:    docRepo,
+		unitOfWork:   uow,
+		kbRepo:       kbRepo,
+		docRepo:      docRepo,
+		attRepo:      attRepo,
+		linkService:  linkService,
+		quotaService: quotaService,
+		permissions:  permissions,
 	}
 }
 
@@ -57,6 +71,14 @@ func (h *MergeKnowledgeBasesHandler) Handle(ctx context.Context, cmd *MergeKnowl
 		return nil, domain.ErrCannotMergeSameKnowledgeBase
 	}
 
+	// 检查调用方权限：源知识库合并后被删除，需要所有者权限；目标知识库需要编辑权限
+	if err := h.permissions.Check(ctx, sourceID, valueobject.MemberRoleOwner); err != nil {
+		return nil, err
+	}
+	if err := h.permissions.Check(ctx, targetID, valueobject.MemberRoleEditor); err != nil {
+		return nil, err
+	}
+
 	var result *dto.MergeResultDTO
 
 	// 使用工作单元执行事务
@@ -82,6 +104,17 @@ func (h *MergeKnowledgeBasesHandler) Handle(ctx context.Context, cmd *MergeKnowl
 			return domain.ErrKnowledgeBaseNotFound
 		}
 
+		// 设置租户配额，由聚合根在移入文档时校验
+		if err := h.quotaService.ApplyTo(txCtx, targetKB); err != nil {
+			return err
+		}
+
+		// 只读或已归档的源知识库不允许被合并（合并会移走其文档）
+		// 目标知识库的状态由 AddDocument 校验
+		if !sourceKB.IsActive() {
+			return domain.ErrKnowledgeBaseNotActive
+		}
+
 		// 3. 获取源知识库的所有文档
 		sourceDocs, err := h.docRepo.FindByKnowledgeBaseID(txCtx, sourceID)
 		if err != nil {
@@ -91,8 +124,8 @@ func (h *MergeKnowledgeBasesHandler) Handle(ctx context.Context, cmd *MergeKnowl
 		// 4. 将每个文档添加到目标知识库
 		movedCount := 0
 		for _, doc := range sourceDocs {
-			// 通过聚合根添加文档到目标知识库
-			newDoc, err := targetKB.AddDocument(doc.Title(), doc.Content(), doc.Tags())
+			// 通过聚合根添加文档到目标知识库（保留发布状态和评审记录）
+			newDoc, err := targetKB.AdoptDocument(doc)
 			if err != nil {
 				return err
 			}
@@ -102,8 +135,27 @@ func (h *MergeKnowledgeBasesHandler) Handle(ctx context.Context, cmd *MergeKnowl
 				return err
 			}
 
-			// 删除原文档
-			if err := h.docRepo.Delete(txCtx, doc.ID()); err != nil {
+			// 记录新文档内容中的链接
+			if err := h.linkService.RefreshLinks(txCtx, newDoc); err != nil {
+				return err
+			}
+
+			// 附件随文档一起移动（内容按哈希共享，无需复制 blob）
+			for _, att := range sourceKB.DocumentAttachments(doc.ID()) {
+				newAtt, err := targetKB.AdoptAttachment(newDoc.ID(), att)
+				if err != nil {
+					return err
+				}
+				if err := h.attRepo.Save(txCtx, newAtt); err != nil {
+					return err
+				}
+			}
+			if err := h.attRepo.DeleteByDocumentID(txCtx, doc.ID()); err != nil {
+				return err
+			}
+
+			// 彻底删除原文档（文档已移动到目标知识库，不需要进入回收站）
+			if err := h.docRepo.Purge(txCtx, doc.ID()); err != nil {
 				return err
 			}
 
@@ -115,7 +167,7 @@ func (h *MergeKnowledgeBasesHandler) Handle(ctx context.Context, cmd *MergeKnowl
 			return err
 		}
 
-		// 6. 删除源知识库
+		// 6. 删除源知识库（移入回收站）
 		if err := h.kbRepo.Delete(txCtx, sourceID); err != nil {
 			return err
 		}
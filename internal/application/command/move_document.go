@@ -0,0 +1,119 @@
+package command
+
+import (
+	"context"
+
+	"gozero-ddd/internal/application/auth"
+	"gozero-ddd/internal/application/dto"
+	"gozero-ddd/internal/domain"
+	"gozero-ddd/internal/domain/entity"
+	"gozero-ddd/internal/domain/event"
+	"gozero-ddd/internal/domain/repository"
+	"gozero-ddd/internal/domain/valueobject"
+)
+
+// MoveDocumentCommand 移动文档到文件夹命令
+type MoveDocumentCommand struct {
+	KnowledgeBaseID string `json:"knowledge_base_id"`
+	DocumentID      string `json:"document_id"`
+	FolderID        string `json:"folder_id"` // 目标文件夹ID，为空表示移动到根目录
+}
+
+// MoveDocumentHandler 移动文档到文件夹命令处理器
+type MoveDocumentHandler struct {
+	unitOfWork     repository.UnitOfWork
+	kbRepo         repository.KnowledgeBaseRepository
+	docRepo        repository.DocumentRepository
+	eventPublisher event.EventPublisher
+	permissions    *auth.PermissionChecker // 权限检查器
+}
+
+// NewMoveDocumentHandler 创建处理器
+func NewMoveDocumentHandler(
+	uow repository.UnitOfWork,
+	kbRepo repository.KnowledgeBaseRepository,
+	docRepo repository.DocumentRepository,
+	ep event.EventPublisher,
+	permissions *auth.PermissionChecker,
+) *MoveDocumentHandler {
+	return &MoveDocumentHandler{
+		unitOfWork:     uow,
+		kbRepo:         kbRepo,
+		docRepo:        docRepo,
+		eventPublisher: ep,
+		permissions:    permissions,
+	}
+}
+
+// Handle 处理移动文档命令
+func (h *MoveDocumentHandler) Handle(ctx context.Context, cmd *MoveDocumentCommand) (*dto.DocumentDTO, error) {
+	// 验证 ID 格式
+	kbID, err := valueobject.KnowledgeBaseIDFromString(cmd.KnowledgeBaseID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 检查调用方权限
+	if err := h.permissions.Check(ctx, kbID, valueobject.MemberRoleEditor); err != nil {
+		return nil, err
+	}
+
+	docID, err := valueobject.DocumentIDFromString(cmd.DocumentID)
+	if err != nil {
+		return nil, err
+	}
+
+	folderID, err := parseOptionalFolderID(cmd.FolderID)
+	if err != nil {
+		return nil, err
+	}
+
+	var kb *entity.KnowledgeBase
+	var result *dto.DocumentDTO
+
+	err = h.unitOfWork.Transaction(ctx, func(txCtx context.Context) error {
+		// 查找知识库
+		var err error
+		kb, err = h.kbRepo.FindByID(txCtx, kbID)
+		if err != nil {
+			return err
+		}
+		if kb == nil {
+			return domain.ErrKnowledgeBaseNotFound
+		}
+
+		// 通过聚合根移动文档（会校验目标文件夹，并收集 DocumentMovedEvent）
+		if err := kb.MoveDocumentToFolder(docID, folderID); err != nil {
+			return err
+		}
+
+		doc, err := kb.GetDocument(docID)
+		if err != nil {
+			return err
+		}
+
+		if err := h.docRepo.Save(txCtx, doc); err != nil {
+			return err
+		}
+
+		if err := h.kbRepo.Save(txCtx, kb); err != nil {
+			return err
+		}
+
+		result = dto.DocumentFromEntity(doc)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// 事务成功后发布事件
+	if h.eventPublisher != nil {
+		events := kb.PullEvents()
+		if len(events) > 0 {
+			_ = h.eventPublisher.PublishAll(ctx, events)
+		}
+	}
+
+	return result, nil
+}
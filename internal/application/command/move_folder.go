@@ -0,0 +1,120 @@
+package command
+
+import (
+	"context"
+
+	"gozero-ddd/internal/application/auth"
+	"gozero-ddd/internal/application/dto"
+	"gozero-ddd/internal/domain"
+	"gozero-ddd/internal/domain/entity"
+	"gozero-ddd/internal/domain/event"
+	"gozero-ddd/internal/domain/repository"
+	"gozero-ddd/internal/domain/valueobject"
+)
+
+// MoveFolderCommand 移动文件夹命令
+type MoveFolderCommand struct {
+	KnowledgeBaseID string `json:"knowledge_base_id"`
+	FolderID        string `json:"folder_id"`
+	ParentID        string `json:"parent_id"` // 目标父文件夹ID，为空表示移动到根目录
+}
+
+// MoveFolderHandler 移动文件夹命令处理器
+type MoveFolderHandler struct {
+	unitOfWork     repository.UnitOfWork
+	kbRepo         repository.KnowledgeBaseRepository
+	folderRepo     repository.FolderRepository
+	eventPublisher event.EventPublisher
+	permissions    *auth.PermissionChecker // 权限检查器
+}
+
+// NewMoveFolderHandler 创建处理器
+func NewMoveFolderHandler(
+	uow repository.UnitOfWork,
+	kbRepo repository.KnowledgeBaseRepository,
+	folderRepo repository.FolderRepository,
+	ep event.EventPublisher,
+	permissions *auth.PermissionChecker,
+) *MoveFolderHandler {
+	return &MoveFolderHandler{
+		unitOfWork:     uow,
+		kbRepo:         kbRepo,
+		folderRepo:     folderRepo,
+		eventPublisher: ep,
+		permissions:    permissions,
+	}
+}
+
+// Handle 处理移动文件夹命令
+// 不允许移动到自身或其子孙文件夹下，否则返回 ErrFolderCycle
+func (h *MoveFolderHandler) Handle(ctx context.Context, cmd *MoveFolderCommand) (*dto.FolderDTO, error) {
+	// 验证 ID 格式
+	kbID, err := valueobject.KnowledgeBaseIDFromString(cmd.KnowledgeBaseID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 检查调用方权限
+	if err := h.permissions.Check(ctx, kbID, valueobject.MemberRoleEditor); err != nil {
+		return nil, err
+	}
+
+	folderID, err := valueobject.FolderIDFromString(cmd.FolderID)
+	if err != nil {
+		return nil, err
+	}
+
+	parentID, err := parseOptionalFolderID(cmd.ParentID)
+	if err != nil {
+		return nil, err
+	}
+
+	var kb *entity.KnowledgeBase
+	var result *dto.FolderDTO
+
+	err = h.unitOfWork.Transaction(ctx, func(txCtx context.Context) error {
+		// 查找知识库
+		var err error
+		kb, err = h.kbRepo.FindByID(txCtx, kbID)
+		if err != nil {
+			return err
+		}
+		if kb == nil {
+			return domain.ErrKnowledgeBaseNotFound
+		}
+
+		// 通过聚合根移动（会进行环检测，并收集 FolderMovedEvent）
+		if err := kb.MoveFolder(folderID, parentID); err != nil {
+			return err
+		}
+
+		folder, err := kb.GetFolder(folderID)
+		if err != nil {
+			return err
+		}
+
+		if err := h.folderRepo.Save(txCtx, folder); err != nil {
+			return err
+		}
+
+		if err := h.kbRepo.Save(txCtx, kb); err != nil {
+			return err
+		}
+
+		result = dto.FolderFromEntity(folder)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// 事务成功后发布事件
+	if h.eventPublisher != nil {
+		events := kb.PullEvents()
+		if len(events) > 0 {
+			_ = h.eventPublisher.PublishAll(ctx, events)
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,145 @@
+package command
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"gozero-ddd/internal/application/auth"
+	"gozero-ddd/internal/application/dto"
+	"gozero-ddd/internal/domain"
+	"gozero-ddd/internal/domain/entity"
+	"gozero-ddd/internal/domain/event"
+	"gozero-ddd/internal/domain/repository"
+)
+
+// ProcessScheduledDocumentsCommand 处理到期的定时发布/下线命令
+type ProcessScheduledDocumentsCommand struct {
+	Now time.Time `json:"now"` // 判断是否到期的时间基准，由调用方的时钟提供
+}
+
+// ProcessScheduledDocumentsHandler 处理到期的定时发布/下线命令处理器
+// 每个文档在独立事务中通过 KnowledgeBase 聚合根完成状态转换，
+// 单个文档失败不影响其他文档，失败的文档会在下一个周期重试
+type ProcessScheduledDocumentsHandler struct {
+	unitOfWork     repository.UnitOfWork
+	kbRepo         repository.KnowledgeBaseRepository
+	docRepo        repository.DocumentRepository
+	eventPublisher event.EventPublisher
+	permissions    *auth.PermissionChecker // 权限检查器
+}
+
+// NewProcessScheduledDocumentsHandler 创建处理器
+func NewProcessScheduledDocumentsHandler(
+	uow repository.UnitOfWork,
+	kbRepo repository.KnowledgeBaseRepository,
+	docRepo repository.DocumentRepository,
+	ep event.EventPublisher,
+	permissions *auth.PermissionChecker,
+) *ProcessScheduledDocumentsHandler {
+	return &ProcessScheduledDocumentsHandler{
+		unitOfWork:     uow,
+		kbRepo:         kbRepo,
+		docRepo:        docRepo,
+		eventPublisher: ep,
+		permissions:    permissions,
+	}
+}
+
+// Handle 处理到期的定时发布/下线命令
+func (h *ProcessScheduledDocumentsHandler) Handle(ctx context.Context, cmd *ProcessScheduledDocumentsCommand) (*dto.ScheduleResultDTO, error) {
+	// 处理会跨越所有知识库，只允许管理员和调度器执行
+	if err := h.permissions.RequireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	now := cmd.Now
+	if now.IsZero() {
+		now = time.Now()
+	}
+	result := &dto.ScheduleResultDTO{Now: now}
+
+	// 1. 定时发布
+	dueForPublish, err := h.docRepo.FindDueForPublish(ctx, now)
+	if err != nil {
+		return nil, err
+	}
+	for _, doc := range dueForPublish {
+		err := h.transition(ctx, doc, func(kb *entity.KnowledgeBase) error {
+			return kb.PublishDueDocument(doc.ID(), now)
+		})
+		if err != nil {
+			log.Printf("❌ [Scheduler] 定时发布文档失败: %s, 错误: %v", doc.ID(), err)
+			result.Failed++
+			continue
+		}
+		result.Published++
+	}
+
+	// 2. 到期下线（在发布之后执行，发布即过期的文档会在同一周期内下线）
+	dueForExpiry, err := h.docRepo.FindDueForExpiry(ctx, now)
+	if err != nil {
+		return nil, err
+	}
+	for _, doc := range dueForExpiry {
+		err := h.transition(ctx, doc, func(kb *entity.KnowledgeBase) error {
+			return kb.ExpireDocument(doc.ID(), now)
+		})
+		if err != nil {
+			log.Printf("❌ [Scheduler] 文档到期下线失败: %s, 错误: %v", doc.ID(), err)
+			result.Failed++
+			continue
+		}
+		result.Expired++
+	}
+
+	return result, nil
+}
+
+// transition 在事务中加载文档所属的聚合根并执行状态转换，事务提交后发布事件
+func (h *ProcessScheduledDocumentsHandler) transition(
+	ctx context.Context,
+	doc *entity.Document,
+	apply func(kb *entity.KnowledgeBase) error,
+) error {
+	var kb *entity.KnowledgeBase
+
+	err := h.unitOfWork.Transaction(ctx, func(txCtx context.Context) error {
+		var err error
+		kb, err = h.kbRepo.FindByID(txCtx, doc.KnowledgeBaseID())
+		if err != nil {
+			return err
+		}
+		if kb == nil {
+			return domain.ErrKnowledgeBaseNotFound
+		}
+
+		if err := apply(kb); err != nil {
+			return err
+		}
+
+		// 保存聚合根中最新的文档状态
+		updated, err := kb.GetDocument(doc.ID())
+		if err != nil {
+			return err
+		}
+		if err := h.docRepo.Save(txCtx, updated); err != nil {
+			return err
+		}
+
+		return h.kbRepo.Save(txCtx, kb)
+	})
+	if err != nil {
+		return err
+	}
+
+	// 事务成功后发布事件
+	if h.eventPublisher != nil {
+		events := kb.PullEvents()
+		if len(events) > 0 {
+			_ = h.eventPublisher.PublishAll(ctx, events)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,142 @@
+package command
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"gozero-ddd/internal/application/auth"
+	"gozero-ddd/internal/application/dto"
+	"gozero-ddd/internal/domain/event"
+	"gozero-ddd/internal/domain/repository"
+	"gozero-ddd/internal/domain/service"
+	"gozero-ddd/internal/domain/valueobject"
+)
+
+// PurgeTrashCommand 清理回收站命令
+// 彻底删除在回收站中停留超过 RetentionDays 天的知识库和文档
+type PurgeTrashCommand struct {
+	RetentionDays int `json:"retention_days"` // 保留天数，0 表示清空整个回收站
+}
+
+// PurgeTrashHandler 清理回收站命令处理器
+// 由定时任务周期性调用，也可以由管理接口手动触发
+type PurgeTrashHandler struct {
+	unitOfWork        repository.UnitOfWork
+	kbRepo            repository.KnowledgeBaseRepository
+	docRepo           repository.DocumentRepository
+	knowledgeService  *service.KnowledgeService
+	attachmentService *service.AttachmentService
+	eventPublisher    event.EventPublisher
+	permissions       *auth.PermissionChecker // 权限检查器
+}
+
+// NewPurgeTrashHandler 创建处理器
+func NewPurgeTrashHandler(
+	uow repository.UnitOfWork,
+	kbRepo repository.KnowledgeBaseRepository,
+	docRepo repository.DocumentRepository,
+	ks *service.KnowledgeService,
+	attachmentService *service.AttachmentService,
+	ep event.EventPublisher,
+	permissions *auth.PermissionChecker,
+) *PurgeTrashHandler {
+	return &PurgeTrashHandler{
+		unitOfWork:        uow,
+		kbRepo:            kbRepo,
+		docRepo:           docRepo,
+		knowledgeService:  ks,
+		attachmentService: attachmentService,
+		eventPublisher:    ep,
+		permissions:       permissions,
+	}
+}
+
+// Handle 处理清理回收站命令
+// 先清理知识库（连同其全部文档），再清理剩余的过期文档
+func (h *PurgeTrashHandler) Handle(ctx context.Context, cmd *PurgeTrashCommand) (*dto.PurgeResultDTO, error) {
+	// 清理会跨越所有知识库，只允许管理员和定时任务执行
+	if err := h.permissions.RequireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	retentionDays := cmd.RetentionDays
+	if retentionDays < 0 {
+		retentionDays = 0
+	}
+	before := time.Now().AddDate(0, 0, -retentionDays)
+
+	result := &dto.PurgeResultDTO{Before: before}
+	var events []event.DomainEvent
+	var releasedHashes []valueobject.ContentHash
+
+	err := h.unitOfWork.Transaction(ctx, func(txCtx context.Context) error {
+		// 1. 彻底删除过期的知识库（包括其下所有文档和附件）
+		kbs, err := h.kbRepo.FindDeletedBefore(txCtx, before)
+		if err != nil {
+			return err
+		}
+		for _, kb := range kbs {
+			hashes, err := h.attachmentService.PurgeKnowledgeBaseAttachments(txCtx, kb.ID())
+			if err != nil {
+				return err
+			}
+			releasedHashes = append(releasedHashes, hashes...)
+
+			// 回收站中知识库的文档都已随之移入回收站，清除前统计以便释放租户的用量
+			kbDocs, err := h.docRepo.FindDeleted(txCtx, kb.ID())
+			if err != nil {
+				return err
+			}
+
+			if err := h.knowledgeService.PurgeKnowledgeBase(txCtx, kb); err != nil {
+				return err
+			}
+			purgedEvent := event.NewKnowledgeBasePurgedEvent(kb.ID(), kb.Name())
+			purgedEvent.DocumentCount = len(kbDocs)
+			for _, doc := range kbDocs {
+				purgedEvent.ContentBytes += doc.ContentBytes()
+			}
+			events = append(events, purgedEvent)
+		}
+
+		// 2. 彻底删除过期的文档
+		docs, err := h.docRepo.FindDeletedBefore(txCtx, before)
+		if err != nil {
+			return err
+		}
+		for _, doc := range docs {
+			hashes, err := h.attachmentService.PurgeDocumentAttachments(txCtx, doc.ID())
+			if err != nil {
+				return err
+			}
+			releasedHashes = append(releasedHashes, hashes...)
+
+			if err := h.docRepo.Purge(txCtx, doc.ID()); err != nil {
+				return err
+			}
+			purgedEvent := event.NewDocumentPurgedEvent(doc.ID(), doc.KnowledgeBaseID(), doc.Title())
+			purgedEvent.ContentBytes = doc.ContentBytes()
+			events = append(events, purgedEvent)
+		}
+
+		result.KnowledgeBasesPurged = len(kbs)
+		result.DocumentsPurged = len(docs)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// 事务成功后清理不再被引用的附件内容，失败不影响清理结果
+	if err := h.attachmentService.ReleaseContent(ctx, releasedHashes...); err != nil {
+		log.Printf("⚠️ [Attachment] 清理附件内容失败: %v", err)
+	}
+
+	// 事务成功后发布事件
+	if len(events) > 0 && h.eventPublisher != nil {
+		_ = h.eventPublisher.PublishAll(ctx, events)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,84 @@
+package command
+
+import (
+	"context"
+
+	"gozero-ddd/internal/application/auth"
+	"gozero-ddd/internal/application/dto"
+	"gozero-ddd/internal/domain"
+	"gozero-ddd/internal/domain/entity"
+	"gozero-ddd/internal/domain/repository"
+	"gozero-ddd/internal/domain/service"
+	"gozero-ddd/internal/domain/valueobject"
+)
+
+// RebuildFingerprintsCommand 重建文档内容指纹命令
+// 内容指纹由文档事件维护；启用重复检测之前已有的文档，
+// 以及不发布文档事件的操作（如合并知识库）移入的文档，需要通过该命令补算
+type RebuildFingerprintsCommand struct {
+	KnowledgeBaseID string `json:"knowledge_base_id"` // 为空时重建所有知识库
+}
+
+// RebuildFingerprintsHandler 重建文档内容指纹命令处理器
+type RebuildFingerprintsHandler struct {
+	kbRepo           repository.KnowledgeBaseRepository
+	duplicateService *service.DuplicateService
+	permissions      *auth.PermissionChecker // 权限检查器
+}
+
+// NewRebuildFingerprintsHandler 创建处理器
+func NewRebuildFingerprintsHandler(
+	kbRepo repository.KnowledgeBaseRepository,
+	duplicateService *service.DuplicateService,
+	permissions *auth.PermissionChecker,
+) *RebuildFingerprintsHandler {
+	return &RebuildFingerprintsHandler{
+		kbRepo:           kbRepo,
+		duplicateService: duplicateService,
+		permissions:      permissions,
+	}
+}
+
+// Handle 处理重建文档内容指纹命令
+func (h *RebuildFingerprintsHandler) Handle(ctx context.Context, cmd *RebuildFingerprintsCommand) (*dto.FingerprintRebuildResultDTO, error) {
+	var kbs []*entity.KnowledgeBase
+	if cmd.KnowledgeBaseID == "" {
+		// 重建所有知识库只允许管理员执行
+		if err := h.permissions.RequireAdmin(ctx); err != nil {
+			return nil, err
+		}
+		var err error
+		if kbs, err = h.kbRepo.FindAll(ctx); err != nil {
+			return nil, err
+		}
+	} else {
+		// 验证 ID 格式
+		kbID, err := valueobject.KnowledgeBaseIDFromString(cmd.KnowledgeBaseID)
+		if err != nil {
+			return nil, err
+		}
+		if err := h.permissions.Check(ctx, kbID, valueobject.MemberRoleEditor); err != nil {
+			return nil, err
+		}
+		kb, err := h.kbRepo.FindByID(ctx, kbID)
+		if err != nil {
+			return nil, err
+		}
+		if kb == nil {
+			return nil, domain.ErrKnowledgeBaseNotFound
+		}
+		kbs = []*entity.KnowledgeBase{kb}
+	}
+
+	result := &dto.FingerprintRebuildResultDTO{KnowledgeBases: len(kbs)}
+	for _, kb := range kbs {
+		for _, doc := range kb.Documents() {
+			if err := h.duplicateService.RefreshFingerprint(ctx, doc); err != nil {
+				return nil, err
+			}
+			result.Documents++
+		}
+	}
+
+	return result, nil
+}
@@ -3,8 +3,12 @@ package command
 import (
 	"context"
 
+	"gozero-ddd/internal/application/auth"
 	"gozero-ddd/internal/domain"
+	"gozero-ddd/internal/domain/entity"
+	"gozero-ddd/internal/domain/event"
 	"gozero-ddd/internal/domain/repository"
+	"gozero-ddd/internal/domain/service"
 	"gozero-ddd/internal/domain/valueobject"
 )
 
@@ -15,10 +19,14 @@ type RemoveDocumentCommand struct {
 }
 
 // RemoveDocumentHandler 删除文档命令处理器
+// 删除为软删除：文档移入回收站，可通过 RestoreDocumentCommand 恢复
 type RemoveDocumentHandler struct {
-	unitOfWork repository.UnitOfWork
-	kbRepo     repository.KnowledgeBaseRepository
-	docRepo    repository.DocumentRepository
+	unitOfWork     repository.UnitOfWork
+	kbRepo         repository.KnowledgeBaseRepository
+	docRepo        repository.DocumentRepository
+	linkService    *service.LinkService
+	eventPublisher event.EventPublisher
+	permissions    *auth.PermissionChecker // 权限检查器
 }
 
 // NewRemoveDocumentHandler 创建处理器
@@ -26,16 +34,23 @@ func NewRemoveDocumentHandler(
 	uow repository.UnitOfWork,
 	kbRepo repository.KnowledgeBaseRepository,
 	docRepo repository.DocumentRepository,
+	linkService *service.LinkService,
+	ep event.EventPublisher,
+	permissions *auth.PermissionChecker,
 ) *RemoveDocumentHandler {
 	return &RemoveDocumentHandler{
-		unitOfWork: uow,
-		kbRepo:     kbRepo,
-		docRepo:    docRepo,
+		unitOfWork:     uow,
+		kbRepo:         kbRepo,
+		docRepo:        docRepo,
+		linkService:    linkService,
+		eventPublisher: ep,
+		permissions:    permissions,
 	}
 }
 
 // Handle 处理删除文档命令
-// 使用事务确保数据一致性
+// 使用事务确保数据一致性，事务提交后发布 DocumentRemovedEvent
+// 以及引用了该文档的每篇文档对应的 DocumentLinkBrokenEvent
 func (h *RemoveDocumentHandler) Handle(ctx context.Context, cmd *RemoveDocumentCommand) error {
 	// 验证 ID 格式
 	kbID, err := valueobject.KnowledgeBaseIDFromString(cmd.KnowledgeBaseID)
@@ -43,15 +58,24 @@ func (h *RemoveDocumentHandler) Handle(ctx context.Context, cmd *RemoveDocumentC
 		return err
 	}
 
+	// 检查调用方权限
+	if err := h.permissions.Check(ctx, kbID, valueobject.MemberRoleEditor); err != nil {
+		return err
+	}
+
 	docID, err := valueobject.DocumentIDFromString(cmd.DocumentID)
 	if err != nil {
 		return err
 	}
 
+	var kb *entity.KnowledgeBase
+	var brokenLinkEvents []event.DomainEvent
+
 	// 使用事务包裹所有数据库操作
-	return h.unitOfWork.Transaction(ctx, func(txCtx context.Context) error {
+	err = h.unitOfWork.Transaction(ctx, func(txCtx context.Context) error {
 		// 查找知识库
-		kb, err := h.kbRepo.FindByID(txCtx, kbID)
+		var err error
+		kb, err = h.kbRepo.FindByID(txCtx, kbID)
 		if err != nil {
 			return err
 		}
@@ -59,11 +83,22 @@ func (h *RemoveDocumentHandler) Handle(ctx context.Context, cmd *RemoveDocumentC
 			return domain.ErrKnowledgeBaseNotFound
 		}
 
+		doc, err := kb.GetDocument(docID)
+		if err != nil {
+			return err
+		}
+
 		// 通过聚合根删除文档
 		if err := kb.RemoveDocument(docID); err != nil {
 			return err
 		}
 
+		// 找出引用了该文档的链接，通知依赖它的文档
+		brokenLinkEvents, err = h.linkService.BrokenLinkEvents(txCtx, kb, doc)
+		if err != nil {
+			return err
+		}
+
 		// 删除文档持久化数据
 		if err := h.docRepo.Delete(txCtx, docID); err != nil {
 			return err
@@ -72,4 +107,17 @@ func (h *RemoveDocumentHandler) Handle(ctx context.Context, cmd *RemoveDocumentC
 		// 更新知识库
 		return h.kbRepo.Save(txCtx, kb)
 	})
+	if err != nil {
+		return err
+	}
+
+	// 事务成功后发布事件
+	if h.eventPublisher != nil {
+		events := append(kb.PullEvents(), brokenLinkEvents...)
+		if len(events) > 0 {
+			_ = h.eventPublisher.PublishAll(ctx, events)
+		}
+	}
+
+	return nil
 }
@@ -0,0 +1,114 @@
+package command
+
+import (
+	"context"
+
+	"gozero-ddd/internal/application/auth"
+	"gozero-ddd/internal/application/dto"
+	"gozero-ddd/internal/domain"
+	"gozero-ddd/internal/domain/entity"
+	"gozero-ddd/internal/domain/event"
+	"gozero-ddd/internal/domain/repository"
+	"gozero-ddd/internal/domain/valueobject"
+)
+
+// RenameFolderCommand 重命名文件夹命令
+type RenameFolderCommand struct {
+	KnowledgeBaseID string `json:"knowledge_base_id"`
+	FolderID        string `json:"folder_id"`
+	Name            string `json:"name"`
+}
+
+// RenameFolderHandler 重命名文件夹命令处理器
+type RenameFolderHandler struct {
+	unitOfWork     repository.UnitOfWork
+	kbRepo         repository.KnowledgeBaseRepository
+	folderRepo     repository.FolderRepository
+	eventPublisher event.EventPublisher
+	permissions    *auth.PermissionChecker // 权限检查器
+}
+
+// NewRenameFolderHandler 创建处理器
+func NewRenameFolderHandler(
+	uow repository.UnitOfWork,
+	kbRepo repository.KnowledgeBaseRepository,
+	folderRepo repository.FolderRepository,
+	ep event.EventPublisher,
+	permissions *auth.PermissionChecker,
+) *RenameFolderHandler {
+	return &RenameFolderHandler{
+		unitOfWork:     uow,
+		kbRepo:         kbRepo,
+		folderRepo:     folderRepo,
+		eventPublisher: ep,
+		permissions:    permissions,
+	}
+}
+
+// Handle 处理重命名文件夹命令
+func (h *RenameFolderHandler) Handle(ctx context.Context, cmd *RenameFolderCommand) (*dto.FolderDTO, error) {
+	// 验证 ID 格式
+	kbID, err := valueobject.KnowledgeBaseIDFromString(cmd.KnowledgeBaseID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 检查调用方权限
+	if err := h.permissions.Check(ctx, kbID, valueobject.MemberRoleEditor); err != nil {
+		return nil, err
+	}
+
+	folderID, err := valueobject.FolderIDFromString(cmd.FolderID)
+	if err != nil {
+		return nil, err
+	}
+
+	var kb *entity.KnowledgeBase
+	var result *dto.FolderDTO
+
+	err = h.unitOfWork.Transaction(ctx, func(txCtx context.Context) error {
+		// 查找知识库
+		var err error
+		kb, err = h.kbRepo.FindByID(txCtx, kbID)
+		if err != nil {
+			return err
+		}
+		if kb == nil {
+			return domain.ErrKnowledgeBaseNotFound
+		}
+
+		// 通过聚合根重命名（会收集 FolderRenamedEvent）
+		if err := kb.RenameFolder(folderID, cmd.Name); err != nil {
+			return err
+		}
+
+		folder, err := kb.GetFolder(folderID)
+		if err != nil {
+			return err
+		}
+
+		if err := h.folderRepo.Save(txCtx, folder); err != nil {
+			return err
+		}
+
+		if err := h.kbRepo.Save(txCtx, kb); err != nil {
+			return err
+		}
+
+		result = dto.FolderFromEntity(folder)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// 事务成功后发布事件
+	if h.eventPublisher != nil {
+		events := kb.PullEvents()
+		if len(events) > 0 {
+			_ = h.eventPublisher.PublishAll(ctx, events)
+		}
+	}
+
+	return result, nil
+}
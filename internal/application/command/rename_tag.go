@@ -0,0 +1,114 @@
+package command
+
+import (
+	"context"
+
+	"gozero-ddd/internal/application/auth"
+	"gozero-ddd/internal/application/dto"
+	"gozero-ddd/internal/domain"
+	"gozero-ddd/internal/domain/entity"
+	"gozero-ddd/internal/domain/event"
+	"gozero-ddd/internal/domain/repository"
+	"gozero-ddd/internal/domain/valueobject"
+)
+
+// RenameTagCommand 重命名标签命令
+type RenameTagCommand struct {
+	KnowledgeBaseID string `json:"knowledge_base_id"`
+	From            string `json:"from"` // 原标签（规范名称或别名）
+	To              string `json:"to"`   // 新标签名称
+}
+
+// RenameTagHandler 重命名标签命令处理器
+// 标签定义和所有使用该标签的文档在同一事务中改写
+type RenameTagHandler struct {
+	unitOfWork     repository.UnitOfWork
+	kbRepo         repository.KnowledgeBaseRepository
+	docRepo        repository.DocumentRepository
+	tagRepo        repository.TagRepository
+	eventPublisher event.EventPublisher
+	permissions    *auth.PermissionChecker // 权限检查器
+}
+
+// NewRenameTagHandler 创建处理器
+func NewRenameTagHandler(
+	uow repository.UnitOfWork,
+	kbRepo repository.KnowledgeBaseRepository,
+	docRepo repository.DocumentRepository,
+	tagRepo repository.TagRepository,
+	ep event.EventPublisher,
+	permissions *auth.PermissionChecker,
+) *RenameTagHandler {
+	return &RenameTagHandler{
+		unitOfWork:     uow,
+		kbRepo:         kbRepo,
+		docRepo:        docRepo,
+		tagRepo:        tagRepo,
+		eventPublisher: ep,
+		permissions:    permissions,
+	}
+}
+
+// Handle 处理重命名标签命令
+func (h *RenameTagHandler) Handle(ctx context.Context, cmd *RenameTagCommand) (*dto.TagChangeResultDTO, error) {
+	// 验证 ID 格式
+	kbID, err := valueobject.KnowledgeBaseIDFromString(cmd.KnowledgeBaseID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 检查调用方权限
+	if err := h.permissions.Check(ctx, kbID, valueobject.MemberRoleEditor); err != nil {
+		return nil, err
+	}
+
+	to, err := valueobject.NewTag(cmd.To)
+	if err != nil {
+		return nil, err
+	}
+
+	var kb *entity.KnowledgeBase
+	var result *dto.TagChangeResultDTO
+
+	err = h.unitOfWork.Transaction(ctx, func(txCtx context.Context) error {
+		// 查找知识库
+		var err error
+		kb, err = h.kbRepo.FindByID(txCtx, kbID)
+		if err != nil {
+			return err
+		}
+		if kb == nil {
+			return domain.ErrKnowledgeBaseNotFound
+		}
+
+		// 通过聚合根重命名标签（会收集 TagRenamedEvent）
+		docs, err := kb.RenameTag(cmd.From, to.String())
+		if err != nil {
+			return err
+		}
+
+		if err := saveTagChanges(txCtx, h.docRepo, h.tagRepo, kb, docs); err != nil {
+			return err
+		}
+
+		if err := h.kbRepo.Save(txCtx, kb); err != nil {
+			return err
+		}
+
+		result = tagChangeResult(kb, kb.LookupTag(to), docs)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// 事务成功后发布事件
+	if h.eventPublisher != nil {
+		events := kb.PullEvents()
+		if len(events) > 0 {
+			_ = h.eventPublisher.PublishAll(ctx, events)
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,409 @@
+package command
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"strings"
+
+	"gozero-ddd/internal/application/auth"
+	"gozero-ddd/internal/application/dto"
+	"gozero-ddd/internal/domain"
+	"gozero-ddd/internal/domain/entity"
+	"gozero-ddd/internal/domain/event"
+	"gozero-ddd/internal/domain/repository"
+	"gozero-ddd/internal/domain/service"
+	"gozero-ddd/internal/domain/valueobject"
+)
+
+// MaxBackupDocumentsBytes 备份包中 documents.jsonl 解压后的最大字节数
+// 恢复时所有文档都会加载到内存中，该上限同时限制了单行文档的大小
+const MaxBackupDocumentsBytes = 512 << 20
+
+// RestoreBackupCommand 从备份包恢复知识库命令
+// 备份包由导出知识库（backup 格式）生成，恢复后ID、状态、评审记录和创建时间与导出时一致
+type RestoreBackupCommand struct {
+	Archive io.ReaderAt `json:"-"` // 备份包（zip）
+	Size    int64       `json:"-"` // 备份包大小（字节）
+}
+
+// RestoreBackupHandler 从备份包恢复知识库命令处理器
+// 知识库ID或名称已存在时拒绝恢复，不会覆盖现有数据
+type RestoreBackupHandler struct {
+	unitOfWork        repository.UnitOfWork
+	attRepo           repository.AttachmentRepository
+	knowledgeService  *service.KnowledgeService
+	attachmentService *service.AttachmentService
+	linkService       *service.LinkService
+	maxBlobBytes      int64
+	eventPublisher    event.EventPublisher
+	permissions       *auth.PermissionChecker // 权限检查器
+}
+
+// NewRestoreBackupHandler 创建处理器
+// maxBlobBytes 为单个附件内容的最大字节数，与附件上传限制一致
+func NewRestoreBackupHandler(
+	uow repository.UnitOfWork,
+	attRepo repository.AttachmentRepository,
+	ks *service.KnowledgeService,
+	attachmentService *service.AttachmentService,
+	linkService *service.LinkService,
+	maxBlobBytes int64,
+	ep event.EventPublisher,
+	permissions *auth.PermissionChecker,
+) *RestoreBackupHandler {
+	return &RestoreBackupHandler{
+		unitOfWork:        uow,
+		attRepo:           attRepo,
+		knowledgeService:  ks,
+		attachmentService: attachmentService,
+		linkService:       linkService,
+		maxBlobBytes:      maxBlobBytes,
+		eventPublisher:    ep,
+		permissions:       permissions,
+	}
+}
+
+// Handle 处理恢复命令
+// 附件内容在事务之前写入 BlobStore；事务失败时回收未被引用的内容
+func (h *RestoreBackupHandler) Handle(ctx context.Context, cmd *RestoreBackupCommand) (*dto.KnowledgeBaseDTO, error) {
+	// 检查调用方权限
+	if err := h.permissions.CheckCreate(ctx); err != nil {
+		return nil, err
+	}
+
+	zr, err := zip.NewReader(cmd.Archive, cmd.Size)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalidBackup, err)
+	}
+	files := make(map[string]*zip.File, len(zr.File))
+	for _, f := range zr.File {
+		files[f.Name] = f
+	}
+
+	var manifest dto.BackupManifest
+	if err := readBackupJSON(files, dto.BackupManifestFile, &manifest); err != nil {
+		return nil, err
+	}
+	if manifest.Format != dto.BackupFormat {
+		return nil, fmt.Errorf("%w: unknown format %q", domain.ErrInvalidBackup, manifest.Format)
+	}
+	if manifest.Version < 1 || manifest.Version > dto.BackupVersion {
+		return nil, domain.ErrUnsupportedBackupVersion
+	}
+
+	kb, err := buildKnowledgeBaseFromBackup(files)
+	if err != nil {
+		return nil, err
+	}
+
+	// 校验并写入附件内容
+	stored, err := h.storeBlobs(ctx, files, kb.Attachments())
+	if err != nil {
+		h.releaseBlobs(ctx, stored)
+		return nil, err
+	}
+
+	err = h.unitOfWork.Transaction(ctx, func(txCtx context.Context) error {
+		if err := h.knowledgeService.ImportKnowledgeBase(txCtx, kb); err != nil {
+			return err
+		}
+		for _, att := range kb.Attachments() {
+			if err := h.attRepo.Save(txCtx, att); err != nil {
+				return err
+			}
+		}
+		// 重建文档之间的链接
+		for _, doc := range kb.Documents() {
+			if err := h.linkService.RefreshLinks(txCtx, doc); err != nil {
+				return err
+			}
+		}
+		// 恢复者成为知识库的所有者
+		return h.permissions.GrantCreator(txCtx, kb.ID())
+	})
+	if err != nil {
+		h.releaseBlobs(ctx, stored)
+		return nil, err
+	}
+
+	// 事务成功后发布事件
+	if h.eventPublisher != nil {
+		events := kb.PullEvents()
+		if len(events) > 0 {
+			_ = h.eventPublisher.PublishAll(ctx, events)
+		}
+	}
+
+	return dto.KnowledgeBaseFromEntity(kb, false), nil
+}
+
+// storeBlobs 校验附件内容的哈希和大小并写入 BlobStore，返回已写入的哈希
+func (h *RestoreBackupHandler) storeBlobs(ctx context.Context, files map[string]*zip.File, atts []*entity.Attachment) ([]valueobject.ContentHash, error) {
+	stored := make([]valueobject.ContentHash, 0, len(atts))
+	seen := make(map[valueobject.ContentHash]bool, len(atts))
+	for _, att := range atts {
+		hash := att.ContentHash()
+		if seen[hash] {
+			continue
+		}
+		seen[hash] = true
+
+		f, ok := files[dto.BackupBlobDir+hash.String()]
+		if !ok {
+			return stored, fmt.Errorf("%w: missing content of attachment %s", domain.ErrInvalidBackup, att.ID())
+		}
+		content, err := readBackupEntry(f, h.maxBlobBytes)
+		if err != nil {
+			return stored, err
+		}
+		if int64(len(content)) != att.Size() || valueobject.ComputeContentHash(content) != hash {
+			return stored, fmt.Errorf("%w: content of attachment %s does not match its hash", domain.ErrInvalidBackup, att.ID())
+		}
+
+		if _, err := h.attachmentService.StoreContent(ctx, content); err != nil {
+			return stored, err
+		}
+		stored = append(stored, hash)
+	}
+	return stored, nil
+}
+
+// releaseBlobs 回收恢复失败时写入的附件内容，失败只记录日志
+func (h *RestoreBackupHandler) releaseBlobs(ctx context.Context, hashes []valueobject.ContentHash) {
+	if len(hashes) == 0 {
+		return
+	}
+	if err := h.attachmentService.ReleaseContent(ctx, hashes...); err != nil {
+		log.Printf("[RestoreBackup] 回收附件内容失败: %v", err)
+	}
+}
+
+// buildKnowledgeBaseFromBackup 读取备份包中的数据并构建知识库聚合根
+func buildKnowledgeBaseFromBackup(files map[string]*zip.File) (*entity.KnowledgeBase, error) {
+	var bkb dto.BackupKnowledgeBase
+	if err := readBackupJSON(files, dto.BackupKnowledgeBaseFile, &bkb); err != nil {
+		return nil, err
+	}
+	kbID, err := valueobject.KnowledgeBaseIDFromString(bkb.ID)
+	if err != nil {
+		return nil, backupFieldError("knowledge base id", err)
+	}
+	kbStatus, err := valueobject.KnowledgeBaseStatusFromString(bkb.Status)
+	if err != nil {
+		return nil, backupFieldError("knowledge base status", err)
+	}
+	dupPolicy := valueobject.DuplicatePolicyAllow
+	if bkb.DuplicatePolicy != "" {
+		if dupPolicy, err = valueobject.DuplicatePolicyFromString(bkb.DuplicatePolicy); err != nil {
+			return nil, backupFieldError("knowledge base duplicate policy", err)
+		}
+	}
+
+	var bfolders []*dto.BackupFolder
+	if err := readBackupJSON(files, dto.BackupFoldersFile, &bfolders); err != nil {
+		return nil, err
+	}
+	folders := make([]*entity.Folder, 0, len(bfolders))
+	for _, bf := range bfolders {
+		id, err := valueobject.FolderIDFromString(bf.ID)
+		if err != nil {
+			return nil, backupFieldError("folder id", err)
+		}
+		parentID, err := optionalFolderID(bf.ParentID)
+		if err != nil {
+			return nil, backupFieldError("folder parent id", err)
+		}
+		if strings.TrimSpace(bf.Name) == "" {
+			return nil, backupFieldError("folder name", domain.ErrFolderNameEmpty)
+		}
+		folders = append(folders, entity.ReconstructFolder(id, kbID, parentID, bf.Name, bf.CreatedAt, bf.UpdatedAt))
+	}
+
+	var btags []*dto.BackupTag
+	if err := readBackupJSON(files, dto.BackupTagsFile, &btags); err != nil {
+		return nil, err
+	}
+	tags := make([]*entity.TagDefinition, 0, len(btags))
+	for _, bt := range btags {
+		id, err := valueobject.TagIDFromString(bt.ID)
+		if err != nil {
+			return nil, backupFieldError("tag id", err)
+		}
+		name, err := valueobject.NewTag(bt.Name)
+		if err != nil {
+			return nil, backupFieldError("tag name", err)
+		}
+		aliases := make([]valueobject.Tag, 0, len(bt.Aliases))
+		for _, a := range bt.Aliases {
+			alias, err := valueobject.NewTag(a)
+			if err != nil {
+				return nil, backupFieldError("tag alias", err)
+			}
+			aliases = append(aliases, alias)
+		}
+		var parentID *valueobject.TagID
+		if bt.ParentID != "" {
+			pid, err := valueobject.TagIDFromString(bt.ParentID)
+			if err != nil {
+				return nil, backupFieldError("tag parent id", err)
+			}
+			parentID = &pid
+		}
+		tags = append(tags, entity.ReconstructTagDefinition(id, kbID, name, aliases, parentID, bt.CreatedAt, bt.UpdatedAt))
+	}
+
+	documents, err := readBackupDocuments(files, kbID, MaxBackupDocumentsBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	var batts []*dto.BackupAttachment
+	if err := readBackupJSON(files, dto.BackupAttachmentsFile, &batts); err != nil {
+		return nil, err
+	}
+	attachments := make([]*entity.Attachment, 0, len(batts))
+	for _, ba := range batts {
+		id, err := valueobject.AttachmentIDFromString(ba.ID)
+		if err != nil {
+			return nil, backupFieldError("attachment id", err)
+		}
+		docID, err := valueobject.DocumentIDFromString(ba.DocumentID)
+		if err != nil {
+			return nil, backupFieldError("attachment document id", err)
+		}
+		hash, err := valueobject.ContentHashFromString(ba.ContentHash)
+		if err != nil {
+			return nil, backupFieldError("attachment content hash", err)
+		}
+		if ba.FileName == "" || !entity.IsAllowedAttachmentType(ba.MediaType) || ba.Size < 0 {
+			return nil, fmt.Errorf("%w: invalid attachment %s", domain.ErrInvalidBackup, ba.ID)
+		}
+		attachments = append(attachments, entity.ReconstructAttachment(id, kbID, docID, ba.FileName, ba.MediaType, ba.Size, hash, ba.CreatedAt))
+	}
+
+	return entity.ImportKnowledgeBase(kbID, bkb.Name, bkb.Description, kbStatus, dupPolicy, documents, folders, tags, attachments, bkb.CreatedAt, bkb.UpdatedAt)
+}
+
+// readBackupDocuments 逐行读取 documents.jsonl，解压后超过 maxBytes 时返回 ErrInvalidBackup
+func readBackupDocuments(files map[string]*zip.File, kbID valueobject.KnowledgeBaseID, maxBytes int64) ([]*entity.Document, error) {
+	f, ok := files[dto.BackupDocumentsFile]
+	if !ok {
+		return nil, fmt.Errorf("%w: missing %s", domain.ErrInvalidBackup, dto.BackupDocumentsFile)
+	}
+	rc, err := f.Open()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalidBackup, err)
+	}
+	defer rc.Close()
+
+	// 多读一个字节用于判断是否超出上限，超出时截断的内容不能当作完整的文件处理
+	limited := &io.LimitedReader{R: rc, N: maxBytes + 1}
+	tooLarge := func() error {
+		return fmt.Errorf("%w: %s exceeds %d bytes", domain.ErrInvalidBackup, dto.BackupDocumentsFile, maxBytes)
+	}
+
+	documents := make([]*entity.Document, 0)
+	dec := json.NewDecoder(limited)
+	for {
+		var bd dto.BackupDocument
+		if err := dec.Decode(&bd); err == io.EOF {
+			break
+		} else if err != nil {
+			if limited.N <= 0 {
+				return nil, tooLarge()
+			}
+			return nil, fmt.Errorf("%w: %s: %v", domain.ErrInvalidBackup, dto.BackupDocumentsFile, err)
+		}
+
+		id, err := valueobject.DocumentIDFromString(bd.ID)
+		if err != nil {
+			return nil, backupFieldError("document id", err)
+		}
+		folderID, err := optionalFolderID(bd.FolderID)
+		if err != nil {
+			return nil, backupFieldError("document folder id", err)
+		}
+		if strings.TrimSpace(bd.Title) == "" {
+			return nil, backupFieldError("document title", domain.ErrDocumentTitleEmpty)
+		}
+		contentType, err := valueobject.ContentTypeFromString(bd.ContentType)
+		if err != nil {
+			return nil, backupFieldError("document content type", err)
+		}
+		status, err := valueobject.DocumentStatusFromString(bd.Status)
+		if err != nil {
+			return nil, backupFieldError("document status", err)
+		}
+		tags := bd.Tags
+		if tags == nil {
+			tags = make([]string, 0)
+		}
+
+		documents = append(documents, entity.ReconstructDocument(
+			id, kbID, folderID,
+			bd.Title, bd.Content, contentType, tags,
+			status, bd.ReviewComments,
+			bd.PublishAt, bd.ExpireAt,
+			bd.CreatedAt, bd.UpdatedAt, nil,
+		))
+	}
+	if limited.N <= 0 {
+		return nil, tooLarge()
+	}
+	return documents, nil
+}
+
+// readBackupJSON 读取备份包中的 JSON 文件
+func readBackupJSON(files map[string]*zip.File, name string, v interface{}) error {
+	f, ok := files[name]
+	if !ok {
+		return fmt.Errorf("%w: missing %s", domain.ErrInvalidBackup, name)
+	}
+	content, err := readBackupEntry(f, MaxImportFileBytes*16)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(content, v); err != nil {
+		return fmt.Errorf("%w: %s: %v", domain.ErrInvalidBackup, name, err)
+	}
+	return nil
+}
+
+// readBackupEntry 读取备份包中的一个文件，按实际解压字节数限制大小
+func readBackupEntry(f *zip.File, maxBytes int64) ([]byte, error) {
+	rc, err := f.Open()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalidBackup, err)
+	}
+	defer rc.Close()
+
+	content, err := io.ReadAll(io.LimitReader(rc, maxBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s: %v", domain.ErrInvalidBackup, f.Name, err)
+	}
+	if int64(len(content)) > maxBytes {
+		return nil, fmt.Errorf("%w: %s exceeds %d bytes", domain.ErrInvalidBackup, f.Name, maxBytes)
+	}
+	return content, nil
+}
+
+// optionalFolderID 解析可为空的文件夹ID，空字符串表示根目录
+func optionalFolderID(s string) (*valueobject.FolderID, error) {
+	if s == "" {
+		return nil, nil
+	}
+	id, err := valueobject.FolderIDFromString(s)
+	if err != nil {
+		return nil, err
+	}
+	return &id, nil
+}
+
+// backupFieldError 包装备份数据中字段的校验错误
+func backupFieldError(field string, err error) error {
+	return fmt.Errorf("%w: %s: %v", domain.ErrInvalidBackup, field, err)
+}
@@ -0,0 +1,95 @@
+package command
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"gozero-ddd/internal/application/dto"
+	"gozero-ddd/internal/domain"
+	"gozero-ddd/internal/domain/valueobject"
+)
+
+// backupDocumentLine 生成 documents.jsonl 中的一行（不含换行符）
+func backupDocumentLine(t *testing.T, id, content string) string {
+	t.Helper()
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	line, err := json.Marshal(dto.BackupDocument{
+		ID:          id,
+		Title:       "doc " + id[:4],
+		Content:     content,
+		ContentType: "markdown",
+		Tags:        []string{},
+		Status:      "published",
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(line)
+}
+
+// backupFiles 构造只包含 documents.jsonl 的备份包文件索引
+func backupFiles(t *testing.T, documents string) map[string]*zip.File {
+	t.Helper()
+	r := buildZip(t, zipEntry{dto.BackupDocumentsFile, documents})
+	zr, err := zip.NewReader(r, r.Size())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return map[string]*zip.File{dto.BackupDocumentsFile: zr.File[0]}
+}
+
+func TestReadBackupDocumentsLimit(t *testing.T) {
+	kbID := valueobject.NewKnowledgeBaseID()
+	line1 := backupDocumentLine(t, "11111111-1111-4111-8111-111111111111", "first")
+	line2 := backupDocumentLine(t, "22222222-2222-4222-8222-222222222222", "second")
+	content := line1 + "\n" + line2 + "\n"
+	size := int64(len(content))
+
+	tests := []struct {
+		name     string
+		content  string
+		maxBytes int64
+		wantDocs int
+		wantErr  string
+	}{
+		{"within limit", content, size + 100, 2, ""},
+		{"exactly at limit", content, size, 2, ""},
+		{"one byte over limit", content, size - 1, 0, "exceeds"},
+		// 截断处恰好是完整的一行，不能当作只有一篇文档的备份
+		{"truncated at line boundary", content, int64(len(line1)), 0, "exceeds"},
+		{"single huge line", backupDocumentLine(t, "33333333-3333-4333-8333-333333333333", strings.Repeat("x", 4096)), 1024, 0, "exceeds"},
+		{"highly compressed padding", content + strings.Repeat(" ", 1<<20), 1 << 16, 0, "exceeds"},
+		{"malformed line", "{not json}\n", 1024, 0, "documents.jsonl"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			docs, err := readBackupDocuments(backupFiles(t, tt.content), kbID, tt.maxBytes)
+			if tt.wantErr != "" {
+				if !errors.Is(err, domain.ErrInvalidBackup) || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("readBackupDocuments() error = %v, want %v containing %q", err, domain.ErrInvalidBackup, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("readBackupDocuments() error = %v", err)
+			}
+			if len(docs) != tt.wantDocs {
+				t.Errorf("readBackupDocuments() returned %d documents, want %d", len(docs), tt.wantDocs)
+			}
+		})
+	}
+}
+
+func TestReadBackupDocumentsMissingFile(t *testing.T) {
+	_, err := readBackupDocuments(map[string]*zip.File{}, valueobject.NewKnowledgeBaseID(), MaxBackupDocumentsBytes)
+	if !errors.Is(err, domain.ErrInvalidBackup) {
+		t.Errorf("error = %v, want %v", err, domain.ErrInvalidBackup)
+	}
+}
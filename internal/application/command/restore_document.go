@@ -0,0 +1,127 @@
+package command
+
+import (
+	"context"
+
+	"gozero-ddd/internal/application/auth"
+	"gozero-ddd/internal/application/dto"
+	"gozero-ddd/internal/domain"
+	"gozero-ddd/internal/domain/entity"
+	"gozero-ddd/internal/domain/event"
+	"gozero-ddd/internal/domain/repository"
+	"gozero-ddd/internal/domain/service"
+	"gozero-ddd/internal/domain/valueobject"
+)
+
+// RestoreDocumentCommand 从回收站恢复文档命令
+type RestoreDocumentCommand struct {
+	KnowledgeBaseID string `json:"knowledge_base_id"`
+	DocumentID      string `json:"document_id"`
+}
+
+// RestoreDocumentHandler 恢复文档命令处理器
+// 文档所属的知识库必须处于正常状态（不在回收站中），知识库或租户已超出配额时不允许恢复
+type RestoreDocumentHandler struct {
+	unitOfWork     repository.UnitOfWork
+	kbRepo         repository.KnowledgeBaseRepository
+	docRepo        repository.DocumentRepository
+	quotaService   *service.QuotaService
+	eventPublisher event.EventPublisher
+	permissions    *auth.PermissionChecker // 权限检查器
+}
+
+// NewRestoreDocumentHandler 创建处理器
+func NewRestoreDocumentHandler(
+	uow repository.UnitOfWork,
+	kbRepo repository.KnowledgeBaseRepository,
+	docRepo repository.DocumentRepository,
+	quotaService *service.QuotaService,
+	ep event.EventPublisher,
+	permissions *auth.PermissionChecker,
+) *RestoreDocumentHandler {
+	return &RestoreDocumentHandler{
+		unitOfWork:     uow,
+		kbRepo:         kbRepo,
+		docRepo:        docRepo,
+		quotaService:   quotaService,
+		eventPublisher: ep,
+		permissions:    permissions,
+	}
+}
+
+// Handle 处理恢复文档命令
+func (h *RestoreDocumentHandler) Handle(ctx context.Context, cmd *RestoreDocumentCommand) (*dto.DocumentDTO, error) {
+	// 验证 ID 格式
+	kbID, err := valueobject.KnowledgeBaseIDFromString(cmd.KnowledgeBaseID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 检查调用方权限
+	if err := h.permissions.Check(ctx, kbID, valueobject.MemberRoleEditor); err != nil {
+		return nil, err
+	}
+
+	docID, err := valueobject.DocumentIDFromString(cmd.DocumentID)
+	if err != nil {
+		return nil, err
+	}
+
+	var kb *entity.KnowledgeBase
+	var result *dto.DocumentDTO
+
+	err = h.unitOfWork.Transaction(ctx, func(txCtx context.Context) error {
+		// 查找知识库（回收站中的知识库需要先恢复知识库）
+		var err error
+		kb, err = h.kbRepo.FindByID(txCtx, kbID)
+		if err != nil {
+			return err
+		}
+		if kb == nil {
+			return domain.ErrKnowledgeBaseNotFound
+		}
+
+		// 在回收站中查找文档
+		doc, err := h.docRepo.FindDeletedByID(txCtx, docID)
+		if err != nil {
+			return err
+		}
+		if doc == nil {
+			return domain.ErrDocumentNotFound
+		}
+
+		// 设置租户配额，由聚合根在恢复文档时校验
+		if err := h.quotaService.ApplyTo(txCtx, kb); err != nil {
+			return err
+		}
+
+		// 通过聚合根恢复文档（会校验归属并收集 DocumentRestoredEvent）
+		if err := kb.RestoreDocument(doc); err != nil {
+			return err
+		}
+
+		if err := h.docRepo.Restore(txCtx, docID); err != nil {
+			return err
+		}
+
+		if err := h.kbRepo.Save(txCtx, kb); err != nil {
+			return err
+		}
+
+		result = dto.DocumentFromEntity(doc)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// 事务成功后发布事件
+	if h.eventPublisher != nil {
+		events := kb.PullEvents()
+		if len(events) > 0 {
+			_ = h.eventPublisher.PublishAll(ctx, events)
+		}
+	}
+
+	return result, nil
+}
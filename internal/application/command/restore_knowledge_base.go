@@ -0,0 +1,98 @@
+package command
+
+import (
+	"context"
+
+	"gozero-ddd/internal/application/auth"
+	"gozero-ddd/internal/application/dto"
+	"gozero-ddd/internal/domain"
+	"gozero-ddd/internal/domain/entity"
+	"gozero-ddd/internal/domain/event"
+	"gozero-ddd/internal/domain/repository"
+	"gozero-ddd/internal/domain/valueobject"
+)
+
+// RestoreKnowledgeBaseCommand 从回收站恢复知识库命令
+type RestoreKnowledgeBaseCommand struct {
+	ID string `json:"id"`
+}
+
+// RestoreKnowledgeBaseHandler 恢复知识库命令处理器
+// 恢复知识库时，一并恢复随知识库一起移入回收站的文档
+// 在知识库删除之前就已单独删除的文档仍保留在回收站中
+type RestoreKnowledgeBaseHandler struct {
+	unitOfWork     repository.UnitOfWork
+	kbRepo         repository.KnowledgeBaseRepository
+	docRepo        repository.DocumentRepository
+	eventPublisher event.EventPublisher
+	permissions    *auth.PermissionChecker // 权限检查器
+}
+
+// NewRestoreKnowledgeBaseHandler 创建处理器
+func NewRestoreKnowledgeBaseHandler(
+	uow repository.UnitOfWork,
+	kbRepo repository.KnowledgeBaseRepository,
+	docRepo repository.DocumentRepository,
+	ep event.EventPublisher,
+	permissions *auth.PermissionChecker,
+) *RestoreKnowledgeBaseHandler {
+	return &RestoreKnowledgeBaseHandler{
+		unitOfWork:     uow,
+		kbRepo:         kbRepo,
+		docRepo:        docRepo,
+		eventPublisher: ep,
+		permissions:    permissions,
+	}
+}
+
+// Handle 处理恢复知识库命令
+func (h *RestoreKnowledgeBaseHandler) Handle(ctx context.Context, cmd *RestoreKnowledgeBaseCommand) (*dto.KnowledgeBaseDTO, error) {
+	// 验证 ID 格式
+	kbID, err := valueobject.KnowledgeBaseIDFromString(cmd.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 检查调用方权限
+	if err := h.permissions.Check(ctx, kbID, valueobject.MemberRoleOwner); err != nil {
+		return nil, err
+	}
+
+	var kb *entity.KnowledgeBase
+
+	err = h.unitOfWork.Transaction(ctx, func(txCtx context.Context) error {
+		// 在回收站中查找知识库
+		var err error
+		kb, err = h.kbRepo.FindDeletedByID(txCtx, kbID)
+		if err != nil {
+			return err
+		}
+		if kb == nil {
+			return domain.ErrKnowledgeBaseNotFound
+		}
+
+		// 通过聚合根恢复（会收集 KnowledgeBaseRestoredEvent）
+		if err := kb.Restore(); err != nil {
+			return err
+		}
+
+		if err := h.kbRepo.Restore(txCtx, kbID); err != nil {
+			return err
+		}
+
+		return h.docRepo.RestoreByKnowledgeBaseID(txCtx, kbID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// 事务成功后发布事件
+	if h.eventPublisher != nil {
+		events := kb.PullEvents()
+		if len(events) > 0 {
+			_ = h.eventPublisher.PublishAll(ctx, events)
+		}
+	}
+
+	return dto.KnowledgeBaseFromEntity(kb, false), nil
+}
@@ -0,0 +1,70 @@
+package command
+
+import (
+	"context"
+	"time"
+
+	"gozero-ddd/internal/application/auth"
+	"gozero-ddd/internal/application/dto"
+	"gozero-ddd/internal/domain"
+	"gozero-ddd/internal/domain/event"
+	"gozero-ddd/internal/domain/repository"
+)
+
+// RevokeAPIKeyCommand 吊销 API Key 命令
+type RevokeAPIKeyCommand struct {
+	ID string `json:"id"`
+}
+
+// RevokeAPIKeyHandler 吊销 API Key 命令处理器
+type RevokeAPIKeyHandler struct {
+	apiKeyRepo     repository.APIKeyRepository
+	eventPublisher event.EventPublisher
+	permissions    *auth.PermissionChecker // 权限检查器
+}
+
+// NewRevokeAPIKeyHandler 创建处理器
+func NewRevokeAPIKeyHandler(
+	apiKeyRepo repository.APIKeyRepository,
+	ep event.EventPublisher,
+	permissions *auth.PermissionChecker,
+) *RevokeAPIKeyHandler {
+	return &RevokeAPIKeyHandler{
+		apiKeyRepo:     apiKeyRepo,
+		eventPublisher: ep,
+		permissions:    permissions,
+	}
+}
+
+// Handle 处理吊销 API Key 命令
+// 吊销后保留记录以便审计，使用该密钥的请求立即被拒绝
+func (h *RevokeAPIKeyHandler) Handle(ctx context.Context, cmd *RevokeAPIKeyCommand) (*dto.APIKeyDTO, error) {
+	// 检查调用方权限
+	if err := h.permissions.RequireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	key, err := h.apiKeyRepo.FindByID(ctx, cmd.ID)
+	if err != nil {
+		return nil, err
+	}
+	if key == nil {
+		return nil, domain.ErrAPIKeyNotFound
+	}
+	if key.RevokedAt != nil {
+		return nil, domain.ErrAPIKeyAlreadyRevoked
+	}
+
+	now := time.Now()
+	key.RevokedAt = &now
+	if err := h.apiKeyRepo.Save(ctx, key); err != nil {
+		return nil, err
+	}
+
+	// 持久化成功后发布事件
+	if h.eventPublisher != nil {
+		_ = h.eventPublisher.Publish(ctx, event.NewAPIKeyRevokedEvent(key.ID, key.Name))
+	}
+
+	return dto.APIKeyFromRecord(key, now), nil
+}
@@ -0,0 +1,106 @@
+package command
+
+import (
+	"context"
+	"strings"
+
+	"gozero-ddd/internal/application/auth"
+	"gozero-ddd/internal/domain"
+	"gozero-ddd/internal/domain/event"
+	"gozero-ddd/internal/domain/repository"
+	"gozero-ddd/internal/domain/valueobject"
+)
+
+// RevokeKnowledgeBaseRoleCommand 移除知识库成员命令
+type RevokeKnowledgeBaseRoleCommand struct {
+	KnowledgeBaseID string `json:"knowledge_base_id"`
+	Subject         string `json:"subject"` // 被移除的调用方标识
+}
+
+// RevokeKnowledgeBaseRoleHandler 移除知识库成员命令处理器
+type RevokeKnowledgeBaseRoleHandler struct {
+	unitOfWork     repository.UnitOfWork
+	kbRepo         repository.KnowledgeBaseRepository
+	memberRepo     repository.KnowledgeBaseMemberRepository
+	eventPublisher event.EventPublisher
+	permissions    *auth.PermissionChecker // 权限检查器
+}
+
+// NewRevokeKnowledgeBaseRoleHandler 创建处理器
+func NewRevokeKnowledgeBaseRoleHandler(
+	uow repository.UnitOfWork,
+	kbRepo repository.KnowledgeBaseRepository,
+	memberRepo repository.KnowledgeBaseMemberRepository,
+	ep event.EventPublisher,
+	permissions *auth.PermissionChecker,
+) *RevokeKnowledgeBaseRoleHandler {
+	return &RevokeKnowledgeBaseRoleHandler{
+		unitOfWork:     uow,
+		kbRepo:         kbRepo,
+		memberRepo:     memberRepo,
+		eventPublisher: ep,
+		permissions:    permissions,
+	}
+}
+
+// Handle 处理移除知识库成员命令
+// 只有所有者可以管理成员；移除最后一个所有者时返回 ErrLastKnowledgeBaseOwner
+func (h *RevokeKnowledgeBaseRoleHandler) Handle(ctx context.Context, cmd *RevokeKnowledgeBaseRoleCommand) error {
+	// 验证 ID 格式
+	kbID, err := valueobject.KnowledgeBaseIDFromString(cmd.KnowledgeBaseID)
+	if err != nil {
+		return err
+	}
+
+	subject := strings.TrimSpace(cmd.Subject)
+	if subject == "" {
+		return domain.ErrMemberSubjectEmpty
+	}
+
+	// 检查调用方权限
+	if err := h.permissions.Check(ctx, kbID, valueobject.MemberRoleOwner); err != nil {
+		return err
+	}
+
+	var evt event.DomainEvent
+
+	err = h.unitOfWork.Transaction(ctx, func(txCtx context.Context) error {
+		kb, err := h.kbRepo.FindByID(txCtx, kbID)
+		if err != nil {
+			return err
+		}
+		if kb == nil {
+			return domain.ErrKnowledgeBaseNotFound
+		}
+
+		existing, err := h.memberRepo.Find(txCtx, kbID, subject)
+		if err != nil {
+			return err
+		}
+		if existing == nil {
+			return domain.ErrMemberNotFound
+		}
+		if existing.Role == valueobject.MemberRoleOwner {
+			if err := ensureOtherOwner(txCtx, h.memberRepo, kbID, subject); err != nil {
+				return err
+			}
+		}
+
+		if err := h.memberRepo.Delete(txCtx, kbID, subject); err != nil {
+			return err
+		}
+
+		evt = event.NewKnowledgeBaseMemberRevokedEvent(kbID, subject, existing.Role)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// 事务成功后发布事件
+	if h.eventPublisher != nil {
+		_ = h.eventPublisher.Publish(ctx, evt)
+	}
+
+	return nil
+}
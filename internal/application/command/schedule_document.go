@@ -0,0 +1,117 @@
+package command
+
+import (
+	"context"
+	"time"
+
+	"gozero-ddd/internal/application/auth"
+	"gozero-ddd/internal/application/dto"
+	"gozero-ddd/internal/domain"
+	"gozero-ddd/internal/domain/entity"
+	"gozero-ddd/internal/domain/event"
+	"gozero-ddd/internal/domain/repository"
+	"gozero-ddd/internal/domain/valueobject"
+)
+
+// ScheduleDocumentCommand 设置文档定时发布/下线命令
+type ScheduleDocumentCommand struct {
+	KnowledgeBaseID string     `json:"knowledge_base_id"`
+	DocumentID      string     `json:"document_id"`
+	PublishAt       *time.Time `json:"publish_at"` // 定时发布时间，nil 表示取消定时发布
+	ExpireAt        *time.Time `json:"expire_at"`  // 定时下线时间，nil 表示永不过期
+}
+
+// ScheduleDocumentHandler 设置文档定时发布/下线命令处理器
+type ScheduleDocumentHandler struct {
+	unitOfWork     repository.UnitOfWork
+	kbRepo         repository.KnowledgeBaseRepository
+	docRepo        repository.DocumentRepository
+	eventPublisher event.EventPublisher
+	permissions    *auth.PermissionChecker // 权限检查器
+}
+
+// NewScheduleDocumentHandler 创建处理器
+func NewScheduleDocumentHandler(
+	uow repository.UnitOfWork,
+	kbRepo repository.KnowledgeBaseRepository,
+	docRepo repository.DocumentRepository,
+	ep event.EventPublisher,
+	permissions *auth.PermissionChecker,
+) *ScheduleDocumentHandler {
+	return &ScheduleDocumentHandler{
+		unitOfWork:     uow,
+		kbRepo:         kbRepo,
+		docRepo:        docRepo,
+		eventPublisher: ep,
+		permissions:    permissions,
+	}
+}
+
+// Handle 处理设置文档定时发布/下线命令
+// 实际的发布和下线由定时任务在到期后执行
+func (h *ScheduleDocumentHandler) Handle(ctx context.Context, cmd *ScheduleDocumentCommand) (*dto.DocumentDTO, error) {
+	// 验证 ID 格式
+	kbID, err := valueobject.KnowledgeBaseIDFromString(cmd.KnowledgeBaseID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 检查调用方权限
+	if err := h.permissions.Check(ctx, kbID, valueobject.MemberRoleEditor); err != nil {
+		return nil, err
+	}
+
+	docID, err := valueobject.DocumentIDFromString(cmd.DocumentID)
+	if err != nil {
+		return nil, err
+	}
+
+	var kb *entity.KnowledgeBase
+	var result *dto.DocumentDTO
+
+	err = h.unitOfWork.Transaction(ctx, func(txCtx context.Context) error {
+		// 查找知识库
+		var err error
+		kb, err = h.kbRepo.FindByID(txCtx, kbID)
+		if err != nil {
+			return err
+		}
+		if kb == nil {
+			return domain.ErrKnowledgeBaseNotFound
+		}
+
+		// 通过聚合根设置定时（会收集 DocumentScheduledEvent）
+		if err := kb.ScheduleDocument(docID, cmd.PublishAt, cmd.ExpireAt); err != nil {
+			return err
+		}
+
+		doc, err := kb.GetDocument(docID)
+		if err != nil {
+			return err
+		}
+
+		if err := h.docRepo.Save(txCtx, doc); err != nil {
+			return err
+		}
+
+		if err := h.kbRepo.Save(txCtx, kb); err != nil {
+			return err
+		}
+
+		result = dto.DocumentFromEntity(doc)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// 事务成功后发布事件
+	if h.eventPublisher != nil {
+		events := kb.PullEvents()
+		if len(events) > 0 {
+			_ = h.eventPublisher.PublishAll(ctx, events)
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,136 @@
+package command
+
+import (
+	"context"
+
+	"gozero-ddd/internal/application/auth"
+	"gozero-ddd/internal/application/dto"
+	"gozero-ddd/internal/domain"
+	"gozero-ddd/internal/domain/entity"
+	"gozero-ddd/internal/domain/event"
+	"gozero-ddd/internal/domain/repository"
+	"gozero-ddd/internal/domain/service"
+	"gozero-ddd/internal/domain/valueobject"
+)
+
+// UpdateDocumentCommand 更新文档命令
+type UpdateDocumentCommand struct {
+	KnowledgeBaseID string   `json:"knowledge_base_id"`
+	DocumentID      string   `json:"document_id"`
+	Title           string   `json:"title"`
+	Content         string   `json:"content"`
+	ContentType     string   `json:"content_type"` // 为空时保留原内容类型
+	Tags            []string `json:"tags"`         // 为 nil 时保留原标签
+}
+
+// UpdateDocumentHandler 更新文档命令处理器
+// 更新内容后会重建文档的链接
+type UpdateDocumentHandler struct {
+	unitOfWork     repository.UnitOfWork
+	kbRepo         repository.KnowledgeBaseRepository
+	docRepo        repository.DocumentRepository
+	linkService    *service.LinkService
+	quotaService   *service.QuotaService
+	eventPublisher event.EventPublisher
+	permissions    *auth.PermissionChecker // 权限检查器
+}
+
+// NewUpdateDocumentHandler 创建处理器
+func NewUpdateDocumentHandler(
+	uow repository.UnitOfWork,
+	kbRepo repository.KnowledgeBaseRepository,
+	docRepo repository.DocumentRepository,
+	linkService *service.LinkService,
+	quotaService *service.QuotaService,
+	ep event.EventPublisher,
+	permissions *auth.PermissionChecker,
+) *UpdateDocumentHandler {
+	return &UpdateDocumentHandler{
+		unitOfWork:     uow,
+		kbRepo:         kbRepo,
+		docRepo:        docRepo,
+		linkService:    linkService,
+		quotaService:   quotaService,
+		eventPublisher: ep,
+		permissions:    permissions,
+	}
+}
+
+// Handle 处理更新文档命令
+func (h *UpdateDocumentHandler) Handle(ctx context.Context, cmd *UpdateDocumentCommand) (*dto.DocumentDTO, error) {
+	// 验证 ID 格式
+	kbID, err := valueobject.KnowledgeBaseIDFromString(cmd.KnowledgeBaseID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 检查调用方权限
+	if err := h.permissions.Check(ctx, kbID, valueobject.MemberRoleEditor); err != nil {
+		return nil, err
+	}
+
+	docID, err := valueobject.DocumentIDFromString(cmd.DocumentID)
+	if err != nil {
+		return nil, err
+	}
+
+	var kb *entity.KnowledgeBase
+	var result *dto.DocumentDTO
+
+	err = h.unitOfWork.Transaction(ctx, func(txCtx context.Context) error {
+		// 查找知识库
+		var err error
+		kb, err = h.kbRepo.FindByID(txCtx, kbID)
+		if err != nil {
+			return err
+		}
+		if kb == nil {
+			return domain.ErrKnowledgeBaseNotFound
+		}
+
+		// 设置租户配额，由聚合根在更新文档时校验
+		if err := h.quotaService.ApplyTo(txCtx, kb); err != nil {
+			return err
+		}
+
+		// 通过聚合根更新文档（会收集 DocumentUpdatedEvent）
+		doc, err := kb.UpdateDocument(docID, cmd.Title, cmd.Content, valueobject.ContentType(cmd.ContentType), cmd.Tags)
+		if err != nil {
+			return err
+		}
+
+		if err := h.docRepo.Save(txCtx, doc); err != nil {
+			return err
+		}
+
+		// 内容变化后重建链接
+		if err := h.linkService.RefreshLinks(txCtx, doc); err != nil {
+			return err
+		}
+
+		// 在同一事务中计入新占用的租户用量
+		if err := h.quotaService.RecordUsed(txCtx, kb); err != nil {
+			return err
+		}
+
+		if err := h.kbRepo.Save(txCtx, kb); err != nil {
+			return err
+		}
+
+		result = dto.DocumentFromEntity(doc)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// 事务成功后发布事件
+	if h.eventPublisher != nil {
+		events := kb.PullEvents()
+		if len(events) > 0 {
+			_ = h.eventPublisher.PublishAll(ctx, events)
+		}
+	}
+
+	return result, nil
+}
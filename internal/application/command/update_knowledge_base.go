@@ -3,6 +3,7 @@ package command
 import (
 	"context"
 
+	"gozero-ddd/internal/application/auth"
 	"gozero-ddd/internal/application/dto"
 	"gozero-ddd/internal/domain"
 	"gozero-ddd/internal/domain/event"
@@ -15,22 +16,27 @@ type UpdateKnowledgeBaseCommand struct {
 	ID          string `json:"id"`
 	Name        string `json:"name"`
 	Description string `json:"description"`
+	// DuplicatePolicy 重复文档策略：allow / reject_exact，为空时保持不变
+	DuplicatePolicy string `json:"duplicate_policy"`
 }
 
 // UpdateKnowledgeBaseHandler 更新知识库命令处理器
 type UpdateKnowledgeBaseHandler struct {
 	kbRepo         repository.KnowledgeBaseRepository
 	eventPublisher event.EventPublisher
+	permissions    *auth.PermissionChecker // 权限检查器
 }
 
 // NewUpdateKnowledgeBaseHandler 创建处理器
 func NewUpdateKnowledgeBaseHandler(
 	kbRepo repository.KnowledgeBaseRepository,
 	ep event.EventPublisher,
+	permissions *auth.PermissionChecker,
 ) *UpdateKnowledgeBaseHandler {
 	return &UpdateKnowledgeBaseHandler{
 		kbRepo:         kbRepo,
 		eventPublisher: ep,
+		permissions:    permissions,
 	}
 }
 
@@ -42,6 +48,11 @@ func (h *UpdateKnowledgeBaseHandler) Handle(ctx context.Context, cmd *UpdateKnow
 		return nil, err
 	}
 
+	// 检查调用方权限
+	if err := h.permissions.Check(ctx, kbID, valueobject.MemberRoleOwner); err != nil {
+		return nil, err
+	}
+
 	// 查找知识库
 	kb, err := h.kbRepo.FindByID(ctx, kbID)
 	if err != nil {
@@ -56,6 +67,17 @@ func (h *UpdateKnowledgeBaseHandler) Handle(ctx context.Context, cmd *UpdateKnow
 		return nil, err
 	}
 
+	// 变更重复文档策略（会收集 KnowledgeBaseDuplicatePolicyChangedEvent）
+	if cmd.DuplicatePolicy != "" {
+		policy, err := valueobject.DuplicatePolicyFromString(cmd.DuplicatePolicy)
+		if err != nil {
+			return nil, err
+		}
+		if err := kb.ChangeDuplicatePolicy(policy); err != nil {
+			return nil, err
+		}
+	}
+
 	// 保存
 	if err := h.kbRepo.Save(ctx, kb); err != nil {
 		return nil, err
@@ -0,0 +1,119 @@
+package command
+
+import (
+	"context"
+
+	"gozero-ddd/internal/application/auth"
+	"gozero-ddd/internal/application/dto"
+	"gozero-ddd/internal/domain"
+	"gozero-ddd/internal/domain/entity"
+	"gozero-ddd/internal/domain/event"
+	"gozero-ddd/internal/domain/repository"
+	"gozero-ddd/internal/domain/valueobject"
+)
+
+// UpdateTagCommand 更新标签定义命令
+type UpdateTagCommand struct {
+	KnowledgeBaseID string   `json:"knowledge_base_id"`
+	TagID           string   `json:"tag_id"`
+	ParentID        string   `json:"parent_id"` // 父标签ID，为空表示顶层标签
+	Aliases         []string `json:"aliases"`   // 别名列表（整体替换）
+}
+
+// UpdateTagHandler 更新标签定义命令处理器
+type UpdateTagHandler struct {
+	unitOfWork     repository.UnitOfWork
+	kbRepo         repository.KnowledgeBaseRepository
+	docRepo        repository.DocumentRepository
+	tagRepo        repository.TagRepository
+	eventPublisher event.EventPublisher
+	permissions    *auth.PermissionChecker // 权限检查器
+}
+
+// NewUpdateTagHandler 创建处理器
+func NewUpdateTagHandler(
+	uow repository.UnitOfWork,
+	kbRepo repository.KnowledgeBaseRepository,
+	docRepo repository.DocumentRepository,
+	tagRepo repository.TagRepository,
+	ep event.EventPublisher,
+	permissions *auth.PermissionChecker,
+) *UpdateTagHandler {
+	return &UpdateTagHandler{
+		unitOfWork:     uow,
+		kbRepo:         kbRepo,
+		docRepo:        docRepo,
+		tagRepo:        tagRepo,
+		eventPublisher: ep,
+		permissions:    permissions,
+	}
+}
+
+// Handle 处理更新标签定义命令
+func (h *UpdateTagHandler) Handle(ctx context.Context, cmd *UpdateTagCommand) (*dto.TagChangeResultDTO, error) {
+	// 验证 ID 格式
+	kbID, err := valueobject.KnowledgeBaseIDFromString(cmd.KnowledgeBaseID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 检查调用方权限
+	if err := h.permissions.Check(ctx, kbID, valueobject.MemberRoleEditor); err != nil {
+		return nil, err
+	}
+
+	tagID, err := valueobject.TagIDFromString(cmd.TagID)
+	if err != nil {
+		return nil, err
+	}
+
+	parentID, err := parseOptionalTagID(cmd.ParentID)
+	if err != nil {
+		return nil, err
+	}
+
+	var kb *entity.KnowledgeBase
+	var result *dto.TagChangeResultDTO
+
+	err = h.unitOfWork.Transaction(ctx, func(txCtx context.Context) error {
+		// 查找知识库
+		var err error
+		kb, err = h.kbRepo.FindByID(txCtx, kbID)
+		if err != nil {
+			return err
+		}
+		if kb == nil {
+			return domain.ErrKnowledgeBaseNotFound
+		}
+
+		// 通过聚合根更新标签定义（会收集 TagUpdatedEvent）
+		def, docs, err := kb.UpdateTag(tagID, parentID, cmd.Aliases)
+		if err != nil {
+			return err
+		}
+
+		if err := saveTagChanges(txCtx, h.docRepo, h.tagRepo, kb, docs); err != nil {
+			return err
+		}
+
+		if err := h.kbRepo.Save(txCtx, kb); err != nil {
+			return err
+		}
+
+		result = tagChangeResult(kb, def, docs)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// 事务成功后发布事件
+	if h.eventPublisher != nil {
+		events := kb.PullEvents()
+		if len(events) > 0 {
+			_ = h.eventPublisher.PublishAll(ctx, events)
+		}
+	}
+
+	return result, nil
+}
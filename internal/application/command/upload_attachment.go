@@ -0,0 +1,151 @@
+package command
+
+import (
+	"context"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+
+	"gozero-ddd/internal/application/auth"
+	"gozero-ddd/internal/application/dto"
+	"gozero-ddd/internal/domain"
+	"gozero-ddd/internal/domain/entity"
+	"gozero-ddd/internal/domain/event"
+	"gozero-ddd/internal/domain/repository"
+	"gozero-ddd/internal/domain/service"
+	"gozero-ddd/internal/domain/valueobject"
+)
+
+// UploadAttachmentCommand 上传附件命令
+type UploadAttachmentCommand struct {
+	KnowledgeBaseID string    `json:"knowledge_base_id"`
+	DocumentID      string    `json:"document_id"`
+	FileName        string    `json:"file_name"`
+	Content         io.Reader `json:"-"` // 附件内容，读取不超过大小上限
+}
+
+// UploadAttachmentHandler 上传附件命令处理器
+// 内容先写入 BlobStore（按哈希去重），再在事务中保存附件元数据
+type UploadAttachmentHandler struct {
+	unitOfWork        repository.UnitOfWork
+	kbRepo            repository.KnowledgeBaseRepository
+	attRepo           repository.AttachmentRepository
+	attachmentService *service.AttachmentService
+	maxBytes          int64
+	eventPublisher    event.EventPublisher
+	permissions       *auth.PermissionChecker // 权限检查器
+}
+
+// NewUploadAttachmentHandler 创建处理器
+// maxBytes 为单个附件的大小上限（字节）
+func NewUploadAttachmentHandler(
+	uow repository.UnitOfWork,
+	kbRepo repository.KnowledgeBaseRepository,
+	attRepo repository.AttachmentRepository,
+	attachmentService *service.AttachmentService,
+	maxBytes int64,
+	ep event.EventPublisher,
+	permissions *auth.PermissionChecker,
+) *UploadAttachmentHandler {
+	return &UploadAttachmentHandler{
+		unitOfWork:        uow,
+		kbRepo:            kbRepo,
+		attRepo:           attRepo,
+		attachmentService: attachmentService,
+		maxBytes:          maxBytes,
+		eventPublisher:    ep,
+		permissions:       permissions,
+	}
+}
+
+// Handle 处理上传附件命令
+func (h *UploadAttachmentHandler) Handle(ctx context.Context, cmd *UploadAttachmentCommand) (*dto.AttachmentDTO, error) {
+	// 验证 ID 格式
+	kbID, err := valueobject.KnowledgeBaseIDFromString(cmd.KnowledgeBaseID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 检查调用方权限
+	if err := h.permissions.Check(ctx, kbID, valueobject.MemberRoleEditor); err != nil {
+		return nil, err
+	}
+
+	docID, err := valueobject.DocumentIDFromString(cmd.DocumentID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 多读一个字节用于判断是否超出上限
+	content, err := io.ReadAll(io.LimitReader(cmd.Content, h.maxBytes+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(content)) > h.maxBytes {
+		return nil, domain.ErrAttachmentTooLarge
+	}
+	if len(content) == 0 {
+		return nil, domain.ErrAttachmentEmpty
+	}
+
+	// 媒体类型以内容嗅探结果为准，不信任客户端声明的类型
+	mediaType, _, _ := mime.ParseMediaType(http.DetectContentType(content))
+	if !entity.IsAllowedAttachmentType(mediaType) {
+		return nil, domain.ErrUnsupportedAttachmentType
+	}
+
+	hash, err := h.attachmentService.StoreContent(ctx, content)
+	if err != nil {
+		return nil, err
+	}
+
+	var kb *entity.KnowledgeBase
+	var result *dto.AttachmentDTO
+
+	err = h.unitOfWork.Transaction(ctx, func(txCtx context.Context) error {
+		// 查找知识库
+		var err error
+		kb, err = h.kbRepo.FindByID(txCtx, kbID)
+		if err != nil {
+			return err
+		}
+		if kb == nil {
+			return domain.ErrKnowledgeBaseNotFound
+		}
+
+		// 通过聚合根添加附件（会收集 AttachmentAddedEvent）
+		att, err := kb.AddAttachment(docID, cmd.FileName, mediaType, int64(len(content)), hash)
+		if err != nil {
+			return err
+		}
+
+		if err := h.attRepo.Save(txCtx, att); err != nil {
+			return err
+		}
+
+		if err := h.kbRepo.Save(txCtx, kb); err != nil {
+			return err
+		}
+
+		result = dto.AttachmentFromEntity(att)
+		return nil
+	})
+	if err != nil {
+		// 事务失败时回收刚写入且未被引用的内容
+		if releaseErr := h.attachmentService.ReleaseContent(ctx, hash); releaseErr != nil {
+			log.Printf("⚠️ [Attachment] 回收附件内容失败: hash=%s, err=%v", hash, releaseErr)
+		}
+		return nil, err
+	}
+
+	// 事务成功后发布事件
+	if h.eventPublisher != nil {
+		events := kb.PullEvents()
+		if len(events) > 0 {
+			_ = h.eventPublisher.PublishAll(ctx, events)
+		}
+	}
+
+	return result, nil
+}
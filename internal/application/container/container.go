@@ -2,9 +2,17 @@ package container
 
 import (
 	"log"
+	"time"
 
+	"gozero-ddd/internal/application/auth"
 	"gozero-ddd/internal/application/command"
+	"gozero-ddd/internal/application/cqrs"
+	"gozero-ddd/internal/application/dto"
+	"gozero-ddd/internal/application/idempotency"
+	"gozero-ddd/internal/application/metrics"
 	"gozero-ddd/internal/application/query"
+	"gozero-ddd/internal/application/ratelimit"
+	"gozero-ddd/internal/application/tracing"
 	"gozero-ddd/internal/domain/event"
 	"gozero-ddd/internal/domain/repository"
 	"gozero-ddd/internal/domain/service"
@@ -18,7 +26,25 @@ type InfraDependencies interface {
 	GetEventBus() event.EventPublisher
 	GetKnowledgeBaseRepo() repository.KnowledgeBaseRepository
 	GetDocumentRepo() repository.DocumentRepository
+	GetFolderRepo() repository.FolderRepository
+	GetDocumentLinkRepo() repository.DocumentLinkRepository
+	GetTagRepo() repository.TagRepository
 	GetKnowledgeService() *service.KnowledgeService
+	GetLinkService() *service.LinkService
+	GetContentRenderer() service.ContentRenderer
+	GetAttachmentRepo() repository.AttachmentRepository
+	GetAttachmentService() *service.AttachmentService
+	GetDuplicateService() *service.DuplicateService
+	GetQuotaService() *service.QuotaService
+	GetRateLimiter() ratelimit.Limiter
+	GetRateLimitPolicy() ratelimit.Policy
+	GetMaxAttachmentBytes() int64
+	GetIdempotencyRepo() repository.IdempotencyRepository
+	GetIdempotencyTTL() time.Duration
+	GetTokenVerifier() auth.TokenVerifier
+	GetKnowledgeBaseMemberRepo() repository.KnowledgeBaseMemberRepository
+	GetAPIKeyRepo() repository.APIKeyRepository
+	GetAuditLogRepo() repository.AuditLogRepository
 }
 
 // ApplicationContainer 应用层容器
@@ -30,25 +56,120 @@ type ApplicationContainer struct {
 
 	// 查询处理器（读操作）
 	Queries *QueryHandlers
+
+	// 幂等键服务（供接口层中间件使用）
+	Idempotency *idempotency.Service
+
+	// 认证服务（供接口层中间件和拦截器使用）
+	Auth *auth.Service
+
+	// 限流服务（供接口层中间件和拦截器使用）
+	RateLimit *ratelimit.Service
+
+	// 知识库权限检查器（命令和查询处理器共用）
+	permissions *auth.PermissionChecker
 }
 
 // CommandHandlers 命令处理器集合
-// CQRS 模式中的 Command 端
+// CQRS 模式中的 Command 端，每个处理器都包装了链路追踪和监控指标
 type CommandHandlers struct {
-	CreateKnowledgeBase *command.CreateKnowledgeBaseHandler
-	UpdateKnowledgeBase *command.UpdateKnowledgeBaseHandler
-	DeleteKnowledgeBase *command.DeleteKnowledgeBaseHandler
-	AddDocument         *command.AddDocumentHandler
-	RemoveDocument      *command.RemoveDocumentHandler
-	MergeKnowledgeBases *command.MergeKnowledgeBasesHandler
+	CreateKnowledgeBase cqrs.Handler[*command.CreateKnowledgeBaseCommand, *dto.KnowledgeBaseDTO]
+	UpdateKnowledgeBase cqrs.Handler[*command.UpdateKnowledgeBaseCommand, *dto.KnowledgeBaseDTO]
+	DeleteKnowledgeBase cqrs.VoidHandler[*command.DeleteKnowledgeBaseCommand]
+	AddDocument         cqrs.Handler[*command.AddDocumentCommand, *dto.DocumentDTO]
+	UpdateDocument      cqrs.Handler[*command.UpdateDocumentCommand, *dto.DocumentDTO]
+	RemoveDocument      cqrs.VoidHandler[*command.RemoveDocumentCommand]
+	MergeKnowledgeBases cqrs.Handler[*command.MergeKnowledgeBasesCommand, *dto.MergeResultDTO]
+
+	// 生命周期状态（只读、归档）
+	ChangeKnowledgeBaseStatus cqrs.Handler[*command.ChangeKnowledgeBaseStatusCommand, *dto.KnowledgeBaseDTO]
+
+	// 文档发布流程（提交审核、审核、发布、撤回）
+	DocumentWorkflow cqrs.Handler[*command.DocumentWorkflowCommand, *dto.DocumentDTO]
+
+	// 文档定时发布/下线
+	ScheduleDocument          cqrs.Handler[*command.ScheduleDocumentCommand, *dto.DocumentDTO]
+	ProcessScheduledDocuments cqrs.Handler[*command.ProcessScheduledDocumentsCommand, *dto.ScheduleResultDTO]
+
+	// 文件夹
+	CreateFolder cqrs.Handler[*command.CreateFolderCommand, *dto.FolderDTO]
+	RenameFolder cqrs.Handler[*command.RenameFolderCommand, *dto.FolderDTO]
+	MoveFolder   cqrs.Handler[*command.MoveFolderCommand, *dto.FolderDTO]
+	DeleteFolder cqrs.VoidHandler[*command.DeleteFolderCommand]
+	MoveDocument cqrs.Handler[*command.MoveDocumentCommand, *dto.DocumentDTO]
+
+	// 批量文档操作
+	BatchDocuments cqrs.Handler[*command.BatchDocumentsCommand, *dto.BatchResultDTO]
+
+	// 批量导入 Markdown
+	ImportDocuments cqrs.Handler[*command.ImportDocumentsCommand, *dto.ImportReportDTO]
+
+	// 从备份包恢复知识库
+	RestoreBackup cqrs.Handler[*command.RestoreBackupCommand, *dto.KnowledgeBaseDTO]
+
+	// 重建文档内容指纹
+	RebuildFingerprints cqrs.Handler[*command.RebuildFingerprintsCommand, *dto.FingerprintRebuildResultDTO]
+
+	// 标签
+	DefineTag cqrs.Handler[*command.DefineTagCommand, *dto.TagChangeResultDTO]
+	UpdateTag cqrs.Handler[*command.UpdateTagCommand, *dto.TagChangeResultDTO]
+	RenameTag cqrs.Handler[*command.RenameTagCommand, *dto.TagChangeResultDTO]
+	MergeTags cqrs.Handler[*command.MergeTagsCommand, *dto.TagChangeResultDTO]
+	DeleteTag cqrs.VoidHandler[*command.DeleteTagCommand]
+
+	// 附件
+	UploadAttachment cqrs.Handler[*command.UploadAttachmentCommand, *dto.AttachmentDTO]
+	DeleteAttachment cqrs.VoidHandler[*command.DeleteAttachmentCommand]
+
+	// 回收站
+	RestoreKnowledgeBase cqrs.Handler[*command.RestoreKnowledgeBaseCommand, *dto.KnowledgeBaseDTO]
+	RestoreDocument      cqrs.Handler[*command.RestoreDocumentCommand, *dto.DocumentDTO]
+	PurgeTrash           cqrs.Handler[*command.PurgeTrashCommand, *dto.PurgeResultDTO]
+
+	// 知识库成员：授予角色、移除成员
+	GrantKnowledgeBaseRole  cqrs.Handler[*command.GrantKnowledgeBaseRoleCommand, *dto.KnowledgeBaseMemberDTO]
+	RevokeKnowledgeBaseRole cqrs.VoidHandler[*command.RevokeKnowledgeBaseRoleCommand]
+
+	// API Key：签发、吊销
+	IssueAPIKey  cqrs.Handler[*command.IssueAPIKeyCommand, *dto.IssuedAPIKeyDTO]
+	RevokeAPIKey cqrs.Handler[*command.RevokeAPIKeyCommand, *dto.APIKeyDTO]
 }
 
 // QueryHandlers 查询处理器集合
-// CQRS 模式中的 Query 端
+// CQRS 模式中的 Query 端，每个处理器都包装了链路追踪和监控指标
 type QueryHandlers struct {
-	GetKnowledgeBase   *query.GetKnowledgeBaseHandler
-	ListKnowledgeBases *query.ListKnowledgeBasesHandler
-	ListDocuments      *query.ListDocumentsHandler
+	GetKnowledgeBase   cqrs.Handler[*query.GetKnowledgeBaseQuery, *dto.KnowledgeBaseDTO]
+	ListKnowledgeBases cqrs.Handler[*query.ListKnowledgeBasesQuery, *dto.KnowledgeBaseListDTO]
+	ListDocuments      cqrs.Handler[*query.ListDocumentsQuery, *dto.DocumentListDTO]
+	GetDocument        cqrs.Handler[*query.GetDocumentQuery, *dto.DocumentDTO]
+	ListTrash          cqrs.Handler[*query.ListTrashQuery, *dto.TrashListDTO]
+	GetFolderTree      cqrs.Handler[*query.GetFolderTreeQuery, *dto.FolderTreeDTO]
+	GetDocumentLinks   cqrs.Handler[*query.GetDocumentLinksQuery, *dto.DocumentLinksDTO]
+	ListBrokenLinks    cqrs.Handler[*query.ListBrokenLinksQuery, *dto.BrokenLinkReportDTO]
+	GetTagCloud        cqrs.Handler[*query.GetTagCloudQuery, *dto.TagCloudDTO]
+
+	// 附件
+	ListAttachments      cqrs.Handler[*query.ListAttachmentsQuery, *dto.AttachmentListDTO]
+	GetAttachmentContent cqrs.Handler[*query.GetAttachmentContentQuery, *query.AttachmentContent]
+
+	// 导出知识库
+	ExportKnowledgeBase cqrs.Handler[*query.ExportKnowledgeBaseQuery, *query.KnowledgeBaseExport]
+
+	// 重复文档检测
+	ListDuplicates cqrs.Handler[*query.ListDuplicatesQuery, *dto.DuplicateReportDTO]
+
+	// 知识库成员
+	ListKnowledgeBaseMembers cqrs.Handler[*query.ListKnowledgeBaseMembersQuery, *dto.KnowledgeBaseMemberListDTO]
+
+	// API Key
+	ListAPIKeys cqrs.Handler[*query.ListAPIKeysQuery, *dto.APIKeyListDTO]
+
+	// 租户配额用量
+	GetQuotaUsage cqrs.Handler[*query.GetQuotaUsageQuery, *dto.QuotaUsageDTO]
+
+	// 审计日志
+	ListAuditLogs   cqrs.Handler[*query.ListAuditLogsQuery, *dto.AuditLogListDTO]
+	ExportAuditLogs cqrs.Handler[*query.ExportAuditLogsQuery, *query.AuditLogExport]
 }
 
 // NewApplicationContainer 创建应用层容器
@@ -59,12 +180,24 @@ func NewApplicationContainer(deps InfraDependencies) *ApplicationContainer {
 		Queries:  &QueryHandlers{},
 	}
 
+	// 初始化权限检查器（命令和查询处理器依赖）
+	container.permissions = auth.NewPermissionChecker(deps.GetKnowledgeBaseMemberRepo())
+
 	// 初始化命令处理器
 	container.initCommandHandlers(deps)
 
 	// 初始化查询处理器
 	container.initQueryHandlers(deps)
 
+	// 初始化幂等键服务
+	container.Idempotency = idempotency.NewService(deps.GetIdempotencyRepo(), deps.GetIdempotencyTTL())
+
+	// 初始化认证服务（访问令牌和 API Key 共用）
+	container.Auth = auth.NewService(deps.GetTokenVerifier(), auth.NewAPIKeyService(deps.GetAPIKeyRepo()))
+
+	// 初始化限流服务（未启用限流时不限流）
+	container.RateLimit = ratelimit.NewService(deps.GetRateLimiter(), deps.GetRateLimitPolicy())
+
 	log.Println("✅ [Application] 应用层容器初始化完成")
 
 	return container
@@ -73,28 +206,98 @@ func NewApplicationContainer(deps InfraDependencies) *ApplicationContainer {
 // initCommandHandlers 初始化所有命令处理器
 func (c *ApplicationContainer) initCommandHandlers(deps InfraDependencies) {
 	uow := deps.GetUnitOfWork()
-	eventBus := deps.GetEventBus()
+	// 发布事件前记录操作者（请求上下文中的已认证调用方），每次发布记录一个 span 和发布指标
+	eventBus := auth.NewActorPublisher(tracing.NewPublisher(metrics.NewPublisher(deps.GetEventBus())))
 	kbRepo := deps.GetKnowledgeBaseRepo()
 	docRepo := deps.GetDocumentRepo()
+	folderRepo := deps.GetFolderRepo()
+	tagRepo := deps.GetTagRepo()
 	kbService := deps.GetKnowledgeService()
+	linkService := deps.GetLinkService()
+	attRepo := deps.GetAttachmentRepo()
+	attachmentService := deps.GetAttachmentService()
+	quotaService := deps.GetQuotaService()
 
 	// 创建知识库
-	c.Commands.CreateKnowledgeBase = command.NewCreateKnowledgeBaseHandler(kbService, eventBus)
+	c.Commands.CreateKnowledgeBase = instrumentCommand("CreateKnowledgeBase", command.NewCreateKnowledgeBaseHandler(uow, kbService, eventBus, c.permissions).Handle)
 
 	// 更新知识库
-	c.Commands.UpdateKnowledgeBase = command.NewUpdateKnowledgeBaseHandler(kbRepo, eventBus)
+	c.Commands.UpdateKnowledgeBase = instrumentCommand("UpdateKnowledgeBase", command.NewUpdateKnowledgeBaseHandler(kbRepo, eventBus, c.permissions).Handle,
+		tracing.KnowledgeBaseIDFrom(func(cmd *command.UpdateKnowledgeBaseCommand) string { return cmd.ID }))
 
-	// 删除知识库
-	c.Commands.DeleteKnowledgeBase = command.NewDeleteKnowledgeBaseHandler(kbRepo, kbService)
+	// 删除知识库（移入回收站）
+	c.Commands.DeleteKnowledgeBase = instrumentVoidCommand("DeleteKnowledgeBase", command.NewDeleteKnowledgeBaseHandler(uow, kbRepo, kbService, eventBus, c.permissions).Handle,
+		tracing.KnowledgeBaseIDFrom(func(cmd *command.DeleteKnowledgeBaseCommand) string { return cmd.ID }))
 
 	// 添加文档
-	c.Commands.AddDocument = command.NewAddDocumentHandler(uow, kbRepo, docRepo, eventBus)
+	c.Commands.AddDocument = instrumentCommand("AddDocument", command.NewAddDocumentHandler(uow, kbRepo, docRepo, linkService, quotaService, eventBus, c.permissions).Handle)
+
+	// 更新文档
+	c.Commands.UpdateDocument = instrumentCommand("UpdateDocument", command.NewUpdateDocumentHandler(uow, kbRepo, docRepo, linkService, quotaService, eventBus, c.permissions).Handle)
 
-	// 删除文档
-	c.Commands.RemoveDocument = command.NewRemoveDocumentHandler(uow, kbRepo, docRepo)
+	// 删除文档（移入回收站）
+	c.Commands.RemoveDocument = instrumentVoidCommand("RemoveDocument", command.NewRemoveDocumentHandler(uow, kbRepo, docRepo, linkService, eventBus, c.permissions).Handle)
+
+	// 批量添加、更新、删除文档（单个事务，提交后统一发布事件）
+	c.Commands.BatchDocuments = instrumentCommand("BatchDocuments", command.NewBatchDocumentsHandler(uow, kbRepo, docRepo, linkService, quotaService, eventBus, c.permissions).Handle)
 
 	// 合并知识库
-	c.Commands.MergeKnowledgeBases = command.NewMergeKnowledgeBasesHandler(uow, kbRepo, docRepo)
+	c.Commands.MergeKnowledgeBases = instrumentCommand("MergeKnowledgeBases", command.NewMergeKnowledgeBasesHandler(uow, kbRepo, docRepo, attRepo, linkService, quotaService, c.permissions).Handle,
+		tracing.KnowledgeBaseIDFrom(func(cmd *command.MergeKnowledgeBasesCommand) string { return cmd.TargetID }))
+
+	// 变更知识库状态
+	c.Commands.ChangeKnowledgeBaseStatus = instrumentCommand("ChangeKnowledgeBaseStatus", command.NewChangeKnowledgeBaseStatusHandler(kbRepo, eventBus, c.permissions).Handle,
+		tracing.KnowledgeBaseIDFrom(func(cmd *command.ChangeKnowledgeBaseStatusCommand) string { return cmd.ID }))
+
+	// 文档发布流程
+	c.Commands.DocumentWorkflow = instrumentCommand("DocumentWorkflow", command.NewDocumentWorkflowHandler(uow, kbRepo, docRepo, eventBus, c.permissions).Handle)
+
+	// 文档定时发布/下线：设置定时、处理到期文档（由调度器周期触发）
+	c.Commands.ScheduleDocument = instrumentCommand("ScheduleDocument", command.NewScheduleDocumentHandler(uow, kbRepo, docRepo, eventBus, c.permissions).Handle)
+	c.Commands.ProcessScheduledDocuments = instrumentCommand("ProcessScheduledDocuments", command.NewProcessScheduledDocumentsHandler(uow, kbRepo, docRepo, eventBus, c.permissions).Handle)
+
+	// 文件夹：创建、重命名、移动、删除，以及移动文档到文件夹
+	c.Commands.CreateFolder = instrumentCommand("CreateFolder", command.NewCreateFolderHandler(uow, kbRepo, folderRepo, eventBus, c.permissions).Handle)
+	c.Commands.RenameFolder = instrumentCommand("RenameFolder", command.NewRenameFolderHandler(uow, kbRepo, folderRepo, eventBus, c.permissions).Handle)
+	c.Commands.MoveFolder = instrumentCommand("MoveFolder", command.NewMoveFolderHandler(uow, kbRepo, folderRepo, eventBus, c.permissions).Handle)
+	c.Commands.DeleteFolder = instrumentVoidCommand("DeleteFolder", command.NewDeleteFolderHandler(uow, kbRepo, docRepo, folderRepo, eventBus, c.permissions).Handle)
+	c.Commands.MoveDocument = instrumentCommand("MoveDocument", command.NewMoveDocumentHandler(uow, kbRepo, docRepo, eventBus, c.permissions).Handle)
+
+	// 批量导入 Markdown 压缩包（按批次分事务）
+	c.Commands.ImportDocuments = instrumentCommand("ImportDocuments", command.NewImportDocumentsHandler(uow, kbRepo, docRepo, folderRepo, linkService, quotaService, eventBus, c.permissions).Handle)
+
+	// 从备份包恢复知识库（保留原有ID，不覆盖已有数据）
+	c.Commands.RestoreBackup = instrumentCommand("RestoreBackup", command.NewRestoreBackupHandler(uow, attRepo, kbService, attachmentService, linkService, deps.GetMaxAttachmentBytes(), eventBus, c.permissions).Handle)
+
+	// 重建文档内容指纹（补算启用重复检测之前的文档）
+	c.Commands.RebuildFingerprints = instrumentCommand("RebuildFingerprints", command.NewRebuildFingerprintsHandler(kbRepo, deps.GetDuplicateService(), c.permissions).Handle)
+
+	// 标签：定义、更新、重命名、合并、删除（重命名和合并会在同一事务中改写文档标签）
+	c.Commands.DefineTag = instrumentCommand("DefineTag", command.NewDefineTagHandler(uow, kbRepo, docRepo, tagRepo, eventBus, c.permissions).Handle)
+	c.Commands.UpdateTag = instrumentCommand("UpdateTag", command.NewUpdateTagHandler(uow, kbRepo, docRepo, tagRepo, eventBus, c.permissions).Handle)
+	c.Commands.RenameTag = instrumentCommand("RenameTag", command.NewRenameTagHandler(uow, kbRepo, docRepo, tagRepo, eventBus, c.permissions).Handle)
+	c.Commands.MergeTags = instrumentCommand("MergeTags", command.NewMergeTagsHandler(uow, kbRepo, docRepo, tagRepo, eventBus, c.permissions).Handle)
+	c.Commands.DeleteTag = instrumentVoidCommand("DeleteTag", command.NewDeleteTagHandler(uow, kbRepo, tagRepo, eventBus, c.permissions).Handle)
+
+	// 附件：上传（按内容哈希去重）、删除
+	c.Commands.UploadAttachment = instrumentCommand("UploadAttachment", command.NewUploadAttachmentHandler(uow, kbRepo, attRepo, attachmentService, deps.GetMaxAttachmentBytes(), eventBus, c.permissions).Handle)
+	c.Commands.DeleteAttachment = instrumentVoidCommand("DeleteAttachment", command.NewDeleteAttachmentHandler(uow, kbRepo, attRepo, attachmentService, eventBus, c.permissions).Handle)
+
+	// 回收站：恢复知识库、恢复文档、清理过期数据
+	c.Commands.RestoreKnowledgeBase = instrumentCommand("RestoreKnowledgeBase", command.NewRestoreKnowledgeBaseHandler(uow, kbRepo, docRepo, eventBus, c.permissions).Handle,
+		tracing.KnowledgeBaseIDFrom(func(cmd *command.RestoreKnowledgeBaseCommand) string { return cmd.ID }))
+	c.Commands.RestoreDocument = instrumentCommand("RestoreDocument", command.NewRestoreDocumentHandler(uow, kbRepo, docRepo, quotaService, eventBus, c.permissions).Handle)
+	c.Commands.PurgeTrash = instrumentCommand("PurgeTrash", command.NewPurgeTrashHandler(uow, kbRepo, docRepo, kbService, attachmentService, eventBus, c.permissions).Handle)
+
+	// 知识库成员：授予或变更角色、移除成员（仅所有者可操作，且至少保留一个所有者）
+	memberRepo := deps.GetKnowledgeBaseMemberRepo()
+	c.Commands.GrantKnowledgeBaseRole = instrumentCommand("GrantKnowledgeBaseRole", command.NewGrantKnowledgeBaseRoleHandler(uow, kbRepo, memberRepo, eventBus, c.permissions).Handle)
+	c.Commands.RevokeKnowledgeBaseRole = instrumentVoidCommand("RevokeKnowledgeBaseRole", command.NewRevokeKnowledgeBaseRoleHandler(uow, kbRepo, memberRepo, eventBus, c.permissions).Handle)
+
+	// API Key：签发、吊销（仅管理员可操作）
+	apiKeyRepo := deps.GetAPIKeyRepo()
+	c.Commands.IssueAPIKey = instrumentCommand("IssueAPIKey", command.NewIssueAPIKeyHandler(apiKeyRepo, kbRepo, eventBus, c.permissions).Handle)
+	c.Commands.RevokeAPIKey = instrumentCommand("RevokeAPIKey", command.NewRevokeAPIKeyHandler(apiKeyRepo, eventBus, c.permissions).Handle)
 
 	log.Println("📝 [Application] 命令处理器初始化完成")
 }
@@ -103,15 +306,60 @@ func (c *ApplicationContainer) initCommandHandlers(deps InfraDependencies) {
 func (c *ApplicationContainer) initQueryHandlers(deps InfraDependencies) {
 	kbRepo := deps.GetKnowledgeBaseRepo()
 	docRepo := deps.GetDocumentRepo()
+	linkRepo := deps.GetDocumentLinkRepo()
+	tagRepo := deps.GetTagRepo()
+	renderer := deps.GetContentRenderer()
+	attachmentService := deps.GetAttachmentService()
 
 	// 获取知识库详情
-	c.Queries.GetKnowledgeBase = query.NewGetKnowledgeBaseHandler(kbRepo, docRepo)
+	c.Queries.GetKnowledgeBase = instrumentQuery("GetKnowledgeBase", query.NewGetKnowledgeBaseHandler(kbRepo, docRepo, c.permissions).Handle,
+		tracing.KnowledgeBaseIDFrom(func(q *query.GetKnowledgeBaseQuery) string { return q.ID }))
 
 	// 列出所有知识库
-	c.Queries.ListKnowledgeBases = query.NewListKnowledgeBasesHandler(kbRepo)
+	c.Queries.ListKnowledgeBases = instrumentQuery("ListKnowledgeBases", query.NewListKnowledgeBasesHandler(kbRepo, c.permissions).Handle)
 
 	// 列出文档
-	c.Queries.ListDocuments = query.NewListDocumentsHandler(docRepo)
+	c.Queries.ListDocuments = instrumentQuery("ListDocuments", query.NewListDocumentsHandler(docRepo, tagRepo, renderer, c.permissions).Handle)
+
+	// 获取单个文档（支持按 html / text 格式输出）
+	c.Queries.GetDocument = instrumentQuery("GetDocument", query.NewGetDocumentHandler(docRepo, renderer, c.permissions).Handle)
+
+	// 列出回收站
+	c.Queries.ListTrash = instrumentQuery("ListTrash", query.NewListTrashHandler(kbRepo, docRepo, c.permissions).Handle)
+
+	// 获取文件夹树
+	c.Queries.GetFolderTree = instrumentQuery("GetFolderTree", query.NewGetFolderTreeHandler(kbRepo, c.permissions).Handle)
+
+	// 文档链接：出链与反向链接、失效链接报告
+	c.Queries.GetDocumentLinks = instrumentQuery("GetDocumentLinks", query.NewGetDocumentLinksHandler(kbRepo, docRepo, linkRepo, c.permissions).Handle)
+	c.Queries.ListBrokenLinks = instrumentQuery("ListBrokenLinks", query.NewListBrokenLinksHandler(kbRepo, docRepo, linkRepo, c.permissions).Handle)
+
+	// 标签云
+	c.Queries.GetTagCloud = instrumentQuery("GetTagCloud", query.NewGetTagCloudHandler(kbRepo, c.permissions).Handle)
+
+	// 附件：列出文档附件、下载附件内容
+	c.Queries.ListAttachments = instrumentQuery("ListAttachments", query.NewListAttachmentsHandler(kbRepo, c.permissions).Handle)
+	c.Queries.GetAttachmentContent = instrumentQuery("GetAttachmentContent", query.NewGetAttachmentContentHandler(kbRepo, attachmentService, c.permissions).Handle)
+
+	// 导出知识库：Markdown 压缩包、JSON Lines、备份包
+	c.Queries.ExportKnowledgeBase = instrumentQuery("ExportKnowledgeBase", query.NewExportKnowledgeBaseHandler(kbRepo, attachmentService, c.permissions).Handle)
+
+	// 重复文档检测（知识库内和跨知识库）
+	c.Queries.ListDuplicates = instrumentQuery("ListDuplicates", query.NewListDuplicatesHandler(kbRepo, deps.GetDuplicateService(), c.permissions).Handle)
+
+	// 列出知识库成员
+	c.Queries.ListKnowledgeBaseMembers = instrumentQuery("ListKnowledgeBaseMembers", query.NewListKnowledgeBaseMembersHandler(kbRepo, deps.GetKnowledgeBaseMemberRepo(), c.permissions).Handle)
+
+	// 列出 API Key
+	c.Queries.ListAPIKeys = instrumentQuery("ListAPIKeys", query.NewListAPIKeysHandler(deps.GetAPIKeyRepo(), c.permissions).Handle)
+
+	// 租户配额用量
+	c.Queries.GetQuotaUsage = instrumentQuery("GetQuotaUsage", query.NewGetQuotaUsageHandler(deps.GetQuotaService()).Handle)
+
+	// 查询和导出审计日志
+	auditLogRepo := deps.GetAuditLogRepo()
+	c.Queries.ListAuditLogs = instrumentQuery("ListAuditLogs", query.NewListAuditLogsHandler(auditLogRepo, c.permissions).Handle)
+	c.Queries.ExportAuditLogs = instrumentQuery("ExportAuditLogs", query.NewExportAuditLogsHandler(auditLogRepo, c.permissions).Handle)
 
 	log.Println("🔍 [Application] 查询处理器初始化完成")
 }
@@ -0,0 +1,24 @@
+package container
+
+import (
+	"context"
+
+	"gozero-ddd/internal/application/cqrs"
+	"gozero-ddd/internal/application/metrics"
+	"gozero-ddd/internal/application/tracing"
+)
+
+// instrumentCommand 为命令处理器叠加链路追踪和监控指标
+func instrumentCommand[Req, Res any](name string, next func(ctx context.Context, req Req) (Res, error), attrs ...tracing.Attributes[Req]) cqrs.HandlerFunc[Req, Res] {
+	return tracing.Command(name, metrics.Command(name, next), attrs...)
+}
+
+// instrumentVoidCommand 为只返回错误的命令处理器叠加链路追踪和监控指标
+func instrumentVoidCommand[Req any](name string, next func(ctx context.Context, req Req) error, attrs ...tracing.Attributes[Req]) cqrs.VoidHandlerFunc[Req] {
+	return tracing.VoidCommand(name, metrics.VoidCommand(name, next), attrs...)
+}
+
+// instrumentQuery 为查询处理器叠加链路追踪和监控指标
+func instrumentQuery[Req, Res any](name string, next func(ctx context.Context, req Req) (Res, error), attrs ...tracing.Attributes[Req]) cqrs.HandlerFunc[Req, Res] {
+	return tracing.Query(name, metrics.Query(name, next), attrs...)
+}
@@ -0,0 +1,47 @@
+package cqrs
+
+import (
+	"context"
+	"fmt"
+
+	"gozero-ddd/internal/domain/event"
+)
+
+// Handler 返回结果的命令或查询处理器
+// 应用层容器以接口形式暴露处理器，便于叠加链路追踪等装饰器
+type Handler[Req, Res any] interface {
+	Handle(ctx context.Context, req Req) (Res, error)
+}
+
+// VoidHandler 只返回错误的命令处理器
+type VoidHandler[Req any] interface {
+	Handle(ctx context.Context, req Req) error
+}
+
+// HandlerFunc 函数形式的 Handler，装饰器以此包装下一层处理器
+type HandlerFunc[Req, Res any] func(ctx context.Context, req Req) (Res, error)
+
+// Handle 调用函数本身
+func (f HandlerFunc[Req, Res]) Handle(ctx context.Context, req Req) (Res, error) {
+	return f(ctx, req)
+}
+
+// VoidHandlerFunc 函数形式的 VoidHandler
+type VoidHandlerFunc[Req any] func(ctx context.Context, req Req) error
+
+// Handle 调用函数本身
+func (f VoidHandlerFunc[Req]) Handle(ctx context.Context, req Req) error {
+	return f(ctx, req)
+}
+
+// EventHandlerName 返回事件处理器的类型名，用作链路追踪属性和监控指标标签
+// 装饰器通过 Unwrap 返回被包装的处理器，名称取最内层处理器的类型
+func EventHandlerName(h event.EventHandler) string {
+	for {
+		wrapper, ok := h.(interface{ Unwrap() event.EventHandler })
+		if !ok {
+			return fmt.Sprintf("%T", h)
+		}
+		h = wrapper.Unwrap()
+	}
+}
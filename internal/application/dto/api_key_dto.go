@@ -0,0 +1,76 @@
+package dto
+
+import (
+	"time"
+
+	"gozero-ddd/internal/domain/repository"
+)
+
+// API Key 状态
+const (
+	APIKeyStatusActive  = "active"
+	APIKeyStatusExpired = "expired"
+	APIKeyStatusRevoked = "revoked"
+)
+
+// APIKeyDTO API Key DTO（不包含密钥明文）
+type APIKeyDTO struct {
+	ID               string     `json:"id"`
+	Name             string     `json:"name"`
+	Prefix           string     `json:"prefix"`                       // 密钥明文的前几位
+	Scopes           []string   `json:"scopes"`                       // read / write / admin
+	KnowledgeBaseIDs []string   `json:"knowledge_base_ids,omitempty"` // 为空表示不限制
+	Status           string     `json:"status"`                       // active / expired / revoked
+	CreatedBy        string     `json:"created_by,omitempty"`
+	CreatedAt        time.Time  `json:"created_at"`
+	ExpiresAt        *time.Time `json:"expires_at,omitempty"`
+	LastUsedAt       *time.Time `json:"last_used_at,omitempty"`
+	RevokedAt        *time.Time `json:"revoked_at,omitempty"`
+}
+
+// IssuedAPIKeyDTO 签发 API Key 的结果
+// Key 为密钥明文，只在签发时返回一次，服务端不保存
+type IssuedAPIKeyDTO struct {
+	*APIKeyDTO
+	Key string `json:"key"`
+}
+
+// APIKeyListDTO API Key 列表DTO
+type APIKeyListDTO struct {
+	Items []*APIKeyDTO `json:"items"`
+	Total int          `json:"total"`
+}
+
+// APIKeyFromRecord 从 API Key 记录创建DTO
+func APIKeyFromRecord(k *repository.APIKey, now time.Time) *APIKeyDTO {
+	scopes := make([]string, len(k.Scopes))
+	for i, s := range k.Scopes {
+		scopes[i] = s.String()
+	}
+	kbIDs := make([]string, len(k.KnowledgeBaseIDs))
+	for i, id := range k.KnowledgeBaseIDs {
+		kbIDs[i] = id.String()
+	}
+
+	status := APIKeyStatusActive
+	switch {
+	case k.RevokedAt != nil:
+		status = APIKeyStatusRevoked
+	case k.ExpiresAt != nil && !now.Before(*k.ExpiresAt):
+		status = APIKeyStatusExpired
+	}
+
+	return &APIKeyDTO{
+		ID:               k.ID,
+		Name:             k.Name,
+		Prefix:           k.Prefix,
+		Scopes:           scopes,
+		KnowledgeBaseIDs: kbIDs,
+		Status:           status,
+		CreatedBy:        k.CreatedBy,
+		CreatedAt:        k.CreatedAt,
+		ExpiresAt:        k.ExpiresAt,
+		LastUsedAt:       k.LastUsedAt,
+		RevokedAt:        k.RevokedAt,
+	}
+}
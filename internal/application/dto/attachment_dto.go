@@ -0,0 +1,39 @@
+package dto
+
+import (
+	"time"
+
+	"gozero-ddd/internal/domain/entity"
+)
+
+// AttachmentDTO 附件数据传输对象
+type AttachmentDTO struct {
+	ID              string    `json:"id"`
+	KnowledgeBaseID string    `json:"knowledge_base_id"`
+	DocumentID      string    `json:"document_id"`
+	FileName        string    `json:"file_name"`
+	MediaType       string    `json:"media_type"`
+	Size            int64     `json:"size"`
+	ContentHash     string    `json:"content_hash"` // SHA-256，可用作 ETag
+	CreatedAt       time.Time `json:"created_at"`
+}
+
+// AttachmentFromEntity 从实体转换为DTO
+func AttachmentFromEntity(att *entity.Attachment) *AttachmentDTO {
+	return &AttachmentDTO{
+		ID:              att.ID().String(),
+		KnowledgeBaseID: att.KnowledgeBaseID().String(),
+		DocumentID:      att.DocumentID().String(),
+		FileName:        att.FileName(),
+		MediaType:       att.MediaType(),
+		Size:            att.Size(),
+		ContentHash:     att.ContentHash().String(),
+		CreatedAt:       att.CreatedAt(),
+	}
+}
+
+// AttachmentListDTO 附件列表DTO
+type AttachmentListDTO struct {
+	Items []*AttachmentDTO `json:"items"`
+	Total int              `json:"total"`
+}
@@ -0,0 +1,51 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+
+	"gozero-ddd/internal/domain/repository"
+)
+
+// AuditLogDTO 审计日志DTO
+type AuditLogDTO struct {
+	Seq             int64           `json:"seq"`
+	EventID         string          `json:"event_id"`
+	EventName       string          `json:"event_name"`
+	AggregateID     string          `json:"aggregate_id"`
+	KnowledgeBaseID string          `json:"knowledge_base_id,omitempty"`
+	Actor           string          `json:"actor,omitempty"`
+	RequestID       string          `json:"request_id,omitempty"`
+	ClientIP        string          `json:"client_ip,omitempty"`
+	Before          json.RawMessage `json:"before,omitempty"` // 变更前的快照
+	After           json.RawMessage `json:"after,omitempty"`  // 变更后的快照
+	Payload         json.RawMessage `json:"payload,omitempty"`
+	OccurredAt      time.Time       `json:"occurred_at"`
+	RecordedAt      time.Time       `json:"recorded_at"`
+}
+
+// AuditLogListDTO 审计日志列表DTO
+// 按写入顺序倒序，NextCursor 为空表示没有更多记录
+type AuditLogListDTO struct {
+	Items      []*AuditLogDTO `json:"items"`
+	NextCursor string         `json:"next_cursor,omitempty"`
+}
+
+// AuditLogFromEntry 从审计日志记录创建DTO
+func AuditLogFromEntry(e *repository.AuditLogEntry) *AuditLogDTO {
+	return &AuditLogDTO{
+		Seq:             e.Seq,
+		EventID:         e.EventID,
+		EventName:       e.EventName,
+		AggregateID:     e.AggregateID,
+		KnowledgeBaseID: e.KnowledgeBaseID,
+		Actor:           e.Actor,
+		RequestID:       e.RequestID,
+		ClientIP:        e.ClientIP,
+		Before:          e.Before,
+		After:           e.After,
+		Payload:         e.Payload,
+		OccurredAt:      e.OccurredAt,
+		RecordedAt:      e.RecordedAt,
+	}
+}
@@ -0,0 +1,29 @@
+package dto
+
+// 批量操作条目状态
+const (
+	BatchStatusSucceeded  = "succeeded"   // 操作成功
+	BatchStatusFailed     = "failed"      // 操作失败
+	BatchStatusRolledBack = "rolled_back" // 操作本身成功，但因其他操作失败而被回滚（atomic 模式）
+	BatchStatusSkipped    = "skipped"     // 未执行（atomic 模式下前面的操作已失败）
+)
+
+// BatchItemResultDTO 单个操作的执行结果
+type BatchItemResultDTO struct {
+	Index      int    `json:"index"`                 // 操作在请求中的序号（从 0 开始）
+	Op         string `json:"op"`                    // add / update / remove
+	Status     string `json:"status"`                // succeeded / failed / rolled_back / skipped
+	DocumentID string `json:"document_id,omitempty"` // 操作的文档ID（add 成功时为新文档ID）
+	Error      string `json:"error,omitempty"`       // 失败原因
+}
+
+// BatchResultDTO 批量操作结果DTO
+type BatchResultDTO struct {
+	KnowledgeBaseID string                `json:"knowledge_base_id"`
+	Mode            string                `json:"mode"`      // atomic / best_effort
+	Committed       bool                  `json:"committed"` // 事务是否已提交
+	Total           int                   `json:"total"`
+	Succeeded       int                   `json:"succeeded"`
+	Failed          int                   `json:"failed"`
+	Items           []*BatchItemResultDTO `json:"items"`
+}
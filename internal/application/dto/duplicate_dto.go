@@ -0,0 +1,32 @@
+package dto
+
+// DuplicateDocumentDTO 重复分组中的文档
+type DuplicateDocumentDTO struct {
+	DocumentID      string `json:"document_id"`
+	KnowledgeBaseID string `json:"knowledge_base_id"`
+	Title           string `json:"title"`
+	ContentHash     string `json:"content_hash"` // 规范化内容的 SHA-256
+	Distance        int    `json:"distance"`     // 与分组中第一篇文档的 SimHash 汉明距离
+}
+
+// DuplicateClusterDTO 一组互相重复的文档
+type DuplicateClusterDTO struct {
+	Kind               string                  `json:"kind"`                 // exact / near
+	CrossKnowledgeBase bool                    `json:"cross_knowledge_base"` // 分组中的文档是否来自多个知识库
+	Documents          []*DuplicateDocumentDTO `json:"documents"`
+}
+
+// DuplicateReportDTO 重复文档检测结果DTO
+type DuplicateReportDTO struct {
+	KnowledgeBaseID    string                 `json:"knowledge_base_id,omitempty"` // 为空表示检测所有知识库
+	CrossKnowledgeBase bool                   `json:"cross_knowledge_base"`
+	MaxDistance        int                    `json:"max_distance"` // 近似重复的 SimHash 汉明距离上限
+	Clusters           []*DuplicateClusterDTO `json:"clusters"`
+	Total              int                    `json:"total"`
+}
+
+// FingerprintRebuildResultDTO 重建内容指纹结果DTO
+type FingerprintRebuildResultDTO struct {
+	KnowledgeBases int `json:"knowledge_bases"` // 处理的知识库数量
+	Documents      int `json:"documents"`       // 重新计算指纹的文档数量
+}
@@ -0,0 +1,207 @@
+package dto
+
+import (
+	"time"
+
+	"gozero-ddd/internal/domain/entity"
+	"gozero-ddd/internal/domain/valueobject"
+)
+
+// 导出格式
+const (
+	ExportFormatMarkdown = "markdown" // Markdown 文件（带 front matter）的 zip 压缩包
+	ExportFormatJSONL    = "jsonl"    // 每行一个文档的 JSON Lines
+	ExportFormatBackup   = "backup"   // 可无损导入的备份包
+)
+
+// ExportDocumentDTO JSON Lines 导出中的一行
+type ExportDocumentDTO struct {
+	ID          string     `json:"id"`
+	Title       string     `json:"title"`
+	Content     string     `json:"content"`
+	ContentType string     `json:"content_type"`
+	Tags        []string   `json:"tags"`
+	Status      string     `json:"status"`
+	FolderPath  string     `json:"folder_path"` // 以 "/" 分隔的文件夹路径，根目录为空
+	PublishAt   *time.Time `json:"publish_at,omitempty"`
+	ExpireAt    *time.Time `json:"expire_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+// ==================== 备份包 ====================
+//
+// 备份包是一个 zip 压缩包，结构如下：
+//
+//	manifest.json        BackupManifest，描述格式、版本和内容统计
+//	knowledge_base.json  BackupKnowledgeBase
+//	folders.json         []BackupFolder
+//	tags.json            []BackupTag
+//	documents.jsonl      每行一个 BackupDocument
+//	attachments.json     []BackupAttachment
+//	blobs/<sha256>       附件内容，按内容哈希命名
+//
+// 版本号只在格式发生不兼容变化时递增
+
+const (
+	// BackupFormat 备份包格式标识
+	BackupFormat = "gozero-ddd/knowledge-base-backup"
+	// BackupVersion 当前备份包格式版本
+	BackupVersion = 1
+)
+
+// 备份包中的文件名
+const (
+	BackupManifestFile      = "manifest.json"
+	BackupKnowledgeBaseFile = "knowledge_base.json"
+	BackupFoldersFile       = "folders.json"
+	BackupTagsFile          = "tags.json"
+	BackupDocumentsFile     = "documents.jsonl"
+	BackupAttachmentsFile   = "attachments.json"
+	BackupBlobDir           = "blobs/"
+)
+
+// BackupManifest 备份包清单
+type BackupManifest struct {
+	Format          string    `json:"format"`
+	Version         int       `json:"version"`
+	ExportedAt      time.Time `json:"exported_at"`
+	KnowledgeBaseID string    `json:"knowledge_base_id"`
+	Documents       int       `json:"documents"`
+	Folders         int       `json:"folders"`
+	Tags            int       `json:"tags"`
+	Attachments     int       `json:"attachments"`
+}
+
+// BackupKnowledgeBase 备份中的知识库
+type BackupKnowledgeBase struct {
+	ID              string    `json:"id"`
+	Name            string    `json:"name"`
+	Description     string    `json:"description"`
+	Status          string    `json:"status"`
+	DuplicatePolicy string    `json:"duplicate_policy,omitempty"` // 旧版本的备份包没有该字段，恢复时使用默认策略
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
+
+// BackupFolder 备份中的文件夹
+type BackupFolder struct {
+	ID        string    `json:"id"`
+	ParentID  string    `json:"parent_id,omitempty"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// BackupTag 备份中的标签定义
+type BackupTag struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	Aliases   []string  `json:"aliases"`
+	ParentID  string    `json:"parent_id,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// BackupDocument 备份中的文档
+type BackupDocument struct {
+	ID             string                      `json:"id"`
+	FolderID       string                      `json:"folder_id,omitempty"`
+	Title          string                      `json:"title"`
+	Content        string                      `json:"content"`
+	ContentType    string                      `json:"content_type"`
+	Tags           []string                    `json:"tags"`
+	Status         string                      `json:"status"`
+	ReviewComments []valueobject.ReviewComment `json:"review_comments"`
+	PublishAt      *time.Time                  `json:"publish_at,omitempty"`
+	ExpireAt       *time.Time                  `json:"expire_at,omitempty"`
+	CreatedAt      time.Time                   `json:"created_at"`
+	UpdatedAt      time.Time                   `json:"updated_at"`
+}
+
+// BackupAttachment 备份中的附件元数据，内容保存在 blobs/<content_hash>
+type BackupAttachment struct {
+	ID          string    `json:"id"`
+	DocumentID  string    `json:"document_id"`
+	FileName    string    `json:"file_name"`
+	MediaType   string    `json:"media_type"`
+	Size        int64     `json:"size"`
+	ContentHash string    `json:"content_hash"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// BackupKnowledgeBaseFromEntity 从实体转换为备份结构
+func BackupKnowledgeBaseFromEntity(kb *entity.KnowledgeBase) *BackupKnowledgeBase {
+	return &BackupKnowledgeBase{
+		ID:              kb.ID().String(),
+		Name:            kb.Name(),
+		Description:     kb.Description(),
+		Status:          kb.Status().String(),
+		DuplicatePolicy: kb.DuplicatePolicy().String(),
+		CreatedAt:       kb.CreatedAt(),
+		UpdatedAt:       kb.UpdatedAt(),
+	}
+}
+
+// BackupFolderFromEntity 从实体转换为备份结构
+func BackupFolderFromEntity(f *entity.Folder) *BackupFolder {
+	b := &BackupFolder{
+		ID:        f.ID().String(),
+		Name:      f.Name(),
+		CreatedAt: f.CreatedAt(),
+		UpdatedAt: f.UpdatedAt(),
+	}
+	if f.ParentID() != nil {
+		b.ParentID = f.ParentID().String()
+	}
+	return b
+}
+
+// BackupTagFromEntity 从实体转换为备份结构
+func BackupTagFromEntity(t *entity.TagDefinition) *BackupTag {
+	b := &BackupTag{
+		ID:        t.ID().String(),
+		Name:      t.Name().String(),
+		Aliases:   valueobject.TagStrings(t.Aliases()),
+		CreatedAt: t.CreatedAt(),
+		UpdatedAt: t.UpdatedAt(),
+	}
+	if t.ParentID() != nil {
+		b.ParentID = t.ParentID().String()
+	}
+	return b
+}
+
+// BackupDocumentFromEntity 从实体转换为备份结构
+func BackupDocumentFromEntity(doc *entity.Document) *BackupDocument {
+	b := &BackupDocument{
+		ID:             doc.ID().String(),
+		Title:          doc.Title(),
+		Content:        doc.Content(),
+		ContentType:    doc.ContentType().String(),
+		Tags:           doc.Tags(),
+		Status:         doc.Status().String(),
+		ReviewComments: doc.ReviewComments(),
+		PublishAt:      doc.PublishAt(),
+		ExpireAt:       doc.ExpireAt(),
+		CreatedAt:      doc.CreatedAt(),
+		UpdatedAt:      doc.UpdatedAt(),
+	}
+	if doc.FolderID() != nil {
+		b.FolderID = doc.FolderID().String()
+	}
+	return b
+}
+
+// BackupAttachmentFromEntity 从实体转换为备份结构
+func BackupAttachmentFromEntity(att *entity.Attachment) *BackupAttachment {
+	return &BackupAttachment{
+		ID:          att.ID().String(),
+		DocumentID:  att.DocumentID().String(),
+		FileName:    att.FileName(),
+		MediaType:   att.MediaType(),
+		Size:        att.Size(),
+		ContentHash: att.ContentHash().String(),
+		CreatedAt:   att.CreatedAt(),
+	}
+}
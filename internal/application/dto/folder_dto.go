@@ -0,0 +1,49 @@
+package dto
+
+import (
+	"time"
+
+	"gozero-ddd/internal/domain/entity"
+)
+
+// FolderDTO 文件夹数据传输对象
+type FolderDTO struct {
+	ID              string    `json:"id"`
+	KnowledgeBaseID string    `json:"knowledge_base_id"`
+	ParentID        string    `json:"parent_id,omitempty"` // 父文件夹ID，根目录为空
+	Name            string    `json:"name"`
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
+
+// FolderFromEntity 从实体转换为DTO
+func FolderFromEntity(f *entity.Folder) *FolderDTO {
+	dto := &FolderDTO{
+		ID:              f.ID().String(),
+		KnowledgeBaseID: f.KnowledgeBaseID().String(),
+		Name:            f.Name(),
+		CreatedAt:       f.CreatedAt(),
+		UpdatedAt:       f.UpdatedAt(),
+	}
+	if f.ParentID() != nil {
+		dto.ParentID = f.ParentID().String()
+	}
+	return dto
+}
+
+// FolderNodeDTO 文件夹树节点
+type FolderNodeDTO struct {
+	ID                 string           `json:"id"`
+	Name               string           `json:"name"`
+	DocumentCount      int              `json:"document_count"`       // 直接位于该文件夹下的文档数量
+	TotalDocumentCount int              `json:"total_document_count"` // 包含所有子文件夹的文档数量
+	Children           []*FolderNodeDTO `json:"children"`
+}
+
+// FolderTreeDTO 知识库文件夹树
+type FolderTreeDTO struct {
+	KnowledgeBaseID    string           `json:"knowledge_base_id"`
+	RootDocumentCount  int              `json:"root_document_count"`  // 位于根目录的文档数量
+	TotalDocumentCount int              `json:"total_document_count"` // 知识库中的文档总数
+	Folders            []*FolderNodeDTO `json:"folders"`              // 根目录下的文件夹
+}
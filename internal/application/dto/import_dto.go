@@ -0,0 +1,30 @@
+package dto
+
+// 导入条目状态
+const (
+	ImportStatusCreated = "created" // 已创建文档
+	ImportStatusSkipped = "skipped" // 已跳过（非 Markdown 文件或同名文档已存在）
+	ImportStatusFailed  = "failed"  // 导入失败
+)
+
+// ImportItemDTO 单个文件的导入结果
+type ImportItemDTO struct {
+	Path       string   `json:"path"`                  // 文件在压缩包中的路径
+	Status     string   `json:"status"`                // created / skipped / failed
+	DocumentID string   `json:"document_id,omitempty"` // 创建的文档ID
+	Title      string   `json:"title,omitempty"`       // 文档标题
+	FolderID   string   `json:"folder_id,omitempty"`   // 文档所在文件夹ID（目录映射为文件夹时）
+	Tags       []string `json:"tags,omitempty"`        // 文档标签
+	Reason     string   `json:"reason,omitempty"`      // 跳过或失败的原因
+}
+
+// ImportReportDTO 导入报告DTO
+type ImportReportDTO struct {
+	KnowledgeBaseID string           `json:"knowledge_base_id"`
+	Total           int              `json:"total"`   // 压缩包中的文件数（不含目录）
+	Created         int              `json:"created"` // 创建的文档数
+	Skipped         int              `json:"skipped"` // 跳过的文件数
+	Failed          int              `json:"failed"`  // 失败的文件数
+	FoldersCreated  int              `json:"folders_created"`
+	Items           []*ImportItemDTO `json:"items"`
+}
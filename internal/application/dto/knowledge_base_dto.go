@@ -4,29 +4,36 @@ import (
 	"time"
 
 	"gozero-ddd/internal/domain/entity"
+	"gozero-ddd/internal/domain/valueobject"
 )
 
 // KnowledgeBaseDTO 知识库数据传输对象
 // DTO 用于在层之间传递数据，解耦领域层和接口层
 type KnowledgeBaseDTO struct {
-	ID            string        `json:"id"`
-	Name          string        `json:"name"`
-	Description   string        `json:"description"`
-	DocumentCount int           `json:"document_count"`
-	Documents     []DocumentDTO `json:"documents,omitempty"`
-	CreatedAt     time.Time     `json:"created_at"`
-	UpdatedAt     time.Time     `json:"updated_at"`
+	ID              string        `json:"id"`
+	Name            string        `json:"name"`
+	Description     string        `json:"description"`
+	Status          string        `json:"status"`           // 生命周期状态：active / read_only / archived
+	DuplicatePolicy string        `json:"duplicate_policy"` // 重复文档策略：allow / reject_exact
+	DocumentCount   int           `json:"document_count"`
+	Documents       []DocumentDTO `json:"documents,omitempty"`
+	CreatedAt       time.Time     `json:"created_at"`
+	UpdatedAt       time.Time     `json:"updated_at"`
+	DeletedAt       *time.Time    `json:"deleted_at,omitempty"` // 移入回收站时间
 }
 
 // KnowledgeBaseFromEntity 从实体转换为DTO
 func KnowledgeBaseFromEntity(kb *entity.KnowledgeBase, includeDocuments bool) *KnowledgeBaseDTO {
 	dto := &KnowledgeBaseDTO{
-		ID:            kb.ID().String(),
-		Name:          kb.Name(),
-		Description:   kb.Description(),
-		DocumentCount: kb.DocumentCount(),
-		CreatedAt:     kb.CreatedAt(),
-		UpdatedAt:     kb.UpdatedAt(),
+		ID:              kb.ID().String(),
+		Name:            kb.Name(),
+		Description:     kb.Description(),
+		Status:          kb.Status().String(),
+		DuplicatePolicy: kb.DuplicatePolicy().String(),
+		DocumentCount:   kb.DocumentCount(),
+		CreatedAt:       kb.CreatedAt(),
+		UpdatedAt:       kb.UpdatedAt(),
+		DeletedAt:       kb.DeletedAt(),
 	}
 
 	if includeDocuments {
@@ -48,26 +55,71 @@ type KnowledgeBaseListDTO struct {
 
 // DocumentDTO 文档数据传输对象
 type DocumentDTO struct {
-	ID              string    `json:"id"`
-	KnowledgeBaseID string    `json:"knowledge_base_id"`
-	Title           string    `json:"title"`
-	Content         string    `json:"content"`
-	Tags            []string  `json:"tags"`
-	CreatedAt       time.Time `json:"created_at"`
-	UpdatedAt       time.Time `json:"updated_at"`
+	ID              string             `json:"id"`
+	KnowledgeBaseID string             `json:"knowledge_base_id"`
+	FolderID        string             `json:"folder_id,omitempty"` // 所在文件夹ID，根目录为空
+	Title           string             `json:"title"`
+	Content         string             `json:"content"`
+	ContentType     string             `json:"content_type"`      // 内容类型：markdown / html / text / asciidoc
+	Format          string             `json:"format,omitempty"`  // content 字段的输出格式：raw / html / text
+	Excerpt         string             `json:"excerpt,omitempty"` // 纯文本摘要（按 text 格式输出时提供）
+	TOC             []HeadingDTO       `json:"toc,omitempty"`     // 目录（按 html 格式输出或读取单个文档时提供）
+	Tags            []string           `json:"tags"`
+	Status          string             `json:"status"` // 发布状态：draft / in_review / approved / rejected / published / expired
+	ReviewComments  []ReviewCommentDTO `json:"review_comments,omitempty"`
+	PublishAt       *time.Time         `json:"publish_at,omitempty"` // 定时发布时间
+	ExpireAt        *time.Time         `json:"expire_at,omitempty"`  // 定时下线时间
+	CreatedAt       time.Time          `json:"created_at"`
+	UpdatedAt       time.Time          `json:"updated_at"`
+	DeletedAt       *time.Time         `json:"deleted_at,omitempty"` // 移入回收站时间
 }
 
 // DocumentFromEntity 从实体转换为DTO
 func DocumentFromEntity(doc *entity.Document) *DocumentDTO {
-	return &DocumentDTO{
+	dto := &DocumentDTO{
 		ID:              doc.ID().String(),
 		KnowledgeBaseID: doc.KnowledgeBaseID().String(),
 		Title:           doc.Title(),
 		Content:         doc.Content(),
+		ContentType:     doc.ContentType().String(),
 		Tags:            doc.Tags(),
+		Status:          doc.Status().String(),
+		ReviewComments:  reviewCommentsFromValueObjects(doc.ReviewComments()),
+		PublishAt:       doc.PublishAt(),
+		ExpireAt:        doc.ExpireAt(),
 		CreatedAt:       doc.CreatedAt(),
 		UpdatedAt:       doc.UpdatedAt(),
+		DeletedAt:       doc.DeletedAt(),
 	}
+	if doc.FolderID() != nil {
+		dto.FolderID = doc.FolderID().String()
+	}
+	return dto
+}
+
+// ReviewCommentDTO 评审意见DTO
+type ReviewCommentDTO struct {
+	Action    string    `json:"action"`
+	Reviewer  string    `json:"reviewer"`
+	Comment   string    `json:"comment"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// reviewCommentsFromValueObjects 从值对象转换为DTO
+func reviewCommentsFromValueObjects(comments []valueobject.ReviewComment) []ReviewCommentDTO {
+	if len(comments) == 0 {
+		return nil
+	}
+	result := make([]ReviewCommentDTO, len(comments))
+	for i, c := range comments {
+		result[i] = ReviewCommentDTO{
+			Action:    c.Action,
+			Reviewer:  c.Reviewer,
+			Comment:   c.Comment,
+			CreatedAt: c.CreatedAt,
+		}
+	}
+	return result
 }
 
 // DocumentListDTO 文档列表DTO
@@ -76,3 +128,18 @@ type DocumentListDTO struct {
 	Total int            `json:"total"`
 }
 
+// HeadingDTO 目录项DTO
+type HeadingDTO struct {
+	Level  int    `json:"level"`
+	Text   string `json:"text"`
+	Anchor string `json:"anchor"`
+}
+
+// HeadingsFromValueObjects 从值对象转换为DTO
+func HeadingsFromValueObjects(headings []valueobject.Heading) []HeadingDTO {
+	result := make([]HeadingDTO, len(headings))
+	for i, h := range headings {
+		result[i] = HeadingDTO{Level: h.Level, Text: h.Text, Anchor: h.Anchor}
+	}
+	return result
+}
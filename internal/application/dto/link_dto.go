@@ -0,0 +1,41 @@
+package dto
+
+// DocumentLinkDTO 文档出链DTO
+type DocumentLinkDTO struct {
+	Type                  string `json:"type"`   // 链接类型：wiki / url
+	Target                string `json:"target"` // 链接目标：Wiki 链接为标题，路径链接为文档路径
+	TargetDocumentID      string `json:"target_document_id,omitempty"`
+	TargetKnowledgeBaseID string `json:"target_knowledge_base_id,omitempty"`
+	TargetTitle           string `json:"target_title,omitempty"`
+	Broken                bool   `json:"broken"` // 目标文档不存在或已删除
+}
+
+// BacklinkDTO 反向链接DTO（引用当前文档的文档）
+type BacklinkDTO struct {
+	DocumentID      string `json:"document_id"`
+	KnowledgeBaseID string `json:"knowledge_base_id"`
+	Title           string `json:"title,omitempty"`
+	Type            string `json:"type"` // 链接类型：wiki / url
+}
+
+// DocumentLinksDTO 文档链接DTO
+type DocumentLinksDTO struct {
+	DocumentID string             `json:"document_id"`
+	Outgoing   []*DocumentLinkDTO `json:"outgoing"`  // 当前文档引用的文档
+	Backlinks  []*BacklinkDTO     `json:"backlinks"` // 引用当前文档的文档
+}
+
+// BrokenLinkDTO 失效链接DTO
+type BrokenLinkDTO struct {
+	SourceDocumentID string `json:"source_document_id"`
+	SourceTitle      string `json:"source_title"`
+	Type             string `json:"type"`
+	Target           string `json:"target"`
+}
+
+// BrokenLinkReportDTO 知识库失效链接报告DTO
+type BrokenLinkReportDTO struct {
+	KnowledgeBaseID string           `json:"knowledge_base_id"`
+	Items           []*BrokenLinkDTO `json:"items"`
+	Total           int              `json:"total"`
+}
@@ -0,0 +1,33 @@
+package dto
+
+import (
+	"time"
+
+	"gozero-ddd/internal/domain/repository"
+)
+
+// KnowledgeBaseMemberDTO 知识库成员DTO
+type KnowledgeBaseMemberDTO struct {
+	KnowledgeBaseID string    `json:"knowledge_base_id"`
+	Subject         string    `json:"subject"`              // 调用方标识（JWT 的 sub）
+	Role            string    `json:"role"`                 // owner / editor / viewer
+	GrantedBy       string    `json:"granted_by,omitempty"` // 授权人标识
+	GrantedAt       time.Time `json:"granted_at"`
+}
+
+// KnowledgeBaseMemberListDTO 知识库成员列表DTO
+type KnowledgeBaseMemberListDTO struct {
+	Items []*KnowledgeBaseMemberDTO `json:"items"`
+	Total int                       `json:"total"`
+}
+
+// KnowledgeBaseMemberFromRecord 从成员记录创建DTO
+func KnowledgeBaseMemberFromRecord(m *repository.KnowledgeBaseMember) *KnowledgeBaseMemberDTO {
+	return &KnowledgeBaseMemberDTO{
+		KnowledgeBaseID: m.KnowledgeBaseID.String(),
+		Subject:         m.Subject,
+		Role:            m.Role.String(),
+		GrantedBy:       m.GrantedBy,
+		GrantedAt:       m.GrantedAt,
+	}
+}
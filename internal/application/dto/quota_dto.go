@@ -0,0 +1,39 @@
+package dto
+
+import (
+	"gozero-ddd/internal/domain/tenant"
+	"gozero-ddd/internal/domain/valueobject"
+)
+
+// QuotaUsageDTO 租户配额用量 DTO
+// 配额上限为 0 表示不限制
+type QuotaUsageDTO struct {
+	TenantID                     string            `json:"tenant_id"`
+	KnowledgeBases               QuotaCounterDTO   `json:"knowledge_bases"`                  // 知识库数量（包括回收站中的）
+	Documents                    int               `json:"documents"`                        // 文档数量（包括回收站中的）
+	MaxDocumentsPerKnowledgeBase int               `json:"max_documents_per_knowledge_base"` // 单个知识库的文档数量上限
+	ContentBytes                 QuotaCounter64DTO `json:"content_bytes"`                    // 文档内容总字节数
+}
+
+// QuotaCounterDTO 计数类配额的用量和上限
+type QuotaCounterDTO struct {
+	Used  int `json:"used"`
+	Limit int `json:"limit"`
+}
+
+// QuotaCounter64DTO 字节数类配额的用量和上限
+type QuotaCounter64DTO struct {
+	Used  int64 `json:"used"`
+	Limit int64 `json:"limit"`
+}
+
+// QuotaUsageFromValueObject 从配额策略和用量创建 DTO
+func QuotaUsageFromValueObject(id tenant.ID, policy valueobject.QuotaPolicy, usage valueobject.QuotaUsage) *QuotaUsageDTO {
+	return &QuotaUsageDTO{
+		TenantID:                     id.String(),
+		KnowledgeBases:               QuotaCounterDTO{Used: usage.KnowledgeBases, Limit: policy.MaxKnowledgeBases},
+		Documents:                    usage.Documents,
+		MaxDocumentsPerKnowledgeBase: policy.MaxDocumentsPerKnowledgeBase,
+		ContentBytes:                 QuotaCounter64DTO{Used: usage.ContentBytes, Limit: policy.MaxContentBytes},
+	}
+}
@@ -0,0 +1,11 @@
+package dto
+
+import "time"
+
+// ScheduleResultDTO 定时发布/下线处理结果DTO
+type ScheduleResultDTO struct {
+	Now       time.Time `json:"now"`       // 本次处理使用的时间基准
+	Published int       `json:"published"` // 定时发布的文档数量
+	Expired   int       `json:"expired"`   // 到期下线的文档数量
+	Failed    int       `json:"failed"`    // 处理失败的文档数量
+}
@@ -0,0 +1,62 @@
+package dto
+
+import (
+	"time"
+
+	"gozero-ddd/internal/domain/entity"
+	"gozero-ddd/internal/domain/valueobject"
+)
+
+// TagDefinitionDTO 标签定义数据传输对象
+type TagDefinitionDTO struct {
+	ID              string    `json:"id"`
+	KnowledgeBaseID string    `json:"knowledge_base_id"`
+	Name            string    `json:"name"`
+	ParentID        string    `json:"parent_id,omitempty"` // 父标签ID，顶层标签为空
+	Aliases         []string  `json:"aliases"`
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
+
+// TagDefinitionFromEntity 从实体转换为DTO
+func TagDefinitionFromEntity(t *entity.TagDefinition) *TagDefinitionDTO {
+	dto := &TagDefinitionDTO{
+		ID:              t.ID().String(),
+		KnowledgeBaseID: t.KnowledgeBaseID().String(),
+		Name:            t.Name().String(),
+		Aliases:         valueobject.TagStrings(t.Aliases()),
+		CreatedAt:       t.CreatedAt(),
+		UpdatedAt:       t.UpdatedAt(),
+	}
+	if t.ParentID() != nil {
+		dto.ParentID = t.ParentID().String()
+	}
+	return dto
+}
+
+// TagChangeResultDTO 标签变更结果DTO
+// 定义、更新、重命名、合并标签时可能改写文档上的标签，结果中列出被改写的文档
+type TagChangeResultDTO struct {
+	KnowledgeBaseID    string            `json:"knowledge_base_id"`
+	Tag                *TagDefinitionDTO `json:"tag,omitempty"` // 变更后的标签定义，标签未在注册表中定义时为空
+	UpdatedDocumentIDs []string          `json:"updated_document_ids"`
+	UpdatedDocuments   int               `json:"updated_documents"`
+}
+
+// TagCountDTO 标签云中的一项
+type TagCountDTO struct {
+	Name       string   `json:"name"`
+	ID         string   `json:"id,omitempty"`        // 标签定义ID，未在注册表中定义时为空
+	ParentID   string   `json:"parent_id,omitempty"` // 父标签ID
+	Aliases    []string `json:"aliases,omitempty"`
+	Registered bool     `json:"registered"`  // 是否已在标签注册表中定义
+	Count      int      `json:"count"`       // 直接使用该标签的文档数量
+	TotalCount int      `json:"total_count"` // 使用该标签或其任一子孙标签的文档数量
+}
+
+// TagCloudDTO 知识库标签云DTO
+type TagCloudDTO struct {
+	KnowledgeBaseID string         `json:"knowledge_base_id"`
+	Tags            []*TagCountDTO `json:"tags"`
+	Total           int            `json:"total"`
+}
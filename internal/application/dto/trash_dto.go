@@ -0,0 +1,16 @@
+package dto
+
+import "time"
+
+// TrashListDTO 回收站列表DTO
+type TrashListDTO struct {
+	KnowledgeBases []*KnowledgeBaseDTO `json:"knowledge_bases"` // 回收站中的知识库
+	Documents      []*DocumentDTO      `json:"documents"`       // 回收站中的文档
+}
+
+// PurgeResultDTO 清理回收站结果DTO
+type PurgeResultDTO struct {
+	Before               time.Time `json:"before"`                 // 清理此时间之前移入回收站的数据
+	KnowledgeBasesPurged int       `json:"knowledge_bases_purged"` // 彻底删除的知识库数量
+	DocumentsPurged      int       `json:"documents_purged"`       // 彻底删除的文档数量
+}
@@ -0,0 +1,110 @@
+package eventhandler
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"gozero-ddd/internal/application/requestmeta"
+	"gozero-ddd/internal/domain/event"
+	"gozero-ddd/internal/domain/repository"
+	"gozero-ddd/internal/domain/tenant"
+)
+
+// AuditLogHandler 审计日志处理器
+// 将所有领域事件连同操作者、租户、请求ID、客户端 IP 写入只追加的审计日志表，
+// 事件携带旧值和新值（Old*/New* 字段）时同时记录变更前后的快照。
+// 这是一个"全局处理器"，处理所有事件
+type AuditLogHandler struct {
+	auditRepo repository.AuditLogRepository
+}
+
+// NewAuditLogHandler 创建审计日志处理器
+func NewAuditLogHandler(auditRepo repository.AuditLogRepository) *AuditLogHandler {
+	return &AuditLogHandler{auditRepo: auditRepo}
+}
+
+// 确保实现了接口
+var _ event.EventHandler = (*AuditLogHandler)(nil)
+
+// EventName 返回空字符串，表示处理所有事件
+func (h *AuditLogHandler) EventName() string {
+	return "" // 空字符串表示处理所有事件
+}
+
+// Handle 记录审计日志
+func (h *AuditLogHandler) Handle(ctx context.Context, evt event.DomainEvent) error {
+	requestmeta.Logf(ctx, "📋 [AuditLog] EventID=%s, EventName=%s, AggregateID=%s, Actor=%s, OccurredAt=%s",
+		evt.EventID(), evt.EventName(), evt.AggregateID(), evt.Actor(), evt.OccurredAt())
+
+	payload, err := eventPayload(evt)
+	if err != nil {
+		return err
+	}
+
+	md := requestmeta.FromContext(ctx)
+	entry := &repository.AuditLogEntry{
+		EventID:     evt.EventID(),
+		TenantID:    tenant.FromContext(ctx).String(),
+		EventName:   evt.EventName(),
+		AggregateID: evt.AggregateID(),
+		Actor:       evt.Actor(),
+		RequestID:   md.RequestID,
+		ClientIP:    md.ClientIP,
+		Payload:     payload,
+		OccurredAt:  evt.OccurredAt(),
+		RecordedAt:  time.Now(),
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(payload, &fields); err == nil {
+		if kbID, ok := fields["KnowledgeBaseID"]; ok {
+			_ = json.Unmarshal(kbID, &entry.KnowledgeBaseID)
+		}
+		entry.Before, entry.After = snapshots(fields)
+	}
+
+	return h.auditRepo.Append(ctx, entry)
+}
+
+// eventPayload 返回事件数据的 JSON
+// 从 Kafka 消费的事件已经是序列化后的数据，直接使用
+func eventPayload(evt event.DomainEvent) (json.RawMessage, error) {
+	if wrapped, ok := evt.(interface{ Payload() json.RawMessage }); ok {
+		return wrapped.Payload(), nil
+	}
+	return json.Marshal(evt)
+}
+
+// snapshots 从事件数据的 Old*/New* 字段中提取变更前后的快照
+// 快照中的字段名去掉 Old/New 前缀，如 OldName、NewName 分别记为变更前后的 Name
+func snapshots(fields map[string]json.RawMessage) (before, after json.RawMessage) {
+	oldValues := make(map[string]json.RawMessage)
+	newValues := make(map[string]json.RawMessage)
+	for key, value := range fields {
+		if name, ok := snapshotField(key, "Old"); ok {
+			oldValues[name] = value
+		} else if name, ok := snapshotField(key, "New"); ok {
+			newValues[name] = value
+		}
+	}
+
+	if len(oldValues) > 0 {
+		before, _ = json.Marshal(oldValues)
+	}
+	if len(newValues) > 0 {
+		after, _ = json.Marshal(newValues)
+	}
+	return before, after
+}
+
+// snapshotField 判断字段是否为带前缀的快照字段，返回去掉前缀后的字段名
+// 前缀之后须为大写字母，避免误判 Newest 之类的字段
+func snapshotField(key, prefix string) (string, bool) {
+	name := strings.TrimPrefix(key, prefix)
+	if name == key || name == "" || name[0] < 'A' || name[0] > 'Z' {
+		return "", false
+	}
+	return name, true
+}
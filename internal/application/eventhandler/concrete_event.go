@@ -0,0 +1,18 @@
+package eventhandler
+
+import (
+	"encoding/json"
+
+	"gozero-ddd/internal/domain/event"
+)
+
+// concreteEvent 返回具体类型的领域事件
+// 同步事件总线传入的已经是具体类型；从 Kafka 消费的事件是只携带序列化数据的包装事件，
+// 按事件名称还原为具体类型，处理器才能按类型取出事件字段
+func concreteEvent(evt event.DomainEvent) (event.DomainEvent, error) {
+	wrapped, ok := evt.(interface{ Payload() json.RawMessage })
+	if !ok {
+		return evt, nil
+	}
+	return event.Decode(evt, wrapped.Payload())
+}
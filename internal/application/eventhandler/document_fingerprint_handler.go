@@ -0,0 +1,96 @@
+package eventhandler
+
+import (
+	"context"
+
+	"gozero-ddd/internal/application/requestmeta"
+	"gozero-ddd/internal/domain/event"
+	"gozero-ddd/internal/domain/repository"
+	"gozero-ddd/internal/domain/service"
+	"gozero-ddd/internal/domain/valueobject"
+)
+
+// DocumentFingerprintHandler 文档内容指纹事件处理器
+// 文档新增、更新或恢复后重新计算其内容指纹，文档或知识库被彻底清除后删除指纹
+// 移入回收站的文档保留指纹，查询重复时由仓储排除
+type DocumentFingerprintHandler struct {
+	docRepo          repository.DocumentRepository
+	duplicateService *service.DuplicateService
+}
+
+// NewDocumentFingerprintHandler 创建文档内容指纹事件处理器
+func NewDocumentFingerprintHandler(
+	docRepo repository.DocumentRepository,
+	duplicateService *service.DuplicateService,
+) *DocumentFingerprintHandler {
+	return &DocumentFingerprintHandler{
+		docRepo:          docRepo,
+		duplicateService: duplicateService,
+	}
+}
+
+// 确保实现了接口
+var _ event.EventHandler = (*DocumentFingerprintHandler)(nil)
+
+// EventName 返回空字符串，表示处理多个事件类型
+func (h *DocumentFingerprintHandler) EventName() string {
+	return ""
+}
+
+// Handle 处理事件，更新文档内容指纹
+func (h *DocumentFingerprintHandler) Handle(ctx context.Context, evt event.DomainEvent) error {
+	evt, err := concreteEvent(evt)
+	if err != nil {
+		return err
+	}
+
+	switch e := evt.(type) {
+	case *event.DocumentAddedEvent:
+		return h.refresh(ctx, e.DocumentID)
+	case *event.DocumentUpdatedEvent:
+		return h.refresh(ctx, e.DocumentID)
+	case *event.DocumentRestoredEvent:
+		return h.refresh(ctx, e.DocumentID)
+	case *event.KnowledgeBaseImportedEvent:
+		return h.refreshKnowledgeBase(ctx, e.KnowledgeBaseID)
+	case *event.DocumentPurgedEvent:
+		return h.duplicateService.ForgetDocument(ctx, e.DocumentID)
+	case *event.KnowledgeBasePurgedEvent:
+		return h.duplicateService.ForgetKnowledgeBase(ctx, e.KnowledgeBaseID)
+	default:
+		// 其他事件不处理
+		return nil
+	}
+}
+
+// refresh 重新计算单篇文档的内容指纹
+func (h *DocumentFingerprintHandler) refresh(ctx context.Context, docID valueobject.DocumentID) error {
+	doc, err := h.docRepo.FindByID(ctx, docID)
+	if err != nil {
+		return err
+	}
+	if doc == nil {
+		// 事件发布前文档已被删除
+		return nil
+	}
+
+	requestmeta.Logf(ctx, "🧬 [Fingerprint] 更新文档内容指纹: DocID=%s", docID)
+	return h.duplicateService.RefreshFingerprint(ctx, doc)
+}
+
+// refreshKnowledgeBase 计算知识库下所有文档的内容指纹
+// 从备份包导入不会为单个文档触发事件
+func (h *DocumentFingerprintHandler) refreshKnowledgeBase(ctx context.Context, kbID valueobject.KnowledgeBaseID) error {
+	docs, err := h.docRepo.FindByKnowledgeBaseID(ctx, kbID)
+	if err != nil {
+		return err
+	}
+
+	requestmeta.Logf(ctx, "🧬 [Fingerprint] 计算知识库文档内容指纹: KnowledgeBaseID=%s, Documents=%d", kbID, len(docs))
+	for _, doc := range docs {
+		if err := h.duplicateService.RefreshFingerprint(ctx, doc); err != nil {
+			return err
+		}
+	}
+	return nil
+}
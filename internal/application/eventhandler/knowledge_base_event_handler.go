@@ -2,8 +2,8 @@ package eventhandler
 
 import (
 	"context"
-	"log"
 
+	"gozero-ddd/internal/application/requestmeta"
 	"gozero-ddd/internal/domain/event"
 )
 
@@ -33,7 +33,7 @@ func (h *KnowledgeBaseCreatedHandler) Handle(ctx context.Context, evt event.Doma
 		return nil
 	}
 
-	log.Printf("📝 [EventHandler] 处理知识库创建事件: EventID=%s, KnowledgeBaseID=%s, Name=%s",
+	requestmeta.Logf(ctx, "📝 [EventHandler] 处理知识库创建事件: EventID=%s, KnowledgeBaseID=%s, Name=%s",
 		e.EventID(), e.KnowledgeBaseID, e.Name)
 
 	// 这里可以执行后续操作：
@@ -69,7 +69,7 @@ func (h *KnowledgeBaseUpdatedHandler) Handle(ctx context.Context, evt event.Doma
 		return nil
 	}
 
-	log.Printf("📝 [EventHandler] 处理知识库更新事件: KnowledgeBaseID=%s, OldName=%s -> NewName=%s",
+	requestmeta.Logf(ctx, "📝 [EventHandler] 处理知识库更新事件: KnowledgeBaseID=%s, OldName=%s -> NewName=%s",
 		e.KnowledgeBaseID, e.OldName, e.NewName)
 
 	// 这里可以执行后续操作：
@@ -104,7 +104,7 @@ func (h *DocumentAddedHandler) Handle(ctx context.Context, evt event.DomainEvent
 		return nil
 	}
 
-	log.Printf("📝 [EventHandler] 处理文档添加事件: DocID=%s, KnowledgeBaseID=%s, Title=%s",
+	requestmeta.Logf(ctx, "📝 [EventHandler] 处理文档添加事件: DocID=%s, KnowledgeBaseID=%s, Title=%s",
 		e.DocumentID, e.KnowledgeBaseID, e.Title)
 
 	// 这里可以执行后续操作：
@@ -139,7 +139,7 @@ func (h *DocumentRemovedHandler) Handle(ctx context.Context, evt event.DomainEve
 		return nil
 	}
 
-	log.Printf("📝 [EventHandler] 处理文档删除事件: DocID=%s, KnowledgeBaseID=%s",
+	requestmeta.Logf(ctx, "📝 [EventHandler] 处理文档删除事件: DocID=%s, KnowledgeBaseID=%s",
 		e.DocumentID, e.KnowledgeBaseID)
 
 	// 这里可以执行后续操作：
@@ -150,35 +150,3 @@ func (h *DocumentRemovedHandler) Handle(ctx context.Context, evt event.DomainEve
 	return nil
 }
 
-// AuditLogHandler 审计日志处理器
-// 示例：记录所有领域事件到审计日志
-// 这是一个"全局处理器"，处理所有事件
-type AuditLogHandler struct{}
-
-// NewAuditLogHandler 创建审计日志处理器
-func NewAuditLogHandler() *AuditLogHandler {
-	return &AuditLogHandler{}
-}
-
-// 确保实现了接口
-var _ event.EventHandler = (*AuditLogHandler)(nil)
-
-// EventName 返回空字符串，表示处理所有事件
-func (h *AuditLogHandler) EventName() string {
-	return "" // 空字符串表示处理所有事件
-}
-
-// Handle 记录审计日志
-func (h *AuditLogHandler) Handle(ctx context.Context, evt event.DomainEvent) error {
-	log.Printf("📋 [AuditLog] EventID=%s, EventName=%s, AggregateID=%s, OccurredAt=%s",
-		evt.EventID(), evt.EventName(), evt.AggregateID(), evt.OccurredAt())
-
-	// 在实际项目中，可以将审计日志：
-	// 1. 写入数据库（创建 audit_logs 表）
-	// 2. 发送到日志服务（如 ELK、Loki）
-	// 3. 写入消息队列供后续分析
-	// 4. 用于事件溯源（Event Sourcing）
-
-	return nil
-}
-
@@ -0,0 +1,44 @@
+package eventhandler
+
+import (
+	"context"
+
+	"gozero-ddd/internal/application/requestmeta"
+	"gozero-ddd/internal/domain/event"
+	"gozero-ddd/internal/domain/repository"
+)
+
+// KnowledgeBaseMemberCleanupHandler 知识库成员清理事件处理器
+// 知识库被彻底清除后删除其成员记录；移入回收站的知识库保留成员，恢复后权限不变
+type KnowledgeBaseMemberCleanupHandler struct {
+	memberRepo repository.KnowledgeBaseMemberRepository
+}
+
+// NewKnowledgeBaseMemberCleanupHandler 创建处理器
+func NewKnowledgeBaseMemberCleanupHandler(memberRepo repository.KnowledgeBaseMemberRepository) *KnowledgeBaseMemberCleanupHandler {
+	return &KnowledgeBaseMemberCleanupHandler{memberRepo: memberRepo}
+}
+
+// 确保实现了接口
+var _ event.EventHandler = (*KnowledgeBaseMemberCleanupHandler)(nil)
+
+// EventName 返回处理的事件名称
+func (h *KnowledgeBaseMemberCleanupHandler) EventName() string {
+	return "knowledge_base.purged"
+}
+
+// Handle 删除被清除知识库的成员记录
+func (h *KnowledgeBaseMemberCleanupHandler) Handle(ctx context.Context, evt event.DomainEvent) error {
+	evt, err := concreteEvent(evt)
+	if err != nil {
+		return err
+	}
+
+	e, ok := evt.(*event.KnowledgeBasePurgedEvent)
+	if !ok {
+		return nil
+	}
+
+	requestmeta.Logf(ctx, "👥 [Member] 删除已清除知识库的成员: KnowledgeBaseID=%s", e.KnowledgeBaseID)
+	return h.memberRepo.DeleteByKnowledgeBaseID(ctx, e.KnowledgeBaseID)
+}
@@ -0,0 +1,50 @@
+package eventhandler
+
+import (
+	"context"
+
+	"gozero-ddd/internal/domain/event"
+	"gozero-ddd/internal/domain/service"
+	"gozero-ddd/internal/domain/valueobject"
+)
+
+// QuotaUsageHandler 租户用量事件处理器
+// 根据知识库和文档的彻底清除事件扣减租户的用量计数
+// 创建、导入和更新占用的用量由配额服务在同一事务中计入，这里只处理提交后释放的用量；
+// 移入回收站和恢复不改变用量：回收站中的数据在被彻底清除之前仍然计入配额
+type QuotaUsageHandler struct {
+	quotas *service.QuotaService
+}
+
+// NewQuotaUsageHandler 创建租户用量事件处理器
+func NewQuotaUsageHandler(quotas *service.QuotaService) *QuotaUsageHandler {
+	return &QuotaUsageHandler{quotas: quotas}
+}
+
+// 确保实现了接口
+var _ event.EventHandler = (*QuotaUsageHandler)(nil)
+
+// EventName 返回空字符串，表示处理多个事件类型
+func (h *QuotaUsageHandler) EventName() string {
+	return ""
+}
+
+// Handle 处理事件，扣减租户用量
+func (h *QuotaUsageHandler) Handle(ctx context.Context, evt event.DomainEvent) error {
+	evt, err := concreteEvent(evt)
+	if err != nil {
+		return err
+	}
+
+	var delta valueobject.QuotaUsage
+	switch e := evt.(type) {
+	case *event.KnowledgeBasePurgedEvent:
+		delta = valueobject.QuotaUsage{KnowledgeBases: -1, Documents: -e.DocumentCount, ContentBytes: -e.ContentBytes}
+	case *event.DocumentPurgedEvent:
+		delta = valueobject.QuotaUsage{Documents: -1, ContentBytes: -e.ContentBytes}
+	default:
+		// 其他事件不处理
+		return nil
+	}
+	return h.quotas.Record(ctx, delta)
+}
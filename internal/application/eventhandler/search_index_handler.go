@@ -2,8 +2,8 @@ package eventhandler
 
 import (
 	"context"
-	"log"
 
+	"gozero-ddd/internal/application/requestmeta"
 	"gozero-ddd/internal/domain/event"
 )
 
@@ -40,6 +40,14 @@ func (h *SearchIndexHandler) Handle(ctx context.Context, evt event.DomainEvent)
 		return h.handleDocumentUpdated(ctx, e)
 	case *event.KnowledgeBaseDeletedEvent:
 		return h.handleKnowledgeBaseDeleted(ctx, e)
+	case *event.KnowledgeBaseTrashedEvent:
+		return h.handleKnowledgeBaseTrashed(ctx, e)
+	case *event.KnowledgeBaseRestoredEvent:
+		return h.handleKnowledgeBaseRestored(ctx, e)
+	case *event.KnowledgeBaseImportedEvent:
+		return h.handleKnowledgeBaseImported(ctx, e)
+	case *event.DocumentRestoredEvent:
+		return h.handleDocumentRestored(ctx, e)
 	default:
 		// 其他事件不处理
 		return nil
@@ -48,7 +56,7 @@ func (h *SearchIndexHandler) Handle(ctx context.Context, evt event.DomainEvent)
 
 // handleDocumentAdded 处理文档添加事件
 func (h *SearchIndexHandler) handleDocumentAdded(ctx context.Context, e *event.DocumentAddedEvent) error {
-	log.Printf("🔍 [SearchIndex] 索引新文档: DocID=%s, Title=%s", e.DocumentID, e.Title)
+	requestmeta.Logf(ctx, "🔍 [SearchIndex] 索引新文档: DocID=%s, Title=%s", e.DocumentID, e.Title)
 
 	// 在实际项目中，这里会：
 	// 1. 从数据库加载文档完整内容
@@ -73,7 +81,7 @@ func (h *SearchIndexHandler) handleDocumentAdded(ctx context.Context, e *event.D
 
 // handleDocumentRemoved 处理文档删除事件
 func (h *SearchIndexHandler) handleDocumentRemoved(ctx context.Context, e *event.DocumentRemovedEvent) error {
-	log.Printf("🔍 [SearchIndex] 从索引删除文档: DocID=%s", e.DocumentID)
+	requestmeta.Logf(ctx, "🔍 [SearchIndex] 从索引删除文档: DocID=%s", e.DocumentID)
 
 	// 在实际项目中，这里会：
 	// h.esClient.Delete(h.indexName, e.DocumentID.String())
@@ -83,7 +91,7 @@ func (h *SearchIndexHandler) handleDocumentRemoved(ctx context.Context, e *event
 
 // handleDocumentUpdated 处理文档更新事件
 func (h *SearchIndexHandler) handleDocumentUpdated(ctx context.Context, e *event.DocumentUpdatedEvent) error {
-	log.Printf("🔍 [SearchIndex] 更新文档索引: DocID=%s, OldTitle=%s -> NewTitle=%s",
+	requestmeta.Logf(ctx, "🔍 [SearchIndex] 更新文档索引: DocID=%s, OldTitle=%s -> NewTitle=%s",
 		e.DocumentID, e.OldTitle, e.NewTitle)
 
 	// 在实际项目中，这里会：
@@ -95,7 +103,7 @@ func (h *SearchIndexHandler) handleDocumentUpdated(ctx context.Context, e *event
 
 // handleKnowledgeBaseDeleted 处理知识库删除事件
 func (h *SearchIndexHandler) handleKnowledgeBaseDeleted(ctx context.Context, e *event.KnowledgeBaseDeletedEvent) error {
-	log.Printf("🔍 [SearchIndex] 删除知识库下所有文档索引: KnowledgeBaseID=%s", e.KnowledgeBaseID)
+	requestmeta.Logf(ctx, "🔍 [SearchIndex] 删除知识库下所有文档索引: KnowledgeBaseID=%s", e.KnowledgeBaseID)
 
 	// 在实际项目中，这里会：
 	// h.esClient.DeleteByQuery(h.indexName, map[string]interface{}{
@@ -109,3 +117,42 @@ func (h *SearchIndexHandler) handleKnowledgeBaseDeleted(ctx context.Context, e *
 	return nil
 }
 
+// handleKnowledgeBaseTrashed 处理知识库移入回收站事件
+// 回收站中的内容不应出现在搜索结果中
+func (h *SearchIndexHandler) handleKnowledgeBaseTrashed(ctx context.Context, e *event.KnowledgeBaseTrashedEvent) error {
+	requestmeta.Logf(ctx, "🔍 [SearchIndex] 知识库移入回收站，删除其文档索引: KnowledgeBaseID=%s", e.KnowledgeBaseID)
+
+	// 在实际项目中，这里与 handleKnowledgeBaseDeleted 相同，按 kb_id 删除索引
+
+	return nil
+}
+
+// handleKnowledgeBaseRestored 处理知识库恢复事件
+func (h *SearchIndexHandler) handleKnowledgeBaseRestored(ctx context.Context, e *event.KnowledgeBaseRestoredEvent) error {
+	requestmeta.Logf(ctx, "🔍 [SearchIndex] 知识库已恢复，重建其文档索引: KnowledgeBaseID=%s", e.KnowledgeBaseID)
+
+	// 在实际项目中，这里会：
+	// 1. 从数据库加载该知识库下的所有文档
+	// 2. 批量写入 Elasticsearch
+
+	return nil
+}
+
+// handleKnowledgeBaseImported 处理从备份包导入知识库事件
+// 导入不会为单个文档触发事件，因此需要按知识库整体建立索引
+func (h *SearchIndexHandler) handleKnowledgeBaseImported(ctx context.Context, e *event.KnowledgeBaseImportedEvent) error {
+	requestmeta.Logf(ctx, "🔍 [SearchIndex] 知识库已导入，建立其文档索引: KnowledgeBaseID=%s, Documents=%d", e.KnowledgeBaseID, e.DocumentCount)
+
+	// 在实际项目中，这里与 handleKnowledgeBaseRestored 相同
+
+	return nil
+}
+
+// handleDocumentRestored 处理文档恢复事件
+func (h *SearchIndexHandler) handleDocumentRestored(ctx context.Context, e *event.DocumentRestoredEvent) error {
+	requestmeta.Logf(ctx, "🔍 [SearchIndex] 文档已恢复，重新索引: DocID=%s, Title=%s", e.DocumentID, e.Title)
+
+	// 在实际项目中，这里与 handleDocumentAdded 相同
+
+	return nil
+}
@@ -0,0 +1,119 @@
+package idempotency
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"time"
+
+	"gozero-ddd/internal/application/auth"
+	"gozero-ddd/internal/domain"
+	"gozero-ddd/internal/domain/repository"
+	"gozero-ddd/internal/domain/tenant"
+)
+
+const (
+	// DefaultTTL 幂等键的默认保留时间
+	DefaultTTL = 24 * time.Hour
+	// MaxKeyLength 幂等键的最大长度
+	MaxKeyLength = 255
+)
+
+// Service 幂等键服务
+// 供接口层的中间件和拦截器使用：请求开始时调用 Begin 占用键，
+// 处理完成后调用 Complete 保存响应；处理失败且允许重试时调用 Release。
+// 幂等键按 Scope 返回的作用范围隔离，不同租户或调用方的相同键互不影响
+type Service struct {
+	repo repository.IdempotencyRepository
+	ttl  time.Duration
+}
+
+// NewService 创建幂等键服务，ttl <= 0 时使用默认保留时间
+func NewService(repo repository.IdempotencyRepository, ttl time.Duration) *Service {
+	if ttl <= 0 {
+		ttl = DefaultTTL
+	}
+	return &Service{repo: repo, ttl: ttl}
+}
+
+// Begin 开始处理带幂等键的请求
+// 返回 nil 表示首次请求，调用方应继续处理；返回记录表示重试，调用方应原样返回记录中的响应
+// 相同的键用于不同请求时返回 ErrIdempotencyKeyReused；首次请求尚未完成时返回 ErrIdempotentRequestInProgress
+func (s *Service) Begin(ctx context.Context, scope repository.IdempotencyScope, fingerprint string) (*repository.IdempotencyRecord, error) {
+	if !ValidKey(scope.Key) {
+		return nil, domain.ErrInvalidIdempotencyKey
+	}
+
+	now := time.Now()
+	existing, err := s.repo.Reserve(ctx, &repository.IdempotencyRecord{
+		IdempotencyScope: scope,
+		Fingerprint:      fingerprint,
+		CreatedAt:        now,
+		ExpiresAt:        now.Add(s.ttl),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if existing == nil {
+		return nil, nil
+	}
+
+	if existing.Fingerprint != fingerprint {
+		return nil, domain.ErrIdempotencyKeyReused
+	}
+	if !existing.Completed {
+		return nil, domain.ErrIdempotentRequestInProgress
+	}
+	return existing, nil
+}
+
+// Complete 保存请求的响应，之后使用相同键的重试会得到该响应
+func (s *Service) Complete(ctx context.Context, scope repository.IdempotencyScope, statusCode int, contentType string, body []byte) error {
+	return s.repo.Complete(ctx, scope, statusCode, contentType, body)
+}
+
+// Release 放弃已占用的键，客户端可以用相同的键重新发起请求
+func (s *Service) Release(ctx context.Context, scope repository.IdempotencyScope) error {
+	return s.repo.Release(ctx, scope)
+}
+
+// PurgeExpired 删除过期的幂等键记录，返回删除数量
+func (s *Service) PurgeExpired(ctx context.Context) (int64, error) {
+	return s.repo.DeleteExpired(ctx, time.Now())
+}
+
+// Scope 返回请求中幂等键的作用范围：上下文中的租户和调用方
+func Scope(ctx context.Context, key string) repository.IdempotencyScope {
+	return repository.IdempotencyScope{
+		TenantID: tenant.FromContext(ctx).String(),
+		Actor:    auth.Actor(ctx),
+		Key:      key,
+	}
+}
+
+// ValidKey 校验幂等键：1-255 个可打印 ASCII 字符
+func ValidKey(key string) bool {
+	if key == "" || len(key) > MaxKeyLength {
+		return false
+	}
+	for i := 0; i < len(key); i++ {
+		if key[i] < 0x21 || key[i] > 0x7e {
+			return false
+		}
+	}
+	return true
+}
+
+// Fingerprint 计算请求指纹
+// 各部分按长度前缀拼接后取 SHA-256，避免不同的拆分方式得到相同的指纹
+func Fingerprint(parts ...[]byte) string {
+	h := sha256.New()
+	var size [8]byte
+	for _, p := range parts {
+		binary.BigEndian.PutUint64(size[:], uint64(len(p)))
+		h.Write(size[:])
+		h.Write(p)
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
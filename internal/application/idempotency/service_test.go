@@ -0,0 +1,177 @@
+package idempotency
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"gozero-ddd/internal/application/auth"
+	"gozero-ddd/internal/domain"
+	"gozero-ddd/internal/domain/repository"
+	"gozero-ddd/internal/domain/tenant"
+)
+
+// memoryRepository 内存幂等键仓储，按作用范围保存记录
+type memoryRepository struct {
+	mu      sync.Mutex
+	records map[repository.IdempotencyScope]*repository.IdempotencyRecord
+}
+
+func newMemoryRepository() *memoryRepository {
+	return &memoryRepository{records: make(map[repository.IdempotencyScope]*repository.IdempotencyRecord)}
+}
+
+func (r *memoryRepository) Reserve(_ context.Context, record *repository.IdempotencyRecord) (*repository.IdempotencyRecord, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if existing, ok := r.records[record.IdempotencyScope]; ok && existing.ExpiresAt.After(time.Now()) {
+		copied := *existing
+		return &copied, nil
+	}
+	copied := *record
+	r.records[record.IdempotencyScope] = &copied
+	return nil, nil
+}
+
+func (r *memoryRepository) Complete(_ context.Context, scope repository.IdempotencyScope, statusCode int, contentType string, body []byte) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if existing, ok := r.records[scope]; ok {
+		existing.Completed = true
+		existing.StatusCode = statusCode
+		existing.ContentType = contentType
+		existing.Body = body
+	}
+	return nil
+}
+
+func (r *memoryRepository) Release(_ context.Context, scope repository.IdempotencyScope) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if existing, ok := r.records[scope]; ok && !existing.Completed {
+		delete(r.records, scope)
+	}
+	return nil
+}
+
+func (r *memoryRepository) DeleteExpired(_ context.Context, before time.Time) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var n int64
+	for scope, record := range r.records {
+		if !record.ExpiresAt.After(before) {
+			delete(r.records, scope)
+			n++
+		}
+	}
+	return n, nil
+}
+
+// requestContext 构造携带租户和调用方的上下文，subject 为空表示未启用认证
+func requestContext(tenantID tenant.ID, subject string) context.Context {
+	ctx := tenant.WithID(context.Background(), tenantID)
+	if subject != "" {
+		ctx = auth.WithPrincipal(ctx, &auth.Principal{Subject: subject})
+	}
+	return ctx
+}
+
+func TestServiceReplayAndMismatch(t *testing.T) {
+	s := NewService(newMemoryRepository(), time.Hour)
+	ctx := requestContext("acme", "alice")
+	scope := Scope(ctx, "key-1")
+	fingerprint := Fingerprint([]byte("POST"), []byte("/api/v1/knowledge"), []byte(`{"name":"a"}`))
+
+	if record, err := s.Begin(ctx, scope, fingerprint); err != nil || record != nil {
+		t.Fatalf("first Begin() = %v, %v, want nil, nil", record, err)
+	}
+
+	// 首次请求完成前重试
+	if _, err := s.Begin(ctx, scope, fingerprint); !errors.Is(err, domain.ErrIdempotentRequestInProgress) {
+		t.Fatalf("Begin() while in progress error = %v, want %v", err, domain.ErrIdempotentRequestInProgress)
+	}
+
+	if err := s.Complete(ctx, scope, http.StatusCreated, "application/json", []byte(`{"id":"1"}`)); err != nil {
+		t.Fatal(err)
+	}
+
+	record, err := s.Begin(ctx, scope, fingerprint)
+	if err != nil {
+		t.Fatalf("Begin() retry error = %v", err)
+	}
+	if record == nil || record.StatusCode != http.StatusCreated || string(record.Body) != `{"id":"1"}` {
+		t.Fatalf("Begin() retry = %+v, want stored response", record)
+	}
+
+	other := Fingerprint([]byte("POST"), []byte("/api/v1/knowledge"), []byte(`{"name":"b"}`))
+	if _, err := s.Begin(ctx, scope, other); !errors.Is(err, domain.ErrIdempotencyKeyReused) {
+		t.Fatalf("Begin() with other request error = %v, want %v", err, domain.ErrIdempotencyKeyReused)
+	}
+}
+
+func TestServiceRelease(t *testing.T) {
+	s := NewService(newMemoryRepository(), time.Hour)
+	ctx := requestContext("acme", "alice")
+	scope := Scope(ctx, "key-1")
+
+	if _, err := s.Begin(ctx, scope, "f1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Release(ctx, scope); err != nil {
+		t.Fatal(err)
+	}
+	// 释放后可以用相同的键发起不同的请求
+	if record, err := s.Begin(ctx, scope, "f2"); err != nil || record != nil {
+		t.Fatalf("Begin() after Release() = %v, %v, want nil, nil", record, err)
+	}
+}
+
+func TestServiceScopesKeys(t *testing.T) {
+	s := NewService(newMemoryRepository(), time.Hour)
+	owner := requestContext("acme", "alice")
+	if _, err := s.Begin(owner, Scope(owner, "1"), "f1"); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+	}{
+		{"other tenant", requestContext("globex", "alice")},
+		{"other actor", requestContext("acme", "bob")},
+		{"no actor", requestContext("acme", "")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// 相同的键和不同的请求：其他作用范围内是首次请求，而不是 409 或 422
+			record, err := s.Begin(tt.ctx, Scope(tt.ctx, "1"), "f2")
+			if err != nil || record != nil {
+				t.Fatalf("Begin() = %v, %v, want nil, nil", record, err)
+			}
+		})
+	}
+}
+
+func TestServiceRejectsInvalidKey(t *testing.T) {
+	s := NewService(newMemoryRepository(), time.Hour)
+	ctx := requestContext(tenant.Default, "")
+	for _, key := range []string{"", "has space", "non-ascii-é", string(make([]byte, MaxKeyLength+1))} {
+		if _, err := s.Begin(ctx, Scope(ctx, key), "f"); !errors.Is(err, domain.ErrInvalidIdempotencyKey) {
+			t.Errorf("Begin(%q) error = %v, want %v", key, err, domain.ErrInvalidIdempotencyKey)
+		}
+	}
+}
+
+func TestScope(t *testing.T) {
+	got := Scope(requestContext("acme", "alice"), "k")
+	want := repository.IdempotencyScope{TenantID: "acme", Actor: "alice", Key: "k"}
+	if got != want {
+		t.Errorf("Scope() = %+v, want %+v", got, want)
+	}
+	if got := Scope(context.Background(), "k"); got.TenantID != tenant.Default.String() || got.Actor != "" {
+		t.Errorf("Scope() without tenant and principal = %+v", got)
+	}
+}
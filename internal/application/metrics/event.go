@@ -0,0 +1,83 @@
+package metrics
+
+import (
+	"context"
+
+	"gozero-ddd/internal/application/cqrs"
+	"gozero-ddd/internal/domain/event"
+)
+
+// Publisher 记录监控指标的事件发布器
+// 包装事件总线，按事件名称统计发布成功和失败的事件数
+type Publisher struct {
+	next event.EventPublisher
+}
+
+// NewPublisher 创建记录监控指标的事件发布器
+func NewPublisher(next event.EventPublisher) *Publisher {
+	return &Publisher{next: next}
+}
+
+// 确保实现了接口
+var _ event.EventPublisher = (*Publisher)(nil)
+
+// Publish 发布单个事件
+func (p *Publisher) Publish(ctx context.Context, evt event.DomainEvent) error {
+	err := p.next.Publish(ctx, evt)
+	observePublished(err, evt)
+	return err
+}
+
+// PublishAll 发布多个事件
+// 批量发布失败时无法区分哪些事件已经发出，全部记为失败
+func (p *Publisher) PublishAll(ctx context.Context, events []event.DomainEvent) error {
+	err := p.next.PublishAll(ctx, events)
+	observePublished(err, events...)
+	return err
+}
+
+// observePublished 记录事件发布结果
+func observePublished(err error, events ...event.DomainEvent) {
+	for _, evt := range events {
+		if err != nil {
+			eventsFailed.Inc(evt.EventName(), stagePublish, "")
+			continue
+		}
+		eventsPublished.Inc(evt.EventName())
+	}
+}
+
+// EventHandler 记录监控指标的事件处理器
+// 按事件名称和处理器统计处理和处理失败的事件数
+type EventHandler struct {
+	next event.EventHandler
+	name string
+}
+
+// NewEventHandler 创建记录监控指标的事件处理器
+func NewEventHandler(next event.EventHandler) *EventHandler {
+	return &EventHandler{next: next, name: cqrs.EventHandlerName(next)}
+}
+
+// 确保实现了接口
+var _ event.EventHandler = (*EventHandler)(nil)
+
+// EventName 返回被包装处理器处理的事件名称
+func (h *EventHandler) EventName() string {
+	return h.next.EventName()
+}
+
+// Unwrap 返回被包装的处理器
+func (h *EventHandler) Unwrap() event.EventHandler {
+	return h.next
+}
+
+// Handle 处理事件并记录结果
+func (h *EventHandler) Handle(ctx context.Context, evt event.DomainEvent) error {
+	err := h.next.Handle(ctx, evt)
+	eventsConsumed.Inc(evt.EventName(), h.name)
+	if err != nil {
+		eventsFailed.Inc(evt.EventName(), stageHandle, h.name)
+	}
+	return err
+}
@@ -0,0 +1,44 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"gozero-ddd/internal/application/cqrs"
+)
+
+// Command 为命令处理器记录执行次数（按结果和错误类别）和耗时
+func Command[Req, Res any](name string, next func(ctx context.Context, req Req) (Res, error)) cqrs.HandlerFunc[Req, Res] {
+	return func(ctx context.Context, req Req) (Res, error) {
+		start := time.Now()
+		res, err := next(ctx, req)
+		observeCommand(name, start, err)
+		return res, err
+	}
+}
+
+// VoidCommand 为只返回错误的命令处理器记录执行次数和耗时
+func VoidCommand[Req any](name string, next func(ctx context.Context, req Req) error) cqrs.VoidHandlerFunc[Req] {
+	return func(ctx context.Context, req Req) error {
+		start := time.Now()
+		err := next(ctx, req)
+		observeCommand(name, start, err)
+		return err
+	}
+}
+
+// Query 为查询处理器记录耗时
+func Query[Req, Res any](name string, next func(ctx context.Context, req Req) (Res, error)) cqrs.HandlerFunc[Req, Res] {
+	return func(ctx context.Context, req Req) (Res, error) {
+		start := time.Now()
+		res, err := next(ctx, req)
+		queryDuration.Observe(time.Since(start).Milliseconds(), name, result(err))
+		return res, err
+	}
+}
+
+// observeCommand 记录一次命令执行
+func observeCommand(name string, start time.Time, err error) {
+	commandTotal.Inc(name, result(err), ErrorClass(err))
+	commandDuration.Observe(time.Since(start).Milliseconds(), name, result(err))
+}
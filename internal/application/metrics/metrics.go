@@ -0,0 +1,193 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/zeromicro/go-zero/core/metric"
+
+	"gozero-ddd/internal/domain"
+)
+
+// namespace 本项目指标的命名空间
+const namespace = "knowledge"
+
+// durationBuckets 耗时直方图的分桶（毫秒）
+var durationBuckets = []float64{5, 10, 25, 50, 100, 250, 500, 1000, 2500, 5000}
+
+// 指标定义
+// 通过 go-zero 的 metric 包注册到 Prometheus 默认注册表，由配置文件 Prometheus 节启动的
+// go-zero 指标端点（默认 :9101/metrics）暴露；未配置 Prometheus 时不记录
+var (
+	commandTotal = metric.NewCounterVec(&metric.CounterVecOpts{
+		Namespace: namespace,
+		Subsystem: "command",
+		Name:      "total",
+		Help:      "命令执行次数，按命令、结果和错误类别统计",
+		Labels:    []string{"command", "result", "error_class"},
+	})
+	commandDuration = metric.NewHistogramVec(&metric.HistogramVecOpts{
+		Namespace: namespace,
+		Subsystem: "command",
+		Name:      "duration_ms",
+		Help:      "命令执行耗时（毫秒）",
+		Labels:    []string{"command", "result"},
+		Buckets:   durationBuckets,
+	})
+
+	queryDuration = metric.NewHistogramVec(&metric.HistogramVecOpts{
+		Namespace: namespace,
+		Subsystem: "query",
+		Name:      "duration_ms",
+		Help:      "查询执行耗时（毫秒）",
+		Labels:    []string{"query", "result"},
+		Buckets:   durationBuckets,
+	})
+
+	transactionDuration = metric.NewHistogramVec(&metric.HistogramVecOpts{
+		Namespace: namespace,
+		Subsystem: "transaction",
+		Name:      "duration_ms",
+		Help:      "事务耗时（毫秒），按提交或回滚统计",
+		Labels:    []string{"result"},
+		Buckets:   durationBuckets,
+	})
+	transactionRollbacks = metric.NewCounterVec(&metric.CounterVecOpts{
+		Namespace: namespace,
+		Subsystem: "transaction",
+		Name:      "rollbacks_total",
+		Help:      "事务回滚次数",
+	})
+
+	eventsPublished = metric.NewCounterVec(&metric.CounterVecOpts{
+		Namespace: namespace,
+		Subsystem: "events",
+		Name:      "published_total",
+		Help:      "发布成功的事件数，按事件名称统计",
+		Labels:    []string{"event"},
+	})
+	eventsConsumed = metric.NewCounterVec(&metric.CounterVecOpts{
+		Namespace: namespace,
+		Subsystem: "events",
+		Name:      "consumed_total",
+		Help:      "事件处理器处理的事件数，按事件名称和处理器统计",
+		Labels:    []string{"event", "handler"},
+	})
+	eventsFailed = metric.NewCounterVec(&metric.CounterVecOpts{
+		Namespace: namespace,
+		Subsystem: "events",
+		Name:      "failed_total",
+		Help:      "发布或处理失败的事件数，stage 为 publish 或 handle，handler 为处理器名称（发布失败时为空）",
+		Labels:    []string{"event", "stage", "handler"},
+	})
+
+	kafkaConsumerLag = metric.NewGaugeVec(&metric.GaugeVecOpts{
+		Namespace: namespace,
+		Subsystem: "kafka",
+		Name:      "consumer_lag",
+		Help:      "Kafka 消费者落后的消息数，按主题和分区统计",
+		Labels:    []string{"topic", "partition"},
+	})
+
+	knowledgeBases = metric.NewGaugeVec(&metric.GaugeVecOpts{
+		Namespace: namespace,
+		Subsystem: "inventory",
+		Name:      "knowledge_bases",
+		Help:      "知识库总数（不含回收站），按状态统计",
+		Labels:    []string{"status"},
+	})
+	documents = metric.NewGaugeVec(&metric.GaugeVecOpts{
+		Namespace: namespace,
+		Subsystem: "inventory",
+		Name:      "documents",
+		Help:      "文档总数（不含回收站），按状态统计",
+		Labels:    []string{"status"},
+	})
+)
+
+// 结果标签值
+const (
+	resultSuccess  = "success"
+	resultError    = "error"
+	resultCommit   = "commit"
+	resultRollback = "rollback"
+)
+
+// 事件失败阶段的 stage 标签值
+const (
+	stagePublish = "publish"
+	stageHandle  = "handle"
+)
+
+// ErrorClass 返回错误类别，作为指标标签
+// 领域错误按接口层映射状态码的分类归类，其余错误归为 internal
+func ErrorClass(err error) string {
+	switch {
+	case err == nil:
+		return "none"
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		return "canceled"
+	case domain.IsUnauthenticatedError(err):
+		return "unauthenticated"
+	case domain.IsForbiddenError(err):
+		return "forbidden"
+	case errors.Is(err, domain.ErrQuotaExceeded):
+		return "quota_exceeded"
+	case errors.Is(err, domain.ErrRateLimitExceeded):
+		return "rate_limited"
+	case domain.IsNotFoundError(err):
+		return "not_found"
+	case domain.IsValidationError(err):
+		return "validation"
+	case domain.IsPreconditionError(err):
+		return "precondition"
+	case domain.IsConflictError(err):
+		return "conflict"
+	case domain.IsDomainError(err):
+		return "domain"
+	default:
+		return "internal"
+	}
+}
+
+// ObserveTransaction 记录事务耗时，err 不为 nil 时记为回滚
+func ObserveTransaction(start time.Time, err error) {
+	result := resultCommit
+	if err != nil {
+		result = resultRollback
+		transactionRollbacks.Inc()
+	}
+	transactionDuration.Observe(time.Since(start).Milliseconds(), result)
+}
+
+// SetKafkaConsumerLag 记录 Kafka 消费者在分区上落后的消息数
+func SetKafkaConsumerLag(topic string, partition int, lag int64) {
+	if lag < 0 {
+		lag = 0
+	}
+	kafkaConsumerLag.Set(float64(lag), topic, strconv.Itoa(partition))
+}
+
+// SetKnowledgeBaseCounts 记录各状态的知识库总数
+func SetKnowledgeBaseCounts(counts map[string]int) {
+	for status, n := range counts {
+		knowledgeBases.Set(float64(n), status)
+	}
+}
+
+// SetDocumentCounts 记录各状态的文档总数
+func SetDocumentCounts(counts map[string]int) {
+	for status, n := range counts {
+		documents.Set(float64(n), status)
+	}
+}
+
+// result 返回结果标签值
+func result(err error) string {
+	if err != nil {
+		return resultError
+	}
+	return resultSuccess
+}
@@ -0,0 +1,37 @@
+package query
+
+import (
+	"gozero-ddd/internal/application/dto"
+	"gozero-ddd/internal/domain/entity"
+	"gozero-ddd/internal/domain/service"
+	"gozero-ddd/internal/domain/valueobject"
+)
+
+// renderDocument 按输出格式转换文档DTO
+// raw 格式原样返回内容；html 格式返回净化后的 HTML 和目录；text 格式返回纯文本
+// withTOC 为 true 时无论何种格式都附带目录
+func renderDocument(renderer service.ContentRenderer, doc *entity.Document, format valueobject.ContentFormat, withTOC bool) (*dto.DocumentDTO, error) {
+	result := dto.DocumentFromEntity(doc)
+	result.Format = format.String()
+	if format == valueobject.ContentFormatRaw && !withTOC {
+		return result, nil
+	}
+
+	rendered, err := renderer.Render(doc.ContentType(), doc.Content())
+	if err != nil {
+		return nil, err
+	}
+
+	switch format {
+	case valueobject.ContentFormatHTML:
+		result.Content = rendered.HTML
+		result.TOC = dto.HeadingsFromValueObjects(rendered.TOC)
+	case valueobject.ContentFormatText:
+		result.Content = rendered.Text
+		result.Excerpt = rendered.Excerpt
+	}
+	if withTOC {
+		result.TOC = dto.HeadingsFromValueObjects(rendered.TOC)
+	}
+	return result, nil
+}
@@ -0,0 +1,407 @@
+package query
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"gozero-ddd/internal/application/auth"
+	"gozero-ddd/internal/application/dto"
+	"gozero-ddd/internal/domain"
+	"gozero-ddd/internal/domain/entity"
+	"gozero-ddd/internal/domain/repository"
+	"gozero-ddd/internal/domain/service"
+	"gozero-ddd/internal/domain/valueobject"
+)
+
+// 导出文件中各内容类型使用的扩展名
+var exportExtensions = map[valueobject.ContentType]string{
+	valueobject.ContentTypeMarkdown: ".md",
+	valueobject.ContentTypeHTML:     ".html",
+	valueobject.ContentTypeText:     ".txt",
+	valueobject.ContentTypeAsciiDoc: ".adoc",
+}
+
+// ExportKnowledgeBaseQuery 导出知识库查询
+type ExportKnowledgeBaseQuery struct {
+	KnowledgeBaseID string
+	Format          string // markdown / jsonl / backup，为空时使用 markdown
+}
+
+// KnowledgeBaseExport 知识库导出结果
+// 查询阶段只加载数据并完成校验，内容在 Write 时流式生成，
+// 因此调用方可以在写出响应头之前处理查询错误
+type KnowledgeBaseExport struct {
+	FileName  string // 建议的下载文件名
+	MediaType string
+
+	format            string
+	kb                *entity.KnowledgeBase
+	attachmentService *service.AttachmentService
+	exportedAt        time.Time
+}
+
+// ExportKnowledgeBaseHandler 导出知识库查询处理器
+type ExportKnowledgeBaseHandler struct {
+	kbRepo            repository.KnowledgeBaseRepository
+	attachmentService *service.AttachmentService
+	permissions       *auth.PermissionChecker // 权限检查器
+}
+
+// NewExportKnowledgeBaseHandler 创建处理器
+func NewExportKnowledgeBaseHandler(kbRepo repository.KnowledgeBaseRepository, attachmentService *service.AttachmentService, permissions *auth.PermissionChecker) *ExportKnowledgeBaseHandler {
+	return &ExportKnowledgeBaseHandler{
+		kbRepo:            kbRepo,
+		attachmentService: attachmentService,
+		permissions:       permissions,
+	}
+}
+
+// Handle 处理导出知识库查询
+func (h *ExportKnowledgeBaseHandler) Handle(ctx context.Context, query *ExportKnowledgeBaseQuery) (*KnowledgeBaseExport, error) {
+	// 验证 ID 格式
+	kbID, err := valueobject.KnowledgeBaseIDFromString(query.KnowledgeBaseID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 检查调用方权限
+	if err := h.permissions.Check(ctx, kbID, valueobject.MemberRoleViewer); err != nil {
+		return nil, err
+	}
+
+	format := query.Format
+	if format == "" {
+		format = dto.ExportFormatMarkdown
+	}
+
+	var ext, mediaType string
+	switch format {
+	case dto.ExportFormatMarkdown:
+		ext, mediaType = ".zip", "application/zip"
+	case dto.ExportFormatJSONL:
+		ext, mediaType = ".jsonl", "application/x-ndjson"
+	case dto.ExportFormatBackup:
+		ext, mediaType = ".kbbackup.zip", "application/zip"
+	default:
+		return nil, domain.ErrInvalidExportFormat
+	}
+
+	kb, err := h.kbRepo.FindByID(ctx, kbID)
+	if err != nil {
+		return nil, err
+	}
+	if kb == nil {
+		return nil, domain.ErrKnowledgeBaseNotFound
+	}
+
+	now := time.Now()
+	return &KnowledgeBaseExport{
+		FileName:          sanitizeExportName(kb.Name(), "knowledge-base") + "-" + now.Format("20060102-150405") + ext,
+		MediaType:         mediaType,
+		format:            format,
+		kb:                kb,
+		attachmentService: h.attachmentService,
+		exportedAt:        now,
+	}, nil
+}
+
+// Write 将导出内容写入 w
+func (e *KnowledgeBaseExport) Write(ctx context.Context, w io.Writer) error {
+	switch e.format {
+	case dto.ExportFormatJSONL:
+		return e.writeJSONL(w)
+	case dto.ExportFormatBackup:
+		return e.writeBackup(ctx, w)
+	default:
+		return e.writeMarkdown(w)
+	}
+}
+
+// writeMarkdown 导出为 Markdown 压缩包，文件夹层级映射为目录，元数据写入 YAML front matter
+func (e *KnowledgeBaseExport) writeMarkdown(w io.Writer) error {
+	folderPaths := exportFolderPaths(e.kb.Folders())
+	used := make(map[string]bool)
+
+	zw := zip.NewWriter(w)
+	for _, doc := range sortedDocuments(e.kb.Documents()) {
+		dir := ""
+		if doc.FolderID() != nil {
+			dir = folderPaths[*doc.FolderID()]
+		}
+		name := uniqueExportPath(used, dir, sanitizeExportName(doc.Title(), "untitled"), exportExtensions[doc.ContentType()])
+
+		content, err := markdownWithFrontMatter(doc)
+		if err != nil {
+			return err
+		}
+		fw, err := zw.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Deflate, Modified: doc.UpdatedAt()})
+		if err != nil {
+			return err
+		}
+		if _, err := fw.Write(content); err != nil {
+			return err
+		}
+	}
+	return zw.Close()
+}
+
+// writeJSONL 导出为 JSON Lines，每行一个文档
+func (e *KnowledgeBaseExport) writeJSONL(w io.Writer) error {
+	folderPaths := exportFolderPaths(e.kb.Folders())
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+
+	for _, doc := range sortedDocuments(e.kb.Documents()) {
+		line := &dto.ExportDocumentDTO{
+			ID:          doc.ID().String(),
+			Title:       doc.Title(),
+			Content:     doc.Content(),
+			ContentType: doc.ContentType().String(),
+			Tags:        doc.Tags(),
+			Status:      doc.Status().String(),
+			PublishAt:   doc.PublishAt(),
+			ExpireAt:    doc.ExpireAt(),
+			CreatedAt:   doc.CreatedAt(),
+			UpdatedAt:   doc.UpdatedAt(),
+		}
+		if doc.FolderID() != nil {
+			line.FolderPath = folderPaths[*doc.FolderID()]
+		}
+		if err := enc.Encode(line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeBackup 导出为备份包，结构见 dto.BackupManifest
+func (e *KnowledgeBaseExport) writeBackup(ctx context.Context, w io.Writer) error {
+	kb := e.kb
+	zw := zip.NewWriter(w)
+
+	manifest := &dto.BackupManifest{
+		Format:          dto.BackupFormat,
+		Version:         dto.BackupVersion,
+		ExportedAt:      e.exportedAt,
+		KnowledgeBaseID: kb.ID().String(),
+		Documents:       len(kb.Documents()),
+		Folders:         len(kb.Folders()),
+		Tags:            len(kb.TagDefinitions()),
+		Attachments:     len(kb.Attachments()),
+	}
+	if err := writeZipJSON(zw, dto.BackupManifestFile, manifest); err != nil {
+		return err
+	}
+	if err := writeZipJSON(zw, dto.BackupKnowledgeBaseFile, dto.BackupKnowledgeBaseFromEntity(kb)); err != nil {
+		return err
+	}
+
+	folders := make([]*dto.BackupFolder, 0, len(kb.Folders()))
+	for _, f := range kb.Folders() {
+		folders = append(folders, dto.BackupFolderFromEntity(f))
+	}
+	if err := writeZipJSON(zw, dto.BackupFoldersFile, folders); err != nil {
+		return err
+	}
+
+	tags := make([]*dto.BackupTag, 0, len(kb.TagDefinitions()))
+	for _, t := range kb.TagDefinitions() {
+		tags = append(tags, dto.BackupTagFromEntity(t))
+	}
+	if err := writeZipJSON(zw, dto.BackupTagsFile, tags); err != nil {
+		return err
+	}
+
+	fw, err := zw.Create(dto.BackupDocumentsFile)
+	if err != nil {
+		return err
+	}
+	enc := json.NewEncoder(fw)
+	enc.SetEscapeHTML(false)
+	for _, doc := range sortedDocuments(kb.Documents()) {
+		if err := enc.Encode(dto.BackupDocumentFromEntity(doc)); err != nil {
+			return err
+		}
+	}
+
+	attachments := make([]*dto.BackupAttachment, 0, len(kb.Attachments()))
+	for _, att := range kb.Attachments() {
+		attachments = append(attachments, dto.BackupAttachmentFromEntity(att))
+	}
+	if err := writeZipJSON(zw, dto.BackupAttachmentsFile, attachments); err != nil {
+		return err
+	}
+
+	// 附件内容按哈希去重，相同内容只写一次
+	written := make(map[valueobject.ContentHash]bool)
+	for _, att := range kb.Attachments() {
+		if written[att.ContentHash()] {
+			continue
+		}
+		written[att.ContentHash()] = true
+		if err := e.writeBlob(ctx, zw, att); err != nil {
+			return err
+		}
+	}
+
+	return zw.Close()
+}
+
+// writeBlob 将附件内容写入备份包（已压缩的内容不再压缩）
+func (e *KnowledgeBaseExport) writeBlob(ctx context.Context, zw *zip.Writer, att *entity.Attachment) error {
+	rc, err := e.attachmentService.OpenContent(ctx, att)
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+
+	fw, err := zw.CreateHeader(&zip.FileHeader{
+		Name:     dto.BackupBlobDir + att.ContentHash().String(),
+		Method:   zip.Store,
+		Modified: att.CreatedAt(),
+	})
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(fw, rc)
+	return err
+}
+
+// writeZipJSON 以缩进 JSON 写入压缩包中的一个文件
+func writeZipJSON(zw *zip.Writer, name string, v interface{}) error {
+	fw, err := zw.Create(name)
+	if err != nil {
+		return err
+	}
+	enc := json.NewEncoder(fw)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+// exportFrontMatter Markdown 导出的 front matter，字段与导入时识别的字段兼容
+type exportFrontMatter struct {
+	ID          string     `yaml:"id"`
+	Title       string     `yaml:"title"`
+	Tags        []string   `yaml:"tags,flow"`
+	Status      string     `yaml:"status"`
+	ContentType string     `yaml:"content_type"`
+	PublishAt   *time.Time `yaml:"publish_at,omitempty"`
+	ExpireAt    *time.Time `yaml:"expire_at,omitempty"`
+	CreatedAt   time.Time  `yaml:"created_at"`
+	UpdatedAt   time.Time  `yaml:"updated_at"`
+}
+
+// markdownWithFrontMatter 生成带 YAML front matter 的文档内容
+func markdownWithFrontMatter(doc *entity.Document) ([]byte, error) {
+	tags := doc.Tags()
+	if tags == nil {
+		tags = make([]string, 0)
+	}
+	front, err := yaml.Marshal(&exportFrontMatter{
+		ID:          doc.ID().String(),
+		Title:       doc.Title(),
+		Tags:        tags,
+		Status:      doc.Status().String(),
+		ContentType: doc.ContentType().String(),
+		PublishAt:   doc.PublishAt(),
+		ExpireAt:    doc.ExpireAt(),
+		CreatedAt:   doc.CreatedAt(),
+		UpdatedAt:   doc.UpdatedAt(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("---\n")
+	buf.Write(front)
+	buf.WriteString("---\n\n")
+	buf.WriteString(doc.Content())
+	if !strings.HasSuffix(doc.Content(), "\n") {
+		buf.WriteByte('\n')
+	}
+	return buf.Bytes(), nil
+}
+
+// exportFolderPaths 计算每个文件夹在压缩包中的目录路径
+func exportFolderPaths(folders []*entity.Folder) map[valueobject.FolderID]string {
+	byID := make(map[valueobject.FolderID]*entity.Folder, len(folders))
+	for _, f := range folders {
+		byID[f.ID()] = f
+	}
+
+	paths := make(map[valueobject.FolderID]string, len(folders))
+	var resolve func(f *entity.Folder, depth int) string
+	resolve = func(f *entity.Folder, depth int) string {
+		if p, ok := paths[f.ID()]; ok {
+			return p
+		}
+		name := sanitizeExportName(f.Name(), "folder")
+		p := name
+		// depth 防止数据异常时出现环导致无限递归
+		if f.ParentID() != nil && depth < len(folders) {
+			if parent, ok := byID[*f.ParentID()]; ok {
+				p = path.Join(resolve(parent, depth+1), name)
+			}
+		}
+		paths[f.ID()] = p
+		return p
+	}
+	for _, f := range folders {
+		resolve(f, 0)
+	}
+	return paths
+}
+
+// uniqueExportPath 生成不重复的文件路径，同名文件追加 " (2)"、" (3)" 等后缀
+func uniqueExportPath(used map[string]bool, dir, name, ext string) string {
+	candidate := path.Join(dir, name+ext)
+	for i := 2; used[strings.ToLower(candidate)]; i++ {
+		candidate = path.Join(dir, fmt.Sprintf("%s (%d)%s", name, i, ext))
+	}
+	used[strings.ToLower(candidate)] = true
+	return candidate
+}
+
+// sanitizeExportName 将标题转换为可用作文件名的字符串
+// 去掉路径分隔符和各平台文件名中不允许的字符，结果为空时使用 fallback
+func sanitizeExportName(name, fallback string) string {
+	name = strings.Map(func(r rune) rune {
+		switch {
+		case r < 0x20 || r == 0x7f:
+			return -1
+		case strings.ContainsRune(`/\:*?"<>|`, r):
+			return '_'
+		}
+		return r
+	}, name)
+	name = strings.Trim(strings.TrimSpace(name), ".")
+	if runes := []rune(name); len(runes) > 100 {
+		name = strings.TrimSpace(string(runes[:100]))
+	}
+	if name == "" {
+		return fallback
+	}
+	return name
+}
+
+// sortedDocuments 按创建时间排序文档，使导出结果稳定
+func sortedDocuments(docs []*entity.Document) []*entity.Document {
+	sorted := make([]*entity.Document, len(docs))
+	copy(sorted, docs)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].CreatedAt().Before(sorted[j].CreatedAt())
+	})
+	return sorted
+}
@@ -0,0 +1,84 @@
+package query
+
+import (
+	"context"
+	"io"
+
+	"gozero-ddd/internal/application/auth"
+	"gozero-ddd/internal/application/dto"
+	"gozero-ddd/internal/domain"
+	"gozero-ddd/internal/domain/repository"
+	"gozero-ddd/internal/domain/service"
+	"gozero-ddd/internal/domain/valueobject"
+)
+
+// GetAttachmentContentQuery 下载附件查询
+type GetAttachmentContentQuery struct {
+	KnowledgeBaseID string
+	AttachmentID    string
+}
+
+// AttachmentContent 附件元数据及其内容
+// Content 由调用方读取后关闭
+type AttachmentContent struct {
+	Attachment *dto.AttachmentDTO
+	Content    io.ReadCloser
+}
+
+// GetAttachmentContentHandler 下载附件查询处理器
+type GetAttachmentContentHandler struct {
+	kbRepo            repository.KnowledgeBaseRepository
+	attachmentService *service.AttachmentService
+	permissions       *auth.PermissionChecker // 权限检查器
+}
+
+// NewGetAttachmentContentHandler 创建处理器
+func NewGetAttachmentContentHandler(kbRepo repository.KnowledgeBaseRepository, attachmentService *service.AttachmentService, permissions *auth.PermissionChecker) *GetAttachmentContentHandler {
+	return &GetAttachmentContentHandler{
+		kbRepo:            kbRepo,
+		attachmentService: attachmentService,
+		permissions:       permissions,
+	}
+}
+
+// Handle 处理下载附件查询
+func (h *GetAttachmentContentHandler) Handle(ctx context.Context, query *GetAttachmentContentQuery) (*AttachmentContent, error) {
+	// 验证 ID 格式
+	kbID, err := valueobject.KnowledgeBaseIDFromString(query.KnowledgeBaseID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 检查调用方权限
+	if err := h.permissions.Check(ctx, kbID, valueobject.MemberRoleViewer); err != nil {
+		return nil, err
+	}
+
+	attID, err := valueobject.AttachmentIDFromString(query.AttachmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	kb, err := h.kbRepo.FindByID(ctx, kbID)
+	if err != nil {
+		return nil, err
+	}
+	if kb == nil {
+		return nil, domain.ErrKnowledgeBaseNotFound
+	}
+
+	att, err := kb.GetAttachment(attID)
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := h.attachmentService.OpenContent(ctx, att)
+	if err != nil {
+		return nil, err
+	}
+
+	return &AttachmentContent{
+		Attachment: dto.AttachmentFromEntity(att),
+		Content:    content,
+	}, nil
+}
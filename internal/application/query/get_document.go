@@ -0,0 +1,72 @@
+package query
+
+import (
+	"context"
+
+	"gozero-ddd/internal/application/auth"
+	"gozero-ddd/internal/application/dto"
+	"gozero-ddd/internal/domain"
+	"gozero-ddd/internal/domain/repository"
+	"gozero-ddd/internal/domain/service"
+	"gozero-ddd/internal/domain/valueobject"
+)
+
+// GetDocumentQuery 获取单个文档查询
+type GetDocumentQuery struct {
+	KnowledgeBaseID string
+	DocumentID      string
+	Format          string // 输出格式：raw（默认）/ html / text
+	IncludeDrafts   bool   // 是否允许读取未发布文档
+}
+
+// GetDocumentHandler 获取单个文档查询处理器
+// 返回的文档总是附带由标题生成的目录
+type GetDocumentHandler struct {
+	docRepo     repository.DocumentRepository
+	renderer    service.ContentRenderer
+	permissions *auth.PermissionChecker // 权限检查器
+}
+
+// NewGetDocumentHandler 创建处理器
+func NewGetDocumentHandler(docRepo repository.DocumentRepository, renderer service.ContentRenderer, permissions *auth.PermissionChecker) *GetDocumentHandler {
+	return &GetDocumentHandler{
+		docRepo:     docRepo,
+		renderer:    renderer,
+		permissions: permissions,
+	}
+}
+
+// Handle 处理获取单个文档查询
+func (h *GetDocumentHandler) Handle(ctx context.Context, query *GetDocumentQuery) (*dto.DocumentDTO, error) {
+	// 验证 ID 格式
+	kbID, err := valueobject.KnowledgeBaseIDFromString(query.KnowledgeBaseID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 检查调用方权限
+	if err := h.permissions.Check(ctx, kbID, valueobject.MemberRoleViewer); err != nil {
+		return nil, err
+	}
+
+	docID, err := valueobject.DocumentIDFromString(query.DocumentID)
+	if err != nil {
+		return nil, err
+	}
+
+	format, err := valueobject.ContentFormatFromString(query.Format)
+	if err != nil {
+		return nil, err
+	}
+
+	doc, err := h.docRepo.FindByID(ctx, docID)
+	if err != nil {
+		return nil, err
+	}
+	// 文档不属于该知识库，或未发布且未要求包含草稿时，都视为不存在
+	if doc == nil || doc.KnowledgeBaseID() != kbID || (!doc.IsPublished() && !query.IncludeDrafts) {
+		return nil, domain.ErrDocumentNotFound
+	}
+
+	return renderDocument(h.renderer, doc, format, true)
+}
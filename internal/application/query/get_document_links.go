@@ -0,0 +1,130 @@
+package query
+
+import (
+	"context"
+
+	"gozero-ddd/internal/application/auth"
+	"gozero-ddd/internal/application/dto"
+	"gozero-ddd/internal/domain"
+	"gozero-ddd/internal/domain/repository"
+	"gozero-ddd/internal/domain/valueobject"
+)
+
+// GetDocumentLinksQuery 获取文档链接查询
+type GetDocumentLinksQuery struct {
+	KnowledgeBaseID string
+	DocumentID      string
+}
+
+// GetDocumentLinksHandler 获取文档链接查询处理器
+// 返回文档的出链（含是否失效）和反向链接
+type GetDocumentLinksHandler struct {
+	kbRepo      repository.KnowledgeBaseRepository
+	docRepo     repository.DocumentRepository
+	linkRepo    repository.DocumentLinkRepository
+	permissions *auth.PermissionChecker // 权限检查器
+}
+
+// NewGetDocumentLinksHandler 创建处理器
+func NewGetDocumentLinksHandler(
+	kbRepo repository.KnowledgeBaseRepository,
+	docRepo repository.DocumentRepository,
+	linkRepo repository.DocumentLinkRepository,
+	permissions *auth.PermissionChecker,
+) *GetDocumentLinksHandler {
+	return &GetDocumentLinksHandler{
+		kbRepo:      kbRepo,
+		docRepo:     docRepo,
+		linkRepo:    linkRepo,
+		permissions: permissions,
+	}
+}
+
+// Handle 处理获取文档链接查询
+func (h *GetDocumentLinksHandler) Handle(ctx context.Context, query *GetDocumentLinksQuery) (*dto.DocumentLinksDTO, error) {
+	// 验证 ID 格式
+	kbID, err := valueobject.KnowledgeBaseIDFromString(query.KnowledgeBaseID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 检查调用方权限
+	if err := h.permissions.Check(ctx, kbID, valueobject.MemberRoleViewer); err != nil {
+		return nil, err
+	}
+
+	docID, err := valueobject.DocumentIDFromString(query.DocumentID)
+	if err != nil {
+		return nil, err
+	}
+
+	kb, err := h.kbRepo.FindByID(ctx, kbID)
+	if err != nil {
+		return nil, err
+	}
+	if kb == nil {
+		return nil, domain.ErrKnowledgeBaseNotFound
+	}
+
+	doc, err := kb.GetDocument(docID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 出链
+	links, err := h.linkRepo.FindBySource(ctx, docID)
+	if err != nil {
+		return nil, err
+	}
+
+	resolver := newLinkResolver(kb, h.docRepo)
+	outgoing := make([]*dto.DocumentLinkDTO, len(links))
+	for i, link := range links {
+		item := &dto.DocumentLinkDTO{
+			Type:   link.Type.String(),
+			Target: link.Target(),
+		}
+		target, err := resolver.resolve(ctx, link)
+		if err != nil {
+			return nil, err
+		}
+		if target == nil {
+			item.Broken = true
+		} else {
+			item.TargetDocumentID = target.ID().String()
+			item.TargetKnowledgeBaseID = target.KnowledgeBaseID().String()
+			item.TargetTitle = target.Title()
+		}
+		outgoing[i] = item
+	}
+
+	// 反向链接
+	edges, err := h.linkRepo.FindBacklinks(ctx, kbID, docID, doc.Title())
+	if err != nil {
+		return nil, err
+	}
+
+	backlinks := make([]*dto.BacklinkDTO, 0, len(edges))
+	for _, edge := range edges {
+		if edge.SourceDocumentID == docID {
+			continue
+		}
+		item := &dto.BacklinkDTO{
+			DocumentID:      edge.SourceDocumentID.String(),
+			KnowledgeBaseID: edge.SourceKnowledgeBaseID.String(),
+			Type:            edge.Link.Type.String(),
+		}
+		if source, err := kb.GetDocument(edge.SourceDocumentID); err == nil {
+			item.Title = source.Title()
+		} else if source, err := h.docRepo.FindByID(ctx, edge.SourceDocumentID); err == nil && source != nil {
+			item.Title = source.Title()
+		}
+		backlinks = append(backlinks, item)
+	}
+
+	return &dto.DocumentLinksDTO{
+		DocumentID: docID.String(),
+		Outgoing:   outgoing,
+		Backlinks:  backlinks,
+	}, nil
+}
@@ -0,0 +1,109 @@
+package query
+
+import (
+	"context"
+
+	"gozero-ddd/internal/application/auth"
+	"gozero-ddd/internal/application/dto"
+	"gozero-ddd/internal/domain"
+	"gozero-ddd/internal/domain/entity"
+	"gozero-ddd/internal/domain/repository"
+	"gozero-ddd/internal/domain/valueobject"
+)
+
+// GetFolderTreeQuery 获取知识库文件夹树查询
+type GetFolderTreeQuery struct {
+	KnowledgeBaseID string
+	IncludeDrafts   bool // 文档数量是否包含未发布文档，默认只统计已发布文档
+}
+
+// GetFolderTreeHandler 获取文件夹树查询处理器
+type GetFolderTreeHandler struct {
+	kbRepo      repository.KnowledgeBaseRepository
+	permissions *auth.PermissionChecker // 权限检查器
+}
+
+// NewGetFolderTreeHandler 创建处理器
+func NewGetFolderTreeHandler(kbRepo repository.KnowledgeBaseRepository, permissions *auth.PermissionChecker) *GetFolderTreeHandler {
+	return &GetFolderTreeHandler{
+		kbRepo:      kbRepo,
+		permissions: permissions,
+	}
+}
+
+// Handle 处理获取文件夹树查询
+// 返回嵌套的文件夹结构，每个节点带有直接文档数和包含子文件夹的文档总数
+func (h *GetFolderTreeHandler) Handle(ctx context.Context, query *GetFolderTreeQuery) (*dto.FolderTreeDTO, error) {
+	// 验证 ID 格式
+	kbID, err := valueobject.KnowledgeBaseIDFromString(query.KnowledgeBaseID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 检查调用方权限
+	if err := h.permissions.Check(ctx, kbID, valueobject.MemberRoleViewer); err != nil {
+		return nil, err
+	}
+
+	kb, err := h.kbRepo.FindByID(ctx, kbID)
+	if err != nil {
+		return nil, err
+	}
+	if kb == nil {
+		return nil, domain.ErrKnowledgeBaseNotFound
+	}
+
+	// 统计每个文件夹下直接包含的文档数量（根目录使用空字符串作为键）
+	counts := make(map[string]int)
+	total := 0
+	for _, doc := range kb.Documents() {
+		if !query.IncludeDrafts && !doc.IsPublished() {
+			continue
+		}
+		counts[folderKey(doc.FolderID())]++
+		total++
+	}
+
+	// 按父文件夹分组
+	children := make(map[string][]*entity.Folder)
+	for _, f := range kb.Folders() {
+		key := folderKey(f.ParentID())
+		children[key] = append(children[key], f)
+	}
+
+	return &dto.FolderTreeDTO{
+		KnowledgeBaseID:    kb.ID().String(),
+		RootDocumentCount:  counts[""],
+		TotalDocumentCount: total,
+		Folders:            buildFolderNodes("", children, counts),
+	}, nil
+}
+
+// buildFolderNodes 递归构建指定父文件夹下的子树
+func buildFolderNodes(parentKey string, children map[string][]*entity.Folder, counts map[string]int) []*dto.FolderNodeDTO {
+	folders := children[parentKey]
+	nodes := make([]*dto.FolderNodeDTO, 0, len(folders))
+	for _, f := range folders {
+		key := f.ID().String()
+		node := &dto.FolderNodeDTO{
+			ID:            key,
+			Name:          f.Name(),
+			DocumentCount: counts[key],
+			Children:      buildFolderNodes(key, children, counts),
+		}
+		node.TotalDocumentCount = node.DocumentCount
+		for _, child := range node.Children {
+			node.TotalDocumentCount += child.TotalDocumentCount
+		}
+		nodes = append(nodes, node)
+	}
+	return nodes
+}
+
+// folderKey 将文件夹ID指针转换为分组键（根目录为空字符串）
+func folderKey(id *valueobject.FolderID) string {
+	if id == nil {
+		return ""
+	}
+	return id.String()
+}
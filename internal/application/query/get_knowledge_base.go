@@ -3,6 +3,7 @@ package query
 import (
 	"context"
 
+	"gozero-ddd/internal/application/auth"
 	"gozero-ddd/internal/application/dto"
 	"gozero-ddd/internal/domain"
 	"gozero-ddd/internal/domain/repository"
@@ -13,22 +14,27 @@ import (
 type GetKnowledgeBaseQuery struct {
 	ID               string
 	IncludeDocuments bool
+	IncludeDrafts    bool   // 是否包含未发布文档，默认只返回已发布文档
+	TagQuery         string // 按标签查询表达式过滤返回的文档（可选）
 }
 
 // GetKnowledgeBaseHandler 获取知识库查询处理器
 type GetKnowledgeBaseHandler struct {
-	kbRepo  repository.KnowledgeBaseRepository
-	docRepo repository.DocumentRepository
+	kbRepo      repository.KnowledgeBaseRepository
+	docRepo     repository.DocumentRepository
+	permissions *auth.PermissionChecker // 权限检查器
 }
 
 // NewGetKnowledgeBaseHandler 创建处理器
 func NewGetKnowledgeBaseHandler(
 	kbRepo repository.KnowledgeBaseRepository,
 	docRepo repository.DocumentRepository,
+	permissions *auth.PermissionChecker,
 ) *GetKnowledgeBaseHandler {
 	return &GetKnowledgeBaseHandler{
-		kbRepo:  kbRepo,
-		docRepo: docRepo,
+		kbRepo:      kbRepo,
+		docRepo:     docRepo,
+		permissions: permissions,
 	}
 }
 
@@ -40,6 +46,11 @@ func (h *GetKnowledgeBaseHandler) Handle(ctx context.Context, query *GetKnowledg
 		return nil, err
 	}
 
+	// 检查调用方权限
+	if err := h.permissions.Check(ctx, kbID, valueobject.MemberRoleViewer); err != nil {
+		return nil, err
+	}
+
 	kb, err := h.kbRepo.FindByID(ctx, kbID)
 	if err != nil {
 		return nil, err
@@ -48,5 +59,31 @@ func (h *GetKnowledgeBaseHandler) Handle(ctx context.Context, query *GetKnowledg
 		return nil, domain.ErrKnowledgeBaseNotFound
 	}
 
-	return dto.KnowledgeBaseFromEntity(kb, query.IncludeDocuments), nil
+	result := dto.KnowledgeBaseFromEntity(kb, query.IncludeDocuments)
+	if query.IncludeDocuments && !query.IncludeDrafts {
+		published := make([]dto.DocumentDTO, 0, len(result.Documents))
+		for _, doc := range result.Documents {
+			if doc.Status == valueobject.DocumentStatusPublished.String() {
+				published = append(published, doc)
+			}
+		}
+		result.Documents = published
+	}
+
+	// 标签查询在内存中对已加载的文档求值
+	if query.IncludeDocuments && query.TagQuery != "" {
+		expr, err := parseTagQuery(query.TagQuery, kb.TagDefinitions())
+		if err != nil {
+			return nil, err
+		}
+		matched := make([]dto.DocumentDTO, 0, len(result.Documents))
+		for _, doc := range result.Documents {
+			if expr.Matches(doc.Tags) {
+				matched = append(matched, doc)
+			}
+		}
+		result.Documents = matched
+	}
+
+	return result, nil
 }
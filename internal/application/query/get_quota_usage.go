@@ -0,0 +1,33 @@
+package query
+
+import (
+	"context"
+
+	"gozero-ddd/internal/application/dto"
+	"gozero-ddd/internal/domain/service"
+	"gozero-ddd/internal/domain/tenant"
+)
+
+// GetQuotaUsageQuery 获取租户配额用量查询
+type GetQuotaUsageQuery struct{}
+
+// GetQuotaUsageHandler 获取租户配额用量查询处理器
+type GetQuotaUsageHandler struct {
+	quotaService *service.QuotaService
+}
+
+// NewGetQuotaUsageHandler 创建处理器
+func NewGetQuotaUsageHandler(quotaService *service.QuotaService) *GetQuotaUsageHandler {
+	return &GetQuotaUsageHandler{quotaService: quotaService}
+}
+
+// Handle 处理获取租户配额用量查询
+// 返回调用方所属租户的配额和用量，租户内的调用方都可以查看
+func (h *GetQuotaUsageHandler) Handle(ctx context.Context, query *GetQuotaUsageQuery) (*dto.QuotaUsageDTO, error) {
+	usage, err := h.quotaService.Usage(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.QuotaUsageFromValueObject(tenant.FromContext(ctx), h.quotaService.Policy(ctx), usage), nil
+}
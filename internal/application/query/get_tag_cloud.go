@@ -0,0 +1,146 @@
+package query
+
+import (
+	"context"
+	"sort"
+
+	"gozero-ddd/internal/application/auth"
+	"gozero-ddd/internal/application/dto"
+	"gozero-ddd/internal/domain"
+	"gozero-ddd/internal/domain/entity"
+	"gozero-ddd/internal/domain/repository"
+	"gozero-ddd/internal/domain/valueobject"
+)
+
+// GetTagCloudQuery 获取知识库标签云查询
+type GetTagCloudQuery struct {
+	KnowledgeBaseID string
+	IncludeDrafts   bool // 是否统计未发布文档，默认只统计已发布文档
+}
+
+// GetTagCloudHandler 获取标签云查询处理器
+type GetTagCloudHandler struct {
+	kbRepo      repository.KnowledgeBaseRepository
+	permissions *auth.PermissionChecker // 权限检查器
+}
+
+// NewGetTagCloudHandler 创建处理器
+func NewGetTagCloudHandler(kbRepo repository.KnowledgeBaseRepository, permissions *auth.PermissionChecker) *GetTagCloudHandler {
+	return &GetTagCloudHandler{
+		kbRepo:      kbRepo,
+		permissions: permissions,
+	}
+}
+
+// Handle 处理获取标签云查询
+// 返回注册表中定义的标签和文档上实际使用的标签，按文档数量降序排列
+// 别名计入其规范名称，TotalCount 包含使用子孙标签的文档
+func (h *GetTagCloudHandler) Handle(ctx context.Context, query *GetTagCloudQuery) (*dto.TagCloudDTO, error) {
+	// 验证 ID 格式
+	kbID, err := valueobject.KnowledgeBaseIDFromString(query.KnowledgeBaseID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 检查调用方权限
+	if err := h.permissions.Check(ctx, kbID, valueobject.MemberRoleViewer); err != nil {
+		return nil, err
+	}
+
+	kb, err := h.kbRepo.FindByID(ctx, kbID)
+	if err != nil {
+		return nil, err
+	}
+	if kb == nil {
+		return nil, domain.ErrKnowledgeBaseNotFound
+	}
+
+	items := make(map[valueobject.Tag]*dto.TagCountDTO)
+	defsByID := make(map[valueobject.TagID]*entity.TagDefinition)
+	for _, def := range kb.TagDefinitions() {
+		defsByID[def.ID()] = def
+		item := &dto.TagCountDTO{
+			Name:       def.Name().String(),
+			ID:         def.ID().String(),
+			Aliases:    valueobject.TagStrings(def.Aliases()),
+			Registered: true,
+		}
+		if def.ParentID() != nil {
+			item.ParentID = def.ParentID().String()
+		}
+		items[def.Name()] = item
+	}
+
+	for _, doc := range kb.Documents() {
+		if !query.IncludeDrafts && !doc.IsPublished() {
+			continue
+		}
+
+		direct := make(map[valueobject.Tag]bool)
+		rolled := make(map[valueobject.Tag]bool)
+		for _, raw := range doc.Tags() {
+			tag := canonicalTag(kb, raw)
+			direct[tag] = true
+
+			// 沿父链向上累计，访问过的标签不重复计数
+			rolled[tag] = true
+			if def := kb.LookupTag(tag); def != nil {
+				for parentID := def.ParentID(); parentID != nil; {
+					parent, ok := defsByID[*parentID]
+					if !ok || rolled[parent.Name()] {
+						break
+					}
+					rolled[parent.Name()] = true
+					parentID = parent.ParentID()
+				}
+			}
+		}
+
+		for tag := range direct {
+			tagItem(items, tag).Count++
+		}
+		for tag := range rolled {
+			tagItem(items, tag).TotalCount++
+		}
+	}
+
+	tags := make([]*dto.TagCountDTO, 0, len(items))
+	for _, item := range items {
+		tags = append(tags, item)
+	}
+	sort.Slice(tags, func(i, j int) bool {
+		if tags[i].TotalCount != tags[j].TotalCount {
+			return tags[i].TotalCount > tags[j].TotalCount
+		}
+		return tags[i].Name < tags[j].Name
+	})
+
+	return &dto.TagCloudDTO{
+		KnowledgeBaseID: kb.ID().String(),
+		Tags:            tags,
+		Total:           len(tags),
+	}, nil
+}
+
+// canonicalTag 将文档上保存的标签解析为规范名称
+// 引入标签规范化之前写入的标签可能未规范化，无法规范化时原样使用
+func canonicalTag(kb *entity.KnowledgeBase, raw string) valueobject.Tag {
+	tag, err := valueobject.NewTag(raw)
+	if err != nil {
+		return valueobject.MustTag(raw)
+	}
+	if def := kb.LookupTag(tag); def != nil {
+		return def.Name()
+	}
+	return tag
+}
+
+// tagItem 获取标签云中的一项，不存在时创建（未在注册表中定义的标签）
+func tagItem(items map[valueobject.Tag]*dto.TagCountDTO, tag valueobject.Tag) *dto.TagCountDTO {
+	item, ok := items[tag]
+	if !ok {
+		item = &dto.TagCountDTO{Name: tag.String()}
+		items[tag] = item
+	}
+	return item
+}
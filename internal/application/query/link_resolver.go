@@ -0,0 +1,57 @@
+package query
+
+import (
+	"context"
+
+	"gozero-ddd/internal/domain/entity"
+	"gozero-ddd/internal/domain/repository"
+	"gozero-ddd/internal/domain/valueobject"
+)
+
+// linkResolver 将链接解析为目标文档
+// Wiki 链接按标题在源文档所属知识库内查找，路径链接按文档ID查找（可跨知识库）
+type linkResolver struct {
+	kb      *entity.KnowledgeBase
+	docRepo repository.DocumentRepository
+	byTitle map[string]*entity.Document
+}
+
+// newLinkResolver 创建链接解析器
+func newLinkResolver(kb *entity.KnowledgeBase, docRepo repository.DocumentRepository) *linkResolver {
+	byTitle := make(map[string]*entity.Document)
+	for _, doc := range kb.Documents() {
+		if _, ok := byTitle[doc.Title()]; !ok {
+			byTitle[doc.Title()] = doc
+		}
+	}
+	return &linkResolver{
+		kb:      kb,
+		docRepo: docRepo,
+		byTitle: byTitle,
+	}
+}
+
+// resolve 解析链接，目标不存在或已删除时返回 nil
+func (r *linkResolver) resolve(ctx context.Context, link valueobject.DocumentLink) (*entity.Document, error) {
+	if link.Type == valueobject.LinkTypeWiki {
+		return r.byTitle[link.TargetTitle], nil
+	}
+
+	docID := valueobject.MustDocumentIDFromString(link.TargetDocumentID)
+	if link.TargetKnowledgeBaseID == r.kb.ID().String() {
+		doc, err := r.kb.GetDocument(docID)
+		if err != nil {
+			return nil, nil
+		}
+		return doc, nil
+	}
+
+	doc, err := r.docRepo.FindByID(ctx, docID)
+	if err != nil {
+		return nil, err
+	}
+	if doc == nil || doc.KnowledgeBaseID().String() != link.TargetKnowledgeBaseID {
+		return nil, nil
+	}
+	return doc, nil
+}
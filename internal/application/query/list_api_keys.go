@@ -0,0 +1,51 @@
+package query
+
+import (
+	"context"
+	"time"
+
+	"gozero-ddd/internal/application/auth"
+	"gozero-ddd/internal/application/dto"
+	"gozero-ddd/internal/domain/repository"
+)
+
+// ListAPIKeysQuery 列出 API Key 查询
+type ListAPIKeysQuery struct{}
+
+// ListAPIKeysHandler 列出 API Key 查询处理器
+type ListAPIKeysHandler struct {
+	apiKeyRepo  repository.APIKeyRepository
+	permissions *auth.PermissionChecker // 权限检查器
+}
+
+// NewListAPIKeysHandler 创建处理器
+func NewListAPIKeysHandler(apiKeyRepo repository.APIKeyRepository, permissions *auth.PermissionChecker) *ListAPIKeysHandler {
+	return &ListAPIKeysHandler{
+		apiKeyRepo:  apiKeyRepo,
+		permissions: permissions,
+	}
+}
+
+// Handle 处理列出 API Key 查询（只有管理员可以查看）
+func (h *ListAPIKeysHandler) Handle(ctx context.Context, query *ListAPIKeysQuery) (*dto.APIKeyListDTO, error) {
+	// 检查调用方权限
+	if err := h.permissions.RequireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	keys, err := h.apiKeyRepo.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	items := make([]*dto.APIKeyDTO, len(keys))
+	for i, k := range keys {
+		items[i] = dto.APIKeyFromRecord(k, now)
+	}
+
+	return &dto.APIKeyListDTO{
+		Items: items,
+		Total: len(items),
+	}, nil
+}
@@ -0,0 +1,73 @@
+package query
+
+import (
+	"context"
+
+	"gozero-ddd/internal/application/auth"
+	"gozero-ddd/internal/application/dto"
+	"gozero-ddd/internal/domain"
+	"gozero-ddd/internal/domain/repository"
+	"gozero-ddd/internal/domain/valueobject"
+)
+
+// ListAttachmentsQuery 列出文档附件查询
+type ListAttachmentsQuery struct {
+	KnowledgeBaseID string
+	DocumentID      string
+}
+
+// ListAttachmentsHandler 列出文档附件查询处理器
+type ListAttachmentsHandler struct {
+	kbRepo      repository.KnowledgeBaseRepository
+	permissions *auth.PermissionChecker // 权限检查器
+}
+
+// NewListAttachmentsHandler 创建处理器
+func NewListAttachmentsHandler(kbRepo repository.KnowledgeBaseRepository, permissions *auth.PermissionChecker) *ListAttachmentsHandler {
+	return &ListAttachmentsHandler{
+		kbRepo:      kbRepo,
+		permissions: permissions,
+	}
+}
+
+// Handle 处理列出文档附件查询
+func (h *ListAttachmentsHandler) Handle(ctx context.Context, query *ListAttachmentsQuery) (*dto.AttachmentListDTO, error) {
+	// 验证 ID 格式
+	kbID, err := valueobject.KnowledgeBaseIDFromString(query.KnowledgeBaseID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 检查调用方权限
+	if err := h.permissions.Check(ctx, kbID, valueobject.MemberRoleViewer); err != nil {
+		return nil, err
+	}
+
+	docID, err := valueobject.DocumentIDFromString(query.DocumentID)
+	if err != nil {
+		return nil, err
+	}
+
+	kb, err := h.kbRepo.FindByID(ctx, kbID)
+	if err != nil {
+		return nil, err
+	}
+	if kb == nil {
+		return nil, domain.ErrKnowledgeBaseNotFound
+	}
+
+	if _, err := kb.GetDocument(docID); err != nil {
+		return nil, err
+	}
+
+	atts := kb.DocumentAttachments(docID)
+	items := make([]*dto.AttachmentDTO, len(atts))
+	for i, att := range atts {
+		items[i] = dto.AttachmentFromEntity(att)
+	}
+
+	return &dto.AttachmentListDTO{
+		Items: items,
+		Total: len(items),
+	}, nil
+}
@@ -0,0 +1,229 @@
+package query
+
+import (
+	"context"
+	"encoding/csv"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"gozero-ddd/internal/application/auth"
+	"gozero-ddd/internal/application/dto"
+	"gozero-ddd/internal/domain"
+	"gozero-ddd/internal/domain/repository"
+)
+
+const (
+	// DefaultAuditLogPageSize 未指定时每页返回的审计日志条数
+	DefaultAuditLogPageSize = 100
+	// MaxAuditLogPageSize 每页最多返回的审计日志条数
+	MaxAuditLogPageSize = 1000
+
+	// auditLogExportBatchSize 导出时每次从仓储读取的条数
+	auditLogExportBatchSize = 500
+)
+
+// AuditLogFilter 审计日志过滤条件，空值字段不参与过滤
+type AuditLogFilter struct {
+	KnowledgeBaseID string
+	Actor           string
+	EventName       string
+	Since           *time.Time // 事件发生时间下限（含）
+	Until           *time.Time // 事件发生时间上限（不含）
+}
+
+// toRepository 校验过滤条件并转换为仓储查询条件
+func (f AuditLogFilter) toRepository() (repository.AuditLogFilter, error) {
+	if f.Since != nil && f.Until != nil && !f.Until.After(*f.Since) {
+		return repository.AuditLogFilter{}, domain.ErrInvalidAuditLogTimeRange
+	}
+	return repository.AuditLogFilter{
+		KnowledgeBaseID: f.KnowledgeBaseID,
+		Actor:           f.Actor,
+		EventName:       f.EventName,
+		Since:           f.Since,
+		Until:           f.Until,
+	}, nil
+}
+
+// ListAuditLogsQuery 查询审计日志
+type ListAuditLogsQuery struct {
+	AuditLogFilter
+	Cursor string // 上一页返回的 next_cursor，为空时从最新的记录开始
+	Limit  int    // 每页条数，默认 100，最多 1000
+}
+
+// ListAuditLogsHandler 查询审计日志处理器
+type ListAuditLogsHandler struct {
+	auditRepo   repository.AuditLogRepository
+	permissions *auth.PermissionChecker // 权限检查器
+}
+
+// NewListAuditLogsHandler 创建处理器
+func NewListAuditLogsHandler(auditRepo repository.AuditLogRepository, permissions *auth.PermissionChecker) *ListAuditLogsHandler {
+	return &ListAuditLogsHandler{
+		auditRepo:   auditRepo,
+		permissions: permissions,
+	}
+}
+
+// Handle 处理查询审计日志（只有管理员可以查看）
+// 按写入顺序倒序分页，游标为上一页最后一条记录的顺序号
+func (h *ListAuditLogsHandler) Handle(ctx context.Context, query *ListAuditLogsQuery) (*dto.AuditLogListDTO, error) {
+	// 检查调用方权限
+	if err := h.permissions.RequireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	filter, err := query.toRepository()
+	if err != nil {
+		return nil, err
+	}
+	if query.Cursor != "" {
+		seq, err := strconv.ParseInt(query.Cursor, 10, 64)
+		if err != nil || seq <= 0 {
+			return nil, domain.ErrInvalidAuditLogCursor
+		}
+		filter.BeforeSeq = seq
+	}
+
+	limit := query.Limit
+	if limit <= 0 {
+		limit = DefaultAuditLogPageSize
+	}
+	if limit > MaxAuditLogPageSize {
+		limit = MaxAuditLogPageSize
+	}
+	// 多取一条判断是否还有下一页
+	filter.Limit = limit + 1
+
+	entries, err := h.auditRepo.Find(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &dto.AuditLogListDTO{Items: make([]*dto.AuditLogDTO, 0, limit)}
+	if len(entries) > limit {
+		entries = entries[:limit]
+		result.NextCursor = strconv.FormatInt(entries[limit-1].Seq, 10)
+	}
+	for _, e := range entries {
+		result.Items = append(result.Items, dto.AuditLogFromEntry(e))
+	}
+	return result, nil
+}
+
+// ExportAuditLogsQuery 导出审计日志查询
+type ExportAuditLogsQuery struct {
+	AuditLogFilter
+}
+
+// AuditLogExport 审计日志导出结果
+// 查询阶段只完成校验，记录在 Write 时分批读取并流式写出 CSV
+type AuditLogExport struct {
+	FileName  string // 建议的下载文件名
+	MediaType string
+
+	auditRepo repository.AuditLogRepository
+	filter    repository.AuditLogFilter
+}
+
+// ExportAuditLogsHandler 导出审计日志查询处理器
+type ExportAuditLogsHandler struct {
+	auditRepo   repository.AuditLogRepository
+	permissions *auth.PermissionChecker // 权限检查器
+}
+
+// NewExportAuditLogsHandler 创建处理器
+func NewExportAuditLogsHandler(auditRepo repository.AuditLogRepository, permissions *auth.PermissionChecker) *ExportAuditLogsHandler {
+	return &ExportAuditLogsHandler{
+		auditRepo:   auditRepo,
+		permissions: permissions,
+	}
+}
+
+// Handle 处理导出审计日志查询（只有管理员可以导出）
+func (h *ExportAuditLogsHandler) Handle(ctx context.Context, query *ExportAuditLogsQuery) (*AuditLogExport, error) {
+	// 检查调用方权限
+	if err := h.permissions.RequireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	filter, err := query.toRepository()
+	if err != nil {
+		return nil, err
+	}
+
+	return &AuditLogExport{
+		FileName:  "audit-logs-" + time.Now().Format("20060102-150405") + ".csv",
+		MediaType: "text/csv; charset=utf-8",
+		auditRepo: h.auditRepo,
+		filter:    filter,
+	}, nil
+}
+
+// auditLogCSVHeader 导出 CSV 的表头
+var auditLogCSVHeader = []string{
+	"seq", "occurred_at", "recorded_at", "event_id", "event_name", "aggregate_id",
+	"knowledge_base_id", "actor", "request_id", "client_ip", "before", "after", "payload",
+}
+
+// csvCell 转义可能被电子表格当作公式执行的单元格
+// 请求ID、操作者等字段由调用方控制，以 = + - @ 制表符或回车开头时加上单引号前缀
+func csvCell(s string) string {
+	if s != "" && strings.ContainsRune("=+-@\t\r", rune(s[0])) {
+		return "'" + s
+	}
+	return s
+}
+
+// Write 将审计日志以 CSV 格式写入 w，按写入顺序倒序
+func (e *AuditLogExport) Write(ctx context.Context, w io.Writer) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(auditLogCSVHeader); err != nil {
+		return err
+	}
+
+	filter := e.filter
+	filter.Limit = auditLogExportBatchSize
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		entries, err := e.auditRepo.Find(ctx, filter)
+		if err != nil {
+			return err
+		}
+		for _, entry := range entries {
+			if err := cw.Write([]string{
+				strconv.FormatInt(entry.Seq, 10),
+				entry.OccurredAt.UTC().Format(time.RFC3339Nano),
+				entry.RecordedAt.UTC().Format(time.RFC3339Nano),
+				csvCell(entry.EventID),
+				csvCell(entry.EventName),
+				csvCell(entry.AggregateID),
+				csvCell(entry.KnowledgeBaseID),
+				csvCell(entry.Actor),
+				csvCell(entry.RequestID),
+				csvCell(entry.ClientIP),
+				csvCell(string(entry.Before)),
+				csvCell(string(entry.After)),
+				csvCell(string(entry.Payload)),
+			}); err != nil {
+				return err
+			}
+		}
+		// 每批写完后刷新，让客户端尽早收到数据
+		cw.Flush()
+		if err := cw.Error(); err != nil {
+			return err
+		}
+
+		if len(entries) < auditLogExportBatchSize {
+			return nil
+		}
+		filter.BeforeSeq = entries[len(entries)-1].Seq
+	}
+}
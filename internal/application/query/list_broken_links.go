@@ -0,0 +1,95 @@
+package query
+
+import (
+	"context"
+
+	"gozero-ddd/internal/application/auth"
+	"gozero-ddd/internal/application/dto"
+	"gozero-ddd/internal/domain"
+	"gozero-ddd/internal/domain/repository"
+	"gozero-ddd/internal/domain/valueobject"
+)
+
+// ListBrokenLinksQuery 列出知识库失效链接查询
+type ListBrokenLinksQuery struct {
+	KnowledgeBaseID string
+}
+
+// ListBrokenLinksHandler 列出失效链接查询处理器
+// 检查知识库内所有文档的出链，报告目标不存在或已删除的链接
+type ListBrokenLinksHandler struct {
+	kbRepo      repository.KnowledgeBaseRepository
+	docRepo     repository.DocumentRepository
+	linkRepo    repository.DocumentLinkRepository
+	permissions *auth.PermissionChecker // 权限检查器
+}
+
+// NewListBrokenLinksHandler 创建处理器
+func NewListBrokenLinksHandler(
+	kbRepo repository.KnowledgeBaseRepository,
+	docRepo repository.DocumentRepository,
+	linkRepo repository.DocumentLinkRepository,
+	permissions *auth.PermissionChecker,
+) *ListBrokenLinksHandler {
+	return &ListBrokenLinksHandler{
+		kbRepo:      kbRepo,
+		docRepo:     docRepo,
+		linkRepo:    linkRepo,
+		permissions: permissions,
+	}
+}
+
+// Handle 处理列出失效链接查询
+func (h *ListBrokenLinksHandler) Handle(ctx context.Context, query *ListBrokenLinksQuery) (*dto.BrokenLinkReportDTO, error) {
+	// 验证 ID 格式
+	kbID, err := valueobject.KnowledgeBaseIDFromString(query.KnowledgeBaseID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 检查调用方权限
+	if err := h.permissions.Check(ctx, kbID, valueobject.MemberRoleViewer); err != nil {
+		return nil, err
+	}
+
+	kb, err := h.kbRepo.FindByID(ctx, kbID)
+	if err != nil {
+		return nil, err
+	}
+	if kb == nil {
+		return nil, domain.ErrKnowledgeBaseNotFound
+	}
+
+	edges, err := h.linkRepo.FindByKnowledgeBaseID(ctx, kbID)
+	if err != nil {
+		return nil, err
+	}
+
+	resolver := newLinkResolver(kb, h.docRepo)
+	items := make([]*dto.BrokenLinkDTO, 0)
+	for _, edge := range edges {
+		target, err := resolver.resolve(ctx, edge.Link)
+		if err != nil {
+			return nil, err
+		}
+		if target != nil {
+			continue
+		}
+
+		item := &dto.BrokenLinkDTO{
+			SourceDocumentID: edge.SourceDocumentID.String(),
+			Type:             edge.Link.Type.String(),
+			Target:           edge.Link.Target(),
+		}
+		if source, err := kb.GetDocument(edge.SourceDocumentID); err == nil {
+			item.SourceTitle = source.Title()
+		}
+		items = append(items, item)
+	}
+
+	return &dto.BrokenLinkReportDTO{
+		KnowledgeBaseID: kbID.String(),
+		Items:           items,
+		Total:           len(items),
+	}, nil
+}
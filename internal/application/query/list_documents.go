@@ -3,25 +3,38 @@ package query
 import (
 	"context"
 
+	"gozero-ddd/internal/application/auth"
 	"gozero-ddd/internal/application/dto"
+	"gozero-ddd/internal/domain/entity"
 	"gozero-ddd/internal/domain/repository"
+	"gozero-ddd/internal/domain/service"
 	"gozero-ddd/internal/domain/valueobject"
 )
 
 // ListDocumentsQuery 列出文档查询
 type ListDocumentsQuery struct {
 	KnowledgeBaseID string
+	Status          string // 按发布状态过滤（可选）
+	IncludeDrafts   bool   // 是否包含未发布文档，默认只返回已发布文档
+	TagQuery        string // 标签查询表达式（可选），如 tag:go AND NOT tag:deprecated
+	Format          string // 内容输出格式：raw（默认）/ html / text
 }
 
 // ListDocumentsHandler 列出文档查询处理器
 type ListDocumentsHandler struct {
-	docRepo repository.DocumentRepository
+	docRepo     repository.DocumentRepository
+	tagRepo     repository.TagRepository
+	renderer    service.ContentRenderer
+	permissions *auth.PermissionChecker // 权限检查器
 }
 
 // NewListDocumentsHandler 创建处理器
-func NewListDocumentsHandler(docRepo repository.DocumentRepository) *ListDocumentsHandler {
+func NewListDocumentsHandler(docRepo repository.DocumentRepository, tagRepo repository.TagRepository, renderer service.ContentRenderer, permissions *auth.PermissionChecker) *ListDocumentsHandler {
 	return &ListDocumentsHandler{
-		docRepo: docRepo,
+		docRepo:     docRepo,
+		tagRepo:     tagRepo,
+		renderer:    renderer,
+		permissions: permissions,
 	}
 }
 
@@ -33,14 +46,50 @@ func (h *ListDocumentsHandler) Handle(ctx context.Context, query *ListDocumentsQ
 		return nil, err
 	}
 
-	docs, err := h.docRepo.FindByKnowledgeBaseID(ctx, kbID)
+	// 检查调用方权限
+	if err := h.permissions.Check(ctx, kbID, valueobject.MemberRoleViewer); err != nil {
+		return nil, err
+	}
+
+	format, err := valueobject.ContentFormatFromString(query.Format)
 	if err != nil {
 		return nil, err
 	}
 
+	var docs []*entity.Document
+	switch {
+	case query.TagQuery != "":
+		docs, err = h.searchByTagQuery(ctx, kbID, query)
+		if err != nil {
+			return nil, err
+		}
+	case query.Status != "":
+		status, err := valueobject.DocumentStatusFromString(query.Status)
+		if err != nil {
+			return nil, err
+		}
+		docs, err = h.docRepo.FindByStatus(ctx, kbID, status)
+		if err != nil {
+			return nil, err
+		}
+	case query.IncludeDrafts:
+		docs, err = h.docRepo.FindByKnowledgeBaseID(ctx, kbID)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		// 默认只对读者暴露已发布文档
+		docs, err = h.docRepo.FindByStatus(ctx, kbID, valueobject.DocumentStatusPublished)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	items := make([]*dto.DocumentDTO, len(docs))
 	for i, doc := range docs {
-		items[i] = dto.DocumentFromEntity(doc)
+		if items[i], err = renderDocument(h.renderer, doc, format, false); err != nil {
+			return nil, err
+		}
 	}
 
 	return &dto.DocumentListDTO{
@@ -48,3 +97,52 @@ func (h *ListDocumentsHandler) Handle(ctx context.Context, query *ListDocumentsQ
 		Total: len(items),
 	}, nil
 }
+
+// searchByTagQuery 按标签查询表达式搜索文档，再按发布状态过滤
+func (h *ListDocumentsHandler) searchByTagQuery(ctx context.Context, kbID valueobject.KnowledgeBaseID, query *ListDocumentsQuery) ([]*entity.Document, error) {
+	defs, err := h.tagRepo.FindByKnowledgeBaseID(ctx, kbID)
+	if err != nil {
+		return nil, err
+	}
+	expr, err := parseTagQuery(query.TagQuery, defs)
+	if err != nil {
+		return nil, err
+	}
+
+	// 状态过滤规则与不带标签查询时一致
+	status := valueobject.DocumentStatusPublished
+	if query.Status != "" {
+		if status, err = valueobject.DocumentStatusFromString(query.Status); err != nil {
+			return nil, err
+		}
+	}
+
+	docs, err := h.docRepo.SearchByTagQuery(ctx, kbID, expr)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*entity.Document, 0, len(docs))
+	for _, doc := range docs {
+		if (query.Status == "" && query.IncludeDrafts) || doc.Status() == status {
+			result = append(result, doc)
+		}
+	}
+	return result, nil
+}
+
+// parseTagQuery 解析标签查询表达式，并将其中的别名解析为标签注册表中的规范名称
+func parseTagQuery(raw string, defs []*entity.TagDefinition) (valueobject.TagExpr, error) {
+	expr, err := valueobject.ParseTagQuery(raw)
+	if err != nil {
+		return nil, err
+	}
+	return valueobject.MapTagExpr(expr, func(tag valueobject.Tag) valueobject.Tag {
+		for _, def := range defs {
+			if def.Matches(tag) {
+				return def.Name()
+			}
+		}
+		return tag
+	}), nil
+}
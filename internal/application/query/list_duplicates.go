@@ -0,0 +1,132 @@
+package query
+
+import (
+	"context"
+
+	"gozero-ddd/internal/application/auth"
+	"gozero-ddd/internal/application/dto"
+	"gozero-ddd/internal/domain"
+	"gozero-ddd/internal/domain/repository"
+	"gozero-ddd/internal/domain/service"
+	"gozero-ddd/internal/domain/valueobject"
+)
+
+// ListDuplicatesQuery 列出重复文档查询
+type ListDuplicatesQuery struct {
+	KnowledgeBaseID    string // 为空时检测所有知识库
+	CrossKnowledgeBase bool   // 指定知识库时，是否同时检测与其他知识库文档的重复
+	MaxDistance        int    // 近似重复的 SimHash 汉明距离上限，0 表示只把 SimHash 相同的文档视为近似重复
+}
+
+// ListDuplicatesHandler 列出重复文档查询处理器
+// 根据事件处理器维护的内容指纹，列出完全重复和近似重复的文档分组
+type ListDuplicatesHandler struct {
+	kbRepo           repository.KnowledgeBaseRepository
+	duplicateService *service.DuplicateService
+	permissions      *auth.PermissionChecker // 权限检查器
+}
+
+// NewListDuplicatesHandler 创建处理器
+func NewListDuplicatesHandler(
+	kbRepo repository.KnowledgeBaseRepository,
+	duplicateService *service.DuplicateService,
+	permissions *auth.PermissionChecker,
+) *ListDuplicatesHandler {
+	return &ListDuplicatesHandler{
+		kbRepo:           kbRepo,
+		duplicateService: duplicateService,
+		permissions:      permissions,
+	}
+}
+
+// Handle 处理列出重复文档查询
+func (h *ListDuplicatesHandler) Handle(ctx context.Context, query *ListDuplicatesQuery) (*dto.DuplicateReportDTO, error) {
+	var kbID valueobject.KnowledgeBaseID
+	if query.KnowledgeBaseID != "" {
+		// 验证 ID 格式
+		var err error
+		kbID, err = valueobject.KnowledgeBaseIDFromString(query.KnowledgeBaseID)
+		if err != nil {
+			return nil, err
+		}
+
+		// 检查调用方权限
+		if err := h.permissions.Check(ctx, kbID, valueobject.MemberRoleViewer); err != nil {
+			return nil, err
+		}
+
+		kb, err := h.kbRepo.FindByID(ctx, kbID)
+		if err != nil {
+			return nil, err
+		}
+		if kb == nil {
+			return nil, domain.ErrKnowledgeBaseNotFound
+		}
+	}
+
+	clusters, err := h.duplicateService.FindClusters(ctx, kbID, query.CrossKnowledgeBase, query.MaxDistance)
+	if err != nil {
+		return nil, err
+	}
+
+	// 跨知识库检测时只保留调用方可以查看的知识库中的文档
+	visible, all, err := h.permissions.VisibleKnowledgeBases(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]*dto.DuplicateClusterDTO, 0, len(clusters))
+	for _, c := range clusters {
+		if !all {
+			c = visibleCluster(c, visible)
+			if c == nil {
+				continue
+			}
+		}
+		items = append(items, duplicateClusterToDTO(c))
+	}
+
+	return &dto.DuplicateReportDTO{
+		KnowledgeBaseID:    query.KnowledgeBaseID,
+		CrossKnowledgeBase: query.CrossKnowledgeBase || query.KnowledgeBaseID == "",
+		MaxDistance:        query.MaxDistance,
+		Clusters:           items,
+		Total:              len(items),
+	}, nil
+}
+
+// visibleCluster 去掉分组中调用方无权查看的文档，剩余不足两篇时返回 nil
+func visibleCluster(c *service.DuplicateCluster, visible map[valueobject.KnowledgeBaseID]bool) *service.DuplicateCluster {
+	docs := make([]valueobject.DocumentFingerprint, 0, len(c.Documents))
+	for _, doc := range c.Documents {
+		if visible[doc.KnowledgeBaseID] {
+			docs = append(docs, doc)
+		}
+	}
+	if len(docs) < 2 {
+		return nil
+	}
+	return &service.DuplicateCluster{Kind: c.Kind, Documents: docs}
+}
+
+// duplicateClusterToDTO 将重复分组转换为DTO
+func duplicateClusterToDTO(c *service.DuplicateCluster) *dto.DuplicateClusterDTO {
+	item := &dto.DuplicateClusterDTO{
+		Kind:      c.Kind,
+		Documents: make([]*dto.DuplicateDocumentDTO, len(c.Documents)),
+	}
+	first := c.Documents[0]
+	for i, doc := range c.Documents {
+		if doc.KnowledgeBaseID != first.KnowledgeBaseID {
+			item.CrossKnowledgeBase = true
+		}
+		item.Documents[i] = &dto.DuplicateDocumentDTO{
+			DocumentID:      doc.DocumentID.String(),
+			KnowledgeBaseID: doc.KnowledgeBaseID.String(),
+			Title:           doc.Title,
+			ContentHash:     doc.Fingerprint.Hash.String(),
+			Distance:        doc.Fingerprint.Distance(first.Fingerprint),
+		}
+	}
+	return item
+}
@@ -0,0 +1,73 @@
+package query
+
+import (
+	"context"
+
+	"gozero-ddd/internal/application/auth"
+	"gozero-ddd/internal/application/dto"
+	"gozero-ddd/internal/domain"
+	"gozero-ddd/internal/domain/repository"
+	"gozero-ddd/internal/domain/valueobject"
+)
+
+// ListKnowledgeBaseMembersQuery 列出知识库成员查询
+type ListKnowledgeBaseMembersQuery struct {
+	KnowledgeBaseID string
+}
+
+// ListKnowledgeBaseMembersHandler 列出知识库成员查询处理器
+type ListKnowledgeBaseMembersHandler struct {
+	kbRepo      repository.KnowledgeBaseRepository
+	memberRepo  repository.KnowledgeBaseMemberRepository
+	permissions *auth.PermissionChecker // 权限检查器
+}
+
+// NewListKnowledgeBaseMembersHandler 创建处理器
+func NewListKnowledgeBaseMembersHandler(
+	kbRepo repository.KnowledgeBaseRepository,
+	memberRepo repository.KnowledgeBaseMemberRepository,
+	permissions *auth.PermissionChecker,
+) *ListKnowledgeBaseMembersHandler {
+	return &ListKnowledgeBaseMembersHandler{
+		kbRepo:      kbRepo,
+		memberRepo:  memberRepo,
+		permissions: permissions,
+	}
+}
+
+// Handle 处理列出知识库成员查询
+func (h *ListKnowledgeBaseMembersHandler) Handle(ctx context.Context, query *ListKnowledgeBaseMembersQuery) (*dto.KnowledgeBaseMemberListDTO, error) {
+	// 验证 ID 格式
+	kbID, err := valueobject.KnowledgeBaseIDFromString(query.KnowledgeBaseID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 检查调用方权限
+	if err := h.permissions.Check(ctx, kbID, valueobject.MemberRoleViewer); err != nil {
+		return nil, err
+	}
+
+	kb, err := h.kbRepo.FindByID(ctx, kbID)
+	if err != nil {
+		return nil, err
+	}
+	if kb == nil {
+		return nil, domain.ErrKnowledgeBaseNotFound
+	}
+
+	members, err := h.memberRepo.FindByKnowledgeBaseID(ctx, kbID)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]*dto.KnowledgeBaseMemberDTO, len(members))
+	for i, m := range members {
+		items[i] = dto.KnowledgeBaseMemberFromRecord(m)
+	}
+
+	return &dto.KnowledgeBaseMemberListDTO{
+		Items: items,
+		Total: len(items),
+	}, nil
+}
@@ -3,37 +3,65 @@ package query
 import (
 	"context"
 
+	"gozero-ddd/internal/application/auth"
 	"gozero-ddd/internal/application/dto"
+	"gozero-ddd/internal/domain/entity"
 	"gozero-ddd/internal/domain/repository"
+	"gozero-ddd/internal/domain/valueobject"
 )
 
 // ListKnowledgeBasesQuery 列出知识库查询
 type ListKnowledgeBasesQuery struct {
 	// 可以添加分页、过滤等参数
+	Status string // 按生命周期状态过滤，为空时返回所有状态
 }
 
 // ListKnowledgeBasesHandler 列出知识库查询处理器
 type ListKnowledgeBasesHandler struct {
-	kbRepo repository.KnowledgeBaseRepository
+	kbRepo      repository.KnowledgeBaseRepository
+	permissions *auth.PermissionChecker // 权限检查器
 }
 
 // NewListKnowledgeBasesHandler 创建处理器
-func NewListKnowledgeBasesHandler(kbRepo repository.KnowledgeBaseRepository) *ListKnowledgeBasesHandler {
+func NewListKnowledgeBasesHandler(kbRepo repository.KnowledgeBaseRepository, permissions *auth.PermissionChecker) *ListKnowledgeBasesHandler {
 	return &ListKnowledgeBasesHandler{
-		kbRepo: kbRepo,
+		kbRepo:      kbRepo,
+		permissions: permissions,
 	}
 }
 
 // Handle 处理列出知识库查询
 func (h *ListKnowledgeBasesHandler) Handle(ctx context.Context, query *ListKnowledgeBasesQuery) (*dto.KnowledgeBaseListDTO, error) {
-	kbs, err := h.kbRepo.FindAll(ctx)
+	var kbs []*entity.KnowledgeBase
+	if query.Status != "" {
+		// 验证状态值
+		status, err := valueobject.KnowledgeBaseStatusFromString(query.Status)
+		if err != nil {
+			return nil, err
+		}
+		kbs, err = h.kbRepo.FindByStatus(ctx, status)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		var err error
+		kbs, err = h.kbRepo.FindAll(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// 只返回调用方可以查看的知识库
+	visible, all, err := h.permissions.VisibleKnowledgeBases(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	items := make([]*dto.KnowledgeBaseDTO, len(kbs))
-	for i, kb := range kbs {
-		items[i] = dto.KnowledgeBaseFromEntity(kb, false)
+	items := make([]*dto.KnowledgeBaseDTO, 0, len(kbs))
+	for _, kb := range kbs {
+		if all || visible[kb.ID()] {
+			items = append(items, dto.KnowledgeBaseFromEntity(kb, false))
+		}
 	}
 
 	return &dto.KnowledgeBaseListDTO{
@@ -41,4 +69,3 @@ func (h *ListKnowledgeBasesHandler) Handle(ctx context.Context, query *ListKnowl
 		Total: len(items),
 	}, nil
 }
-
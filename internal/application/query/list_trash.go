@@ -0,0 +1,90 @@
+package query
+
+import (
+	"context"
+
+	"gozero-ddd/internal/application/auth"
+	"gozero-ddd/internal/application/dto"
+	"gozero-ddd/internal/domain/repository"
+	"gozero-ddd/internal/domain/valueobject"
+)
+
+// ListTrashQuery 列出回收站查询
+type ListTrashQuery struct {
+	// KnowledgeBaseID 为空时列出回收站中的知识库和所有已删除文档
+	// 不为空时只列出该知识库下的已删除文档
+	KnowledgeBaseID string
+}
+
+// ListTrashHandler 列出回收站查询处理器
+type ListTrashHandler struct {
+	kbRepo      repository.KnowledgeBaseRepository
+	docRepo     repository.DocumentRepository
+	permissions *auth.PermissionChecker // 权限检查器
+}
+
+// NewListTrashHandler 创建处理器
+func NewListTrashHandler(
+	kbRepo repository.KnowledgeBaseRepository,
+	docRepo repository.DocumentRepository,
+	permissions *auth.PermissionChecker,
+) *ListTrashHandler {
+	return &ListTrashHandler{
+		kbRepo:      kbRepo,
+		docRepo:     docRepo,
+		permissions: permissions,
+	}
+}
+
+// Handle 处理列出回收站查询
+func (h *ListTrashHandler) Handle(ctx context.Context, query *ListTrashQuery) (*dto.TrashListDTO, error) {
+	result := &dto.TrashListDTO{
+		KnowledgeBases: make([]*dto.KnowledgeBaseDTO, 0),
+		Documents:      make([]*dto.DocumentDTO, 0),
+	}
+
+	var kbID valueobject.KnowledgeBaseID
+	visible, all := map[valueobject.KnowledgeBaseID]bool(nil), true
+	if query.KnowledgeBaseID != "" {
+		// 验证 ID 格式
+		id, err := valueobject.KnowledgeBaseIDFromString(query.KnowledgeBaseID)
+		if err != nil {
+			return nil, err
+		}
+		kbID = id
+
+		// 检查调用方权限
+		if err := h.permissions.Check(ctx, kbID, valueobject.MemberRoleViewer); err != nil {
+			return nil, err
+		}
+	} else {
+		// 只列出调用方可以查看的知识库中的数据
+		var err error
+		visible, all, err = h.permissions.VisibleKnowledgeBases(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		kbs, err := h.kbRepo.FindDeleted(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, kb := range kbs {
+			if all || visible[kb.ID()] {
+				result.KnowledgeBases = append(result.KnowledgeBases, dto.KnowledgeBaseFromEntity(kb, false))
+			}
+		}
+	}
+
+	docs, err := h.docRepo.FindDeleted(ctx, kbID)
+	if err != nil {
+		return nil, err
+	}
+	for _, doc := range docs {
+		if all || visible[doc.KnowledgeBaseID()] {
+			result.Documents = append(result.Documents, dto.DocumentFromEntity(doc))
+		}
+	}
+
+	return result, nil
+}
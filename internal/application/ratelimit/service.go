@@ -0,0 +1,235 @@
+package ratelimit
+
+import (
+	"context"
+	"log"
+	"net"
+	"sort"
+	"strings"
+	"time"
+
+	"gozero-ddd/internal/application/auth"
+)
+
+const (
+	// DefaultReadCost 未配置时读请求消耗的令牌数
+	DefaultReadCost = 1
+	// DefaultWriteCost 未配置时写请求消耗的令牌数
+	DefaultWriteCost = 5
+)
+
+// Limit 令牌桶参数
+type Limit struct {
+	Rate  float64 // 每秒补充的令牌数
+	Burst int     // 桶容量，即允许的最大突发消耗
+}
+
+// Result 一次取令牌的结果
+type Result struct {
+	Allowed    bool          // 令牌是否足够
+	Remaining  int           // 取令牌后桶中剩余的令牌数（向下取整）
+	RetryAfter time.Duration // 令牌不足时，补充到足够令牌需要等待的时间
+}
+
+// Limiter 令牌桶限流器接口
+// 按 key 维护相互独立的令牌桶，由基础设施层实现（单实例用内存，多实例共享用 Redis）
+type Limiter interface {
+	// Take 从 key 对应的令牌桶中取出 cost 个令牌，令牌不足时不扣减
+	Take(ctx context.Context, key string, limit Limit, cost int) (Result, error)
+}
+
+// Policy 限流策略
+type Policy struct {
+	Limit
+	ReadCost  int // 读请求消耗的令牌数
+	WriteCost int // 写请求消耗的令牌数
+
+	// IP 认证之前按客户端 IP 限流的令牌桶参数，每个请求消耗一个令牌，Rate 为 0 时不启用。
+	// 认证失败的请求不会进入按调用方分桶的限流，由这个令牌桶限制暴力尝试访问令牌或 API Key
+	IP Limit
+
+	// Routes 按路由覆盖的消耗：REST 路由写作 "POST /api/v1/knowledge/:id/import"，
+	// :param 段匹配任意值；gRPC 方法写作方法全名，如 "/knowledge.KnowledgeService/CreateKnowledgeBase"
+	Routes map[string]int
+}
+
+// Service 限流服务
+// 供接口层的中间件和拦截器使用：按调用方（API Key、用户或客户端 IP）分桶，
+// 每个请求按路由消耗令牌，写请求默认比读请求消耗更多。
+// 限流器出错（如 Redis 不可用）时放行请求，避免限流组件故障导致整个服务不可用
+type Service struct {
+	limiter    Limiter
+	policy     Policy
+	restRoutes []restRoute
+}
+
+// restRoute 按路由覆盖消耗的 REST 路由
+type restRoute struct {
+	method   string
+	segments []string
+	cost     int
+}
+
+// NewService 创建限流服务，limiter 为 nil 时不限流
+func NewService(limiter Limiter, policy Policy) *Service {
+	if policy.ReadCost <= 0 {
+		policy.ReadCost = DefaultReadCost
+	}
+	if policy.WriteCost <= 0 {
+		policy.WriteCost = DefaultWriteCost
+	}
+
+	s := &Service{limiter: limiter, policy: policy}
+	for route, cost := range policy.Routes {
+		method, path, ok := strings.Cut(strings.TrimSpace(route), " ")
+		if !ok {
+			// gRPC 方法全名，按原样匹配
+			continue
+		}
+		s.restRoutes = append(s.restRoutes, restRoute{
+			method:   strings.ToUpper(method),
+			segments: splitPath(strings.TrimSpace(path)),
+			cost:     cost,
+		})
+	}
+	// 多个路由都匹配时，参数段少的（更具体的）优先
+	sort.SliceStable(s.restRoutes, func(i, j int) bool {
+		return countParams(s.restRoutes[i].segments) < countParams(s.restRoutes[j].segments)
+	})
+	return s
+}
+
+// Enabled 是否启用了限流
+func (s *Service) Enabled() bool {
+	return s != nil && s.limiter != nil
+}
+
+// Allow 为调用方取出 cost 个令牌
+// 调用方从上下文中的已认证调用方确定，匿名请求按 remoteAddr 中的客户端 IP 分桶
+func (s *Service) Allow(ctx context.Context, remoteAddr string, cost int) Result {
+	if !s.Enabled() {
+		return Result{Allowed: true}
+	}
+	// 消耗超过桶容量的请求永远拿不到足够的令牌，按桶容量计
+	if cost > s.policy.Burst {
+		cost = s.policy.Burst
+	}
+
+	return s.take(ctx, Key(ctx, remoteAddr), s.policy.Limit, cost)
+}
+
+// IPEnabled 是否启用了认证之前按客户端 IP 的限流
+func (s *Service) IPEnabled() bool {
+	return s.Enabled() && s.policy.IP.Rate > 0 && s.policy.IP.Burst > 0
+}
+
+// AllowIP 在认证之前为 remoteAddr 中的客户端 IP 取出一个令牌
+// 与 Allow 使用不同的令牌桶，认证失败的请求同样计数
+func (s *Service) AllowIP(ctx context.Context, remoteAddr string) Result {
+	if !s.IPEnabled() {
+		return Result{Allowed: true}
+	}
+	return s.take(ctx, "preauth:"+ipKey(remoteAddr), s.policy.IP, 1)
+}
+
+// take 从 key 对应的令牌桶中取出令牌，限流器出错时放行
+func (s *Service) take(ctx context.Context, key string, limit Limit, cost int) Result {
+	result, err := s.limiter.Take(ctx, key, limit, cost)
+	if err != nil {
+		log.Printf("⚠️ [RateLimit] 限流器不可用，放行请求: Key=%s, Error=%v", key, err)
+		return Result{Allowed: true}
+	}
+	return result
+}
+
+// HTTPCost 返回 REST 请求消耗的令牌数
+func (s *Service) HTTPCost(method, path string) int {
+	segments := splitPath(path)
+	for _, route := range s.restRoutes {
+		if route.method == method && matchSegments(route.segments, segments) {
+			return route.cost
+		}
+	}
+	switch method {
+	case "GET", "HEAD", "OPTIONS":
+		return s.policy.ReadCost
+	default:
+		return s.policy.WriteCost
+	}
+}
+
+// RPCCost 返回 gRPC 调用消耗的令牌数
+// 方法名以 Get、List、Search、Export 开头的视为读请求
+func (s *Service) RPCCost(fullMethod string) int {
+	if cost, ok := s.policy.Routes[fullMethod]; ok {
+		return cost
+	}
+	name := fullMethod[strings.LastIndex(fullMethod, "/")+1:]
+	for _, prefix := range []string{"Get", "List", "Search", "Export"} {
+		if strings.HasPrefix(name, prefix) {
+			return s.policy.ReadCost
+		}
+	}
+	return s.policy.WriteCost
+}
+
+// Key 返回调用方的令牌桶标识
+// 通过 API Key 认证时按 API Key，通过访问令牌认证时按用户，匿名请求按客户端 IP
+func Key(ctx context.Context, remoteAddr string) string {
+	if principal, ok := auth.PrincipalFromContext(ctx); ok {
+		if principal.APIKey != nil {
+			return "apikey:" + principal.APIKey.KeyID
+		}
+		return "user:" + principal.Subject
+	}
+	return ipKey(remoteAddr)
+}
+
+// ipKey 返回按客户端 IP 分桶的令牌桶标识
+func ipKey(remoteAddr string) string {
+	if host, _, err := net.SplitHostPort(remoteAddr); err == nil {
+		return "ip:" + host
+	}
+	return "ip:" + remoteAddr
+}
+
+// RetryAfterSeconds 将等待时间换算为 Retry-After 的秒数（向上取整，至少 1 秒）
+func RetryAfterSeconds(d time.Duration) int {
+	seconds := int((d + time.Second - 1) / time.Second)
+	if seconds < 1 {
+		return 1
+	}
+	return seconds
+}
+
+// splitPath 将路径按 "/" 拆分为段，忽略首尾的 "/"
+func splitPath(path string) []string {
+	return strings.Split(strings.Trim(path, "/"), "/")
+}
+
+// matchSegments 判断请求路径是否匹配路由，路由中以 ":" 开头的段匹配任意值
+func matchSegments(route, path []string) bool {
+	if len(route) != len(path) {
+		return false
+	}
+	for i, segment := range route {
+		if strings.HasPrefix(segment, ":") {
+			continue
+		}
+		if segment != path[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// countParams 统计路由中的参数段数量
+func countParams(segments []string) int {
+	n := 0
+	for _, segment := range segments {
+		if strings.HasPrefix(segment, ":") {
+			n++
+		}
+	}
+	return n
+}
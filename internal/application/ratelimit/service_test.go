@@ -0,0 +1,79 @@
+package ratelimit
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"gozero-ddd/internal/application/auth"
+)
+
+// countingLimiter 按 key 计数的限流器，每个令牌桶最多放行 burst 个令牌
+type countingLimiter struct {
+	taken map[string]int
+	keys  []string
+}
+
+func (l *countingLimiter) Take(_ context.Context, key string, limit Limit, cost int) (Result, error) {
+	l.keys = append(l.keys, key)
+	if l.taken[key]+cost > limit.Burst {
+		return Result{Allowed: false}, nil
+	}
+	l.taken[key] += cost
+	return Result{Allowed: true, Remaining: limit.Burst - l.taken[key]}, nil
+}
+
+func TestServiceAllowIP(t *testing.T) {
+	limiter := &countingLimiter{taken: make(map[string]int)}
+	service := NewService(limiter, Policy{
+		Limit: Limit{Rate: 1, Burst: 100},
+		IP:    Limit{Rate: 1, Burst: 2},
+	})
+
+	// 认证之前的令牌桶与调用方的令牌桶相互独立，同一 IP 的不同端口共用一个令牌桶
+	var allowed []bool
+	for _, addr := range []string{"10.0.0.1:1000", "10.0.0.1:1001", "10.0.0.1:1002", "10.0.0.2:1000"} {
+		allowed = append(allowed, service.AllowIP(context.Background(), addr).Allowed)
+	}
+	if want := []bool{true, true, false, true}; !reflect.DeepEqual(allowed, want) {
+		t.Errorf("AllowIP() allowed = %v, want %v", allowed, want)
+	}
+
+	// 已认证的调用方在认证之前仍按 IP 计数
+	ctx := auth.WithPrincipal(context.Background(), &auth.Principal{Subject: "alice"})
+	if service.AllowIP(ctx, "10.0.0.1:1003").Allowed {
+		t.Error("AllowIP() for authenticated caller ignored the exhausted IP bucket")
+	}
+	if !service.Allow(ctx, "10.0.0.1:1003", 1).Allowed {
+		t.Error("Allow() shares tokens with the pre-auth IP bucket")
+	}
+
+	wantKeys := []string{"preauth:ip:10.0.0.1", "preauth:ip:10.0.0.1", "preauth:ip:10.0.0.1", "preauth:ip:10.0.0.2", "preauth:ip:10.0.0.1", "user:alice"}
+	if !reflect.DeepEqual(limiter.keys, wantKeys) {
+		t.Errorf("keys = %q, want %q", limiter.keys, wantKeys)
+	}
+}
+
+func TestServiceIPEnabled(t *testing.T) {
+	limiter := &countingLimiter{taken: make(map[string]int)}
+	tests := []struct {
+		name    string
+		service *Service
+		want    bool
+	}{
+		{"rate limiting disabled", NewService(nil, Policy{IP: Limit{Rate: 1, Burst: 1}}), false},
+		{"ip limit not configured", NewService(limiter, Policy{Limit: Limit{Rate: 1, Burst: 1}}), false},
+		{"ip limit configured", NewService(limiter, Policy{Limit: Limit{Rate: 1, Burst: 1}, IP: Limit{Rate: 1, Burst: 1}}), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.service.IPEnabled(); got != tt.want {
+				t.Errorf("IPEnabled() = %v, want %v", got, tt.want)
+			}
+			if !tt.want && !tt.service.AllowIP(context.Background(), "10.0.0.1:1000").Allowed {
+				t.Error("AllowIP() rejected a request while the IP limit is disabled")
+			}
+		})
+	}
+}
@@ -0,0 +1,152 @@
+package requestmeta
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"log"
+	"strings"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// MaxRequestIDLength 客户端传入的请求ID的最大长度
+const MaxRequestIDLength = 128
+
+// traceparentVersion 生成的 traceparent 使用的版本
+const traceparentVersion = "00"
+
+// Metadata 请求元数据
+// 由接口层的中间件和拦截器在请求开始时放入上下文，Kafka 消费端从事件元数据中恢复，
+// 用于日志、错误响应和审计日志关联同一个请求
+type Metadata struct {
+	RequestID  string // 请求ID，客户端未提供时由服务端生成
+	ClientIP   string // 客户端 IP
+	TraceID    string // W3C Trace Context 的 trace-id（32 位十六进制）
+	SpanID     string // 当前服务处理该请求的 span-id（16 位十六进制）
+	TraceFlags string // W3C Trace Context 的 trace-flags（2 位十六进制）
+}
+
+// metadataKey 上下文键，使用私有类型避免与其他包冲突
+type metadataKey struct{}
+
+// WithMetadata 将请求元数据放入上下文
+func WithMetadata(ctx context.Context, md Metadata) context.Context {
+	return context.WithValue(ctx, metadataKey{}, md)
+}
+
+// FromContext 从上下文中取出请求元数据
+// 后台任务等不是由请求发起的调用返回零值
+func FromContext(ctx context.Context) Metadata {
+	md, _ := ctx.Value(metadataKey{}).(Metadata)
+	return md
+}
+
+// New 为新到达的请求（或消费到的事件）创建请求元数据
+// requestID 和 traceparent 为上游传入的值，无效时重新生成。
+// 上下文中已有 OpenTelemetry span 时沿用其 trace-id、span-id 和 trace-flags，
+// 使日志和错误响应中的 trace-id 与导出的链路一致；
+// 否则有效的 traceparent 沿用其 trace-id 和 trace-flags，并为当前服务生成新的 span-id
+func New(ctx context.Context, requestID, traceparent, clientIP string) Metadata {
+	md := Metadata{
+		RequestID: ResolveRequestID(requestID),
+		ClientIP:  clientIP,
+		SpanID:    randomHex(8),
+	}
+	if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+		md.TraceID, md.SpanID, md.TraceFlags = sc.TraceID().String(), sc.SpanID().String(), sc.TraceFlags().String()
+	} else if traceID, flags, ok := parseTraceparent(traceparent); ok {
+		md.TraceID, md.TraceFlags = traceID, flags
+	} else {
+		md.TraceID, md.TraceFlags = randomHex(16), "01"
+	}
+	return md
+}
+
+// Traceparent 返回传给下游的 W3C traceparent，没有 trace-id 时返回空字符串
+func (m Metadata) Traceparent() string {
+	if m.TraceID == "" || m.SpanID == "" {
+		return ""
+	}
+	flags := m.TraceFlags
+	if flags == "" {
+		flags = "00"
+	}
+	return traceparentVersion + "-" + m.TraceID + "-" + m.SpanID + "-" + flags
+}
+
+// Logf 输出日志，末尾附加上下文中的请求ID和 trace-id，便于按请求检索日志
+func Logf(ctx context.Context, format string, args ...interface{}) {
+	md := FromContext(ctx)
+	if md.RequestID == "" && md.TraceID == "" {
+		log.Printf(format, args...)
+		return
+	}
+	log.Printf(format+", RequestID=%s, TraceID=%s", append(args, md.RequestID, md.TraceID)...)
+}
+
+// ResolveRequestID 返回客户端传入的请求ID，未传入或无效时生成新的请求ID
+// 有效的请求ID为 1-128 个可打印 ASCII 字符
+func ResolveRequestID(requested string) string {
+	if validRequestID(requested) {
+		return requested
+	}
+	return uuid.New().String()
+}
+
+// validRequestID 校验请求ID
+func validRequestID(id string) bool {
+	if id == "" || len(id) > MaxRequestIDLength {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] < 0x21 || id[i] > 0x7e {
+			return false
+		}
+	}
+	return true
+}
+
+// parseTraceparent 解析 W3C traceparent（version-traceid-parentid-flags），返回 trace-id 和 trace-flags
+// 版本 ff 无效；高于 00 的版本按规范只解析前四个字段
+func parseTraceparent(s string) (traceID, flags string, ok bool) {
+	parts := strings.Split(strings.TrimSpace(s), "-")
+	if len(parts) < 4 {
+		return "", "", false
+	}
+	version, traceID, parentID, flags := parts[0], parts[1], parts[2], parts[3]
+	if !isLowerHex(version, 2) || version == "ff" || (version == traceparentVersion && len(parts) != 4) {
+		return "", "", false
+	}
+	if !isLowerHex(traceID, 32) || isZero(traceID) || !isLowerHex(parentID, 16) || isZero(parentID) || !isLowerHex(flags, 2) {
+		return "", "", false
+	}
+	return traceID, flags, true
+}
+
+// isLowerHex 判断 s 是否为指定长度的小写十六进制字符串
+func isLowerHex(s string, n int) bool {
+	if len(s) != n {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if !(c >= '0' && c <= '9' || c >= 'a' && c <= 'f') {
+			return false
+		}
+	}
+	return true
+}
+
+// isZero 判断十六进制字符串是否全为 0（全 0 的 trace-id 和 parent-id 无效）
+func isZero(s string) bool {
+	return strings.Trim(s, "0") == ""
+}
+
+// randomHex 生成 n 个随机字节的十六进制字符串
+func randomHex(n int) string {
+	b := make([]byte, n)
+	_, _ = rand.Read(b) // crypto/rand 在支持的平台上不会返回错误
+	return hex.EncodeToString(b)
+}
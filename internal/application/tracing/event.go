@@ -0,0 +1,99 @@
+package tracing
+
+import (
+	"context"
+	"encoding/json"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"gozero-ddd/internal/application/cqrs"
+	"gozero-ddd/internal/domain/event"
+)
+
+// Publisher 带链路追踪的事件发布器
+// 包装事件总线，每次发布一个 span
+type Publisher struct {
+	next event.EventPublisher
+}
+
+// NewPublisher 创建带链路追踪的事件发布器
+func NewPublisher(next event.EventPublisher) *Publisher {
+	return &Publisher{next: next}
+}
+
+// 确保实现了接口
+var _ event.EventPublisher = (*Publisher)(nil)
+
+// Publish 发布单个事件
+func (p *Publisher) Publish(ctx context.Context, evt event.DomainEvent) (err error) {
+	ctx, span := Start(ctx, "event publish "+evt.EventName(),
+		trace.WithSpanKind(trace.SpanKindProducer),
+		trace.WithAttributes(eventAttributes(evt)...))
+	defer func() { End(span, err) }()
+
+	return p.next.Publish(ctx, evt)
+}
+
+// PublishAll 发布多个事件
+func (p *Publisher) PublishAll(ctx context.Context, events []event.DomainEvent) (err error) {
+	kv := []attribute.KeyValue{EventCountKey.Int(len(events))}
+	if len(events) == 1 {
+		kv = append(kv, eventAttributes(events[0])...)
+	}
+	ctx, span := Start(ctx, "event publish", trace.WithSpanKind(trace.SpanKindProducer), trace.WithAttributes(kv...))
+	defer func() { End(span, err) }()
+
+	return p.next.PublishAll(ctx, events)
+}
+
+// EventHandler 带链路追踪的事件处理器
+// 每次处理事件一个 span，名为 "event handle <事件名>"
+type EventHandler struct {
+	next event.EventHandler
+	name string
+}
+
+// NewEventHandler 创建带链路追踪的事件处理器
+func NewEventHandler(next event.EventHandler) *EventHandler {
+	return &EventHandler{next: next, name: cqrs.EventHandlerName(next)}
+}
+
+// 确保实现了接口
+var _ event.EventHandler = (*EventHandler)(nil)
+
+// EventName 返回被包装处理器处理的事件名称
+func (h *EventHandler) EventName() string {
+	return h.next.EventName()
+}
+
+// Unwrap 返回被包装的处理器
+func (h *EventHandler) Unwrap() event.EventHandler {
+	return h.next
+}
+
+// Handle 在 span 中处理事件
+func (h *EventHandler) Handle(ctx context.Context, evt event.DomainEvent) (err error) {
+	kv := append(eventAttributes(evt), EventHandlerKey.String(h.name))
+	ctx, span := Start(ctx, "event handle "+evt.EventName(), trace.WithAttributes(kv...))
+	defer func() { End(span, err) }()
+
+	return h.next.Handle(ctx, evt)
+}
+
+// eventAttributes 返回事件的 span 属性：事件名称、知识库ID、文档ID
+// 从 Kafka 消费的事件只有序列化后的数据，从 JSON 中提取
+func eventAttributes(evt event.DomainEvent) []attribute.KeyValue {
+	kv := []attribute.KeyValue{EventNameKey.String(evt.EventName())}
+	if wrapped, ok := evt.(interface{ Payload() json.RawMessage }); ok {
+		var ids struct {
+			KnowledgeBaseID string
+			DocumentID      string
+		}
+		if json.Unmarshal(wrapped.Payload(), &ids) == nil {
+			return append(kv, fieldAttributes(ids)...)
+		}
+		return kv
+	}
+	return append(kv, fieldAttributes(evt)...)
+}
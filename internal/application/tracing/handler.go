@@ -0,0 +1,91 @@
+package tracing
+
+import (
+	"context"
+	"reflect"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"gozero-ddd/internal/application/cqrs"
+)
+
+// Attributes 从命令或查询中提取 span 属性
+// 默认按字段名 KnowledgeBaseID、DocumentID 提取，字段名不同时（如 UpdateKnowledgeBaseCommand.ID）需要显式指定
+type Attributes[Req any] func(req Req) []attribute.KeyValue
+
+// KnowledgeBaseIDFrom 返回从请求的指定字段提取知识库ID的 Attributes
+func KnowledgeBaseIDFrom[Req any](get func(req Req) string) Attributes[Req] {
+	return func(req Req) []attribute.KeyValue {
+		return KnowledgeBaseID(get(req))
+	}
+}
+
+// Command 为命令处理器添加链路追踪，span 名为 "command <name>"
+func Command[Req, Res any](name string, next func(ctx context.Context, req Req) (Res, error), attrs ...Attributes[Req]) cqrs.HandlerFunc[Req, Res] {
+	return traced("command "+name, next, attrs)
+}
+
+// VoidCommand 为只返回错误的命令处理器添加链路追踪，span 名为 "command <name>"
+func VoidCommand[Req any](name string, next func(ctx context.Context, req Req) error, attrs ...Attributes[Req]) cqrs.VoidHandlerFunc[Req] {
+	handle := traced("command "+name, func(ctx context.Context, req Req) (struct{}, error) {
+		return struct{}{}, next(ctx, req)
+	}, attrs)
+	return func(ctx context.Context, req Req) error {
+		_, err := handle(ctx, req)
+		return err
+	}
+}
+
+// Query 为查询处理器添加链路追踪，span 名为 "query <name>"
+func Query[Req, Res any](name string, next func(ctx context.Context, req Req) (Res, error), attrs ...Attributes[Req]) cqrs.HandlerFunc[Req, Res] {
+	return traced("query "+name, next, attrs)
+}
+
+// traced 在 span 中调用处理器
+func traced[Req, Res any](spanName string, next func(ctx context.Context, req Req) (Res, error), attrs []Attributes[Req]) cqrs.HandlerFunc[Req, Res] {
+	return func(ctx context.Context, req Req) (res Res, err error) {
+		kv := fieldAttributes(req)
+		for _, extract := range attrs {
+			kv = append(kv, extract(req)...)
+		}
+
+		ctx, span := Start(ctx, spanName, trace.WithAttributes(kv...))
+		defer func() { End(span, err) }()
+
+		return next(ctx, req)
+	}
+}
+
+// idFields 按字段名提取的 ID 属性
+var idFields = []struct {
+	name string
+	key  attribute.Key
+}{
+	{"KnowledgeBaseID", KnowledgeBaseIDKey},
+	{"DocumentID", DocumentIDKey},
+}
+
+// fieldAttributes 从结构体（或结构体指针）的 KnowledgeBaseID、DocumentID 字段提取属性
+// 只提取字符串类型（包括 valueobject 中的 ID 类型）的非空字段
+func fieldAttributes(v interface{}) []attribute.KeyValue {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return nil
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return nil
+	}
+
+	var kv []attribute.KeyValue
+	for _, field := range idFields {
+		f := rv.FieldByName(field.name)
+		if f.IsValid() && f.Kind() == reflect.String && f.String() != "" {
+			kv = append(kv, field.key.String(f.String()))
+		}
+	}
+	return kv
+}
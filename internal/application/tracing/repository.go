@@ -0,0 +1,737 @@
+package tracing
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"gozero-ddd/internal/domain/entity"
+	"gozero-ddd/internal/domain/repository"
+	"gozero-ddd/internal/domain/tenant"
+	"gozero-ddd/internal/domain/valueobject"
+)
+
+// 带链路追踪的仓储
+// 包装仓储接口，每次调用一个 span，名为 "repository <仓储>.<方法>"，
+// 带有仓储名、方法名以及参数中的知识库ID和文档ID；GORM 插件记录的 SQL 语句 span 挂在其下
+
+// KnowledgeBaseRepository 带链路追踪的知识库仓储
+type KnowledgeBaseRepository struct {
+	next repository.KnowledgeBaseRepository
+}
+
+// NewKnowledgeBaseRepository 创建带链路追踪的知识库仓储
+func NewKnowledgeBaseRepository(next repository.KnowledgeBaseRepository) *KnowledgeBaseRepository {
+	return &KnowledgeBaseRepository{next: next}
+}
+
+// 确保实现了接口
+var _ repository.KnowledgeBaseRepository = (*KnowledgeBaseRepository)(nil)
+
+const knowledgeBaseRepository = "KnowledgeBaseRepository"
+
+// Save 保存知识库（创建或更新）
+func (r *KnowledgeBaseRepository) Save(ctx context.Context, kb *entity.KnowledgeBase) error {
+	return repositoryExec(ctx, knowledgeBaseRepository, "Save", idAttributes(kb.ID(), ""), func(ctx context.Context) error {
+		return r.next.Save(ctx, kb)
+	})
+}
+
+// FindByID 根据ID查找知识库
+func (r *KnowledgeBaseRepository) FindByID(ctx context.Context, id valueobject.KnowledgeBaseID) (*entity.KnowledgeBase, error) {
+	return repositoryCall(ctx, knowledgeBaseRepository, "FindByID", idAttributes(id, ""), func(ctx context.Context) (*entity.KnowledgeBase, error) {
+		return r.next.FindByID(ctx, id)
+	})
+}
+
+// FindAll 查找所有知识库
+func (r *KnowledgeBaseRepository) FindAll(ctx context.Context) ([]*entity.KnowledgeBase, error) {
+	return repositoryCall(ctx, knowledgeBaseRepository, "FindAll", nil, r.next.FindAll)
+}
+
+// FindByStatus 查找指定生命周期状态的知识库
+func (r *KnowledgeBaseRepository) FindByStatus(ctx context.Context, status valueobject.KnowledgeBaseStatus) ([]*entity.KnowledgeBase, error) {
+	return repositoryCall(ctx, knowledgeBaseRepository, "FindByStatus", nil, func(ctx context.Context) ([]*entity.KnowledgeBase, error) {
+		return r.next.FindByStatus(ctx, status)
+	})
+}
+
+// Delete 删除知识库（软删除，移入回收站）
+func (r *KnowledgeBaseRepository) Delete(ctx context.Context, id valueobject.KnowledgeBaseID) error {
+	return repositoryExec(ctx, knowledgeBaseRepository, "Delete", idAttributes(id, ""), func(ctx context.Context) error {
+		return r.next.Delete(ctx, id)
+	})
+}
+
+// ExistsByName 检查名称在当前租户内是否已存在（包含回收站中的知识库）
+func (r *KnowledgeBaseRepository) ExistsByName(ctx context.Context, name string) (bool, error) {
+	return repositoryCall(ctx, knowledgeBaseRepository, "ExistsByName", nil, func(ctx context.Context) (bool, error) {
+		return r.next.ExistsByName(ctx, name)
+	})
+}
+
+// FindDeleted 查找回收站中的所有知识库
+func (r *KnowledgeBaseRepository) FindDeleted(ctx context.Context) ([]*entity.KnowledgeBase, error) {
+	return repositoryCall(ctx, knowledgeBaseRepository, "FindDeleted", nil, r.next.FindDeleted)
+}
+
+// FindDeletedByID 根据ID查找回收站中的知识库
+func (r *KnowledgeBaseRepository) FindDeletedByID(ctx context.Context, id valueobject.KnowledgeBaseID) (*entity.KnowledgeBase, error) {
+	return repositoryCall(ctx, knowledgeBaseRepository, "FindDeletedByID", idAttributes(id, ""), func(ctx context.Context) (*entity.KnowledgeBase, error) {
+		return r.next.FindDeletedByID(ctx, id)
+	})
+}
+
+// FindDeletedBefore 查找在指定时间之前移入回收站的知识库
+func (r *KnowledgeBaseRepository) FindDeletedBefore(ctx context.Context, before time.Time) ([]*entity.KnowledgeBase, error) {
+	return repositoryCall(ctx, knowledgeBaseRepository, "FindDeletedBefore", nil, func(ctx context.Context) ([]*entity.KnowledgeBase, error) {
+		return r.next.FindDeletedBefore(ctx, before)
+	})
+}
+
+// Restore 从回收站恢复知识库
+func (r *KnowledgeBaseRepository) Restore(ctx context.Context, id valueobject.KnowledgeBaseID) error {
+	return repositoryExec(ctx, knowledgeBaseRepository, "Restore", idAttributes(id, ""), func(ctx context.Context) error {
+		return r.next.Restore(ctx, id)
+	})
+}
+
+// Purge 彻底删除知识库（物理删除，不可恢复）
+func (r *KnowledgeBaseRepository) Purge(ctx context.Context, id valueobject.KnowledgeBaseID) error {
+	return repositoryExec(ctx, knowledgeBaseRepository, "Purge", idAttributes(id, ""), func(ctx context.Context) error {
+		return r.next.Purge(ctx, id)
+	})
+}
+
+// FindTenantIDs 查找拥有知识库（包括回收站中的）的所有租户
+// 唯一不按租户过滤的方法，供后台任务逐个租户执行
+func (r *KnowledgeBaseRepository) FindTenantIDs(ctx context.Context) ([]tenant.ID, error) {
+	return repositoryCall(ctx, knowledgeBaseRepository, "FindTenantIDs", nil, r.next.FindTenantIDs)
+}
+
+// CountByStatus 按状态统计知识库数量（不包括回收站中的）
+func (r *KnowledgeBaseRepository) CountByStatus(ctx context.Context) (map[valueobject.KnowledgeBaseStatus]int, error) {
+	return repositoryCall(ctx, knowledgeBaseRepository, "CountByStatus", nil, r.next.CountByStatus)
+}
+
+// DocumentRepository 带链路追踪的文档仓储
+type DocumentRepository struct {
+	next repository.DocumentRepository
+}
+
+// NewDocumentRepository 创建带链路追踪的文档仓储
+func NewDocumentRepository(next repository.DocumentRepository) *DocumentRepository {
+	return &DocumentRepository{next: next}
+}
+
+// 确保实现了接口
+var _ repository.DocumentRepository = (*DocumentRepository)(nil)
+
+const documentRepository = "DocumentRepository"
+
+// Save 保存文档
+func (r *DocumentRepository) Save(ctx context.Context, doc *entity.Document) error {
+	return repositoryExec(ctx, documentRepository, "Save", idAttributes(doc.KnowledgeBaseID(), doc.ID()), func(ctx context.Context) error {
+		return r.next.Save(ctx, doc)
+	})
+}
+
+// FindByID 根据ID查找文档
+func (r *DocumentRepository) FindByID(ctx context.Context, id valueobject.DocumentID) (*entity.Document, error) {
+	return repositoryCall(ctx, documentRepository, "FindByID", idAttributes("", id), func(ctx context.Context) (*entity.Document, error) {
+		return r.next.FindByID(ctx, id)
+	})
+}
+
+// FindByKnowledgeBaseID 根据知识库ID查找所有文档
+func (r *DocumentRepository) FindByKnowledgeBaseID(ctx context.Context, kbID valueobject.KnowledgeBaseID) ([]*entity.Document, error) {
+	return repositoryCall(ctx, documentRepository, "FindByKnowledgeBaseID", idAttributes(kbID, ""), func(ctx context.Context) ([]*entity.Document, error) {
+		return r.next.FindByKnowledgeBaseID(ctx, kbID)
+	})
+}
+
+// FindByStatus 根据知识库ID和发布状态查找文档
+func (r *DocumentRepository) FindByStatus(ctx context.Context, kbID valueobject.KnowledgeBaseID, status valueobject.DocumentStatus) ([]*entity.Document, error) {
+	return repositoryCall(ctx, documentRepository, "FindByStatus", idAttributes(kbID, ""), func(ctx context.Context) ([]*entity.Document, error) {
+		return r.next.FindByStatus(ctx, kbID, status)
+	})
+}
+
+// FindDueForPublish 查找到达定时发布时间的已审核文档
+func (r *DocumentRepository) FindDueForPublish(ctx context.Context, now time.Time) ([]*entity.Document, error) {
+	return repositoryCall(ctx, documentRepository, "FindDueForPublish", nil, func(ctx context.Context) ([]*entity.Document, error) {
+		return r.next.FindDueForPublish(ctx, now)
+	})
+}
+
+// FindDueForExpiry 查找到达定时下线时间的已发布文档
+func (r *DocumentRepository) FindDueForExpiry(ctx context.Context, now time.Time) ([]*entity.Document, error) {
+	return repositoryCall(ctx, documentRepository, "FindDueForExpiry", nil, func(ctx context.Context) ([]*entity.Document, error) {
+		return r.next.FindDueForExpiry(ctx, now)
+	})
+}
+
+// Delete 删除文档（软删除，移入回收站）
+func (r *DocumentRepository) Delete(ctx context.Context, id valueobject.DocumentID) error {
+	return repositoryExec(ctx, documentRepository, "Delete", idAttributes("", id), func(ctx context.Context) error {
+		return r.next.Delete(ctx, id)
+	})
+}
+
+// DeleteByKnowledgeBaseID 删除知识库下所有文档（软删除，移入回收站）
+func (r *DocumentRepository) DeleteByKnowledgeBaseID(ctx context.Context, kbID valueobject.KnowledgeBaseID) error {
+	return repositoryExec(ctx, documentRepository, "DeleteByKnowledgeBaseID", idAttributes(kbID, ""), func(ctx context.Context) error {
+		return r.next.DeleteByKnowledgeBaseID(ctx, kbID)
+	})
+}
+
+// SearchByTagQuery 根据标签查询表达式搜索文档
+// kbID 为空时搜索所有知识库；别名应由调用方先解析为规范名称
+func (r *DocumentRepository) SearchByTagQuery(ctx context.Context, kbID valueobject.KnowledgeBaseID, expr valueobject.TagExpr) ([]*entity.Document, error) {
+	return repositoryCall(ctx, documentRepository, "SearchByTagQuery", idAttributes(kbID, ""), func(ctx context.Context) ([]*entity.Document, error) {
+		return r.next.SearchByTagQuery(ctx, kbID, expr)
+	})
+}
+
+// FindDeleted 查找回收站中的文档
+// kbID 为空时返回所有知识库的已删除文档
+func (r *DocumentRepository) FindDeleted(ctx context.Context, kbID valueobject.KnowledgeBaseID) ([]*entity.Document, error) {
+	return repositoryCall(ctx, documentRepository, "FindDeleted", idAttributes(kbID, ""), func(ctx context.Context) ([]*entity.Document, error) {
+		return r.next.FindDeleted(ctx, kbID)
+	})
+}
+
+// FindDeletedByID 根据ID查找回收站中的文档
+func (r *DocumentRepository) FindDeletedByID(ctx context.Context, id valueobject.DocumentID) (*entity.Document, error) {
+	return repositoryCall(ctx, documentRepository, "FindDeletedByID", idAttributes("", id), func(ctx context.Context) (*entity.Document, error) {
+		return r.next.FindDeletedByID(ctx, id)
+	})
+}
+
+// CountDeleted 统计知识库在回收站中的文档数量
+func (r *DocumentRepository) CountDeleted(ctx context.Context, kbID valueobject.KnowledgeBaseID) (int, error) {
+	return repositoryCall(ctx, documentRepository, "CountDeleted", idAttributes(kbID, ""), func(ctx context.Context) (int, error) {
+		return r.next.CountDeleted(ctx, kbID)
+	})
+}
+
+// FindDeletedBefore 查找在指定时间之前移入回收站的文档
+func (r *DocumentRepository) FindDeletedBefore(ctx context.Context, before time.Time) ([]*entity.Document, error) {
+	return repositoryCall(ctx, documentRepository, "FindDeletedBefore", nil, func(ctx context.Context) ([]*entity.Document, error) {
+		return r.next.FindDeletedBefore(ctx, before)
+	})
+}
+
+// Restore 从回收站恢复文档
+func (r *DocumentRepository) Restore(ctx context.Context, id valueobject.DocumentID) error {
+	return repositoryExec(ctx, documentRepository, "Restore", idAttributes("", id), func(ctx context.Context) error {
+		return r.next.Restore(ctx, id)
+	})
+}
+
+// RestoreByKnowledgeBaseID 恢复随知识库一起移入回收站的文档
+// 用于恢复知识库时使用，之前单独删除的文档仍保留在回收站中
+func (r *DocumentRepository) RestoreByKnowledgeBaseID(ctx context.Context, kbID valueobject.KnowledgeBaseID) error {
+	return repositoryExec(ctx, documentRepository, "RestoreByKnowledgeBaseID", idAttributes(kbID, ""), func(ctx context.Context) error {
+		return r.next.RestoreByKnowledgeBaseID(ctx, kbID)
+	})
+}
+
+// Purge 彻底删除文档（物理删除，不可恢复）
+func (r *DocumentRepository) Purge(ctx context.Context, id valueobject.DocumentID) error {
+	return repositoryExec(ctx, documentRepository, "Purge", idAttributes("", id), func(ctx context.Context) error {
+		return r.next.Purge(ctx, id)
+	})
+}
+
+// PurgeByKnowledgeBaseID 彻底删除知识库下所有文档（包括回收站中的文档）
+func (r *DocumentRepository) PurgeByKnowledgeBaseID(ctx context.Context, kbID valueobject.KnowledgeBaseID) error {
+	return repositoryExec(ctx, documentRepository, "PurgeByKnowledgeBaseID", idAttributes(kbID, ""), func(ctx context.Context) error {
+		return r.next.PurgeByKnowledgeBaseID(ctx, kbID)
+	})
+}
+
+// CountByStatus 按状态统计文档数量（不包括回收站中的）
+func (r *DocumentRepository) CountByStatus(ctx context.Context) (map[valueobject.DocumentStatus]int, error) {
+	return repositoryCall(ctx, documentRepository, "CountByStatus", nil, r.next.CountByStatus)
+}
+
+// FolderRepository 带链路追踪的文件夹仓储
+type FolderRepository struct {
+	next repository.FolderRepository
+}
+
+// NewFolderRepository 创建带链路追踪的文件夹仓储
+func NewFolderRepository(next repository.FolderRepository) *FolderRepository {
+	return &FolderRepository{next: next}
+}
+
+// 确保实现了接口
+var _ repository.FolderRepository = (*FolderRepository)(nil)
+
+const folderRepository = "FolderRepository"
+
+// Save 保存文件夹（创建或更新）
+func (r *FolderRepository) Save(ctx context.Context, folder *entity.Folder) error {
+	return repositoryExec(ctx, folderRepository, "Save", idAttributes(folder.KnowledgeBaseID(), ""), func(ctx context.Context) error {
+		return r.next.Save(ctx, folder)
+	})
+}
+
+// FindByKnowledgeBaseID 查找知识库下的所有文件夹
+func (r *FolderRepository) FindByKnowledgeBaseID(ctx context.Context, kbID valueobject.KnowledgeBaseID) ([]*entity.Folder, error) {
+	return repositoryCall(ctx, folderRepository, "FindByKnowledgeBaseID", idAttributes(kbID, ""), func(ctx context.Context) ([]*entity.Folder, error) {
+		return r.next.FindByKnowledgeBaseID(ctx, kbID)
+	})
+}
+
+// Delete 删除文件夹（物理删除）
+func (r *FolderRepository) Delete(ctx context.Context, id valueobject.FolderID) error {
+	return repositoryExec(ctx, folderRepository, "Delete", nil, func(ctx context.Context) error {
+		return r.next.Delete(ctx, id)
+	})
+}
+
+// DeleteByKnowledgeBaseID 删除知识库下的所有文件夹（物理删除）
+func (r *FolderRepository) DeleteByKnowledgeBaseID(ctx context.Context, kbID valueobject.KnowledgeBaseID) error {
+	return repositoryExec(ctx, folderRepository, "DeleteByKnowledgeBaseID", idAttributes(kbID, ""), func(ctx context.Context) error {
+		return r.next.DeleteByKnowledgeBaseID(ctx, kbID)
+	})
+}
+
+// TagRepository 带链路追踪的标签定义仓储
+type TagRepository struct {
+	next repository.TagRepository
+}
+
+// NewTagRepository 创建带链路追踪的标签定义仓储
+func NewTagRepository(next repository.TagRepository) *TagRepository {
+	return &TagRepository{next: next}
+}
+
+// 确保实现了接口
+var _ repository.TagRepository = (*TagRepository)(nil)
+
+const tagRepository = "TagRepository"
+
+// ReplaceAll 用给定的标签定义整体替换知识库的标签注册表
+// 重命名、合并等操作会同时修改和删除多个定义，整体替换可以保证注册表与聚合一致
+func (r *TagRepository) ReplaceAll(ctx context.Context, kbID valueobject.KnowledgeBaseID, tags []*entity.TagDefinition) error {
+	return repositoryExec(ctx, tagRepository, "ReplaceAll", idAttributes(kbID, ""), func(ctx context.Context) error {
+		return r.next.ReplaceAll(ctx, kbID, tags)
+	})
+}
+
+// FindByKnowledgeBaseID 查找知识库下的所有标签定义
+func (r *TagRepository) FindByKnowledgeBaseID(ctx context.Context, kbID valueobject.KnowledgeBaseID) ([]*entity.TagDefinition, error) {
+	return repositoryCall(ctx, tagRepository, "FindByKnowledgeBaseID", idAttributes(kbID, ""), func(ctx context.Context) ([]*entity.TagDefinition, error) {
+		return r.next.FindByKnowledgeBaseID(ctx, kbID)
+	})
+}
+
+// DeleteByKnowledgeBaseID 删除知识库下的所有标签定义（物理删除）
+func (r *TagRepository) DeleteByKnowledgeBaseID(ctx context.Context, kbID valueobject.KnowledgeBaseID) error {
+	return repositoryExec(ctx, tagRepository, "DeleteByKnowledgeBaseID", idAttributes(kbID, ""), func(ctx context.Context) error {
+		return r.next.DeleteByKnowledgeBaseID(ctx, kbID)
+	})
+}
+
+// AttachmentRepository 带链路追踪的附件仓储
+type AttachmentRepository struct {
+	next repository.AttachmentRepository
+}
+
+// NewAttachmentRepository 创建带链路追踪的附件仓储
+func NewAttachmentRepository(next repository.AttachmentRepository) *AttachmentRepository {
+	return &AttachmentRepository{next: next}
+}
+
+// 确保实现了接口
+var _ repository.AttachmentRepository = (*AttachmentRepository)(nil)
+
+const attachmentRepository = "AttachmentRepository"
+
+// Save 保存附件（创建或更新）
+func (r *AttachmentRepository) Save(ctx context.Context, att *entity.Attachment) error {
+	return repositoryExec(ctx, attachmentRepository, "Save", idAttributes(att.KnowledgeBaseID(), att.DocumentID()), func(ctx context.Context) error {
+		return r.next.Save(ctx, att)
+	})
+}
+
+// Delete 删除附件（物理删除）
+func (r *AttachmentRepository) Delete(ctx context.Context, id valueobject.AttachmentID) error {
+	return repositoryExec(ctx, attachmentRepository, "Delete", nil, func(ctx context.Context) error {
+		return r.next.Delete(ctx, id)
+	})
+}
+
+// FindByKnowledgeBaseID 查找知识库下的所有附件
+func (r *AttachmentRepository) FindByKnowledgeBaseID(ctx context.Context, kbID valueobject.KnowledgeBaseID) ([]*entity.Attachment, error) {
+	return repositoryCall(ctx, attachmentRepository, "FindByKnowledgeBaseID", idAttributes(kbID, ""), func(ctx context.Context) ([]*entity.Attachment, error) {
+		return r.next.FindByKnowledgeBaseID(ctx, kbID)
+	})
+}
+
+// FindByDocumentID 查找文档的所有附件
+func (r *AttachmentRepository) FindByDocumentID(ctx context.Context, docID valueobject.DocumentID) ([]*entity.Attachment, error) {
+	return repositoryCall(ctx, attachmentRepository, "FindByDocumentID", idAttributes("", docID), func(ctx context.Context) ([]*entity.Attachment, error) {
+		return r.next.FindByDocumentID(ctx, docID)
+	})
+}
+
+// DeleteByDocumentID 删除文档的所有附件（物理删除）
+func (r *AttachmentRepository) DeleteByDocumentID(ctx context.Context, docID valueobject.DocumentID) error {
+	return repositoryExec(ctx, attachmentRepository, "DeleteByDocumentID", idAttributes("", docID), func(ctx context.Context) error {
+		return r.next.DeleteByDocumentID(ctx, docID)
+	})
+}
+
+// DeleteByKnowledgeBaseID 删除知识库下的所有附件（物理删除）
+func (r *AttachmentRepository) DeleteByKnowledgeBaseID(ctx context.Context, kbID valueobject.KnowledgeBaseID) error {
+	return repositoryExec(ctx, attachmentRepository, "DeleteByKnowledgeBaseID", idAttributes(kbID, ""), func(ctx context.Context) error {
+		return r.next.DeleteByKnowledgeBaseID(ctx, kbID)
+	})
+}
+
+// ExistsByContentHash 检查是否仍有附件引用指定内容
+// 用于判断 BlobStore 中的内容能否被清理
+func (r *AttachmentRepository) ExistsByContentHash(ctx context.Context, hash valueobject.ContentHash) (bool, error) {
+	return repositoryCall(ctx, attachmentRepository, "ExistsByContentHash", nil, func(ctx context.Context) (bool, error) {
+		return r.next.ExistsByContentHash(ctx, hash)
+	})
+}
+
+// DocumentLinkRepository 带链路追踪的文档链接仓储
+type DocumentLinkRepository struct {
+	next repository.DocumentLinkRepository
+}
+
+// NewDocumentLinkRepository 创建带链路追踪的文档链接仓储
+func NewDocumentLinkRepository(next repository.DocumentLinkRepository) *DocumentLinkRepository {
+	return &DocumentLinkRepository{next: next}
+}
+
+// 确保实现了接口
+var _ repository.DocumentLinkRepository = (*DocumentLinkRepository)(nil)
+
+const documentLinkRepository = "DocumentLinkRepository"
+
+// ReplaceLinks 替换源文档的全部出链
+func (r *DocumentLinkRepository) ReplaceLinks(ctx context.Context, sourceDocID valueobject.DocumentID, sourceKBID valueobject.KnowledgeBaseID, links []valueobject.DocumentLink) error {
+	return repositoryExec(ctx, documentLinkRepository, "ReplaceLinks", idAttributes(sourceKBID, sourceDocID), func(ctx context.Context) error {
+		return r.next.ReplaceLinks(ctx, sourceDocID, sourceKBID, links)
+	})
+}
+
+// FindBySource 查找源文档的全部出链
+func (r *DocumentLinkRepository) FindBySource(ctx context.Context, sourceDocID valueobject.DocumentID) ([]valueobject.DocumentLink, error) {
+	return repositoryCall(ctx, documentLinkRepository, "FindBySource", idAttributes("", sourceDocID), func(ctx context.Context) ([]valueobject.DocumentLink, error) {
+		return r.next.FindBySource(ctx, sourceDocID)
+	})
+}
+
+// FindBacklinks 查找引用目标文档的链接
+// Wiki 链接按标题在目标文档所属知识库内匹配，路径链接按文档ID匹配
+func (r *DocumentLinkRepository) FindBacklinks(ctx context.Context, kbID valueobject.KnowledgeBaseID, docID valueobject.DocumentID, title string) ([]valueobject.DocumentLinkEdge, error) {
+	return repositoryCall(ctx, documentLinkRepository, "FindBacklinks", idAttributes(kbID, docID), func(ctx context.Context) ([]valueobject.DocumentLinkEdge, error) {
+		return r.next.FindBacklinks(ctx, kbID, docID, title)
+	})
+}
+
+// FindByKnowledgeBaseID 查找知识库内所有文档的出链
+func (r *DocumentLinkRepository) FindByKnowledgeBaseID(ctx context.Context, kbID valueobject.KnowledgeBaseID) ([]valueobject.DocumentLinkEdge, error) {
+	return repositoryCall(ctx, documentLinkRepository, "FindByKnowledgeBaseID", idAttributes(kbID, ""), func(ctx context.Context) ([]valueobject.DocumentLinkEdge, error) {
+		return r.next.FindByKnowledgeBaseID(ctx, kbID)
+	})
+}
+
+// DocumentFingerprintRepository 带链路追踪的文档指纹仓储
+type DocumentFingerprintRepository struct {
+	next repository.DocumentFingerprintRepository
+}
+
+// NewDocumentFingerprintRepository 创建带链路追踪的文档指纹仓储
+func NewDocumentFingerprintRepository(next repository.DocumentFingerprintRepository) *DocumentFingerprintRepository {
+	return &DocumentFingerprintRepository{next: next}
+}
+
+// 确保实现了接口
+var _ repository.DocumentFingerprintRepository = (*DocumentFingerprintRepository)(nil)
+
+const documentFingerprintRepository = "DocumentFingerprintRepository"
+
+// Save 保存文档的内容指纹（已存在时覆盖）
+func (r *DocumentFingerprintRepository) Save(ctx context.Context, docID valueobject.DocumentID, kbID valueobject.KnowledgeBaseID, fp valueobject.ContentFingerprint) error {
+	return repositoryExec(ctx, documentFingerprintRepository, "Save", idAttributes(kbID, docID), func(ctx context.Context) error {
+		return r.next.Save(ctx, docID, kbID, fp)
+	})
+}
+
+// Delete 删除文档的内容指纹
+func (r *DocumentFingerprintRepository) Delete(ctx context.Context, docID valueobject.DocumentID) error {
+	return repositoryExec(ctx, documentFingerprintRepository, "Delete", idAttributes("", docID), func(ctx context.Context) error {
+		return r.next.Delete(ctx, docID)
+	})
+}
+
+// DeleteByKnowledgeBaseID 删除知识库下所有文档的内容指纹
+func (r *DocumentFingerprintRepository) DeleteByKnowledgeBaseID(ctx context.Context, kbID valueobject.KnowledgeBaseID) error {
+	return repositoryExec(ctx, documentFingerprintRepository, "DeleteByKnowledgeBaseID", idAttributes(kbID, ""), func(ctx context.Context) error {
+		return r.next.DeleteByKnowledgeBaseID(ctx, kbID)
+	})
+}
+
+// Find 查找文档的内容指纹
+// kbID 为空时返回所有知识库的文档
+func (r *DocumentFingerprintRepository) Find(ctx context.Context, kbID valueobject.KnowledgeBaseID) ([]valueobject.DocumentFingerprint, error) {
+	return repositoryCall(ctx, documentFingerprintRepository, "Find", idAttributes(kbID, ""), func(ctx context.Context) ([]valueobject.DocumentFingerprint, error) {
+		return r.next.Find(ctx, kbID)
+	})
+}
+
+// KnowledgeBaseMemberRepository 带链路追踪的知识库成员仓储
+type KnowledgeBaseMemberRepository struct {
+	next repository.KnowledgeBaseMemberRepository
+}
+
+// NewKnowledgeBaseMemberRepository 创建带链路追踪的知识库成员仓储
+func NewKnowledgeBaseMemberRepository(next repository.KnowledgeBaseMemberRepository) *KnowledgeBaseMemberRepository {
+	return &KnowledgeBaseMemberRepository{next: next}
+}
+
+// 确保实现了接口
+var _ repository.KnowledgeBaseMemberRepository = (*KnowledgeBaseMemberRepository)(nil)
+
+const knowledgeBaseMemberRepository = "KnowledgeBaseMemberRepository"
+
+// Save 保存成员（已存在时覆盖角色）
+func (r *KnowledgeBaseMemberRepository) Save(ctx context.Context, member *repository.KnowledgeBaseMember) error {
+	return repositoryExec(ctx, knowledgeBaseMemberRepository, "Save", idAttributes(member.KnowledgeBaseID, ""), func(ctx context.Context) error {
+		return r.next.Save(ctx, member)
+	})
+}
+
+// Delete 删除成员
+func (r *KnowledgeBaseMemberRepository) Delete(ctx context.Context, kbID valueobject.KnowledgeBaseID, subject string) error {
+	return repositoryExec(ctx, knowledgeBaseMemberRepository, "Delete", idAttributes(kbID, ""), func(ctx context.Context) error {
+		return r.next.Delete(ctx, kbID, subject)
+	})
+}
+
+// DeleteByKnowledgeBaseID 删除知识库的所有成员
+func (r *KnowledgeBaseMemberRepository) DeleteByKnowledgeBaseID(ctx context.Context, kbID valueobject.KnowledgeBaseID) error {
+	return repositoryExec(ctx, knowledgeBaseMemberRepository, "DeleteByKnowledgeBaseID", idAttributes(kbID, ""), func(ctx context.Context) error {
+		return r.next.DeleteByKnowledgeBaseID(ctx, kbID)
+	})
+}
+
+// Find 查找调用方在知识库中的成员记录，不存在时返回 nil
+func (r *KnowledgeBaseMemberRepository) Find(ctx context.Context, kbID valueobject.KnowledgeBaseID, subject string) (*repository.KnowledgeBaseMember, error) {
+	return repositoryCall(ctx, knowledgeBaseMemberRepository, "Find", idAttributes(kbID, ""), func(ctx context.Context) (*repository.KnowledgeBaseMember, error) {
+		return r.next.Find(ctx, kbID, subject)
+	})
+}
+
+// FindByKnowledgeBaseID 查找知识库的所有成员
+func (r *KnowledgeBaseMemberRepository) FindByKnowledgeBaseID(ctx context.Context, kbID valueobject.KnowledgeBaseID) ([]*repository.KnowledgeBaseMember, error) {
+	return repositoryCall(ctx, knowledgeBaseMemberRepository, "FindByKnowledgeBaseID", idAttributes(kbID, ""), func(ctx context.Context) ([]*repository.KnowledgeBaseMember, error) {
+		return r.next.FindByKnowledgeBaseID(ctx, kbID)
+	})
+}
+
+// FindByKnowledgeBaseIDForUpdate 查找知识库的所有成员并锁定，直到所在事务结束
+func (r *KnowledgeBaseMemberRepository) FindByKnowledgeBaseIDForUpdate(ctx context.Context, kbID valueobject.KnowledgeBaseID) ([]*repository.KnowledgeBaseMember, error) {
+	return repositoryCall(ctx, knowledgeBaseMemberRepository, "FindByKnowledgeBaseIDForUpdate", idAttributes(kbID, ""), func(ctx context.Context) ([]*repository.KnowledgeBaseMember, error) {
+		return r.next.FindByKnowledgeBaseIDForUpdate(ctx, kbID)
+	})
+}
+
+// FindBySubject 查找调用方加入的所有知识库成员记录
+func (r *KnowledgeBaseMemberRepository) FindBySubject(ctx context.Context, subject string) ([]*repository.KnowledgeBaseMember, error) {
+	return repositoryCall(ctx, knowledgeBaseMemberRepository, "FindBySubject", nil, func(ctx context.Context) ([]*repository.KnowledgeBaseMember, error) {
+		return r.next.FindBySubject(ctx, subject)
+	})
+}
+
+// APIKeyRepository 带链路追踪的 API 密钥仓储
+type APIKeyRepository struct {
+	next repository.APIKeyRepository
+}
+
+// NewAPIKeyRepository 创建带链路追踪的 API 密钥仓储
+func NewAPIKeyRepository(next repository.APIKeyRepository) *APIKeyRepository {
+	return &APIKeyRepository{next: next}
+}
+
+// 确保实现了接口
+var _ repository.APIKeyRepository = (*APIKeyRepository)(nil)
+
+const apiKeyRepository = "APIKeyRepository"
+
+// Save 保存 API Key（新增或更新）
+func (r *APIKeyRepository) Save(ctx context.Context, key *repository.APIKey) error {
+	return repositoryExec(ctx, apiKeyRepository, "Save", nil, func(ctx context.Context) error {
+		return r.next.Save(ctx, key)
+	})
+}
+
+// FindByID 根据 ID 查找，不存在时返回 nil
+func (r *APIKeyRepository) FindByID(ctx context.Context, id string) (*repository.APIKey, error) {
+	return repositoryCall(ctx, apiKeyRepository, "FindByID", nil, func(ctx context.Context) (*repository.APIKey, error) {
+		return r.next.FindByID(ctx, id)
+	})
+}
+
+// FindByHash 根据密钥哈希查找，不存在时返回 nil
+// 认证发生在确定租户之前，因此不按租户过滤
+func (r *APIKeyRepository) FindByHash(ctx context.Context, hash string) (*repository.APIKey, error) {
+	return repositoryCall(ctx, apiKeyRepository, "FindByHash", nil, func(ctx context.Context) (*repository.APIKey, error) {
+		return r.next.FindByHash(ctx, hash)
+	})
+}
+
+// FindAll 查找当前租户的所有 API Key（包括已吊销和已过期的）
+func (r *APIKeyRepository) FindAll(ctx context.Context) ([]*repository.APIKey, error) {
+	return repositoryCall(ctx, apiKeyRepository, "FindAll", nil, r.next.FindAll)
+}
+
+// UpdateLastUsed 更新最近使用时间
+func (r *APIKeyRepository) UpdateLastUsed(ctx context.Context, id string, at time.Time) error {
+	return repositoryExec(ctx, apiKeyRepository, "UpdateLastUsed", nil, func(ctx context.Context) error {
+		return r.next.UpdateLastUsed(ctx, id, at)
+	})
+}
+
+// TenantUsageRepository 带链路追踪的租户资源用量仓储
+type TenantUsageRepository struct {
+	next repository.TenantUsageRepository
+}
+
+// NewTenantUsageRepository 创建带链路追踪的租户资源用量仓储
+func NewTenantUsageRepository(next repository.TenantUsageRepository) *TenantUsageRepository {
+	return &TenantUsageRepository{next: next}
+}
+
+// 确保实现了接口
+var _ repository.TenantUsageRepository = (*TenantUsageRepository)(nil)
+
+const tenantUsageRepository = "TenantUsageRepository"
+
+// Find 查询当前租户的用量
+func (r *TenantUsageRepository) Find(ctx context.Context) (valueobject.QuotaUsage, error) {
+	return repositoryCall(ctx, tenantUsageRepository, "Find", nil, r.next.Find)
+}
+
+// FindForUpdate 查询当前租户的用量并锁定，直到所在事务结束
+// 需要在事务中调用，同一租户并发的配额校验因此串行执行
+func (r *TenantUsageRepository) FindForUpdate(ctx context.Context) (valueobject.QuotaUsage, error) {
+	return repositoryCall(ctx, tenantUsageRepository, "FindForUpdate", nil, r.next.FindForUpdate)
+}
+
+// Add 按增减量更新当前租户的用量
+func (r *TenantUsageRepository) Add(ctx context.Context, delta valueobject.QuotaUsage) error {
+	return repositoryExec(ctx, tenantUsageRepository, "Add", nil, func(ctx context.Context) error {
+		return r.next.Add(ctx, delta)
+	})
+}
+
+// AuditLogRepository 带链路追踪的审计日志仓储
+type AuditLogRepository struct {
+	next repository.AuditLogRepository
+}
+
+// NewAuditLogRepository 创建带链路追踪的审计日志仓储
+func NewAuditLogRepository(next repository.AuditLogRepository) *AuditLogRepository {
+	return &AuditLogRepository{next: next}
+}
+
+// 确保实现了接口
+var _ repository.AuditLogRepository = (*AuditLogRepository)(nil)
+
+const auditLogRepository = "AuditLogRepository"
+
+// Append 追加一条审计日志，事件ID已存在时忽略
+func (r *AuditLogRepository) Append(ctx context.Context, entry *repository.AuditLogEntry) error {
+	return repositoryExec(ctx, auditLogRepository, "Append", KnowledgeBaseID(entry.KnowledgeBaseID), func(ctx context.Context) error {
+		return r.next.Append(ctx, entry)
+	})
+}
+
+// Find 按条件查询审计日志，按写入顺序倒序返回
+func (r *AuditLogRepository) Find(ctx context.Context, filter repository.AuditLogFilter) ([]*repository.AuditLogEntry, error) {
+	return repositoryCall(ctx, auditLogRepository, "Find", KnowledgeBaseID(filter.KnowledgeBaseID), func(ctx context.Context) ([]*repository.AuditLogEntry, error) {
+		return r.next.Find(ctx, filter)
+	})
+}
+
+// IdempotencyRepository 带链路追踪的幂等键仓储
+type IdempotencyRepository struct {
+	next repository.IdempotencyRepository
+}
+
+// NewIdempotencyRepository 创建带链路追踪的幂等键仓储
+func NewIdempotencyRepository(next repository.IdempotencyRepository) *IdempotencyRepository {
+	return &IdempotencyRepository{next: next}
+}
+
+// 确保实现了接口
+var _ repository.IdempotencyRepository = (*IdempotencyRepository)(nil)
+
+const idempotencyRepository = "IdempotencyRepository"
+
+// Reserve 占用 record 作用范围内的幂等键
+// 键不存在或已过期时写入 record 并返回 nil；否则返回已有的记录，不做修改
+func (r *IdempotencyRepository) Reserve(ctx context.Context, record *repository.IdempotencyRecord) (*repository.IdempotencyRecord, error) {
+	return repositoryCall(ctx, idempotencyRepository, "Reserve", nil, func(ctx context.Context) (*repository.IdempotencyRecord, error) {
+		return r.next.Reserve(ctx, record)
+	})
+}
+
+// Complete 保存请求的处理结果
+func (r *IdempotencyRepository) Complete(ctx context.Context, scope repository.IdempotencyScope, statusCode int, contentType string, body []byte) error {
+	return repositoryExec(ctx, idempotencyRepository, "Complete", nil, func(ctx context.Context) error {
+		return r.next.Complete(ctx, scope, statusCode, contentType, body)
+	})
+}
+
+// Release 删除未完成的记录，使客户端可以用相同的键重试
+func (r *IdempotencyRepository) Release(ctx context.Context, scope repository.IdempotencyScope) error {
+	return repositoryExec(ctx, idempotencyRepository, "Release", nil, func(ctx context.Context) error {
+		return r.next.Release(ctx, scope)
+	})
+}
+
+// DeleteExpired 删除在 before 之前过期的记录，返回删除数量
+func (r *IdempotencyRepository) DeleteExpired(ctx context.Context, before time.Time) (int64, error) {
+	return repositoryCall(ctx, idempotencyRepository, "DeleteExpired", nil, func(ctx context.Context) (int64, error) {
+		return r.next.DeleteExpired(ctx, before)
+	})
+}
+
+// repositoryCall 在 span 中调用仓储方法
+func repositoryCall[T any](ctx context.Context, repo, method string, kv []attribute.KeyValue, call func(ctx context.Context) (T, error)) (res T, err error) {
+	kv = append(kv, RepositoryKey.String(repo), RepositoryMethodKey.String(method))
+	ctx, span := Start(ctx, "repository "+repo+"."+method, trace.WithAttributes(kv...))
+	defer func() { End(span, err) }()
+
+	return call(ctx)
+}
+
+// repositoryExec 在 span 中调用只返回错误的仓储方法
+func repositoryExec(ctx context.Context, repo, method string, kv []attribute.KeyValue, call func(ctx context.Context) error) error {
+	_, err := repositoryCall(ctx, repo, method, kv, func(ctx context.Context) (struct{}, error) {
+		return struct{}{}, call(ctx)
+	})
+	return err
+}
+
+// idAttributes 返回知识库ID和文档ID属性，ID 为空时不返回
+func idAttributes(kbID valueobject.KnowledgeBaseID, docID valueobject.DocumentID) []attribute.KeyValue {
+	kv := KnowledgeBaseID(kbID.String())
+	if docID != "" {
+		kv = append(kv, DocumentIDKey.String(docID.String()))
+	}
+	return kv
+}
@@ -0,0 +1,73 @@
+package tracing
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// instrumentationName 本项目创建的 span 使用的 tracer 名称
+const instrumentationName = "gozero-ddd"
+
+// span 属性键
+const (
+	KnowledgeBaseIDKey  = attribute.Key("knowledge_base.id")
+	DocumentIDKey       = attribute.Key("document.id")
+	EventNameKey        = attribute.Key("event.name")
+	EventCountKey       = attribute.Key("event.count")
+	EventHandlerKey     = attribute.Key("event.handler")
+	RepositoryKey       = attribute.Key("repository.name")
+	RepositoryMethodKey = attribute.Key("repository.method")
+)
+
+// traceparentKey W3C Trace Context 在载体中的键
+const traceparentKey = "traceparent"
+
+// Tracer 返回本项目使用的 tracer
+// TracerProvider 由 go-zero 按配置文件的 Telemetry 节初始化（导出到 stdout 文件或 OTLP 采集器），
+// 未初始化时为空实现，span 不会被记录
+func Tracer() trace.Tracer {
+	return otel.Tracer(instrumentationName)
+}
+
+// Start 开始一个 span
+func Start(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	return Tracer().Start(ctx, name, opts...)
+}
+
+// End 结束 span，err 不为 nil 时记录错误并将状态置为 Error
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// Traceparent 返回上下文中当前 span 的 W3C traceparent，没有有效 span 时返回空字符串
+func Traceparent(ctx context.Context) string {
+	carrier := propagation.MapCarrier{}
+	otel.GetTextMapPropagator().Inject(ctx, carrier)
+	return carrier.Get(traceparentKey)
+}
+
+// ContextWithTraceparent 将上游传来的 W3C traceparent 作为远程父 span 放入上下文
+// 之后在该上下文中开始的 span 与上游处于同一条链路
+func ContextWithTraceparent(ctx context.Context, traceparent string) context.Context {
+	if traceparent == "" {
+		return ctx
+	}
+	return otel.GetTextMapPropagator().Extract(ctx, propagation.MapCarrier{traceparentKey: traceparent})
+}
+
+// KnowledgeBaseID 返回知识库ID属性，ID 为空时不返回
+func KnowledgeBaseID(id string) []attribute.KeyValue {
+	if id == "" {
+		return nil
+	}
+	return []attribute.KeyValue{KnowledgeBaseIDKey.String(id)}
+}
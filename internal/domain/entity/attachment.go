@@ -0,0 +1,157 @@
+package entity
+
+import (
+	"path"
+	"strings"
+	"time"
+	"unicode"
+
+	"gozero-ddd/internal/domain"
+	"gozero-ddd/internal/domain/valueobject"
+)
+
+// MaxAttachmentNameLength 附件文件名的最大长度（字符数）
+const MaxAttachmentNameLength = 255
+
+// 允许上传的附件类型：图片和 PDF
+// SVG 可以内嵌脚本，不在允许范围内
+var allowedAttachmentTypes = map[string]bool{
+	"image/png":       true,
+	"image/jpeg":      true,
+	"image/gif":       true,
+	"image/webp":      true,
+	"image/bmp":       true,
+	"application/pdf": true,
+}
+
+// Attachment 附件实体
+// Attachment 属于 KnowledgeBase 聚合，不是聚合根
+// 附件挂在某篇文档下，二进制内容按内容哈希存放在 BlobStore 中，相同内容只存一份
+type Attachment struct {
+	id              valueobject.AttachmentID    // 唯一标识
+	knowledgeBaseID valueobject.KnowledgeBaseID // 所属知识库ID
+	documentID      valueobject.DocumentID      // 所属文档ID
+	fileName        string                      // 文件名
+	mediaType       string                      // 媒体类型（MIME）
+	size            int64                       // 大小（字节）
+	contentHash     valueobject.ContentHash     // 内容哈希，同时作为 BlobStore 中的键
+	createdAt       time.Time                   // 上传时间
+}
+
+// NewAttachment 创建新的附件
+// 文件名会去掉路径部分和控制字符
+func NewAttachment(
+	kbID valueobject.KnowledgeBaseID,
+	docID valueobject.DocumentID,
+	fileName, mediaType string,
+	size int64,
+	contentHash valueobject.ContentHash,
+) (*Attachment, error) {
+	name := cleanAttachmentName(fileName)
+	if name == "" {
+		return nil, domain.ErrAttachmentNameEmpty
+	}
+	if size <= 0 {
+		return nil, domain.ErrAttachmentEmpty
+	}
+	if !IsAllowedAttachmentType(mediaType) {
+		return nil, domain.ErrUnsupportedAttachmentType
+	}
+
+	return &Attachment{
+		id:              valueobject.NewAttachmentID(),
+		knowledgeBaseID: kbID,
+		documentID:      docID,
+		fileName:        name,
+		mediaType:       mediaType,
+		size:            size,
+		contentHash:     contentHash,
+		createdAt:       time.Now(),
+	}, nil
+}
+
+// ReconstructAttachment 从持久化数据重建附件实体
+func ReconstructAttachment(
+	id valueobject.AttachmentID,
+	kbID valueobject.KnowledgeBaseID,
+	docID valueobject.DocumentID,
+	fileName, mediaType string,
+	size int64,
+	contentHash valueobject.ContentHash,
+	createdAt time.Time,
+) *Attachment {
+	return &Attachment{
+		id:              id,
+		knowledgeBaseID: kbID,
+		documentID:      docID,
+		fileName:        fileName,
+		mediaType:       mediaType,
+		size:            size,
+		contentHash:     contentHash,
+		createdAt:       createdAt,
+	}
+}
+
+// IsAllowedAttachmentType 判断媒体类型是否允许作为附件上传
+func IsAllowedAttachmentType(mediaType string) bool {
+	return allowedAttachmentTypes[mediaType]
+}
+
+// ID 获取附件ID
+func (a *Attachment) ID() valueobject.AttachmentID {
+	return a.id
+}
+
+// KnowledgeBaseID 获取所属知识库ID
+func (a *Attachment) KnowledgeBaseID() valueobject.KnowledgeBaseID {
+	return a.knowledgeBaseID
+}
+
+// DocumentID 获取所属文档ID
+func (a *Attachment) DocumentID() valueobject.DocumentID {
+	return a.documentID
+}
+
+// FileName 获取文件名
+func (a *Attachment) FileName() string {
+	return a.fileName
+}
+
+// MediaType 获取媒体类型
+func (a *Attachment) MediaType() string {
+	return a.mediaType
+}
+
+// Size 获取大小（字节）
+func (a *Attachment) Size() int64 {
+	return a.size
+}
+
+// ContentHash 获取内容哈希
+func (a *Attachment) ContentHash() valueobject.ContentHash {
+	return a.contentHash
+}
+
+// CreatedAt 获取上传时间
+func (a *Attachment) CreatedAt() time.Time {
+	return a.createdAt
+}
+
+// cleanAttachmentName 规范化文件名：去掉路径部分和控制字符，并限制长度
+func cleanAttachmentName(name string) string {
+	name = strings.ReplaceAll(name, "\\", "/")
+	name = path.Base(strings.TrimSpace(name))
+	if name == "." || name == "/" {
+		return ""
+	}
+	name = strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) {
+			return -1
+		}
+		return r
+	}, name)
+	if runes := []rune(name); len(runes) > MaxAttachmentNameLength {
+		name = string(runes[:MaxAttachmentNameLength])
+	}
+	return strings.TrimSpace(name)
+}
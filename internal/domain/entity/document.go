@@ -13,21 +13,39 @@ import (
 type Document struct {
 	id              valueobject.DocumentID      // 唯一标识
 	knowledgeBaseID valueobject.KnowledgeBaseID // 所属知识库ID
+	folderID        *valueobject.FolderID       // 所在文件夹ID（nil 表示根目录）
 	title           string                      // 文档标题
 	content         string                      // 文档内容
+	contentType     valueobject.ContentType     // 内容类型
 	tags            []string                    // 标签
+	status          valueobject.DocumentStatus  // 发布状态
+	reviewComments  []valueobject.ReviewComment // 评审意见记录
+	publishAt       *time.Time                  // 定时发布时间（nil 表示不定时发布）
+	expireAt        *time.Time                  // 定时下线时间（nil 表示永不过期）
 	createdAt       time.Time                   // 创建时间
 	updatedAt       time.Time                   // 更新时间
+	deletedAt       *time.Time                  // 移入回收站时间（nil 表示未删除）
+
+	// 规范化内容的哈希缓存，由 NormalizedContentHash 首次调用时计算，内容修改后清空
+	normalizedHash valueobject.ContentHash
 }
 
 // NewDocument 创建新文档
-func NewDocument(kbID valueobject.KnowledgeBaseID, title, content string, tags []string) (*Document, error) {
+// 新文档处于草稿状态，需要经过审核和发布后才对 API 消费方可见
+// contentType 为空时使用默认的 Markdown
+func NewDocument(kbID valueobject.KnowledgeBaseID, title, content string, contentType valueobject.ContentType, tags []string) (*Document, error) {
 	if title == "" {
 		return nil, domain.ErrDocumentTitleEmpty
 	}
 	if content == "" {
 		return nil, domain.ErrDocumentContentEmpty
 	}
+	if contentType == "" {
+		contentType = valueobject.DefaultContentType
+	}
+	if !contentType.IsValid() {
+		return nil, valueobject.ErrInvalidContentType
+	}
 
 	if tags == nil {
 		tags = make([]string, 0)
@@ -39,7 +57,10 @@ func NewDocument(kbID valueobject.KnowledgeBaseID, title, content string, tags [
 		knowledgeBaseID: kbID,
 		title:           title,
 		content:         content,
+		contentType:     contentType,
 		tags:            tags,
+		status:          valueobject.DocumentStatusDraft,
+		reviewComments:  make([]valueobject.ReviewComment, 0),
 		createdAt:       now,
 		updatedAt:       now,
 	}, nil
@@ -49,18 +70,37 @@ func NewDocument(kbID valueobject.KnowledgeBaseID, title, content string, tags [
 func ReconstructDocument(
 	id valueobject.DocumentID,
 	kbID valueobject.KnowledgeBaseID,
+	folderID *valueobject.FolderID,
 	title, content string,
+	contentType valueobject.ContentType,
 	tags []string,
+	status valueobject.DocumentStatus,
+	reviewComments []valueobject.ReviewComment,
+	publishAt, expireAt *time.Time,
 	createdAt, updatedAt time.Time,
+	deletedAt *time.Time,
 ) *Document {
+	if reviewComments == nil {
+		reviewComments = make([]valueobject.ReviewComment, 0)
+	}
+	if contentType == "" {
+		contentType = valueobject.DefaultContentType
+	}
 	return &Document{
 		id:              id,
 		knowledgeBaseID: kbID,
+		folderID:        folderID,
 		title:           title,
 		content:         content,
+		contentType:     contentType,
 		tags:            tags,
+		status:          status,
+		reviewComments:  reviewComments,
+		publishAt:       publishAt,
+		expireAt:        expireAt,
 		createdAt:       createdAt,
 		updatedAt:       updatedAt,
+		deletedAt:       deletedAt,
 	}
 }
 
@@ -74,6 +114,11 @@ func (d *Document) KnowledgeBaseID() valueobject.KnowledgeBaseID {
 	return d.knowledgeBaseID
 }
 
+// FolderID 获取所在文件夹ID（nil 表示根目录）
+func (d *Document) FolderID() *valueobject.FolderID {
+	return d.folderID
+}
+
 // Title 获取文档标题
 func (d *Document) Title() string {
 	return d.title
@@ -84,6 +129,16 @@ func (d *Document) Content() string {
 	return d.content
 }
 
+// ContentBytes 获取文档内容的字节数，计入租户的存储配额
+func (d *Document) ContentBytes() int64 {
+	return int64(len(d.content))
+}
+
+// ContentType 获取内容类型
+func (d *Document) ContentType() valueobject.ContentType {
+	return d.contentType
+}
+
 // Tags 获取标签列表
 func (d *Document) Tags() []string {
 	result := make([]string, len(d.tags))
@@ -91,6 +146,64 @@ func (d *Document) Tags() []string {
 	return result
 }
 
+// Status 获取发布状态
+func (d *Document) Status() valueobject.DocumentStatus {
+	return d.status
+}
+
+// IsPublished 是否已发布
+func (d *Document) IsPublished() bool {
+	return d.status == valueobject.DocumentStatusPublished
+}
+
+// ReviewComments 获取评审意见记录（返回副本）
+func (d *Document) ReviewComments() []valueobject.ReviewComment {
+	result := make([]valueobject.ReviewComment, len(d.reviewComments))
+	copy(result, d.reviewComments)
+	return result
+}
+
+// PublishAt 获取定时发布时间
+func (d *Document) PublishAt() *time.Time {
+	return d.publishAt
+}
+
+// ExpireAt 获取定时下线时间
+func (d *Document) ExpireAt() *time.Time {
+	return d.expireAt
+}
+
+// IsDueForPublish 在给定时间是否到达定时发布时间（仅已审核的文档可定时发布）
+func (d *Document) IsDueForPublish(now time.Time) bool {
+	return d.status == valueobject.DocumentStatusApproved &&
+		d.publishAt != nil && !d.publishAt.After(now)
+}
+
+// IsDueForExpiry 在给定时间是否到达定时下线时间（仅已发布的文档会过期）
+func (d *Document) IsDueForExpiry(now time.Time) bool {
+	return d.status == valueobject.DocumentStatusPublished &&
+		d.expireAt != nil && !d.expireAt.After(now)
+}
+
+// Fingerprint 计算文档内容的指纹，用于重复检测
+func (d *Document) Fingerprint() valueobject.ContentFingerprint {
+	return valueobject.ComputeContentFingerprint(d.content)
+}
+
+// NormalizedContentHash 获取规范化内容的哈希（忽略空白差异），用于判断内容完全重复
+// 首次调用时计算并缓存，避免每次添加文档都重新计算知识库中所有文档的哈希
+func (d *Document) NormalizedContentHash() valueobject.ContentHash {
+	if d.normalizedHash == "" {
+		d.normalizedHash = valueobject.NormalizedContentHash(d.content)
+	}
+	return d.normalizedHash
+}
+
+// Links 解析文档内容中引用其他文档的链接
+func (d *Document) Links() []valueobject.DocumentLink {
+	return valueobject.ParseDocumentLinks(d.content)
+}
+
 // CreatedAt 获取创建时间
 func (d *Document) CreatedAt() time.Time {
 	return d.createdAt
@@ -101,6 +214,28 @@ func (d *Document) UpdatedAt() time.Time {
 	return d.updatedAt
 }
 
+// DeletedAt 获取移入回收站的时间
+func (d *Document) DeletedAt() *time.Time {
+	return d.deletedAt
+}
+
+// IsDeleted 是否已移入回收站
+func (d *Document) IsDeleted() bool {
+	return d.deletedAt != nil
+}
+
+// markDeleted 标记为已删除（仅供聚合根调用）
+func (d *Document) markDeleted() {
+	now := time.Now()
+	d.deletedAt = &now
+}
+
+// restore 清除删除标记（仅供聚合根调用）
+func (d *Document) restore() {
+	d.deletedAt = nil
+	d.updatedAt = time.Now()
+}
+
 // UpdateContent 更新文档内容
 func (d *Document) UpdateContent(title, content string) error {
 	if title == "" {
@@ -111,6 +246,17 @@ func (d *Document) UpdateContent(title, content string) error {
 	}
 	d.title = title
 	d.content = content
+	d.normalizedHash = ""
+	d.updatedAt = time.Now()
+	return nil
+}
+
+// ChangeContentType 修改内容类型
+func (d *Document) ChangeContentType(contentType valueobject.ContentType) error {
+	if !contentType.IsValid() {
+		return valueobject.ErrInvalidContentType
+	}
+	d.contentType = contentType
 	d.updatedAt = time.Now()
 	return nil
 }
@@ -123,3 +269,42 @@ func (d *Document) UpdateTags(tags []string) {
 	d.tags = tags
 	d.updatedAt = time.Now()
 }
+
+// ==================== 发布流程（仅供聚合根调用） ====================
+
+// transitionTo 转换发布状态，校验状态机规则
+func (d *Document) transitionTo(target valueobject.DocumentStatus) error {
+	if !d.status.CanTransitionTo(target) {
+		return domain.ErrInvalidDocumentStatusTransition
+	}
+	d.status = target
+	d.updatedAt = time.Now()
+	return nil
+}
+
+// addReviewComment 追加评审意见
+func (d *Document) addReviewComment(comment valueobject.ReviewComment) {
+	d.reviewComments = append(d.reviewComments, comment)
+}
+
+// moveToFolder 移动到指定文件夹（仅供聚合根调用）
+func (d *Document) moveToFolder(folderID *valueobject.FolderID) {
+	d.folderID = folderID
+	d.updatedAt = time.Now()
+}
+
+// setSchedule 设置定时发布和下线时间
+func (d *Document) setSchedule(publishAt, expireAt *time.Time) {
+	d.publishAt = publishAt
+	d.expireAt = expireAt
+	d.updatedAt = time.Now()
+}
+
+// copyWorkflowFrom 从另一个文档复制发布状态、评审记录和定时设置
+// 用于文档在知识库之间移动时保留其发布流程信息
+func (d *Document) copyWorkflowFrom(src *Document) {
+	d.status = src.status
+	d.reviewComments = src.ReviewComments()
+	d.publishAt = src.publishAt
+	d.expireAt = src.expireAt
+}
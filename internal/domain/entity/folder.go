@@ -0,0 +1,118 @@
+package entity
+
+import (
+	"time"
+
+	"gozero-ddd/internal/domain"
+	"gozero-ddd/internal/domain/valueobject"
+)
+
+// Folder 文件夹实体
+// Folder 属于 KnowledgeBase 聚合，不是聚合根
+// 文件夹之间通过 parentID 组成树形结构，parentID 为 nil 表示位于根目录
+type Folder struct {
+	id              valueobject.FolderID        // 唯一标识
+	knowledgeBaseID valueobject.KnowledgeBaseID // 所属知识库ID
+	parentID        *valueobject.FolderID       // 父文件夹ID（nil 表示根目录）
+	name            string                      // 文件夹名称
+	createdAt       time.Time                   // 创建时间
+	updatedAt       time.Time                   // 更新时间
+}
+
+// NewFolder 创建新文件夹
+func NewFolder(kbID valueobject.KnowledgeBaseID, parentID *valueobject.FolderID, name string) (*Folder, error) {
+	if name == "" {
+		return nil, domain.ErrFolderNameEmpty
+	}
+
+	now := time.Now()
+	return &Folder{
+		id:              valueobject.NewFolderID(),
+		knowledgeBaseID: kbID,
+		parentID:        parentID,
+		name:            name,
+		createdAt:       now,
+		updatedAt:       now,
+	}, nil
+}
+
+// ReconstructFolder 从持久化数据重建文件夹实体
+func ReconstructFolder(
+	id valueobject.FolderID,
+	kbID valueobject.KnowledgeBaseID,
+	parentID *valueobject.FolderID,
+	name string,
+	createdAt, updatedAt time.Time,
+) *Folder {
+	return &Folder{
+		id:              id,
+		knowledgeBaseID: kbID,
+		parentID:        parentID,
+		name:            name,
+		createdAt:       createdAt,
+		updatedAt:       updatedAt,
+	}
+}
+
+// ID 获取文件夹ID
+func (f *Folder) ID() valueobject.FolderID {
+	return f.id
+}
+
+// KnowledgeBaseID 获取所属知识库ID
+func (f *Folder) KnowledgeBaseID() valueobject.KnowledgeBaseID {
+	return f.knowledgeBaseID
+}
+
+// ParentID 获取父文件夹ID（nil 表示根目录）
+func (f *Folder) ParentID() *valueobject.FolderID {
+	return f.parentID
+}
+
+// Name 获取文件夹名称
+func (f *Folder) Name() string {
+	return f.name
+}
+
+// CreatedAt 获取创建时间
+func (f *Folder) CreatedAt() time.Time {
+	return f.createdAt
+}
+
+// UpdatedAt 获取更新时间
+func (f *Folder) UpdatedAt() time.Time {
+	return f.updatedAt
+}
+
+// IsRoot 是否位于根目录
+func (f *Folder) IsRoot() bool {
+	return f.parentID == nil
+}
+
+// rename 重命名（仅供聚合根调用）
+func (f *Folder) rename(name string) {
+	f.name = name
+	f.updatedAt = time.Now()
+}
+
+// moveTo 移动到新的父文件夹（仅供聚合根调用）
+func (f *Folder) moveTo(parentID *valueobject.FolderID) {
+	f.parentID = parentID
+	f.updatedAt = time.Now()
+}
+
+// sameFolder 判断两个文件夹ID指针是否指向同一位置（nil 表示根目录）
+func sameFolder(a, b *valueobject.FolderID) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
+
+// folderIDString 将文件夹ID指针转换为字符串（根目录为空字符串）
+func folderIDString(id *valueobject.FolderID) string {
+	if id == nil {
+		return ""
+	}
+	return id.String()
+}
@@ -1,6 +1,7 @@
 package entity
 
 import (
+	"fmt"
 	"time"
 
 	"gozero-ddd/internal/domain"
@@ -15,12 +16,24 @@ import (
 // 领域事件：聚合根负责收集领域事件，在应用层持久化成功后发布
 // 这确保了事件与状态变更的一致性
 type KnowledgeBase struct {
-	id          valueobject.KnowledgeBaseID // 唯一标识
-	name        string                      // 知识库名称
-	description string                      // 描述
-	documents   []*Document                 // 文档集合
-	createdAt   time.Time                   // 创建时间
-	updatedAt   time.Time                   // 更新时间
+	id          valueobject.KnowledgeBaseID     // 唯一标识
+	name        string                          // 知识库名称
+	description string                          // 描述
+	status      valueobject.KnowledgeBaseStatus // 生命周期状态
+	dupPolicy   valueobject.DuplicatePolicy     // 重复文档策略
+	documents   []*Document                     // 文档集合
+	folders     []*Folder                       // 文件夹集合
+	tags        []*TagDefinition                // 标签注册表
+	attachments []*Attachment                   // 文档附件
+	createdAt   time.Time                       // 创建时间
+	updatedAt   time.Time                       // 更新时间
+	deletedAt   *time.Time                      // 移入回收站时间（nil 表示未删除）
+
+	// 所属租户的配额策略和当前用量，由 ApplyQuota 设置，未设置时不限制
+	quotaPolicy  valueobject.QuotaPolicy
+	quotaUsage   valueobject.QuotaUsage
+	quotaUsed    valueobject.QuotaUsage // 本次操作新占用的用量，由 PullQuotaUsed 取出后计入租户用量
+	quotaTrashed int                    // 回收站中的文档数，与未删除的文档一起计入单个知识库的文档数量
 
 	// 领域事件收集器
 	// 聚合根在业务操作时收集事件，由应用层负责发布
@@ -41,7 +54,12 @@ func NewKnowledgeBase(name, description string) (*KnowledgeBase, error) {
 		id:          id,
 		name:        name,
 		description: description,
+		status:      valueobject.KnowledgeBaseStatusActive,
+		dupPolicy:   valueobject.DuplicatePolicyAllow,
 		documents:   make([]*Document, 0),
+		folders:     make([]*Folder, 0),
+		tags:        make([]*TagDefinition, 0),
+		attachments: make([]*Attachment, 0),
 		createdAt:   now,
 		updatedAt:   now,
 		events:      make([]event.DomainEvent, 0),
@@ -59,20 +77,141 @@ func NewKnowledgeBase(name, description string) (*KnowledgeBase, error) {
 func ReconstructKnowledgeBase(
 	id valueobject.KnowledgeBaseID,
 	name, description string,
+	status valueobject.KnowledgeBaseStatus,
+	dupPolicy valueobject.DuplicatePolicy,
 	documents []*Document,
+	folders []*Folder,
+	tags []*TagDefinition,
+	attachments []*Attachment,
 	createdAt, updatedAt time.Time,
+	deletedAt *time.Time,
 ) *KnowledgeBase {
+	if folders == nil {
+		folders = make([]*Folder, 0)
+	}
+	if tags == nil {
+		tags = make([]*TagDefinition, 0)
+	}
+	if attachments == nil {
+		attachments = make([]*Attachment, 0)
+	}
+	if !dupPolicy.IsValid() {
+		dupPolicy = valueobject.DuplicatePolicyAllow
+	}
 	return &KnowledgeBase{
 		id:          id,
 		name:        name,
 		description: description,
+		status:      status,
+		dupPolicy:   dupPolicy,
 		documents:   documents,
+		folders:     folders,
+		tags:        tags,
+		attachments: attachments,
 		createdAt:   createdAt,
 		updatedAt:   updatedAt,
+		deletedAt:   deletedAt,
 		events:      make([]event.DomainEvent, 0), // 重建不产生事件
 	}
 }
 
+// ImportKnowledgeBase 从备份数据导入知识库
+// 与 ReconstructKnowledgeBase 不同，导入的数据来自外部，需要校验聚合内部的一致性：
+// 所有成员都属于该知识库、ID 不重复、文件夹和标签的父节点存在且不成环、文档和附件的引用有效
+// 导入保留原有的ID、状态和时间戳，会收集 KnowledgeBaseImportedEvent 事件
+func ImportKnowledgeBase(
+	id valueobject.KnowledgeBaseID,
+	name, description string,
+	status valueobject.KnowledgeBaseStatus,
+	dupPolicy valueobject.DuplicatePolicy,
+	documents []*Document,
+	folders []*Folder,
+	tags []*TagDefinition,
+	attachments []*Attachment,
+	createdAt, updatedAt time.Time,
+) (*KnowledgeBase, error) {
+	if name == "" {
+		return nil, domain.ErrKnowledgeBaseNameEmpty
+	}
+	if documents == nil {
+		documents = make([]*Document, 0)
+	}
+
+	kb := ReconstructKnowledgeBase(id, name, description, status, dupPolicy, documents, folders, tags, attachments, createdAt, updatedAt, nil)
+	if err := kb.validateImported(); err != nil {
+		return nil, err
+	}
+
+	importedEvent := event.NewKnowledgeBaseImportedEvent(id, name, len(documents))
+	for _, doc := range documents {
+		importedEvent.ContentBytes += doc.ContentBytes()
+	}
+	kb.addEvent(importedEvent)
+	return kb, nil
+}
+
+// validateImported 校验导入数据的一致性
+func (kb *KnowledgeBase) validateImported() error {
+	folderIDs := make(map[valueobject.FolderID]bool, len(kb.folders))
+	for _, f := range kb.folders {
+		if f.KnowledgeBaseID() != kb.id || folderIDs[f.ID()] {
+			return domain.ErrInvalidBackup
+		}
+		folderIDs[f.ID()] = true
+	}
+	for _, f := range kb.folders {
+		if f.ParentID() != nil && !folderIDs[*f.ParentID()] {
+			return domain.ErrInvalidBackup
+		}
+		if f.ParentID() != nil && kb.isDescendantOrSelf(*f.ParentID(), f.ID()) {
+			return domain.ErrFolderCycle
+		}
+	}
+
+	tagIDs := make(map[valueobject.TagID]bool, len(kb.tags))
+	names := make(map[valueobject.Tag]bool)
+	for _, t := range kb.tags {
+		if t.KnowledgeBaseID() != kb.id || tagIDs[t.ID()] {
+			return domain.ErrInvalidBackup
+		}
+		tagIDs[t.ID()] = true
+		for _, n := range append([]valueobject.Tag{t.Name()}, t.Aliases()...) {
+			if names[n] {
+				return domain.ErrTagAlreadyExists
+			}
+			names[n] = true
+		}
+	}
+	for _, t := range kb.tags {
+		if t.ParentID() != nil && !tagIDs[*t.ParentID()] {
+			return domain.ErrInvalidBackup
+		}
+		if t.ParentID() != nil && kb.isTagDescendantOrSelf(*t.ParentID(), t.ID()) {
+			return domain.ErrTagCycle
+		}
+	}
+
+	docIDs := make(map[valueobject.DocumentID]bool, len(kb.documents))
+	for _, d := range kb.documents {
+		if d.KnowledgeBaseID() != kb.id || docIDs[d.ID()] {
+			return domain.ErrInvalidBackup
+		}
+		if d.FolderID() != nil && !folderIDs[*d.FolderID()] {
+			return domain.ErrInvalidBackup
+		}
+		docIDs[d.ID()] = true
+	}
+
+	attIDs := make(map[valueobject.AttachmentID]bool, len(kb.attachments))
+	for _, a := range kb.attachments {
+		if a.KnowledgeBaseID() != kb.id || attIDs[a.ID()] || !docIDs[a.DocumentID()] {
+			return domain.ErrInvalidBackup
+		}
+		attIDs[a.ID()] = true
+	}
+	return nil
+}
+
 // ID 获取知识库ID
 func (kb *KnowledgeBase) ID() valueobject.KnowledgeBaseID {
 	return kb.id
@@ -88,6 +227,21 @@ func (kb *KnowledgeBase) Description() string {
 	return kb.description
 }
 
+// Status 获取知识库生命周期状态
+func (kb *KnowledgeBase) Status() valueobject.KnowledgeBaseStatus {
+	return kb.status
+}
+
+// DuplicatePolicy 获取重复文档策略
+func (kb *KnowledgeBase) DuplicatePolicy() valueobject.DuplicatePolicy {
+	return kb.dupPolicy
+}
+
+// IsActive 是否处于可写状态
+func (kb *KnowledgeBase) IsActive() bool {
+	return kb.status == valueobject.KnowledgeBaseStatusActive
+}
+
 // Documents 获取文档列表（返回副本，保护内部状态）
 func (kb *KnowledgeBase) Documents() []*Document {
 	result := make([]*Document, len(kb.documents))
@@ -95,6 +249,27 @@ func (kb *KnowledgeBase) Documents() []*Document {
 	return result
 }
 
+// Folders 获取文件夹列表（返回副本，保护内部状态）
+func (kb *KnowledgeBase) Folders() []*Folder {
+	result := make([]*Folder, len(kb.folders))
+	copy(result, kb.folders)
+	return result
+}
+
+// TagDefinitions 获取标签注册表（返回副本，保护内部状态）
+func (kb *KnowledgeBase) TagDefinitions() []*TagDefinition {
+	result := make([]*TagDefinition, len(kb.tags))
+	copy(result, kb.tags)
+	return result
+}
+
+// Attachments 获取所有附件（返回副本）
+func (kb *KnowledgeBase) Attachments() []*Attachment {
+	result := make([]*Attachment, len(kb.attachments))
+	copy(result, kb.attachments)
+	return result
+}
+
 // CreatedAt 获取创建时间
 func (kb *KnowledgeBase) CreatedAt() time.Time {
 	return kb.createdAt
@@ -105,9 +280,22 @@ func (kb *KnowledgeBase) UpdatedAt() time.Time {
 	return kb.updatedAt
 }
 
+// DeletedAt 获取移入回收站的时间
+func (kb *KnowledgeBase) DeletedAt() *time.Time {
+	return kb.deletedAt
+}
+
+// IsDeleted 是否已移入回收站
+func (kb *KnowledgeBase) IsDeleted() bool {
+	return kb.deletedAt != nil
+}
+
 // UpdateInfo 更新知识库信息
 // 会收集 KnowledgeBaseUpdatedEvent 事件
 func (kb *KnowledgeBase) UpdateInfo(name, description string) error {
+	if err := kb.ensureActive(); err != nil {
+		return err
+	}
 	if name == "" {
 		return domain.ErrKnowledgeBaseNameEmpty
 	}
@@ -127,33 +315,158 @@ func (kb *KnowledgeBase) UpdateInfo(name, description string) error {
 	return nil
 }
 
+// ChangeDuplicatePolicy 变更重复文档策略
+// 新策略只对之后添加的文档生效，已有的重复文档不受影响
+// 会收集 KnowledgeBaseDuplicatePolicyChangedEvent 事件
+func (kb *KnowledgeBase) ChangeDuplicatePolicy(policy valueobject.DuplicatePolicy) error {
+	if err := kb.ensureActive(); err != nil {
+		return err
+	}
+	if !policy.IsValid() {
+		return valueobject.ErrInvalidDuplicatePolicy
+	}
+	if policy == kb.dupPolicy {
+		return nil
+	}
+
+	oldPolicy := kb.dupPolicy
+	kb.dupPolicy = policy
+	kb.updatedAt = time.Now()
+
+	kb.addEvent(event.NewKnowledgeBaseDuplicatePolicyChangedEvent(kb.id, oldPolicy, policy))
+
+	return nil
+}
+
 // AddDocument 添加文档到知识库
 // 通过聚合根添加文档，确保业务规则的一致性
+// 标签会被规范化，别名替换为标签注册表中的规范名称；contentType 为空时使用默认的 Markdown
+// 重复文档策略为 reject_exact 时，内容与已有文档完全相同（忽略空白差异）的文档会被拒绝
+// 超出单个知识库的文档数量或租户的内容总字节数配额时返回 ErrQuotaExceeded
 // 会收集 DocumentAddedEvent 事件
-func (kb *KnowledgeBase) AddDocument(title, content string, tags []string) (*Document, error) {
-	doc, err := NewDocument(kb.id, title, content, tags)
+func (kb *KnowledgeBase) AddDocument(title, content string, contentType valueobject.ContentType, tags []string) (*Document, error) {
+	return kb.addDocument(title, content, contentType, tags, valueobject.QuotaUsage{Documents: 1, ContentBytes: int64(len(content))})
+}
+
+// addDocument 添加文档到知识库，used 为计入租户用量的增量
+func (kb *KnowledgeBase) addDocument(title, content string, contentType valueobject.ContentType, tags []string, used valueobject.QuotaUsage) (*Document, error) {
+	if err := kb.ensureActive(); err != nil {
+		return nil, err
+	}
+	normalized, err := kb.normalizeTags(tags)
+	if err != nil {
+		return nil, err
+	}
+	doc, err := NewDocument(kb.id, title, content, contentType, normalized)
 	if err != nil {
 		return nil, err
 	}
+	if kb.dupPolicy == valueobject.DuplicatePolicyRejectExact && kb.hasDocumentWithContent(content) {
+		return nil, domain.ErrDuplicateDocument
+	}
+	if err := kb.checkQuota(1, used.ContentBytes); err != nil {
+		return nil, err
+	}
 	kb.documents = append(kb.documents, doc)
+	kb.useQuota(used)
 	kb.updatedAt = time.Now()
 
 	// 收集文档添加事件
-	kb.addEvent(event.NewDocumentAddedEvent(doc.ID(), kb.id, title))
+	addedEvent := event.NewDocumentAddedEvent(doc.ID(), kb.id, title)
+	addedEvent.ContentBytes = doc.ContentBytes()
+	kb.addEvent(addedEvent)
+
+	return doc, nil
+}
+
+// UpdateDocument 更新文档的标题、内容、内容类型和标签
+// contentType 为空时保留原内容类型，tags 为 nil 时保留原标签
+// 编辑受发布流程约束：
+//   - draft / rejected：直接修改
+//   - in_review / approved：返回 ErrDocumentUnderReview，审核结论只对评审看到的内容有效，需要先驳回再修改
+//   - published / expired：修改后退回 draft，需要重新提交审核才能发布
+//
+// 内容变长导致超出租户的内容总字节数配额时返回 ErrQuotaExceeded
+// 会收集 DocumentUpdatedEvent 事件，其中记录更新前后的发布状态
+func (kb *KnowledgeBase) UpdateDocument(docID valueobject.DocumentID, title, content string, contentType valueobject.ContentType, tags []string) (*Document, error) {
+	if err := kb.ensureActive(); err != nil {
+		return nil, err
+	}
+
+	doc, err := kb.GetDocument(docID)
+	if err != nil {
+		return nil, err
+	}
+	oldStatus := doc.Status()
+	if oldStatus == valueobject.DocumentStatusInReview || oldStatus == valueobject.DocumentStatusApproved {
+		return nil, domain.ErrDocumentUnderReview
+	}
+
+	var normalized []string
+	if tags != nil {
+		if normalized, err = kb.normalizeTags(tags); err != nil {
+			return nil, err
+		}
+	}
+
+	if contentType != "" && !contentType.IsValid() {
+		return nil, valueobject.ErrInvalidContentType
+	}
+
+	oldTitle := doc.Title()
+	oldBytes := doc.ContentBytes()
+	grownBytes := int64(len(content)) - oldBytes
+	if err := kb.checkQuota(0, grownBytes); err != nil {
+		return nil, err
+	}
+	if err := doc.UpdateContent(title, content); err != nil {
+		return nil, err
+	}
+	kb.useQuota(valueobject.QuotaUsage{ContentBytes: grownBytes})
+	if contentType != "" {
+		if err := doc.ChangeContentType(contentType); err != nil {
+			return nil, err
+		}
+	}
+	if normalized != nil {
+		doc.UpdateTags(normalized)
+	}
+	// 已发布或已过期的内容被修改后退回草稿，避免未经审核的内容对外可见
+	if oldStatus == valueobject.DocumentStatusPublished || oldStatus == valueobject.DocumentStatusExpired {
+		if err := doc.transitionTo(valueobject.DocumentStatusDraft); err != nil {
+			return nil, err
+		}
+	}
+	kb.updatedAt = time.Now()
+
+	updatedEvent := event.NewDocumentUpdatedEvent(docID, kb.id, oldTitle, title)
+	updatedEvent.OldContentBytes = oldBytes
+	updatedEvent.NewContentBytes = doc.ContentBytes()
+	updatedEvent.OldStatus = oldStatus
+	updatedEvent.NewStatus = doc.Status()
+	kb.addEvent(updatedEvent)
 
 	return doc, nil
 }
 
 // RemoveDocument 从知识库移除文档
+// 移除的文档进入回收站，在保留期内可以通过 RestoreDocument 恢复
 // 会收集 DocumentRemovedEvent 事件
 func (kb *KnowledgeBase) RemoveDocument(docID valueobject.DocumentID) error {
+	if err := kb.ensureActive(); err != nil {
+		return err
+	}
 	for i, doc := range kb.documents {
 		if doc.ID() == docID {
+			doc.markDeleted()
 			kb.documents = append(kb.documents[:i], kb.documents[i+1:]...)
+			kb.quotaTrashed++
 			kb.updatedAt = time.Now()
 
 			// 收集文档删除事件
-			kb.addEvent(event.NewDocumentRemovedEvent(docID, kb.id))
+			removedEvent := event.NewDocumentRemovedEvent(docID, kb.id)
+			removedEvent.Title = doc.Title()
+			kb.addEvent(removedEvent)
 
 			return nil
 		}
@@ -161,14 +474,1085 @@ func (kb *KnowledgeBase) RemoveDocument(docID valueobject.DocumentID) error {
 	return domain.ErrDocumentNotFound
 }
 
-// GetDocument 获取指定文档
-func (kb *KnowledgeBase) GetDocument(docID valueobject.DocumentID) (*Document, error) {
-	for _, doc := range kb.documents {
-		if doc.ID() == docID {
-			return doc, nil
-		}
+// AdoptDocument 接收从其他知识库移入的文档
+// 在当前知识库中创建一份新文档，并保留原文档的发布状态和评审记录
+// 会收集 DocumentAddedEvent 事件
+func (kb *KnowledgeBase) AdoptDocument(src *Document) (*Document, error) {
+	// 文档从同一租户的其他知识库移入，租户的用量不变，只校验单个知识库的文档数量
+	doc, err := kb.addDocument(src.Title(), src.Content(), src.ContentType(), src.Tags(), valueobject.QuotaUsage{})
+	if err != nil {
+		return nil, err
 	}
-	return nil, domain.ErrDocumentNotFound
+	doc.copyWorkflowFrom(src)
+	return doc, nil
+}
+
+// RestoreDocument 将回收站中的文档恢复到知识库
+// 文档必须属于当前知识库，且处于已删除状态
+// 回收站中的文档已计入配额，恢复不增加用量；但配额调低后知识库或租户已超出配额时返回 ErrQuotaExceeded，
+// 需要先清理回收站
+// 会收集 DocumentRestoredEvent 事件
+func (kb *KnowledgeBase) RestoreDocument(doc *Document) error {
+	if err := kb.ensureActive(); err != nil {
+		return err
+	}
+	if doc.KnowledgeBaseID() != kb.id || !doc.IsDeleted() {
+		return domain.ErrDocumentNotFound
+	}
+	if err := kb.ensureWithinQuota(); err != nil {
+		return err
+	}
+
+	doc.restore()
+	if kb.quotaTrashed > 0 {
+		kb.quotaTrashed--
+	}
+	// 文档所在的文件夹在其处于回收站期间被删除时，恢复到根目录
+	if doc.folderID != nil && kb.findFolder(*doc.folderID) == nil {
+		doc.folderID = nil
+	}
+	kb.documents = append(kb.documents, doc)
+	kb.updatedAt = time.Now()
+
+	// 收集文档恢复事件
+	kb.addEvent(event.NewDocumentRestoredEvent(doc.ID(), kb.id, doc.Title()))
+
+	return nil
+}
+
+// MoveToTrash 将知识库移入回收站（软删除）
+// 回收站中的知识库在保留期内可以恢复，超过保留期后会被彻底清除
+// 会收集 KnowledgeBaseTrashedEvent 事件
+func (kb *KnowledgeBase) MoveToTrash() {
+	if kb.deletedAt != nil {
+		return
+	}
+
+	now := time.Now()
+	kb.deletedAt = &now
+
+	// 收集移入回收站事件
+	kb.addEvent(event.NewKnowledgeBaseTrashedEvent(kb.id, kb.name))
+}
+
+// Restore 从回收站恢复知识库
+// 会收集 KnowledgeBaseRestoredEvent 事件
+func (kb *KnowledgeBase) Restore() error {
+	if kb.deletedAt == nil {
+		return domain.ErrKnowledgeBaseNotFound
+	}
+
+	kb.deletedAt = nil
+	kb.updatedAt = time.Now()
+
+	// 收集恢复事件
+	kb.addEvent(event.NewKnowledgeBaseRestoredEvent(kb.id, kb.name))
+
+	return nil
+}
+
+// ==================== 文档发布流程 ====================
+
+// SubmitDocument 提交文档审核（draft / rejected -> in_review）
+// 会收集 DocumentSubmittedEvent 事件
+func (kb *KnowledgeBase) SubmitDocument(docID valueobject.DocumentID) error {
+	doc, err := kb.transitionDocument(docID, valueobject.DocumentStatusInReview)
+	if err != nil {
+		return err
+	}
+
+	kb.addEvent(event.NewDocumentSubmittedEvent(doc.ID(), kb.id, doc.Title()))
+	return nil
+}
+
+// ApproveDocument 审核通过文档（in_review -> approved）
+// 会记录评审意见并收集 DocumentApprovedEvent 事件
+func (kb *KnowledgeBase) ApproveDocument(docID valueobject.DocumentID, reviewer, comment string) error {
+	doc, err := kb.transitionDocument(docID, valueobject.DocumentStatusApproved)
+	if err != nil {
+		return err
+	}
+	doc.addReviewComment(valueobject.NewReviewComment("approve", reviewer, comment))
+
+	kb.addEvent(event.NewDocumentApprovedEvent(doc.ID(), kb.id, reviewer, comment))
+	return nil
+}
+
+// RejectDocument 驳回文档（in_review -> rejected）
+// 驳回必须填写评审意见，告知作者需要修改的内容
+// 会收集 DocumentRejectedEvent 事件
+func (kb *KnowledgeBase) RejectDocument(docID valueobject.DocumentID, reviewer, comment string) error {
+	if comment == "" {
+		return domain.ErrReviewCommentEmpty
+	}
+
+	doc, err := kb.transitionDocument(docID, valueobject.DocumentStatusRejected)
+	if err != nil {
+		return err
+	}
+	doc.addReviewComment(valueobject.NewReviewComment("reject", reviewer, comment))
+
+	kb.addEvent(event.NewDocumentRejectedEvent(doc.ID(), kb.id, reviewer, comment))
+	return nil
+}
+
+// PublishDocument 发布文档（approved -> published）
+// 会收集 DocumentPublishedEvent 事件
+func (kb *KnowledgeBase) PublishDocument(docID valueobject.DocumentID) error {
+	doc, err := kb.transitionDocument(docID, valueobject.DocumentStatusPublished)
+	if err != nil {
+		return err
+	}
+
+	kb.addEvent(event.NewDocumentPublishedEvent(doc.ID(), kb.id, doc.Title()))
+	return nil
+}
+
+// UnpublishDocument 撤回已发布的文档（published -> draft）
+// 会收集 DocumentUnpublishedEvent 事件
+func (kb *KnowledgeBase) UnpublishDocument(docID valueobject.DocumentID) error {
+	doc, err := kb.transitionDocument(docID, valueobject.DocumentStatusDraft)
+	if err != nil {
+		return err
+	}
+
+	kb.addEvent(event.NewDocumentUnpublishedEvent(doc.ID(), kb.id, doc.Title()))
+	return nil
+}
+
+// ==================== 定时发布与下线 ====================
+
+// ScheduleDocument 设置文档的定时发布时间和定时下线时间
+// publishAt / expireAt 为 nil 表示取消对应的定时设置
+// 会收集 DocumentScheduledEvent 事件
+func (kb *KnowledgeBase) ScheduleDocument(docID valueobject.DocumentID, publishAt, expireAt *time.Time) error {
+	if err := kb.ensureActive(); err != nil {
+		return err
+	}
+	if publishAt != nil && expireAt != nil && !expireAt.After(*publishAt) {
+		return domain.ErrInvalidDocumentSchedule
+	}
+
+	doc, err := kb.GetDocument(docID)
+	if err != nil {
+		return err
+	}
+
+	doc.setSchedule(publishAt, expireAt)
+	kb.updatedAt = time.Now()
+
+	kb.addEvent(event.NewDocumentScheduledEvent(doc.ID(), kb.id, publishAt, expireAt))
+	return nil
+}
+
+// PublishDueDocument 发布到达定时发布时间的文档（approved -> published）
+// 由定时任务调用，now 由调用方传入以便替换时钟
+// 会收集 DocumentPublishedEvent 事件
+func (kb *KnowledgeBase) PublishDueDocument(docID valueobject.DocumentID, now time.Time) error {
+	doc, err := kb.GetDocument(docID)
+	if err != nil {
+		return err
+	}
+	if !doc.IsDueForPublish(now) {
+		return domain.ErrDocumentNotDue
+	}
+
+	if _, err := kb.transitionDocument(docID, valueobject.DocumentStatusPublished); err != nil {
+		return err
+	}
+	// 定时发布已执行，清除发布时间，保留下线时间
+	doc.setSchedule(nil, doc.expireAt)
+
+	evt := event.NewDocumentPublishedEvent(doc.ID(), kb.id, doc.Title())
+	evt.Scheduled = true
+	kb.addEvent(evt)
+	return nil
+}
+
+// ExpireDocument 下线到达定时下线时间的文档（published -> expired）
+// 由定时任务调用，now 由调用方传入以便替换时钟
+// 会收集 DocumentExpiredEvent 事件
+func (kb *KnowledgeBase) ExpireDocument(docID valueobject.DocumentID, now time.Time) error {
+	doc, err := kb.GetDocument(docID)
+	if err != nil {
+		return err
+	}
+	if !doc.IsDueForExpiry(now) {
+		return domain.ErrDocumentNotDue
+	}
+
+	if _, err := kb.transitionDocument(docID, valueobject.DocumentStatusExpired); err != nil {
+		return err
+	}
+
+	kb.addEvent(event.NewDocumentExpiredEvent(doc.ID(), kb.id, doc.Title(), now))
+	return nil
+}
+
+// transitionDocument 转换文档发布状态（内部方法）
+// 只读或已归档的知识库不允许变更文档状态
+func (kb *KnowledgeBase) transitionDocument(docID valueobject.DocumentID, target valueobject.DocumentStatus) (*Document, error) {
+	if err := kb.ensureActive(); err != nil {
+		return nil, err
+	}
+
+	doc, err := kb.GetDocument(docID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := doc.transitionTo(target); err != nil {
+		return nil, err
+	}
+	kb.updatedAt = time.Now()
+
+	return doc, nil
+}
+
+// ==================== 文件夹 ====================
+
+// CreateFolder 在指定父文件夹下创建文件夹
+// parentID 为 nil 表示在根目录创建；同一父文件夹下名称不能重复
+// 会收集 FolderCreatedEvent 事件
+func (kb *KnowledgeBase) CreateFolder(name string, parentID *valueobject.FolderID) (*Folder, error) {
+	if err := kb.ensureActive(); err != nil {
+		return nil, err
+	}
+	if parentID != nil && kb.findFolder(*parentID) == nil {
+		return nil, domain.ErrFolderNotFound
+	}
+	if kb.hasSiblingNamed(parentID, name, "") {
+		return nil, domain.ErrFolderNameExists
+	}
+
+	folder, err := NewFolder(kb.id, parentID, name)
+	if err != nil {
+		return nil, err
+	}
+	kb.folders = append(kb.folders, folder)
+	kb.updatedAt = time.Now()
+
+	kb.addEvent(event.NewFolderCreatedEvent(folder.ID(), kb.id, folderIDString(parentID), name))
+
+	return folder, nil
+}
+
+// RenameFolder 重命名文件夹
+// 会收集 FolderRenamedEvent 事件
+func (kb *KnowledgeBase) RenameFolder(folderID valueobject.FolderID, name string) error {
+	if err := kb.ensureActive(); err != nil {
+		return err
+	}
+	if name == "" {
+		return domain.ErrFolderNameEmpty
+	}
+
+	folder, err := kb.GetFolder(folderID)
+	if err != nil {
+		return err
+	}
+	if folder.Name() == name {
+		return nil
+	}
+	if kb.hasSiblingNamed(folder.ParentID(), name, folderID) {
+		return domain.ErrFolderNameExists
+	}
+
+	oldName := folder.Name()
+	folder.rename(name)
+	kb.updatedAt = time.Now()
+
+	kb.addEvent(event.NewFolderRenamedEvent(folderID, kb.id, oldName, name))
+	return nil
+}
+
+// MoveFolder 将文件夹移动到新的父文件夹下
+// 不允许移动到自身或其子孙文件夹下（防止形成环）
+// 会收集 FolderMovedEvent 事件
+func (kb *KnowledgeBase) MoveFolder(folderID valueobject.FolderID, newParentID *valueobject.FolderID) error {
+	if err := kb.ensureActive(); err != nil {
+		return err
+	}
+
+	folder, err := kb.GetFolder(folderID)
+	if err != nil {
+		return err
+	}
+	if sameFolder(folder.ParentID(), newParentID) {
+		return nil
+	}
+
+	if newParentID != nil {
+		if kb.findFolder(*newParentID) == nil {
+			return domain.ErrFolderNotFound
+		}
+		if kb.isDescendantOrSelf(*newParentID, folderID) {
+			return domain.ErrFolderCycle
+		}
+	}
+	if kb.hasSiblingNamed(newParentID, folder.Name(), folderID) {
+		return domain.ErrFolderNameExists
+	}
+
+	oldParentID := folder.ParentID()
+	folder.moveTo(newParentID)
+	kb.updatedAt = time.Now()
+
+	kb.addEvent(event.NewFolderMovedEvent(folderID, kb.id, folderIDString(oldParentID), folderIDString(newParentID)))
+	return nil
+}
+
+// DeleteFolder 删除文件夹
+// 文件夹中的子文件夹和文档会移动到被删除文件夹的父文件夹下，不会随之删除
+// 返回被移动的文档和子文件夹，供应用层持久化
+// 会收集 FolderDeletedEvent 事件
+func (kb *KnowledgeBase) DeleteFolder(folderID valueobject.FolderID) ([]*Document, []*Folder, error) {
+	if err := kb.ensureActive(); err != nil {
+		return nil, nil, err
+	}
+
+	folder, err := kb.GetFolder(folderID)
+	if err != nil {
+		return nil, nil, err
+	}
+	parentID := folder.ParentID()
+
+	// 子文件夹上移一级，名称冲突时拒绝删除
+	movedFolders := make([]*Folder, 0)
+	for _, child := range kb.folders {
+		if child.ParentID() != nil && *child.ParentID() == folderID {
+			if kb.hasSiblingNamed(parentID, child.Name(), folderID) {
+				return nil, nil, domain.ErrFolderNameExists
+			}
+			movedFolders = append(movedFolders, child)
+		}
+	}
+	for _, child := range movedFolders {
+		child.moveTo(parentID)
+	}
+
+	// 文档上移一级
+	movedDocs := make([]*Document, 0)
+	for _, doc := range kb.documents {
+		if doc.FolderID() != nil && *doc.FolderID() == folderID {
+			doc.moveToFolder(parentID)
+			movedDocs = append(movedDocs, doc)
+		}
+	}
+
+	for i, f := range kb.folders {
+		if f.ID() == folderID {
+			kb.folders = append(kb.folders[:i], kb.folders[i+1:]...)
+			break
+		}
+	}
+	kb.updatedAt = time.Now()
+
+	kb.addEvent(event.NewFolderDeletedEvent(folderID, kb.id, folder.Name()))
+	return movedDocs, movedFolders, nil
+}
+
+// MoveDocumentToFolder 将文档移动到指定文件夹
+// folderID 为 nil 表示移动到根目录
+// 会收集 DocumentMovedEvent 事件
+func (kb *KnowledgeBase) MoveDocumentToFolder(docID valueobject.DocumentID, folderID *valueobject.FolderID) error {
+	if err := kb.ensureActive(); err != nil {
+		return err
+	}
+
+	doc, err := kb.GetDocument(docID)
+	if err != nil {
+		return err
+	}
+	if folderID != nil && kb.findFolder(*folderID) == nil {
+		return domain.ErrFolderNotFound
+	}
+	if sameFolder(doc.FolderID(), folderID) {
+		return nil
+	}
+
+	oldFolderID := doc.FolderID()
+	doc.moveToFolder(folderID)
+	kb.updatedAt = time.Now()
+
+	kb.addEvent(event.NewDocumentMovedEvent(docID, kb.id, folderIDString(oldFolderID), folderIDString(folderID)))
+	return nil
+}
+
+// GetFolder 获取指定文件夹
+func (kb *KnowledgeBase) GetFolder(folderID valueobject.FolderID) (*Folder, error) {
+	folder := kb.findFolder(folderID)
+	if folder == nil {
+		return nil, domain.ErrFolderNotFound
+	}
+	return folder, nil
+}
+
+// findFolder 查找文件夹（内部方法），未找到返回 nil
+func (kb *KnowledgeBase) findFolder(folderID valueobject.FolderID) *Folder {
+	for _, f := range kb.folders {
+		if f.ID() == folderID {
+			return f
+		}
+	}
+	return nil
+}
+
+// hasSiblingNamed 判断父文件夹下是否已存在同名文件夹（excludeID 用于排除自身）
+func (kb *KnowledgeBase) hasSiblingNamed(parentID *valueobject.FolderID, name string, excludeID valueobject.FolderID) bool {
+	for _, f := range kb.folders {
+		if f.ID() != excludeID && f.Name() == name && sameFolder(f.ParentID(), parentID) {
+			return true
+		}
+	}
+	return false
+}
+
+// isDescendantOrSelf 判断 folderID 是否为 ancestorID 自身或其子孙文件夹
+// 沿父链向上查找，访问过的节点超过文件夹总数时视为已存在环
+func (kb *KnowledgeBase) isDescendantOrSelf(folderID, ancestorID valueobject.FolderID) bool {
+	current := &folderID
+	for steps := 0; current != nil && steps <= len(kb.folders); steps++ {
+		if *current == ancestorID {
+			return true
+		}
+		folder := kb.findFolder(*current)
+		if folder == nil {
+			return false
+		}
+		current = folder.ParentID()
+	}
+	return current != nil
+}
+
+// ==================== 标签 ====================
+
+// DefineTag 在标签注册表中定义标签
+// parentID 为 nil 表示顶层标签；已使用别名的文档会被改写为规范名称
+// 返回新的标签定义和被改写标签的文档
+// 会收集 TagDefinedEvent 事件
+func (kb *KnowledgeBase) DefineTag(name string, parentID *valueobject.TagID, aliases []string) (*TagDefinition, []*Document, error) {
+	if err := kb.ensureActive(); err != nil {
+		return nil, nil, err
+	}
+
+	tag, err := valueobject.NewTag(name)
+	if err != nil {
+		return nil, nil, err
+	}
+	if kb.findTagByName(tag) != nil {
+		return nil, nil, domain.ErrTagAlreadyExists
+	}
+	if parentID != nil && kb.findTagDefinition(*parentID) == nil {
+		return nil, nil, domain.ErrTagNotFound
+	}
+	aliasTags, err := kb.validateAliases(tag, aliases, "")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	def := NewTagDefinition(kb.id, tag, parentID, aliasTags)
+	kb.tags = append(kb.tags, def)
+	changed := kb.retagDocuments(aliasMapping(aliasTags, tag))
+	kb.updatedAt = time.Now()
+
+	kb.addEvent(event.NewTagDefinedEvent(def.ID(), kb.id, tag.String(), tagIDString(parentID), valueobject.TagStrings(aliasTags)))
+	return def, changed, nil
+}
+
+// UpdateTag 修改标签定义的父标签和别名
+// 别名列表整体替换；已使用新别名的文档会被改写为规范名称
+// 会收集 TagUpdatedEvent 事件
+func (kb *KnowledgeBase) UpdateTag(tagID valueobject.TagID, parentID *valueobject.TagID, aliases []string) (*TagDefinition, []*Document, error) {
+	if err := kb.ensureActive(); err != nil {
+		return nil, nil, err
+	}
+
+	def, err := kb.GetTagDefinition(tagID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if parentID != nil {
+		if kb.findTagDefinition(*parentID) == nil {
+			return nil, nil, domain.ErrTagNotFound
+		}
+		if kb.isTagDescendantOrSelf(*parentID, tagID) {
+			return nil, nil, domain.ErrTagCycle
+		}
+	}
+	aliasTags, err := kb.validateAliases(def.Name(), aliases, tagID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	def.setParent(parentID)
+	def.setAliases(aliasTags)
+	changed := kb.retagDocuments(aliasMapping(aliasTags, def.Name()))
+	kb.updatedAt = time.Now()
+
+	kb.addEvent(event.NewTagUpdatedEvent(tagID, kb.id, def.Name().String(), tagIDString(parentID), valueobject.TagStrings(aliasTags)))
+	return def, changed, nil
+}
+
+// RenameTag 重命名标签，并改写所有使用该标签的文档
+// from 可以是规范名称或别名，也可以是未在注册表中定义、仅被文档使用的标签
+// 目标名称已被其他标签占用时返回 ErrTagAlreadyExists，此时应使用 MergeTags
+// 会收集 TagRenamedEvent 事件
+func (kb *KnowledgeBase) RenameTag(from, to string) ([]*Document, error) {
+	if err := kb.ensureActive(); err != nil {
+		return nil, err
+	}
+
+	fromTag, err := valueobject.NewTag(from)
+	if err != nil {
+		return nil, err
+	}
+	toTag, err := valueobject.NewTag(to)
+	if err != nil {
+		return nil, err
+	}
+
+	fromTag = kb.canonicalTag(fromTag)
+	if fromTag == toTag {
+		return nil, nil
+	}
+
+	def := kb.findTagByName(fromTag)
+	if other := kb.findTagByName(toTag); other != nil && other != def {
+		return nil, domain.ErrTagAlreadyExists
+	}
+	if def == nil && !kb.hasDocumentTagged(fromTag) {
+		return nil, domain.ErrTagNotFound
+	}
+
+	if def != nil {
+		def.rename(toTag)
+	}
+	changed := kb.retagDocuments(map[valueobject.Tag]valueobject.Tag{fromTag: toTag})
+	kb.updatedAt = time.Now()
+
+	kb.addEvent(event.NewTagRenamedEvent(kb.id, fromTag.String(), toTag.String(), documentIDStrings(changed)))
+	return changed, nil
+}
+
+// MergeTags 将若干源标签合并到目标标签
+// 源标签及其别名成为目标标签的别名，源标签的子标签移动到目标标签下，
+// 所有使用源标签的文档改写为目标标签；目标标签未定义时自动定义为顶层标签
+// 会收集 TagsMergedEvent 事件
+func (kb *KnowledgeBase) MergeTags(sources []string, target string) ([]*Document, error) {
+	if err := kb.ensureActive(); err != nil {
+		return nil, err
+	}
+
+	targetTag, err := valueobject.NewTag(target)
+	if err != nil {
+		return nil, err
+	}
+	targetTag = kb.canonicalTag(targetTag)
+
+	sourceTags, err := valueobject.NewTags(sources)
+	if err != nil {
+		return nil, err
+	}
+	if len(sourceTags) == 0 {
+		return nil, valueobject.ErrTagEmpty
+	}
+
+	// 先完成全部校验，保证合并要么整体成功要么不做任何修改
+	mapping := make(map[valueobject.Tag]valueobject.Tag, len(sourceTags))
+	merged := make([]valueobject.Tag, 0, len(sourceTags))
+	sourceDefs := make([]*TagDefinition, 0, len(sourceTags))
+	for _, source := range sourceTags {
+		source = kb.canonicalTag(source)
+		if source == targetTag {
+			return nil, domain.ErrTagMergeIntoSelf
+		}
+		if _, ok := mapping[source]; ok {
+			continue
+		}
+		def := kb.findTagByName(source)
+		if def == nil && !kb.hasDocumentTagged(source) {
+			return nil, domain.ErrTagNotFound
+		}
+		if def != nil {
+			sourceDefs = append(sourceDefs, def)
+		}
+		mapping[source] = targetTag
+		merged = append(merged, source)
+	}
+
+	targetDef := kb.findTagByName(targetTag)
+	if targetDef == nil {
+		targetDef = NewTagDefinition(kb.id, targetTag, nil, nil)
+		kb.tags = append(kb.tags, targetDef)
+	}
+
+	removed := make(map[valueobject.TagID]bool, len(sourceDefs))
+	for _, source := range merged {
+		targetDef.addAliases(source)
+	}
+	for _, def := range sourceDefs {
+		targetDef.addAliases(def.Aliases()...)
+		for _, child := range kb.tags {
+			if child.ParentID() == nil || *child.ParentID() != def.ID() {
+				continue
+			}
+			if child == targetDef {
+				child.setParent(def.ParentID())
+			} else {
+				child.setParent(&targetDef.id)
+			}
+		}
+		removed[def.ID()] = true
+	}
+
+	remaining := make([]*TagDefinition, 0, len(kb.tags))
+	for _, def := range kb.tags {
+		if !removed[def.ID()] {
+			remaining = append(remaining, def)
+		}
+	}
+	kb.tags = remaining
+
+	// 父标签同样被合并时，子标签可能仍指向已移除的定义，统一移动到目标标签下
+	for _, def := range kb.tags {
+		if def != targetDef && def.ParentID() != nil && removed[*def.ParentID()] {
+			def.setParent(&targetDef.id)
+		}
+	}
+	if targetDef.ParentID() != nil && removed[*targetDef.ParentID()] {
+		targetDef.setParent(nil)
+	}
+
+	changed := kb.retagDocuments(mapping)
+	kb.updatedAt = time.Now()
+
+	kb.addEvent(event.NewTagsMergedEvent(kb.id, valueobject.TagStrings(merged), targetTag.String(), documentIDStrings(changed)))
+	return changed, nil
+}
+
+// DeleteTag 从标签注册表中删除标签定义
+// 文档上的标签保持不变，子标签移动到被删除标签的父标签下
+// 会收集 TagDeletedEvent 事件
+func (kb *KnowledgeBase) DeleteTag(tagID valueobject.TagID) error {
+	if err := kb.ensureActive(); err != nil {
+		return err
+	}
+
+	def, err := kb.GetTagDefinition(tagID)
+	if err != nil {
+		return err
+	}
+
+	for _, child := range kb.tags {
+		if child.ParentID() != nil && *child.ParentID() == tagID {
+			child.setParent(def.ParentID())
+		}
+	}
+	for i, t := range kb.tags {
+		if t.ID() == tagID {
+			kb.tags = append(kb.tags[:i], kb.tags[i+1:]...)
+			break
+		}
+	}
+	kb.updatedAt = time.Now()
+
+	kb.addEvent(event.NewTagDeletedEvent(tagID, kb.id, def.Name().String()))
+	return nil
+}
+
+// GetTagDefinition 获取指定标签定义
+func (kb *KnowledgeBase) GetTagDefinition(tagID valueobject.TagID) (*TagDefinition, error) {
+	def := kb.findTagDefinition(tagID)
+	if def == nil {
+		return nil, domain.ErrTagNotFound
+	}
+	return def, nil
+}
+
+// LookupTag 按规范名称或别名查找标签定义，未定义时返回 nil
+func (kb *KnowledgeBase) LookupTag(tag valueobject.Tag) *TagDefinition {
+	return kb.findTagByName(tag)
+}
+
+// findTagDefinition 按ID查找标签定义（内部方法），未找到返回 nil
+func (kb *KnowledgeBase) findTagDefinition(tagID valueobject.TagID) *TagDefinition {
+	for _, t := range kb.tags {
+		if t.ID() == tagID {
+			return t
+		}
+	}
+	return nil
+}
+
+// findTagByName 按规范名称或别名查找标签定义（内部方法），未找到返回 nil
+func (kb *KnowledgeBase) findTagByName(tag valueobject.Tag) *TagDefinition {
+	for _, t := range kb.tags {
+		if t.Matches(tag) {
+			return t
+		}
+	}
+	return nil
+}
+
+// canonicalTag 将别名解析为规范名称，未定义的标签原样返回
+func (kb *KnowledgeBase) canonicalTag(tag valueobject.Tag) valueobject.Tag {
+	if def := kb.findTagByName(tag); def != nil {
+		return def.Name()
+	}
+	return tag
+}
+
+// normalizeTags 规范化文档标签：统一格式、别名替换为规范名称并去重
+func (kb *KnowledgeBase) normalizeTags(raw []string) ([]string, error) {
+	tags, err := valueobject.NewTags(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]valueobject.Tag, 0, len(tags))
+	seen := make(map[valueobject.Tag]bool, len(tags))
+	for _, tag := range tags {
+		tag = kb.canonicalTag(tag)
+		if !seen[tag] {
+			seen[tag] = true
+			result = append(result, tag)
+		}
+	}
+	return valueobject.TagStrings(result), nil
+}
+
+// validateAliases 规范化别名并检查冲突
+// 与规范名称相同的别名被忽略，已被其他标签定义占用的别名返回 ErrTagAlreadyExists
+func (kb *KnowledgeBase) validateAliases(name valueobject.Tag, aliases []string, excludeID valueobject.TagID) ([]valueobject.Tag, error) {
+	tags, err := valueobject.NewTags(aliases)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]valueobject.Tag, 0, len(tags))
+	for _, alias := range tags {
+		if alias == name {
+			continue
+		}
+		if def := kb.findTagByName(alias); def != nil && def.ID() != excludeID {
+			return nil, domain.ErrTagAlreadyExists
+		}
+		result = append(result, alias)
+	}
+	return result, nil
+}
+
+// isTagDescendantOrSelf 判断 tagID 是否为 ancestorID 自身或其子孙标签
+// 沿父链向上查找，访问过的节点超过标签总数时视为已存在环
+func (kb *KnowledgeBase) isTagDescendantOrSelf(tagID, ancestorID valueobject.TagID) bool {
+	current := &tagID
+	for steps := 0; current != nil && steps <= len(kb.tags); steps++ {
+		if *current == ancestorID {
+			return true
+		}
+		def := kb.findTagDefinition(*current)
+		if def == nil {
+			return false
+		}
+		current = def.ParentID()
+	}
+	return current != nil
+}
+
+// hasDocumentTagged 判断知识库中是否有文档使用了指定标签
+func (kb *KnowledgeBase) hasDocumentTagged(tag valueobject.Tag) bool {
+	for _, doc := range kb.documents {
+		for _, t := range doc.tags {
+			if storedTag(t) == tag {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// retagDocuments 按映射改写文档标签并去重，返回标签发生变化的文档
+func (kb *KnowledgeBase) retagDocuments(mapping map[valueobject.Tag]valueobject.Tag) []*Document {
+	changed := make([]*Document, 0)
+	if len(mapping) == 0 {
+		return changed
+	}
+
+	for _, doc := range kb.documents {
+		tags := make([]string, 0, len(doc.tags))
+		seen := make(map[string]bool, len(doc.tags))
+		modified := false
+		for _, t := range doc.tags {
+			if replacement, ok := mapping[storedTag(t)]; ok {
+				t = replacement.String()
+				modified = true
+			}
+			if seen[t] {
+				modified = true
+				continue
+			}
+			seen[t] = true
+			tags = append(tags, t)
+		}
+		if modified {
+			doc.UpdateTags(tags)
+			changed = append(changed, doc)
+		}
+	}
+	return changed
+}
+
+// storedTag 将文档上保存的标签转换为标签值对象
+// 引入标签规范化之前写入的标签可能未规范化，这里按规范化后的值比较
+func storedTag(s string) valueobject.Tag {
+	if tag, err := valueobject.NewTag(s); err == nil {
+		return tag
+	}
+	return valueobject.MustTag(s)
+}
+
+// aliasMapping 构造别名到规范名称的映射
+func aliasMapping(aliases []valueobject.Tag, name valueobject.Tag) map[valueobject.Tag]valueobject.Tag {
+	mapping := make(map[valueobject.Tag]valueobject.Tag, len(aliases))
+	for _, alias := range aliases {
+		mapping[alias] = name
+	}
+	return mapping
+}
+
+// documentIDStrings 提取文档ID字符串列表
+func documentIDStrings(docs []*Document) []string {
+	ids := make([]string, len(docs))
+	for i, doc := range docs {
+		ids[i] = doc.ID().String()
+	}
+	return ids
+}
+
+// ==================== 附件 ====================
+
+// AddAttachment 为文档添加附件
+// 同一文档下已存在相同内容的附件时直接返回已有附件，不重复添加
+// 会收集 AttachmentAddedEvent 事件
+func (kb *KnowledgeBase) AddAttachment(
+	docID valueobject.DocumentID,
+	fileName, mediaType string,
+	size int64,
+	contentHash valueobject.ContentHash,
+) (*Attachment, error) {
+	if err := kb.ensureActive(); err != nil {
+		return nil, err
+	}
+	if _, err := kb.GetDocument(docID); err != nil {
+		return nil, err
+	}
+
+	for _, att := range kb.attachments {
+		if att.DocumentID() == docID && att.ContentHash() == contentHash {
+			return att, nil
+		}
+	}
+
+	att, err := NewAttachment(kb.id, docID, fileName, mediaType, size, contentHash)
+	if err != nil {
+		return nil, err
+	}
+	kb.attachments = append(kb.attachments, att)
+	kb.updatedAt = time.Now()
+
+	// 收集附件上传事件
+	kb.addEvent(event.NewAttachmentAddedEvent(
+		att.ID(), kb.id, docID, att.FileName(), att.MediaType(), att.Size(), att.ContentHash().String(),
+	))
+
+	return att, nil
+}
+
+// AdoptAttachment 将其他知识库中的附件复制到当前知识库的文档下
+// 用于合并知识库时随文档一起迁移附件，二进制内容按哈希共享，无需复制
+func (kb *KnowledgeBase) AdoptAttachment(docID valueobject.DocumentID, src *Attachment) (*Attachment, error) {
+	return kb.AddAttachment(docID, src.FileName(), src.MediaType(), src.Size(), src.ContentHash())
+}
+
+// RemoveAttachment 删除附件
+// 附件的二进制内容在不再被任何附件引用时由应用层清理
+// 会收集 AttachmentRemovedEvent 事件
+func (kb *KnowledgeBase) RemoveAttachment(attID valueobject.AttachmentID) (*Attachment, error) {
+	if err := kb.ensureActive(); err != nil {
+		return nil, err
+	}
+	for i, att := range kb.attachments {
+		if att.ID() == attID {
+			kb.attachments = append(kb.attachments[:i], kb.attachments[i+1:]...)
+			kb.updatedAt = time.Now()
+
+			// 收集附件删除事件
+			kb.addEvent(event.NewAttachmentRemovedEvent(attID, kb.id, att.DocumentID(), att.FileName()))
+
+			return att, nil
+		}
+	}
+	return nil, domain.ErrAttachmentNotFound
+}
+
+// GetAttachment 获取指定附件
+// 所属文档在回收站中时，附件同样不可访问
+func (kb *KnowledgeBase) GetAttachment(attID valueobject.AttachmentID) (*Attachment, error) {
+	for _, att := range kb.attachments {
+		if att.ID() == attID {
+			if _, err := kb.GetDocument(att.DocumentID()); err != nil {
+				return nil, domain.ErrAttachmentNotFound
+			}
+			return att, nil
+		}
+	}
+	return nil, domain.ErrAttachmentNotFound
+}
+
+// DocumentAttachments 获取文档的所有附件
+func (kb *KnowledgeBase) DocumentAttachments(docID valueobject.DocumentID) []*Attachment {
+	result := make([]*Attachment, 0)
+	for _, att := range kb.attachments {
+		if att.DocumentID() == docID {
+			result = append(result, att)
+		}
+	}
+	return result
+}
+
+// ==================== 生命周期状态 ====================
+
+// MakeReadOnly 将知识库设为只读
+// 会收集 KnowledgeBaseStatusChangedEvent 事件
+func (kb *KnowledgeBase) MakeReadOnly() error {
+	return kb.changeStatus(valueobject.KnowledgeBaseStatusReadOnly)
+}
+
+// Archive 归档知识库
+// 会收集 KnowledgeBaseStatusChangedEvent 事件
+func (kb *KnowledgeBase) Archive() error {
+	return kb.changeStatus(valueobject.KnowledgeBaseStatusArchived)
+}
+
+// Activate 将知识库恢复为正常可写状态（取消只读或取消归档）
+// 会收集 KnowledgeBaseStatusChangedEvent 事件
+func (kb *KnowledgeBase) Activate() error {
+	return kb.changeStatus(valueobject.KnowledgeBaseStatusActive)
+}
+
+// ChangeStatus 将知识库转换到指定状态
+// 根据目标状态分派到对应的业务方法
+func (kb *KnowledgeBase) ChangeStatus(target valueobject.KnowledgeBaseStatus) error {
+	switch target {
+	case valueobject.KnowledgeBaseStatusActive:
+		return kb.Activate()
+	case valueobject.KnowledgeBaseStatusReadOnly:
+		return kb.MakeReadOnly()
+	case valueobject.KnowledgeBaseStatusArchived:
+		return kb.Archive()
+	default:
+		return valueobject.ErrInvalidKnowledgeBaseStatus
+	}
+}
+
+// changeStatus 状态转换（内部方法），校验转换规则
+func (kb *KnowledgeBase) changeStatus(target valueobject.KnowledgeBaseStatus) error {
+	if !kb.status.CanTransitionTo(target) {
+		return domain.ErrInvalidStatusTransition
+	}
+
+	oldStatus := kb.status
+	kb.status = target
+	kb.updatedAt = time.Now()
+
+	// 收集状态变更事件
+	kb.addEvent(event.NewKnowledgeBaseStatusChangedEvent(kb.id, oldStatus, target))
+
+	return nil
+}
+
+// ApplyQuota 设置所属租户的配额策略、当前用量和知识库回收站中的文档数
+// 之后添加、更新和恢复文档时校验单个知识库的文档数量和租户的内容总字节数，未设置时不限制。
+// 与租户用量一致，回收站中的文档在被彻底清除之前仍然计入单个知识库的文档数量
+func (kb *KnowledgeBase) ApplyQuota(policy valueobject.QuotaPolicy, usage valueobject.QuotaUsage, trashedDocuments int) {
+	kb.quotaPolicy = policy
+	kb.quotaUsage = usage
+	kb.quotaTrashed = trashedDocuments
+}
+
+// checkQuota 校验新增 documents 篇文档、contentBytes 字节内容后是否超出配额
+func (kb *KnowledgeBase) checkQuota(documents int, contentBytes int64) error {
+	if documents > 0 && !kb.quotaPolicy.AllowsDocuments(len(kb.documents)+kb.quotaTrashed+documents) {
+		return fmt.Errorf("%w: a knowledge base can hold at most %d documents",
+			domain.ErrQuotaExceeded, kb.quotaPolicy.MaxDocumentsPerKnowledgeBase)
+	}
+	if !kb.quotaPolicy.AllowsContentBytes(kb.quotaUsage, contentBytes) {
+		return fmt.Errorf("%w: total document content is limited to %d bytes",
+			domain.ErrQuotaExceeded, kb.quotaPolicy.MaxContentBytes)
+	}
+	return nil
+}
+
+// ensureWithinQuota 校验知识库的文档数量和租户的内容总字节数当前没有超出配额
+func (kb *KnowledgeBase) ensureWithinQuota() error {
+	if !kb.quotaPolicy.AllowsDocuments(len(kb.documents) + kb.quotaTrashed) {
+		return fmt.Errorf("%w: a knowledge base can hold at most %d documents",
+			domain.ErrQuotaExceeded, kb.quotaPolicy.MaxDocumentsPerKnowledgeBase)
+	}
+	if kb.quotaPolicy.MaxContentBytes > 0 && kb.quotaUsage.ContentBytes > kb.quotaPolicy.MaxContentBytes {
+		return fmt.Errorf("%w: total document content is limited to %d bytes",
+			domain.ErrQuotaExceeded, kb.quotaPolicy.MaxContentBytes)
+	}
+	return nil
+}
+
+// useQuota 记录已占用的配额，同一次操作中后续添加的文档按最新用量校验
+func (kb *KnowledgeBase) useQuota(delta valueobject.QuotaUsage) {
+	kb.quotaUsage = kb.quotaUsage.Add(delta)
+	kb.quotaUsed = kb.quotaUsed.Add(delta)
+}
+
+// PullQuotaUsed 取出本次操作新占用的用量并清空
+// 由应用层在同一事务中计入租户用量
+func (kb *KnowledgeBase) PullQuotaUsed() valueobject.QuotaUsage {
+	used := kb.quotaUsed
+	kb.quotaUsed = valueobject.QuotaUsage{}
+	return used
+}
+
+// ensureActive 确保知识库处于可写状态
+// 只读和已归档的知识库不允许修改信息和文档
+func (kb *KnowledgeBase) ensureActive() error {
+	if !kb.IsActive() {
+		return domain.ErrKnowledgeBaseNotActive
+	}
+	return nil
+}
+
+// GetDocument 获取指定文档
+func (kb *KnowledgeBase) GetDocument(docID valueobject.DocumentID) (*Document, error) {
+	for _, doc := range kb.documents {
+		if doc.ID() == docID {
+			return doc, nil
+		}
+	}
+	return nil, domain.ErrDocumentNotFound
+}
+
+// hasDocumentWithContent 判断知识库中是否已有内容完全相同的文档（忽略空白差异）
+// 空内容不视为重复；已有文档的哈希由文档缓存，批量添加时不会反复计算
+func (kb *KnowledgeBase) hasDocumentWithContent(content string) bool {
+	hash := valueobject.NormalizedContentHash(content)
+	if hash == valueobject.NormalizedContentHash("") {
+		return false
+	}
+	for _, doc := range kb.documents {
+		if doc.NormalizedContentHash() == hash {
+			return true
+		}
+	}
+	return false
 }
 
 // DocumentCount 获取文档数量
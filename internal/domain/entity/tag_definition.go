@@ -0,0 +1,166 @@
+package entity
+
+import (
+	"time"
+
+	"gozero-ddd/internal/domain/valueobject"
+)
+
+// TagDefinition 标签定义实体
+// TagDefinition 属于 KnowledgeBase 聚合，不是聚合根
+// 知识库的标签注册表由若干标签定义组成：每个定义有一个规范名称、若干别名（同义词），
+// 并通过 parentID 组成层级结构（如 golang -> programming）
+// 文档上的标签总是保存规范名称，别名在写入时被替换为规范名称
+type TagDefinition struct {
+	id              valueobject.TagID           // 唯一标识
+	knowledgeBaseID valueobject.KnowledgeBaseID // 所属知识库ID
+	name            valueobject.Tag             // 规范名称
+	aliases         []valueobject.Tag           // 别名（同义词）
+	parentID        *valueobject.TagID          // 父标签ID（nil 表示顶层标签）
+	createdAt       time.Time                   // 创建时间
+	updatedAt       time.Time                   // 更新时间
+}
+
+// NewTagDefinition 创建新的标签定义
+func NewTagDefinition(
+	kbID valueobject.KnowledgeBaseID,
+	name valueobject.Tag,
+	parentID *valueobject.TagID,
+	aliases []valueobject.Tag,
+) *TagDefinition {
+	if aliases == nil {
+		aliases = make([]valueobject.Tag, 0)
+	}
+
+	now := time.Now()
+	return &TagDefinition{
+		id:              valueobject.NewTagID(),
+		knowledgeBaseID: kbID,
+		name:            name,
+		aliases:         aliases,
+		parentID:        parentID,
+		createdAt:       now,
+		updatedAt:       now,
+	}
+}
+
+// ReconstructTagDefinition 从持久化数据重建标签定义实体
+func ReconstructTagDefinition(
+	id valueobject.TagID,
+	kbID valueobject.KnowledgeBaseID,
+	name valueobject.Tag,
+	aliases []valueobject.Tag,
+	parentID *valueobject.TagID,
+	createdAt, updatedAt time.Time,
+) *TagDefinition {
+	if aliases == nil {
+		aliases = make([]valueobject.Tag, 0)
+	}
+	return &TagDefinition{
+		id:              id,
+		knowledgeBaseID: kbID,
+		name:            name,
+		aliases:         aliases,
+		parentID:        parentID,
+		createdAt:       createdAt,
+		updatedAt:       updatedAt,
+	}
+}
+
+// ID 获取标签定义ID
+func (t *TagDefinition) ID() valueobject.TagID {
+	return t.id
+}
+
+// KnowledgeBaseID 获取所属知识库ID
+func (t *TagDefinition) KnowledgeBaseID() valueobject.KnowledgeBaseID {
+	return t.knowledgeBaseID
+}
+
+// Name 获取规范名称
+func (t *TagDefinition) Name() valueobject.Tag {
+	return t.name
+}
+
+// Aliases 获取别名列表（返回副本）
+func (t *TagDefinition) Aliases() []valueobject.Tag {
+	result := make([]valueobject.Tag, len(t.aliases))
+	copy(result, t.aliases)
+	return result
+}
+
+// ParentID 获取父标签ID（nil 表示顶层标签）
+func (t *TagDefinition) ParentID() *valueobject.TagID {
+	return t.parentID
+}
+
+// CreatedAt 获取创建时间
+func (t *TagDefinition) CreatedAt() time.Time {
+	return t.createdAt
+}
+
+// UpdatedAt 获取更新时间
+func (t *TagDefinition) UpdatedAt() time.Time {
+	return t.updatedAt
+}
+
+// Matches 判断标签是否为该定义的规范名称或别名
+func (t *TagDefinition) Matches(tag valueobject.Tag) bool {
+	if t.name == tag {
+		return true
+	}
+	for _, alias := range t.aliases {
+		if alias == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// rename 修改规范名称（仅供聚合根调用）
+func (t *TagDefinition) rename(name valueobject.Tag) {
+	t.name = name
+	t.aliases = removeTag(t.aliases, name)
+	t.updatedAt = time.Now()
+}
+
+// setAliases 替换别名列表（仅供聚合根调用）
+func (t *TagDefinition) setAliases(aliases []valueobject.Tag) {
+	t.aliases = aliases
+	t.updatedAt = time.Now()
+}
+
+// addAliases 追加别名，忽略已存在的别名和规范名称（仅供聚合根调用）
+func (t *TagDefinition) addAliases(aliases ...valueobject.Tag) {
+	for _, alias := range aliases {
+		if !t.Matches(alias) {
+			t.aliases = append(t.aliases, alias)
+		}
+	}
+	t.updatedAt = time.Now()
+}
+
+// setParent 设置父标签（仅供聚合根调用）
+func (t *TagDefinition) setParent(parentID *valueobject.TagID) {
+	t.parentID = parentID
+	t.updatedAt = time.Now()
+}
+
+// removeTag 从标签列表中移除指定标签
+func removeTag(tags []valueobject.Tag, target valueobject.Tag) []valueobject.Tag {
+	result := make([]valueobject.Tag, 0, len(tags))
+	for _, tag := range tags {
+		if tag != target {
+			result = append(result, tag)
+		}
+	}
+	return result
+}
+
+// tagIDString 将标签ID指针转换为字符串（顶层标签为空字符串）
+func tagIDString(id *valueobject.TagID) string {
+	if id == nil {
+		return ""
+	}
+	return id.String()
+}
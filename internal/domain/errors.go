@@ -10,11 +10,102 @@ var (
 	ErrKnowledgeBaseNotFound   = errors.New("knowledge base not found")
 	ErrKnowledgeBaseNameExists = errors.New("knowledge base name already exists")
 	ErrKnowledgeBaseNameEmpty  = errors.New("knowledge base name cannot be empty")
+	ErrKnowledgeBaseNotActive  = errors.New("knowledge base is read-only or archived")
+	ErrKnowledgeBaseIDExists   = errors.New("knowledge base with the same id already exists")
+	ErrInvalidStatusTransition = errors.New("invalid knowledge base status transition")
 
 	// 文档相关错误
 	ErrDocumentNotFound     = errors.New("document not found")
 	ErrDocumentTitleEmpty   = errors.New("document title cannot be empty")
 	ErrDocumentContentEmpty = errors.New("document content cannot be empty")
+	ErrDuplicateDocument    = errors.New("a document with identical content already exists in knowledge base")
+	ErrDocumentIDExists     = errors.New("document with the same id already exists")
+
+	// 文档发布流程相关错误
+	ErrInvalidDocumentStatusTransition = errors.New("invalid document status transition")
+	ErrReviewCommentEmpty              = errors.New("review comment cannot be empty when rejecting")
+	ErrInvalidWorkflowAction           = errors.New("invalid document workflow action")
+	ErrDocumentUnderReview             = errors.New("document is in review or approved and cannot be edited, reject it first")
+
+	// 文档定时发布相关错误
+	ErrInvalidDocumentSchedule = errors.New("document expire_at must be after publish_at")
+	ErrDocumentNotDue          = errors.New("document is not due for scheduled transition")
+
+	// 文件夹相关错误
+	ErrFolderNotFound   = errors.New("folder not found")
+	ErrFolderNameEmpty  = errors.New("folder name cannot be empty")
+	ErrFolderNameExists = errors.New("folder with the same name already exists in parent folder")
+	ErrFolderCycle      = errors.New("folder cannot be moved into itself or its descendants")
+
+	// 标签相关错误
+	ErrTagNotFound      = errors.New("tag not found")
+	ErrTagAlreadyExists = errors.New("tag or alias is already registered in knowledge base")
+	ErrTagCycle         = errors.New("tag cannot be a child of itself or its descendants")
+	ErrTagMergeIntoSelf = errors.New("cannot merge tag into itself")
+
+	// 附件相关错误
+	ErrAttachmentNotFound        = errors.New("attachment not found")
+	ErrAttachmentEmpty           = errors.New("attachment content cannot be empty")
+	ErrAttachmentNameEmpty       = errors.New("attachment file name cannot be empty")
+	ErrAttachmentTooLarge        = errors.New("attachment exceeds the maximum allowed size")
+	ErrUnsupportedAttachmentType = errors.New("unsupported attachment type, only images and PDF are allowed")
+	ErrBlobNotFound              = errors.New("blob not found")
+
+	// 导入相关错误
+	ErrInvalidImportArchive       = errors.New("import archive is not a valid zip file")
+	ErrImportTooManyFiles         = errors.New("import archive contains too many files")
+	ErrInvalidImportDirectoryMode = errors.New("invalid import directory mode, must be folder, tag or none")
+
+	// 导出与备份相关错误
+	ErrInvalidExportFormat      = errors.New("invalid export format, must be markdown, jsonl or backup")
+	ErrInvalidBackup            = errors.New("invalid backup bundle")
+	ErrUnsupportedBackupVersion = errors.New("unsupported backup bundle version")
+
+	// 重复检测相关错误
+	ErrInvalidDuplicateDistance = errors.New("invalid max distance for near-duplicate detection, must be between 0 and 16")
+
+	// 批量操作相关错误
+	ErrBatchEmpty            = errors.New("batch must contain at least one operation")
+	ErrBatchTooLarge         = errors.New("batch contains too many operations")
+	ErrInvalidBatchMode      = errors.New("invalid batch mode, must be atomic or best_effort")
+	ErrInvalidBatchOperation = errors.New("invalid batch operation, must be add, update or remove")
+
+	// 幂等键相关错误
+	ErrInvalidIdempotencyKey       = errors.New("invalid idempotency key, must be 1-255 printable ASCII characters")
+	ErrIdempotencyKeyReused        = errors.New("idempotency key was already used with a different request")
+	ErrIdempotentRequestInProgress = errors.New("a request with the same idempotency key is still being processed")
+
+	// 认证相关错误
+	ErrUnauthenticated    = errors.New("authentication required")
+	ErrInvalidAccessToken = errors.New("invalid access token")
+
+	// 权限相关错误
+	ErrPermissionDenied       = errors.New("permission denied")
+	ErrMemberNotFound         = errors.New("knowledge base member not found")
+	ErrMemberSubjectEmpty     = errors.New("member subject cannot be empty")
+	ErrLastKnowledgeBaseOwner = errors.New("knowledge base must keep at least one owner")
+
+	// API Key 相关错误
+	ErrInvalidAPIKey        = errors.New("invalid api key")
+	ErrAPIKeyNotFound       = errors.New("api key not found")
+	ErrAPIKeyNameEmpty      = errors.New("api key name cannot be empty")
+	ErrAPIKeyScopesEmpty    = errors.New("api key must have at least one scope")
+	ErrAPIKeyExpiryInPast   = errors.New("api key expires_at must be in the future")
+	ErrAPIKeyAlreadyRevoked = errors.New("api key is already revoked")
+
+	// 租户相关错误
+	ErrInvalidTenantID = errors.New("invalid tenant id, must be 1-64 lowercase letters, digits, '_' or '-'")
+	ErrTenantMismatch  = errors.New("requested tenant does not match the caller's tenant")
+
+	// 配额相关错误
+	ErrQuotaExceeded = errors.New("tenant quota exceeded")
+
+	// 限流相关错误
+	ErrRateLimitExceeded = errors.New("rate limit exceeded, retry later")
+
+	// 审计日志相关错误
+	ErrInvalidAuditLogCursor    = errors.New("invalid audit log cursor")
+	ErrInvalidAuditLogTimeRange = errors.New("audit log until must be after since")
 
 	// 操作相关错误
 	ErrCannotMergeSameKnowledgeBase = errors.New("cannot merge knowledge base with itself")
@@ -62,19 +153,84 @@ func IsDomainError(err error) bool {
 // IsNotFoundError 判断是否为"未找到"类型的错误
 func IsNotFoundError(err error) bool {
 	return errors.Is(err, ErrKnowledgeBaseNotFound) ||
-		errors.Is(err, ErrDocumentNotFound)
+		errors.Is(err, ErrDocumentNotFound) ||
+		errors.Is(err, ErrFolderNotFound) ||
+		errors.Is(err, ErrTagNotFound) ||
+		errors.Is(err, ErrAttachmentNotFound) ||
+		errors.Is(err, ErrBlobNotFound) ||
+		errors.Is(err, ErrMemberNotFound) ||
+		errors.Is(err, ErrAPIKeyNotFound)
 }
 
 // IsValidationError 判断是否为验证错误
 func IsValidationError(err error) bool {
 	return errors.Is(err, ErrKnowledgeBaseNameEmpty) ||
 		errors.Is(err, ErrDocumentTitleEmpty) ||
-		errors.Is(err, ErrDocumentContentEmpty)
+		errors.Is(err, ErrDocumentContentEmpty) ||
+		errors.Is(err, ErrReviewCommentEmpty) ||
+		errors.Is(err, ErrInvalidWorkflowAction) ||
+		errors.Is(err, ErrInvalidDocumentSchedule) ||
+		errors.Is(err, ErrFolderNameEmpty) ||
+		errors.Is(err, ErrTagMergeIntoSelf) ||
+		errors.Is(err, ErrAttachmentEmpty) ||
+		errors.Is(err, ErrAttachmentNameEmpty) ||
+		errors.Is(err, ErrInvalidImportArchive) ||
+		errors.Is(err, ErrImportTooManyFiles) ||
+		errors.Is(err, ErrInvalidImportDirectoryMode) ||
+		errors.Is(err, ErrInvalidExportFormat) ||
+		errors.Is(err, ErrInvalidDuplicateDistance) ||
+		errors.Is(err, ErrInvalidBackup) ||
+		errors.Is(err, ErrUnsupportedBackupVersion) ||
+		errors.Is(err, ErrBatchEmpty) ||
+		errors.Is(err, ErrBatchTooLarge) ||
+		errors.Is(err, ErrInvalidBatchMode) ||
+		errors.Is(err, ErrInvalidBatchOperation) ||
+		errors.Is(err, ErrInvalidIdempotencyKey) ||
+		errors.Is(err, ErrMemberSubjectEmpty) ||
+		errors.Is(err, ErrAPIKeyNameEmpty) ||
+		errors.Is(err, ErrAPIKeyScopesEmpty) ||
+		errors.Is(err, ErrAPIKeyExpiryInPast) ||
+		errors.Is(err, ErrInvalidTenantID) ||
+		errors.Is(err, ErrInvalidAuditLogCursor) ||
+		errors.Is(err, ErrInvalidAuditLogTimeRange)
+}
+
+// IsPreconditionError 判断是否为前置条件不满足的错误
+// 例如：对只读或已归档的知识库执行写操作
+func IsPreconditionError(err error) bool {
+	return errors.Is(err, ErrKnowledgeBaseNotActive) ||
+		errors.Is(err, ErrInvalidStatusTransition) ||
+		errors.Is(err, ErrInvalidDocumentStatusTransition) ||
+		errors.Is(err, ErrDocumentUnderReview) ||
+		errors.Is(err, ErrDocumentNotDue) ||
+		errors.Is(err, ErrFolderCycle) ||
+		errors.Is(err, ErrTagCycle) ||
+		errors.Is(err, ErrLastKnowledgeBaseOwner) ||
+		errors.Is(err, ErrAPIKeyAlreadyRevoked)
 }
 
 // IsConflictError 判断是否为冲突错误
 func IsConflictError(err error) bool {
 	return errors.Is(err, ErrKnowledgeBaseNameExists) ||
-		errors.Is(err, ErrCannotMergeSameKnowledgeBase)
+		errors.Is(err, ErrKnowledgeBaseIDExists) ||
+		errors.Is(err, ErrDocumentIDExists) ||
+		errors.Is(err, ErrCannotMergeSameKnowledgeBase) ||
+		errors.Is(err, ErrFolderNameExists) ||
+		errors.Is(err, ErrTagAlreadyExists) ||
+		errors.Is(err, ErrIdempotentRequestInProgress)
+}
+
+
+// IsUnauthenticatedError 判断是否为认证失败的错误
+// 例如：未携带访问令牌，或令牌、API Key 无效、已过期
+func IsUnauthenticatedError(err error) bool {
+	return errors.Is(err, ErrUnauthenticated) ||
+		errors.Is(err, ErrInvalidAccessToken) ||
+		errors.Is(err, ErrInvalidAPIKey)
 }
 
+// IsForbiddenError 判断是否为权限不足的错误
+func IsForbiddenError(err error) bool {
+	return errors.Is(err, ErrPermissionDenied) ||
+		errors.Is(err, ErrTenantMismatch)
+}
@@ -19,6 +19,7 @@ type DomainEvent interface {
 	EventName() string  // 事件名称
 	OccurredAt() time.Time // 发生时间
 	AggregateID() string   // 聚合根ID
+	Actor() string         // 操作者标识，后台任务等系统操作为空
 }
 
 // BaseEvent 基础事件
@@ -27,6 +28,7 @@ type BaseEvent struct {
 	eventID     string
 	occurredAt  time.Time
 	aggregateID string
+	actor       string
 }
 
 // NewBaseEvent 创建基础事件
@@ -50,6 +52,33 @@ func (e BaseEvent) AggregateID() string {
 	return e.aggregateID
 }
 
+func (e BaseEvent) Actor() string {
+	return e.actor
+}
+
+func (e *BaseEvent) setActor(actor string) {
+	e.actor = actor
+}
+
+// restoreBase 从反序列化前的事件恢复基础字段（事件ID、发生时间、聚合根ID、操作者）
+func (e *BaseEvent) restoreBase(src DomainEvent) {
+	e.eventID = src.EventID()
+	e.occurredAt = src.OccurredAt()
+	e.aggregateID = src.AggregateID()
+	e.actor = src.Actor()
+}
+
+// SetActor 记录事件的操作者
+// 聚合根产生事件时不知道操作者，由应用层在发布前根据请求上下文补充；已记录操作者的事件不会被覆盖
+func SetActor(evt DomainEvent, actor string) {
+	if actor == "" || evt.Actor() != "" {
+		return
+	}
+	if e, ok := evt.(interface{ setActor(string) }); ok {
+		e.setActor(actor)
+	}
+}
+
 // ==================== 知识库相关事件 ====================
 
 // KnowledgeBaseCreatedEvent 知识库创建事件
@@ -121,6 +150,194 @@ func (e *KnowledgeBaseDeletedEvent) EventName() string {
 	return "knowledge_base.deleted"
 }
 
+// KnowledgeBaseTrashedEvent 知识库移入回收站事件
+// 软删除知识库时触发，此时数据仍可恢复
+type KnowledgeBaseTrashedEvent struct {
+	BaseEvent
+	KnowledgeBaseID valueobject.KnowledgeBaseID
+	Name            string
+}
+
+func NewKnowledgeBaseTrashedEvent(id valueobject.KnowledgeBaseID, name string) *KnowledgeBaseTrashedEvent {
+	return &KnowledgeBaseTrashedEvent{
+		BaseEvent:       NewBaseEvent(id.String()),
+		KnowledgeBaseID: id,
+		Name:            name,
+	}
+}
+
+func (e *KnowledgeBaseTrashedEvent) EventName() string {
+	return "knowledge_base.trashed"
+}
+
+// KnowledgeBaseRestoredEvent 知识库从回收站恢复事件
+type KnowledgeBaseRestoredEvent struct {
+	BaseEvent
+	KnowledgeBaseID valueobject.KnowledgeBaseID
+	Name            string
+}
+
+func NewKnowledgeBaseRestoredEvent(id valueobject.KnowledgeBaseID, name string) *KnowledgeBaseRestoredEvent {
+	return &KnowledgeBaseRestoredEvent{
+		BaseEvent:       NewBaseEvent(id.String()),
+		KnowledgeBaseID: id,
+		Name:            name,
+	}
+}
+
+func (e *KnowledgeBaseRestoredEvent) EventName() string {
+	return "knowledge_base.restored"
+}
+
+// KnowledgeBaseImportedEvent 从备份包导入知识库事件
+// 导入保留原有的ID和时间戳，不会为其中的文档、文件夹和标签单独触发事件
+type KnowledgeBaseImportedEvent struct {
+	BaseEvent
+	KnowledgeBaseID valueobject.KnowledgeBaseID
+	Name            string
+	DocumentCount   int
+	ContentBytes    int64 // 导入文档的内容总字节数
+}
+
+func NewKnowledgeBaseImportedEvent(id valueobject.KnowledgeBaseID, name string, documentCount int) *KnowledgeBaseImportedEvent {
+	return &KnowledgeBaseImportedEvent{
+		BaseEvent:       NewBaseEvent(id.String()),
+		KnowledgeBaseID: id,
+		Name:            name,
+		DocumentCount:   documentCount,
+	}
+}
+
+func (e *KnowledgeBaseImportedEvent) EventName() string {
+	return "knowledge_base.imported"
+}
+
+// KnowledgeBasePurgedEvent 知识库彻底清除事件
+// 回收站中的知识库超过保留期被物理删除时触发，之后数据不可恢复
+type KnowledgeBasePurgedEvent struct {
+	BaseEvent
+	KnowledgeBaseID valueobject.KnowledgeBaseID
+	Name            string
+	DocumentCount   int   // 随知识库一起清除的文档数量
+	ContentBytes    int64 // 随知识库一起清除的文档内容总字节数
+}
+
+func NewKnowledgeBasePurgedEvent(id valueobject.KnowledgeBaseID, name string) *KnowledgeBasePurgedEvent {
+	return &KnowledgeBasePurgedEvent{
+		BaseEvent:       NewBaseEvent(id.String()),
+		KnowledgeBaseID: id,
+		Name:            name,
+	}
+}
+
+func (e *KnowledgeBasePurgedEvent) EventName() string {
+	return "knowledge_base.purged"
+}
+
+// KnowledgeBaseStatusChangedEvent 知识库状态变更事件
+// 知识库在 active / read_only / archived 之间转换时触发
+type KnowledgeBaseStatusChangedEvent struct {
+	BaseEvent
+	KnowledgeBaseID valueobject.KnowledgeBaseID
+	OldStatus       valueobject.KnowledgeBaseStatus
+	NewStatus       valueobject.KnowledgeBaseStatus
+}
+
+func NewKnowledgeBaseStatusChangedEvent(
+	id valueobject.KnowledgeBaseID,
+	oldStatus, newStatus valueobject.KnowledgeBaseStatus,
+) *KnowledgeBaseStatusChangedEvent {
+	return &KnowledgeBaseStatusChangedEvent{
+		BaseEvent:       NewBaseEvent(id.String()),
+		KnowledgeBaseID: id,
+		OldStatus:       oldStatus,
+		NewStatus:       newStatus,
+	}
+}
+
+func (e *KnowledgeBaseStatusChangedEvent) EventName() string {
+	return "knowledge_base.status_changed"
+}
+
+// KnowledgeBaseDuplicatePolicyChangedEvent 知识库重复文档策略变更事件
+type KnowledgeBaseDuplicatePolicyChangedEvent struct {
+	BaseEvent
+	KnowledgeBaseID valueobject.KnowledgeBaseID
+	OldPolicy       valueobject.DuplicatePolicy
+	NewPolicy       valueobject.DuplicatePolicy
+}
+
+func NewKnowledgeBaseDuplicatePolicyChangedEvent(
+	id valueobject.KnowledgeBaseID,
+	oldPolicy, newPolicy valueobject.DuplicatePolicy,
+) *KnowledgeBaseDuplicatePolicyChangedEvent {
+	return &KnowledgeBaseDuplicatePolicyChangedEvent{
+		BaseEvent:       NewBaseEvent(id.String()),
+		KnowledgeBaseID: id,
+		OldPolicy:       oldPolicy,
+		NewPolicy:       newPolicy,
+	}
+}
+
+func (e *KnowledgeBaseDuplicatePolicyChangedEvent) EventName() string {
+	return "knowledge_base.duplicate_policy_changed"
+}
+
+// ==================== 知识库成员相关事件 ====================
+
+// KnowledgeBaseMemberGrantedEvent 知识库成员授权事件
+// 调用方被加入知识库或角色被变更时触发
+type KnowledgeBaseMemberGrantedEvent struct {
+	BaseEvent
+	KnowledgeBaseID valueobject.KnowledgeBaseID
+	Subject         string
+	OldRole         valueobject.MemberRole // 新加入的成员为空
+	NewRole         valueobject.MemberRole
+}
+
+func NewKnowledgeBaseMemberGrantedEvent(
+	kbID valueobject.KnowledgeBaseID,
+	subject string,
+	oldRole, newRole valueobject.MemberRole,
+) *KnowledgeBaseMemberGrantedEvent {
+	return &KnowledgeBaseMemberGrantedEvent{
+		BaseEvent:       NewBaseEvent(kbID.String()),
+		KnowledgeBaseID: kbID,
+		Subject:         subject,
+		OldRole:         oldRole,
+		NewRole:         newRole,
+	}
+}
+
+func (e *KnowledgeBaseMemberGrantedEvent) EventName() string {
+	return "knowledge_base.member_granted"
+}
+
+// KnowledgeBaseMemberRevokedEvent 知识库成员移除事件
+type KnowledgeBaseMemberRevokedEvent struct {
+	BaseEvent
+	KnowledgeBaseID valueobject.KnowledgeBaseID
+	Subject         string
+	Role            valueobject.MemberRole // 移除前的角色
+}
+
+func NewKnowledgeBaseMemberRevokedEvent(
+	kbID valueobject.KnowledgeBaseID,
+	subject string,
+	role valueobject.MemberRole,
+) *KnowledgeBaseMemberRevokedEvent {
+	return &KnowledgeBaseMemberRevokedEvent{
+		BaseEvent:       NewBaseEvent(kbID.String()),
+		KnowledgeBaseID: kbID,
+		Subject:         subject,
+		Role:            role,
+	}
+}
+
+func (e *KnowledgeBaseMemberRevokedEvent) EventName() string {
+	return "knowledge_base.member_revoked"
+}
+
 // ==================== 文档相关事件 ====================
 
 // DocumentAddedEvent 文档添加事件
@@ -131,6 +348,7 @@ type DocumentAddedEvent struct {
 	KnowledgeBaseID valueobject.KnowledgeBaseID
 	Title           string
 	Tags            []string
+	ContentBytes    int64 // 文档内容字节数
 }
 
 func NewDocumentAddedEvent(docID valueobject.DocumentID, kbID valueobject.KnowledgeBaseID, title string) *DocumentAddedEvent {
@@ -147,7 +365,7 @@ func (e *DocumentAddedEvent) EventName() string {
 }
 
 // DocumentRemovedEvent 文档删除事件
-// 当文档从知识库中移除时触发
+// 当文档从知识库中移除（移入回收站）时触发
 type DocumentRemovedEvent struct {
 	BaseEvent
 	DocumentID      valueobject.DocumentID
@@ -174,6 +392,12 @@ type DocumentUpdatedEvent struct {
 	KnowledgeBaseID valueobject.KnowledgeBaseID
 	OldTitle        string
 	NewTitle        string
+	OldContentBytes int64 // 更新前的文档内容字节数
+	NewContentBytes int64 // 更新后的文档内容字节数
+
+	// 编辑已发布或已过期的文档会使其退回草稿，需要重新审核后才能发布
+	OldStatus valueobject.DocumentStatus // 更新前的发布状态
+	NewStatus valueobject.DocumentStatus // 更新后的发布状态
 }
 
 func NewDocumentUpdatedEvent(
@@ -194,3 +418,594 @@ func (e *DocumentUpdatedEvent) EventName() string {
 	return "document.updated"
 }
 
+// DocumentRestoredEvent 文档从回收站恢复事件
+type DocumentRestoredEvent struct {
+	BaseEvent
+	DocumentID      valueobject.DocumentID
+	KnowledgeBaseID valueobject.KnowledgeBaseID
+	Title           string
+}
+
+func NewDocumentRestoredEvent(docID valueobject.DocumentID, kbID valueobject.KnowledgeBaseID, title string) *DocumentRestoredEvent {
+	return &DocumentRestoredEvent{
+		BaseEvent:       NewBaseEvent(kbID.String()),
+		DocumentID:      docID,
+		KnowledgeBaseID: kbID,
+		Title:           title,
+	}
+}
+
+func (e *DocumentRestoredEvent) EventName() string {
+	return "document.restored"
+}
+
+// DocumentPurgedEvent 文档彻底清除事件
+// 回收站中的文档超过保留期被物理删除时触发
+type DocumentPurgedEvent struct {
+	BaseEvent
+	DocumentID      valueobject.DocumentID
+	KnowledgeBaseID valueobject.KnowledgeBaseID
+	Title           string
+	ContentBytes    int64 // 文档内容字节数
+}
+
+func NewDocumentPurgedEvent(docID valueobject.DocumentID, kbID valueobject.KnowledgeBaseID, title string) *DocumentPurgedEvent {
+	return &DocumentPurgedEvent{
+		BaseEvent:       NewBaseEvent(kbID.String()),
+		DocumentID:      docID,
+		KnowledgeBaseID: kbID,
+		Title:           title,
+	}
+}
+
+func (e *DocumentPurgedEvent) EventName() string {
+	return "document.purged"
+}
+
+// ==================== 文档发布流程事件 ====================
+
+// DocumentSubmittedEvent 文档提交审核事件
+type DocumentSubmittedEvent struct {
+	BaseEvent
+	DocumentID      valueobject.DocumentID
+	KnowledgeBaseID valueobject.KnowledgeBaseID
+	Title           string
+}
+
+func NewDocumentSubmittedEvent(docID valueobject.DocumentID, kbID valueobject.KnowledgeBaseID, title string) *DocumentSubmittedEvent {
+	return &DocumentSubmittedEvent{
+		BaseEvent:       NewBaseEvent(kbID.String()),
+		DocumentID:      docID,
+		KnowledgeBaseID: kbID,
+		Title:           title,
+	}
+}
+
+func (e *DocumentSubmittedEvent) EventName() string {
+	return "document.submitted"
+}
+
+// DocumentApprovedEvent 文档审核通过事件
+type DocumentApprovedEvent struct {
+	BaseEvent
+	DocumentID      valueobject.DocumentID
+	KnowledgeBaseID valueobject.KnowledgeBaseID
+	Reviewer        string
+	Comment         string
+}
+
+func NewDocumentApprovedEvent(
+	docID valueobject.DocumentID,
+	kbID valueobject.KnowledgeBaseID,
+	reviewer, comment string,
+) *DocumentApprovedEvent {
+	return &DocumentApprovedEvent{
+		BaseEvent:       NewBaseEvent(kbID.String()),
+		DocumentID:      docID,
+		KnowledgeBaseID: kbID,
+		Reviewer:        reviewer,
+		Comment:         comment,
+	}
+}
+
+func (e *DocumentApprovedEvent) EventName() string {
+	return "document.approved"
+}
+
+// DocumentRejectedEvent 文档被驳回事件
+type DocumentRejectedEvent struct {
+	BaseEvent
+	DocumentID      valueobject.DocumentID
+	KnowledgeBaseID valueobject.KnowledgeBaseID
+	Reviewer        string
+	Comment         string
+}
+
+func NewDocumentRejectedEvent(
+	docID valueobject.DocumentID,
+	kbID valueobject.KnowledgeBaseID,
+	reviewer, comment string,
+) *DocumentRejectedEvent {
+	return &DocumentRejectedEvent{
+		BaseEvent:       NewBaseEvent(kbID.String()),
+		DocumentID:      docID,
+		KnowledgeBaseID: kbID,
+		Reviewer:        reviewer,
+		Comment:         comment,
+	}
+}
+
+func (e *DocumentRejectedEvent) EventName() string {
+	return "document.rejected"
+}
+
+// DocumentPublishedEvent 文档发布事件
+// 文档发布后才对 API 消费方可见
+type DocumentPublishedEvent struct {
+	BaseEvent
+	DocumentID      valueobject.DocumentID
+	KnowledgeBaseID valueobject.KnowledgeBaseID
+	Title           string
+	Scheduled       bool // 是否由定时任务自动发布
+}
+
+func NewDocumentPublishedEvent(docID valueobject.DocumentID, kbID valueobject.KnowledgeBaseID, title string) *DocumentPublishedEvent {
+	return &DocumentPublishedEvent{
+		BaseEvent:       NewBaseEvent(kbID.String()),
+		DocumentID:      docID,
+		KnowledgeBaseID: kbID,
+		Title:           title,
+	}
+}
+
+func (e *DocumentPublishedEvent) EventName() string {
+	return "document.published"
+}
+
+// DocumentUnpublishedEvent 文档撤回发布事件
+type DocumentUnpublishedEvent struct {
+	BaseEvent
+	DocumentID      valueobject.DocumentID
+	KnowledgeBaseID valueobject.KnowledgeBaseID
+	Title           string
+}
+
+func NewDocumentUnpublishedEvent(docID valueobject.DocumentID, kbID valueobject.KnowledgeBaseID, title string) *DocumentUnpublishedEvent {
+	return &DocumentUnpublishedEvent{
+		BaseEvent:       NewBaseEvent(kbID.String()),
+		DocumentID:      docID,
+		KnowledgeBaseID: kbID,
+		Title:           title,
+	}
+}
+
+func (e *DocumentUnpublishedEvent) EventName() string {
+	return "document.unpublished"
+}
+
+// DocumentScheduledEvent 文档定时设置变更事件
+type DocumentScheduledEvent struct {
+	BaseEvent
+	DocumentID      valueobject.DocumentID
+	KnowledgeBaseID valueobject.KnowledgeBaseID
+	PublishAt       *time.Time
+	ExpireAt        *time.Time
+}
+
+func NewDocumentScheduledEvent(docID valueobject.DocumentID, kbID valueobject.KnowledgeBaseID, publishAt, expireAt *time.Time) *DocumentScheduledEvent {
+	return &DocumentScheduledEvent{
+		BaseEvent:       NewBaseEvent(kbID.String()),
+		DocumentID:      docID,
+		KnowledgeBaseID: kbID,
+		PublishAt:       publishAt,
+		ExpireAt:        expireAt,
+	}
+}
+
+func (e *DocumentScheduledEvent) EventName() string {
+	return "document.scheduled"
+}
+
+// DocumentExpiredEvent 文档到期下线事件
+// 由定时任务在到达下线时间后触发
+type DocumentExpiredEvent struct {
+	BaseEvent
+	DocumentID      valueobject.DocumentID
+	KnowledgeBaseID valueobject.KnowledgeBaseID
+	Title           string
+	ExpiredAt       time.Time
+}
+
+func NewDocumentExpiredEvent(docID valueobject.DocumentID, kbID valueobject.KnowledgeBaseID, title string, expiredAt time.Time) *DocumentExpiredEvent {
+	return &DocumentExpiredEvent{
+		BaseEvent:       NewBaseEvent(kbID.String()),
+		DocumentID:      docID,
+		KnowledgeBaseID: kbID,
+		Title:           title,
+		ExpiredAt:       expiredAt,
+	}
+}
+
+func (e *DocumentExpiredEvent) EventName() string {
+	return "document.expired"
+}
+
+// ==================== 文件夹相关事件 ====================
+
+// FolderCreatedEvent 文件夹创建事件
+type FolderCreatedEvent struct {
+	BaseEvent
+	FolderID        valueobject.FolderID
+	KnowledgeBaseID valueobject.KnowledgeBaseID
+	ParentID        string // 父文件夹ID，根目录为空
+	Name            string
+}
+
+func NewFolderCreatedEvent(folderID valueobject.FolderID, kbID valueobject.KnowledgeBaseID, parentID, name string) *FolderCreatedEvent {
+	return &FolderCreatedEvent{
+		BaseEvent:       NewBaseEvent(kbID.String()),
+		FolderID:        folderID,
+		KnowledgeBaseID: kbID,
+		ParentID:        parentID,
+		Name:            name,
+	}
+}
+
+func (e *FolderCreatedEvent) EventName() string {
+	return "folder.created"
+}
+
+// FolderRenamedEvent 文件夹重命名事件
+type FolderRenamedEvent struct {
+	BaseEvent
+	FolderID        valueobject.FolderID
+	KnowledgeBaseID valueobject.KnowledgeBaseID
+	OldName         string
+	NewName         string
+}
+
+func NewFolderRenamedEvent(folderID valueobject.FolderID, kbID valueobject.KnowledgeBaseID, oldName, newName string) *FolderRenamedEvent {
+	return &FolderRenamedEvent{
+		BaseEvent:       NewBaseEvent(kbID.String()),
+		FolderID:        folderID,
+		KnowledgeBaseID: kbID,
+		OldName:         oldName,
+		NewName:         newName,
+	}
+}
+
+func (e *FolderRenamedEvent) EventName() string {
+	return "folder.renamed"
+}
+
+// FolderMovedEvent 文件夹移动事件
+type FolderMovedEvent struct {
+	BaseEvent
+	FolderID        valueobject.FolderID
+	KnowledgeBaseID valueobject.KnowledgeBaseID
+	OldParentID     string // 原父文件夹ID，根目录为空
+	NewParentID     string // 新父文件夹ID，根目录为空
+}
+
+func NewFolderMovedEvent(folderID valueobject.FolderID, kbID valueobject.KnowledgeBaseID, oldParentID, newParentID string) *FolderMovedEvent {
+	return &FolderMovedEvent{
+		BaseEvent:       NewBaseEvent(kbID.String()),
+		FolderID:        folderID,
+		KnowledgeBaseID: kbID,
+		OldParentID:     oldParentID,
+		NewParentID:     newParentID,
+	}
+}
+
+func (e *FolderMovedEvent) EventName() string {
+	return "folder.moved"
+}
+
+// FolderDeletedEvent 文件夹删除事件
+// 被删除文件夹中的子文件夹和文档会移动到其父文件夹
+type FolderDeletedEvent struct {
+	BaseEvent
+	FolderID        valueobject.FolderID
+	KnowledgeBaseID valueobject.KnowledgeBaseID
+	Name            string
+}
+
+func NewFolderDeletedEvent(folderID valueobject.FolderID, kbID valueobject.KnowledgeBaseID, name string) *FolderDeletedEvent {
+	return &FolderDeletedEvent{
+		BaseEvent:       NewBaseEvent(kbID.String()),
+		FolderID:        folderID,
+		KnowledgeBaseID: kbID,
+		Name:            name,
+	}
+}
+
+func (e *FolderDeletedEvent) EventName() string {
+	return "folder.deleted"
+}
+
+// DocumentMovedEvent 文档移动到其他文件夹事件
+type DocumentMovedEvent struct {
+	BaseEvent
+	DocumentID      valueobject.DocumentID
+	KnowledgeBaseID valueobject.KnowledgeBaseID
+	OldFolderID     string // 原文件夹ID，根目录为空
+	NewFolderID     string // 新文件夹ID，根目录为空
+}
+
+func NewDocumentMovedEvent(docID valueobject.DocumentID, kbID valueobject.KnowledgeBaseID, oldFolderID, newFolderID string) *DocumentMovedEvent {
+	return &DocumentMovedEvent{
+		BaseEvent:       NewBaseEvent(kbID.String()),
+		DocumentID:      docID,
+		KnowledgeBaseID: kbID,
+		OldFolderID:     oldFolderID,
+		NewFolderID:     newFolderID,
+	}
+}
+
+func (e *DocumentMovedEvent) EventName() string {
+	return "document.moved"
+}
+
+// ==================== 文档链接相关事件 ====================
+
+// DocumentLinkBrokenEvent 文档链接失效事件
+// 被引用的文档删除后，为每个引用它的文档触发，便于标记需要修复的文档
+type DocumentLinkBrokenEvent struct {
+	BaseEvent
+	SourceDocumentID      valueobject.DocumentID      // 包含失效链接的文档
+	SourceKnowledgeBaseID valueobject.KnowledgeBaseID // 包含失效链接的文档所属知识库
+	TargetDocumentID      valueobject.DocumentID      // 被删除的文档
+	TargetTitle           string                      // 被删除文档的标题
+	LinkType              string                      // 链接类型：wiki / url
+}
+
+func NewDocumentLinkBrokenEvent(
+	sourceDocID valueobject.DocumentID,
+	sourceKBID valueobject.KnowledgeBaseID,
+	targetDocID valueobject.DocumentID,
+	targetTitle, linkType string,
+) *DocumentLinkBrokenEvent {
+	return &DocumentLinkBrokenEvent{
+		BaseEvent:             NewBaseEvent(sourceKBID.String()),
+		SourceDocumentID:      sourceDocID,
+		SourceKnowledgeBaseID: sourceKBID,
+		TargetDocumentID:      targetDocID,
+		TargetTitle:           targetTitle,
+		LinkType:              linkType,
+	}
+}
+
+func (e *DocumentLinkBrokenEvent) EventName() string {
+	return "document.link_broken"
+}
+
+// ==================== 标签相关事件 ====================
+
+// TagDefinedEvent 标签定义事件
+type TagDefinedEvent struct {
+	BaseEvent
+	TagID           valueobject.TagID
+	KnowledgeBaseID valueobject.KnowledgeBaseID
+	Name            string
+	ParentID        string // 父标签ID，顶层标签为空
+	Aliases         []string
+}
+
+func NewTagDefinedEvent(tagID valueobject.TagID, kbID valueobject.KnowledgeBaseID, name, parentID string, aliases []string) *TagDefinedEvent {
+	return &TagDefinedEvent{
+		BaseEvent:       NewBaseEvent(kbID.String()),
+		TagID:           tagID,
+		KnowledgeBaseID: kbID,
+		Name:            name,
+		ParentID:        parentID,
+		Aliases:         aliases,
+	}
+}
+
+func (e *TagDefinedEvent) EventName() string {
+	return "tag.defined"
+}
+
+// TagUpdatedEvent 标签定义更新事件（父标签或别名变化）
+type TagUpdatedEvent struct {
+	BaseEvent
+	TagID           valueobject.TagID
+	KnowledgeBaseID valueobject.KnowledgeBaseID
+	Name            string
+	ParentID        string // 父标签ID，顶层标签为空
+	Aliases         []string
+}
+
+func NewTagUpdatedEvent(tagID valueobject.TagID, kbID valueobject.KnowledgeBaseID, name, parentID string, aliases []string) *TagUpdatedEvent {
+	return &TagUpdatedEvent{
+		BaseEvent:       NewBaseEvent(kbID.String()),
+		TagID:           tagID,
+		KnowledgeBaseID: kbID,
+		Name:            name,
+		ParentID:        parentID,
+		Aliases:         aliases,
+	}
+}
+
+func (e *TagUpdatedEvent) EventName() string {
+	return "tag.updated"
+}
+
+// TagRenamedEvent 标签重命名事件
+// DocumentIDs 为标签被改写的文档
+type TagRenamedEvent struct {
+	BaseEvent
+	KnowledgeBaseID valueobject.KnowledgeBaseID
+	OldName         string
+	NewName         string
+	DocumentIDs     []string
+}
+
+func NewTagRenamedEvent(kbID valueobject.KnowledgeBaseID, oldName, newName string, documentIDs []string) *TagRenamedEvent {
+	return &TagRenamedEvent{
+		BaseEvent:       NewBaseEvent(kbID.String()),
+		KnowledgeBaseID: kbID,
+		OldName:         oldName,
+		NewName:         newName,
+		DocumentIDs:     documentIDs,
+	}
+}
+
+func (e *TagRenamedEvent) EventName() string {
+	return "tag.renamed"
+}
+
+// TagsMergedEvent 标签合并事件
+// 源标签成为目标标签的别名，DocumentIDs 为标签被改写的文档
+type TagsMergedEvent struct {
+	BaseEvent
+	KnowledgeBaseID valueobject.KnowledgeBaseID
+	Sources         []string
+	Target          string
+	DocumentIDs     []string
+}
+
+func NewTagsMergedEvent(kbID valueobject.KnowledgeBaseID, sources []string, target string, documentIDs []string) *TagsMergedEvent {
+	return &TagsMergedEvent{
+		BaseEvent:       NewBaseEvent(kbID.String()),
+		KnowledgeBaseID: kbID,
+		Sources:         sources,
+		Target:          target,
+		DocumentIDs:     documentIDs,
+	}
+}
+
+func (e *TagsMergedEvent) EventName() string {
+	return "tag.merged"
+}
+
+// TagDeletedEvent 标签定义删除事件
+// 只删除注册表中的定义，文档上的标签保持不变，子标签移动到被删除标签的父标签下
+type TagDeletedEvent struct {
+	BaseEvent
+	TagID           valueobject.TagID
+	KnowledgeBaseID valueobject.KnowledgeBaseID
+	Name            string
+}
+
+func NewTagDeletedEvent(tagID valueobject.TagID, kbID valueobject.KnowledgeBaseID, name string) *TagDeletedEvent {
+	return &TagDeletedEvent{
+		BaseEvent:       NewBaseEvent(kbID.String()),
+		TagID:           tagID,
+		KnowledgeBaseID: kbID,
+		Name:            name,
+	}
+}
+
+func (e *TagDeletedEvent) EventName() string {
+	return "tag.deleted"
+}
+
+// ==================== 附件相关事件 ====================
+
+// AttachmentAddedEvent 附件上传事件
+type AttachmentAddedEvent struct {
+	BaseEvent
+	AttachmentID    valueobject.AttachmentID
+	KnowledgeBaseID valueobject.KnowledgeBaseID
+	DocumentID      valueobject.DocumentID
+	FileName        string
+	MediaType       string
+	Size            int64
+	ContentHash     string
+}
+
+func NewAttachmentAddedEvent(
+	attID valueobject.AttachmentID,
+	kbID valueobject.KnowledgeBaseID,
+	docID valueobject.DocumentID,
+	fileName, mediaType string,
+	size int64,
+	contentHash string,
+) *AttachmentAddedEvent {
+	return &AttachmentAddedEvent{
+		BaseEvent:       NewBaseEvent(kbID.String()),
+		AttachmentID:    attID,
+		KnowledgeBaseID: kbID,
+		DocumentID:      docID,
+		FileName:        fileName,
+		MediaType:       mediaType,
+		Size:            size,
+		ContentHash:     contentHash,
+	}
+}
+
+func (e *AttachmentAddedEvent) EventName() string {
+	return "attachment.added"
+}
+
+// AttachmentRemovedEvent 附件删除事件
+type AttachmentRemovedEvent struct {
+	BaseEvent
+	AttachmentID    valueobject.AttachmentID
+	KnowledgeBaseID valueobject.KnowledgeBaseID
+	DocumentID      valueobject.DocumentID
+	FileName        string
+}
+
+func NewAttachmentRemovedEvent(attID valueobject.AttachmentID, kbID valueobject.KnowledgeBaseID, docID valueobject.DocumentID, fileName string) *AttachmentRemovedEvent {
+	return &AttachmentRemovedEvent{
+		BaseEvent:       NewBaseEvent(kbID.String()),
+		AttachmentID:    attID,
+		KnowledgeBaseID: kbID,
+		DocumentID:      docID,
+		FileName:        fileName,
+	}
+}
+
+func (e *AttachmentRemovedEvent) EventName() string {
+	return "attachment.removed"
+}
+
+// ==================== API Key 相关事件 ====================
+
+// APIKeyIssuedEvent API Key 签发事件
+type APIKeyIssuedEvent struct {
+	BaseEvent
+	KeyID            string
+	Name             string
+	Scopes           []valueobject.APIKeyScope
+	KnowledgeBaseIDs []valueobject.KnowledgeBaseID // 为空表示不限制
+}
+
+func NewAPIKeyIssuedEvent(
+	keyID, name string,
+	scopes []valueobject.APIKeyScope,
+	kbIDs []valueobject.KnowledgeBaseID,
+) *APIKeyIssuedEvent {
+	return &APIKeyIssuedEvent{
+		BaseEvent:        NewBaseEvent(keyID),
+		KeyID:            keyID,
+		Name:             name,
+		Scopes:           scopes,
+		KnowledgeBaseIDs: kbIDs,
+	}
+}
+
+func (e *APIKeyIssuedEvent) EventName() string {
+	return "api_key.issued"
+}
+
+// APIKeyRevokedEvent API Key 吊销事件
+type APIKeyRevokedEvent struct {
+	BaseEvent
+	KeyID string
+	Name  string
+}
+
+func NewAPIKeyRevokedEvent(keyID, name string) *APIKeyRevokedEvent {
+	return &APIKeyRevokedEvent{
+		BaseEvent: NewBaseEvent(keyID),
+		KeyID:     keyID,
+		Name:      name,
+	}
+}
+
+func (e *APIKeyRevokedEvent) EventName() string {
+	return "api_key.revoked"
+}
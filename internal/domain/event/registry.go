@@ -0,0 +1,73 @@
+package event
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// eventFactories 按事件名称创建空的具体事件，用于反序列化
+var eventFactories = make(map[string]func() DomainEvent)
+
+func init() {
+	for _, factory := range []func() DomainEvent{
+		func() DomainEvent { return &KnowledgeBaseCreatedEvent{} },
+		func() DomainEvent { return &KnowledgeBaseUpdatedEvent{} },
+		func() DomainEvent { return &KnowledgeBaseDeletedEvent{} },
+		func() DomainEvent { return &KnowledgeBaseTrashedEvent{} },
+		func() DomainEvent { return &KnowledgeBaseRestoredEvent{} },
+		func() DomainEvent { return &KnowledgeBaseImportedEvent{} },
+		func() DomainEvent { return &KnowledgeBasePurgedEvent{} },
+		func() DomainEvent { return &KnowledgeBaseStatusChangedEvent{} },
+		func() DomainEvent { return &KnowledgeBaseDuplicatePolicyChangedEvent{} },
+		func() DomainEvent { return &KnowledgeBaseMemberGrantedEvent{} },
+		func() DomainEvent { return &KnowledgeBaseMemberRevokedEvent{} },
+		func() DomainEvent { return &DocumentAddedEvent{} },
+		func() DomainEvent { return &DocumentRemovedEvent{} },
+		func() DomainEvent { return &DocumentUpdatedEvent{} },
+		func() DomainEvent { return &DocumentRestoredEvent{} },
+		func() DomainEvent { return &DocumentPurgedEvent{} },
+		func() DomainEvent { return &DocumentSubmittedEvent{} },
+		func() DomainEvent { return &DocumentApprovedEvent{} },
+		func() DomainEvent { return &DocumentRejectedEvent{} },
+		func() DomainEvent { return &DocumentPublishedEvent{} },
+		func() DomainEvent { return &DocumentUnpublishedEvent{} },
+		func() DomainEvent { return &DocumentScheduledEvent{} },
+		func() DomainEvent { return &DocumentExpiredEvent{} },
+		func() DomainEvent { return &FolderCreatedEvent{} },
+		func() DomainEvent { return &FolderRenamedEvent{} },
+		func() DomainEvent { return &FolderMovedEvent{} },
+		func() DomainEvent { return &FolderDeletedEvent{} },
+		func() DomainEvent { return &DocumentMovedEvent{} },
+		func() DomainEvent { return &DocumentLinkBrokenEvent{} },
+		func() DomainEvent { return &TagDefinedEvent{} },
+		func() DomainEvent { return &TagUpdatedEvent{} },
+		func() DomainEvent { return &TagRenamedEvent{} },
+		func() DomainEvent { return &TagsMergedEvent{} },
+		func() DomainEvent { return &TagDeletedEvent{} },
+		func() DomainEvent { return &AttachmentAddedEvent{} },
+		func() DomainEvent { return &AttachmentRemovedEvent{} },
+		func() DomainEvent { return &APIKeyIssuedEvent{} },
+		func() DomainEvent { return &APIKeyRevokedEvent{} },
+	} {
+		eventFactories[factory().EventName()] = factory
+	}
+}
+
+// Decode 将序列化的事件数据还原为具体的领域事件
+// 用于从 Kafka 等消息中间件消费的事件：src 提供事件名称、ID、发生时间、聚合根ID和操作者，
+// payload 为发布时 json.Marshal 得到的事件数据。未登记的事件名称返回错误
+func Decode(src DomainEvent, payload []byte) (DomainEvent, error) {
+	factory, ok := eventFactories[src.EventName()]
+	if !ok {
+		return nil, fmt.Errorf("unknown domain event %q", src.EventName())
+	}
+
+	evt := factory()
+	if err := json.Unmarshal(payload, evt); err != nil {
+		return nil, fmt.Errorf("decode domain event %q: %w", src.EventName(), err)
+	}
+	if base, ok := evt.(interface{ restoreBase(src DomainEvent) }); ok {
+		base.restoreBase(src)
+	}
+	return evt, nil
+}
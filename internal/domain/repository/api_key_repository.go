@@ -0,0 +1,44 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"gozero-ddd/internal/domain/valueobject"
+)
+
+// APIKey API Key 记录
+// 只保存密钥的 SHA-256 哈希，明文仅在签发时返回一次
+type APIKey struct {
+	ID               string
+	TenantID         string // 签发时所在的租户，密钥只能访问该租户的数据
+	Name             string
+	Prefix           string // 密钥明文的前几位，便于在列表中识别
+	Hash             string // 密钥的 SHA-256 哈希（十六进制）
+	Scopes           []valueobject.APIKeyScope
+	KnowledgeBaseIDs []valueobject.KnowledgeBaseID // 允许访问的知识库，为空表示不限制
+	CreatedBy        string
+	CreatedAt        time.Time
+	ExpiresAt        *time.Time // 为 nil 表示永不过期
+	LastUsedAt       *time.Time
+	RevokedAt        *time.Time
+}
+
+// APIKeyRepository API Key 仓储接口
+type APIKeyRepository interface {
+	// Save 保存 API Key（新增或更新）
+	Save(ctx context.Context, key *APIKey) error
+
+	// FindByID 根据 ID 查找，不存在时返回 nil
+	FindByID(ctx context.Context, id string) (*APIKey, error)
+
+	// FindByHash 根据密钥哈希查找，不存在时返回 nil
+	// 认证发生在确定租户之前，因此不按租户过滤
+	FindByHash(ctx context.Context, hash string) (*APIKey, error)
+
+	// FindAll 查找当前租户的所有 API Key（包括已吊销和已过期的）
+	FindAll(ctx context.Context) ([]*APIKey, error)
+
+	// UpdateLastUsed 更新最近使用时间
+	UpdateLastUsed(ctx context.Context, id string, at time.Time) error
+}
@@ -0,0 +1,35 @@
+package repository
+
+import (
+	"context"
+
+	"gozero-ddd/internal/domain/entity"
+	"gozero-ddd/internal/domain/valueobject"
+)
+
+// AttachmentRepository 附件仓储接口
+// 附件属于 KnowledgeBase 聚合，由知识库仓储加载聚合时一并加载
+// 这里只保存附件元数据，二进制内容由 BlobStore 保存
+type AttachmentRepository interface {
+	// Save 保存附件（创建或更新）
+	Save(ctx context.Context, att *entity.Attachment) error
+
+	// Delete 删除附件（物理删除）
+	Delete(ctx context.Context, id valueobject.AttachmentID) error
+
+	// FindByKnowledgeBaseID 查找知识库下的所有附件
+	FindByKnowledgeBaseID(ctx context.Context, kbID valueobject.KnowledgeBaseID) ([]*entity.Attachment, error)
+
+	// FindByDocumentID 查找文档的所有附件
+	FindByDocumentID(ctx context.Context, docID valueobject.DocumentID) ([]*entity.Attachment, error)
+
+	// DeleteByDocumentID 删除文档的所有附件（物理删除）
+	DeleteByDocumentID(ctx context.Context, docID valueobject.DocumentID) error
+
+	// DeleteByKnowledgeBaseID 删除知识库下的所有附件（物理删除）
+	DeleteByKnowledgeBaseID(ctx context.Context, kbID valueobject.KnowledgeBaseID) error
+
+	// ExistsByContentHash 检查是否仍有附件引用指定内容
+	// 用于判断 BlobStore 中的内容能否被清理
+	ExistsByContentHash(ctx context.Context, hash valueobject.ContentHash) (bool, error)
+}
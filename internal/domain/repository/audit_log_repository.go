@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+// AuditLogEntry 审计日志记录
+// 每个领域事件对应一条记录，写入后不再修改
+type AuditLogEntry struct {
+	Seq             int64  // 写入顺序号，由存储生成，用于分页
+	EventID         string // 事件ID，重复投递的事件只记录一次
+	TenantID        string
+	EventName       string
+	AggregateID     string
+	KnowledgeBaseID string          // 事件所属知识库，与知识库无关的事件为空
+	Actor           string          // 操作者标识，系统操作为空
+	RequestID       string          // 产生事件的请求ID，定时任务等后台操作为空
+	ClientIP        string          // 产生事件的请求的客户端 IP
+	Before          json.RawMessage // 变更前的快照，事件不携带旧值时为空
+	After           json.RawMessage // 变更后的快照，事件不携带新值时为空
+	Payload         json.RawMessage // 完整的事件数据
+	OccurredAt      time.Time
+	RecordedAt      time.Time
+}
+
+// AuditLogFilter 审计日志查询条件，零值字段不参与过滤
+type AuditLogFilter struct {
+	KnowledgeBaseID string
+	Actor           string
+	EventName       string
+	Since           *time.Time // 事件发生时间下限（含）
+	Until           *time.Time // 事件发生时间上限（不含）
+	BeforeSeq       int64      // 只返回顺序号小于该值的记录，用于翻页
+	Limit           int
+}
+
+// AuditLogRepository 审计日志仓储接口
+// 只追加不修改，按上下文中的租户隔离
+type AuditLogRepository interface {
+	// Append 追加一条审计日志，事件ID已存在时忽略
+	Append(ctx context.Context, entry *AuditLogEntry) error
+
+	// Find 按条件查询审计日志，按写入顺序倒序返回
+	Find(ctx context.Context, filter AuditLogFilter) ([]*AuditLogEntry, error)
+}
@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"context"
+	"io"
+)
+
+// BlobStore 二进制内容存储接口
+// 以键值方式保存附件等二进制内容，不参与数据库事务
+// 实现包括本地文件系统和 S3 兼容的对象存储
+type BlobStore interface {
+	// Put 写入内容，键已存在时覆盖
+	Put(ctx context.Context, key string, r io.Reader, size int64) error
+
+	// Open 打开内容用于读取，调用方负责关闭
+	// 键不存在时返回 domain.ErrBlobNotFound
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+
+	// Exists 检查键是否存在
+	Exists(ctx context.Context, key string) (bool, error)
+
+	// Delete 删除内容，键不存在时不报错
+	Delete(ctx context.Context, key string) error
+}
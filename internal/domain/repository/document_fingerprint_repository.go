@@ -0,0 +1,25 @@
+package repository
+
+import (
+	"context"
+
+	"gozero-ddd/internal/domain/valueobject"
+)
+
+// DocumentFingerprintRepository 文档内容指纹仓储接口
+// 保存每篇文档的内容指纹，用于检测重复和近似重复的文档
+// 查询只返回文档及其所属知识库都未被删除的指纹
+type DocumentFingerprintRepository interface {
+	// Save 保存文档的内容指纹（已存在时覆盖）
+	Save(ctx context.Context, docID valueobject.DocumentID, kbID valueobject.KnowledgeBaseID, fp valueobject.ContentFingerprint) error
+
+	// Delete 删除文档的内容指纹
+	Delete(ctx context.Context, docID valueobject.DocumentID) error
+
+	// DeleteByKnowledgeBaseID 删除知识库下所有文档的内容指纹
+	DeleteByKnowledgeBaseID(ctx context.Context, kbID valueobject.KnowledgeBaseID) error
+
+	// Find 查找文档的内容指纹
+	// kbID 为空时返回所有知识库的文档
+	Find(ctx context.Context, kbID valueobject.KnowledgeBaseID) ([]valueobject.DocumentFingerprint, error)
+}
@@ -0,0 +1,25 @@
+package repository
+
+import (
+	"context"
+
+	"gozero-ddd/internal/domain/valueobject"
+)
+
+// DocumentLinkRepository 文档链接图仓储接口
+// 记录每篇文档内容中引用的其他文档，用于查询出链、反向链接和失效链接
+// 只返回源文档未被删除的链接
+type DocumentLinkRepository interface {
+	// ReplaceLinks 替换源文档的全部出链
+	ReplaceLinks(ctx context.Context, sourceDocID valueobject.DocumentID, sourceKBID valueobject.KnowledgeBaseID, links []valueobject.DocumentLink) error
+
+	// FindBySource 查找源文档的全部出链
+	FindBySource(ctx context.Context, sourceDocID valueobject.DocumentID) ([]valueobject.DocumentLink, error)
+
+	// FindBacklinks 查找引用目标文档的链接
+	// Wiki 链接按标题在目标文档所属知识库内匹配，路径链接按文档ID匹配
+	FindBacklinks(ctx context.Context, kbID valueobject.KnowledgeBaseID, docID valueobject.DocumentID, title string) ([]valueobject.DocumentLinkEdge, error)
+
+	// FindByKnowledgeBaseID 查找知识库内所有文档的出链
+	FindByKnowledgeBaseID(ctx context.Context, kbID valueobject.KnowledgeBaseID) ([]valueobject.DocumentLinkEdge, error)
+}
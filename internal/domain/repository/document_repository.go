@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"gozero-ddd/internal/domain/entity"
 	"gozero-ddd/internal/domain/valueobject"
@@ -19,13 +20,58 @@ type DocumentRepository interface {
 	// FindByKnowledgeBaseID 根据知识库ID查找所有文档
 	FindByKnowledgeBaseID(ctx context.Context, kbID valueobject.KnowledgeBaseID) ([]*entity.Document, error)
 
-	// Delete 删除文档
+	// FindByStatus 根据知识库ID和发布状态查找文档
+	FindByStatus(ctx context.Context, kbID valueobject.KnowledgeBaseID, status valueobject.DocumentStatus) ([]*entity.Document, error)
+
+	// FindDueForPublish 查找到达定时发布时间的已审核文档
+	FindDueForPublish(ctx context.Context, now time.Time) ([]*entity.Document, error)
+
+	// FindDueForExpiry 查找到达定时下线时间的已发布文档
+	FindDueForExpiry(ctx context.Context, now time.Time) ([]*entity.Document, error)
+
+	// Delete 删除文档（软删除，移入回收站）
 	Delete(ctx context.Context, id valueobject.DocumentID) error
 
-	// DeleteByKnowledgeBaseID 删除知识库下所有文档
+	// DeleteByKnowledgeBaseID 删除知识库下所有文档（软删除，移入回收站）
+	// 这些文档会标记为随知识库一起删除，已在回收站中的文档不受影响
 	DeleteByKnowledgeBaseID(ctx context.Context, kbID valueobject.KnowledgeBaseID) error
 
-	// SearchByTags 根据标签搜索文档
-	SearchByTags(ctx context.Context, tags []string) ([]*entity.Document, error)
+	// SearchByTagQuery 根据标签查询表达式搜索文档
+	// kbID 为空时搜索所有知识库；别名应由调用方先解析为规范名称
+	SearchByTagQuery(ctx context.Context, kbID valueobject.KnowledgeBaseID, expr valueobject.TagExpr) ([]*entity.Document, error)
+
+	// ==================== 回收站 ====================
+	// 以上查询方法默认排除回收站中的文档，以下方法专门用于操作回收站
+
+	// FindDeleted 查找回收站中的文档
+	// kbID 为空时返回所有知识库的已删除文档
+	FindDeleted(ctx context.Context, kbID valueobject.KnowledgeBaseID) ([]*entity.Document, error)
+
+	// FindDeletedByID 根据ID查找回收站中的文档
+	FindDeletedByID(ctx context.Context, id valueobject.DocumentID) (*entity.Document, error)
+
+	// CountDeleted 统计知识库在回收站中的文档数量
+	CountDeleted(ctx context.Context, kbID valueobject.KnowledgeBaseID) (int, error)
+
+	// FindDeletedBefore 查找在指定时间之前移入回收站的文档
+	FindDeletedBefore(ctx context.Context, before time.Time) ([]*entity.Document, error)
+
+	// Restore 从回收站恢复文档
+	Restore(ctx context.Context, id valueobject.DocumentID) error
+
+	// RestoreByKnowledgeBaseID 恢复随知识库一起移入回收站的文档
+	// 用于恢复知识库时使用，之前单独删除的文档仍保留在回收站中
+	RestoreByKnowledgeBaseID(ctx context.Context, kbID valueobject.KnowledgeBaseID) error
+
+	// Purge 彻底删除文档（物理删除，不可恢复）
+	Purge(ctx context.Context, id valueobject.DocumentID) error
+
+	// PurgeByKnowledgeBaseID 彻底删除知识库下所有文档（包括回收站中的文档）
+	PurgeByKnowledgeBaseID(ctx context.Context, kbID valueobject.KnowledgeBaseID) error
+
+	// ==================== 统计 ====================
+
+	// CountByStatus 按状态统计文档数量（不包括回收站中的）
+	CountByStatus(ctx context.Context) (map[valueobject.DocumentStatus]int, error)
 }
 
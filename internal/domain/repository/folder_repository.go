@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"context"
+
+	"gozero-ddd/internal/domain/entity"
+	"gozero-ddd/internal/domain/valueobject"
+)
+
+// FolderRepository 文件夹仓储接口
+// 文件夹属于 KnowledgeBase 聚合，由知识库仓储加载聚合时一并加载
+type FolderRepository interface {
+	// Save 保存文件夹（创建或更新）
+	Save(ctx context.Context, folder *entity.Folder) error
+
+	// FindByKnowledgeBaseID 查找知识库下的所有文件夹
+	FindByKnowledgeBaseID(ctx context.Context, kbID valueobject.KnowledgeBaseID) ([]*entity.Folder, error)
+
+	// Delete 删除文件夹（物理删除）
+	Delete(ctx context.Context, id valueobject.FolderID) error
+
+	// DeleteByKnowledgeBaseID 删除知识库下的所有文件夹（物理删除）
+	DeleteByKnowledgeBaseID(ctx context.Context, kbID valueobject.KnowledgeBaseID) error
+}
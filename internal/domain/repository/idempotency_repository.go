@@ -0,0 +1,44 @@
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+// IdempotencyScope 幂等键的作用范围
+// 幂等键只在同一租户的同一调用方内唯一，不同租户或调用方使用相同的键互不影响
+type IdempotencyScope struct {
+	TenantID string
+	Actor    string // 调用方标识，未启用认证时为空
+	Key      string
+}
+
+// IdempotencyRecord 幂等键记录
+// 保存请求指纹和处理结果，客户端使用相同的键重试时直接返回保存的结果
+type IdempotencyRecord struct {
+	IdempotencyScope
+	Fingerprint string // 请求指纹（SHA-256）
+	Completed   bool   // 请求是否已处理完成；未完成表示首个请求仍在处理中
+	StatusCode  int    // HTTP 状态码或 gRPC 状态码
+	ContentType string
+	Body        []byte
+	CreatedAt   time.Time
+	ExpiresAt   time.Time
+}
+
+// IdempotencyRepository 幂等键仓储接口
+// 记录不参与业务事务：请求开始时占用键，处理完成后保存响应
+type IdempotencyRepository interface {
+	// Reserve 占用 record 作用范围内的幂等键
+	// 键不存在或已过期时写入 record 并返回 nil；否则返回已有的记录，不做修改
+	Reserve(ctx context.Context, record *IdempotencyRecord) (*IdempotencyRecord, error)
+
+	// Complete 保存请求的处理结果
+	Complete(ctx context.Context, scope IdempotencyScope, statusCode int, contentType string, body []byte) error
+
+	// Release 删除未完成的记录，使客户端可以用相同的键重试
+	Release(ctx context.Context, scope IdempotencyScope) error
+
+	// DeleteExpired 删除在 before 之前过期的记录，返回删除数量
+	DeleteExpired(ctx context.Context, before time.Time) (int64, error)
+}
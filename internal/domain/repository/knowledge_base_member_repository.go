@@ -0,0 +1,43 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"gozero-ddd/internal/domain/valueobject"
+)
+
+// KnowledgeBaseMember 知识库成员
+// 记录调用方在某个知识库中的角色，每个调用方在一个知识库中只有一个角色
+type KnowledgeBaseMember struct {
+	KnowledgeBaseID valueobject.KnowledgeBaseID
+	Subject         string // 调用方标识（JWT 的 sub）
+	Role            valueobject.MemberRole
+	GrantedBy       string // 授权人标识，系统授权时为空
+	GrantedAt       time.Time
+}
+
+// KnowledgeBaseMemberRepository 知识库成员仓储接口
+type KnowledgeBaseMemberRepository interface {
+	// Save 保存成员（已存在时覆盖角色）
+	Save(ctx context.Context, member *KnowledgeBaseMember) error
+
+	// Delete 删除成员
+	Delete(ctx context.Context, kbID valueobject.KnowledgeBaseID, subject string) error
+
+	// DeleteByKnowledgeBaseID 删除知识库的所有成员
+	DeleteByKnowledgeBaseID(ctx context.Context, kbID valueobject.KnowledgeBaseID) error
+
+	// Find 查找调用方在知识库中的成员记录，不存在时返回 nil
+	Find(ctx context.Context, kbID valueobject.KnowledgeBaseID, subject string) (*KnowledgeBaseMember, error)
+
+	// FindByKnowledgeBaseID 查找知识库的所有成员
+	FindByKnowledgeBaseID(ctx context.Context, kbID valueobject.KnowledgeBaseID) ([]*KnowledgeBaseMember, error)
+
+	// FindByKnowledgeBaseIDForUpdate 查找知识库的所有成员并锁定，直到所在事务结束
+	// 需要在事务中调用，同一知识库并发的成员变更因此串行执行
+	FindByKnowledgeBaseIDForUpdate(ctx context.Context, kbID valueobject.KnowledgeBaseID) ([]*KnowledgeBaseMember, error)
+
+	// FindBySubject 查找调用方加入的所有知识库成员记录
+	FindBySubject(ctx context.Context, subject string) ([]*KnowledgeBaseMember, error)
+}
@@ -2,14 +2,17 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"gozero-ddd/internal/domain/entity"
+	"gozero-ddd/internal/domain/tenant"
 	"gozero-ddd/internal/domain/valueobject"
 )
 
 // KnowledgeBaseRepository 知识库仓储接口
 // 仓储接口定义在领域层，实现在基础设施层
 // 这体现了依赖倒置原则：领域层不依赖基础设施层
+// 所有方法只操作上下文中租户的知识库，名称在租户内唯一
 type KnowledgeBaseRepository interface {
 	// Save 保存知识库（创建或更新）
 	Save(ctx context.Context, kb *entity.KnowledgeBase) error
@@ -20,10 +23,42 @@ type KnowledgeBaseRepository interface {
 	// FindAll 查找所有知识库
 	FindAll(ctx context.Context) ([]*entity.KnowledgeBase, error)
 
-	// Delete 删除知识库
+	// FindByStatus 查找指定生命周期状态的知识库
+	FindByStatus(ctx context.Context, status valueobject.KnowledgeBaseStatus) ([]*entity.KnowledgeBase, error)
+
+	// Delete 删除知识库（软删除，移入回收站）
 	Delete(ctx context.Context, id valueobject.KnowledgeBaseID) error
 
-	// ExistsByName 检查名称是否已存在
+	// ExistsByName 检查名称在当前租户内是否已存在（包含回收站中的知识库）
 	ExistsByName(ctx context.Context, name string) (bool, error)
+
+	// ==================== 回收站 ====================
+	// 以上查询方法默认排除回收站中的知识库，以下方法专门用于操作回收站
+
+	// FindDeleted 查找回收站中的所有知识库
+	FindDeleted(ctx context.Context) ([]*entity.KnowledgeBase, error)
+
+	// FindDeletedByID 根据ID查找回收站中的知识库
+	FindDeletedByID(ctx context.Context, id valueobject.KnowledgeBaseID) (*entity.KnowledgeBase, error)
+
+	// FindDeletedBefore 查找在指定时间之前移入回收站的知识库
+	FindDeletedBefore(ctx context.Context, before time.Time) ([]*entity.KnowledgeBase, error)
+
+	// Restore 从回收站恢复知识库
+	Restore(ctx context.Context, id valueobject.KnowledgeBaseID) error
+
+	// Purge 彻底删除知识库（物理删除，不可恢复）
+	Purge(ctx context.Context, id valueobject.KnowledgeBaseID) error
+
+	// ==================== 租户 ====================
+
+	// FindTenantIDs 查找拥有知识库（包括回收站中的）的所有租户
+	// 唯一不按租户过滤的方法，供后台任务逐个租户执行
+	FindTenantIDs(ctx context.Context) ([]tenant.ID, error)
+
+	// ==================== 统计 ====================
+
+	// CountByStatus 按状态统计知识库数量（不包括回收站中的）
+	CountByStatus(ctx context.Context) (map[valueobject.KnowledgeBaseStatus]int, error)
 }
 
@@ -0,0 +1,22 @@
+package repository
+
+import (
+	"context"
+
+	"gozero-ddd/internal/domain/entity"
+	"gozero-ddd/internal/domain/valueobject"
+)
+
+// TagRepository 标签注册表仓储接口
+// 标签定义属于 KnowledgeBase 聚合，由知识库仓储加载聚合时一并加载
+type TagRepository interface {
+	// ReplaceAll 用给定的标签定义整体替换知识库的标签注册表
+	// 重命名、合并等操作会同时修改和删除多个定义，整体替换可以保证注册表与聚合一致
+	ReplaceAll(ctx context.Context, kbID valueobject.KnowledgeBaseID, tags []*entity.TagDefinition) error
+
+	// FindByKnowledgeBaseID 查找知识库下的所有标签定义
+	FindByKnowledgeBaseID(ctx context.Context, kbID valueobject.KnowledgeBaseID) ([]*entity.TagDefinition, error)
+
+	// DeleteByKnowledgeBaseID 删除知识库下的所有标签定义（物理删除）
+	DeleteByKnowledgeBaseID(ctx context.Context, kbID valueobject.KnowledgeBaseID) error
+}
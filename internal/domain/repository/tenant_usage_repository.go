@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"context"
+
+	"gozero-ddd/internal/domain/valueobject"
+)
+
+// TenantUsageRepository 租户资源用量仓储接口
+// 用量的增加在占用配额的事务中记录，彻底清除后的减少由事件处理器记录，所有方法只操作上下文中租户的用量
+type TenantUsageRepository interface {
+	// Find 查询当前租户的用量
+	Find(ctx context.Context) (valueobject.QuotaUsage, error)
+
+	// FindForUpdate 查询当前租户的用量并锁定，直到所在事务结束
+	// 需要在事务中调用，同一租户并发的配额校验因此串行执行
+	FindForUpdate(ctx context.Context) (valueobject.QuotaUsage, error)
+
+	// Add 按增减量更新当前租户的用量
+	Add(ctx context.Context, delta valueobject.QuotaUsage) error
+}
@@ -0,0 +1,117 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"io"
+
+	"gozero-ddd/internal/domain/entity"
+	"gozero-ddd/internal/domain/repository"
+	"gozero-ddd/internal/domain/valueobject"
+)
+
+// AttachmentService 附件领域服务
+// 附件元数据保存在数据库中，二进制内容按内容哈希保存在 BlobStore 中
+// 相同内容只保存一份，多个附件（可能跨文档、跨知识库）共享同一个 blob
+// BlobStore 不参与数据库事务，因此 blob 的写入在事务之前、清理在事务提交之后进行
+type AttachmentService struct {
+	attRepo   repository.AttachmentRepository
+	blobStore repository.BlobStore
+}
+
+// NewAttachmentService 创建附件领域服务
+func NewAttachmentService(attRepo repository.AttachmentRepository, blobStore repository.BlobStore) *AttachmentService {
+	return &AttachmentService{
+		attRepo:   attRepo,
+		blobStore: blobStore,
+	}
+}
+
+// StoreContent 保存附件内容并返回其哈希
+// 相同内容已存在时不重复写入
+func (s *AttachmentService) StoreContent(ctx context.Context, content []byte) (valueobject.ContentHash, error) {
+	hash := valueobject.ComputeContentHash(content)
+	key := blobKey(hash)
+
+	exists, err := s.blobStore.Exists(ctx, key)
+	if err != nil {
+		return "", err
+	}
+	if !exists {
+		if err := s.blobStore.Put(ctx, key, bytes.NewReader(content), int64(len(content))); err != nil {
+			return "", err
+		}
+	}
+	return hash, nil
+}
+
+// OpenContent 打开附件内容用于读取，调用方负责关闭
+func (s *AttachmentService) OpenContent(ctx context.Context, att *entity.Attachment) (io.ReadCloser, error) {
+	return s.blobStore.Open(ctx, blobKey(att.ContentHash()))
+}
+
+// PurgeDocumentAttachments 删除文档的所有附件记录，返回它们引用的内容哈希
+// 应在事务中调用，事务提交后再调用 ReleaseContent 清理 blob
+func (s *AttachmentService) PurgeDocumentAttachments(ctx context.Context, docID valueobject.DocumentID) ([]valueobject.ContentHash, error) {
+	atts, err := s.attRepo.FindByDocumentID(ctx, docID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.attRepo.DeleteByDocumentID(ctx, docID); err != nil {
+		return nil, err
+	}
+	return contentHashes(atts), nil
+}
+
+// PurgeKnowledgeBaseAttachments 删除知识库下的所有附件记录，返回它们引用的内容哈希
+// 应在事务中调用，事务提交后再调用 ReleaseContent 清理 blob
+func (s *AttachmentService) PurgeKnowledgeBaseAttachments(ctx context.Context, kbID valueobject.KnowledgeBaseID) ([]valueobject.ContentHash, error) {
+	atts, err := s.attRepo.FindByKnowledgeBaseID(ctx, kbID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.attRepo.DeleteByKnowledgeBaseID(ctx, kbID); err != nil {
+		return nil, err
+	}
+	return contentHashes(atts), nil
+}
+
+// ReleaseContent 清理不再被任何附件引用的内容
+// 在删除附件的事务提交后调用；也用于上传失败时回收已写入的内容
+func (s *AttachmentService) ReleaseContent(ctx context.Context, hashes ...valueobject.ContentHash) error {
+	seen := make(map[valueobject.ContentHash]bool, len(hashes))
+	for _, hash := range hashes {
+		if seen[hash] {
+			continue
+		}
+		seen[hash] = true
+
+		referenced, err := s.attRepo.ExistsByContentHash(ctx, hash)
+		if err != nil {
+			return err
+		}
+		if referenced {
+			continue
+		}
+		if err := s.blobStore.Delete(ctx, blobKey(hash)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// blobKey 内容哈希在 BlobStore 中的键
+// 按哈希前两位分目录，避免单个目录下文件过多
+func blobKey(hash valueobject.ContentHash) string {
+	h := hash.String()
+	return "attachments/" + h[:2] + "/" + h
+}
+
+// contentHashes 收集附件引用的内容哈希
+func contentHashes(atts []*entity.Attachment) []valueobject.ContentHash {
+	result := make([]valueobject.ContentHash, len(atts))
+	for i, att := range atts {
+		result[i] = att.ContentHash()
+	}
+	return result
+}
@@ -0,0 +1,18 @@
+package service
+
+import "gozero-ddd/internal/domain/valueobject"
+
+// RenderedContent 文档内容的渲染结果
+type RenderedContent struct {
+	HTML    string                // 净化后的 HTML，可直接嵌入页面
+	Text    string                // 纯文本，用于搜索索引
+	Excerpt string                // 纯文本摘要，用于列表和搜索结果片段
+	TOC     []valueobject.Heading // 按出现顺序排列的标题目录
+}
+
+// ContentRenderer 文档内容渲染器
+// 将不同内容类型的原始内容统一转换为净化后的 HTML 和纯文本
+// 具体的解析和净化实现属于基础设施层
+type ContentRenderer interface {
+	Render(contentType valueobject.ContentType, content string) (*RenderedContent, error)
+}
@@ -0,0 +1,223 @@
+package service
+
+import (
+	"context"
+
+	"gozero-ddd/internal/domain"
+	"gozero-ddd/internal/domain/entity"
+	"gozero-ddd/internal/domain/repository"
+	"gozero-ddd/internal/domain/valueobject"
+)
+
+// 重复类型
+const (
+	DuplicateKindExact = "exact" // 内容完全相同（忽略空白差异）
+	DuplicateKindNear  = "near"  // 内容相近（SimHash 汉明距离不超过阈值）
+)
+
+// DuplicateCluster 一组互相重复的文档
+type DuplicateCluster struct {
+	Kind      string
+	Documents []valueobject.DocumentFingerprint
+}
+
+// DuplicateService 重复文档检测领域服务
+// 重复可能跨越知识库，不适合放在单个聚合根中处理
+type DuplicateService struct {
+	fingerprintRepo repository.DocumentFingerprintRepository
+}
+
+// NewDuplicateService 创建重复文档检测领域服务
+func NewDuplicateService(fingerprintRepo repository.DocumentFingerprintRepository) *DuplicateService {
+	return &DuplicateService{
+		fingerprintRepo: fingerprintRepo,
+	}
+}
+
+// RefreshFingerprint 根据文档当前内容重新计算其内容指纹
+// 在文档新增或内容更新后调用
+func (s *DuplicateService) RefreshFingerprint(ctx context.Context, doc *entity.Document) error {
+	return s.fingerprintRepo.Save(ctx, doc.ID(), doc.KnowledgeBaseID(), doc.Fingerprint())
+}
+
+// ForgetDocument 删除文档的内容指纹，在文档被彻底清除后调用
+func (s *DuplicateService) ForgetDocument(ctx context.Context, docID valueobject.DocumentID) error {
+	return s.fingerprintRepo.Delete(ctx, docID)
+}
+
+// ForgetKnowledgeBase 删除知识库下所有文档的内容指纹，在知识库被彻底清除后调用
+func (s *DuplicateService) ForgetKnowledgeBase(ctx context.Context, kbID valueobject.KnowledgeBaseID) error {
+	return s.fingerprintRepo.DeleteByKnowledgeBaseID(ctx, kbID)
+}
+
+// FindClusters 查找重复文档分组
+//   - kbID 为空：在所有知识库中查找
+//   - kbID 不为空且 crossKnowledgeBase 为 false：只在该知识库内查找
+//   - kbID 不为空且 crossKnowledgeBase 为 true：在所有知识库中查找，只返回包含该知识库文档的分组
+//
+// maxDistance 为近似重复的 SimHash 汉明距离上限
+func (s *DuplicateService) FindClusters(
+	ctx context.Context,
+	kbID valueobject.KnowledgeBaseID,
+	crossKnowledgeBase bool,
+	maxDistance int,
+) ([]*DuplicateCluster, error) {
+	if maxDistance < 0 || maxDistance > valueobject.MaxSimHashDistance {
+		return nil, domain.ErrInvalidDuplicateDistance
+	}
+
+	scope := kbID
+	if crossKnowledgeBase {
+		scope = ""
+	}
+	fingerprints, err := s.fingerprintRepo.Find(ctx, scope)
+	if err != nil {
+		return nil, err
+	}
+
+	clusters := ClusterDuplicates(fingerprints, maxDistance)
+	if kbID.IsEmpty() || !crossKnowledgeBase {
+		return clusters, nil
+	}
+
+	result := make([]*DuplicateCluster, 0, len(clusters))
+	for _, c := range clusters {
+		for _, doc := range c.Documents {
+			if doc.KnowledgeBaseID == kbID {
+				result = append(result, c)
+				break
+			}
+		}
+	}
+	return result, nil
+}
+
+// ClusterDuplicates 将文档按重复关系分组
+// 内容哈希相同的文档组成一个 exact 分组；
+// 内容不同但 SimHash 汉明距离不超过 maxDistance 的文档按传递关系合并为一个 near 分组，
+// 分组内也包含各自内容相同的文档，因此同一篇文档可能同时出现在 exact 和 near 分组中。
+// 空内容的文档不参与分组
+func ClusterDuplicates(fingerprints []valueobject.DocumentFingerprint, maxDistance int) []*DuplicateCluster {
+	// 按内容哈希分组，保持首次出现的顺序
+	groups := make(map[valueobject.ContentHash][]valueobject.DocumentFingerprint)
+	hashes := make([]valueobject.ContentHash, 0)
+	for _, fp := range fingerprints {
+		if fp.Fingerprint.IsEmpty() {
+			continue
+		}
+		if _, ok := groups[fp.Fingerprint.Hash]; !ok {
+			hashes = append(hashes, fp.Fingerprint.Hash)
+		}
+		groups[fp.Fingerprint.Hash] = append(groups[fp.Fingerprint.Hash], fp)
+	}
+
+	clusters := make([]*DuplicateCluster, 0)
+	for _, h := range hashes {
+		if len(groups[h]) > 1 {
+			clusters = append(clusters, &DuplicateCluster{Kind: DuplicateKindExact, Documents: groups[h]})
+		}
+	}
+
+	// 每种内容取一个代表参与近似重复的比较
+	reps := make([]valueobject.ContentFingerprint, len(hashes))
+	for i, h := range hashes {
+		reps[i] = groups[h][0].Fingerprint
+	}
+
+	sets := newDisjointSet(len(reps))
+	for _, pair := range nearPairs(reps, maxDistance) {
+		sets.union(pair[0], pair[1])
+	}
+
+	members := make(map[int][]int)
+	roots := make([]int, 0)
+	for i := range reps {
+		root := sets.find(i)
+		if _, ok := members[root]; !ok {
+			roots = append(roots, root)
+		}
+		members[root] = append(members[root], i)
+	}
+	for _, root := range roots {
+		if len(members[root]) < 2 {
+			continue
+		}
+		docs := make([]valueobject.DocumentFingerprint, 0)
+		for _, i := range members[root] {
+			docs = append(docs, groups[hashes[i]]...)
+		}
+		clusters = append(clusters, &DuplicateCluster{Kind: DuplicateKindNear, Documents: docs})
+	}
+
+	return clusters
+}
+
+// nearPairs 找出 SimHash 汉明距离不超过 maxDistance 的指纹对
+// 将 64 位 SimHash 分成 maxDistance+1 段，距离不超过 maxDistance 的两个指纹至少有一段完全相同（抽屉原理），
+// 因此只需比较至少有一段相同的候选对
+func nearPairs(fps []valueobject.ContentFingerprint, maxDistance int) [][2]int {
+	type bandKey struct {
+		band  int
+		value uint64
+	}
+
+	bands := maxDistance + 1
+	index := make(map[bandKey][]int)
+	checked := make(map[[2]int]bool)
+	pairs := make([][2]int, 0)
+
+	for i, fp := range fps {
+		for b := 0; b < bands; b++ {
+			start := b * 64 / bands
+			width := (b+1)*64/bands - start
+			key := bandKey{band: b, value: (fp.SimHash >> uint(start)) & (1<<uint(width) - 1)}
+			for _, j := range index[key] {
+				pair := [2]int{j, i}
+				if checked[pair] {
+					continue
+				}
+				checked[pair] = true
+				if fp.Distance(fps[j]) <= maxDistance {
+					pairs = append(pairs, pair)
+				}
+			}
+			index[key] = append(index[key], i)
+		}
+	}
+
+	return pairs
+}
+
+// disjointSet 并查集，用于按传递关系合并近似重复的文档
+type disjointSet struct {
+	parent []int
+}
+
+func newDisjointSet(n int) *disjointSet {
+	parent := make([]int, n)
+	for i := range parent {
+		parent[i] = i
+	}
+	return &disjointSet{parent: parent}
+}
+
+func (s *disjointSet) find(i int) int {
+	for s.parent[i] != i {
+		s.parent[i] = s.parent[s.parent[i]]
+		i = s.parent[i]
+	}
+	return i
+}
+
+func (s *disjointSet) union(a, b int) {
+	ra, rb := s.find(a), s.find(b)
+	if ra == rb {
+		return
+	}
+	// 以较小的序号为根，使分组中的文档保持原有顺序
+	if ra < rb {
+		s.parent[rb] = ra
+	} else {
+		s.parent[ra] = rb
+	}
+}
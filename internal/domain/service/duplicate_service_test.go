@@ -0,0 +1,182 @@
+package service
+
+import (
+	"fmt"
+	"math/bits"
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"gozero-ddd/internal/domain/valueobject"
+)
+
+// fingerprint 构造指定 SimHash 的文档指纹，content 决定内容哈希
+func fingerprint(id, content string, simHash uint64) valueobject.DocumentFingerprint {
+	return valueobject.DocumentFingerprint{
+		DocumentID:      valueobject.DocumentID(id),
+		KnowledgeBaseID: "kb",
+		Fingerprint: valueobject.ContentFingerprint{
+			Hash:    valueobject.ComputeContentHash([]byte(content)),
+			SimHash: simHash,
+		},
+	}
+}
+
+// lowBits 返回低 n 位全为 1 的值，与 0 的汉明距离为 n
+func lowBits(n int) uint64 {
+	return 1<<uint(n) - 1
+}
+
+// clusterIDs 将分组转换为 "kind:id,id" 形式便于比较
+func clusterIDs(clusters []*DuplicateCluster) []string {
+	result := make([]string, 0, len(clusters))
+	for _, c := range clusters {
+		s := c.Kind + ":"
+		for i, doc := range c.Documents {
+			if i > 0 {
+				s += ","
+			}
+			s += string(doc.DocumentID)
+		}
+		result = append(result, s)
+	}
+	return result
+}
+
+func TestClusterDuplicatesThreshold(t *testing.T) {
+	tests := []struct {
+		name         string
+		maxDistance  int
+		fingerprints []valueobject.DocumentFingerprint
+		want         []string
+	}{
+		{
+			name:        "distance equal to threshold is near",
+			maxDistance: valueobject.DefaultSimHashDistance,
+			fingerprints: []valueobject.DocumentFingerprint{
+				fingerprint("a", "a", 0),
+				fingerprint("b", "b", lowBits(valueobject.DefaultSimHashDistance)),
+			},
+			want: []string{"near:a,b"},
+		},
+		{
+			name:        "distance above threshold is not near",
+			maxDistance: valueobject.DefaultSimHashDistance,
+			fingerprints: []valueobject.DocumentFingerprint{
+				fingerprint("a", "a", 0),
+				fingerprint("b", "b", lowBits(valueobject.DefaultSimHashDistance+1)),
+			},
+			want: []string{},
+		},
+		{
+			name:        "max threshold",
+			maxDistance: valueobject.MaxSimHashDistance,
+			fingerprints: []valueobject.DocumentFingerprint{
+				fingerprint("a", "a", 0),
+				fingerprint("b", "b", lowBits(valueobject.MaxSimHashDistance)),
+				fingerprint("c", "c", lowBits(valueobject.MaxSimHashDistance+1)<<20),
+			},
+			want: []string{"near:a,b"},
+		},
+		{
+			name:        "zero threshold only groups equal SimHash",
+			maxDistance: 0,
+			fingerprints: []valueobject.DocumentFingerprint{
+				fingerprint("a", "a", 0xf0),
+				fingerprint("b", "b", 0xf0),
+				fingerprint("c", "c", 0xf1),
+			},
+			want: []string{"near:a,b"},
+		},
+		{
+			name:        "differing bits spread across bands",
+			maxDistance: 3,
+			fingerprints: []valueobject.DocumentFingerprint{
+				fingerprint("a", "a", 0),
+				fingerprint("b", "b", 1|1<<20|1<<40),
+				fingerprint("c", "c", 1|1<<20|1<<40|1<<60),
+			},
+			want: []string{"near:a,b,c"},
+		},
+		{
+			name:        "near duplicates are merged transitively",
+			maxDistance: 2,
+			fingerprints: []valueobject.DocumentFingerprint{
+				fingerprint("a", "a", 0),
+				fingerprint("b", "b", lowBits(2)),
+				fingerprint("c", "c", lowBits(4)),
+				fingerprint("d", "d", lowBits(7)),
+			},
+			want: []string{"near:a,b,c"},
+		},
+		{
+			name:        "exact duplicates are grouped and included in near group",
+			maxDistance: valueobject.DefaultSimHashDistance,
+			fingerprints: []valueobject.DocumentFingerprint{
+				fingerprint("a", "same", 0),
+				fingerprint("b", "other", lowBits(1)),
+				fingerprint("c", "same", 0),
+			},
+			want: []string{"exact:a,c", "near:a,c,b"},
+		},
+		{
+			name:        "empty content is ignored",
+			maxDistance: valueobject.DefaultSimHashDistance,
+			fingerprints: []valueobject.DocumentFingerprint{
+				fingerprint("a", "", 0),
+				fingerprint("b", "", 0),
+				fingerprint("c", "c", 0),
+			},
+			want: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := clusterIDs(ClusterDuplicates(tt.fingerprints, tt.maxDistance))
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ClusterDuplicates() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNearPairsMatchesBruteForce(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+
+	// 围绕少量中心随机翻转若干位，使结果中同时有阈值内外的指纹对
+	centers := []uint64{rng.Uint64(), rng.Uint64(), rng.Uint64()}
+	fps := make([]valueobject.ContentFingerprint, 200)
+	for i := range fps {
+		simHash := centers[i%len(centers)]
+		for flips := rng.Intn(valueobject.MaxSimHashDistance + 4); flips > 0; flips-- {
+			simHash ^= 1 << uint(rng.Intn(64))
+		}
+		fps[i] = valueobject.ContentFingerprint{SimHash: simHash}
+	}
+
+	for maxDistance := 0; maxDistance <= valueobject.MaxSimHashDistance; maxDistance++ {
+		t.Run(fmt.Sprintf("distance %d", maxDistance), func(t *testing.T) {
+			got := make(map[[2]int]bool)
+			for _, pair := range nearPairs(fps, maxDistance) {
+				got[pair] = true
+			}
+
+			want := 0
+			for i := range fps {
+				for j := 0; j < i; j++ {
+					if bits.OnesCount64(fps[i].SimHash^fps[j].SimHash) > maxDistance {
+						continue
+					}
+					want++
+					if !got[[2]int{j, i}] {
+						t.Errorf("pair (%d, %d) at distance %d is missing", j, i, fps[i].Distance(fps[j]))
+					}
+				}
+			}
+			if len(got) != want {
+				t.Errorf("nearPairs() returned %d pairs, want %d", len(got), want)
+			}
+		})
+	}
+}
@@ -11,24 +11,38 @@ import (
 // KnowledgeService 知识库领域服务
 // 领域服务处理跨实体的业务逻辑，或不适合放在实体中的业务逻辑
 type KnowledgeService struct {
-	kbRepo  repository.KnowledgeBaseRepository
-	docRepo repository.DocumentRepository
+	kbRepo     repository.KnowledgeBaseRepository
+	docRepo    repository.DocumentRepository
+	folderRepo repository.FolderRepository
+	tagRepo    repository.TagRepository
+	quotas     *QuotaService
 }
 
 // NewKnowledgeService 创建知识库领域服务
 func NewKnowledgeService(
 	kbRepo repository.KnowledgeBaseRepository,
 	docRepo repository.DocumentRepository,
+	folderRepo repository.FolderRepository,
+	tagRepo repository.TagRepository,
+	quotas *QuotaService,
 ) *KnowledgeService {
 	return &KnowledgeService{
-		kbRepo:  kbRepo,
-		docRepo: docRepo,
+		kbRepo:     kbRepo,
+		docRepo:    docRepo,
+		folderRepo: folderRepo,
+		tagRepo:    tagRepo,
+		quotas:     quotas,
 	}
 }
 
 // CreateKnowledgeBase 创建知识库
-// 包含业务规则验证：名称不能重复
+// 包含业务规则验证：名称不能重复，不能超出租户的知识库数量配额
+// 需要在事务中调用，占用的配额与知识库一起提交
 func (s *KnowledgeService) CreateKnowledgeBase(ctx context.Context, name, description string) (*entity.KnowledgeBase, error) {
+	if err := s.quotas.ReserveKnowledgeBase(ctx, 0, 0); err != nil {
+		return nil, err
+	}
+
 	// 检查名称是否已存在
 	exists, err := s.kbRepo.ExistsByName(ctx, name)
 	if err != nil {
@@ -52,14 +66,103 @@ func (s *KnowledgeService) CreateKnowledgeBase(ctx context.Context, name, descri
 	return kb, nil
 }
 
-// DeleteKnowledgeBase 删除知识库及其所有文档
+// DeleteKnowledgeBase 将知识库及其所有文档移入回收站
 // 这是一个跨聚合的操作，适合放在领域服务中
+// 会收集 KnowledgeBaseTrashedEvent 事件
 func (s *KnowledgeService) DeleteKnowledgeBase(ctx context.Context, kb *entity.KnowledgeBase) error {
-	// 先删除所有文档
-	if err := s.docRepo.DeleteByKnowledgeBaseID(ctx, kb.ID()); err != nil {
+	kb.MoveToTrash()
+
+	// 文档会标记为随知识库一起删除，恢复知识库时据此一并恢复，
+	// 之前单独移入回收站的文档不受影响
+	if err := s.kbRepo.Delete(ctx, kb.ID()); err != nil {
+		return err
+	}
+
+	return s.docRepo.DeleteByKnowledgeBaseID(ctx, kb.ID())
+}
+
+// PurgeKnowledgeBase 彻底删除知识库及其所有文档（包括回收站中的文档）和文件夹
+// 物理删除后数据不可恢复
+func (s *KnowledgeService) PurgeKnowledgeBase(ctx context.Context, kb *entity.KnowledgeBase) error {
+	// 先删除文档、文件夹和标签定义，再删除知识库（满足外键约束）
+	if err := s.docRepo.PurgeByKnowledgeBaseID(ctx, kb.ID()); err != nil {
+		return err
+	}
+	if err := s.folderRepo.DeleteByKnowledgeBaseID(ctx, kb.ID()); err != nil {
+		return err
+	}
+	if err := s.tagRepo.DeleteByKnowledgeBaseID(ctx, kb.ID()); err != nil {
+		return err
+	}
+
+	return s.kbRepo.Purge(ctx, kb.ID())
+}
+
+// ImportKnowledgeBase 保存从备份导入的知识库及其文档、文件夹和标签定义
+// 导入保留原有的ID，因此知识库ID、名称和文档ID都不能与现有数据（包括回收站）冲突，
+// 导入后的知识库数量、文档数量和内容字节数也不能超出租户的配额
+// 需要在事务中调用，占用的配额与知识库一起提交
+func (s *KnowledgeService) ImportKnowledgeBase(ctx context.Context, kb *entity.KnowledgeBase) error {
+	var contentBytes int64
+	for _, doc := range kb.Documents() {
+		contentBytes += doc.ContentBytes()
+	}
+	if err := s.quotas.ReserveKnowledgeBase(ctx, len(kb.Documents()), contentBytes); err != nil {
+		return err
+	}
+
+	existing, err := s.kbRepo.FindByID(ctx, kb.ID())
+	if err != nil {
+		return err
+	}
+	if existing == nil {
+		if existing, err = s.kbRepo.FindDeletedByID(ctx, kb.ID()); err != nil {
+			return err
+		}
+	}
+	if existing != nil {
+		return domain.ErrKnowledgeBaseIDExists
+	}
+
+	exists, err := s.kbRepo.ExistsByName(ctx, kb.Name())
+	if err != nil {
 		return err
 	}
+	if exists {
+		return domain.ErrKnowledgeBaseNameExists
+	}
+
+	for _, doc := range kb.Documents() {
+		found, err := s.docRepo.FindByID(ctx, doc.ID())
+		if err != nil {
+			return err
+		}
+		if found == nil {
+			if found, err = s.docRepo.FindDeletedByID(ctx, doc.ID()); err != nil {
+				return err
+			}
+		}
+		if found != nil {
+			return domain.ErrInvalidBackup
+		}
+	}
 
-	// 再删除知识库
-	return s.kbRepo.Delete(ctx, kb.ID())
+	// 先保存知识库，再保存其成员（满足外键约束）
+	if err := s.kbRepo.Save(ctx, kb); err != nil {
+		return err
+	}
+	for _, folder := range kb.Folders() {
+		if err := s.folderRepo.Save(ctx, folder); err != nil {
+			return err
+		}
+	}
+	if err := s.tagRepo.ReplaceAll(ctx, kb.ID(), kb.TagDefinitions()); err != nil {
+		return err
+	}
+	for _, doc := range kb.Documents() {
+		if err := s.docRepo.Save(ctx, doc); err != nil {
+			return err
+		}
+	}
+	return nil
 }
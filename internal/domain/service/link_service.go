@@ -0,0 +1,66 @@
+package service
+
+import (
+	"context"
+
+	"gozero-ddd/internal/domain/entity"
+	"gozero-ddd/internal/domain/event"
+	"gozero-ddd/internal/domain/repository"
+	"gozero-ddd/internal/domain/valueobject"
+)
+
+// LinkService 文档链接领域服务
+// 文档之间的引用可能跨越知识库，不适合放在单个聚合根中处理
+type LinkService struct {
+	linkRepo repository.DocumentLinkRepository
+}
+
+// NewLinkService 创建文档链接领域服务
+func NewLinkService(linkRepo repository.DocumentLinkRepository) *LinkService {
+	return &LinkService{
+		linkRepo: linkRepo,
+	}
+}
+
+// RefreshLinks 根据文档当前内容重建其出链
+// 在文档新增或内容更新后调用
+func (s *LinkService) RefreshLinks(ctx context.Context, doc *entity.Document) error {
+	return s.linkRepo.ReplaceLinks(ctx, doc.ID(), doc.KnowledgeBaseID(), doc.Links())
+}
+
+// BrokenLinkEvents 计算文档删除后失效的链接，为每个受影响的源文档生成 DocumentLinkBrokenEvent
+// kb 为删除文档后的知识库聚合，若知识库中仍有同名文档，则 Wiki 链接不视为失效
+func (s *LinkService) BrokenLinkEvents(ctx context.Context, kb *entity.KnowledgeBase, removed *entity.Document) ([]event.DomainEvent, error) {
+	backlinks, err := s.linkRepo.FindBacklinks(ctx, kb.ID(), removed.ID(), removed.Title())
+	if err != nil {
+		return nil, err
+	}
+
+	titleStillExists := false
+	for _, doc := range kb.Documents() {
+		if doc.Title() == removed.Title() {
+			titleStillExists = true
+			break
+		}
+	}
+
+	events := make([]event.DomainEvent, 0, len(backlinks))
+	for _, edge := range backlinks {
+		// 忽略文档对自身的引用
+		if edge.SourceDocumentID == removed.ID() {
+			continue
+		}
+		if edge.Link.Type == valueobject.LinkTypeWiki && titleStillExists {
+			continue
+		}
+		events = append(events, event.NewDocumentLinkBrokenEvent(
+			edge.SourceDocumentID,
+			edge.SourceKnowledgeBaseID,
+			removed.ID(),
+			removed.Title(),
+			edge.Link.Type.String(),
+		))
+	}
+
+	return events, nil
+}
@@ -0,0 +1,115 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"gozero-ddd/internal/domain"
+	"gozero-ddd/internal/domain/entity"
+	"gozero-ddd/internal/domain/repository"
+	"gozero-ddd/internal/domain/tenant"
+	"gozero-ddd/internal/domain/valueobject"
+)
+
+// QuotaService 租户配额领域服务
+// 按上下文中租户的配额策略和当前用量判断能否创建知识库、添加文档。
+// 校验时锁定租户的用量记录，并在同一事务中计入新占用的用量，
+// 同一租户的并发请求因此串行校验，不会一起超出配额；
+// 彻底清除释放的用量由事件处理器在提交后通过 Record 扣减
+type QuotaService struct {
+	usageRepo     repository.TenantUsageRepository
+	docRepo       repository.DocumentRepository
+	defaultPolicy valueobject.QuotaPolicy
+	policies      map[tenant.ID]valueobject.QuotaPolicy // 按租户覆盖的配额策略
+}
+
+// NewQuotaService 创建租户配额领域服务
+// policies 中没有的租户使用 defaultPolicy
+func NewQuotaService(
+	usageRepo repository.TenantUsageRepository,
+	docRepo repository.DocumentRepository,
+	defaultPolicy valueobject.QuotaPolicy,
+	policies map[tenant.ID]valueobject.QuotaPolicy,
+) *QuotaService {
+	return &QuotaService{
+		usageRepo:     usageRepo,
+		docRepo:       docRepo,
+		defaultPolicy: defaultPolicy,
+		policies:      policies,
+	}
+}
+
+// Policy 获取当前租户的配额策略
+func (s *QuotaService) Policy(ctx context.Context) valueobject.QuotaPolicy {
+	if policy, ok := s.policies[tenant.FromContext(ctx)]; ok {
+		return policy
+	}
+	return s.defaultPolicy
+}
+
+// Usage 获取当前租户的用量
+func (s *QuotaService) Usage(ctx context.Context) (valueobject.QuotaUsage, error) {
+	return s.usageRepo.Find(ctx)
+}
+
+// ReserveKnowledgeBase 校验当前租户能否再创建一个包含 documents 篇、共 contentBytes 字节文档的知识库，
+// 并将其计入租户用量。需要在创建知识库的事务中调用，事务回滚时占用的用量一并撤销
+// 超出配额时返回 ErrQuotaExceeded
+func (s *QuotaService) ReserveKnowledgeBase(ctx context.Context, documents int, contentBytes int64) error {
+	delta := valueobject.QuotaUsage{KnowledgeBases: 1, Documents: documents, ContentBytes: contentBytes}
+
+	policy := s.Policy(ctx)
+	if policy.IsUnlimited() {
+		return s.usageRepo.Add(ctx, delta)
+	}
+
+	usage, err := s.usageRepo.FindForUpdate(ctx)
+	if err != nil {
+		return err
+	}
+	if !policy.AllowsKnowledgeBases(usage, 1) {
+		return fmt.Errorf("%w: at most %d knowledge bases are allowed", domain.ErrQuotaExceeded, policy.MaxKnowledgeBases)
+	}
+	if !policy.AllowsDocuments(documents) {
+		return fmt.Errorf("%w: a knowledge base can hold at most %d documents", domain.ErrQuotaExceeded, policy.MaxDocumentsPerKnowledgeBase)
+	}
+	if !policy.AllowsContentBytes(usage, contentBytes) {
+		return fmt.Errorf("%w: total document content is limited to %d bytes", domain.ErrQuotaExceeded, policy.MaxContentBytes)
+	}
+	return s.usageRepo.Add(ctx, delta)
+}
+
+// ApplyTo 锁定当前租户的用量，并将配额策略、用量和知识库回收站中的文档数设置到知识库聚合根
+// 之后由聚合根在添加、更新和恢复文档时校验配额，需要在修改知识库的事务中调用，
+// 操作完成后通过 RecordUsed 在同一事务中计入新占用的用量
+func (s *QuotaService) ApplyTo(ctx context.Context, kb *entity.KnowledgeBase) error {
+	policy := s.Policy(ctx)
+	if policy.IsUnlimited() {
+		return nil
+	}
+
+	usage, err := s.usageRepo.FindForUpdate(ctx)
+	if err != nil {
+		return err
+	}
+	trashed, err := s.docRepo.CountDeleted(ctx, kb.ID())
+	if err != nil {
+		return err
+	}
+	kb.ApplyQuota(policy, usage, trashed)
+	return nil
+}
+
+// RecordUsed 将知识库聚合根在本次操作中新占用的用量计入当前租户的用量
+// 需要在修改知识库的事务中调用
+func (s *QuotaService) RecordUsed(ctx context.Context, kb *entity.KnowledgeBase) error {
+	return s.Record(ctx, kb.PullQuotaUsed())
+}
+
+// Record 按增减量更新当前租户的用量
+func (s *QuotaService) Record(ctx context.Context, delta valueobject.QuotaUsage) error {
+	if delta.IsZero() {
+		return nil
+	}
+	return s.usageRepo.Add(ctx, delta)
+}
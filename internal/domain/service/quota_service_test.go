@@ -0,0 +1,250 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"gozero-ddd/internal/domain"
+	"gozero-ddd/internal/domain/tenant"
+	"gozero-ddd/internal/domain/valueobject"
+)
+
+// usageTxKey 上下文中事务的键
+type usageTxKey struct{}
+
+// usageTx 模拟数据库事务：记录未提交的增减量和持有的行锁
+type usageTx struct {
+	pending map[tenant.ID]valueobject.QuotaUsage
+	locked  []*sync.Mutex
+}
+
+// lockingUsageRepository 模拟 SELECT ... FOR UPDATE 的租户用量仓储
+// FindForUpdate 持有租户的行锁直到事务结束，Add 的增减量在事务提交时生效
+type lockingUsageRepository struct {
+	mu      sync.Mutex
+	usage   map[tenant.ID]valueobject.QuotaUsage
+	rowLock map[tenant.ID]*sync.Mutex
+	locks   int // FindForUpdate 的调用次数
+}
+
+func newLockingUsageRepository() *lockingUsageRepository {
+	return &lockingUsageRepository{
+		usage:   make(map[tenant.ID]valueobject.QuotaUsage),
+		rowLock: make(map[tenant.ID]*sync.Mutex),
+	}
+}
+
+// transaction 在事务中执行 fn，fn 返回错误时回滚
+func (r *lockingUsageRepository) transaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	tx := &usageTx{pending: make(map[tenant.ID]valueobject.QuotaUsage)}
+	err := fn(context.WithValue(ctx, usageTxKey{}, tx))
+	if err == nil {
+		r.mu.Lock()
+		for id, delta := range tx.pending {
+			r.usage[id] = r.usage[id].Add(delta)
+		}
+		r.mu.Unlock()
+	}
+	for _, l := range tx.locked {
+		l.Unlock()
+	}
+	return err
+}
+
+func (r *lockingUsageRepository) Find(ctx context.Context) (valueobject.QuotaUsage, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.usage[tenant.FromContext(ctx)], nil
+}
+
+func (r *lockingUsageRepository) FindForUpdate(ctx context.Context) (valueobject.QuotaUsage, error) {
+	tx, ok := ctx.Value(usageTxKey{}).(*usageTx)
+	if !ok {
+		return valueobject.QuotaUsage{}, errors.New("FindForUpdate called outside a transaction")
+	}
+	id := tenant.FromContext(ctx)
+
+	r.mu.Lock()
+	r.locks++
+	l, ok := r.rowLock[id]
+	if !ok {
+		l = &sync.Mutex{}
+		r.rowLock[id] = l
+	}
+	r.mu.Unlock()
+
+	l.Lock()
+	tx.locked = append(tx.locked, l)
+	return r.Find(ctx)
+}
+
+func (r *lockingUsageRepository) Add(ctx context.Context, delta valueobject.QuotaUsage) error {
+	tx, ok := ctx.Value(usageTxKey{}).(*usageTx)
+	if !ok {
+		return errors.New("Add called outside a transaction")
+	}
+	id := tenant.FromContext(ctx)
+	tx.pending[id] = tx.pending[id].Add(delta)
+	return nil
+}
+
+func TestQuotaServiceReserveKnowledgeBase(t *testing.T) {
+	policy := valueobject.QuotaPolicy{MaxKnowledgeBases: 2, MaxDocumentsPerKnowledgeBase: 10, MaxContentBytes: 100}
+
+	type reservation struct {
+		tenant    tenant.ID
+		documents int
+		bytes     int64
+		wantErr   error
+	}
+	tests := []struct {
+		name         string
+		reservations []reservation
+		want         map[tenant.ID]valueobject.QuotaUsage
+	}{
+		{
+			name: "within quota",
+			reservations: []reservation{
+				{"acme", 3, 40, nil},
+				{"acme", 10, 60, nil},
+			},
+			want: map[tenant.ID]valueobject.QuotaUsage{"acme": {KnowledgeBases: 2, Documents: 13, ContentBytes: 100}},
+		},
+		{
+			name: "too many knowledge bases",
+			reservations: []reservation{
+				{"acme", 0, 0, nil},
+				{"acme", 0, 0, nil},
+				{"acme", 0, 0, domain.ErrQuotaExceeded},
+			},
+			want: map[tenant.ID]valueobject.QuotaUsage{"acme": {KnowledgeBases: 2}},
+		},
+		{
+			name: "too many documents in one knowledge base",
+			reservations: []reservation{
+				{"acme", 11, 0, domain.ErrQuotaExceeded},
+			},
+			want: map[tenant.ID]valueobject.QuotaUsage{},
+		},
+		{
+			name: "content bytes exceeded",
+			reservations: []reservation{
+				{"acme", 1, 80, nil},
+				{"acme", 1, 21, domain.ErrQuotaExceeded},
+			},
+			want: map[tenant.ID]valueobject.QuotaUsage{"acme": {KnowledgeBases: 1, Documents: 1, ContentBytes: 80}},
+		},
+		{
+			name: "tenants have separate usage",
+			reservations: []reservation{
+				{"acme", 0, 0, nil},
+				{"acme", 0, 0, nil},
+				{"globex", 0, 0, nil},
+			},
+			want: map[tenant.ID]valueobject.QuotaUsage{"acme": {KnowledgeBases: 2}, "globex": {KnowledgeBases: 1}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newLockingUsageRepository()
+			service := NewQuotaService(repo, nil, policy, nil)
+			for i, res := range tt.reservations {
+				ctx := tenant.WithID(context.Background(), res.tenant)
+				err := repo.transaction(ctx, func(ctx context.Context) error {
+					return service.ReserveKnowledgeBase(ctx, res.documents, res.bytes)
+				})
+				if !errors.Is(err, res.wantErr) {
+					t.Fatalf("reservation %d: error = %v, want %v", i, err, res.wantErr)
+				}
+			}
+			for id, want := range tt.want {
+				if got := repo.usage[id]; got != want {
+					t.Errorf("usage of %s = %+v, want %+v", id, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestQuotaServiceReserveKnowledgeBaseRollback(t *testing.T) {
+	repo := newLockingUsageRepository()
+	service := NewQuotaService(repo, nil, valueobject.QuotaPolicy{MaxKnowledgeBases: 1}, nil)
+	ctx := tenant.WithID(context.Background(), "acme")
+
+	// 创建知识库的后续步骤失败，事务回滚时占用的用量一并撤销
+	failed := errors.New("save failed")
+	err := repo.transaction(ctx, func(ctx context.Context) error {
+		if err := service.ReserveKnowledgeBase(ctx, 0, 0); err != nil {
+			return err
+		}
+		return failed
+	})
+	if !errors.Is(err, failed) {
+		t.Fatalf("error = %v, want %v", err, failed)
+	}
+
+	if err := repo.transaction(ctx, func(ctx context.Context) error {
+		return service.ReserveKnowledgeBase(ctx, 0, 0)
+	}); err != nil {
+		t.Fatalf("reservation after rollback: error = %v", err)
+	}
+}
+
+func TestQuotaServiceReserveKnowledgeBaseConcurrent(t *testing.T) {
+	const maxKnowledgeBases, attempts = 3, 20
+
+	repo := newLockingUsageRepository()
+	service := NewQuotaService(repo, nil, valueobject.QuotaPolicy{MaxKnowledgeBases: maxKnowledgeBases}, nil)
+	ctx := tenant.WithID(context.Background(), "acme")
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	succeeded := 0
+	for i := 0; i < attempts; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := repo.transaction(ctx, func(ctx context.Context) error {
+				return service.ReserveKnowledgeBase(ctx, 0, 0)
+			})
+			if err == nil {
+				mu.Lock()
+				succeeded++
+				mu.Unlock()
+			} else if !errors.Is(err, domain.ErrQuotaExceeded) {
+				t.Errorf("unexpected error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if succeeded != maxKnowledgeBases {
+		t.Errorf("%d reservations succeeded, want %d", succeeded, maxKnowledgeBases)
+	}
+	if got := repo.usage["acme"].KnowledgeBases; got != maxKnowledgeBases {
+		t.Errorf("knowledge base usage = %d, want %d", got, maxKnowledgeBases)
+	}
+}
+
+func TestQuotaServiceUnlimitedSkipsLock(t *testing.T) {
+	repo := newLockingUsageRepository()
+	service := NewQuotaService(repo, nil, valueobject.QuotaPolicy{}, nil)
+	ctx := tenant.WithID(context.Background(), "acme")
+
+	for i := 0; i < 3; i++ {
+		if err := repo.transaction(ctx, func(ctx context.Context) error {
+			return service.ReserveKnowledgeBase(ctx, 5, 10)
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if repo.locks != 0 {
+		t.Errorf("FindForUpdate called %d times for an unlimited policy, want 0", repo.locks)
+	}
+	if want := (valueobject.QuotaUsage{KnowledgeBases: 3, Documents: 15, ContentBytes: 30}); repo.usage["acme"] != want {
+		t.Errorf("usage = %+v, want %+v", repo.usage["acme"], want)
+	}
+}
@@ -0,0 +1,50 @@
+package tenant
+
+import (
+	"context"
+	"regexp"
+
+	"gozero-ddd/internal/domain"
+)
+
+// ID 租户标识
+// 同一部署中的多个业务单元以租户隔离数据：知识库、文档和 API Key 都归属于一个租户，
+// 仓储按上下文中的租户过滤所有查询
+type ID string
+
+// Default 默认租户
+// 请求没有指定租户，以及单租户部署中的存量数据都属于默认租户
+const Default ID = "default"
+
+// idPattern 租户标识格式：小写字母、数字、下划线和短横线，1-64 个字符
+var idPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,63}$`)
+
+// Parse 从字符串创建租户标识（带验证）
+func Parse(s string) (ID, error) {
+	if !idPattern.MatchString(s) {
+		return "", domain.ErrInvalidTenantID
+	}
+	return ID(s), nil
+}
+
+// String 转换为字符串
+func (id ID) String() string {
+	return string(id)
+}
+
+// contextKey 上下文键，使用私有类型避免与其他包冲突
+type contextKey struct{}
+
+// WithID 将租户放入上下文
+func WithID(ctx context.Context, id ID) context.Context {
+	return context.WithValue(ctx, contextKey{}, id)
+}
+
+// FromContext 从上下文中取出租户
+// 上下文中没有租户时（如后台任务、命令行工具）返回默认租户
+func FromContext(ctx context.Context) ID {
+	if id, ok := ctx.Value(contextKey{}).(ID); ok && id != "" {
+		return id
+	}
+	return Default
+}
@@ -0,0 +1,58 @@
+package valueobject
+
+import "errors"
+
+var (
+	ErrInvalidAPIKeyScope = errors.New("invalid api key scope, must be read, write or admin")
+)
+
+// APIKeyScope API Key 权限范围值对象
+// read 对应知识库查看者，write 对应编辑者，admin 对应所有者及维护操作
+type APIKeyScope string
+
+const (
+	// APIKeyScopeRead 只读访问
+	APIKeyScopeRead APIKeyScope = "read"
+	// APIKeyScopeWrite 管理文档、文件夹、标签和附件
+	APIKeyScopeWrite APIKeyScope = "write"
+	// APIKeyScopeAdmin 管理知识库本身，以及清理回收站等维护操作
+	APIKeyScopeAdmin APIKeyScope = "admin"
+)
+
+// APIKeyScopeFromString 从字符串创建权限范围（带验证）
+func APIKeyScopeFromString(s string) (APIKeyScope, error) {
+	scope := APIKeyScope(s)
+	if !scope.IsValid() {
+		return "", ErrInvalidAPIKeyScope
+	}
+	return scope, nil
+}
+
+// String 转换为字符串
+func (s APIKeyScope) String() string {
+	return string(s)
+}
+
+// IsValid 判断是否为合法权限范围
+func (s APIKeyScope) IsValid() bool {
+	switch s {
+	case APIKeyScopeRead, APIKeyScopeWrite, APIKeyScopeAdmin:
+		return true
+	default:
+		return false
+	}
+}
+
+// Role 权限范围对应的知识库成员角色
+func (s APIKeyScope) Role() MemberRole {
+	switch s {
+	case APIKeyScopeAdmin:
+		return MemberRoleOwner
+	case APIKeyScopeWrite:
+		return MemberRoleEditor
+	case APIKeyScopeRead:
+		return MemberRoleViewer
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,141 @@
+package valueobject
+
+import (
+	"hash/fnv"
+	"math/bits"
+	"strings"
+	"unicode"
+)
+
+const (
+	// DefaultSimHashDistance 判定近似重复的默认汉明距离上限
+	DefaultSimHashDistance = 8
+	// MaxSimHashDistance 允许的最大汉明距离上限，更大的距离已经没有区分度
+	MaxSimHashDistance = 16
+
+	// simHashShingleSize SimHash 特征使用的连续词元数量
+	simHashShingleSize = 3
+)
+
+// emptyContentHash 空内容的哈希，空内容不参与重复检测
+var emptyContentHash = ComputeContentHash(nil)
+
+// ContentFingerprint 文档内容指纹值对象
+// Hash 为规范化内容的 SHA-256，内容完全相同的文档 Hash 相同；
+// SimHash 为 64 位局部敏感哈希，内容相近的文档 SimHash 的汉明距离较小
+type ContentFingerprint struct {
+	Hash    ContentHash
+	SimHash uint64
+}
+
+// ComputeContentFingerprint 计算文档内容的指纹
+func ComputeContentFingerprint(content string) ContentFingerprint {
+	normalized := normalizeContent(content)
+	return ContentFingerprint{
+		Hash:    ComputeContentHash([]byte(normalized)),
+		SimHash: computeSimHash(contentTokens(normalized)),
+	}
+}
+
+// NormalizedContentHash 计算规范化内容的 SHA-256
+// 与 ComputeContentFingerprint 的 Hash 相同，只需要判断完全重复时使用
+func NormalizedContentHash(content string) ContentHash {
+	return ComputeContentHash([]byte(normalizeContent(content)))
+}
+
+// IsEmpty 判断是否为空内容的指纹
+func (f ContentFingerprint) IsEmpty() bool {
+	return f.Hash == emptyContentHash
+}
+
+// Distance 计算两个指纹 SimHash 的汉明距离
+func (f ContentFingerprint) Distance(other ContentFingerprint) int {
+	return bits.OnesCount64(f.SimHash ^ other.SimHash)
+}
+
+// DocumentFingerprint 文档的内容指纹
+// 用于在知识库内和跨知识库检测重复文档
+type DocumentFingerprint struct {
+	DocumentID      DocumentID
+	KnowledgeBaseID KnowledgeBaseID
+	Title           string
+	Fingerprint     ContentFingerprint
+}
+
+// normalizeContent 规范化内容：统一换行符，去掉行尾和首尾空白
+// 只有空白差异的内容视为相同
+func normalizeContent(content string) string {
+	content = strings.ReplaceAll(content, "\r\n", "\n")
+	content = strings.ReplaceAll(content, "\r", "\n")
+	lines := strings.Split(content, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRightFunc(line, unicode.IsSpace)
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
+
+// contentTokens 将内容切分为小写词元
+// 连续的字母和数字组成一个词元；中日韩文字没有空格分词，每个字单独作为一个词元；其余字符作为分隔符
+func contentTokens(content string) []string {
+	tokens := make([]string, 0)
+	var word strings.Builder
+	flush := func() {
+		if word.Len() > 0 {
+			tokens = append(tokens, word.String())
+			word.Reset()
+		}
+	}
+
+	for _, r := range strings.ToLower(content) {
+		switch {
+		case unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul):
+			flush()
+			tokens = append(tokens, string(r))
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			word.WriteRune(r)
+		default:
+			flush()
+		}
+	}
+	flush()
+
+	return tokens
+}
+
+// computeSimHash 以连续词元片段（shingle）为特征计算 SimHash
+// 词元少于片段长度时，整段内容作为一个特征
+func computeSimHash(tokens []string) uint64 {
+	if len(tokens) == 0 {
+		return 0
+	}
+	size := simHashShingleSize
+	if len(tokens) < size {
+		size = len(tokens)
+	}
+
+	var weights [64]int
+	h := fnv.New64a()
+	for i := 0; i+size <= len(tokens); i++ {
+		h.Reset()
+		for _, token := range tokens[i : i+size] {
+			h.Write([]byte(token))
+			h.Write([]byte{0})
+		}
+		sum := h.Sum64()
+		for b := 0; b < 64; b++ {
+			if sum&(1<<uint(b)) != 0 {
+				weights[b]++
+			} else {
+				weights[b]--
+			}
+		}
+	}
+
+	var simHash uint64
+	for b, w := range weights {
+		if w > 0 {
+			simHash |= 1 << uint(b)
+		}
+	}
+	return simHash
+}
@@ -0,0 +1,116 @@
+package valueobject
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// sampleParagraph 生成用于近似重复测试的正文，每句带序号，避免重复片段影响 SimHash
+func sampleParagraph(sentences int) string {
+	var b strings.Builder
+	for i := 0; i < sentences; i++ {
+		fmt.Fprintf(&b, "Step %d of the deployment guide explains how the service reads its configuration and connects to the database. ", i)
+	}
+	return b.String()
+}
+
+func TestComputeContentFingerprintIgnoresWhitespace(t *testing.T) {
+	base := "# Title\n\nfirst line\nsecond line"
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{"CRLF line endings", "# Title\r\n\r\nfirst line\r\nsecond line"},
+		{"CR line endings", "# Title\r\rfirst line\rsecond line"},
+		{"trailing spaces", "# Title  \n\nfirst line\t\nsecond line "},
+		{"leading and trailing blank lines", "\n\n# Title\n\nfirst line\nsecond line\n\n"},
+	}
+
+	want := ComputeContentFingerprint(base)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ComputeContentFingerprint(tt.content)
+			if got != want {
+				t.Errorf("ComputeContentFingerprint(%q) = %+v, want %+v", tt.content, got, want)
+			}
+			if h := NormalizedContentHash(tt.content); h != want.Hash {
+				t.Errorf("NormalizedContentHash(%q) = %s, want %s", tt.content, h, want.Hash)
+			}
+		})
+	}
+}
+
+func TestComputeContentFingerprintEmpty(t *testing.T) {
+	for _, content := range []string{"", " ", "\n\r\n\t"} {
+		fp := ComputeContentFingerprint(content)
+		if !fp.IsEmpty() {
+			t.Errorf("ComputeContentFingerprint(%q).IsEmpty() = false", content)
+		}
+		if fp.SimHash != 0 {
+			t.Errorf("ComputeContentFingerprint(%q).SimHash = %#x, want 0", content, fp.SimHash)
+		}
+	}
+	if ComputeContentFingerprint("x").IsEmpty() {
+		t.Error(`ComputeContentFingerprint("x").IsEmpty() = true`)
+	}
+}
+
+func TestContentFingerprintDistance(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b uint64
+		want int
+	}{
+		{"identical", 0xdeadbeef, 0xdeadbeef, 0},
+		{"lowest bit", 0, 1, 1},
+		{"highest bit", 0, 1 << 63, 1},
+		{"default threshold", 0, 1<<DefaultSimHashDistance - 1, DefaultSimHashDistance},
+		{"max threshold", 0, 1<<MaxSimHashDistance - 1, MaxSimHashDistance},
+		{"complement", 0x00ff00ff00ff00ff, 0xff00ff00ff00ff00, 64},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := ContentFingerprint{SimHash: tt.a}
+			b := ContentFingerprint{SimHash: tt.b}
+			if got := a.Distance(b); got != tt.want {
+				t.Errorf("Distance() = %d, want %d", got, tt.want)
+			}
+			if got := b.Distance(a); got != tt.want {
+				t.Errorf("Distance() is not symmetric: %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestContentFingerprintNearDuplicateThreshold(t *testing.T) {
+	base := sampleParagraph(40)
+	cjk := strings.Repeat("知识库用于管理文档和标签，支持全文检索。", 20)
+	tests := []struct {
+		name string
+		a, b string
+		near bool // 是否在默认阈值内
+	}{
+		{"case change", base, strings.ToUpper(base), true},
+		{"punctuation change", base, strings.ReplaceAll(base, ".", "!"), true},
+		{"one word changed", base, strings.Replace(base, "database", "cache", 1), true},
+		{"one sentence appended", base, base + "Finally restart the service.", true},
+		{"CJK one character changed", cjk + "版本一", cjk + "版本二", true},
+		{"unrelated content", base,
+			strings.Repeat("Kafka consumers commit offsets after each batch is processed successfully. ", 40), false},
+		{"unrelated CJK content", cjk, strings.Repeat("消息队列在处理完一批消息后提交偏移量。", 20), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := ComputeContentFingerprint(tt.a).Distance(ComputeContentFingerprint(tt.b))
+			if tt.near && d > DefaultSimHashDistance {
+				t.Errorf("distance = %d, want <= %d", d, DefaultSimHashDistance)
+			}
+			if !tt.near && d <= DefaultSimHashDistance {
+				t.Errorf("distance = %d, want > %d", d, DefaultSimHashDistance)
+			}
+		})
+	}
+}
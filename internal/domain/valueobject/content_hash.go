@@ -0,0 +1,37 @@
+package valueobject
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+)
+
+var ErrInvalidContentHash = errors.New("invalid content hash, expected hex encoded SHA-256")
+
+// ContentHash 内容哈希值对象（SHA-256，十六进制小写）
+// 相同内容的哈希相同，用于附件去重
+type ContentHash string
+
+// ComputeContentHash 计算内容的 SHA-256 哈希
+func ComputeContentHash(data []byte) ContentHash {
+	sum := sha256.Sum256(data)
+	return ContentHash(hex.EncodeToString(sum[:]))
+}
+
+// ContentHashFromString 从字符串创建内容哈希（带验证）
+func ContentHashFromString(s string) (ContentHash, error) {
+	if len(s) != sha256.Size*2 {
+		return "", ErrInvalidContentHash
+	}
+	for _, r := range s {
+		if !(r >= '0' && r <= '9' || r >= 'a' && r <= 'f') {
+			return "", ErrInvalidContentHash
+		}
+	}
+	return ContentHash(s), nil
+}
+
+// String 转换为字符串
+func (h ContentHash) String() string {
+	return string(h)
+}
@@ -0,0 +1,89 @@
+package valueobject
+
+import "errors"
+
+var (
+	ErrInvalidContentType   = errors.New("invalid content type")
+	ErrInvalidContentFormat = errors.New("invalid content format, expected html, text or raw")
+)
+
+// ContentType 文档内容类型值对象
+// 决定文档内容如何被渲染为 HTML 和纯文本
+type ContentType string
+
+const (
+	// ContentTypeMarkdown Markdown（默认）
+	ContentTypeMarkdown ContentType = "markdown"
+	// ContentTypeHTML HTML，渲染时会经过净化
+	ContentTypeHTML ContentType = "html"
+	// ContentTypeText 纯文本
+	ContentTypeText ContentType = "text"
+	// ContentTypeAsciiDoc AsciiDoc
+	ContentTypeAsciiDoc ContentType = "asciidoc"
+)
+
+// DefaultContentType 未指定内容类型时使用的默认值
+const DefaultContentType = ContentTypeMarkdown
+
+// ContentTypeFromString 从字符串创建内容类型（带验证），空字符串返回默认类型
+func ContentTypeFromString(s string) (ContentType, error) {
+	if s == "" {
+		return DefaultContentType, nil
+	}
+	t := ContentType(s)
+	if !t.IsValid() {
+		return "", ErrInvalidContentType
+	}
+	return t, nil
+}
+
+// String 转换为字符串
+func (t ContentType) String() string {
+	return string(t)
+}
+
+// IsValid 判断是否为合法的内容类型
+func (t ContentType) IsValid() bool {
+	switch t {
+	case ContentTypeMarkdown, ContentTypeHTML, ContentTypeText, ContentTypeAsciiDoc:
+		return true
+	default:
+		return false
+	}
+}
+
+// ContentFormat 文档内容的输出格式
+type ContentFormat string
+
+const (
+	// ContentFormatRaw 原始内容（默认）
+	ContentFormatRaw ContentFormat = "raw"
+	// ContentFormatHTML 渲染后的净化 HTML
+	ContentFormatHTML ContentFormat = "html"
+	// ContentFormatText 纯文本
+	ContentFormatText ContentFormat = "text"
+)
+
+// ContentFormatFromString 从字符串创建输出格式（带验证），空字符串返回 raw
+func ContentFormatFromString(s string) (ContentFormat, error) {
+	switch f := ContentFormat(s); f {
+	case "":
+		return ContentFormatRaw, nil
+	case ContentFormatRaw, ContentFormatHTML, ContentFormatText:
+		return f, nil
+	default:
+		return "", ErrInvalidContentFormat
+	}
+}
+
+// String 转换为字符串
+func (f ContentFormat) String() string {
+	return string(f)
+}
+
+// Heading 文档标题（目录项）值对象
+type Heading struct {
+	Level  int    `json:"level"`  // 标题级别 1-6
+	Text   string `json:"text"`   // 标题文本
+	Anchor string `json:"anchor"` // 渲染后 HTML 中的锚点 ID
+}
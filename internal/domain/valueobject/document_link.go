@@ -0,0 +1,90 @@
+package valueobject
+
+import (
+	"regexp"
+	"strings"
+)
+
+// LinkType 文档链接类型
+type LinkType string
+
+const (
+	// LinkTypeWiki Wiki 链接：[[文档标题]] 或 [[文档标题|显示文本]]，按标题在同一知识库内解析
+	LinkTypeWiki LinkType = "wiki"
+	// LinkTypeURL 路径链接：/knowledge/:id/documents/:doc_id，按文档ID解析
+	LinkTypeURL LinkType = "url"
+)
+
+// String 转换为字符串
+func (t LinkType) String() string {
+	return string(t)
+}
+
+var (
+	wikiLinkPattern = regexp.MustCompile(`\[\[([^\[\]|]+)(?:\|[^\[\]]*)?\]\]`)
+	urlLinkPattern  = regexp.MustCompile(`/knowledge/([0-9a-fA-F-]{36})/documents/([0-9a-fA-F-]{36})`)
+)
+
+// DocumentLink 文档链接值对象
+// 表示文档内容中对另一篇文档的一次引用
+type DocumentLink struct {
+	Type                  LinkType // 链接类型
+	TargetTitle           string   // Wiki 链接的目标标题
+	TargetKnowledgeBaseID string   // 路径链接的目标知识库ID
+	TargetDocumentID      string   // 路径链接的目标文档ID
+}
+
+// Target 返回链接目标的可读表示
+func (l DocumentLink) Target() string {
+	if l.Type == LinkTypeWiki {
+		return l.TargetTitle
+	}
+	return "/knowledge/" + l.TargetKnowledgeBaseID + "/documents/" + l.TargetDocumentID
+}
+
+// ParseDocumentLinks 从文档内容中解析链接
+// 同一目标只保留一次，顺序与在内容中首次出现的顺序一致
+func ParseDocumentLinks(content string) []DocumentLink {
+	links := make([]DocumentLink, 0)
+	seen := make(map[DocumentLink]bool)
+
+	add := func(link DocumentLink) {
+		if !seen[link] {
+			seen[link] = true
+			links = append(links, link)
+		}
+	}
+
+	for _, m := range wikiLinkPattern.FindAllStringSubmatch(content, -1) {
+		title := strings.TrimSpace(m[1])
+		if title == "" {
+			continue
+		}
+		add(DocumentLink{Type: LinkTypeWiki, TargetTitle: title})
+	}
+
+	for _, m := range urlLinkPattern.FindAllStringSubmatch(content, -1) {
+		kbID, err := KnowledgeBaseIDFromString(m[1])
+		if err != nil {
+			continue
+		}
+		docID, err := DocumentIDFromString(m[2])
+		if err != nil {
+			continue
+		}
+		add(DocumentLink{
+			Type:                  LinkTypeURL,
+			TargetKnowledgeBaseID: kbID.String(),
+			TargetDocumentID:      docID.String(),
+		})
+	}
+
+	return links
+}
+
+// DocumentLinkEdge 链接图中的一条边：源文档 -> 链接目标
+type DocumentLinkEdge struct {
+	SourceDocumentID      DocumentID
+	SourceKnowledgeBaseID KnowledgeBaseID
+	Link                  DocumentLink
+}
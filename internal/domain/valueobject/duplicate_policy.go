@@ -0,0 +1,41 @@
+package valueobject
+
+import "errors"
+
+var (
+	ErrInvalidDuplicatePolicy = errors.New("invalid duplicate policy, must be allow or reject_exact")
+)
+
+// DuplicatePolicy 知识库的重复文档策略值对象
+type DuplicatePolicy string
+
+const (
+	// DuplicatePolicyAllow 允许添加与已有文档内容相同的文档（默认）
+	DuplicatePolicyAllow DuplicatePolicy = "allow"
+	// DuplicatePolicyRejectExact 拒绝添加与知识库中已有文档内容完全相同的文档
+	DuplicatePolicyRejectExact DuplicatePolicy = "reject_exact"
+)
+
+// DuplicatePolicyFromString 从字符串创建重复文档策略（带验证）
+func DuplicatePolicyFromString(s string) (DuplicatePolicy, error) {
+	policy := DuplicatePolicy(s)
+	if !policy.IsValid() {
+		return "", ErrInvalidDuplicatePolicy
+	}
+	return policy, nil
+}
+
+// String 转换为字符串
+func (p DuplicatePolicy) String() string {
+	return string(p)
+}
+
+// IsValid 判断是否为合法策略
+func (p DuplicatePolicy) IsValid() bool {
+	switch p {
+	case DuplicatePolicyAllow, DuplicatePolicyRejectExact:
+		return true
+	default:
+		return false
+	}
+}
@@ -9,6 +9,9 @@ import (
 var (
 	ErrInvalidKnowledgeBaseID = errors.New("invalid knowledge base ID format")
 	ErrInvalidDocumentID      = errors.New("invalid document ID format")
+	ErrInvalidFolderID        = errors.New("invalid folder ID format")
+	ErrInvalidTagID           = errors.New("invalid tag ID format")
+	ErrInvalidAttachmentID    = errors.New("invalid attachment ID format")
 	ErrEmptyID                = errors.New("ID cannot be empty")
 )
 
@@ -82,3 +85,106 @@ func (id DocumentID) String() string {
 func (id DocumentID) IsEmpty() bool {
 	return string(id) == ""
 }
+
+// FolderID 文件夹ID值对象
+type FolderID string
+
+// NewFolderID 创建新的文件夹ID
+func NewFolderID() FolderID {
+	return FolderID(uuid.New().String())
+}
+
+// FolderIDFromString 从字符串创建文件夹ID（带验证）
+func FolderIDFromString(s string) (FolderID, error) {
+	if s == "" {
+		return "", ErrEmptyID
+	}
+	if _, err := uuid.Parse(s); err != nil {
+		return "", ErrInvalidFolderID
+	}
+	return FolderID(s), nil
+}
+
+// MustFolderIDFromString 从字符串创建文件夹ID（不验证，用于从数据库重建）
+// 仅在确定数据来源可靠时使用（如从数据库读取）
+func MustFolderIDFromString(s string) FolderID {
+	return FolderID(s)
+}
+
+// String 转换为字符串
+func (id FolderID) String() string {
+	return string(id)
+}
+
+// IsEmpty 判断ID是否为空
+func (id FolderID) IsEmpty() bool {
+	return string(id) == ""
+}
+
+// TagID 标签定义ID值对象
+type TagID string
+
+// NewTagID 创建新的标签定义ID
+func NewTagID() TagID {
+	return TagID(uuid.New().String())
+}
+
+// TagIDFromString 从字符串创建标签定义ID（带验证）
+func TagIDFromString(s string) (TagID, error) {
+	if s == "" {
+		return "", ErrEmptyID
+	}
+	if _, err := uuid.Parse(s); err != nil {
+		return "", ErrInvalidTagID
+	}
+	return TagID(s), nil
+}
+
+// MustTagIDFromString 从字符串创建标签定义ID（不验证，用于从数据库重建）
+func MustTagIDFromString(s string) TagID {
+	return TagID(s)
+}
+
+// String 转换为字符串
+func (id TagID) String() string {
+	return string(id)
+}
+
+// IsEmpty 判断ID是否为空
+func (id TagID) IsEmpty() bool {
+	return string(id) == ""
+}
+
+// AttachmentID 附件ID值对象
+type AttachmentID string
+
+// NewAttachmentID 创建新的附件ID
+func NewAttachmentID() AttachmentID {
+	return AttachmentID(uuid.New().String())
+}
+
+// AttachmentIDFromString 从字符串创建附件ID（带验证）
+func AttachmentIDFromString(s string) (AttachmentID, error) {
+	if s == "" {
+		return "", ErrEmptyID
+	}
+	if _, err := uuid.Parse(s); err != nil {
+		return "", ErrInvalidAttachmentID
+	}
+	return AttachmentID(s), nil
+}
+
+// MustAttachmentIDFromString 从字符串创建附件ID（不验证，用于从数据库重建）
+func MustAttachmentIDFromString(s string) AttachmentID {
+	return AttachmentID(s)
+}
+
+// String 转换为字符串
+func (id AttachmentID) String() string {
+	return string(id)
+}
+
+// IsEmpty 判断ID是否为空
+func (id AttachmentID) IsEmpty() bool {
+	return string(id) == ""
+}
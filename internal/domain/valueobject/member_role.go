@@ -0,0 +1,58 @@
+package valueobject
+
+import "errors"
+
+var (
+	ErrInvalidMemberRole = errors.New("invalid member role, must be owner, editor or viewer")
+)
+
+// MemberRole 知识库成员角色值对象
+// 权限由高到低：owner > editor > viewer，高级角色拥有低级角色的全部权限
+type MemberRole string
+
+const (
+	// MemberRoleOwner 所有者：管理知识库本身（更新、删除、状态变更）和成员
+	MemberRoleOwner MemberRole = "owner"
+	// MemberRoleEditor 编辑者：管理文档、文件夹、标签和附件
+	MemberRoleEditor MemberRole = "editor"
+	// MemberRoleViewer 查看者：只读访问
+	MemberRoleViewer MemberRole = "viewer"
+)
+
+// MemberRoleFromString 从字符串创建成员角色（带验证）
+func MemberRoleFromString(s string) (MemberRole, error) {
+	role := MemberRole(s)
+	if !role.IsValid() {
+		return "", ErrInvalidMemberRole
+	}
+	return role, nil
+}
+
+// String 转换为字符串
+func (r MemberRole) String() string {
+	return string(r)
+}
+
+// IsValid 判断是否为合法角色
+func (r MemberRole) IsValid() bool {
+	return r.level() > 0
+}
+
+// Includes 判断该角色是否拥有 required 角色的权限
+func (r MemberRole) Includes(required MemberRole) bool {
+	return r.IsValid() && r.level() >= required.level()
+}
+
+// level 角色级别，非法角色为 0
+func (r MemberRole) level() int {
+	switch r {
+	case MemberRoleOwner:
+		return 3
+	case MemberRoleEditor:
+		return 2
+	case MemberRoleViewer:
+		return 1
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,52 @@
+package valueobject
+
+// QuotaPolicy 租户配额策略
+// 限制租户的知识库数量、单个知识库的文档数量和文档内容总字节数，0 表示不限制。
+// 回收站中的知识库和文档在被彻底清除之前仍然计入用量
+type QuotaPolicy struct {
+	MaxKnowledgeBases            int   // 知识库数量上限
+	MaxDocumentsPerKnowledgeBase int   // 单个知识库的文档数量上限
+	MaxContentBytes              int64 // 文档内容总字节数上限
+}
+
+// IsUnlimited 是否不做任何限制
+func (p QuotaPolicy) IsUnlimited() bool {
+	return p.MaxKnowledgeBases <= 0 && p.MaxDocumentsPerKnowledgeBase <= 0 && p.MaxContentBytes <= 0
+}
+
+// AllowsKnowledgeBases 判断在当前用量的基础上能否再创建 n 个知识库
+func (p QuotaPolicy) AllowsKnowledgeBases(usage QuotaUsage, n int) bool {
+	return p.MaxKnowledgeBases <= 0 || usage.KnowledgeBases+n <= p.MaxKnowledgeBases
+}
+
+// AllowsDocuments 判断单个知识库能否容纳 count 篇文档
+func (p QuotaPolicy) AllowsDocuments(count int) bool {
+	return p.MaxDocumentsPerKnowledgeBase <= 0 || count <= p.MaxDocumentsPerKnowledgeBase
+}
+
+// AllowsContentBytes 判断在当前用量的基础上能否再增加 n 字节的文档内容
+func (p QuotaPolicy) AllowsContentBytes(usage QuotaUsage, n int64) bool {
+	return p.MaxContentBytes <= 0 || n <= 0 || usage.ContentBytes+n <= p.MaxContentBytes
+}
+
+// QuotaUsage 租户资源用量
+// 也用于表示用量的增减量
+type QuotaUsage struct {
+	KnowledgeBases int   // 知识库数量（包括回收站中的）
+	Documents      int   // 文档数量（包括回收站中的）
+	ContentBytes   int64 // 文档内容总字节数
+}
+
+// Add 返回加上增减量后的用量
+func (u QuotaUsage) Add(delta QuotaUsage) QuotaUsage {
+	return QuotaUsage{
+		KnowledgeBases: u.KnowledgeBases + delta.KnowledgeBases,
+		Documents:      u.Documents + delta.Documents,
+		ContentBytes:   u.ContentBytes + delta.ContentBytes,
+	}
+}
+
+// IsZero 是否没有任何用量（或增减量）
+func (u QuotaUsage) IsZero() bool {
+	return u == QuotaUsage{}
+}
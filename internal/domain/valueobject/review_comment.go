@@ -0,0 +1,22 @@
+package valueobject
+
+import "time"
+
+// ReviewComment 评审意见值对象
+// 记录文档在发布流程中每一次评审的结论，不可变
+type ReviewComment struct {
+	Action    string    `json:"action"`     // 评审动作：approve / reject
+	Reviewer  string    `json:"reviewer"`   // 评审人
+	Comment   string    `json:"comment"`    // 评审意见
+	CreatedAt time.Time `json:"created_at"` // 评审时间
+}
+
+// NewReviewComment 创建评审意见
+func NewReviewComment(action, reviewer, comment string) ReviewComment {
+	return ReviewComment{
+		Action:    action,
+		Reviewer:  reviewer,
+		Comment:   comment,
+		CreatedAt: time.Now(),
+	}
+}
@@ -0,0 +1,133 @@
+package valueobject
+
+import "errors"
+
+var (
+	ErrInvalidKnowledgeBaseStatus = errors.New("invalid knowledge base status")
+)
+
+// KnowledgeBaseStatus 知识库生命周期状态值对象
+type KnowledgeBaseStatus string
+
+const (
+	// KnowledgeBaseStatusActive 正常：可以读写
+	KnowledgeBaseStatusActive KnowledgeBaseStatus = "active"
+	// KnowledgeBaseStatusReadOnly 只读：可以查看，不能修改信息和文档
+	KnowledgeBaseStatusReadOnly KnowledgeBaseStatus = "read_only"
+	// KnowledgeBaseStatusArchived 已归档：冻结的历史知识库，不能修改
+	KnowledgeBaseStatusArchived KnowledgeBaseStatus = "archived"
+)
+
+// KnowledgeBaseStatusFromString 从字符串创建知识库状态（带验证）
+func KnowledgeBaseStatusFromString(s string) (KnowledgeBaseStatus, error) {
+	status := KnowledgeBaseStatus(s)
+	if !status.IsValid() {
+		return "", ErrInvalidKnowledgeBaseStatus
+	}
+	return status, nil
+}
+
+// String 转换为字符串
+func (s KnowledgeBaseStatus) String() string {
+	return string(s)
+}
+
+// IsValid 判断是否为合法状态
+func (s KnowledgeBaseStatus) IsValid() bool {
+	switch s {
+	case KnowledgeBaseStatusActive, KnowledgeBaseStatusReadOnly, KnowledgeBaseStatusArchived:
+		return true
+	default:
+		return false
+	}
+}
+
+// CanTransitionTo 判断是否允许从当前状态转换到目标状态
+// 允许的转换：
+//   - active    -> read_only, archived
+//   - read_only -> active, archived
+//   - archived  -> active（取消归档）
+func (s KnowledgeBaseStatus) CanTransitionTo(target KnowledgeBaseStatus) bool {
+	switch s {
+	case KnowledgeBaseStatusActive:
+		return target == KnowledgeBaseStatusReadOnly || target == KnowledgeBaseStatusArchived
+	case KnowledgeBaseStatusReadOnly:
+		return target == KnowledgeBaseStatusActive || target == KnowledgeBaseStatusArchived
+	case KnowledgeBaseStatusArchived:
+		return target == KnowledgeBaseStatusActive
+	default:
+		return false
+	}
+}
+
+var (
+	ErrInvalidDocumentStatus = errors.New("invalid document status")
+)
+
+// DocumentStatus 文档发布状态值对象
+// 文档的发布流程：草稿 -> 待审核 -> 已审核 -> 已发布 -> 已过期
+type DocumentStatus string
+
+const (
+	// DocumentStatusDraft 草稿：新建或撤回发布的文档
+	DocumentStatusDraft DocumentStatus = "draft"
+	// DocumentStatusInReview 待审核：作者已提交，等待评审
+	DocumentStatusInReview DocumentStatus = "in_review"
+	// DocumentStatusApproved 已审核：评审通过，等待发布
+	DocumentStatusApproved DocumentStatus = "approved"
+	// DocumentStatusRejected 已驳回：评审未通过，作者修改后可重新提交
+	DocumentStatusRejected DocumentStatus = "rejected"
+	// DocumentStatusPublished 已发布：对 API 消费方可见
+	DocumentStatusPublished DocumentStatus = "published"
+	// DocumentStatusExpired 已过期：到达下线时间后自动下线，不再对 API 消费方可见
+	DocumentStatusExpired DocumentStatus = "expired"
+)
+
+// DocumentStatusFromString 从字符串创建文档状态（带验证）
+func DocumentStatusFromString(s string) (DocumentStatus, error) {
+	status := DocumentStatus(s)
+	if !status.IsValid() {
+		return "", ErrInvalidDocumentStatus
+	}
+	return status, nil
+}
+
+// String 转换为字符串
+func (s DocumentStatus) String() string {
+	return string(s)
+}
+
+// IsValid 判断是否为合法状态
+func (s DocumentStatus) IsValid() bool {
+	switch s {
+	case DocumentStatusDraft, DocumentStatusInReview, DocumentStatusApproved,
+		DocumentStatusRejected, DocumentStatusPublished, DocumentStatusExpired:
+		return true
+	default:
+		return false
+	}
+}
+
+// CanTransitionTo 判断是否允许从当前状态转换到目标状态
+// 允许的转换：
+//   - draft / rejected -> in_review（提交审核）
+//   - in_review        -> approved（审核通过）/ rejected（驳回）
+//   - approved         -> published（发布）
+//   - published        -> draft（撤回发布）/ expired（到期下线）
+//   - expired          -> draft（重新编辑）
+func (s DocumentStatus) CanTransitionTo(target DocumentStatus) bool {
+	switch s {
+	case DocumentStatusDraft, DocumentStatusRejected:
+		return target == DocumentStatusInReview
+	case DocumentStatusInReview:
+		return target == DocumentStatusApproved || target == DocumentStatusRejected
+	case DocumentStatusApproved:
+		return target == DocumentStatusPublished
+	case DocumentStatusPublished:
+		return target == DocumentStatusDraft || target == DocumentStatusExpired
+	case DocumentStatusExpired:
+		return target == DocumentStatusDraft
+	default:
+		return false
+	}
+}
@@ -0,0 +1,85 @@
+package valueobject
+
+import (
+	"errors"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// MaxTagLength 标签的最大长度（按字符计）
+const MaxTagLength = 64
+
+var (
+	ErrTagEmpty   = errors.New("tag cannot be empty")
+	ErrTagTooLong = errors.New("tag is too long")
+	ErrInvalidTag = errors.New("tag may only contain letters, digits and - _ . + # / :")
+)
+
+// Tag 标签值对象
+// 标签在创建时统一规范化：去除首尾空白、转为小写、连续空白替换为 "-"，
+// 因此 "Go"、" go " 与 "GO" 是同一个标签
+type Tag string
+
+// NewTag 从原始输入创建规范化的标签（带验证）
+func NewTag(raw string) (Tag, error) {
+	s := strings.ToLower(strings.TrimSpace(raw))
+	s = strings.Join(strings.Fields(s), "-")
+	if s == "" {
+		return "", ErrTagEmpty
+	}
+	if utf8.RuneCountInString(s) > MaxTagLength {
+		return "", ErrTagTooLong
+	}
+	for _, r := range s {
+		if !isTagRune(r) {
+			return "", ErrInvalidTag
+		}
+	}
+	return Tag(s), nil
+}
+
+// MustTag 从字符串创建标签（不验证，用于从数据库重建）
+func MustTag(s string) Tag {
+	return Tag(s)
+}
+
+// NewTags 批量创建标签，按首次出现的顺序去重
+func NewTags(raw []string) ([]Tag, error) {
+	tags := make([]Tag, 0, len(raw))
+	seen := make(map[Tag]bool, len(raw))
+	for _, r := range raw {
+		tag, err := NewTag(r)
+		if err != nil {
+			return nil, err
+		}
+		if seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		tags = append(tags, tag)
+	}
+	return tags, nil
+}
+
+// TagStrings 将标签列表转换为字符串列表
+func TagStrings(tags []Tag) []string {
+	result := make([]string, len(tags))
+	for i, tag := range tags {
+		result[i] = tag.String()
+	}
+	return result
+}
+
+// String 转换为字符串
+func (t Tag) String() string {
+	return string(t)
+}
+
+// isTagRune 判断字符是否允许出现在标签中
+func isTagRune(r rune) bool {
+	if unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.IsMark(r) {
+		return true
+	}
+	return strings.ContainsRune("-_.+#/:", r)
+}
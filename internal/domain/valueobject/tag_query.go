@@ -0,0 +1,311 @@
+package valueobject
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// 标签查询的复杂度限制，防止构造过大的表达式拖慢数据库
+const (
+	MaxTagQueryTerms = 32 // 最多包含的标签条件数
+	MaxTagQueryDepth = 16 // 最大嵌套深度
+)
+
+var (
+	ErrInvalidTagQuery    = errors.New("invalid tag query")
+	ErrTagQueryTooComplex = errors.New("tag query is too complex")
+)
+
+// TagExpr 标签查询表达式（抽象语法树节点）
+// 语法：
+//
+//	expr   := or
+//	or     := and { OR and }
+//	and    := unary { AND unary }
+//	unary  := NOT unary | "(" expr ")" | term
+//	term   := tag:<标签> | tag:"<标签>"
+//
+// 关键字不区分大小写，例如：tag:go AND (tag:grpc OR tag:kafka) AND NOT tag:deprecated
+type TagExpr interface {
+	// Matches 判断标签集合是否满足表达式（内存中求值）
+	Matches(tags []string) bool
+
+	// String 返回规范化的查询字符串
+	String() string
+}
+
+// TagTerm 单个标签条件：文档包含该标签
+type TagTerm struct {
+	Tag Tag
+}
+
+// TagAnd 逻辑与
+type TagAnd struct {
+	Left, Right TagExpr
+}
+
+// TagOr 逻辑或
+type TagOr struct {
+	Left, Right TagExpr
+}
+
+// TagNot 逻辑非
+type TagNot struct {
+	Expr TagExpr
+}
+
+// Matches 文档标签中包含该标签
+// 引入标签规范化之前写入的标签可能未规范化，这里按规范化后的值比较；
+// 数据库中的查询依赖迁移时已规范化的存量标签（见 persistence.NormalizeDocumentTags），两者结果一致
+func (t *TagTerm) Matches(tags []string) bool {
+	for _, s := range tags {
+		if s == t.Tag.String() {
+			return true
+		}
+		if normalized, err := NewTag(s); err == nil && normalized == t.Tag {
+			return true
+		}
+	}
+	return false
+}
+
+func (t *TagTerm) String() string {
+	return "tag:" + t.Tag.String()
+}
+
+func (e *TagAnd) Matches(tags []string) bool {
+	return e.Left.Matches(tags) && e.Right.Matches(tags)
+}
+
+func (e *TagAnd) String() string {
+	return "(" + e.Left.String() + " AND " + e.Right.String() + ")"
+}
+
+func (e *TagOr) Matches(tags []string) bool {
+	return e.Left.Matches(tags) || e.Right.Matches(tags)
+}
+
+func (e *TagOr) String() string {
+	return "(" + e.Left.String() + " OR " + e.Right.String() + ")"
+}
+
+func (e *TagNot) Matches(tags []string) bool {
+	return !e.Expr.Matches(tags)
+}
+
+func (e *TagNot) String() string {
+	return "NOT " + e.Expr.String()
+}
+
+// MapTagExpr 返回将表达式中每个标签经 fn 替换后的新表达式
+// 用于将别名解析为标签注册表中的规范名称
+func MapTagExpr(expr TagExpr, fn func(Tag) Tag) TagExpr {
+	switch e := expr.(type) {
+	case *TagTerm:
+		return &TagTerm{Tag: fn(e.Tag)}
+	case *TagAnd:
+		return &TagAnd{Left: MapTagExpr(e.Left, fn), Right: MapTagExpr(e.Right, fn)}
+	case *TagOr:
+		return &TagOr{Left: MapTagExpr(e.Left, fn), Right: MapTagExpr(e.Right, fn)}
+	case *TagNot:
+		return &TagNot{Expr: MapTagExpr(e.Expr, fn)}
+	default:
+		return expr
+	}
+}
+
+// ParseTagQuery 解析标签查询字符串
+func ParseTagQuery(s string) (TagExpr, error) {
+	tokens, err := tokenizeTagQuery(s)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("%w: empty query", ErrInvalidTagQuery)
+	}
+
+	p := &tagQueryParser{tokens: tokens}
+	expr, err := p.parseOr(0)
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.tokens) {
+		return nil, fmt.Errorf("%w: unexpected %q", ErrInvalidTagQuery, p.tokens[p.pos].text)
+	}
+	return expr, nil
+}
+
+// ==================== 词法分析 ====================
+
+type tagTokenKind int
+
+const (
+	tagTokenTerm tagTokenKind = iota
+	tagTokenAnd
+	tagTokenOr
+	tagTokenNot
+	tagTokenLParen
+	tagTokenRParen
+)
+
+type tagToken struct {
+	kind tagTokenKind
+	text string // 原始文本，用于错误信息
+	tag  Tag    // 仅 tagTokenTerm 有效
+}
+
+// tokenizeTagQuery 将查询字符串切分为词法单元
+func tokenizeTagQuery(s string) ([]tagToken, error) {
+	tokens := make([]tagToken, 0)
+	runes := []rune(s)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '(':
+			tokens = append(tokens, tagToken{kind: tagTokenLParen, text: "("})
+			i++
+		case r == ')':
+			tokens = append(tokens, tagToken{kind: tagTokenRParen, text: ")"})
+			i++
+		default:
+			start := i
+			for i < len(runes) && !unicode.IsSpace(runes[i]) && runes[i] != '(' && runes[i] != ')' {
+				if runes[i] == '"' {
+					// 引号内的内容（可包含空白和括号）作为一个整体
+					end := i + 1
+					for end < len(runes) && runes[end] != '"' {
+						end++
+					}
+					if end >= len(runes) {
+						return nil, fmt.Errorf("%w: unterminated quote", ErrInvalidTagQuery)
+					}
+					i = end
+				}
+				i++
+			}
+			token, err := newTagToken(string(runes[start:i]))
+			if err != nil {
+				return nil, err
+			}
+			tokens = append(tokens, token)
+		}
+	}
+	return tokens, nil
+}
+
+// newTagToken 识别关键字或标签条件
+func newTagToken(word string) (tagToken, error) {
+	switch strings.ToUpper(word) {
+	case "AND":
+		return tagToken{kind: tagTokenAnd, text: word}, nil
+	case "OR":
+		return tagToken{kind: tagTokenOr, text: word}, nil
+	case "NOT":
+		return tagToken{kind: tagTokenNot, text: word}, nil
+	}
+
+	field, value, ok := strings.Cut(word, ":")
+	if !ok || !strings.EqualFold(field, "tag") {
+		return tagToken{}, fmt.Errorf("%w: expected tag:<name>, got %q", ErrInvalidTagQuery, word)
+	}
+	value = strings.TrimSuffix(strings.TrimPrefix(value, `"`), `"`)
+
+	tag, err := NewTag(value)
+	if err != nil {
+		return tagToken{}, fmt.Errorf("%w: %q: %v", ErrInvalidTagQuery, word, err)
+	}
+	return tagToken{kind: tagTokenTerm, text: word, tag: tag}, nil
+}
+
+// ==================== 语法分析 ====================
+
+// tagQueryParser 递归下降解析器
+type tagQueryParser struct {
+	tokens []tagToken
+	pos    int
+	terms  int
+}
+
+func (p *tagQueryParser) peek() *tagToken {
+	if p.pos < len(p.tokens) {
+		return &p.tokens[p.pos]
+	}
+	return nil
+}
+
+func (p *tagQueryParser) parseOr(depth int) (TagExpr, error) {
+	left, err := p.parseAnd(depth)
+	if err != nil {
+		return nil, err
+	}
+	for t := p.peek(); t != nil && t.kind == tagTokenOr; t = p.peek() {
+		p.pos++
+		right, err := p.parseAnd(depth)
+		if err != nil {
+			return nil, err
+		}
+		left = &TagOr{Left: left, Right: right}
+	}
+	return left, nil
+}
+
+func (p *tagQueryParser) parseAnd(depth int) (TagExpr, error) {
+	left, err := p.parseUnary(depth)
+	if err != nil {
+		return nil, err
+	}
+	for t := p.peek(); t != nil && t.kind == tagTokenAnd; t = p.peek() {
+		p.pos++
+		right, err := p.parseUnary(depth)
+		if err != nil {
+			return nil, err
+		}
+		left = &TagAnd{Left: left, Right: right}
+	}
+	return left, nil
+}
+
+func (p *tagQueryParser) parseUnary(depth int) (TagExpr, error) {
+	if depth > MaxTagQueryDepth {
+		return nil, ErrTagQueryTooComplex
+	}
+
+	t := p.peek()
+	if t == nil {
+		return nil, fmt.Errorf("%w: unexpected end of query", ErrInvalidTagQuery)
+	}
+
+	switch t.kind {
+	case tagTokenNot:
+		p.pos++
+		expr, err := p.parseUnary(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		return &TagNot{Expr: expr}, nil
+	case tagTokenLParen:
+		p.pos++
+		expr, err := p.parseOr(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		if t := p.peek(); t == nil || t.kind != tagTokenRParen {
+			return nil, fmt.Errorf("%w: missing closing parenthesis", ErrInvalidTagQuery)
+		}
+		p.pos++
+		return expr, nil
+	case tagTokenTerm:
+		p.pos++
+		p.terms++
+		if p.terms > MaxTagQueryTerms {
+			return nil, ErrTagQueryTooComplex
+		}
+		return &TagTerm{Tag: t.tag}, nil
+	default:
+		return nil, fmt.Errorf("%w: unexpected %q", ErrInvalidTagQuery, t.text)
+	}
+}
@@ -0,0 +1,169 @@
+package valueobject
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestParseTagQueryPrecedence(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		want  string
+	}{
+		{"single term", "tag:go", "tag:go"},
+		{"AND binds tighter than OR", "tag:a OR tag:b AND tag:c", "(tag:a OR (tag:b AND tag:c))"},
+		{"AND before OR on the left", "tag:a AND tag:b OR tag:c", "((tag:a AND tag:b) OR tag:c)"},
+		{"NOT binds tighter than AND", "NOT tag:a AND tag:b", "(NOT tag:a AND tag:b)"},
+		{"NOT binds tighter than OR", "tag:a OR NOT tag:b", "(tag:a OR NOT tag:b)"},
+		{"OR is left associative", "tag:a OR tag:b OR tag:c", "((tag:a OR tag:b) OR tag:c)"},
+		{"AND is left associative", "tag:a AND tag:b AND tag:c", "((tag:a AND tag:b) AND tag:c)"},
+		{"parentheses override precedence", "tag:a AND (tag:b OR tag:c)", "(tag:a AND (tag:b OR tag:c))"},
+		{"NOT applies to a group", "NOT (tag:a OR tag:b)", "NOT (tag:a OR tag:b)"},
+		{"double NOT", "NOT NOT tag:a", "NOT NOT tag:a"},
+		{"keywords are case insensitive", "tag:a and not tag:b Or tag:c", "((tag:a AND NOT tag:b) OR tag:c)"},
+		{"field is case insensitive", "TAG:Go", "tag:go"},
+		{"quoted tag with spaces", `tag:"Machine Learning"`, "tag:machine-learning"},
+		{"no spaces around parentheses", "(tag:a)AND(tag:b)", "(tag:a AND tag:b)"},
+		{"example from the docs", "tag:go AND (tag:grpc OR tag:kafka) AND NOT tag:deprecated",
+			"((tag:go AND (tag:grpc OR tag:kafka)) AND NOT tag:deprecated)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expr, err := ParseTagQuery(tt.query)
+			if err != nil {
+				t.Fatalf("ParseTagQuery(%q) error = %v", tt.query, err)
+			}
+			if got := expr.String(); got != tt.want {
+				t.Errorf("ParseTagQuery(%q) = %s, want %s", tt.query, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseTagQueryInvalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+	}{
+		{"empty", ""},
+		{"only spaces", "   "},
+		{"missing field", "go"},
+		{"unknown field", "title:go"},
+		{"empty tag", "tag:"},
+		{"invalid tag character", "tag:a*b"},
+		{"dangling AND", "tag:a AND"},
+		{"dangling OR", "tag:a OR"},
+		{"dangling NOT", "NOT"},
+		{"leading AND", "AND tag:a"},
+		{"missing operator", "tag:a tag:b"},
+		{"missing closing parenthesis", "(tag:a OR tag:b"},
+		{"unexpected closing parenthesis", "tag:a)"},
+		{"empty parentheses", "()"},
+		{"unterminated quote", `tag:"go`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseTagQuery(tt.query)
+			if !errors.Is(err, ErrInvalidTagQuery) {
+				t.Errorf("ParseTagQuery(%q) error = %v, want %v", tt.query, err, ErrInvalidTagQuery)
+			}
+		})
+	}
+}
+
+func TestParseTagQueryLimits(t *testing.T) {
+	terms := func(n int) string {
+		parts := make([]string, n)
+		for i := range parts {
+			parts[i] = "tag:t" + strings.Repeat("x", i%3)
+		}
+		return strings.Join(parts, " OR ")
+	}
+
+	tests := []struct {
+		name    string
+		query   string
+		wantErr error
+	}{
+		{"max terms", terms(MaxTagQueryTerms), nil},
+		{"too many terms", terms(MaxTagQueryTerms + 1), ErrTagQueryTooComplex},
+		{"max NOT depth", strings.Repeat("NOT ", MaxTagQueryDepth) + "tag:a", nil},
+		{"NOT too deep", strings.Repeat("NOT ", MaxTagQueryDepth+1) + "tag:a", ErrTagQueryTooComplex},
+		{"max parenthesis depth",
+			strings.Repeat("(", MaxTagQueryDepth) + "tag:a" + strings.Repeat(")", MaxTagQueryDepth), nil},
+		{"parentheses too deep",
+			strings.Repeat("(", MaxTagQueryDepth+1) + "tag:a" + strings.Repeat(")", MaxTagQueryDepth+1), ErrTagQueryTooComplex},
+		{"mixed NOT and parentheses too deep",
+			strings.Repeat("NOT (", MaxTagQueryDepth/2+1) + "tag:a" + strings.Repeat(")", MaxTagQueryDepth/2+1), ErrTagQueryTooComplex},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseTagQuery(tt.query)
+			if tt.wantErr == nil && err != nil {
+				t.Fatalf("ParseTagQuery() error = %v, want nil", err)
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ParseTagQuery() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestTagExprMatches(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		tags  []string
+		want  bool
+	}{
+		{"term present", "tag:go", []string{"go", "grpc"}, true},
+		{"term absent", "tag:go", []string{"rust"}, false},
+		{"no tags", "tag:go", nil, false},
+		{"unnormalized stored tag", "tag:machine-learning", []string{"Machine Learning"}, true},
+		{"AND both present", "tag:go AND tag:grpc", []string{"go", "grpc"}, true},
+		{"AND one missing", "tag:go AND tag:grpc", []string{"go"}, false},
+		{"OR one present", "tag:go OR tag:rust", []string{"rust"}, true},
+		{"NOT present", "NOT tag:deprecated", []string{"deprecated"}, false},
+		{"NOT absent", "NOT tag:deprecated", []string{"go"}, true},
+		{"precedence without parentheses", "tag:a OR tag:b AND tag:c", []string{"a"}, true},
+		{"precedence with parentheses", "(tag:a OR tag:b) AND tag:c", []string{"a"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expr, err := ParseTagQuery(tt.query)
+			if err != nil {
+				t.Fatalf("ParseTagQuery(%q) error = %v", tt.query, err)
+			}
+			if got := expr.Matches(tt.tags); got != tt.want {
+				t.Errorf("%s Matches(%v) = %v, want %v", tt.query, tt.tags, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMapTagExpr(t *testing.T) {
+	expr, err := ParseTagQuery("tag:golang AND NOT (tag:js OR tag:rust)")
+	if err != nil {
+		t.Fatal(err)
+	}
+	aliases := map[Tag]Tag{"golang": "go", "js": "javascript"}
+	mapped := MapTagExpr(expr, func(tag Tag) Tag {
+		if canonical, ok := aliases[tag]; ok {
+			return canonical
+		}
+		return tag
+	})
+
+	if got, want := mapped.String(), "(tag:go AND NOT (tag:javascript OR tag:rust))"; got != want {
+		t.Errorf("MapTagExpr() = %s, want %s", got, want)
+	}
+	if got, want := expr.String(), "(tag:golang AND NOT (tag:js OR tag:rust))"; got != want {
+		t.Errorf("original expression changed to %s, want %s", got, want)
+	}
+}
@@ -0,0 +1,29 @@
+package blobstore
+
+import (
+	"errors"
+	"strings"
+)
+
+// ErrInvalidKey 键不合法
+var ErrInvalidKey = errors.New("invalid blob key")
+
+// validateKey 校验键的格式
+// 键由 "/" 分隔的若干段组成，每段只允许字母、数字和 "._-"，且不能为 "." 或 ".."
+// 这样本地存储不会越出根目录，对象存储也无需额外转义
+func validateKey(key string) error {
+	if key == "" || strings.HasPrefix(key, "/") {
+		return ErrInvalidKey
+	}
+	for _, seg := range strings.Split(key, "/") {
+		if seg == "" || seg == "." || seg == ".." {
+			return ErrInvalidKey
+		}
+		for _, r := range seg {
+			if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '.' || r == '_' || r == '-') {
+				return ErrInvalidKey
+			}
+		}
+	}
+	return nil
+}
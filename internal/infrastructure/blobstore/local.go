@@ -0,0 +1,112 @@
+package blobstore
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"gozero-ddd/internal/domain"
+	"gozero-ddd/internal/domain/repository"
+)
+
+// LocalBlobStore 本地文件系统存储
+// 每个键对应根目录下的一个文件，写入时先写临时文件再重命名，保证读取方不会看到写了一半的内容
+type LocalBlobStore struct {
+	root string
+}
+
+// 确保实现了接口
+var _ repository.BlobStore = (*LocalBlobStore)(nil)
+
+// NewLocalBlobStore 创建本地文件系统存储，根目录不存在时自动创建
+func NewLocalBlobStore(root string) (*LocalBlobStore, error) {
+	if err := os.MkdirAll(root, 0o755); err != nil {
+		return nil, err
+	}
+	return &LocalBlobStore{root: root}, nil
+}
+
+// path 将键转换为文件路径
+func (s *LocalBlobStore) path(key string) (string, error) {
+	if err := validateKey(key); err != nil {
+		return "", err
+	}
+	return filepath.Join(s.root, filepath.FromSlash(key)), nil
+}
+
+// Put 写入内容
+func (s *LocalBlobStore) Put(ctx context.Context, key string, r io.Reader, size int64) error {
+	p, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	dir := filepath.Dir(p)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(dir, ".upload-*")
+	if err != nil {
+		return err
+	}
+	// 重命名成功后临时文件已不存在，Remove 只在失败时生效
+	defer os.Remove(tmp.Name())
+
+	written, err := io.Copy(tmp, r)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+	if size >= 0 && written != size {
+		return fmt.Errorf("blob size mismatch: expected %d bytes, wrote %d", size, written)
+	}
+
+	return os.Rename(tmp.Name(), p)
+}
+
+// Open 打开内容用于读取
+func (s *LocalBlobStore) Open(ctx context.Context, key string) (io.ReadCloser, error) {
+	p, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(p)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, domain.ErrBlobNotFound
+		}
+		return nil, err
+	}
+	return f, nil
+}
+
+// Exists 检查键是否存在
+func (s *LocalBlobStore) Exists(ctx context.Context, key string) (bool, error) {
+	p, err := s.path(key)
+	if err != nil {
+		return false, err
+	}
+	if _, err := os.Stat(p); err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// Delete 删除内容
+func (s *LocalBlobStore) Delete(ctx context.Context, key string) error {
+	p, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(p); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
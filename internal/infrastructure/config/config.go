@@ -14,6 +14,14 @@ type Config struct {
 	Redis         RedisConfig `json:",optional"` // Redis 配置
 	Kafka         KafkaConfig `json:",optional"` // Kafka 配置
 	UseKafka      bool        `json:",default=false"` // 是否使用 Kafka 事件总线
+	Trash         TrashConfig `json:",optional"` // 回收站配置
+	Scheduler     SchedulerConfig `json:",optional"` // 文档定时发布配置
+	BlobStore     BlobStoreConfig `json:",optional"` // 附件存储配置
+	Idempotency   IdempotencyConfig `json:",optional"` // 幂等键配置
+	Auth          AuthConfig `json:",optional"` // 认证配置
+	Quota         QuotaConfig `json:",optional"` // 租户配额配置
+	RateLimit     RateLimitConfig `json:",optional"` // 限流配置
+	Metrics       MetricsConfig `json:",optional"` // 监控指标配置
 }
 
 // RpcConfig gRPC 服务配置
@@ -21,8 +29,17 @@ type Config struct {
 type RpcConfig struct {
 	zrpc.RpcServerConf             // go-zero gRPC 服务配置
 	MySQL              MySQLConfig `json:",optional"` // MySQL 配置
+	Redis              RedisConfig `json:",optional"` // Redis 配置（限流器使用）
 	Kafka              KafkaConfig `json:",optional"` // Kafka 配置
 	UseKafka           bool        `json:",default=false"` // 是否使用 Kafka 事件总线
+	Trash              TrashConfig `json:",optional"` // 回收站配置
+	Scheduler          SchedulerConfig `json:",optional"` // 文档定时发布配置
+	BlobStore          BlobStoreConfig `json:",optional"` // 附件存储配置（清理回收站时需要删除附件内容）
+	Idempotency        IdempotencyConfig `json:",optional"` // 幂等键配置
+	Auth               AuthConfig `json:",optional"` // 认证配置
+	Quota              QuotaConfig `json:",optional"` // 租户配额配置
+	RateLimit          RateLimitConfig `json:",optional"` // 限流配置
+	Metrics            MetricsConfig `json:",optional"` // 监控指标配置
 }
 
 // MySQLConfig MySQL 数据库配置
@@ -51,3 +68,103 @@ type KafkaConfig struct {
 	Async           bool          `json:",default=false"`          // 是否异步发送
 	AutoCreateTopic bool          `json:",default=true"`           // 是否自动创建主题
 }
+
+// TrashConfig 回收站配置
+// 删除的知识库和文档先进入回收站，超过保留期后由定时任务彻底清除
+type TrashConfig struct {
+	RetentionDays  int           `json:",default=30"`   // 回收站保留天数
+	PurgeInterval  time.Duration `json:",default=1h"`   // 清理任务执行间隔
+	EnablePurgeJob bool          `json:",default=true"` // 是否启用定时清理任务
+}
+
+// SchedulerConfig 文档定时发布/下线配置
+type SchedulerConfig struct {
+	Interval time.Duration `json:",default=1m"`   // 扫描到期文档的间隔
+	Enabled  bool          `json:",default=true"` // 是否启用调度器
+}
+
+// BlobStoreConfig 附件存储配置
+// 附件的二进制内容按内容哈希保存，相同内容只存一份
+type BlobStoreConfig struct {
+	Driver   string   `json:",default=local,options=local|s3"` // 存储驱动：local（本地文件系统）/ s3（S3 兼容对象存储）
+	LocalDir string   `json:",default=data/blobs"`             // 本地存储根目录
+	S3       S3Config `json:",optional"`                       // S3 兼容存储配置
+}
+
+// IdempotencyConfig 幂等键配置
+// 写请求携带 Idempotency-Key 时，响应按键保存在数据库中，过期后由定时任务清除
+type IdempotencyConfig struct {
+	TTL              time.Duration `json:",default=24h"`  // 幂等键保留时间
+	CleanupInterval  time.Duration `json:",default=1h"`   // 清理过期记录的间隔
+	EnableCleanupJob bool          `json:",default=true"` // 是否启用定时清理任务
+}
+
+// AuthConfig 认证配置
+// 启用后 REST 和 gRPC 接口都要求携带 JWT 访问令牌；
+// HS256 令牌使用共享密钥校验，RS256 令牌使用本地 JWKS 文件中的公钥校验，两者可以同时配置
+type AuthConfig struct {
+	Enabled  bool          `json:",default=false"` // 是否启用认证
+	Secret   string        `json:",optional"`      // HS256 共享密钥
+	JWKSFile string        `json:",optional"`      // RS256 公钥 JWKS 文件路径
+	Issuer   string        `json:",optional"`      // 要求的签发方（iss），为空时不校验
+	Audience string        `json:",optional"`      // 要求的受众（aud），为空时不校验
+	Leeway   time.Duration `json:",default=30s"`   // 校验有效期时允许的时钟偏差
+}
+
+// QuotaConfig 租户配额配置
+// 限制每个租户的知识库数量、单个知识库的文档数量和文档内容总字节数，0 表示不限制；
+// Tenants 中列出的租户使用单独的配额，其余租户使用默认配额
+type QuotaConfig struct {
+	MaxKnowledgeBases            int                 `json:",default=0"` // 知识库数量上限
+	MaxDocumentsPerKnowledgeBase int                 `json:",default=0"` // 单个知识库的文档数量上限
+	MaxContentBytes              int64               `json:",default=0"` // 文档内容总字节数上限
+	Tenants                      []TenantQuotaConfig `json:",optional"`  // 按租户覆盖的配额
+}
+
+// TenantQuotaConfig 单个租户的配额配置
+type TenantQuotaConfig struct {
+	Tenant                       string // 租户标识
+	MaxKnowledgeBases            int    `json:",default=0"` // 知识库数量上限
+	MaxDocumentsPerKnowledgeBase int    `json:",default=0"` // 单个知识库的文档数量上限
+	MaxContentBytes              int64  `json:",default=0"` // 文档内容总字节数上限
+}
+
+// RateLimitConfig 限流配置
+// 按调用方（API Key、用户或客户端 IP）使用令牌桶限流，每个请求按路由消耗令牌；
+// 认证之前另按客户端 IP 限流，认证失败的请求同样计数；
+// memory 驱动的令牌桶保存在进程内，多实例部署时使用 redis 驱动共享令牌桶
+type RateLimitConfig struct {
+	Enabled   bool              `json:",default=false"`                       // 是否启用限流
+	Driver    string            `json:",default=memory,options=memory|redis"` // 令牌桶存储：memory / redis（使用 Redis 配置）
+	Rate      float64           `json:",default=10"`                          // 每秒补充的令牌数
+	Burst     int               `json:",default=50"`                          // 令牌桶容量（允许的最大突发消耗）
+	ReadCost  int               `json:",default=1"`                           // 读请求消耗的令牌数
+	WriteCost int               `json:",default=5"`                           // 写请求消耗的令牌数
+	IPRate    float64           `json:",default=20"`                          // 认证之前按客户端 IP 每秒补充的令牌数，为 0 时不启用
+	IPBurst   int               `json:",default=100"`                         // 认证之前按客户端 IP 的令牌桶容量
+	Routes    []RouteCostConfig `json:",optional"`                            // 按路由覆盖的消耗
+}
+
+// RouteCostConfig 单个路由消耗的令牌数
+type RouteCostConfig struct {
+	Route string // REST 路由（如 "POST /api/v1/knowledge/:id/import"）或 gRPC 方法全名
+	Cost  int    // 消耗的令牌数
+}
+
+// MetricsConfig 监控指标配置
+// 指标由配置文件 Prometheus 节启动的 go-zero 指标端点暴露，未配置 Prometheus 时不采集
+type MetricsConfig struct {
+	InventoryInterval time.Duration `json:",default=1m"` // 刷新知识库和文档总数的间隔
+}
+
+// S3Config S3 兼容对象存储配置
+// 本地开发和测试可以使用 MinIO 等兼容实现作为替身
+type S3Config struct {
+	Endpoint        string        `json:",optional"`          // 服务地址，如 http://127.0.0.1:9000
+	Region          string        `json:",default=us-east-1"` // 区域
+	Bucket          string        `json:",optional"`          // 存储桶
+	AccessKeyID     string        `json:",optional"`          // 访问密钥ID
+	SecretAccessKey string        `json:",optional"`          // 访问密钥
+	UsePathStyle    bool          `json:",default=true"`      // 使用路径风格地址
+	Timeout         time.Duration `json:",default=30s"`       // 单次请求超时
+}
@@ -1,19 +1,33 @@
 package container
 
 import (
+	"context"
 	"log"
+	"time"
 
+	"github.com/go-redis/redis/v8"
 	"gorm.io/driver/mysql"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
 
+	"gozero-ddd/internal/application/auth"
 	"gozero-ddd/internal/application/eventhandler"
+	"gozero-ddd/internal/application/metrics"
+	"gozero-ddd/internal/application/ratelimit"
+	"gozero-ddd/internal/application/tracing"
 	"gozero-ddd/internal/domain/event"
 	"gozero-ddd/internal/domain/repository"
 	"gozero-ddd/internal/domain/service"
+	"gozero-ddd/internal/domain/tenant"
+	"gozero-ddd/internal/domain/valueobject"
+	"gozero-ddd/internal/infrastructure/blobstore"
+	"gozero-ddd/internal/infrastructure/config"
 	"gozero-ddd/internal/infrastructure/eventbus"
+	"gozero-ddd/internal/infrastructure/jwtauth"
 	"gozero-ddd/internal/infrastructure/persistence"
 	"gozero-ddd/internal/infrastructure/persistence/model"
+	ratelimitstore "gozero-ddd/internal/infrastructure/ratelimit"
+	"gozero-ddd/internal/infrastructure/render"
 )
 
 // InfraConfig 基础设施配置接口
@@ -21,8 +35,18 @@ import (
 type InfraConfig interface {
 	GetMySQLDataSource() string
 	IsAutoMigrate() bool
+	GetBlobStoreConfig() config.BlobStoreConfig
+	GetMaxAttachmentBytes() int64
+	GetIdempotencyTTL() time.Duration
+	GetAuthConfig() config.AuthConfig
+	GetQuotaConfig() config.QuotaConfig
+	GetRedisConfig() config.RedisConfig
+	GetRateLimitConfig() config.RateLimitConfig
 }
 
+// DefaultMaxAttachmentBytes 未配置时的附件大小上限
+const DefaultMaxAttachmentBytes = 10 << 20 // 10MB
+
 // InfrastructureContainer 基础设施层容器
 // 负责管理所有基础设施层的组件：数据库、仓储、事件总线等
 // 这些组件对上层（应用层）是透明的，上层只依赖接口
@@ -39,9 +63,41 @@ type InfrastructureContainer struct {
 	// 仓储接口（注意：这里是接口类型，不是具体实现）
 	KnowledgeBaseRepo repository.KnowledgeBaseRepository
 	DocumentRepo      repository.DocumentRepository
+	FolderRepo        repository.FolderRepository
+	DocumentLinkRepo  repository.DocumentLinkRepository
+	TagRepo           repository.TagRepository
+	AttachmentRepo    repository.AttachmentRepository
+	FingerprintRepo   repository.DocumentFingerprintRepository
+	MemberRepo        repository.KnowledgeBaseMemberRepository
+	APIKeyRepo        repository.APIKeyRepository
+	TenantUsageRepo   repository.TenantUsageRepository
+	AuditLogRepo      repository.AuditLogRepository
+
+	// 附件二进制内容存储
+	BlobStore          repository.BlobStore
+	MaxAttachmentBytes int64
+
+	// 幂等键记录
+	IdempotencyRepo repository.IdempotencyRepository
+	IdempotencyTTL  time.Duration
 
 	// 领域服务（领域层，但由基础设施层组装）
-	KnowledgeService *service.KnowledgeService
+	KnowledgeService  *service.KnowledgeService
+	LinkService       *service.LinkService
+	AttachmentService *service.AttachmentService
+	DuplicateService  *service.DuplicateService
+	QuotaService      *service.QuotaService
+
+	// 文档内容渲染器
+	ContentRenderer service.ContentRenderer
+
+	// 访问令牌校验器，未启用认证时为 nil
+	TokenVerifier auth.TokenVerifier
+
+	// 限流器，未启用限流时为 nil
+	RateLimiter     ratelimit.Limiter
+	RateLimitPolicy ratelimit.Policy
+	redisClient     *redis.Client
 }
 
 // NewInfrastructureContainer 创建基础设施层容器
@@ -52,11 +108,20 @@ func NewInfrastructureContainer(cfg InfraConfig) *InfrastructureContainer {
 	// 1. 初始化存储层（仓储和工作单元）
 	container.initStorage(cfg)
 
-	// 2. 初始化事件总线
+	// 2. 初始化附件存储
+	container.initBlobStore(cfg)
+
+	// 3. 初始化领域服务（事件处理器依赖领域服务）
+	container.initDomainServices(cfg)
+
+	// 4. 初始化事件总线
 	container.initEventBus()
 
-	// 3. 初始化领域服务
-	container.initDomainServices()
+	// 5. 初始化访问令牌校验器
+	container.initTokenVerifier(cfg)
+
+	// 6. 初始化限流器
+	container.initRateLimiter(cfg)
 
 	return container
 }
@@ -82,21 +147,154 @@ func (c *InfrastructureContainer) initStorage(cfg InfraConfig) {
 	// 自动迁移表结构（开发环境使用）
 	if cfg.IsAutoMigrate() {
 		log.Println("🔄 [Infrastructure] 自动迁移数据库表结构...")
-		if err := c.db.AutoMigrate(&model.KnowledgeBaseModel{}, &model.DocumentModel{}); err != nil {
+		if err := c.db.AutoMigrate(&model.KnowledgeBaseModel{}, &model.DocumentModel{}, &model.FolderModel{}, &model.DocumentLinkModel{}, &model.TagModel{}, &model.AttachmentModel{}, &model.DocumentFingerprintModel{}, &model.IdempotencyKeyModel{}, &model.KnowledgeBaseMemberModel{}, &model.APIKeyModel{}, &model.TenantUsageModel{}, &model.AuditLogModel{}); err != nil {
+			log.Fatalf("❌ 数据库迁移失败: %v", err)
+		}
+		// 知识库名称改为在租户内唯一，删除旧的全局唯一索引（AutoMigrate 和 init.sql 创建的）
+		for _, index := range []string{"idx_knowledge_bases_name", "uk_name"} {
+			if !c.db.Migrator().HasIndex(&model.KnowledgeBaseModel{}, index) {
+				continue
+			}
+			if err := c.db.Migrator().DropIndex(&model.KnowledgeBaseModel{}, index); err != nil {
+				log.Fatalf("❌ 数据库迁移失败: %v", err)
+			}
+		}
+		// 规范化引入标签规范化之前写入的文档标签，使数据库中的标签查询与内存求值结果一致
+		normalized, err := persistence.NormalizeDocumentTags(context.Background(), c.db)
+		if err != nil {
 			log.Fatalf("❌ 数据库迁移失败: %v", err)
 		}
+		if normalized > 0 {
+			log.Printf("🏷️  [Infrastructure] 已规范化 %d 篇文档的标签", normalized)
+		}
+	}
+
+	// 注册链路追踪插件（在迁移之后注册，迁移语句不记录 span）
+	if err := c.db.Use(persistence.NewTracingPlugin()); err != nil {
+		log.Fatalf("❌ 注册 GORM 链路追踪插件失败: %v", err)
 	}
 
 	// 创建工作单元（事务管理）
 	c.UnitOfWork = persistence.NewGormUnitOfWork(c.db)
 
 	// 创建仓储实例
-	c.DocumentRepo = persistence.NewGormDocumentRepository(c.db)
-	c.KnowledgeBaseRepo = persistence.NewGormKnowledgeBaseRepository(c.db, c.DocumentRepo)
+	// 每个仓储都包装一层链路追踪，每次调用一个 span，SQL 语句的 span 挂在其下；
+	// 知识库仓储使用包装后的文档、文件夹、标签和附件仓储，加载聚合时的子查询也有各自的 span
+	c.DocumentRepo = tracing.NewDocumentRepository(persistence.NewGormDocumentRepository(c.db))
+	c.FolderRepo = tracing.NewFolderRepository(persistence.NewGormFolderRepository(c.db))
+	c.TagRepo = tracing.NewTagRepository(persistence.NewGormTagRepository(c.db))
+	c.AttachmentRepo = tracing.NewAttachmentRepository(persistence.NewGormAttachmentRepository(c.db))
+	c.DocumentLinkRepo = tracing.NewDocumentLinkRepository(persistence.NewGormDocumentLinkRepository(c.db))
+	c.FingerprintRepo = tracing.NewDocumentFingerprintRepository(persistence.NewGormDocumentFingerprintRepository(c.db))
+	c.KnowledgeBaseRepo = tracing.NewKnowledgeBaseRepository(persistence.NewGormKnowledgeBaseRepository(c.db, c.DocumentRepo, c.FolderRepo, c.TagRepo, c.AttachmentRepo))
+	c.IdempotencyRepo = tracing.NewIdempotencyRepository(persistence.NewGormIdempotencyRepository(c.db))
+	c.MemberRepo = tracing.NewKnowledgeBaseMemberRepository(persistence.NewGormKnowledgeBaseMemberRepository(c.db))
+	c.APIKeyRepo = tracing.NewAPIKeyRepository(persistence.NewGormAPIKeyRepository(c.db))
+	c.TenantUsageRepo = tracing.NewTenantUsageRepository(persistence.NewGormTenantUsageRepository(c.db))
+	c.AuditLogRepo = tracing.NewAuditLogRepository(persistence.NewGormAuditLogRepository(c.db))
+	c.IdempotencyTTL = cfg.GetIdempotencyTTL()
 
 	log.Println("✅ [Infrastructure] 存储层初始化完成")
 }
 
+// initBlobStore 初始化附件存储
+func (c *InfrastructureContainer) initBlobStore(cfg InfraConfig) {
+	blobCfg := cfg.GetBlobStoreConfig()
+
+	var err error
+	switch blobCfg.Driver {
+	case "s3":
+		log.Printf("🗄️  [Infrastructure] 初始化 S3 附件存储: %s/%s", blobCfg.S3.Endpoint, blobCfg.S3.Bucket)
+		c.BlobStore, err = blobstore.NewS3BlobStore(blobstore.S3Options{
+			Endpoint:        blobCfg.S3.Endpoint,
+			Region:          blobCfg.S3.Region,
+			Bucket:          blobCfg.S3.Bucket,
+			AccessKeyID:     blobCfg.S3.AccessKeyID,
+			SecretAccessKey: blobCfg.S3.SecretAccessKey,
+			UsePathStyle:    blobCfg.S3.UsePathStyle,
+			Timeout:         blobCfg.S3.Timeout,
+		}, nil)
+	default:
+		dir := blobCfg.LocalDir
+		if dir == "" {
+			dir = "data/blobs"
+		}
+		log.Printf("🗄️  [Infrastructure] 初始化本地附件存储: %s", dir)
+		c.BlobStore, err = blobstore.NewLocalBlobStore(dir)
+	}
+	if err != nil {
+		log.Fatalf("❌ 初始化附件存储失败: %v", err)
+	}
+
+	c.MaxAttachmentBytes = cfg.GetMaxAttachmentBytes()
+	if c.MaxAttachmentBytes <= 0 {
+		c.MaxAttachmentBytes = DefaultMaxAttachmentBytes
+	}
+}
+
+// initTokenVerifier 初始化访问令牌校验器
+func (c *InfrastructureContainer) initTokenVerifier(cfg InfraConfig) {
+	authCfg := cfg.GetAuthConfig()
+	if !authCfg.Enabled {
+		log.Println("⚠️  [Infrastructure] 未启用认证，所有接口允许匿名访问")
+		return
+	}
+
+	verifier, err := jwtauth.NewVerifier(jwtauth.Options{
+		Secret:   authCfg.Secret,
+		JWKSFile: authCfg.JWKSFile,
+		Issuer:   authCfg.Issuer,
+		Audience: authCfg.Audience,
+		Leeway:   authCfg.Leeway,
+	})
+	if err != nil {
+		log.Fatalf("❌ 初始化 JWT 认证失败: %v", err)
+	}
+	c.TokenVerifier = verifier
+	log.Println("🔐 [Infrastructure] JWT 认证已启用")
+}
+
+// initRateLimiter 初始化限流器
+func (c *InfrastructureContainer) initRateLimiter(cfg InfraConfig) {
+	rlCfg := cfg.GetRateLimitConfig()
+	if !rlCfg.Enabled {
+		return
+	}
+	if rlCfg.Rate <= 0 || rlCfg.Burst <= 0 {
+		log.Fatalf("❌ 限流配置无效: Rate 和 Burst 必须大于 0")
+	}
+
+	routes := make(map[string]int, len(rlCfg.Routes))
+	for _, r := range rlCfg.Routes {
+		routes[r.Route] = r.Cost
+	}
+	c.RateLimitPolicy = ratelimit.Policy{
+		Limit:     ratelimit.Limit{Rate: rlCfg.Rate, Burst: rlCfg.Burst},
+		ReadCost:  rlCfg.ReadCost,
+		WriteCost: rlCfg.WriteCost,
+		IP:        ratelimit.Limit{Rate: rlCfg.IPRate, Burst: rlCfg.IPBurst},
+		Routes:    routes,
+	}
+
+	switch rlCfg.Driver {
+	case "redis":
+		redisCfg := cfg.GetRedisConfig()
+		if redisCfg.Host == "" {
+			log.Fatal("❌ 限流器使用 redis 驱动，但 Redis Host 未配置")
+		}
+		c.redisClient = redis.NewClient(&redis.Options{
+			Addr:     redisCfg.Host,
+			Password: redisCfg.Password,
+			DB:       redisCfg.DB,
+		})
+		c.RateLimiter = ratelimitstore.NewRedisLimiter(c.redisClient)
+		log.Printf("🚦 [Infrastructure] 已启用限流（Redis: %s）: Rate=%.2f/s, Burst=%d", redisCfg.Host, rlCfg.Rate, rlCfg.Burst)
+	default:
+		c.RateLimiter = ratelimitstore.NewMemoryLimiter()
+		log.Printf("🚦 [Infrastructure] 已启用限流（内存）: Rate=%.2f/s, Burst=%d", rlCfg.Rate, rlCfg.Burst)
+	}
+}
+
 // initEventBus 初始化事件总线
 func (c *InfrastructureContainer) initEventBus() {
 	// 使用同步事件总线
@@ -110,37 +308,94 @@ func (c *InfrastructureContainer) initEventBus() {
 
 // registerEventHandlers 注册所有事件处理器
 func (c *InfrastructureContainer) registerEventHandlers() {
+	// 注册时包装链路追踪和监控指标，每次处理事件记录一个 span 和处理指标
+	subscribe := func(handler event.EventHandler) {
+		c.EventBus.Subscribe(handler.EventName(), tracing.NewEventHandler(metrics.NewEventHandler(handler)))
+	}
+	subscribeAll := func(handler event.EventHandler) {
+		c.EventBus.SubscribeAll(tracing.NewEventHandler(metrics.NewEventHandler(handler)))
+	}
+
 	// 知识库创建事件处理器
 	kbCreatedHandler := eventhandler.NewKnowledgeBaseCreatedHandler()
-	c.EventBus.Subscribe(kbCreatedHandler.EventName(), kbCreatedHandler)
+	subscribe(kbCreatedHandler)
 
 	// 知识库更新事件处理器
 	kbUpdatedHandler := eventhandler.NewKnowledgeBaseUpdatedHandler()
-	c.EventBus.Subscribe(kbUpdatedHandler.EventName(), kbUpdatedHandler)
+	subscribe(kbUpdatedHandler)
 
 	// 文档添加事件处理器
 	docAddedHandler := eventhandler.NewDocumentAddedHandler()
-	c.EventBus.Subscribe(docAddedHandler.EventName(), docAddedHandler)
+	subscribe(docAddedHandler)
 
 	// 文档删除事件处理器
 	docRemovedHandler := eventhandler.NewDocumentRemovedHandler()
-	c.EventBus.Subscribe(docRemovedHandler.EventName(), docRemovedHandler)
+	subscribe(docRemovedHandler)
 
-	// 审计日志处理器（全局处理器，处理所有事件）
-	auditLogHandler := eventhandler.NewAuditLogHandler()
-	c.EventBus.SubscribeAll(auditLogHandler)
+	// 文档内容指纹处理器（处理文档新增、更新、恢复和清除等多个事件）
+	fingerprintHandler := eventhandler.NewDocumentFingerprintHandler(c.DocumentRepo, c.DuplicateService)
+	subscribeAll(fingerprintHandler)
+
+	// 知识库成员清理处理器（知识库被彻底清除后删除成员记录）
+	memberCleanupHandler := eventhandler.NewKnowledgeBaseMemberCleanupHandler(c.MemberRepo)
+	subscribe(memberCleanupHandler)
+
+	// 租户用量处理器（处理知识库和文档的彻底清除事件）
+	quotaUsageHandler := eventhandler.NewQuotaUsageHandler(c.QuotaService)
+	subscribeAll(quotaUsageHandler)
+
+	// 审计日志处理器（全局处理器，将所有事件写入审计日志表）
+	auditLogHandler := eventhandler.NewAuditLogHandler(c.AuditLogRepo)
+	subscribeAll(auditLogHandler)
 
 	log.Println("📫 [Infrastructure] 事件处理器注册完成")
 }
 
 // initDomainServices 初始化领域服务
-func (c *InfrastructureContainer) initDomainServices() {
-	c.KnowledgeService = service.NewKnowledgeService(c.KnowledgeBaseRepo, c.DocumentRepo)
+func (c *InfrastructureContainer) initDomainServices(cfg InfraConfig) {
+	c.initQuotaService(cfg)
+	c.KnowledgeService = service.NewKnowledgeService(c.KnowledgeBaseRepo, c.DocumentRepo, c.FolderRepo, c.TagRepo, c.QuotaService)
+	c.LinkService = service.NewLinkService(c.DocumentLinkRepo)
+	c.AttachmentService = service.NewAttachmentService(c.AttachmentRepo, c.BlobStore)
+	c.DuplicateService = service.NewDuplicateService(c.FingerprintRepo)
+	c.ContentRenderer = render.NewRenderer(render.DefaultExcerptLength)
 	log.Println("✅ [Infrastructure] 领域服务初始化完成")
 }
 
+// initQuotaService 初始化租户配额领域服务
+// 配置中的租户标识无效时启动失败，避免配额静默失效
+func (c *InfrastructureContainer) initQuotaService(cfg InfraConfig) {
+	quotaCfg := cfg.GetQuotaConfig()
+	defaultPolicy := valueobject.QuotaPolicy{
+		MaxKnowledgeBases:            quotaCfg.MaxKnowledgeBases,
+		MaxDocumentsPerKnowledgeBase: quotaCfg.MaxDocumentsPerKnowledgeBase,
+		MaxContentBytes:              quotaCfg.MaxContentBytes,
+	}
+
+	policies := make(map[tenant.ID]valueobject.QuotaPolicy, len(quotaCfg.Tenants))
+	for _, t := range quotaCfg.Tenants {
+		id, err := tenant.Parse(t.Tenant)
+		if err != nil {
+			log.Fatalf("❌ 租户配额配置无效: %q: %v", t.Tenant, err)
+		}
+		policies[id] = valueobject.QuotaPolicy{
+			MaxKnowledgeBases:            t.MaxKnowledgeBases,
+			MaxDocumentsPerKnowledgeBase: t.MaxDocumentsPerKnowledgeBase,
+			MaxContentBytes:              t.MaxContentBytes,
+		}
+	}
+
+	c.QuotaService = service.NewQuotaService(c.TenantUsageRepo, c.DocumentRepo, defaultPolicy, policies)
+	if !defaultPolicy.IsUnlimited() || len(policies) > 0 {
+		log.Printf("📏 [Infrastructure] 已启用租户配额: 默认 %+v，单独配置 %d 个租户", defaultPolicy, len(policies))
+	}
+}
+
 // Close 关闭基础设施资源
 func (c *InfrastructureContainer) Close() error {
+	if c.redisClient != nil {
+		_ = c.redisClient.Close()
+	}
 	if c.db != nil {
 		sqlDB, err := c.db.DB()
 		if err != nil {
@@ -174,7 +429,97 @@ func (c *InfrastructureContainer) GetDocumentRepo() repository.DocumentRepositor
 	return c.DocumentRepo
 }
 
+// GetFolderRepo 获取文件夹仓储
+func (c *InfrastructureContainer) GetFolderRepo() repository.FolderRepository {
+	return c.FolderRepo
+}
+
+// GetDocumentLinkRepo 获取文档链接仓储
+func (c *InfrastructureContainer) GetDocumentLinkRepo() repository.DocumentLinkRepository {
+	return c.DocumentLinkRepo
+}
+
+// GetTagRepo 获取标签注册表仓储
+func (c *InfrastructureContainer) GetTagRepo() repository.TagRepository {
+	return c.TagRepo
+}
+
+// GetAttachmentRepo 获取附件仓储
+func (c *InfrastructureContainer) GetAttachmentRepo() repository.AttachmentRepository {
+	return c.AttachmentRepo
+}
+
 // GetKnowledgeService 获取知识库领域服务
 func (c *InfrastructureContainer) GetKnowledgeService() *service.KnowledgeService {
 	return c.KnowledgeService
 }
+
+// GetRateLimiter 获取限流器，未启用限流时为 nil
+func (c *InfrastructureContainer) GetRateLimiter() ratelimit.Limiter {
+	return c.RateLimiter
+}
+
+// GetRateLimitPolicy 获取限流策略
+func (c *InfrastructureContainer) GetRateLimitPolicy() ratelimit.Policy {
+	return c.RateLimitPolicy
+}
+
+// GetQuotaService 获取租户配额领域服务
+func (c *InfrastructureContainer) GetQuotaService() *service.QuotaService {
+	return c.QuotaService
+}
+
+// GetLinkService 获取文档链接领域服务
+func (c *InfrastructureContainer) GetLinkService() *service.LinkService {
+	return c.LinkService
+}
+
+// GetContentRenderer 获取文档内容渲染器
+func (c *InfrastructureContainer) GetContentRenderer() service.ContentRenderer {
+	return c.ContentRenderer
+}
+
+// GetAttachmentService 获取附件领域服务
+func (c *InfrastructureContainer) GetAttachmentService() *service.AttachmentService {
+	return c.AttachmentService
+}
+
+// GetDuplicateService 获取重复文档检测领域服务
+func (c *InfrastructureContainer) GetDuplicateService() *service.DuplicateService {
+	return c.DuplicateService
+}
+
+// GetMaxAttachmentBytes 获取附件大小上限（字节）
+func (c *InfrastructureContainer) GetMaxAttachmentBytes() int64 {
+	return c.MaxAttachmentBytes
+}
+
+// GetIdempotencyRepo 获取幂等键仓储
+func (c *InfrastructureContainer) GetIdempotencyRepo() repository.IdempotencyRepository {
+	return c.IdempotencyRepo
+}
+
+// GetIdempotencyTTL 获取幂等键保留时间
+func (c *InfrastructureContainer) GetIdempotencyTTL() time.Duration {
+	return c.IdempotencyTTL
+}
+
+// GetTokenVerifier 获取访问令牌校验器，未启用认证时返回 nil
+func (c *InfrastructureContainer) GetTokenVerifier() auth.TokenVerifier {
+	return c.TokenVerifier
+}
+
+// GetKnowledgeBaseMemberRepo 获取知识库成员仓储
+func (c *InfrastructureContainer) GetKnowledgeBaseMemberRepo() repository.KnowledgeBaseMemberRepository {
+	return c.MemberRepo
+}
+
+// GetAPIKeyRepo 获取 API Key 仓储
+func (c *InfrastructureContainer) GetAPIKeyRepo() repository.APIKeyRepository {
+	return c.APIKeyRepo
+}
+
+// GetAuditLogRepo 获取审计日志仓储
+func (c *InfrastructureContainer) GetAuditLogRepo() repository.AuditLogRepository {
+	return c.AuditLogRepo
+}
@@ -9,8 +9,13 @@ import (
 	"time"
 
 	"github.com/segmentio/kafka-go"
+	"go.opentelemetry.io/otel/trace"
 
+	"gozero-ddd/internal/application/metrics"
+	"gozero-ddd/internal/application/requestmeta"
+	"gozero-ddd/internal/application/tracing"
 	"gozero-ddd/internal/domain/event"
+	"gozero-ddd/internal/domain/tenant"
 )
 
 // KafkaConfig Kafka 配置
@@ -52,13 +57,18 @@ type EventMessage struct {
 	EventName   string          `json:"event_name"`
 	AggregateID string          `json:"aggregate_id"`
 	OccurredAt  time.Time       `json:"occurred_at"`
+	Actor       string          `json:"actor,omitempty"` // 操作者标识
 	Payload     json.RawMessage `json:"payload"` // 事件具体数据
 	Metadata    EventMetadata   `json:"metadata"`
 }
 
 // EventMetadata 事件元数据
 type EventMetadata struct {
-	TraceID     string `json:"trace_id,omitempty"`
+	TraceID     string `json:"trace_id,omitempty"`    // 产生事件的请求的 trace-id
+	Traceparent string `json:"traceparent,omitempty"` // 产生事件的请求的 W3C traceparent，消费端据此延续链路
+	TenantID    string `json:"tenant_id,omitempty"`   // 事件所属租户
+	RequestID   string `json:"request_id,omitempty"`  // 产生事件的请求ID
+	ClientIP    string `json:"client_ip,omitempty"`   // 产生事件的请求的客户端 IP
 	ServiceName string `json:"service_name,omitempty"`
 	Version     string `json:"version,omitempty"`
 }
@@ -174,23 +184,29 @@ func (p *KafkaEventPublisher) buildMessage(ctx context.Context, evt event.Domain
 	}
 
 	// 构建事件消息
+	md := requestmeta.FromContext(ctx)
+	traceparent := tracing.Traceparent(ctx)
+	if traceparent == "" {
+		traceparent = md.Traceparent()
+	}
 	eventMsg := EventMessage{
 		EventID:     evt.EventID(),
 		EventName:   evt.EventName(),
 		AggregateID: evt.AggregateID(),
 		OccurredAt:  evt.OccurredAt(),
+		Actor:       evt.Actor(),
 		Payload:     payload,
 		Metadata: EventMetadata{
+			TraceID:     md.TraceID,
+			Traceparent: traceparent,
+			TenantID:    tenant.FromContext(ctx).String(),
+			RequestID:   md.RequestID,
+			ClientIP:    md.ClientIP,
 			ServiceName: "knowledge-service",
 			Version:     "1.0",
 		},
 	}
 
-	// 从 context 提取 trace_id（如果有）
-	if traceID, ok := ctx.Value("trace_id").(string); ok {
-		eventMsg.Metadata.TraceID = traceID
-	}
-
 	value, err := json.Marshal(eventMsg)
 	if err != nil {
 		return kafka.Message{}, err
@@ -320,20 +336,40 @@ func (c *KafkaEventConsumer) consumeLoop(ctx context.Context) {
 
 // handleMessage 处理 Kafka 消息
 func (c *KafkaEventConsumer) handleMessage(ctx context.Context, msg kafka.Message) {
+	// 记录消费者在分区上落后的消息数（高水位减去下一条待消费消息的偏移量）
+	metrics.SetKafkaConsumerLag(msg.Topic, msg.Partition, msg.HighWaterMark-msg.Offset-1)
+
 	var eventMsg EventMessage
 	if err := json.Unmarshal(msg.Value, &eventMsg); err != nil {
 		log.Printf("❌ [Kafka] 解析消息失败: %v", err)
 		return
 	}
 
-	log.Printf("📥 [Kafka] 收到事件: %s, EventID=%s, AggregateID=%s",
-		eventMsg.EventName, eventMsg.EventID, eventMsg.AggregateID)
-
 	// 创建包装的事件对象
 	wrappedEvent := &WrappedDomainEvent{
 		eventMsg: eventMsg,
 	}
 
+	// 恢复事件所属租户，处理器访问仓储时只操作该租户的数据
+	if eventMsg.Metadata.TenantID != "" {
+		ctx = tenant.WithID(ctx, tenant.ID(eventMsg.Metadata.TenantID))
+	}
+	// 延续发布事件时的链路，消费记录为发布 span 的子 span
+	ctx = tracing.ContextWithTraceparent(ctx, eventMsg.Metadata.Traceparent)
+	ctx, span := tracing.Start(ctx, "kafka consume "+eventMsg.EventName,
+		trace.WithSpanKind(trace.SpanKindConsumer),
+		trace.WithAttributes(tracing.EventNameKey.String(eventMsg.EventName)),
+	)
+	defer span.End()
+
+	// 恢复产生事件的请求元数据：沿用请求ID和 trace-id，处理器的日志和审计日志据此关联原始请求
+	if md := eventMsg.Metadata; md.RequestID != "" || md.Traceparent != "" {
+		ctx = requestmeta.WithMetadata(ctx, requestmeta.New(ctx, md.RequestID, md.Traceparent, md.ClientIP))
+	}
+
+	requestmeta.Logf(ctx, "📥 [Kafka] 收到事件: %s, EventID=%s, AggregateID=%s",
+		eventMsg.EventName, eventMsg.EventID, eventMsg.AggregateID)
+
 	// 调用处理器
 	c.dispatchEvent(ctx, eventMsg.EventName, wrappedEvent)
 }
@@ -347,7 +383,7 @@ func (c *KafkaEventConsumer) dispatchEvent(ctx context.Context, eventName string
 	if handlers, ok := c.handlers[eventName]; ok {
 		for _, handler := range handlers {
 			if err := handler.Handle(ctx, evt); err != nil {
-				log.Printf("❌ [Kafka] 事件处理失败: %s, 错误: %v", eventName, err)
+				requestmeta.Logf(ctx, "❌ [Kafka] 事件处理失败: %s, 错误: %v", eventName, err)
 			}
 		}
 	}
@@ -355,7 +391,7 @@ func (c *KafkaEventConsumer) dispatchEvent(ctx context.Context, eventName string
 	// 调用全局处理器
 	for _, handler := range c.allHandlers {
 		if err := handler.Handle(ctx, evt); err != nil {
-			log.Printf("❌ [Kafka] 全局处理器执行失败: %v", err)
+			requestmeta.Logf(ctx, "❌ [Kafka] 全局处理器执行失败: %v", err)
 		}
 	}
 }
@@ -401,6 +437,10 @@ func (e *WrappedDomainEvent) AggregateID() string {
 	return e.eventMsg.AggregateID
 }
 
+func (e *WrappedDomainEvent) Actor() string {
+	return e.eventMsg.Actor
+}
+
 // Payload 获取原始事件数据
 func (e *WrappedDomainEvent) Payload() json.RawMessage {
 	return e.eventMsg.Payload
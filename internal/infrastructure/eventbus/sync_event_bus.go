@@ -5,6 +5,7 @@ import (
 	"log"
 	"sync"
 
+	"gozero-ddd/internal/application/requestmeta"
 	"gozero-ddd/internal/domain/event"
 )
 
@@ -54,7 +55,7 @@ func (b *SyncEventBus) Publish(ctx context.Context, evt event.DomainEvent) error
 	defer b.mu.RUnlock()
 
 	eventName := evt.EventName()
-	log.Printf("📤 [EventBus] 发布事件: %s", eventName)
+	requestmeta.Logf(ctx, "📤 [EventBus] 发布事件: %s", eventName)
 
 	// 调用特定事件的处理器
 	if handlers, ok := b.handlers[eventName]; ok {
@@ -88,7 +89,7 @@ func (b *SyncEventBus) PublishAll(ctx context.Context, events []event.DomainEven
 // invokeHandler 调用处理器（带错误处理）
 func (b *SyncEventBus) invokeHandler(ctx context.Context, handler event.EventHandler, evt event.DomainEvent) error {
 	if err := handler.Handle(ctx, evt); err != nil {
-		log.Printf("❌ [EventBus] 事件处理失败: %s, 错误: %v", evt.EventName(), err)
+		requestmeta.Logf(ctx, "❌ [EventBus] 事件处理失败: %s, 错误: %v", evt.EventName(), err)
 		// 记录错误但不中断后续处理
 		return nil
 	}
@@ -0,0 +1,28 @@
+package job
+
+import "time"
+
+// Clock 时钟接口
+// 定时任务通过 Clock 获取当前时间，便于在测试中替换为可控的时钟
+type Clock interface {
+	Now() time.Time
+}
+
+// SystemClock 系统时钟，返回真实的当前时间
+type SystemClock struct{}
+
+// Now 返回当前时间
+func (SystemClock) Now() time.Time {
+	return time.Now()
+}
+
+// FixedClock 固定时钟，始终返回设定的时间
+// 用于测试或手动回放某一时刻的定时任务
+type FixedClock struct {
+	T time.Time
+}
+
+// Now 返回设定的时间
+func (c FixedClock) Now() time.Time {
+	return c.T
+}
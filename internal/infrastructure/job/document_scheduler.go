@@ -0,0 +1,106 @@
+package job
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"gozero-ddd/internal/application/command"
+	"gozero-ddd/internal/application/dto"
+	"gozero-ddd/internal/domain/tenant"
+)
+
+// ScheduledDocumentProcessor 定时发布/下线处理接口
+// 由应用层的 ProcessScheduledDocumentsHandler 实现，调度器只负责按周期触发并提供当前时间
+type ScheduledDocumentProcessor interface {
+	Handle(ctx context.Context, cmd *command.ProcessScheduledDocumentsCommand) (*dto.ScheduleResultDTO, error)
+}
+
+// DocumentScheduler 文档定时发布/下线调度器
+// 周期性地扫描到期文档，发布到达 publish_at 的已审核文档，下线到达 expire_at 的已发布文档，
+// 逐个租户执行
+type DocumentScheduler struct {
+	processor ScheduledDocumentProcessor
+	tenants   TenantLister
+	clock     Clock         // 时钟，可替换以便测试
+	interval  time.Duration // 扫描间隔
+
+	stopCh   chan struct{}
+	stopOnce sync.Once
+	wg       sync.WaitGroup
+}
+
+// NewDocumentScheduler 创建文档调度器
+// tenants 为 nil 时只处理默认租户，clock 为 nil 时使用系统时钟
+func NewDocumentScheduler(processor ScheduledDocumentProcessor, tenants TenantLister, clock Clock, interval time.Duration) *DocumentScheduler {
+	if clock == nil {
+		clock = SystemClock{}
+	}
+	if interval <= 0 {
+		interval = time.Minute
+	}
+	return &DocumentScheduler{
+		processor: processor,
+		tenants:   tenants,
+		clock:     clock,
+		interval:  interval,
+		stopCh:    make(chan struct{}),
+	}
+}
+
+// Start 启动调度器（非阻塞）
+func (s *DocumentScheduler) Start() {
+	log.Printf("⏰ [Scheduler] 启动文档定时发布调度器: 间隔 %v", s.interval)
+
+	s.wg.Add(1)
+	go s.loop()
+}
+
+// loop 定时执行循环
+func (s *DocumentScheduler) loop() {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.stopCh:
+			return
+		case <-ticker.C:
+			s.RunOnce(context.Background())
+		}
+	}
+}
+
+// RunOnce 立即执行一次扫描，使用时钟提供的当前时间判断是否到期
+func (s *DocumentScheduler) RunOnce(ctx context.Context) {
+	now := s.clock.Now()
+	forEachTenant(ctx, s.tenants, "Scheduler", func(ctx context.Context) {
+		s.process(ctx, now)
+	})
+}
+
+// process 处理上下文中租户的到期文档
+func (s *DocumentScheduler) process(ctx context.Context, now time.Time) {
+	result, err := s.processor.Handle(ctx, &command.ProcessScheduledDocumentsCommand{Now: now})
+	if err != nil {
+		log.Printf("❌ [Scheduler] 扫描租户 %s 的到期文档失败: %v", tenant.FromContext(ctx), err)
+		return
+	}
+
+	if result.Published > 0 || result.Expired > 0 || result.Failed > 0 {
+		log.Printf("⏰ [Scheduler] 租户 %s 处理完成: 发布 %d 篇, 下线 %d 篇, 失败 %d 篇",
+			tenant.FromContext(ctx), result.Published, result.Expired, result.Failed)
+	}
+}
+
+// Stop 停止调度器，等待正在执行的扫描完成
+func (s *DocumentScheduler) Stop() {
+	s.stopOnce.Do(func() {
+		close(s.stopCh)
+	})
+	s.wg.Wait()
+	log.Println("🛑 [Scheduler] 文档定时发布调度器已停止")
+}
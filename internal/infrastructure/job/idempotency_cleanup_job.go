@@ -0,0 +1,84 @@
+package job
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+)
+
+// IdempotencyPurger 过期幂等键清理接口
+// 由应用层的 idempotency.Service 实现，定时任务只负责按周期触发
+type IdempotencyPurger interface {
+	PurgeExpired(ctx context.Context) (int64, error)
+}
+
+// IdempotencyCleanupJob 幂等键定时清理任务
+// 周期性地删除已过期的幂等键记录；过期记录在占用键时也会被忽略，清理只是为了回收空间
+type IdempotencyCleanupJob struct {
+	purger   IdempotencyPurger
+	interval time.Duration // 执行间隔
+
+	stopCh   chan struct{}
+	stopOnce sync.Once
+	wg       sync.WaitGroup
+}
+
+// NewIdempotencyCleanupJob 创建幂等键清理任务
+func NewIdempotencyCleanupJob(purger IdempotencyPurger, interval time.Duration) *IdempotencyCleanupJob {
+	if interval <= 0 {
+		interval = time.Hour
+	}
+	return &IdempotencyCleanupJob{
+		purger:   purger,
+		interval: interval,
+		stopCh:   make(chan struct{}),
+	}
+}
+
+// Start 启动定时任务（非阻塞）
+func (j *IdempotencyCleanupJob) Start() {
+	log.Printf("🔑 [IdempotencyCleanup] 启动幂等键清理任务: 间隔 %v", j.interval)
+
+	j.wg.Add(1)
+	go j.loop()
+}
+
+// loop 定时执行循环
+func (j *IdempotencyCleanupJob) loop() {
+	defer j.wg.Done()
+
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-j.stopCh:
+			return
+		case <-ticker.C:
+			j.RunOnce(context.Background())
+		}
+	}
+}
+
+// RunOnce 立即执行一次清理
+func (j *IdempotencyCleanupJob) RunOnce(ctx context.Context) {
+	deleted, err := j.purger.PurgeExpired(ctx)
+	if err != nil {
+		log.Printf("❌ [IdempotencyCleanup] 清理过期幂等键失败: %v", err)
+		return
+	}
+
+	if deleted > 0 {
+		log.Printf("🔑 [IdempotencyCleanup] 清理完成: 删除过期幂等键 %d 个", deleted)
+	}
+}
+
+// Stop 停止定时任务，等待正在执行的清理完成
+func (j *IdempotencyCleanupJob) Stop() {
+	j.stopOnce.Do(func() {
+		close(j.stopCh)
+	})
+	j.wg.Wait()
+	log.Println("🛑 [IdempotencyCleanup] 幂等键清理任务已停止")
+}
@@ -0,0 +1,141 @@
+package job
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"gozero-ddd/internal/application/metrics"
+	"gozero-ddd/internal/domain/tenant"
+	"gozero-ddd/internal/domain/valueobject"
+)
+
+// KnowledgeBaseCounter 知识库统计接口，由知识库仓储实现
+type KnowledgeBaseCounter interface {
+	CountByStatus(ctx context.Context) (map[valueobject.KnowledgeBaseStatus]int, error)
+}
+
+// DocumentCounter 文档统计接口，由文档仓储实现
+type DocumentCounter interface {
+	CountByStatus(ctx context.Context) (map[valueobject.DocumentStatus]int, error)
+}
+
+// InventoryMetricsJob 知识库和文档总数指标刷新任务
+// 周期性地逐个租户按状态统计知识库和文档数量，汇总后更新监控指标中的总数
+type InventoryMetricsJob struct {
+	knowledgeBases KnowledgeBaseCounter
+	documents      DocumentCounter
+	tenants        TenantLister
+	interval       time.Duration // 执行间隔
+
+	// 上次上报过的状态，本次统计中没有的状态需要置为 0
+	seenKnowledgeBaseStatuses map[string]bool
+	seenDocumentStatuses      map[string]bool
+
+	stopCh   chan struct{}
+	stopOnce sync.Once
+	wg       sync.WaitGroup
+}
+
+// NewInventoryMetricsJob 创建知识库和文档总数指标刷新任务
+// tenants 为 nil 时只统计默认租户
+func NewInventoryMetricsJob(knowledgeBases KnowledgeBaseCounter, documents DocumentCounter, tenants TenantLister, interval time.Duration) *InventoryMetricsJob {
+	if interval <= 0 {
+		interval = time.Minute
+	}
+	return &InventoryMetricsJob{
+		knowledgeBases:            knowledgeBases,
+		documents:                 documents,
+		tenants:                   tenants,
+		interval:                  interval,
+		seenKnowledgeBaseStatuses: make(map[string]bool),
+		seenDocumentStatuses:      make(map[string]bool),
+		stopCh:                    make(chan struct{}),
+	}
+}
+
+// Start 启动定时任务（非阻塞），启动后立即刷新一次
+func (j *InventoryMetricsJob) Start() {
+	log.Printf("📊 [InventoryMetrics] 启动知识库和文档总数指标刷新任务: 间隔 %v", j.interval)
+
+	j.wg.Add(1)
+	go j.loop()
+}
+
+// loop 定时执行循环
+func (j *InventoryMetricsJob) loop() {
+	defer j.wg.Done()
+
+	j.RunOnce(context.Background())
+
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-j.stopCh:
+			return
+		case <-ticker.C:
+			j.RunOnce(context.Background())
+		}
+	}
+}
+
+// RunOnce 立即统计一次所有租户的知识库和文档总数
+// 查询租户或任一租户统计失败时不更新指标，避免上报不完整的总数
+func (j *InventoryMetricsJob) RunOnce(ctx context.Context) {
+	kbCounts := make(map[string]int)
+	docCounts := make(map[string]int)
+	failed := false
+
+	err := forEachTenant(ctx, j.tenants, "InventoryMetrics", func(ctx context.Context) {
+		kbs, err := j.knowledgeBases.CountByStatus(ctx)
+		if err != nil {
+			log.Printf("❌ [InventoryMetrics] 统计租户 %s 的知识库失败: %v", tenant.FromContext(ctx), err)
+			failed = true
+			return
+		}
+		docs, err := j.documents.CountByStatus(ctx)
+		if err != nil {
+			log.Printf("❌ [InventoryMetrics] 统计租户 %s 的文档失败: %v", tenant.FromContext(ctx), err)
+			failed = true
+			return
+		}
+
+		for status, n := range kbs {
+			kbCounts[status.String()] += n
+		}
+		for status, n := range docs {
+			docCounts[status.String()] += n
+		}
+	})
+	if err != nil || failed {
+		return
+	}
+
+	metrics.SetKnowledgeBaseCounts(withZeros(kbCounts, j.seenKnowledgeBaseStatuses))
+	metrics.SetDocumentCounts(withZeros(docCounts, j.seenDocumentStatuses))
+}
+
+// withZeros 为上次上报过、本次统计中没有的状态补 0，并记录本次上报的状态
+func withZeros(counts map[string]int, seen map[string]bool) map[string]int {
+	for status := range seen {
+		if _, ok := counts[status]; !ok {
+			counts[status] = 0
+		}
+	}
+	for status := range counts {
+		seen[status] = true
+	}
+	return counts
+}
+
+// Stop 停止定时任务，等待正在执行的统计完成
+func (j *InventoryMetricsJob) Stop() {
+	j.stopOnce.Do(func() {
+		close(j.stopCh)
+	})
+	j.wg.Wait()
+	log.Println("🛑 [InventoryMetrics] 知识库和文档总数指标刷新任务已停止")
+}
@@ -0,0 +1,34 @@
+package job
+
+import (
+	"context"
+	"log"
+
+	"gozero-ddd/internal/domain/tenant"
+)
+
+// TenantLister 租户列表接口
+// 由知识库仓储实现；仓储按上下文中的租户隔离数据，定时任务需要逐个租户执行
+type TenantLister interface {
+	FindTenantIDs(ctx context.Context) ([]tenant.ID, error)
+}
+
+// forEachTenant 在每个租户的上下文中执行 fn
+// tenants 为 nil 时只在上下文中已有的租户（默认为默认租户）中执行一次；
+// 查询租户失败时记录日志并返回错误
+func forEachTenant(ctx context.Context, tenants TenantLister, name string, fn func(ctx context.Context)) error {
+	if tenants == nil {
+		fn(ctx)
+		return nil
+	}
+
+	ids, err := tenants.FindTenantIDs(ctx)
+	if err != nil {
+		log.Printf("❌ [%s] 查询租户失败: %v", name, err)
+		return err
+	}
+	for _, id := range ids {
+		fn(tenant.WithID(ctx, id))
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+package job
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"gozero-ddd/internal/application/command"
+	"gozero-ddd/internal/application/dto"
+	"gozero-ddd/internal/domain/tenant"
+)
+
+// TrashPurger 回收站清理接口
+// 由应用层的 PurgeTrashHandler 实现，定时任务只负责按周期触发
+type TrashPurger interface {
+	Handle(ctx context.Context, cmd *command.PurgeTrashCommand) (*dto.PurgeResultDTO, error)
+}
+
+// TrashPurgeJob 回收站定时清理任务
+// 周期性地彻底删除在回收站中超过保留期的知识库和文档，逐个租户执行
+type TrashPurgeJob struct {
+	purger        TrashPurger
+	tenants       TenantLister
+	retentionDays int           // 回收站保留天数
+	interval      time.Duration // 执行间隔
+
+	stopCh   chan struct{}
+	stopOnce sync.Once
+	wg       sync.WaitGroup
+}
+
+// NewTrashPurgeJob 创建回收站清理任务
+// tenants 为 nil 时只清理默认租户
+func NewTrashPurgeJob(purger TrashPurger, tenants TenantLister, retentionDays int, interval time.Duration) *TrashPurgeJob {
+	if interval <= 0 {
+		interval = time.Hour
+	}
+	return &TrashPurgeJob{
+		purger:        purger,
+		tenants:       tenants,
+		retentionDays: retentionDays,
+		interval:      interval,
+		stopCh:        make(chan struct{}),
+	}
+}
+
+// Start 启动定时任务（非阻塞）
+func (j *TrashPurgeJob) Start() {
+	log.Printf("🗑️ [TrashPurge] 启动回收站清理任务: 保留 %d 天, 间隔 %v", j.retentionDays, j.interval)
+
+	j.wg.Add(1)
+	go j.loop()
+}
+
+// loop 定时执行循环
+func (j *TrashPurgeJob) loop() {
+	defer j.wg.Done()
+
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-j.stopCh:
+			return
+		case <-ticker.C:
+			j.RunOnce(context.Background())
+		}
+	}
+}
+
+// RunOnce 立即对每个租户执行一次清理
+func (j *TrashPurgeJob) RunOnce(ctx context.Context) {
+	forEachTenant(ctx, j.tenants, "TrashPurge", j.purge)
+}
+
+// purge 清理上下文中租户的回收站
+func (j *TrashPurgeJob) purge(ctx context.Context) {
+	result, err := j.purger.Handle(ctx, &command.PurgeTrashCommand{RetentionDays: j.retentionDays})
+	if err != nil {
+		log.Printf("❌ [TrashPurge] 清理租户 %s 的回收站失败: %v", tenant.FromContext(ctx), err)
+		return
+	}
+
+	if result.KnowledgeBasesPurged > 0 || result.DocumentsPurged > 0 {
+		log.Printf("🗑️ [TrashPurge] 租户 %s 清理完成: 知识库 %d 个, 文档 %d 个",
+			tenant.FromContext(ctx), result.KnowledgeBasesPurged, result.DocumentsPurged)
+	}
+}
+
+// Stop 停止定时任务，等待正在执行的清理完成
+func (j *TrashPurgeJob) Stop() {
+	j.stopOnce.Do(func() {
+		close(j.stopCh)
+	})
+	j.wg.Wait()
+	log.Println("🛑 [TrashPurge] 回收站清理任务已停止")
+}
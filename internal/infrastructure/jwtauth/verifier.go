@@ -0,0 +1,147 @@
+package jwtauth
+
+import (
+	"context"
+	"crypto/rsa"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+
+	"gozero-ddd/internal/application/auth"
+)
+
+// minSecretLength HS256 共享密钥的建议最小长度（字节）
+const minSecretLength = 32
+
+// Options JWT 校验选项
+type Options struct {
+	Secret   string        // HS256 共享密钥
+	JWKSFile string        // RS256 公钥 JWKS 文件路径
+	Issuer   string        // 要求的签发方，为空时不校验
+	Audience string        // 要求的受众，为空时不校验
+	Leeway   time.Duration // 校验有效期时允许的时钟偏差
+}
+
+// Verifier JWT 访问令牌校验器
+// 实现应用层的 auth.TokenVerifier 接口：
+// 配置了共享密钥时接受 HS256 令牌，配置了 JWKS 文件时接受 RS256 令牌。
+// 令牌必须包含 sub 和 exp，可选的 name 和 roles 声明会放入调用方信息
+type Verifier struct {
+	secret   []byte
+	keys     map[string]*rsa.PublicKey
+	issuer   string
+	audience string
+	leeway   time.Duration
+	parser   *jwt.Parser
+}
+
+// claims 访问令牌声明
+type claims struct {
+	jwt.RegisteredClaims
+	Name   string   `json:"name,omitempty"`
+	Roles  []string `json:"roles,omitempty"`
+	Tenant string   `json:"tenant_id,omitempty"` // 调用方所属租户（可选）
+}
+
+// 确保实现了接口
+var _ auth.TokenVerifier = (*Verifier)(nil)
+
+// NewVerifier 创建 JWT 校验器
+// JWKS 文件在创建时加载，更换公钥后需要重启服务
+func NewVerifier(opts Options) (*Verifier, error) {
+	if opts.Secret == "" && opts.JWKSFile == "" {
+		return nil, errors.New("jwt auth requires a secret (HS256) or a JWKS file (RS256)")
+	}
+
+	v := &Verifier{
+		issuer:   opts.Issuer,
+		audience: opts.Audience,
+		leeway:   opts.Leeway,
+	}
+
+	methods := make([]string, 0, 2)
+	if opts.Secret != "" {
+		if len(opts.Secret) < minSecretLength {
+			log.Printf("⚠️  [Auth] HS256 密钥长度不足 %d 字节，建议使用更长的随机密钥", minSecretLength)
+		}
+		v.secret = []byte(opts.Secret)
+		methods = append(methods, jwt.SigningMethodHS256.Alg())
+	}
+	if opts.JWKSFile != "" {
+		keys, err := LoadJWKSFile(opts.JWKSFile)
+		if err != nil {
+			return nil, err
+		}
+		v.keys = keys
+		methods = append(methods, jwt.SigningMethodRS256.Alg())
+	}
+
+	// 有效期由 Verify 按 leeway 校验，解析时跳过内置的声明校验
+	v.parser = jwt.NewParser(jwt.WithValidMethods(methods), jwt.WithoutClaimsValidation())
+	return v, nil
+}
+
+// Verify 校验令牌签名和声明，返回令牌代表的调用方
+func (v *Verifier) Verify(_ context.Context, token string) (*auth.Principal, error) {
+	var c claims
+	if _, err := v.parser.ParseWithClaims(token, &c, v.keyFunc); err != nil {
+		return nil, err
+	}
+	if err := v.validate(&c, time.Now()); err != nil {
+		return nil, err
+	}
+
+	return &auth.Principal{
+		Subject: c.Subject,
+		Name:    c.Name,
+		Roles:   c.Roles,
+		Issuer:  c.Issuer,
+		Tenant:  c.Tenant,
+	}, nil
+}
+
+// keyFunc 按令牌的签名算法选择校验密钥
+func (v *Verifier) keyFunc(token *jwt.Token) (interface{}, error) {
+	switch token.Method.Alg() {
+	case jwt.SigningMethodHS256.Alg():
+		return v.secret, nil
+	case jwt.SigningMethodRS256.Alg():
+		kid, _ := token.Header["kid"].(string)
+		if kid == "" && len(v.keys) == 1 {
+			// JWKS 中只有一个公钥时，令牌可以不指定 kid
+			for _, key := range v.keys {
+				return key, nil
+			}
+		}
+		key, ok := v.keys[kid]
+		if !ok {
+			return nil, fmt.Errorf("unknown key id %q", kid)
+		}
+		return key, nil
+	default:
+		return nil, fmt.Errorf("unexpected signing method %s", token.Method.Alg())
+	}
+}
+
+// validate 校验令牌声明
+func (v *Verifier) validate(c *claims, now time.Time) error {
+	if !c.VerifyExpiresAt(now.Add(-v.leeway), true) {
+		return errors.New("token is expired or has no expiration time")
+	}
+	if !c.VerifyNotBefore(now.Add(v.leeway), false) {
+		return errors.New("token is not valid yet")
+	}
+	if v.issuer != "" && !c.VerifyIssuer(v.issuer, true) {
+		return errors.New("token has an unexpected issuer")
+	}
+	if v.audience != "" && !c.VerifyAudience(v.audience, true) {
+		return errors.New("token has an unexpected audience")
+	}
+	if c.Subject == "" {
+		return errors.New("token has no subject")
+	}
+	return nil
+}
@@ -0,0 +1,304 @@
+package jwtauth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+const testSecret = "0123456789abcdef0123456789abcdef"
+
+// generateKey 生成测试用 RSA 私钥
+func generateKey(t *testing.T) *rsa.PrivateKey {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key
+}
+
+// publicJWK 将 RSA 公钥转换为 JWK
+func publicJWK(kid string, key *rsa.PublicKey) jwk {
+	return jwk{
+		Kty: "RSA",
+		Kid: kid,
+		Use: "sig",
+		Alg: "RS256",
+		N:   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+		E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+	}
+}
+
+// writeJWKS 将公钥写入临时 JWKS 文件，返回文件路径
+func writeJWKS(t *testing.T, keys ...jwk) string {
+	t.Helper()
+	data, err := json.Marshal(jwkSet{Keys: keys})
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// sign 按指定算法签名令牌，kid 为空时不设置 kid 头
+func sign(t *testing.T, method jwt.SigningMethod, kid string, key interface{}, c jwt.Claims) string {
+	t.Helper()
+	token := jwt.NewWithClaims(method, c)
+	if kid != "" {
+		token.Header["kid"] = kid
+	}
+	signed, err := token.SignedString(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signed
+}
+
+func TestVerifierVerify(t *testing.T) {
+	key := generateKey(t)
+	otherKey := generateKey(t)
+	jwksFile := writeJWKS(t, publicJWK("k1", &key.PublicKey))
+
+	v, err := NewVerifier(Options{
+		Secret:   testSecret,
+		JWKSFile: jwksFile,
+		Issuer:   "https://issuer.example",
+		Audience: "knowledge-api",
+		Leeway:   30 * time.Second,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Now()
+	valid := func() *claims {
+		return &claims{
+			RegisteredClaims: jwt.RegisteredClaims{
+				Subject:   "alice",
+				Issuer:    "https://issuer.example",
+				Audience:  jwt.ClaimStrings{"knowledge-api"},
+				ExpiresAt: jwt.NewNumericDate(now.Add(time.Hour)),
+				IssuedAt:  jwt.NewNumericDate(now),
+			},
+			Name:   "Alice",
+			Roles:  []string{"admin"},
+			Tenant: "t1",
+		}
+	}
+	with := func(modify func(c *claims)) *claims {
+		c := valid()
+		modify(c)
+		return c
+	}
+
+	// 用 RSA 公钥作为 HS256 密钥签名，模拟算法混淆攻击
+	publicKeyPEM := pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: mustMarshalPKIX(t, &key.PublicKey)})
+
+	tests := []struct {
+		name    string
+		token   string
+		wantErr string // 期望的错误信息片段，为空表示校验通过
+	}{
+		{"valid HS256", sign(t, jwt.SigningMethodHS256, "", []byte(testSecret), valid()), ""},
+		{"valid RS256", sign(t, jwt.SigningMethodRS256, "k1", key, valid()), ""},
+		{"expired", sign(t, jwt.SigningMethodRS256, "k1", key, with(func(c *claims) {
+			c.ExpiresAt = jwt.NewNumericDate(now.Add(-time.Minute))
+		})), "expired"},
+		{"expired within leeway", sign(t, jwt.SigningMethodRS256, "k1", key, with(func(c *claims) {
+			c.ExpiresAt = jwt.NewNumericDate(now.Add(-10 * time.Second))
+		})), ""},
+		{"no expiration", sign(t, jwt.SigningMethodRS256, "k1", key, with(func(c *claims) {
+			c.ExpiresAt = nil
+		})), "no expiration"},
+		{"not valid yet", sign(t, jwt.SigningMethodRS256, "k1", key, with(func(c *claims) {
+			c.NotBefore = jwt.NewNumericDate(now.Add(time.Minute))
+		})), "not valid yet"},
+		{"wrong audience", sign(t, jwt.SigningMethodRS256, "k1", key, with(func(c *claims) {
+			c.Audience = jwt.ClaimStrings{"other-api"}
+		})), "unexpected audience"},
+		{"one of several audiences", sign(t, jwt.SigningMethodRS256, "k1", key, with(func(c *claims) {
+			c.Audience = jwt.ClaimStrings{"other-api", "knowledge-api"}
+		})), ""},
+		{"no audience", sign(t, jwt.SigningMethodRS256, "k1", key, with(func(c *claims) {
+			c.Audience = nil
+		})), "unexpected audience"},
+		{"wrong issuer", sign(t, jwt.SigningMethodRS256, "k1", key, with(func(c *claims) {
+			c.Issuer = "https://evil.example"
+		})), "unexpected issuer"},
+		{"no subject", sign(t, jwt.SigningMethodRS256, "k1", key, with(func(c *claims) {
+			c.Subject = ""
+		})), "no subject"},
+		{"alg none", sign(t, jwt.SigningMethodNone, "", jwt.UnsafeAllowNoneSignatureType, valid()), "signing method none is invalid"},
+		{"unknown kid", sign(t, jwt.SigningMethodRS256, "k2", key, valid()), `unknown key id "k2"`},
+		{"known kid signed by another key", sign(t, jwt.SigningMethodRS256, "k1", otherKey, valid()), "verification error"},
+		{"wrong HS256 secret", sign(t, jwt.SigningMethodHS256, "", []byte("another-secret-another-secret-xx"), valid()), "signature is invalid"},
+		{"HS256 signed with RSA public key", sign(t, jwt.SigningMethodHS256, "k1", publicKeyPEM, valid()), "signature is invalid"},
+		{"RS512 not allowed", sign(t, jwt.SigningMethodRS512, "k1", key, valid()), "signing method RS512 is invalid"},
+		{"malformed", "not.a.token", "invalid character"},
+		{"empty", "", "invalid number of segments"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := v.Verify(context.Background(), tt.token)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Verify() = %+v, %v, want error containing %q", p, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Verify() error = %v", err)
+			}
+			if p.Subject != "alice" || p.Name != "Alice" || p.Tenant != "t1" || len(p.Roles) != 1 || p.Roles[0] != "admin" {
+				t.Errorf("Verify() = %+v", p)
+			}
+		})
+	}
+}
+
+func TestVerifierAlgorithmsFollowConfiguration(t *testing.T) {
+	key := generateKey(t)
+	rsOnly, err := NewVerifier(Options{JWKSFile: writeJWKS(t, publicJWK("k1", &key.PublicKey))})
+	if err != nil {
+		t.Fatal(err)
+	}
+	hsOnly, err := NewVerifier(Options{Secret: testSecret})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c := &claims{RegisteredClaims: jwt.RegisteredClaims{
+		Subject:   "alice",
+		ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+	}}
+	hsToken := sign(t, jwt.SigningMethodHS256, "", []byte(testSecret), c)
+	rsToken := sign(t, jwt.SigningMethodRS256, "k1", key, c)
+	rsTokenWithoutKid := sign(t, jwt.SigningMethodRS256, "", key, c)
+
+	tests := []struct {
+		name     string
+		verifier *Verifier
+		token    string
+		wantErr  bool
+	}{
+		{"HS256 rejected without secret", rsOnly, hsToken, true},
+		{"RS256 rejected without JWKS", hsOnly, rsToken, true},
+		{"RS256 accepted with JWKS", rsOnly, rsToken, false},
+		{"kid optional with a single key", rsOnly, rsTokenWithoutKid, false},
+		{"HS256 accepted with secret", hsOnly, hsToken, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.verifier.Verify(context.Background(), tt.token)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Verify() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestVerifierKidRequiredWithSeveralKeys(t *testing.T) {
+	key := generateKey(t)
+	other := generateKey(t)
+	v, err := NewVerifier(Options{JWKSFile: writeJWKS(t,
+		publicJWK("k1", &key.PublicKey),
+		publicJWK("k2", &other.PublicKey),
+	)})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c := &claims{RegisteredClaims: jwt.RegisteredClaims{
+		Subject:   "alice",
+		ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+	}}
+	if _, err := v.Verify(context.Background(), sign(t, jwt.SigningMethodRS256, "", key, c)); err == nil {
+		t.Error("Verify() of token without kid succeeded, want error")
+	}
+	if _, err := v.Verify(context.Background(), sign(t, jwt.SigningMethodRS256, "k2", other, c)); err != nil {
+		t.Errorf("Verify() error = %v", err)
+	}
+}
+
+func TestLoadJWKSFile(t *testing.T) {
+	key := generateKey(t)
+	good := publicJWK("k1", &key.PublicKey)
+	with := func(modify func(k *jwk)) jwk {
+		k := good
+		modify(&k)
+		return k
+	}
+
+	tests := []struct {
+		name     string
+		keys     []jwk
+		wantKids []string
+		wantErr  bool
+	}{
+		{"single key", []jwk{good}, []string{"k1"}, false},
+		{"use and alg optional", []jwk{with(func(k *jwk) { k.Use, k.Alg = "", "" })}, []string{"k1"}, false},
+		{"encryption key ignored", []jwk{good, with(func(k *jwk) { k.Kid, k.Use = "enc", "enc" })}, []string{"k1"}, false},
+		{"other algorithm ignored", []jwk{good, with(func(k *jwk) { k.Kid, k.Alg = "ps", "PS256" })}, []string{"k1"}, false},
+		{"non RSA key ignored", []jwk{good, {Kty: "EC", Kid: "ec"}}, []string{"k1"}, false},
+		{"no usable keys", []jwk{{Kty: "EC", Kid: "ec"}}, nil, true},
+		{"duplicate kid", []jwk{good, good}, nil, true},
+		{"invalid modulus", []jwk{with(func(k *jwk) { k.N = "!!" })}, nil, true},
+		{"empty modulus", []jwk{with(func(k *jwk) { k.N = "" })}, nil, true},
+		{"exponent too small", []jwk{with(func(k *jwk) { k.E = "AQ" })}, nil, true},
+		{"padded base64", []jwk{with(func(k *jwk) { k.E = "AQAB==" })}, []string{"k1"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			keys, err := LoadJWKSFile(writeJWKS(t, tt.keys...))
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("LoadJWKSFile() = %v, want error", keys)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("LoadJWKSFile() error = %v", err)
+			}
+			if len(keys) != len(tt.wantKids) {
+				t.Fatalf("LoadJWKSFile() returned %d keys, want %d", len(keys), len(tt.wantKids))
+			}
+			for _, kid := range tt.wantKids {
+				if k, ok := keys[kid]; !ok || k.N.Cmp(key.N) != 0 || k.E != key.E {
+					t.Errorf("key %q = %v, want the test public key", kid, k)
+				}
+			}
+		})
+	}
+
+	if _, err := LoadJWKSFile(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Error("LoadJWKSFile() of missing file succeeded, want error")
+	}
+}
+
+func mustMarshalPKIX(t *testing.T, key *rsa.PublicKey) []byte {
+	t.Helper()
+	der, err := x509.MarshalPKIXPublicKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return der
+}
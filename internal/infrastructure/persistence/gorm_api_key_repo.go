@@ -0,0 +1,85 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+
+	"gozero-ddd/internal/domain/repository"
+	"gozero-ddd/internal/infrastructure/persistence/model"
+)
+
+// GormAPIKeyRepository GORM API Key 仓储实现
+type GormAPIKeyRepository struct {
+	db *gorm.DB
+}
+
+// NewGormAPIKeyRepository 创建 GORM API Key 仓储
+func NewGormAPIKeyRepository(db *gorm.DB) *GormAPIKeyRepository {
+	return &GormAPIKeyRepository{db: db}
+}
+
+// 确保实现了接口
+var _ repository.APIKeyRepository = (*GormAPIKeyRepository)(nil)
+
+// getDB 获取数据库连接（支持事务）
+func (r *GormAPIKeyRepository) getDB(ctx context.Context) *gorm.DB {
+	return GetDBFromContext(ctx, r.db)
+}
+
+// Save 保存 API Key（新增或更新）
+func (r *GormAPIKeyRepository) Save(ctx context.Context, key *repository.APIKey) error {
+	m := model.APIKeyModelFromAPIKey(key)
+	return r.getDB(ctx).WithContext(ctx).Save(m).Error
+}
+
+// FindByID 根据 ID 查找当前租户的 API Key，不存在时返回 nil
+func (r *GormAPIKeyRepository) FindByID(ctx context.Context, id string) (*repository.APIKey, error) {
+	return r.findOne(r.getDB(ctx).WithContext(ctx).Scopes(tenantScope(ctx, "")), "id = ?", id)
+}
+
+// FindByHash 根据密钥哈希查找，不存在时返回 nil
+// 认证时租户尚未确定，因此不按租户过滤
+func (r *GormAPIKeyRepository) FindByHash(ctx context.Context, hash string) (*repository.APIKey, error) {
+	return r.findOne(r.getDB(ctx).WithContext(ctx), "key_hash = ?", hash)
+}
+
+// FindAll 查找当前租户的所有 API Key，按创建时间倒序
+func (r *GormAPIKeyRepository) FindAll(ctx context.Context) ([]*repository.APIKey, error) {
+	var models []model.APIKeyModel
+	if err := r.getDB(ctx).WithContext(ctx).
+		Scopes(tenantScope(ctx, "")).
+		Order("created_at DESC").
+		Find(&models).Error; err != nil {
+		return nil, err
+	}
+
+	keys := make([]*repository.APIKey, len(models))
+	for i := range models {
+		keys[i] = models[i].ToAPIKey()
+	}
+	return keys, nil
+}
+
+// UpdateLastUsed 更新最近使用时间
+func (r *GormAPIKeyRepository) UpdateLastUsed(ctx context.Context, id string, at time.Time) error {
+	return r.getDB(ctx).WithContext(ctx).
+		Model(&model.APIKeyModel{}).
+		Where("id = ?", id).
+		Update("last_used_at", at).Error
+}
+
+// findOne 按条件查找单个 API Key
+func (r *GormAPIKeyRepository) findOne(db *gorm.DB, query string, args ...interface{}) (*repository.APIKey, error) {
+	var m model.APIKeyModel
+	err := db.Where(query, args...).First(&m).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return m.ToAPIKey(), nil
+}
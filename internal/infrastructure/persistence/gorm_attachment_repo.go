@@ -0,0 +1,91 @@
+package persistence
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+
+	"gozero-ddd/internal/domain/entity"
+	"gozero-ddd/internal/domain/repository"
+	"gozero-ddd/internal/domain/valueobject"
+	"gozero-ddd/internal/infrastructure/persistence/model"
+)
+
+// GormAttachmentRepository GORM 附件仓储实现
+type GormAttachmentRepository struct {
+	db *gorm.DB
+}
+
+// NewGormAttachmentRepository 创建 GORM 附件仓储
+func NewGormAttachmentRepository(db *gorm.DB) *GormAttachmentRepository {
+	return &GormAttachmentRepository{db: db}
+}
+
+// 确保实现了接口
+var _ repository.AttachmentRepository = (*GormAttachmentRepository)(nil)
+
+// getDB 获取数据库连接（支持事务）
+func (r *GormAttachmentRepository) getDB(ctx context.Context) *gorm.DB {
+	return GetDBFromContext(ctx, r.db)
+}
+
+// Save 保存附件
+func (r *GormAttachmentRepository) Save(ctx context.Context, att *entity.Attachment) error {
+	m := model.AttachmentModelFromEntity(att)
+	return r.getDB(ctx).WithContext(ctx).Save(m).Error
+}
+
+// Delete 删除附件
+func (r *GormAttachmentRepository) Delete(ctx context.Context, id valueobject.AttachmentID) error {
+	return r.getDB(ctx).WithContext(ctx).Scopes(tenantKnowledgeBaseScope(ctx, "knowledge_base_id")).Where("id = ?", id.String()).Delete(&model.AttachmentModel{}).Error
+}
+
+// FindByKnowledgeBaseID 查找知识库下的所有附件
+func (r *GormAttachmentRepository) FindByKnowledgeBaseID(ctx context.Context, kbID valueobject.KnowledgeBaseID) ([]*entity.Attachment, error) {
+	return r.find(ctx, "knowledge_base_id = ?", kbID.String())
+}
+
+// FindByDocumentID 查找文档的所有附件
+func (r *GormAttachmentRepository) FindByDocumentID(ctx context.Context, docID valueobject.DocumentID) ([]*entity.Attachment, error) {
+	return r.find(ctx, "document_id = ?", docID.String())
+}
+
+// DeleteByDocumentID 删除文档的所有附件
+func (r *GormAttachmentRepository) DeleteByDocumentID(ctx context.Context, docID valueobject.DocumentID) error {
+	return r.getDB(ctx).WithContext(ctx).Scopes(tenantKnowledgeBaseScope(ctx, "knowledge_base_id")).Where("document_id = ?", docID.String()).Delete(&model.AttachmentModel{}).Error
+}
+
+// DeleteByKnowledgeBaseID 删除知识库下的所有附件
+func (r *GormAttachmentRepository) DeleteByKnowledgeBaseID(ctx context.Context, kbID valueobject.KnowledgeBaseID) error {
+	return r.getDB(ctx).WithContext(ctx).Scopes(tenantKnowledgeBaseScope(ctx, "knowledge_base_id")).Where("knowledge_base_id = ?", kbID.String()).Delete(&model.AttachmentModel{}).Error
+}
+
+// ExistsByContentHash 检查是否仍有附件引用指定内容
+// 内容存储在所有租户间共享，因此不按租户过滤
+func (r *GormAttachmentRepository) ExistsByContentHash(ctx context.Context, hash valueobject.ContentHash) (bool, error) {
+	var count int64
+
+	err := r.getDB(ctx).WithContext(ctx).Model(&model.AttachmentModel{}).Where("content_hash = ?", hash.String()).Count(&count).Error
+	if err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+// find 按条件查找附件，按上传时间排序
+func (r *GormAttachmentRepository) find(ctx context.Context, query string, args ...interface{}) ([]*entity.Attachment, error) {
+	var models []model.AttachmentModel
+
+	err := r.getDB(ctx).WithContext(ctx).Scopes(tenantKnowledgeBaseScope(ctx, "knowledge_base_id")).Where(query, args...).Order("created_at ASC").Find(&models).Error
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*entity.Attachment, len(models))
+	for i, m := range models {
+		result[i] = m.ToEntity()
+	}
+
+	return result, nil
+}
@@ -0,0 +1,79 @@
+package persistence
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"gozero-ddd/internal/domain/repository"
+	"gozero-ddd/internal/infrastructure/persistence/model"
+)
+
+// GormAuditLogRepository GORM 审计日志仓储实现
+type GormAuditLogRepository struct {
+	db *gorm.DB
+}
+
+// NewGormAuditLogRepository 创建 GORM 审计日志仓储
+func NewGormAuditLogRepository(db *gorm.DB) *GormAuditLogRepository {
+	return &GormAuditLogRepository{db: db}
+}
+
+// 确保实现了接口
+var _ repository.AuditLogRepository = (*GormAuditLogRepository)(nil)
+
+// getDB 获取数据库连接（支持事务）
+func (r *GormAuditLogRepository) getDB(ctx context.Context) *gorm.DB {
+	return GetDBFromContext(ctx, r.db)
+}
+
+// Append 追加一条审计日志
+// Kafka 至少投递一次，同一事件可能被处理多次，事件ID已存在时忽略
+func (r *GormAuditLogRepository) Append(ctx context.Context, entry *repository.AuditLogEntry) error {
+	m := model.AuditLogModelFromEntry(entry)
+	if err := r.getDB(ctx).WithContext(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(m).Error; err != nil {
+		return err
+	}
+	entry.Seq = m.Seq
+	return nil
+}
+
+// Find 按条件查询当前租户的审计日志，按写入顺序倒序
+func (r *GormAuditLogRepository) Find(ctx context.Context, filter repository.AuditLogFilter) ([]*repository.AuditLogEntry, error) {
+	db := r.getDB(ctx).WithContext(ctx).Scopes(tenantScope(ctx, ""))
+	if filter.KnowledgeBaseID != "" {
+		db = db.Where("knowledge_base_id = ?", filter.KnowledgeBaseID)
+	}
+	if filter.Actor != "" {
+		db = db.Where("actor = ?", filter.Actor)
+	}
+	if filter.EventName != "" {
+		db = db.Where("event_name = ?", filter.EventName)
+	}
+	if filter.Since != nil {
+		db = db.Where("occurred_at >= ?", *filter.Since)
+	}
+	if filter.Until != nil {
+		db = db.Where("occurred_at < ?", *filter.Until)
+	}
+	if filter.BeforeSeq > 0 {
+		db = db.Where("seq < ?", filter.BeforeSeq)
+	}
+	if filter.Limit > 0 {
+		db = db.Limit(filter.Limit)
+	}
+
+	var models []model.AuditLogModel
+	if err := db.Order("seq DESC").Find(&models).Error; err != nil {
+		return nil, err
+	}
+
+	entries := make([]*repository.AuditLogEntry, len(models))
+	for i := range models {
+		entries[i] = models[i].ToEntry()
+	}
+	return entries, nil
+}
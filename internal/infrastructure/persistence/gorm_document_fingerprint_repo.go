@@ -0,0 +1,87 @@
+package persistence
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"gozero-ddd/internal/domain/repository"
+	"gozero-ddd/internal/domain/valueobject"
+	"gozero-ddd/internal/infrastructure/persistence/model"
+)
+
+// GormDocumentFingerprintRepository GORM 文档内容指纹仓储实现
+type GormDocumentFingerprintRepository struct {
+	db *gorm.DB
+}
+
+// NewGormDocumentFingerprintRepository 创建 GORM 文档内容指纹仓储
+func NewGormDocumentFingerprintRepository(db *gorm.DB) *GormDocumentFingerprintRepository {
+	return &GormDocumentFingerprintRepository{db: db}
+}
+
+// 确保实现了接口
+var _ repository.DocumentFingerprintRepository = (*GormDocumentFingerprintRepository)(nil)
+
+// getDB 获取数据库连接（支持事务）
+func (r *GormDocumentFingerprintRepository) getDB(ctx context.Context) *gorm.DB {
+	return GetDBFromContext(ctx, r.db)
+}
+
+// Save 保存文档的内容指纹（已存在时覆盖）
+func (r *GormDocumentFingerprintRepository) Save(
+	ctx context.Context,
+	docID valueobject.DocumentID,
+	kbID valueobject.KnowledgeBaseID,
+	fp valueobject.ContentFingerprint,
+) error {
+	m := model.DocumentFingerprintModelFromValueObject(docID, kbID, fp)
+	return r.getDB(ctx).WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "document_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"knowledge_base_id", "content_hash", "sim_hash", "updated_at"}),
+		}).
+		Create(m).Error
+}
+
+// Delete 删除文档的内容指纹
+func (r *GormDocumentFingerprintRepository) Delete(ctx context.Context, docID valueobject.DocumentID) error {
+	return r.getDB(ctx).WithContext(ctx).
+		Scopes(tenantKnowledgeBaseScope(ctx, "knowledge_base_id")).
+		Where("document_id = ?", docID.String()).
+		Delete(&model.DocumentFingerprintModel{}).Error
+}
+
+// DeleteByKnowledgeBaseID 删除知识库下所有文档的内容指纹
+func (r *GormDocumentFingerprintRepository) DeleteByKnowledgeBaseID(ctx context.Context, kbID valueobject.KnowledgeBaseID) error {
+	return r.getDB(ctx).WithContext(ctx).
+		Scopes(tenantKnowledgeBaseScope(ctx, "knowledge_base_id")).
+		Where("knowledge_base_id = ?", kbID.String()).
+		Delete(&model.DocumentFingerprintModel{}).Error
+}
+
+// Find 查找文档的内容指纹
+// 与文档表、知识库表联表，排除回收站中的文档和知识库，同时取得文档标题
+func (r *GormDocumentFingerprintRepository) Find(ctx context.Context, kbID valueobject.KnowledgeBaseID) ([]valueobject.DocumentFingerprint, error) {
+	var rows []model.DocumentFingerprintRow
+
+	db := r.getDB(ctx).WithContext(ctx).
+		Model(&model.DocumentFingerprintModel{}).
+		Select("document_fingerprints.*, documents.title").
+		Joins("JOIN documents ON documents.id = document_fingerprints.document_id AND documents.deleted_at IS NULL").
+		Joins("JOIN knowledge_bases ON knowledge_bases.id = document_fingerprints.knowledge_base_id AND knowledge_bases.deleted_at IS NULL").
+		Scopes(tenantScope(ctx, "knowledge_bases"))
+	if !kbID.IsEmpty() {
+		db = db.Where("document_fingerprints.knowledge_base_id = ?", kbID.String())
+	}
+	if err := db.Order("document_fingerprints.document_id ASC").Find(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	result := make([]valueobject.DocumentFingerprint, len(rows))
+	for i := range rows {
+		result[i] = rows[i].ToValueObject()
+	}
+	return result, nil
+}
@@ -0,0 +1,132 @@
+package persistence
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+
+	"gozero-ddd/internal/domain/repository"
+	"gozero-ddd/internal/domain/valueobject"
+	"gozero-ddd/internal/infrastructure/persistence/model"
+)
+
+// GormDocumentLinkRepository GORM 文档链接仓储实现
+type GormDocumentLinkRepository struct {
+	db *gorm.DB
+}
+
+// NewGormDocumentLinkRepository 创建 GORM 文档链接仓储
+func NewGormDocumentLinkRepository(db *gorm.DB) *GormDocumentLinkRepository {
+	return &GormDocumentLinkRepository{db: db}
+}
+
+// 确保实现了接口
+var _ repository.DocumentLinkRepository = (*GormDocumentLinkRepository)(nil)
+
+// getDB 获取数据库连接（支持事务）
+func (r *GormDocumentLinkRepository) getDB(ctx context.Context) *gorm.DB {
+	return GetDBFromContext(ctx, r.db)
+}
+
+// liveSources 只保留源文档未被删除的链接
+// 文档移入回收站时保留其链接记录，恢复后链接随之恢复
+func (r *GormDocumentLinkRepository) liveSources(ctx context.Context) *gorm.DB {
+	return r.getDB(ctx).WithContext(ctx).
+		Model(&model.DocumentLinkModel{}).
+		Select("document_links.*").
+		Joins("JOIN documents ON documents.id = document_links.source_document_id AND documents.deleted_at IS NULL").
+		Scopes(tenantScope(ctx, "documents"))
+}
+
+// ReplaceLinks 替换源文档的全部出链
+func (r *GormDocumentLinkRepository) ReplaceLinks(
+	ctx context.Context,
+	sourceDocID valueobject.DocumentID,
+	sourceKBID valueobject.KnowledgeBaseID,
+	links []valueobject.DocumentLink,
+) error {
+	db := r.getDB(ctx).WithContext(ctx)
+
+	if err := db.Scopes(tenantKnowledgeBaseScope(ctx, "source_knowledge_base_id")).Where("source_document_id = ?", sourceDocID.String()).Delete(&model.DocumentLinkModel{}).Error; err != nil {
+		return err
+	}
+	if len(links) == 0 {
+		return nil
+	}
+
+	models := make([]*model.DocumentLinkModel, len(links))
+	for i, link := range links {
+		models[i] = model.DocumentLinkModelFromValueObject(sourceDocID, sourceKBID, link)
+	}
+	return db.Create(&models).Error
+}
+
+// FindBySource 查找源文档的全部出链
+func (r *GormDocumentLinkRepository) FindBySource(ctx context.Context, sourceDocID valueobject.DocumentID) ([]valueobject.DocumentLink, error) {
+	var models []model.DocumentLinkModel
+
+	err := r.getDB(ctx).WithContext(ctx).
+		Scopes(tenantKnowledgeBaseScope(ctx, "source_knowledge_base_id")).
+		Where("source_document_id = ?", sourceDocID.String()).
+		Order("id ASC").
+		Find(&models).Error
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]valueobject.DocumentLink, len(models))
+	for i, m := range models {
+		result[i] = m.ToValueObject()
+	}
+
+	return result, nil
+}
+
+// FindBacklinks 查找引用目标文档的链接
+func (r *GormDocumentLinkRepository) FindBacklinks(
+	ctx context.Context,
+	kbID valueobject.KnowledgeBaseID,
+	docID valueobject.DocumentID,
+	title string,
+) ([]valueobject.DocumentLinkEdge, error) {
+	var models []model.DocumentLinkModel
+
+	err := r.liveSources(ctx).
+		Where(
+			"(document_links.link_type = ? AND document_links.source_knowledge_base_id = ? AND document_links.target_title = ?) OR "+
+				"(document_links.link_type = ? AND document_links.target_document_id = ?)",
+			valueobject.LinkTypeWiki.String(), kbID.String(), title,
+			valueobject.LinkTypeURL.String(), docID.String(),
+		).
+		Order("document_links.id ASC").
+		Find(&models).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return toEdges(models), nil
+}
+
+// FindByKnowledgeBaseID 查找知识库内所有文档的出链
+func (r *GormDocumentLinkRepository) FindByKnowledgeBaseID(ctx context.Context, kbID valueobject.KnowledgeBaseID) ([]valueobject.DocumentLinkEdge, error) {
+	var models []model.DocumentLinkModel
+
+	err := r.liveSources(ctx).
+		Where("document_links.source_knowledge_base_id = ?", kbID.String()).
+		Order("document_links.id ASC").
+		Find(&models).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return toEdges(models), nil
+}
+
+// toEdges 将数据库模型列表转换为链接图中的边
+func toEdges(models []model.DocumentLinkModel) []valueobject.DocumentLinkEdge {
+	result := make([]valueobject.DocumentLinkEdge, len(models))
+	for i, m := range models {
+		result[i] = m.ToEdge()
+	}
+	return result
+}
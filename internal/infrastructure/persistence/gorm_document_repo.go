@@ -3,11 +3,14 @@ package persistence
 import (
 	"context"
 	"errors"
+	"time"
 
 	"gorm.io/gorm"
 
+	"gozero-ddd/internal/domain"
 	"gozero-ddd/internal/domain/entity"
 	"gozero-ddd/internal/domain/repository"
+	"gozero-ddd/internal/domain/tenant"
 	"gozero-ddd/internal/domain/valueobject"
 	"gozero-ddd/internal/infrastructure/persistence/model"
 )
@@ -30,17 +33,28 @@ func (r *GormDocumentRepository) getDB(ctx context.Context) *gorm.DB {
 	return GetDBFromContext(ctx, r.db)
 }
 
-// Save 保存文档
+// Save 保存文档，文档归属于上下文中的租户
+// ID 已被其他租户的文档占用时返回 ErrDocumentIDExists
 func (r *GormDocumentRepository) Save(ctx context.Context, doc *entity.Document) error {
 	m := model.DocumentModelFromEntity(doc)
-	return r.getDB(ctx).WithContext(ctx).Save(m).Error
+	m.TenantID = tenant.FromContext(ctx).String()
+
+	db := r.getDB(ctx).WithContext(ctx)
+	taken, err := ownedByOtherTenant(ctx, db, &model.DocumentModel{}, m.ID)
+	if err != nil {
+		return err
+	}
+	if taken {
+		return domain.ErrDocumentIDExists
+	}
+	return db.Save(m).Error
 }
 
 // FindByID 根据ID查找文档
 func (r *GormDocumentRepository) FindByID(ctx context.Context, id valueobject.DocumentID) (*entity.Document, error) {
 	var m model.DocumentModel
 
-	err := r.getDB(ctx).WithContext(ctx).Where("id = ?", id.String()).First(&m).Error
+	err := r.getDB(ctx).WithContext(ctx).Scopes(tenantScope(ctx, "")).Where("id = ?", id.String()).First(&m).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, nil
@@ -55,7 +69,7 @@ func (r *GormDocumentRepository) FindByID(ctx context.Context, id valueobject.Do
 func (r *GormDocumentRepository) FindByKnowledgeBaseID(ctx context.Context, kbID valueobject.KnowledgeBaseID) ([]*entity.Document, error) {
 	var models []model.DocumentModel
 
-	err := r.getDB(ctx).WithContext(ctx).
+	err := r.getDB(ctx).WithContext(ctx).Scopes(tenantScope(ctx, "")).
 		Where("knowledge_base_id = ?", kbID.String()).
 		Order("created_at DESC").
 		Find(&models).Error
@@ -71,34 +85,153 @@ func (r *GormDocumentRepository) FindByKnowledgeBaseID(ctx context.Context, kbID
 	return result, nil
 }
 
-// Delete 删除文档
+// FindByStatus 根据知识库ID和发布状态查找文档
+func (r *GormDocumentRepository) FindByStatus(ctx context.Context, kbID valueobject.KnowledgeBaseID, status valueobject.DocumentStatus) ([]*entity.Document, error) {
+	var models []model.DocumentModel
+
+	err := r.getDB(ctx).WithContext(ctx).Scopes(tenantScope(ctx, "")).
+		Where("knowledge_base_id = ? AND status = ?", kbID.String(), status.String()).
+		Order("created_at DESC").
+		Find(&models).Error
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*entity.Document, len(models))
+	for i, m := range models {
+		result[i] = m.ToEntity()
+	}
+
+	return result, nil
+}
+
+// FindDueForPublish 查找到达定时发布时间的已审核文档
+func (r *GormDocumentRepository) FindDueForPublish(ctx context.Context, now time.Time) ([]*entity.Document, error) {
+	return r.findDue(ctx, "publish_at", valueobject.DocumentStatusApproved, now)
+}
+
+// FindDueForExpiry 查找到达定时下线时间的已发布文档
+func (r *GormDocumentRepository) FindDueForExpiry(ctx context.Context, now time.Time) ([]*entity.Document, error) {
+	return r.findDue(ctx, "expire_at", valueobject.DocumentStatusPublished, now)
+}
+
+// findDue 按状态和时间列查找到期文档
+func (r *GormDocumentRepository) findDue(ctx context.Context, column string, status valueobject.DocumentStatus, now time.Time) ([]*entity.Document, error) {
+	var models []model.DocumentModel
+
+	err := r.getDB(ctx).WithContext(ctx).Scopes(tenantScope(ctx, "")).
+		Where("status = ? AND "+column+" IS NOT NULL AND "+column+" <= ?", status.String(), now).
+		Order(column + " ASC").
+		Find(&models).Error
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*entity.Document, len(models))
+	for i, m := range models {
+		result[i] = m.ToEntity()
+	}
+
+	return result, nil
+}
+
+// Delete 删除文档（软删除）
 func (r *GormDocumentRepository) Delete(ctx context.Context, id valueobject.DocumentID) error {
-	return r.getDB(ctx).WithContext(ctx).Where("id = ?", id.String()).Delete(&model.DocumentModel{}).Error
+	return r.getDB(ctx).WithContext(ctx).Scopes(tenantScope(ctx, "")).Where("id = ?", id.String()).Delete(&model.DocumentModel{}).Error
 }
 
-// DeleteByKnowledgeBaseID 删除知识库下所有文档
+// DeleteByKnowledgeBaseID 删除知识库下所有文档（软删除）
+// 同时标记 trashed_with_knowledge_base，已在回收站中的文档不受影响（Model 查询默认排除已删除记录）
 func (r *GormDocumentRepository) DeleteByKnowledgeBaseID(ctx context.Context, kbID valueobject.KnowledgeBaseID) error {
-	return r.getDB(ctx).WithContext(ctx).Where("knowledge_base_id = ?", kbID.String()).Delete(&model.DocumentModel{}).Error
+	return r.getDB(ctx).WithContext(ctx).Scopes(tenantScope(ctx, "")).
+		Model(&model.DocumentModel{}).
+		Where("knowledge_base_id = ?", kbID.String()).
+		Updates(map[string]interface{}{"deleted_at": time.Now(), "trashed_with_knowledge_base": true}).Error
 }
 
-// SearchByTags 根据标签搜索文档
-func (r *GormDocumentRepository) SearchByTags(ctx context.Context, tags []string) ([]*entity.Document, error) {
-	if len(tags) == 0 {
-		return make([]*entity.Document, 0), nil
+// SearchByTagQuery 根据标签查询表达式搜索文档
+func (r *GormDocumentRepository) SearchByTagQuery(ctx context.Context, kbID valueobject.KnowledgeBaseID, expr valueobject.TagExpr) ([]*entity.Document, error) {
+	condition, args, err := compileTagQuery(expr)
+	if err != nil {
+		return nil, err
+	}
+
+	query := r.getDB(ctx).WithContext(ctx).Scopes(tenantScope(ctx, "")).Where(condition, args...)
+	if !kbID.IsEmpty() {
+		query = query.Where("knowledge_base_id = ?", kbID.String())
 	}
 
-	query := r.getDB(ctx).WithContext(ctx).Model(&model.DocumentModel{})
+	var models []model.DocumentModel
+	if err := query.Order("created_at DESC").Find(&models).Error; err != nil {
+		return nil, err
+	}
 
-	for i, tag := range tags {
-		if i == 0 {
-			query = query.Where("JSON_CONTAINS(tags, ?)", `"`+tag+`"`)
-		} else {
-			query = query.Or("JSON_CONTAINS(tags, ?)", `"`+tag+`"`)
+	result := make([]*entity.Document, len(models))
+	for i, m := range models {
+		result[i] = m.ToEntity()
+	}
+
+	return result, nil
+}
+
+// FindDeleted 查找回收站中的文档
+func (r *GormDocumentRepository) FindDeleted(ctx context.Context, kbID valueobject.KnowledgeBaseID) ([]*entity.Document, error) {
+	var models []model.DocumentModel
+
+	query := r.getDB(ctx).WithContext(ctx).Scopes(tenantScope(ctx, "")).Unscoped().Where("deleted_at IS NOT NULL")
+	if !kbID.IsEmpty() {
+		query = query.Where("knowledge_base_id = ?", kbID.String())
+	}
+
+	if err := query.Order("deleted_at DESC").Find(&models).Error; err != nil {
+		return nil, err
+	}
+
+	result := make([]*entity.Document, len(models))
+	for i, m := range models {
+		result[i] = m.ToEntity()
+	}
+
+	return result, nil
+}
+
+// CountDeleted 统计知识库在回收站中的文档数量
+func (r *GormDocumentRepository) CountDeleted(ctx context.Context, kbID valueobject.KnowledgeBaseID) (int, error) {
+	var count int64
+	err := r.getDB(ctx).WithContext(ctx).Scopes(tenantScope(ctx, "")).Unscoped().
+		Model(&model.DocumentModel{}).
+		Where("knowledge_base_id = ? AND deleted_at IS NOT NULL", kbID.String()).
+		Count(&count).Error
+	if err != nil {
+		return 0, err
+	}
+	return int(count), nil
+}
+
+// FindDeletedByID 根据ID查找回收站中的文档
+func (r *GormDocumentRepository) FindDeletedByID(ctx context.Context, id valueobject.DocumentID) (*entity.Document, error) {
+	var m model.DocumentModel
+
+	err := r.getDB(ctx).WithContext(ctx).Scopes(tenantScope(ctx, "")).Unscoped().
+		Where("id = ? AND deleted_at IS NOT NULL", id.String()).
+		First(&m).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
 		}
+		return nil, err
 	}
 
+	return m.ToEntity(), nil
+}
+
+// FindDeletedBefore 查找在指定时间之前移入回收站的文档
+func (r *GormDocumentRepository) FindDeletedBefore(ctx context.Context, before time.Time) ([]*entity.Document, error) {
 	var models []model.DocumentModel
-	err := query.Order("created_at DESC").Find(&models).Error
+
+	err := r.getDB(ctx).WithContext(ctx).Scopes(tenantScope(ctx, "")).Unscoped().
+		Where("deleted_at IS NOT NULL AND deleted_at < ?", before).
+		Find(&models).Error
 	if err != nil {
 		return nil, err
 	}
@@ -110,3 +243,48 @@ func (r *GormDocumentRepository) SearchByTags(ctx context.Context, tags []string
 
 	return result, nil
 }
+
+// Restore 从回收站恢复文档
+func (r *GormDocumentRepository) Restore(ctx context.Context, id valueobject.DocumentID) error {
+	return r.getDB(ctx).WithContext(ctx).Scopes(tenantScope(ctx, "")).Unscoped().
+		Model(&model.DocumentModel{}).
+		Where("id = ?", id.String()).
+		Updates(map[string]interface{}{"deleted_at": nil, "trashed_with_knowledge_base": false, "updated_at": time.Now()}).Error
+}
+
+// RestoreByKnowledgeBaseID 恢复随知识库一起移入回收站的文档
+func (r *GormDocumentRepository) RestoreByKnowledgeBaseID(ctx context.Context, kbID valueobject.KnowledgeBaseID) error {
+	return r.getDB(ctx).WithContext(ctx).Scopes(tenantScope(ctx, "")).Unscoped().
+		Model(&model.DocumentModel{}).
+		Where("knowledge_base_id = ? AND deleted_at IS NOT NULL AND trashed_with_knowledge_base = ?", kbID.String(), true).
+		Updates(map[string]interface{}{"deleted_at": nil, "trashed_with_knowledge_base": false, "updated_at": time.Now()}).Error
+}
+
+// Purge 彻底删除文档
+func (r *GormDocumentRepository) Purge(ctx context.Context, id valueobject.DocumentID) error {
+	return r.getDB(ctx).WithContext(ctx).Scopes(tenantScope(ctx, "")).Unscoped().Where("id = ?", id.String()).Delete(&model.DocumentModel{}).Error
+}
+
+// PurgeByKnowledgeBaseID 彻底删除知识库下所有文档
+func (r *GormDocumentRepository) PurgeByKnowledgeBaseID(ctx context.Context, kbID valueobject.KnowledgeBaseID) error {
+	return r.getDB(ctx).WithContext(ctx).Scopes(tenantScope(ctx, "")).Unscoped().Where("knowledge_base_id = ?", kbID.String()).Delete(&model.DocumentModel{}).Error
+}
+
+// CountByStatus 按状态统计文档数量（不包括回收站中的）
+func (r *GormDocumentRepository) CountByStatus(ctx context.Context) (map[valueobject.DocumentStatus]int, error) {
+	var rows []statusCount
+	err := r.getDB(ctx).WithContext(ctx).Scopes(tenantScope(ctx, "")).
+		Model(&model.DocumentModel{}).
+		Select("status, COUNT(*) AS count").
+		Group("status").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[valueobject.DocumentStatus]int, len(rows))
+	for _, row := range rows {
+		result[valueobject.DocumentStatus(row.Status)] = row.Count
+	}
+	return result, nil
+}
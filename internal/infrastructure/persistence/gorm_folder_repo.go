@@ -0,0 +1,67 @@
+package persistence
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+
+	"gozero-ddd/internal/domain/entity"
+	"gozero-ddd/internal/domain/repository"
+	"gozero-ddd/internal/domain/valueobject"
+	"gozero-ddd/internal/infrastructure/persistence/model"
+)
+
+// GormFolderRepository GORM 文件夹仓储实现
+type GormFolderRepository struct {
+	db *gorm.DB
+}
+
+// NewGormFolderRepository 创建 GORM 文件夹仓储
+func NewGormFolderRepository(db *gorm.DB) *GormFolderRepository {
+	return &GormFolderRepository{db: db}
+}
+
+// 确保实现了接口
+var _ repository.FolderRepository = (*GormFolderRepository)(nil)
+
+// getDB 获取数据库连接（支持事务）
+func (r *GormFolderRepository) getDB(ctx context.Context) *gorm.DB {
+	return GetDBFromContext(ctx, r.db)
+}
+
+// Save 保存文件夹
+func (r *GormFolderRepository) Save(ctx context.Context, folder *entity.Folder) error {
+	m := model.FolderModelFromEntity(folder)
+	return r.getDB(ctx).WithContext(ctx).Save(m).Error
+}
+
+// FindByKnowledgeBaseID 查找知识库下的所有文件夹
+func (r *GormFolderRepository) FindByKnowledgeBaseID(ctx context.Context, kbID valueobject.KnowledgeBaseID) ([]*entity.Folder, error) {
+	var models []model.FolderModel
+
+	err := r.getDB(ctx).WithContext(ctx).
+		Scopes(tenantKnowledgeBaseScope(ctx, "knowledge_base_id")).
+		Where("knowledge_base_id = ?", kbID.String()).
+		Order("name ASC").
+		Find(&models).Error
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*entity.Folder, len(models))
+	for i, m := range models {
+		result[i] = m.ToEntity()
+	}
+
+	return result, nil
+}
+
+// Delete 删除文件夹
+func (r *GormFolderRepository) Delete(ctx context.Context, id valueobject.FolderID) error {
+	return r.getDB(ctx).WithContext(ctx).Scopes(tenantKnowledgeBaseScope(ctx, "knowledge_base_id")).Where("id = ?", id.String()).Delete(&model.FolderModel{}).Error
+}
+
+// DeleteByKnowledgeBaseID 删除知识库下的所有文件夹
+func (r *GormFolderRepository) DeleteByKnowledgeBaseID(ctx context.Context, kbID valueobject.KnowledgeBaseID) error {
+	return r.getDB(ctx).WithContext(ctx).Scopes(tenantKnowledgeBaseScope(ctx, "knowledge_base_id")).Where("knowledge_base_id = ?", kbID.String()).Delete(&model.FolderModel{}).Error
+}
@@ -0,0 +1,92 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"gozero-ddd/internal/domain/repository"
+	"gozero-ddd/internal/infrastructure/persistence/model"
+)
+
+// GormIdempotencyRepository GORM 幂等键仓储实现
+// 幂等键记录独立于业务事务，始终使用默认连接
+type GormIdempotencyRepository struct {
+	db *gorm.DB
+}
+
+// NewGormIdempotencyRepository 创建 GORM 幂等键仓储
+func NewGormIdempotencyRepository(db *gorm.DB) *GormIdempotencyRepository {
+	return &GormIdempotencyRepository{db: db}
+}
+
+// 确保实现了接口
+var _ repository.IdempotencyRepository = (*GormIdempotencyRepository)(nil)
+
+// Reserve 占用 record 作用范围内的幂等键
+// 依赖主键唯一约束保证并发请求中只有一个能占用成功
+func (r *GormIdempotencyRepository) Reserve(ctx context.Context, record *repository.IdempotencyRecord) (*repository.IdempotencyRecord, error) {
+	db := r.db.WithContext(ctx)
+
+	// 已过期的记录视为不存在
+	if err := db.Scopes(idempotencyScope(record.IdempotencyScope)).Where("expires_at <= ?", time.Now()).
+		Delete(&model.IdempotencyKeyModel{}).Error; err != nil {
+		return nil, err
+	}
+
+	m := model.IdempotencyKeyModelFromRecord(record)
+	result := db.Clauses(clause.OnConflict{DoNothing: true}).Create(m)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 1 {
+		return nil, nil
+	}
+
+	var existing model.IdempotencyKeyModel
+	err := db.Scopes(idempotencyScope(record.IdempotencyScope)).First(&existing).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		// 已有记录恰好在两次查询之间被释放，视为冲突，由客户端重试
+		return &repository.IdempotencyRecord{IdempotencyScope: record.IdempotencyScope, Fingerprint: record.Fingerprint}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return existing.ToRecord(), nil
+}
+
+// Complete 保存请求的处理结果
+func (r *GormIdempotencyRepository) Complete(ctx context.Context, scope repository.IdempotencyScope, statusCode int, contentType string, body []byte) error {
+	return r.db.WithContext(ctx).Model(&model.IdempotencyKeyModel{}).
+		Scopes(idempotencyScope(scope)).
+		Updates(map[string]interface{}{
+			"completed":    true,
+			"status_code":  statusCode,
+			"content_type": contentType,
+			"body":         body,
+		}).Error
+}
+
+// Release 删除未完成的记录
+func (r *GormIdempotencyRepository) Release(ctx context.Context, scope repository.IdempotencyScope) error {
+	return r.db.WithContext(ctx).
+		Scopes(idempotencyScope(scope)).
+		Where("completed = ?", false).
+		Delete(&model.IdempotencyKeyModel{}).Error
+}
+
+// DeleteExpired 删除过期记录
+func (r *GormIdempotencyRepository) DeleteExpired(ctx context.Context, before time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).Where("expires_at <= ?", before).Delete(&model.IdempotencyKeyModel{})
+	return result.RowsAffected, result.Error
+}
+
+// idempotencyScope 按作用范围（租户、调用方、幂等键）定位记录
+func idempotencyScope(scope repository.IdempotencyScope) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where("tenant_id = ? AND actor = ? AND idempotency_key = ?", scope.TenantID, scope.Actor, scope.Key)
+	}
+}
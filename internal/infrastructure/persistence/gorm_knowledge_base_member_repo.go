@@ -0,0 +1,122 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"gozero-ddd/internal/domain/repository"
+	"gozero-ddd/internal/domain/valueobject"
+	"gozero-ddd/internal/infrastructure/persistence/model"
+)
+
+// GormKnowledgeBaseMemberRepository GORM 知识库成员仓储实现
+type GormKnowledgeBaseMemberRepository struct {
+	db *gorm.DB
+}
+
+// NewGormKnowledgeBaseMemberRepository 创建 GORM 知识库成员仓储
+func NewGormKnowledgeBaseMemberRepository(db *gorm.DB) *GormKnowledgeBaseMemberRepository {
+	return &GormKnowledgeBaseMemberRepository{db: db}
+}
+
+// 确保实现了接口
+var _ repository.KnowledgeBaseMemberRepository = (*GormKnowledgeBaseMemberRepository)(nil)
+
+// getDB 获取数据库连接（支持事务）
+func (r *GormKnowledgeBaseMemberRepository) getDB(ctx context.Context) *gorm.DB {
+	return GetDBFromContext(ctx, r.db)
+}
+
+// Save 保存成员（已存在时覆盖角色）
+func (r *GormKnowledgeBaseMemberRepository) Save(ctx context.Context, member *repository.KnowledgeBaseMember) error {
+	m := model.KnowledgeBaseMemberModelFromMember(member)
+	return r.getDB(ctx).WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "knowledge_base_id"}, {Name: "subject"}},
+			DoUpdates: clause.AssignmentColumns([]string{"role", "granted_by", "granted_at"}),
+		}).
+		Create(m).Error
+}
+
+// Delete 删除成员
+func (r *GormKnowledgeBaseMemberRepository) Delete(ctx context.Context, kbID valueobject.KnowledgeBaseID, subject string) error {
+	return r.getDB(ctx).WithContext(ctx).
+		Scopes(tenantKnowledgeBaseScope(ctx, "knowledge_base_id")).
+		Where("knowledge_base_id = ? AND subject = ?", kbID.String(), subject).
+		Delete(&model.KnowledgeBaseMemberModel{}).Error
+}
+
+// DeleteByKnowledgeBaseID 删除知识库的所有成员
+func (r *GormKnowledgeBaseMemberRepository) DeleteByKnowledgeBaseID(ctx context.Context, kbID valueobject.KnowledgeBaseID) error {
+	return r.getDB(ctx).WithContext(ctx).
+		Scopes(tenantKnowledgeBaseScope(ctx, "knowledge_base_id")).
+		Where("knowledge_base_id = ?", kbID.String()).
+		Delete(&model.KnowledgeBaseMemberModel{}).Error
+}
+
+// Find 查找调用方在知识库中的成员记录，不存在时返回 nil
+func (r *GormKnowledgeBaseMemberRepository) Find(ctx context.Context, kbID valueobject.KnowledgeBaseID, subject string) (*repository.KnowledgeBaseMember, error) {
+	var m model.KnowledgeBaseMemberModel
+	err := r.getDB(ctx).WithContext(ctx).
+		Scopes(tenantKnowledgeBaseScope(ctx, "knowledge_base_id")).
+		Where("knowledge_base_id = ? AND subject = ?", kbID.String(), subject).
+		First(&m).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return m.ToMember(), nil
+}
+
+// FindByKnowledgeBaseID 查找知识库的所有成员
+func (r *GormKnowledgeBaseMemberRepository) FindByKnowledgeBaseID(ctx context.Context, kbID valueobject.KnowledgeBaseID) ([]*repository.KnowledgeBaseMember, error) {
+	var models []model.KnowledgeBaseMemberModel
+	if err := r.getDB(ctx).WithContext(ctx).
+		Scopes(tenantKnowledgeBaseScope(ctx, "knowledge_base_id")).
+		Where("knowledge_base_id = ?", kbID.String()).
+		Order("granted_at ASC").
+		Find(&models).Error; err != nil {
+		return nil, err
+	}
+	return toMembers(models), nil
+}
+
+// FindByKnowledgeBaseIDForUpdate 查找知识库的所有成员并加行锁（SELECT ... FOR UPDATE），直到所在事务结束
+func (r *GormKnowledgeBaseMemberRepository) FindByKnowledgeBaseIDForUpdate(ctx context.Context, kbID valueobject.KnowledgeBaseID) ([]*repository.KnowledgeBaseMember, error) {
+	var models []model.KnowledgeBaseMemberModel
+	if err := r.getDB(ctx).WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Scopes(tenantKnowledgeBaseScope(ctx, "knowledge_base_id")).
+		Where("knowledge_base_id = ?", kbID.String()).
+		Order("granted_at ASC").
+		Find(&models).Error; err != nil {
+		return nil, err
+	}
+	return toMembers(models), nil
+}
+
+// FindBySubject 查找调用方加入的所有知识库成员记录
+func (r *GormKnowledgeBaseMemberRepository) FindBySubject(ctx context.Context, subject string) ([]*repository.KnowledgeBaseMember, error) {
+	var models []model.KnowledgeBaseMemberModel
+	if err := r.getDB(ctx).WithContext(ctx).
+		Scopes(tenantKnowledgeBaseScope(ctx, "knowledge_base_id")).
+		Where("subject = ?", subject).
+		Find(&models).Error; err != nil {
+		return nil, err
+	}
+	return toMembers(models), nil
+}
+
+// toMembers 将数据库模型列表转换为成员列表
+func toMembers(models []model.KnowledgeBaseMemberModel) []*repository.KnowledgeBaseMember {
+	members := make([]*repository.KnowledgeBaseMember, len(models))
+	for i := range models {
+		members[i] = models[i].ToMember()
+	}
+	return members
+}
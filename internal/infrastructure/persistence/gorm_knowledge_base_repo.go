@@ -3,26 +3,41 @@ package persistence
 import (
 	"context"
 	"errors"
+	"time"
 
 	"gorm.io/gorm"
 
+	"gozero-ddd/internal/domain"
 	"gozero-ddd/internal/domain/entity"
 	"gozero-ddd/internal/domain/repository"
+	"gozero-ddd/internal/domain/tenant"
 	"gozero-ddd/internal/domain/valueobject"
 	"gozero-ddd/internal/infrastructure/persistence/model"
 )
 
 // GormKnowledgeBaseRepository GORM 知识库仓储实现
 type GormKnowledgeBaseRepository struct {
-	db      *gorm.DB
-	docRepo repository.DocumentRepository
+	db         *gorm.DB
+	docRepo    repository.DocumentRepository
+	folderRepo repository.FolderRepository
+	tagRepo    repository.TagRepository
+	attRepo    repository.AttachmentRepository
 }
 
 // NewGormKnowledgeBaseRepository 创建 GORM 知识库仓储
-func NewGormKnowledgeBaseRepository(db *gorm.DB, docRepo repository.DocumentRepository) *GormKnowledgeBaseRepository {
+func NewGormKnowledgeBaseRepository(
+	db *gorm.DB,
+	docRepo repository.DocumentRepository,
+	folderRepo repository.FolderRepository,
+	tagRepo repository.TagRepository,
+	attRepo repository.AttachmentRepository,
+) *GormKnowledgeBaseRepository {
 	return &GormKnowledgeBaseRepository{
-		db:      db,
-		docRepo: docRepo,
+		db:         db,
+		docRepo:    docRepo,
+		folderRepo: folderRepo,
+		tagRepo:    tagRepo,
+		attRepo:    attRepo,
 	}
 }
 
@@ -34,17 +49,28 @@ func (r *GormKnowledgeBaseRepository) getDB(ctx context.Context) *gorm.DB {
 	return GetDBFromContext(ctx, r.db)
 }
 
-// Save 保存知识库（创建或更新）
+// Save 保存知识库（创建或更新），知识库归属于上下文中的租户
+// ID 已被其他租户的知识库占用时返回 ErrKnowledgeBaseIDExists
 func (r *GormKnowledgeBaseRepository) Save(ctx context.Context, kb *entity.KnowledgeBase) error {
 	m := model.KnowledgeBaseModelFromEntity(kb)
-	return r.getDB(ctx).WithContext(ctx).Save(m).Error
+	m.TenantID = tenant.FromContext(ctx).String()
+
+	db := r.getDB(ctx).WithContext(ctx)
+	taken, err := ownedByOtherTenant(ctx, db, &model.KnowledgeBaseModel{}, m.ID)
+	if err != nil {
+		return err
+	}
+	if taken {
+		return domain.ErrKnowledgeBaseIDExists
+	}
+	return db.Save(m).Error
 }
 
 // FindByID 根据ID查找知识库
 func (r *GormKnowledgeBaseRepository) FindByID(ctx context.Context, id valueobject.KnowledgeBaseID) (*entity.KnowledgeBase, error) {
 	var m model.KnowledgeBaseModel
 
-	err := r.getDB(ctx).WithContext(ctx).Where("id = ?", id.String()).First(&m).Error
+	err := r.getDB(ctx).WithContext(ctx).Scopes(tenantScope(ctx, "")).Where("id = ?", id.String()).First(&m).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, nil
@@ -58,39 +84,200 @@ func (r *GormKnowledgeBaseRepository) FindByID(ctx context.Context, id valueobje
 		return nil, err
 	}
 
-	return m.ToEntity(docs), nil
+	// 加载文件夹结构
+	folders, err := r.folderRepo.FindByKnowledgeBaseID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// 加载标签注册表
+	tags, err := r.tagRepo.FindByKnowledgeBaseID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// 加载附件
+	attachments, err := r.attRepo.FindByKnowledgeBaseID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return m.ToEntity(docs, folders, tags, attachments), nil
 }
 
 // FindAll 查找所有知识库
 func (r *GormKnowledgeBaseRepository) FindAll(ctx context.Context) ([]*entity.KnowledgeBase, error) {
 	var models []model.KnowledgeBaseModel
 
-	err := r.getDB(ctx).WithContext(ctx).Order("created_at DESC").Find(&models).Error
+	err := r.getDB(ctx).WithContext(ctx).Scopes(tenantScope(ctx, "")).Order("created_at DESC").Find(&models).Error
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*entity.KnowledgeBase, len(models))
+	for i, m := range models {
+		result[i] = m.ToEntity(nil, nil, nil, nil)
+	}
+
+	return result, nil
+}
+
+// FindByStatus 查找指定生命周期状态的知识库
+func (r *GormKnowledgeBaseRepository) FindByStatus(ctx context.Context, status valueobject.KnowledgeBaseStatus) ([]*entity.KnowledgeBase, error) {
+	var models []model.KnowledgeBaseModel
+
+	err := r.getDB(ctx).WithContext(ctx).
+		Scopes(tenantScope(ctx, "")).
+		Where("status = ?", status.String()).
+		Order("created_at DESC").
+		Find(&models).Error
 	if err != nil {
 		return nil, err
 	}
 
 	result := make([]*entity.KnowledgeBase, len(models))
 	for i, m := range models {
-		result[i] = m.ToEntity(nil)
+		result[i] = m.ToEntity(nil, nil, nil, nil)
 	}
 
 	return result, nil
 }
 
-// Delete 删除知识库
+// Delete 删除知识库（软删除）
+// 模型包含 gorm.DeletedAt 字段，GORM 只会写入 deleted_at 而不会物理删除
 func (r *GormKnowledgeBaseRepository) Delete(ctx context.Context, id valueobject.KnowledgeBaseID) error {
-	return r.getDB(ctx).WithContext(ctx).Where("id = ?", id.String()).Delete(&model.KnowledgeBaseModel{}).Error
+	return r.getDB(ctx).WithContext(ctx).Scopes(tenantScope(ctx, "")).Where("id = ?", id.String()).Delete(&model.KnowledgeBaseModel{}).Error
 }
 
-// ExistsByName 检查名称是否已存在
+// ExistsByName 检查名称在当前租户内是否已存在
+// 回收站中的知识库同样占用名称（租户和名称上有联合唯一索引），保证其可以被恢复
 func (r *GormKnowledgeBaseRepository) ExistsByName(ctx context.Context, name string) (bool, error) {
 	var count int64
 
-	err := r.getDB(ctx).WithContext(ctx).Model(&model.KnowledgeBaseModel{}).Where("name = ?", name).Count(&count).Error
+	err := r.getDB(ctx).WithContext(ctx).Unscoped().Model(&model.KnowledgeBaseModel{}).
+		Scopes(tenantScope(ctx, "")).
+		Where("name = ?", name).
+		Count(&count).Error
 	if err != nil {
 		return false, err
 	}
 
 	return count > 0, nil
 }
+
+// FindDeleted 查找回收站中的所有知识库
+func (r *GormKnowledgeBaseRepository) FindDeleted(ctx context.Context) ([]*entity.KnowledgeBase, error) {
+	var models []model.KnowledgeBaseModel
+
+	err := r.getDB(ctx).WithContext(ctx).Unscoped().
+		Scopes(tenantScope(ctx, "")).
+		Where("deleted_at IS NOT NULL").
+		Order("deleted_at DESC").
+		Find(&models).Error
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*entity.KnowledgeBase, len(models))
+	for i, m := range models {
+		result[i] = m.ToEntity(nil, nil, nil, nil)
+	}
+
+	return result, nil
+}
+
+// FindDeletedByID 根据ID查找回收站中的知识库
+func (r *GormKnowledgeBaseRepository) FindDeletedByID(ctx context.Context, id valueobject.KnowledgeBaseID) (*entity.KnowledgeBase, error) {
+	var m model.KnowledgeBaseModel
+
+	err := r.getDB(ctx).WithContext(ctx).Unscoped().
+		Scopes(tenantScope(ctx, "")).
+		Where("id = ? AND deleted_at IS NOT NULL", id.String()).
+		First(&m).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return m.ToEntity(nil, nil, nil, nil), nil
+}
+
+// FindDeletedBefore 查找在指定时间之前移入回收站的知识库
+func (r *GormKnowledgeBaseRepository) FindDeletedBefore(ctx context.Context, before time.Time) ([]*entity.KnowledgeBase, error) {
+	var models []model.KnowledgeBaseModel
+
+	err := r.getDB(ctx).WithContext(ctx).Unscoped().
+		Scopes(tenantScope(ctx, "")).
+		Where("deleted_at IS NOT NULL AND deleted_at < ?", before).
+		Find(&models).Error
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*entity.KnowledgeBase, len(models))
+	for i, m := range models {
+		result[i] = m.ToEntity(nil, nil, nil, nil)
+	}
+
+	return result, nil
+}
+
+// Restore 从回收站恢复知识库
+func (r *GormKnowledgeBaseRepository) Restore(ctx context.Context, id valueobject.KnowledgeBaseID) error {
+	return r.getDB(ctx).WithContext(ctx).Unscoped().
+		Model(&model.KnowledgeBaseModel{}).
+		Scopes(tenantScope(ctx, "")).
+		Where("id = ?", id.String()).
+		Updates(map[string]interface{}{"deleted_at": nil, "updated_at": time.Now()}).Error
+}
+
+// Purge 彻底删除知识库
+func (r *GormKnowledgeBaseRepository) Purge(ctx context.Context, id valueobject.KnowledgeBaseID) error {
+	return r.getDB(ctx).WithContext(ctx).Unscoped().Scopes(tenantScope(ctx, "")).Where("id = ?", id.String()).Delete(&model.KnowledgeBaseModel{}).Error
+}
+
+// FindTenantIDs 查找拥有知识库（包括回收站中的）的所有租户
+func (r *GormKnowledgeBaseRepository) FindTenantIDs(ctx context.Context) ([]tenant.ID, error) {
+	var ids []string
+	err := r.getDB(ctx).WithContext(ctx).Unscoped().
+		Model(&model.KnowledgeBaseModel{}).
+		Distinct("tenant_id").
+		Order("tenant_id ASC").
+		Pluck("tenant_id", &ids).Error
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]tenant.ID, len(ids))
+	for i, id := range ids {
+		result[i] = tenant.ID(id)
+	}
+	return result, nil
+}
+
+// CountByStatus 按状态统计知识库数量（不包括回收站中的）
+func (r *GormKnowledgeBaseRepository) CountByStatus(ctx context.Context) (map[valueobject.KnowledgeBaseStatus]int, error) {
+	var rows []statusCount
+	err := r.getDB(ctx).WithContext(ctx).Scopes(tenantScope(ctx, "")).
+		Model(&model.KnowledgeBaseModel{}).
+		Select("status, COUNT(*) AS count").
+		Group("status").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[valueobject.KnowledgeBaseStatus]int, len(rows))
+	for _, row := range rows {
+		result[valueobject.KnowledgeBaseStatus(row.Status)] = row.Count
+	}
+	return result, nil
+}
+
+// statusCount 按状态分组统计的一行结果
+type statusCount struct {
+	Status string
+	Count  int
+}
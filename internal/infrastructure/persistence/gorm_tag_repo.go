@@ -0,0 +1,75 @@
+package persistence
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+
+	"gozero-ddd/internal/domain/entity"
+	"gozero-ddd/internal/domain/repository"
+	"gozero-ddd/internal/domain/valueobject"
+	"gozero-ddd/internal/infrastructure/persistence/model"
+)
+
+// GormTagRepository GORM 标签注册表仓储实现
+type GormTagRepository struct {
+	db *gorm.DB
+}
+
+// NewGormTagRepository 创建 GORM 标签注册表仓储
+func NewGormTagRepository(db *gorm.DB) *GormTagRepository {
+	return &GormTagRepository{db: db}
+}
+
+// 确保实现了接口
+var _ repository.TagRepository = (*GormTagRepository)(nil)
+
+// getDB 获取数据库连接（支持事务）
+func (r *GormTagRepository) getDB(ctx context.Context) *gorm.DB {
+	return GetDBFromContext(ctx, r.db)
+}
+
+// ReplaceAll 整体替换知识库的标签注册表
+// 应在事务中调用，先删除后插入
+func (r *GormTagRepository) ReplaceAll(ctx context.Context, kbID valueobject.KnowledgeBaseID, tags []*entity.TagDefinition) error {
+	db := r.getDB(ctx).WithContext(ctx)
+
+	if err := db.Scopes(tenantKnowledgeBaseScope(ctx, "knowledge_base_id")).Where("knowledge_base_id = ?", kbID.String()).Delete(&model.TagModel{}).Error; err != nil {
+		return err
+	}
+	if len(tags) == 0 {
+		return nil
+	}
+
+	models := make([]*model.TagModel, len(tags))
+	for i, t := range tags {
+		models[i] = model.TagModelFromEntity(t)
+	}
+	return db.Create(&models).Error
+}
+
+// FindByKnowledgeBaseID 查找知识库下的所有标签定义
+func (r *GormTagRepository) FindByKnowledgeBaseID(ctx context.Context, kbID valueobject.KnowledgeBaseID) ([]*entity.TagDefinition, error) {
+	var models []model.TagModel
+
+	err := r.getDB(ctx).WithContext(ctx).
+		Scopes(tenantKnowledgeBaseScope(ctx, "knowledge_base_id")).
+		Where("knowledge_base_id = ?", kbID.String()).
+		Order("name ASC").
+		Find(&models).Error
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*entity.TagDefinition, len(models))
+	for i, m := range models {
+		result[i] = m.ToEntity()
+	}
+
+	return result, nil
+}
+
+// DeleteByKnowledgeBaseID 删除知识库下的所有标签定义
+func (r *GormTagRepository) DeleteByKnowledgeBaseID(ctx context.Context, kbID valueobject.KnowledgeBaseID) error {
+	return r.getDB(ctx).WithContext(ctx).Scopes(tenantKnowledgeBaseScope(ctx, "knowledge_base_id")).Where("knowledge_base_id = ?", kbID.String()).Delete(&model.TagModel{}).Error
+}
@@ -0,0 +1,139 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"gozero-ddd/internal/domain/repository"
+	"gozero-ddd/internal/domain/tenant"
+	"gozero-ddd/internal/domain/valueobject"
+	"gozero-ddd/internal/infrastructure/persistence/model"
+)
+
+// GormTenantUsageRepository GORM 租户资源用量仓储实现
+// 租户还没有用量记录时（首次使用或升级前已有数据），按现有的知识库和文档统计出初始用量
+type GormTenantUsageRepository struct {
+	db *gorm.DB
+}
+
+// NewGormTenantUsageRepository 创建 GORM 租户资源用量仓储
+func NewGormTenantUsageRepository(db *gorm.DB) *GormTenantUsageRepository {
+	return &GormTenantUsageRepository{db: db}
+}
+
+// 确保实现了接口
+var _ repository.TenantUsageRepository = (*GormTenantUsageRepository)(nil)
+
+// getDB 获取数据库连接（支持事务）
+func (r *GormTenantUsageRepository) getDB(ctx context.Context) *gorm.DB {
+	return GetDBFromContext(ctx, r.db)
+}
+
+// Find 查询当前租户的用量
+func (r *GormTenantUsageRepository) Find(ctx context.Context) (valueobject.QuotaUsage, error) {
+	var m model.TenantUsageModel
+	err := r.getDB(ctx).WithContext(ctx).
+		Where("tenant_id = ?", tenant.FromContext(ctx).String()).
+		First(&m).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return r.initialize(ctx)
+	}
+	if err != nil {
+		return valueobject.QuotaUsage{}, err
+	}
+	return m.ToValueObject(), nil
+}
+
+// FindForUpdate 查询当前租户的用量并加行锁（SELECT ... FOR UPDATE），直到所在事务结束
+// 还没有用量记录时先统计并保存，再锁定保存的记录
+func (r *GormTenantUsageRepository) FindForUpdate(ctx context.Context) (valueobject.QuotaUsage, error) {
+	m, err := r.lock(ctx)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		if _, err := r.initialize(ctx); err != nil {
+			return valueobject.QuotaUsage{}, err
+		}
+		m, err = r.lock(ctx)
+	}
+	if err != nil {
+		return valueobject.QuotaUsage{}, err
+	}
+	return m.ToValueObject(), nil
+}
+
+// lock 查询并锁定当前租户的用量记录
+func (r *GormTenantUsageRepository) lock(ctx context.Context) (*model.TenantUsageModel, error) {
+	var m model.TenantUsageModel
+	err := r.getDB(ctx).WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("tenant_id = ?", tenant.FromContext(ctx).String()).
+		First(&m).Error
+	if err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+// Add 按增减量更新当前租户的用量
+// 还没有用量记录时按现有数据统计，统计结果已包含本次变更，不再叠加增减量
+func (r *GormTenantUsageRepository) Add(ctx context.Context, delta valueobject.QuotaUsage) error {
+	if delta.IsZero() {
+		return nil
+	}
+
+	result := r.getDB(ctx).WithContext(ctx).
+		Model(&model.TenantUsageModel{}).
+		Where("tenant_id = ?", tenant.FromContext(ctx).String()).
+		Updates(map[string]interface{}{
+			"knowledge_bases": gorm.Expr("knowledge_bases + ?", delta.KnowledgeBases),
+			"documents":       gorm.Expr("documents + ?", delta.Documents),
+			"content_bytes":   gorm.Expr("content_bytes + ?", delta.ContentBytes),
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		_, err := r.initialize(ctx)
+		return err
+	}
+	return nil
+}
+
+// initialize 按现有的知识库和文档（包括回收站中的）统计当前租户的用量并保存
+// 并发初始化时以先保存的记录为准
+func (r *GormTenantUsageRepository) initialize(ctx context.Context) (valueobject.QuotaUsage, error) {
+	db := r.getDB(ctx).WithContext(ctx)
+
+	var kbCount int64
+	if err := db.Unscoped().
+		Model(&model.KnowledgeBaseModel{}).
+		Scopes(tenantScope(ctx, "")).
+		Count(&kbCount).Error; err != nil {
+		return valueobject.QuotaUsage{}, err
+	}
+
+	var docs struct {
+		Documents    int64
+		ContentBytes int64
+	}
+	if err := db.Unscoped().
+		Model(&model.DocumentModel{}).
+		Scopes(tenantScope(ctx, "")).
+		Select("COUNT(*) AS documents, COALESCE(SUM(LENGTH(content)), 0) AS content_bytes").
+		Scan(&docs).Error; err != nil {
+		return valueobject.QuotaUsage{}, err
+	}
+
+	m := &model.TenantUsageModel{
+		TenantID:       tenant.FromContext(ctx).String(),
+		KnowledgeBases: int(kbCount),
+		Documents:      int(docs.Documents),
+		ContentBytes:   docs.ContentBytes,
+	}
+	if err := db.Clauses(clause.OnConflict{DoNothing: true}).Create(m).Error; err != nil {
+		return valueobject.QuotaUsage{}, err
+	}
+	return m.ToValueObject(), nil
+}
@@ -3,9 +3,12 @@ package persistence
 import (
 	"context"
 	"errors"
+	"time"
 
 	"gorm.io/gorm"
 
+	"gozero-ddd/internal/application/metrics"
+	"gozero-ddd/internal/application/tracing"
 	"gozero-ddd/internal/domain/repository"
 )
 
@@ -54,7 +57,15 @@ func (u *GormUnitOfWork) Rollback(ctx context.Context) error {
 }
 
 // Transaction 在事务中执行函数（推荐方式）
-func (u *GormUnitOfWork) Transaction(ctx context.Context, fn func(ctx context.Context) error) error {
+// 整个事务记录为一个 span，事务内的仓储调用作为其子 span；同时记录事务耗时和回滚次数
+func (u *GormUnitOfWork) Transaction(ctx context.Context, fn func(ctx context.Context) error) (err error) {
+	start := time.Now()
+	ctx, span := tracing.Start(ctx, "db.transaction")
+	defer func() {
+		metrics.ObserveTransaction(start, err)
+		tracing.End(span, err)
+	}()
+
 	// 开始事务
 	txCtx, err := u.Begin(ctx)
 	if err != nil {
@@ -0,0 +1,84 @@
+package model
+
+import (
+	"time"
+
+	"gozero-ddd/internal/domain/repository"
+	"gozero-ddd/internal/domain/valueobject"
+)
+
+// APIKeyModel API Key 数据库模型
+// 权限范围和知识库限制以 JSON 数组保存
+type APIKeyModel struct {
+	ID               string      `gorm:"column:id;type:varchar(36);primaryKey"`
+	TenantID         string      `gorm:"column:tenant_id;type:varchar(64);index;not null;default:default"`
+	Name             string      `gorm:"column:name;type:varchar(100);not null"`
+	Prefix           string      `gorm:"column:prefix;type:varchar(16);not null"`
+	KeyHash          string      `gorm:"column:key_hash;type:char(64);not null;uniqueIndex"`
+	Scopes           StringSlice `gorm:"column:scopes;type:json"`
+	KnowledgeBaseIDs StringSlice `gorm:"column:knowledge_base_ids;type:json"`
+	CreatedBy        string      `gorm:"column:created_by;type:varchar(255);not null;default:''"`
+	CreatedAt        time.Time   `gorm:"column:created_at;not null"`
+	ExpiresAt        *time.Time  `gorm:"column:expires_at"`
+	LastUsedAt       *time.Time  `gorm:"column:last_used_at"`
+	RevokedAt        *time.Time  `gorm:"column:revoked_at"`
+}
+
+// TableName 指定表名
+func (APIKeyModel) TableName() string {
+	return "api_keys"
+}
+
+// ToAPIKey 将数据库模型转换为 API Key 记录
+func (m *APIKeyModel) ToAPIKey() *repository.APIKey {
+	scopes := make([]valueobject.APIKeyScope, len(m.Scopes))
+	for i, s := range m.Scopes {
+		scopes[i] = valueobject.APIKeyScope(s)
+	}
+	kbIDs := make([]valueobject.KnowledgeBaseID, len(m.KnowledgeBaseIDs))
+	for i, id := range m.KnowledgeBaseIDs {
+		kbIDs[i] = valueobject.MustKnowledgeBaseIDFromString(id)
+	}
+
+	return &repository.APIKey{
+		ID:               m.ID,
+		TenantID:         m.TenantID,
+		Name:             m.Name,
+		Prefix:           m.Prefix,
+		Hash:             m.KeyHash,
+		Scopes:           scopes,
+		KnowledgeBaseIDs: kbIDs,
+		CreatedBy:        m.CreatedBy,
+		CreatedAt:        m.CreatedAt,
+		ExpiresAt:        m.ExpiresAt,
+		LastUsedAt:       m.LastUsedAt,
+		RevokedAt:        m.RevokedAt,
+	}
+}
+
+// APIKeyModelFromAPIKey 从 API Key 记录创建数据库模型
+func APIKeyModelFromAPIKey(key *repository.APIKey) *APIKeyModel {
+	scopes := make(StringSlice, len(key.Scopes))
+	for i, s := range key.Scopes {
+		scopes[i] = s.String()
+	}
+	kbIDs := make(StringSlice, len(key.KnowledgeBaseIDs))
+	for i, id := range key.KnowledgeBaseIDs {
+		kbIDs[i] = id.String()
+	}
+
+	return &APIKeyModel{
+		ID:               key.ID,
+		TenantID:         key.TenantID,
+		Name:             key.Name,
+		Prefix:           key.Prefix,
+		KeyHash:          key.Hash,
+		Scopes:           scopes,
+		KnowledgeBaseIDs: kbIDs,
+		CreatedBy:        key.CreatedBy,
+		CreatedAt:        key.CreatedAt,
+		ExpiresAt:        key.ExpiresAt,
+		LastUsedAt:       key.LastUsedAt,
+		RevokedAt:        key.RevokedAt,
+	}
+}
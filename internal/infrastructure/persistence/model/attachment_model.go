@@ -0,0 +1,54 @@
+package model
+
+import (
+	"time"
+
+	"gozero-ddd/internal/domain/entity"
+	"gozero-ddd/internal/domain/valueobject"
+)
+
+// AttachmentModel 附件数据库模型
+// 只保存元数据，二进制内容按 content_hash 保存在 BlobStore 中
+type AttachmentModel struct {
+	ID              string    `gorm:"column:id;type:varchar(36);primaryKey"`
+	KnowledgeBaseID string    `gorm:"column:knowledge_base_id;type:varchar(36);index;not null"`
+	DocumentID      string    `gorm:"column:document_id;type:varchar(36);index;not null"`
+	FileName        string    `gorm:"column:file_name;type:varchar(255);not null"`
+	MediaType       string    `gorm:"column:media_type;type:varchar(100);not null"`
+	Size            int64     `gorm:"column:size;not null"`
+	ContentHash     string    `gorm:"column:content_hash;type:char(64);index;not null"` // SHA-256，同时作为 BlobStore 的键
+	CreatedAt       time.Time `gorm:"column:created_at;autoCreateTime"`
+}
+
+// TableName 指定表名
+func (AttachmentModel) TableName() string {
+	return "attachments"
+}
+
+// ToEntity 将数据库模型转换为领域实体
+func (m *AttachmentModel) ToEntity() *entity.Attachment {
+	return entity.ReconstructAttachment(
+		valueobject.MustAttachmentIDFromString(m.ID),
+		valueobject.MustKnowledgeBaseIDFromString(m.KnowledgeBaseID),
+		valueobject.MustDocumentIDFromString(m.DocumentID),
+		m.FileName,
+		m.MediaType,
+		m.Size,
+		valueobject.ContentHash(m.ContentHash),
+		m.CreatedAt,
+	)
+}
+
+// AttachmentModelFromEntity 从领域实体创建数据库模型
+func AttachmentModelFromEntity(att *entity.Attachment) *AttachmentModel {
+	return &AttachmentModel{
+		ID:              att.ID().String(),
+		KnowledgeBaseID: att.KnowledgeBaseID().String(),
+		DocumentID:      att.DocumentID().String(),
+		FileName:        att.FileName(),
+		MediaType:       att.MediaType(),
+		Size:            att.Size(),
+		ContentHash:     att.ContentHash().String(),
+		CreatedAt:       att.CreatedAt(),
+	}
+}
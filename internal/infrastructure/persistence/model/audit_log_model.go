@@ -0,0 +1,99 @@
+package model
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"gozero-ddd/internal/domain/repository"
+)
+
+// JSONRaw 自定义类型，按原样保存 JSON 数据，为空时保存 NULL
+type JSONRaw json.RawMessage
+
+// Scan 实现 sql.Scanner 接口
+func (j *JSONRaw) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		*j = nil
+	case []byte:
+		*j = append((*j)[:0], v...)
+	case string:
+		*j = JSONRaw(v)
+	default:
+		return errors.New("failed to scan JSONRaw")
+	}
+	return nil
+}
+
+// Value 实现 driver.Valuer 接口
+func (j JSONRaw) Value() (driver.Value, error) {
+	if len(j) == 0 {
+		return nil, nil
+	}
+	return string(j), nil
+}
+
+// AuditLogModel 审计日志数据库模型
+// 只追加不修改；seq 自增，用于按写入顺序分页
+type AuditLogModel struct {
+	Seq             int64     `gorm:"column:seq;primaryKey;autoIncrement"`
+	EventID         string    `gorm:"column:event_id;type:varchar(36);not null;uniqueIndex"`
+	TenantID        string    `gorm:"column:tenant_id;type:varchar(64);index;not null;default:default"`
+	EventName       string    `gorm:"column:event_name;type:varchar(100);index;not null"`
+	AggregateID     string    `gorm:"column:aggregate_id;type:varchar(64);not null;default:''"`
+	KnowledgeBaseID string    `gorm:"column:knowledge_base_id;type:varchar(36);index;not null;default:''"`
+	Actor           string    `gorm:"column:actor;type:varchar(255);index;not null;default:''"`
+	RequestID       string    `gorm:"column:request_id;type:varchar(128);not null;default:''"`
+	ClientIP        string    `gorm:"column:client_ip;type:varchar(45);not null;default:''"`
+	Before          JSONRaw   `gorm:"column:before_snapshot;type:json"`
+	After           JSONRaw   `gorm:"column:after_snapshot;type:json"`
+	Payload         JSONRaw   `gorm:"column:payload;type:json"`
+	OccurredAt      time.Time `gorm:"column:occurred_at;index;not null"`
+	RecordedAt      time.Time `gorm:"column:recorded_at;not null"`
+}
+
+// TableName 指定表名
+func (AuditLogModel) TableName() string {
+	return "audit_logs"
+}
+
+// ToEntry 将数据库模型转换为审计日志记录
+func (m *AuditLogModel) ToEntry() *repository.AuditLogEntry {
+	return &repository.AuditLogEntry{
+		Seq:             m.Seq,
+		EventID:         m.EventID,
+		TenantID:        m.TenantID,
+		EventName:       m.EventName,
+		AggregateID:     m.AggregateID,
+		KnowledgeBaseID: m.KnowledgeBaseID,
+		Actor:           m.Actor,
+		RequestID:       m.RequestID,
+		ClientIP:        m.ClientIP,
+		Before:          json.RawMessage(m.Before),
+		After:           json.RawMessage(m.After),
+		Payload:         json.RawMessage(m.Payload),
+		OccurredAt:      m.OccurredAt,
+		RecordedAt:      m.RecordedAt,
+	}
+}
+
+// AuditLogModelFromEntry 从审计日志记录创建数据库模型
+func AuditLogModelFromEntry(entry *repository.AuditLogEntry) *AuditLogModel {
+	return &AuditLogModel{
+		EventID:         entry.EventID,
+		TenantID:        entry.TenantID,
+		EventName:       entry.EventName,
+		AggregateID:     entry.AggregateID,
+		KnowledgeBaseID: entry.KnowledgeBaseID,
+		Actor:           entry.Actor,
+		RequestID:       entry.RequestID,
+		ClientIP:        entry.ClientIP,
+		Before:          JSONRaw(entry.Before),
+		After:           JSONRaw(entry.After),
+		Payload:         JSONRaw(entry.Payload),
+		OccurredAt:      entry.OccurredAt,
+		RecordedAt:      entry.RecordedAt,
+	}
+}
@@ -0,0 +1,55 @@
+package model
+
+import (
+	"time"
+
+	"gozero-ddd/internal/domain/valueobject"
+)
+
+// DocumentFingerprintModel 文档内容指纹数据库模型
+// 每篇文档一行，文档内容变化时覆盖
+type DocumentFingerprintModel struct {
+	DocumentID      string    `gorm:"column:document_id;type:varchar(36);primaryKey"`
+	KnowledgeBaseID string    `gorm:"column:knowledge_base_id;type:varchar(36);index;not null"`
+	ContentHash     string    `gorm:"column:content_hash;type:char(64);index;not null"` // 规范化内容的 SHA-256
+	SimHash         uint64    `gorm:"column:sim_hash;type:bigint unsigned;not null"`
+	UpdatedAt       time.Time `gorm:"column:updated_at;autoUpdateTime"`
+}
+
+// TableName 指定表名
+func (DocumentFingerprintModel) TableName() string {
+	return "document_fingerprints"
+}
+
+// DocumentFingerprintRow 查询指纹时与文档表联表得到的行
+type DocumentFingerprintRow struct {
+	DocumentFingerprintModel
+	Title string `gorm:"column:title"`
+}
+
+// ToValueObject 将查询结果转换为文档指纹值对象
+func (r *DocumentFingerprintRow) ToValueObject() valueobject.DocumentFingerprint {
+	return valueobject.DocumentFingerprint{
+		DocumentID:      valueobject.MustDocumentIDFromString(r.DocumentID),
+		KnowledgeBaseID: valueobject.MustKnowledgeBaseIDFromString(r.KnowledgeBaseID),
+		Title:           r.Title,
+		Fingerprint: valueobject.ContentFingerprint{
+			Hash:    valueobject.ContentHash(r.ContentHash),
+			SimHash: r.SimHash,
+		},
+	}
+}
+
+// DocumentFingerprintModelFromValueObject 从内容指纹创建数据库模型
+func DocumentFingerprintModelFromValueObject(
+	docID valueobject.DocumentID,
+	kbID valueobject.KnowledgeBaseID,
+	fp valueobject.ContentFingerprint,
+) *DocumentFingerprintModel {
+	return &DocumentFingerprintModel{
+		DocumentID:      docID.String(),
+		KnowledgeBaseID: kbID.String(),
+		ContentHash:     fp.Hash.String(),
+		SimHash:         fp.SimHash,
+	}
+}
@@ -0,0 +1,60 @@
+package model
+
+import (
+	"time"
+
+	"gozero-ddd/internal/domain/valueobject"
+)
+
+// DocumentLinkModel 文档链接数据库模型
+// 每行表示一篇文档内容中的一个链接，文档内容变化时整体替换
+type DocumentLinkModel struct {
+	ID                    uint      `gorm:"column:id;primaryKey;autoIncrement"`
+	SourceDocumentID      string    `gorm:"column:source_document_id;type:varchar(36);index;not null"`
+	SourceKnowledgeBaseID string    `gorm:"column:source_knowledge_base_id;type:varchar(36);index;not null"`
+	LinkType              string    `gorm:"column:link_type;type:varchar(20);not null"`
+	TargetTitle           string    `gorm:"column:target_title;type:varchar(500);index"`      // Wiki 链接的目标标题
+	TargetKnowledgeBaseID string    `gorm:"column:target_knowledge_base_id;type:varchar(36)"` // 路径链接的目标知识库
+	TargetDocumentID      string    `gorm:"column:target_document_id;type:varchar(36);index"` // 路径链接的目标文档
+	CreatedAt             time.Time `gorm:"column:created_at;autoCreateTime"`
+}
+
+// TableName 指定表名
+func (DocumentLinkModel) TableName() string {
+	return "document_links"
+}
+
+// ToValueObject 将数据库模型转换为链接值对象
+func (m *DocumentLinkModel) ToValueObject() valueobject.DocumentLink {
+	return valueobject.DocumentLink{
+		Type:                  valueobject.LinkType(m.LinkType),
+		TargetTitle:           m.TargetTitle,
+		TargetKnowledgeBaseID: m.TargetKnowledgeBaseID,
+		TargetDocumentID:      m.TargetDocumentID,
+	}
+}
+
+// ToEdge 将数据库模型转换为链接图中的边
+func (m *DocumentLinkModel) ToEdge() valueobject.DocumentLinkEdge {
+	return valueobject.DocumentLinkEdge{
+		SourceDocumentID:      valueobject.MustDocumentIDFromString(m.SourceDocumentID),
+		SourceKnowledgeBaseID: valueobject.MustKnowledgeBaseIDFromString(m.SourceKnowledgeBaseID),
+		Link:                  m.ToValueObject(),
+	}
+}
+
+// DocumentLinkModelFromValueObject 从链接值对象创建数据库模型
+func DocumentLinkModelFromValueObject(
+	sourceDocID valueobject.DocumentID,
+	sourceKBID valueobject.KnowledgeBaseID,
+	link valueobject.DocumentLink,
+) *DocumentLinkModel {
+	return &DocumentLinkModel{
+		SourceDocumentID:      sourceDocID.String(),
+		SourceKnowledgeBaseID: sourceKBID.String(),
+		LinkType:              link.Type.String(),
+		TargetTitle:           link.TargetTitle,
+		TargetKnowledgeBaseID: link.TargetKnowledgeBaseID,
+		TargetDocumentID:      link.TargetDocumentID,
+	}
+}
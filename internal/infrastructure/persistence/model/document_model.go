@@ -6,6 +6,8 @@ import (
 	"errors"
 	"time"
 
+	"gorm.io/gorm"
+
 	"gozero-ddd/internal/domain/entity"
 	"gozero-ddd/internal/domain/valueobject"
 )
@@ -46,15 +48,77 @@ func (s StringSlice) Value() (driver.Value, error) {
 	return json.Marshal(s)
 }
 
+// ReviewCommentList 评审意见列表，用于 GORM JSON 序列化
+type ReviewCommentList []valueobject.ReviewComment
+
+// Scan 实现 sql.Scanner 接口
+func (l *ReviewCommentList) Scan(value interface{}) error {
+	if value == nil {
+		*l = make([]valueobject.ReviewComment, 0)
+		return nil
+	}
+
+	var bytes []byte
+	switch v := value.(type) {
+	case []byte:
+		bytes = v
+	case string:
+		bytes = []byte(v)
+	default:
+		return errors.New("failed to scan ReviewCommentList")
+	}
+
+	if len(bytes) == 0 {
+		*l = make([]valueobject.ReviewComment, 0)
+		return nil
+	}
+
+	return json.Unmarshal(bytes, l)
+}
+
+// Value 实现 driver.Valuer 接口
+func (l ReviewCommentList) Value() (driver.Value, error) {
+	if l == nil {
+		return "[]", nil
+	}
+	return json.Marshal(l)
+}
+
+// DeletedAtToPtr 将 GORM 软删除字段转换为领域实体使用的时间指针
+func DeletedAtToPtr(d gorm.DeletedAt) *time.Time {
+	if !d.Valid {
+		return nil
+	}
+	t := d.Time
+	return &t
+}
+
+// DeletedAtFromPtr 将领域实体的删除时间转换为 GORM 软删除字段
+func DeletedAtFromPtr(t *time.Time) gorm.DeletedAt {
+	if t == nil {
+		return gorm.DeletedAt{}
+	}
+	return gorm.DeletedAt{Time: *t, Valid: true}
+}
+
 // DocumentModel 文档数据库模型
 type DocumentModel struct {
-	ID              string      `gorm:"column:id;type:varchar(36);primaryKey"`
-	KnowledgeBaseID string      `gorm:"column:knowledge_base_id;type:varchar(36);index;not null"`
-	Title           string      `gorm:"column:title;type:varchar(500);not null"`
-	Content         string      `gorm:"column:content;type:longtext;not null"`
-	Tags            StringSlice `gorm:"column:tags;type:json"`
-	CreatedAt       time.Time   `gorm:"column:created_at;autoCreateTime"`
-	UpdatedAt       time.Time   `gorm:"column:updated_at;autoUpdateTime"`
+	ID              string            `gorm:"column:id;type:varchar(36);primaryKey"`
+	TenantID        string            `gorm:"column:tenant_id;type:varchar(64);index;not null;default:default"` // 所属租户，与知识库一致
+	KnowledgeBaseID string            `gorm:"column:knowledge_base_id;type:varchar(36);index;not null"`
+	FolderID        *string           `gorm:"column:folder_id;type:varchar(36);index"` // 所在文件夹，NULL 表示根目录
+	Title           string            `gorm:"column:title;type:varchar(500);not null"`
+	Content         string            `gorm:"column:content;type:longtext;not null"`
+	ContentType     string            `gorm:"column:content_type;type:varchar(20);not null;default:markdown"` // 内容类型，存量文档视为 Markdown
+	Tags            StringSlice       `gorm:"column:tags;type:json"`
+	Status          string            `gorm:"column:status;type:varchar(20);index;not null;default:published"` // 发布状态，存量文档视为已发布
+	ReviewComments  ReviewCommentList `gorm:"column:review_comments;type:json"`                                // 评审意见记录
+	PublishAt       *time.Time        `gorm:"column:publish_at;index"`                                         // 定时发布时间
+	ExpireAt        *time.Time        `gorm:"column:expire_at;index"`                                          // 定时下线时间
+	CreatedAt       time.Time         `gorm:"column:created_at;autoCreateTime"`
+	UpdatedAt       time.Time         `gorm:"column:updated_at;autoUpdateTime"`
+	DeletedAt       gorm.DeletedAt    `gorm:"column:deleted_at;index"`                                   // 软删除标记，GORM 查询时自动排除已删除记录
+	TrashedWithKB   bool              `gorm:"column:trashed_with_knowledge_base;not null;default:false"` // 是否随知识库一起移入回收站，恢复知识库时只恢复这些文档
 }
 
 // TableName 指定表名
@@ -71,11 +135,18 @@ func (m *DocumentModel) ToEntity() *entity.Document {
 	return entity.ReconstructDocument(
 		valueobject.MustDocumentIDFromString(m.ID),
 		valueobject.MustKnowledgeBaseIDFromString(m.KnowledgeBaseID),
+		FolderIDFromPtr(m.FolderID),
 		m.Title,
 		m.Content,
+		valueobject.ContentType(m.ContentType),
 		tags,
+		documentStatusFromString(m.Status),
+		m.ReviewComments,
+		m.PublishAt,
+		m.ExpireAt,
 		m.CreatedAt,
 		m.UpdatedAt,
+		DeletedAtToPtr(m.DeletedAt),
 	)
 }
 
@@ -84,10 +155,27 @@ func DocumentModelFromEntity(doc *entity.Document) *DocumentModel {
 	return &DocumentModel{
 		ID:              doc.ID().String(),
 		KnowledgeBaseID: doc.KnowledgeBaseID().String(),
+		FolderID:        FolderIDToPtr(doc.FolderID()),
 		Title:           doc.Title(),
 		Content:         doc.Content(),
+		ContentType:     doc.ContentType().String(),
 		Tags:            StringSlice(doc.Tags()),
+		Status:          doc.Status().String(),
+		ReviewComments:  ReviewCommentList(doc.ReviewComments()),
+		PublishAt:       doc.PublishAt(),
+		ExpireAt:        doc.ExpireAt(),
 		CreatedAt:       doc.CreatedAt(),
 		UpdatedAt:       doc.UpdatedAt(),
+		DeletedAt:       DeletedAtFromPtr(doc.DeletedAt()),
+	}
+}
+
+// documentStatusFromString 将数据库中的发布状态转换为值对象
+// 引入发布流程之前的存量文档没有状态，视为已发布
+func documentStatusFromString(s string) valueobject.DocumentStatus {
+	status := valueobject.DocumentStatus(s)
+	if !status.IsValid() {
+		return valueobject.DocumentStatusPublished
 	}
+	return status
 }
@@ -0,0 +1,66 @@
+package model
+
+import (
+	"time"
+
+	"gozero-ddd/internal/domain/entity"
+	"gozero-ddd/internal/domain/valueobject"
+)
+
+// FolderModel 文件夹数据库模型
+// 文件夹是知识库内部的组织结构，删除时直接物理删除，不进入回收站
+type FolderModel struct {
+	ID              string    `gorm:"column:id;type:varchar(36);primaryKey"`
+	KnowledgeBaseID string    `gorm:"column:knowledge_base_id;type:varchar(36);index;not null"`
+	ParentID        *string   `gorm:"column:parent_id;type:varchar(36);index"` // 父文件夹，NULL 表示根目录
+	Name            string    `gorm:"column:name;type:varchar(255);not null"`
+	CreatedAt       time.Time `gorm:"column:created_at;autoCreateTime"`
+	UpdatedAt       time.Time `gorm:"column:updated_at;autoUpdateTime"`
+}
+
+// TableName 指定表名
+func (FolderModel) TableName() string {
+	return "folders"
+}
+
+// ToEntity 将数据库模型转换为领域实体
+func (m *FolderModel) ToEntity() *entity.Folder {
+	return entity.ReconstructFolder(
+		valueobject.MustFolderIDFromString(m.ID),
+		valueobject.MustKnowledgeBaseIDFromString(m.KnowledgeBaseID),
+		FolderIDFromPtr(m.ParentID),
+		m.Name,
+		m.CreatedAt,
+		m.UpdatedAt,
+	)
+}
+
+// FolderModelFromEntity 从领域实体创建数据库模型
+func FolderModelFromEntity(f *entity.Folder) *FolderModel {
+	return &FolderModel{
+		ID:              f.ID().String(),
+		KnowledgeBaseID: f.KnowledgeBaseID().String(),
+		ParentID:        FolderIDToPtr(f.ParentID()),
+		Name:            f.Name(),
+		CreatedAt:       f.CreatedAt(),
+		UpdatedAt:       f.UpdatedAt(),
+	}
+}
+
+// FolderIDFromPtr 将可空的文件夹ID列转换为值对象指针
+func FolderIDFromPtr(s *string) *valueobject.FolderID {
+	if s == nil || *s == "" {
+		return nil
+	}
+	id := valueobject.MustFolderIDFromString(*s)
+	return &id
+}
+
+// FolderIDToPtr 将文件夹ID值对象指针转换为可空的列值
+func FolderIDToPtr(id *valueobject.FolderID) *string {
+	if id == nil {
+		return nil
+	}
+	s := id.String()
+	return &s
+}
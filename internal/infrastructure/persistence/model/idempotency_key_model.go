@@ -0,0 +1,61 @@
+package model
+
+import (
+	"time"
+
+	"gozero-ddd/internal/domain/repository"
+)
+
+// IdempotencyKeyModel 幂等键数据库模型
+// 主键为（租户, 调用方, 幂等键），不同租户或调用方的相同键互不影响
+type IdempotencyKeyModel struct {
+	TenantID    string    `gorm:"column:tenant_id;type:varchar(64);primaryKey"`
+	Actor       string    `gorm:"column:actor;type:varchar(255);primaryKey"`
+	Key         string    `gorm:"column:idempotency_key;type:varchar(255) CHARACTER SET ascii COLLATE ascii_bin;primaryKey"` // 幂等键区分大小写
+	Fingerprint string    `gorm:"column:fingerprint;type:char(64);not null"`
+	Completed   bool      `gorm:"column:completed;not null;default:false"`
+	StatusCode  int       `gorm:"column:status_code;not null;default:0"`
+	ContentType string    `gorm:"column:content_type;type:varchar(100);not null;default:''"`
+	Body        []byte    `gorm:"column:body;type:mediumblob"`
+	CreatedAt   time.Time `gorm:"column:created_at;autoCreateTime"`
+	ExpiresAt   time.Time `gorm:"column:expires_at;index;not null"`
+}
+
+// TableName 指定表名
+func (IdempotencyKeyModel) TableName() string {
+	return "idempotency_keys"
+}
+
+// ToRecord 将数据库模型转换为幂等键记录
+func (m *IdempotencyKeyModel) ToRecord() *repository.IdempotencyRecord {
+	return &repository.IdempotencyRecord{
+		IdempotencyScope: repository.IdempotencyScope{
+			TenantID: m.TenantID,
+			Actor:    m.Actor,
+			Key:      m.Key,
+		},
+		Fingerprint: m.Fingerprint,
+		Completed:   m.Completed,
+		StatusCode:  m.StatusCode,
+		ContentType: m.ContentType,
+		Body:        m.Body,
+		CreatedAt:   m.CreatedAt,
+		ExpiresAt:   m.ExpiresAt,
+	}
+}
+
+// IdempotencyKeyModelFromRecord 从幂等键记录创建数据库模型
+func IdempotencyKeyModelFromRecord(r *repository.IdempotencyRecord) *IdempotencyKeyModel {
+	return &IdempotencyKeyModel{
+		TenantID:    r.TenantID,
+		Actor:       r.Actor,
+		Key:         r.Key,
+		Fingerprint: r.Fingerprint,
+		Completed:   r.Completed,
+		StatusCode:  r.StatusCode,
+		ContentType: r.ContentType,
+		Body:        r.Body,
+		CreatedAt:   r.CreatedAt,
+		ExpiresAt:   r.ExpiresAt,
+	}
+}
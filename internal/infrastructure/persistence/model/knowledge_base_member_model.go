@@ -0,0 +1,45 @@
+package model
+
+import (
+	"time"
+
+	"gozero-ddd/internal/domain/repository"
+	"gozero-ddd/internal/domain/valueobject"
+)
+
+// KnowledgeBaseMemberModel 知识库成员数据库模型
+// 以 (knowledge_base_id, subject) 为联合主键，每个调用方在一个知识库中只有一个角色
+type KnowledgeBaseMemberModel struct {
+	KnowledgeBaseID string    `gorm:"column:knowledge_base_id;type:varchar(36);primaryKey"`
+	Subject         string    `gorm:"column:subject;type:varchar(255);primaryKey;index"`
+	Role            string    `gorm:"column:role;type:varchar(20);not null"`
+	GrantedBy       string    `gorm:"column:granted_by;type:varchar(255);not null;default:''"`
+	GrantedAt       time.Time `gorm:"column:granted_at;not null"`
+}
+
+// TableName 指定表名
+func (KnowledgeBaseMemberModel) TableName() string {
+	return "knowledge_base_members"
+}
+
+// ToMember 将数据库模型转换为知识库成员
+func (m *KnowledgeBaseMemberModel) ToMember() *repository.KnowledgeBaseMember {
+	return &repository.KnowledgeBaseMember{
+		KnowledgeBaseID: valueobject.MustKnowledgeBaseIDFromString(m.KnowledgeBaseID),
+		Subject:         m.Subject,
+		Role:            valueobject.MemberRole(m.Role),
+		GrantedBy:       m.GrantedBy,
+		GrantedAt:       m.GrantedAt,
+	}
+}
+
+// KnowledgeBaseMemberModelFromMember 从知识库成员创建数据库模型
+func KnowledgeBaseMemberModelFromMember(member *repository.KnowledgeBaseMember) *KnowledgeBaseMemberModel {
+	return &KnowledgeBaseMemberModel{
+		KnowledgeBaseID: member.KnowledgeBaseID.String(),
+		Subject:         member.Subject,
+		Role:            member.Role.String(),
+		GrantedBy:       member.GrantedBy,
+		GrantedAt:       member.GrantedAt,
+	}
+}
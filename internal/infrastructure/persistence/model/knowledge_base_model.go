@@ -3,18 +3,25 @@ package model
 import (
 	"time"
 
+	"gorm.io/gorm"
+
 	"gozero-ddd/internal/domain/entity"
 	"gozero-ddd/internal/domain/valueobject"
 )
 
 // KnowledgeBaseModel 知识库数据库模型
 // GORM 模型，用于数据库表映射
+// 租户不属于知识库聚合，由仓储在保存时从上下文写入、在查询时从上下文过滤
 type KnowledgeBaseModel struct {
-	ID          string    `gorm:"column:id;type:varchar(36);primaryKey"`
-	Name        string    `gorm:"column:name;type:varchar(255);uniqueIndex;not null"`
-	Description string    `gorm:"column:description;type:text"`
-	CreatedAt   time.Time `gorm:"column:created_at;autoCreateTime"`
-	UpdatedAt   time.Time `gorm:"column:updated_at;autoUpdateTime"`
+	ID              string         `gorm:"column:id;type:varchar(36);primaryKey"`
+	TenantID        string         `gorm:"column:tenant_id;type:varchar(64);not null;default:default;uniqueIndex:idx_knowledge_bases_tenant_name,priority:1"` // 所属租户
+	Name            string         `gorm:"column:name;type:varchar(255);not null;uniqueIndex:idx_knowledge_bases_tenant_name,priority:2"`                     // 名称在租户内唯一
+	Description     string         `gorm:"column:description;type:text"`
+	Status          string         `gorm:"column:status;type:varchar(20);index;not null;default:active"`    // 生命周期状态
+	DuplicatePolicy string         `gorm:"column:duplicate_policy;type:varchar(20);not null;default:allow"` // 重复文档策略
+	CreatedAt       time.Time      `gorm:"column:created_at;autoCreateTime"`
+	UpdatedAt       time.Time      `gorm:"column:updated_at;autoUpdateTime"`
+	DeletedAt       gorm.DeletedAt `gorm:"column:deleted_at;index"` // 软删除标记，GORM 查询时自动排除已删除记录
 }
 
 // TableName 指定表名
@@ -24,24 +31,43 @@ func (KnowledgeBaseModel) TableName() string {
 
 // ToEntity 将数据库模型转换为领域实体
 // 使用 MustKnowledgeBaseIDFromString 因为数据来自数据库，是可信的
-func (m *KnowledgeBaseModel) ToEntity(documents []*entity.Document) *entity.KnowledgeBase {
+func (m *KnowledgeBaseModel) ToEntity(documents []*entity.Document, folders []*entity.Folder, tags []*entity.TagDefinition, attachments []*entity.Attachment) *entity.KnowledgeBase {
 	return entity.ReconstructKnowledgeBase(
 		valueobject.MustKnowledgeBaseIDFromString(m.ID),
 		m.Name,
 		m.Description,
+		statusFromString(m.Status),
+		valueobject.DuplicatePolicy(m.DuplicatePolicy),
 		documents,
+		folders,
+		tags,
+		attachments,
 		m.CreatedAt,
 		m.UpdatedAt,
+		DeletedAtToPtr(m.DeletedAt),
 	)
 }
 
 // FromEntity 从领域实体创建数据库模型
 func KnowledgeBaseModelFromEntity(kb *entity.KnowledgeBase) *KnowledgeBaseModel {
 	return &KnowledgeBaseModel{
-		ID:          kb.ID().String(),
-		Name:        kb.Name(),
-		Description: kb.Description(),
-		CreatedAt:   kb.CreatedAt(),
-		UpdatedAt:   kb.UpdatedAt(),
+		ID:              kb.ID().String(),
+		Name:            kb.Name(),
+		Description:     kb.Description(),
+		Status:          kb.Status().String(),
+		DuplicatePolicy: kb.DuplicatePolicy().String(),
+		CreatedAt:       kb.CreatedAt(),
+		UpdatedAt:       kb.UpdatedAt(),
+		DeletedAt:       DeletedAtFromPtr(kb.DeletedAt()),
+	}
+}
+
+// statusFromString 将数据库中的状态转换为值对象
+// 历史数据没有状态字段时视为正常状态
+func statusFromString(s string) valueobject.KnowledgeBaseStatus {
+	status := valueobject.KnowledgeBaseStatus(s)
+	if !status.IsValid() {
+		return valueobject.KnowledgeBaseStatusActive
 	}
+	return status
 }
@@ -0,0 +1,68 @@
+package model
+
+import (
+	"time"
+
+	"gozero-ddd/internal/domain/entity"
+	"gozero-ddd/internal/domain/valueobject"
+)
+
+// TagModel 标签定义数据库模型
+// 同一知识库内规范名称唯一，别名以 JSON 数组保存
+type TagModel struct {
+	ID              string      `gorm:"column:id;type:varchar(36);primaryKey"`
+	KnowledgeBaseID string      `gorm:"column:knowledge_base_id;type:varchar(36);not null;uniqueIndex:idx_tags_kb_name,priority:1"`
+	Name            string      `gorm:"column:name;type:varchar(64);not null;uniqueIndex:idx_tags_kb_name,priority:2"`
+	Aliases         StringSlice `gorm:"column:aliases;type:json"`
+	ParentID        *string     `gorm:"column:parent_id;type:varchar(36);index"` // 父标签，NULL 表示顶层标签
+	CreatedAt       time.Time   `gorm:"column:created_at;autoCreateTime"`
+	UpdatedAt       time.Time   `gorm:"column:updated_at;autoUpdateTime"`
+}
+
+// TableName 指定表名
+func (TagModel) TableName() string {
+	return "tags"
+}
+
+// ToEntity 将数据库模型转换为领域实体
+func (m *TagModel) ToEntity() *entity.TagDefinition {
+	aliases := make([]valueobject.Tag, len(m.Aliases))
+	for i, alias := range m.Aliases {
+		aliases[i] = valueobject.MustTag(alias)
+	}
+
+	var parentID *valueobject.TagID
+	if m.ParentID != nil && *m.ParentID != "" {
+		id := valueobject.MustTagIDFromString(*m.ParentID)
+		parentID = &id
+	}
+
+	return entity.ReconstructTagDefinition(
+		valueobject.MustTagIDFromString(m.ID),
+		valueobject.MustKnowledgeBaseIDFromString(m.KnowledgeBaseID),
+		valueobject.MustTag(m.Name),
+		aliases,
+		parentID,
+		m.CreatedAt,
+		m.UpdatedAt,
+	)
+}
+
+// TagModelFromEntity 从领域实体创建数据库模型
+func TagModelFromEntity(t *entity.TagDefinition) *TagModel {
+	var parentID *string
+	if t.ParentID() != nil {
+		s := t.ParentID().String()
+		parentID = &s
+	}
+
+	return &TagModel{
+		ID:              t.ID().String(),
+		KnowledgeBaseID: t.KnowledgeBaseID().String(),
+		Name:            t.Name().String(),
+		Aliases:         StringSlice(valueobject.TagStrings(t.Aliases())),
+		ParentID:        parentID,
+		CreatedAt:       t.CreatedAt(),
+		UpdatedAt:       t.UpdatedAt(),
+	}
+}
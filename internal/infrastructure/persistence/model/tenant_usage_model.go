@@ -0,0 +1,31 @@
+package model
+
+import (
+	"time"
+
+	"gozero-ddd/internal/domain/valueobject"
+)
+
+// TenantUsageModel 租户资源用量数据库模型
+// 每个租户一行，由配额事件处理器按领域事件增减
+type TenantUsageModel struct {
+	TenantID       string    `gorm:"column:tenant_id;type:varchar(64);primaryKey"`
+	KnowledgeBases int       `gorm:"column:knowledge_bases;not null;default:0"`
+	Documents      int       `gorm:"column:documents;not null;default:0"`
+	ContentBytes   int64     `gorm:"column:content_bytes;not null;default:0"`
+	UpdatedAt      time.Time `gorm:"column:updated_at;autoUpdateTime"`
+}
+
+// TableName 指定表名
+func (TenantUsageModel) TableName() string {
+	return "tenant_usages"
+}
+
+// ToValueObject 将数据库模型转换为用量值对象
+func (m *TenantUsageModel) ToValueObject() valueobject.QuotaUsage {
+	return valueobject.QuotaUsage{
+		KnowledgeBases: m.KnowledgeBases,
+		Documents:      m.Documents,
+		ContentBytes:   m.ContentBytes,
+	}
+}
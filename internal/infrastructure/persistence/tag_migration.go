@@ -0,0 +1,75 @@
+package persistence
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+
+	"gozero-ddd/internal/domain/valueobject"
+	"gozero-ddd/internal/infrastructure/persistence/model"
+)
+
+// tagMigrationBatchSize 规范化存量标签时每批处理的文档数
+const tagMigrationBatchSize = 500
+
+// NormalizeDocumentTags 规范化存量文档的标签（包括回收站中的文档）
+// 引入标签规范化之前写入的标签可能是 "Go"、"Machine Learning" 这样的原始输入，
+// 而标签查询在数据库中按规范化后的值精确匹配（JSON_CONTAINS），需要先将存量数据规范化。
+// 按主键分批处理，只更新标签有变化的文档，可以重复执行
+func NormalizeDocumentTags(ctx context.Context, db *gorm.DB) (int, error) {
+	updated := 0
+	lastID := ""
+	for {
+		var models []model.DocumentModel
+		if err := db.WithContext(ctx).Unscoped().
+			Select("id", "tags").
+			Where("id > ?", lastID).
+			Order("id ASC").
+			Limit(tagMigrationBatchSize).
+			Find(&models).Error; err != nil {
+			return updated, err
+		}
+		if len(models) == 0 {
+			return updated, nil
+		}
+
+		for _, m := range models {
+			tags, changed := normalizeStoredTags(m.Tags)
+			if !changed {
+				continue
+			}
+			if err := db.WithContext(ctx).Unscoped().
+				Model(&model.DocumentModel{}).
+				Where("id = ?", m.ID).
+				UpdateColumn("tags", model.StringSlice(tags)).Error; err != nil {
+				return updated, err
+			}
+			updated++
+		}
+		lastID = models[len(models)-1].ID
+	}
+}
+
+// normalizeStoredTags 按 valueobject.NewTag 的规则规范化已存储的标签并去重
+// 无法规范化的标签（例如包含不允许的字符）保持原样，避免迁移丢失数据
+func normalizeStoredTags(stored []string) ([]string, bool) {
+	result := make([]string, 0, len(stored))
+	seen := make(map[string]bool, len(stored))
+	changed := false
+	for _, s := range stored {
+		value := s
+		if tag, err := valueobject.NewTag(s); err == nil {
+			value = tag.String()
+		}
+		if value != s {
+			changed = true
+		}
+		if seen[value] {
+			changed = true
+			continue
+		}
+		seen[value] = true
+		result = append(result, value)
+	}
+	return result, changed
+}
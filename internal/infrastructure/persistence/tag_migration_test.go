@@ -0,0 +1,67 @@
+package persistence
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"gozero-ddd/internal/domain/valueobject"
+)
+
+func TestNormalizeStoredTags(t *testing.T) {
+	tests := []struct {
+		name        string
+		stored      []string
+		want        []string
+		wantChanged bool
+	}{
+		{"already normalized", []string{"go", "machine-learning"}, []string{"go", "machine-learning"}, false},
+		{"legacy tags", []string{"Go", " Machine  Learning "}, []string{"go", "machine-learning"}, true},
+		{"duplicates after normalization", []string{"go", "GO", "Go "}, []string{"go"}, true},
+		{"invalid tag is kept", []string{"c*", "Go"}, []string{"c*", "go"}, true},
+		{"empty", []string{}, []string{}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, changed := normalizeStoredTags(tt.stored)
+			if !reflect.DeepEqual(got, tt.want) || changed != tt.wantChanged {
+				t.Errorf("normalizeStoredTags(%q) = %q, %v, want %q, %v", tt.stored, got, changed, tt.want, tt.wantChanged)
+			}
+		})
+	}
+}
+
+// TestLegacyTagsMatchInDatabaseAndMemory 未规范化的存量标签经迁移后，
+// 数据库查询（JSON_CONTAINS 精确匹配）与内存求值得到相同的结果
+func TestLegacyTagsMatchInDatabaseAndMemory(t *testing.T) {
+	legacy := []string{"Machine Learning", "Go"}
+	normalized, _ := normalizeStoredTags(legacy)
+
+	for _, query := range []string{"tag:machine-learning", "tag:go", "tag:rust"} {
+		t.Run(query, func(t *testing.T) {
+			expr, err := valueobject.ParseTagQuery(query)
+			if err != nil {
+				t.Fatal(err)
+			}
+			_, args, err := compileTagQuery(expr)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var value string
+			if err := json.Unmarshal([]byte(args[0].(string)), &value); err != nil {
+				t.Fatal(err)
+			}
+
+			inDatabase := false
+			for _, tag := range normalized {
+				if tag == value {
+					inDatabase = true
+				}
+			}
+			if inMemory := expr.Matches(legacy); inDatabase != inMemory {
+				t.Errorf("database match = %v, in-memory match = %v", inDatabase, inMemory)
+			}
+		})
+	}
+}
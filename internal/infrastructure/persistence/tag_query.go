@@ -0,0 +1,51 @@
+package persistence
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"gozero-ddd/internal/domain/valueobject"
+)
+
+// compileTagQuery 将标签查询表达式编译为参数化的 SQL 条件
+// 标签值通过占位符传入（JSON 编码后交给 JSON_CONTAINS），不拼接进 SQL 文本
+func compileTagQuery(expr valueobject.TagExpr) (string, []interface{}, error) {
+	switch e := expr.(type) {
+	case *valueobject.TagTerm:
+		value, err := json.Marshal(e.Tag.String())
+		if err != nil {
+			return "", nil, err
+		}
+		return "JSON_CONTAINS(tags, ?)", []interface{}{string(value)}, nil
+
+	case *valueobject.TagAnd:
+		return compileTagBinary(e.Left, e.Right, "AND")
+
+	case *valueobject.TagOr:
+		return compileTagBinary(e.Left, e.Right, "OR")
+
+	case *valueobject.TagNot:
+		sql, args, err := compileTagQuery(e.Expr)
+		if err != nil {
+			return "", nil, err
+		}
+		// tags 为 NULL 时 JSON_CONTAINS 返回 NULL，NOT 之后仍为 NULL，这里显式视为不包含
+		return "NOT COALESCE(" + sql + ", FALSE)", args, nil
+
+	default:
+		return "", nil, fmt.Errorf("%w: unsupported expression %T", valueobject.ErrInvalidTagQuery, expr)
+	}
+}
+
+// compileTagBinary 编译二元逻辑表达式
+func compileTagBinary(left, right valueobject.TagExpr, op string) (string, []interface{}, error) {
+	leftSQL, leftArgs, err := compileTagQuery(left)
+	if err != nil {
+		return "", nil, err
+	}
+	rightSQL, rightArgs, err := compileTagQuery(right)
+	if err != nil {
+		return "", nil, err
+	}
+	return "(" + leftSQL + " " + op + " " + rightSQL + ")", append(leftArgs, rightArgs...), nil
+}
@@ -0,0 +1,49 @@
+package persistence
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+
+	"gozero-ddd/internal/domain/tenant"
+	"gozero-ddd/internal/infrastructure/persistence/model"
+)
+
+// tenantScope 按上下文中的租户过滤带 tenant_id 列的表
+// table 为空时使用当前模型的表，联表查询时需要指定表名避免列名歧义
+func tenantScope(ctx context.Context, table string) func(*gorm.DB) *gorm.DB {
+	column := "tenant_id"
+	if table != "" {
+		column = table + ".tenant_id"
+	}
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where(column+" = ?", tenant.FromContext(ctx).String())
+	}
+}
+
+// tenantKnowledgeBaseScope 按知识库所属租户过滤只有 knowledge_base_id 列的表
+// （文件夹、标签、附件、链接、指纹、成员），子查询包含回收站中的知识库
+func tenantKnowledgeBaseScope(ctx context.Context, column string) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		kbIDs := db.Session(&gorm.Session{NewDB: true}).
+			Unscoped().
+			Model(&model.KnowledgeBaseModel{}).
+			Select("id").
+			Where("tenant_id = ?", tenant.FromContext(ctx).String())
+		return db.Where(column+" IN (?)", kbIDs)
+	}
+}
+
+// ownedByOtherTenant 检查主键是否已被其他租户的记录（包括回收站中的）占用
+// 保存知识库和文档前调用：GORM 的 Save 按主键更新，不能覆盖其他租户的记录
+func ownedByOtherTenant(ctx context.Context, db *gorm.DB, value interface{}, id string) (bool, error) {
+	var owners []string
+	if err := db.Session(&gorm.Session{NewDB: true}).
+		Unscoped().
+		Model(value).
+		Where("id = ?", id).
+		Pluck("tenant_id", &owners).Error; err != nil {
+		return false, err
+	}
+	return len(owners) > 0 && owners[0] != tenant.FromContext(ctx).String(), nil
+}
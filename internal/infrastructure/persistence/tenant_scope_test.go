@@ -0,0 +1,217 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"gozero-ddd/internal/domain/tenant"
+	"gozero-ddd/internal/infrastructure/persistence/model"
+)
+
+// recordedQuery 数据库收到的一条查询
+type recordedQuery struct {
+	sql  string
+	args []interface{}
+}
+
+// recordingConnector 记录查询并返回固定结果的 database/sql 连接器
+// 每条查询都返回 columns 和 rows，不连接真实的数据库
+type recordingConnector struct {
+	mu      sync.Mutex
+	queries []recordedQuery
+	columns []string
+	rows    [][]driver.Value
+}
+
+func (c *recordingConnector) Connect(context.Context) (driver.Conn, error) {
+	return &recordingConn{connector: c}, nil
+}
+
+func (c *recordingConnector) Driver() driver.Driver { return recordingDriver{} }
+
+type recordingDriver struct{}
+
+func (recordingDriver) Open(string) (driver.Conn, error) {
+	return nil, errors.New("use the connector")
+}
+
+type recordingConn struct {
+	connector *recordingConnector
+}
+
+func (c *recordingConn) Prepare(string) (driver.Stmt, error) {
+	return nil, errors.New("prepared statements are not supported")
+}
+
+func (c *recordingConn) Close() error { return nil }
+
+func (c *recordingConn) Begin() (driver.Tx, error) {
+	return nil, errors.New("transactions are not supported")
+}
+
+func (c *recordingConn) QueryContext(_ context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	c.connector.mu.Lock()
+	defer c.connector.mu.Unlock()
+	values := make([]interface{}, len(args))
+	for i, a := range args {
+		values[i] = a.Value
+	}
+	c.connector.queries = append(c.connector.queries, recordedQuery{sql: query, args: values})
+	return &recordingRows{columns: c.connector.columns, rows: c.connector.rows}, nil
+}
+
+type recordingRows struct {
+	columns []string
+	rows    [][]driver.Value
+	pos     int
+}
+
+func (r *recordingRows) Columns() []string { return r.columns }
+
+func (r *recordingRows) Close() error { return nil }
+
+func (r *recordingRows) Next(dest []driver.Value) error {
+	if r.pos >= len(r.rows) {
+		return io.EOF
+	}
+	copy(dest, r.rows[r.pos])
+	r.pos++
+	return nil
+}
+
+// openRecordingDB 打开使用 MySQL 方言、由 connector 应答查询的 GORM 连接
+func openRecordingDB(t *testing.T, connector *recordingConnector) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(mysql.New(mysql.Config{
+		Conn:                      sql.OpenDB(connector),
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{Logger: logger.Discard, DisableAutomaticPing: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return db
+}
+
+// dryRun 生成查询的 SQL 和参数，不执行查询
+func dryRun(t *testing.T, query func(db *gorm.DB) *gorm.DB) (string, []interface{}) {
+	t.Helper()
+	db := openRecordingDB(t, &recordingConnector{})
+	stmt := query(db.Session(&gorm.Session{DryRun: true})).Statement
+	return stmt.SQL.String(), stmt.Vars
+}
+
+func TestTenantScope(t *testing.T) {
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		table    string
+		wantSQL  string
+		wantVars []interface{}
+	}{
+		{
+			name:     "tenant from context",
+			ctx:      tenant.WithID(context.Background(), "acme"),
+			wantSQL:  "SELECT * FROM `documents` WHERE tenant_id = ? AND `documents`.`deleted_at` IS NULL",
+			wantVars: []interface{}{"acme"},
+		},
+		{
+			name:     "default tenant when unset",
+			ctx:      context.Background(),
+			wantSQL:  "SELECT * FROM `documents` WHERE tenant_id = ? AND `documents`.`deleted_at` IS NULL",
+			wantVars: []interface{}{"default"},
+		},
+		{
+			name:     "qualified column for joins",
+			ctx:      tenant.WithID(context.Background(), "acme"),
+			table:    "documents",
+			wantSQL:  "SELECT * FROM `documents` WHERE documents.tenant_id = ? AND `documents`.`deleted_at` IS NULL",
+			wantVars: []interface{}{"acme"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sql, vars := dryRun(t, func(db *gorm.DB) *gorm.DB {
+				var models []model.DocumentModel
+				return db.Scopes(tenantScope(tt.ctx, tt.table)).Find(&models)
+			})
+			if sql != tt.wantSQL {
+				t.Errorf("SQL = %s, want %s", sql, tt.wantSQL)
+			}
+			if !reflect.DeepEqual(vars, tt.wantVars) {
+				t.Errorf("vars = %v, want %v", vars, tt.wantVars)
+			}
+		})
+	}
+}
+
+func TestTenantKnowledgeBaseScope(t *testing.T) {
+	ctx := tenant.WithID(context.Background(), "acme")
+	sql, vars := dryRun(t, func(db *gorm.DB) *gorm.DB {
+		var models []model.KnowledgeBaseMemberModel
+		return db.Scopes(tenantKnowledgeBaseScope(ctx, "knowledge_base_id")).Where("subject = ?", "alice").Find(&models)
+	})
+
+	// 子查询不排除回收站中的知识库，否则知识库移入回收站后其成员等记录对所属租户不可见
+	wantSubquery := "knowledge_base_id IN (SELECT `id` FROM `knowledge_bases` WHERE tenant_id = ?)"
+	if !strings.Contains(sql, wantSubquery) {
+		t.Errorf("SQL = %s, want it to contain %s", sql, wantSubquery)
+	}
+	if want := []interface{}{"alice", "acme"}; !reflect.DeepEqual(vars, want) {
+		t.Errorf("vars = %v, want %v", vars, want)
+	}
+}
+
+func TestOwnedByOtherTenant(t *testing.T) {
+	tests := []struct {
+		name   string
+		owners []string
+		want   bool
+	}{
+		{"new id", nil, false},
+		{"owned by current tenant", []string{"acme"}, false},
+		{"owned by other tenant", []string{"globex"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			connector := &recordingConnector{columns: []string{"tenant_id"}}
+			for _, owner := range tt.owners {
+				connector.rows = append(connector.rows, []driver.Value{owner})
+			}
+			db := openRecordingDB(t, connector)
+			ctx := tenant.WithID(context.Background(), "acme")
+
+			got, err := ownedByOtherTenant(ctx, db, &model.DocumentModel{}, "doc-1")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("ownedByOtherTenant() = %v, want %v", got, tt.want)
+			}
+
+			// 查询包含回收站中的记录，且不按当前租户过滤
+			if len(connector.queries) != 1 {
+				t.Fatalf("got %d queries, want 1", len(connector.queries))
+			}
+			query := connector.queries[0]
+			if want := "SELECT `tenant_id` FROM `documents` WHERE id = ?"; query.sql != want {
+				t.Errorf("SQL = %s, want %s", query.sql, want)
+			}
+			if want := []interface{}{"doc-1"}; !reflect.DeepEqual(query.args, want) {
+				t.Errorf("args = %v, want %v", query.args, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package persistence
+
+import (
+	"errors"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+
+	"gozero-ddd/internal/application/tracing"
+)
+
+// tracingSpanKey 保存当前语句 span 的实例键
+const tracingSpanKey = "tracing:span"
+
+// TracingPlugin GORM 链路追踪插件
+// 为仓储执行的每条 SQL 语句记录一个 span（如 "gorm.query documents"），
+// 仓储都通过 WithContext(ctx) 执行语句，span 挂在仓储方法的 span 之下。
+// 记录的语句不含参数值，避免文档内容等数据写入链路
+type TracingPlugin struct{}
+
+// NewTracingPlugin 创建 GORM 链路追踪插件
+func NewTracingPlugin() *TracingPlugin {
+	return &TracingPlugin{}
+}
+
+// 确保实现了接口
+var _ gorm.Plugin = (*TracingPlugin)(nil)
+
+// Name 插件名称
+func (p *TracingPlugin) Name() string {
+	return "tracing"
+}
+
+// Initialize 注册各类操作的前后回调
+func (p *TracingPlugin) Initialize(db *gorm.DB) error {
+	cb := db.Callback()
+	return errors.Join(
+		cb.Create().Before("gorm:create").Register("tracing:before_create", startSpan("create")),
+		cb.Create().After("gorm:create").Register("tracing:after_create", endSpan("create")),
+		cb.Query().Before("gorm:query").Register("tracing:before_query", startSpan("query")),
+		cb.Query().After("gorm:query").Register("tracing:after_query", endSpan("query")),
+		cb.Update().Before("gorm:update").Register("tracing:before_update", startSpan("update")),
+		cb.Update().After("gorm:update").Register("tracing:after_update", endSpan("update")),
+		cb.Delete().Before("gorm:delete").Register("tracing:before_delete", startSpan("delete")),
+		cb.Delete().After("gorm:delete").Register("tracing:after_delete", endSpan("delete")),
+		cb.Row().Before("gorm:row").Register("tracing:before_row", startSpan("row")),
+		cb.Row().After("gorm:row").Register("tracing:after_row", endSpan("row")),
+		cb.Raw().Before("gorm:raw").Register("tracing:before_raw", startSpan("raw")),
+		cb.Raw().After("gorm:raw").Register("tracing:after_raw", endSpan("raw")),
+	)
+}
+
+// startSpan 返回语句执行前开始 span 的回调
+func startSpan(operation string) func(*gorm.DB) {
+	return func(db *gorm.DB) {
+		if db.Statement == nil || db.Statement.Context == nil {
+			return
+		}
+		_, span := tracing.Start(db.Statement.Context, "gorm."+operation,
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(
+				attribute.String("db.system", "mysql"),
+				attribute.String("db.operation", operation),
+			),
+		)
+		db.InstanceSet(tracingSpanKey, span)
+	}
+}
+
+// endSpan 返回语句执行后结束 span 的回调，记录表名、执行的语句、影响行数和错误
+// 表名在 GORM 解析模型后才确定，因此在结束时补充到 span 名称中；
+// 记录不存在不视为错误，由仓储转换为领域错误
+func endSpan(operation string) func(*gorm.DB) {
+	return func(db *gorm.DB) {
+		value, ok := db.InstanceGet(tracingSpanKey)
+		if !ok {
+			return
+		}
+		span, ok := value.(trace.Span)
+		if !ok {
+			return
+		}
+
+		if table := db.Statement.Table; table != "" {
+			span.SetName("gorm." + operation + " " + table)
+			span.SetAttributes(attribute.String("db.sql.table", table))
+		}
+		span.SetAttributes(
+			attribute.String("db.statement", db.Statement.SQL.String()),
+			attribute.Int64("db.rows_affected", db.RowsAffected),
+		)
+		err := db.Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			err = nil
+		}
+		tracing.End(span, err)
+	}
+}
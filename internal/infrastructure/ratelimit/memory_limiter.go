@@ -0,0 +1,88 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	"gozero-ddd/internal/application/ratelimit"
+)
+
+// sweepInterval 清理已补满的令牌桶的间隔
+const sweepInterval = time.Minute
+
+// MemoryLimiter 内存令牌桶限流器
+// 令牌桶保存在进程内，只适用于单实例部署；多实例部署需要共享令牌桶时使用 RedisLimiter
+type MemoryLimiter struct {
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+// bucket 单个调用方的令牌桶
+type bucket struct {
+	tokens  float64   // 上次更新时的令牌数
+	updated time.Time // 上次更新时间
+	fullAt  time.Time // 补满的时间，之后可以丢弃该令牌桶
+}
+
+// NewMemoryLimiter 创建内存令牌桶限流器
+func NewMemoryLimiter() *MemoryLimiter {
+	return &MemoryLimiter{
+		buckets: make(map[string]*bucket),
+		now:     time.Now,
+	}
+}
+
+// 确保实现了接口
+var _ ratelimit.Limiter = (*MemoryLimiter)(nil)
+
+// Take 从 key 对应的令牌桶中取出 cost 个令牌，令牌不足时不扣减
+func (l *MemoryLimiter) Take(ctx context.Context, key string, limit ratelimit.Limit, cost int) (ratelimit.Result, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.sweep(now)
+
+	b, ok := l.buckets[key]
+	if !ok {
+		// 新调用方的令牌桶是满的
+		b = &bucket{tokens: float64(limit.Burst), updated: now}
+		l.buckets[key] = b
+	}
+
+	// 按经过的时间补充令牌，不超过桶容量
+	elapsed := now.Sub(b.updated).Seconds()
+	if elapsed > 0 {
+		b.tokens = math.Min(float64(limit.Burst), b.tokens+elapsed*limit.Rate)
+	}
+	b.updated = now
+
+	result := ratelimit.Result{}
+	if b.tokens >= float64(cost) {
+		b.tokens -= float64(cost)
+		result.Allowed = true
+	} else {
+		result.RetryAfter = time.Duration((float64(cost) - b.tokens) / limit.Rate * float64(time.Second))
+	}
+	result.Remaining = int(b.tokens)
+	b.fullAt = now.Add(time.Duration((float64(limit.Burst) - b.tokens) / limit.Rate * float64(time.Second)))
+	return result, nil
+}
+
+// sweep 定期丢弃已补满的令牌桶，避免调用方（尤其是匿名 IP）很多时内存持续增长
+// 丢弃已补满的令牌桶不影响限流结果：下次请求会重新创建一个满的令牌桶
+func (l *MemoryLimiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < sweepInterval {
+		return
+	}
+	l.lastSweep = now
+	for key, b := range l.buckets {
+		if !now.Before(b.fullAt) {
+			delete(l.buckets, key)
+		}
+	}
+}
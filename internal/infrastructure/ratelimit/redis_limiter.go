@@ -0,0 +1,84 @@
+package ratelimit
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+
+	"gozero-ddd/internal/application/ratelimit"
+)
+
+// keyPrefix Redis 中令牌桶的键前缀
+const keyPrefix = "ratelimit:"
+
+// takeScript 原子地补充令牌并尝试取出令牌
+// 令牌桶保存为哈希（tokens 为令牌数，ts 为更新时间的毫秒数），补满所需时间之后过期。
+// 当前时间取自 Redis 服务器（TIME），各服务实例的时钟偏差不会影响令牌的补充。
+// 返回 {是否允许, 剩余令牌数, 需要等待的毫秒数}
+var takeScript = redis.NewScript(`
+local rate = tonumber(ARGV[1])
+local burst = tonumber(ARGV[2])
+local cost = tonumber(ARGV[3])
+
+-- Redis 5 之前的版本需要按效果复制，才能在 TIME 之后执行写命令
+if redis.replicate_commands then
+	redis.replicate_commands()
+end
+local time = redis.call("TIME")
+local now = tonumber(time[1]) * 1000 + math.floor(tonumber(time[2]) / 1000)
+
+local state = redis.call("HMGET", KEYS[1], "tokens", "ts")
+local tokens = tonumber(state[1])
+local ts = tonumber(state[2])
+if tokens == nil or ts == nil then
+	tokens = burst
+	ts = now
+end
+
+local elapsed = math.max(0, now - ts)
+tokens = math.min(burst, tokens + elapsed * rate / 1000)
+
+local allowed = 0
+local wait = 0
+if tokens >= cost then
+	tokens = tokens - cost
+	allowed = 1
+else
+	wait = math.ceil((cost - tokens) * 1000 / rate)
+end
+
+redis.call("HMSET", KEYS[1], "tokens", tostring(tokens), "ts", tostring(now))
+redis.call("PEXPIRE", KEYS[1], math.ceil((burst - tokens) * 1000 / rate) + 1000)
+return {allowed, math.floor(tokens), wait}
+`)
+
+// RedisLimiter Redis 令牌桶限流器
+// 令牌桶保存在 Redis 中，多个服务实例共享同一调用方的令牌桶
+type RedisLimiter struct {
+	client *redis.Client
+}
+
+// NewRedisLimiter 创建 Redis 令牌桶限流器
+func NewRedisLimiter(client *redis.Client) *RedisLimiter {
+	return &RedisLimiter{client: client}
+}
+
+// 确保实现了接口
+var _ ratelimit.Limiter = (*RedisLimiter)(nil)
+
+// Take 从 key 对应的令牌桶中取出 cost 个令牌，令牌不足时不扣减
+func (l *RedisLimiter) Take(ctx context.Context, key string, limit ratelimit.Limit, cost int) (ratelimit.Result, error) {
+	values, err := takeScript.Run(ctx, l.client, []string{keyPrefix + key},
+		limit.Rate, limit.Burst, cost,
+	).Int64Slice()
+	if err != nil {
+		return ratelimit.Result{}, err
+	}
+
+	return ratelimit.Result{
+		Allowed:    values[0] == 1,
+		Remaining:  int(values[1]),
+		RetryAfter: time.Duration(values[2]) * time.Millisecond,
+	}, nil
+}
@@ -0,0 +1,158 @@
+package render
+
+import (
+	"html"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// 支持的 AsciiDoc 子集：
+//   - 章节标题（= ~ ======）、段落、分隔线（'''）
+//   - 代码块（----，可由 [source,lang] 指定语言）、字面块（....）、引用块（____）
+//   - 无序列表（* -）、有序列表（.）
+//   - 行内：代码、粗体、斜体、链接（link:url[text] 或 https://url[text]）、交叉引用（<<id,text>>）
+//
+// 文档属性（:name: value）、块属性（[...]）和注释（//）会被忽略
+
+var (
+	adocHeadingPattern   = regexp.MustCompile(`^(={1,6})\s+(.+?)\s*$`)
+	adocAttrPattern      = regexp.MustCompile(`^:[\w-]+!?:`)
+	adocBlockAttrPattern = regexp.MustCompile(`^\[([^\]]*)\]$`)
+	adocBulletPattern    = regexp.MustCompile(`^\s*[*-]\s+(.*)$`)
+	adocOrderedPattern   = regexp.MustCompile(`^\s*\.\s+(.*)$`)
+	adocLinkPattern      = regexp.MustCompile(`(?:link:)?((?:https?://|mailto:)[^\s\[]+|link:[^\s\[]+)\[([^\]]*)\]`)
+	adocXrefPattern      = regexp.MustCompile(`&lt;&lt;([\w-]+)(?:,\s*([^&]*))?&gt;&gt;`)
+	adocBoldPattern      = regexp.MustCompile(`(^|[^\w*])\*(\S(?:[^*]*?\S)?)\*`)
+	adocItalicPattern    = regexp.MustCompile(`(^|[^\w_])_(\S(?:[^_]*?\S)?)_`)
+)
+
+// asciidocToHTML 将 AsciiDoc 转换为 HTML
+func asciidocToHTML(src string) string {
+	lines := splitLines(src)
+	var b strings.Builder
+	lang := "" // 由 [source,lang] 块属性指定，作用于紧随其后的代码块
+
+	for i := 0; i < len(lines); {
+		line := lines[i]
+		trimmed := strings.TrimSpace(line)
+
+		switch {
+		case trimmed == "":
+			i++
+
+		case strings.HasPrefix(trimmed, "//"), adocAttrPattern.MatchString(trimmed):
+			i++
+
+		case adocBlockAttrPattern.MatchString(trimmed):
+			attrs := strings.Split(adocBlockAttrPattern.FindStringSubmatch(trimmed)[1], ",")
+			if len(attrs) > 1 && strings.TrimSpace(attrs[0]) == "source" {
+				lang = strings.TrimSpace(attrs[1])
+			}
+			i++
+
+		case trimmed == "----", trimmed == "....":
+			delimiter := trimmed
+			i++
+			code := make([]string, 0)
+			for i < len(lines) && strings.TrimSpace(lines[i]) != delimiter {
+				code = append(code, lines[i])
+				i++
+			}
+			i++
+			writeCodeBlock(&b, lang, code)
+			lang = ""
+
+		case trimmed == "____":
+			i++
+			quoted := make([]string, 0)
+			for i < len(lines) && strings.TrimSpace(lines[i]) != "____" {
+				quoted = append(quoted, lines[i])
+				i++
+			}
+			i++
+			b.WriteString("<blockquote>\n" + asciidocToHTML(strings.Join(quoted, "\n")) + "</blockquote>\n")
+
+		case trimmed == "'''":
+			b.WriteString("<hr>\n")
+			i++
+
+		case adocHeadingPattern.MatchString(trimmed):
+			m := adocHeadingPattern.FindStringSubmatch(trimmed)
+			level := strconv.Itoa(len(m[1]))
+			b.WriteString("<h" + level + ">" + asciidocInline(m[2]) + "</h" + level + ">\n")
+			i++
+
+		case adocBulletPattern.MatchString(line):
+			i = writeList(&b, lines, i, adocBulletPattern, "ul", 1, asciidocInline)
+
+		case adocOrderedPattern.MatchString(line):
+			i = writeList(&b, lines, i, adocOrderedPattern, "ol", 1, asciidocInline)
+
+		default:
+			para := make([]string, 0)
+			for i < len(lines) && !isAsciidocBlockStart(lines[i]) {
+				para = append(para, strings.TrimSpace(lines[i]))
+				i++
+			}
+			b.WriteString("<p>" + asciidocInline(strings.Join(para, "\n")) + "</p>\n")
+		}
+	}
+	return b.String()
+}
+
+// isAsciidocBlockStart 判断一行是否结束当前段落
+func isAsciidocBlockStart(line string) bool {
+	trimmed := strings.TrimSpace(line)
+	switch trimmed {
+	case "", "----", "....", "____", "'''":
+		return true
+	}
+	return adocHeadingPattern.MatchString(trimmed) ||
+		adocBlockAttrPattern.MatchString(trimmed) ||
+		adocBulletPattern.MatchString(line) ||
+		adocOrderedPattern.MatchString(line)
+}
+
+// asciidocInline 处理行内语法
+func asciidocInline(s string) string {
+	s = html.EscapeString(strings.ReplaceAll(s, "\x00", ""))
+
+	spans := make([]string, 0)
+	protect := func(rendered string) string {
+		spans = append(spans, rendered)
+		return "\x00" + strconv.Itoa(len(spans)-1) + "\x00"
+	}
+
+	s = codeSpanPattern.ReplaceAllStringFunc(s, func(m string) string {
+		return protect("<code>" + codeSpanPattern.FindStringSubmatch(m)[1] + "</code>")
+	})
+	s = replaceWikiLinks(s)
+	s = adocLinkPattern.ReplaceAllStringFunc(s, func(m string) string {
+		sub := adocLinkPattern.FindStringSubmatch(m)
+		href := strings.TrimPrefix(sub[1], "link:")
+		text := sub[2]
+		if text == "" {
+			text = href
+		}
+		return protect(`<a href="` + href + `">` + asciidocEmphasis(text) + "</a>")
+	})
+	s = adocXrefPattern.ReplaceAllStringFunc(s, func(m string) string {
+		sub := adocXrefPattern.FindStringSubmatch(m)
+		text := strings.TrimSpace(sub[2])
+		if text == "" {
+			text = sub[1]
+		}
+		return protect(`<a href="#` + sub[1] + `">` + text + "</a>")
+	})
+	s = asciidocEmphasis(s)
+	s = strings.ReplaceAll(s, "\n", " ")
+
+	return restorePlaceholders(s, spans)
+}
+
+// asciidocEmphasis 处理粗体和斜体
+func asciidocEmphasis(s string) string {
+	s = adocBoldPattern.ReplaceAllString(s, "$1<strong>$2</strong>")
+	return adocItalicPattern.ReplaceAllString(s, "$1<em>$2</em>")
+}
@@ -0,0 +1,246 @@
+package render
+
+import (
+	"html"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// 支持的 Markdown 子集：
+//   - ATX 标题（# ~ ######）、段落、分隔线
+//   - 围栏代码块（``` 或 ~~~，可带语言）
+//   - 引用块（>）、无序列表（- * +）、有序列表（1.）
+//   - GFM 表格
+//   - 行内：代码、粗体、斜体、删除线、链接、图片、Wiki 链接
+//
+// 原始 HTML 一律按文本转义，输出结果仍会经过净化器
+
+var (
+	mdHeadingPattern   = regexp.MustCompile(`^(#{1,6})\s+(.*?)\s*#*\s*$`)
+	mdFencePattern     = regexp.MustCompile("^(```|~~~)\\s*([\\w+#.-]*)")
+	mdRulePattern      = regexp.MustCompile(`^\s{0,3}([-*_])(\s*[-*_]){2,}\s*$`)
+	mdBulletPattern    = regexp.MustCompile(`^\s{0,3}[-*+]\s+(.*)$`)
+	mdOrderedPattern   = regexp.MustCompile(`^\s{0,3}(\d{1,9})[.)]\s+(.*)$`)
+	mdTableSepPattern  = regexp.MustCompile(`^\s*\|?\s*:?-{3,}:?\s*(\|\s*:?-{3,}:?\s*)*\|?\s*$`)
+	mdImagePattern     = regexp.MustCompile(`!\[([^\]]*)\]\(([^)\s]+)\)`)
+	mdLinkPattern      = regexp.MustCompile(`\[([^\]]+)\]\(([^)\s]+)\)`)
+	mdBoldPattern      = regexp.MustCompile(`(\*\*|__)(\S(?:.*?\S)?)(\*\*|__)`)
+	mdItalicPattern    = regexp.MustCompile(`(^|[^\w*])[*_](\S(?:[^*_]*?\S)?)[*_]`)
+	mdStrikePattern    = regexp.MustCompile(`~~(\S(?:.*?\S)?)~~`)
+	wikiLinkPattern    = regexp.MustCompile(`\[\[([^\[\]|]+)(?:\|([^\[\]]*))?\]\]`)
+	codeSpanPattern    = regexp.MustCompile("`([^`]+)`")
+	placeholderPattern = regexp.MustCompile("\x00(\\d+)\x00")
+)
+
+// markdownToHTML 将 Markdown 转换为 HTML
+func markdownToHTML(src string) string {
+	lines := splitLines(src)
+	var b strings.Builder
+
+	for i := 0; i < len(lines); {
+		line := lines[i]
+		trimmed := strings.TrimSpace(line)
+
+		switch {
+		case trimmed == "":
+			i++
+
+		case mdFencePattern.MatchString(trimmed):
+			m := mdFencePattern.FindStringSubmatch(trimmed)
+			fence, lang := m[1], m[2]
+			i++
+			code := make([]string, 0)
+			for i < len(lines) && !strings.HasPrefix(strings.TrimSpace(lines[i]), fence) {
+				code = append(code, lines[i])
+				i++
+			}
+			i++ // 跳过结束围栏（若缺失则到文末为止）
+			writeCodeBlock(&b, lang, code)
+
+		case mdHeadingPattern.MatchString(trimmed):
+			m := mdHeadingPattern.FindStringSubmatch(trimmed)
+			level := strconv.Itoa(len(m[1]))
+			b.WriteString("<h" + level + ">" + markdownInline(m[2]) + "</h" + level + ">\n")
+			i++
+
+		case mdRulePattern.MatchString(line):
+			b.WriteString("<hr>\n")
+			i++
+
+		case strings.HasPrefix(trimmed, ">"):
+			quoted := make([]string, 0)
+			for i < len(lines) && strings.HasPrefix(strings.TrimSpace(lines[i]), ">") {
+				q := strings.TrimPrefix(strings.TrimSpace(lines[i]), ">")
+				quoted = append(quoted, strings.TrimPrefix(q, " "))
+				i++
+			}
+			b.WriteString("<blockquote>\n" + markdownToHTML(strings.Join(quoted, "\n")) + "</blockquote>\n")
+
+		case mdBulletPattern.MatchString(line):
+			i = writeList(&b, lines, i, mdBulletPattern, "ul", 1, markdownInline)
+
+		case mdOrderedPattern.MatchString(line):
+			i = writeList(&b, lines, i, mdOrderedPattern, "ol", 2, markdownInline)
+
+		case strings.Contains(trimmed, "|") && i+1 < len(lines) && mdTableSepPattern.MatchString(lines[i+1]):
+			i = writeTable(&b, lines, i)
+
+		default:
+			para := make([]string, 0)
+			for i < len(lines) && !isMarkdownBlockStart(lines[i]) {
+				para = append(para, strings.TrimSpace(lines[i]))
+				i++
+			}
+			b.WriteString("<p>" + markdownInline(strings.Join(para, "\n")) + "</p>\n")
+		}
+	}
+	return b.String()
+}
+
+// isMarkdownBlockStart 判断一行是否结束当前段落
+func isMarkdownBlockStart(line string) bool {
+	trimmed := strings.TrimSpace(line)
+	return trimmed == "" ||
+		mdFencePattern.MatchString(trimmed) ||
+		mdHeadingPattern.MatchString(trimmed) ||
+		mdRulePattern.MatchString(line) ||
+		strings.HasPrefix(trimmed, ">") ||
+		mdBulletPattern.MatchString(line) ||
+		mdOrderedPattern.MatchString(line)
+}
+
+// writeTable 输出 GFM 表格，返回表格之后的行号
+func writeTable(b *strings.Builder, lines []string, i int) int {
+	header := splitTableRow(lines[i])
+	i += 2 // 跳过表头和分隔行
+
+	b.WriteString("<table>\n<thead>\n<tr>")
+	for _, cell := range header {
+		b.WriteString("<th>" + markdownInline(cell) + "</th>")
+	}
+	b.WriteString("</tr>\n</thead>\n<tbody>\n")
+	for i < len(lines) && strings.Contains(lines[i], "|") && strings.TrimSpace(lines[i]) != "" {
+		b.WriteString("<tr>")
+		for _, cell := range splitTableRow(lines[i]) {
+			b.WriteString("<td>" + markdownInline(cell) + "</td>")
+		}
+		b.WriteString("</tr>\n")
+		i++
+	}
+	b.WriteString("</tbody>\n</table>\n")
+	return i
+}
+
+// splitTableRow 拆分表格行中的单元格
+func splitTableRow(line string) []string {
+	line = strings.TrimSpace(line)
+	line = strings.TrimPrefix(line, "|")
+	line = strings.TrimSuffix(line, "|")
+	cells := strings.Split(line, "|")
+	for i := range cells {
+		cells[i] = strings.TrimSpace(cells[i])
+	}
+	return cells
+}
+
+// markdownInline 处理行内语法
+// 先转义 HTML，再把代码片段、图片和链接替换为占位符，避免其中的符号被当作强调语法处理
+func markdownInline(s string) string {
+	s = html.EscapeString(strings.ReplaceAll(s, "\x00", ""))
+
+	spans := make([]string, 0)
+	protect := func(rendered string) string {
+		spans = append(spans, rendered)
+		return "\x00" + strconv.Itoa(len(spans)-1) + "\x00"
+	}
+
+	s = codeSpanPattern.ReplaceAllStringFunc(s, func(m string) string {
+		return protect("<code>" + codeSpanPattern.FindStringSubmatch(m)[1] + "</code>")
+	})
+	s = replaceWikiLinks(s)
+	s = mdImagePattern.ReplaceAllStringFunc(s, func(m string) string {
+		sub := mdImagePattern.FindStringSubmatch(m)
+		return protect(`<img src="` + sub[2] + `" alt="` + sub[1] + `">`)
+	})
+	s = mdLinkPattern.ReplaceAllStringFunc(s, func(m string) string {
+		sub := mdLinkPattern.FindStringSubmatch(m)
+		return protect(`<a href="` + sub[2] + `">` + markdownEmphasis(sub[1]) + "</a>")
+	})
+	s = markdownEmphasis(s)
+	s = strings.ReplaceAll(s, "\n", " ")
+
+	return restorePlaceholders(s, spans)
+}
+
+// markdownEmphasis 处理粗体、斜体和删除线
+func markdownEmphasis(s string) string {
+	s = mdBoldPattern.ReplaceAllString(s, "<strong>$2</strong>")
+	s = mdItalicPattern.ReplaceAllString(s, "$1<em>$2</em>")
+	return mdStrikePattern.ReplaceAllString(s, "<del>$1</del>")
+}
+
+// replaceWikiLinks 将 [[标题|显示文本]] 渲染为显示文本
+// Wiki 链接的目标由链接图谱解析，渲染时只保留可读文本
+func replaceWikiLinks(s string) string {
+	return wikiLinkPattern.ReplaceAllStringFunc(s, func(m string) string {
+		sub := wikiLinkPattern.FindStringSubmatch(m)
+		if text := strings.TrimSpace(sub[2]); text != "" {
+			return text
+		}
+		return strings.TrimSpace(sub[1])
+	})
+}
+
+// restorePlaceholders 还原占位符
+func restorePlaceholders(s string, values []string) string {
+	return placeholderPattern.ReplaceAllStringFunc(s, func(m string) string {
+		idx, err := strconv.Atoi(placeholderPattern.FindStringSubmatch(m)[1])
+		if err != nil || idx >= len(values) {
+			return ""
+		}
+		return values[idx]
+	})
+}
+
+// writeList 输出连续的列表项，返回列表之后的行号
+// 缩进的后续行并入上一个列表项；不支持嵌套列表
+func writeList(b *strings.Builder, lines []string, i int, pattern *regexp.Regexp, tag string, textGroup int, inline func(string) string) int {
+	b.WriteString("<" + tag + ">\n")
+	for i < len(lines) {
+		m := pattern.FindStringSubmatch(lines[i])
+		if m == nil {
+			break
+		}
+		item := []string{m[textGroup]}
+		i++
+		for i < len(lines) && isContinuation(lines[i]) && pattern.FindStringSubmatch(lines[i]) == nil {
+			item = append(item, strings.TrimSpace(lines[i]))
+			i++
+		}
+		b.WriteString("<li>" + inline(strings.Join(item, "\n")) + "</li>\n")
+	}
+	b.WriteString("</" + tag + ">\n")
+	return i
+}
+
+// isContinuation 判断是否为列表项的缩进续行
+func isContinuation(line string) bool {
+	return strings.TrimSpace(line) != "" && (strings.HasPrefix(line, "  ") || strings.HasPrefix(line, "\t"))
+}
+
+// writeCodeBlock 输出代码块
+func writeCodeBlock(b *strings.Builder, lang string, code []string) {
+	b.WriteString("<pre><code")
+	if lang != "" {
+		b.WriteString(` class="language-` + html.EscapeString(lang) + `"`)
+	}
+	b.WriteString(">" + html.EscapeString(strings.Join(code, "\n")) + "</code></pre>\n")
+}
+
+// splitLines 统一换行符后按行拆分
+func splitLines(s string) []string {
+	s = strings.ReplaceAll(s, "\r\n", "\n")
+	s = strings.ReplaceAll(s, "\r", "\n")
+	return strings.Split(s, "\n")
+}
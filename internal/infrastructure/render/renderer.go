@@ -0,0 +1,93 @@
+package render
+
+import (
+	"html"
+	"strings"
+	"unicode/utf8"
+
+	"gozero-ddd/internal/domain/service"
+	"gozero-ddd/internal/domain/valueobject"
+)
+
+// DefaultExcerptLength 默认摘要长度（字符数）
+const DefaultExcerptLength = 200
+
+// Renderer 文档内容渲染器
+// 先将各类内容转换为 HTML，再统一经过白名单净化，保证输出不含脚本等危险内容
+type Renderer struct {
+	excerptLength int
+}
+
+// 确保实现了接口
+var _ service.ContentRenderer = (*Renderer)(nil)
+
+// NewRenderer 创建渲染器
+// excerptLength 为摘要的最大字符数，<= 0 时使用默认值
+func NewRenderer(excerptLength int) *Renderer {
+	if excerptLength <= 0 {
+		excerptLength = DefaultExcerptLength
+	}
+	return &Renderer{excerptLength: excerptLength}
+}
+
+// Render 渲染文档内容
+func (r *Renderer) Render(contentType valueobject.ContentType, content string) (*service.RenderedContent, error) {
+	var fragment string
+	switch contentType {
+	case valueobject.ContentTypeMarkdown, "":
+		fragment = markdownToHTML(content)
+	case valueobject.ContentTypeAsciiDoc:
+		fragment = asciidocToHTML(content)
+	case valueobject.ContentTypeHTML:
+		fragment = content
+	case valueobject.ContentTypeText:
+		fragment = textToHTML(content)
+	default:
+		return nil, valueobject.ErrInvalidContentType
+	}
+
+	result, err := sanitize(fragment)
+	if err != nil {
+		return nil, err
+	}
+
+	toc := make([]valueobject.Heading, len(result.headings))
+	for i, h := range result.headings {
+		toc[i] = valueobject.Heading{Level: h.level, Text: h.text, Anchor: h.anchor}
+	}
+
+	return &service.RenderedContent{
+		HTML:    result.html,
+		Text:    result.text,
+		Excerpt: excerpt(result.text, r.excerptLength),
+		TOC:     toc,
+	}, nil
+}
+
+// textToHTML 将纯文本转换为 HTML：空行分段，段内换行保留为 <br>
+func textToHTML(src string) string {
+	var b strings.Builder
+	for _, para := range strings.Split(strings.Join(splitLines(src), "\n"), "\n\n") {
+		if para = strings.TrimSpace(para); para == "" {
+			continue
+		}
+		b.WriteString("<p>" + strings.ReplaceAll(html.EscapeString(para), "\n", "<br>") + "</p>\n")
+	}
+	return b.String()
+}
+
+// excerpt 截取纯文本摘要，换行折叠为空格，超出长度时在词边界截断并追加省略号
+func excerpt(text string, maxLen int) string {
+	text = strings.Join(strings.Fields(text), " ")
+	if utf8.RuneCountInString(text) <= maxLen {
+		return text
+	}
+
+	runes := []rune(text)[:maxLen]
+	cut := string(runes)
+	// 英文等以空格分词的文本尽量不截断单词
+	if idx := strings.LastIndex(cut, " "); idx > len(cut)/2 {
+		cut = cut[:idx]
+	}
+	return strings.TrimSpace(cut) + "…"
+}
@@ -0,0 +1,304 @@
+package render
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// 允许保留的元素及其属性（白名单）
+// 不在白名单中的元素会被展开（保留子节点），属性会被丢弃
+var allowedElements = map[atom.Atom][]string{
+	atom.H1: nil, atom.H2: nil, atom.H3: nil, atom.H4: nil, atom.H5: nil, atom.H6: nil,
+	atom.P: nil, atom.Br: nil, atom.Hr: nil, atom.Div: nil, atom.Span: nil,
+	atom.Blockquote: nil, atom.Pre: nil, atom.Code: {"class"},
+	atom.Ul: nil, atom.Ol: {"start"}, atom.Li: nil, atom.Dl: nil, atom.Dt: nil, atom.Dd: nil,
+	atom.Strong: nil, atom.B: nil, atom.Em: nil, atom.I: nil, atom.U: nil, atom.S: nil, atom.Del: nil, atom.Ins: nil,
+	atom.Sub: nil, atom.Sup: nil, atom.Mark: nil, atom.Kbd: nil, atom.Small: nil, atom.Abbr: {"title"},
+	atom.A:     {"href", "title"},
+	atom.Img:   {"src", "alt", "title", "width", "height"},
+	atom.Table: nil, atom.Thead: nil, atom.Tbody: nil, atom.Tfoot: nil, atom.Tr: nil,
+	atom.Th: {"colspan", "rowspan", "align"}, atom.Td: {"colspan", "rowspan", "align"},
+}
+
+// 连同内容一起丢弃的元素
+var droppedElements = map[atom.Atom]bool{
+	atom.Script: true, atom.Style: true, atom.Iframe: true, atom.Object: true, atom.Embed: true,
+	atom.Form: true, atom.Input: true, atom.Button: true, atom.Textarea: true, atom.Select: true,
+	atom.Noscript: true, atom.Template: true, atom.Svg: true, atom.Math: true,
+	atom.Head: true, atom.Title: true, atom.Meta: true, atom.Link: true, atom.Base: true,
+}
+
+// 文本提取时视为块级的元素，前后需要换行
+var blockElements = map[atom.Atom]bool{
+	atom.H1: true, atom.H2: true, atom.H3: true, atom.H4: true, atom.H5: true, atom.H6: true,
+	atom.P: true, atom.Br: true, atom.Hr: true, atom.Div: true, atom.Blockquote: true, atom.Pre: true,
+	atom.Ul: true, atom.Ol: true, atom.Li: true, atom.Dl: true, atom.Dt: true, atom.Dd: true,
+	atom.Table: true, atom.Tr: true, atom.Th: true, atom.Td: true,
+}
+
+// 链接和图片允许的 URL 协议，不带协议的相对地址和锚点也被允许
+var allowedSchemes = map[string]bool{
+	"http":   true,
+	"https":  true,
+	"mailto": true,
+}
+
+// sanitizeResult 净化结果
+type sanitizeResult struct {
+	html     string
+	text     string
+	headings []heading
+}
+
+// heading 标题信息
+type heading struct {
+	level  int
+	text   string
+	anchor string
+}
+
+// sanitize 按白名单净化 HTML 片段
+// 同时为标题生成锚点 ID，并提取纯文本
+func sanitize(fragment string) (*sanitizeResult, error) {
+	body := &html.Node{Type: html.ElementNode, Data: "body", DataAtom: atom.Body}
+	nodes, err := html.ParseFragment(strings.NewReader(fragment), body)
+	if err != nil {
+		return nil, err
+	}
+	for _, n := range nodes {
+		body.AppendChild(n)
+	}
+
+	cleanChildren(body)
+
+	result := &sanitizeResult{headings: make([]heading, 0)}
+	anchors := make(map[string]int)
+	var text strings.Builder
+	collect(body, &text, &result.headings, anchors)
+
+	var out strings.Builder
+	for c := body.FirstChild; c != nil; c = c.NextSibling {
+		if err := html.Render(&out, c); err != nil {
+			return nil, err
+		}
+	}
+
+	result.html = out.String()
+	result.text = normalizeText(text.String())
+	return result, nil
+}
+
+// cleanChildren 递归净化子节点
+func cleanChildren(n *html.Node) {
+	for c := n.FirstChild; c != nil; {
+		next := c.NextSibling
+
+		switch c.Type {
+		case html.TextNode:
+			// 文本节点原样保留，渲染时会被转义
+		case html.ElementNode:
+			attrs, allowed := allowedElements[c.DataAtom]
+			switch {
+			case droppedElements[c.DataAtom]:
+				n.RemoveChild(c)
+			case !allowed:
+				// 展开：净化其子节点后移到当前位置
+				cleanChildren(c)
+				for gc := c.FirstChild; gc != nil; {
+					gcNext := gc.NextSibling
+					c.RemoveChild(gc)
+					n.InsertBefore(gc, c)
+					gc = gcNext
+				}
+				n.RemoveChild(c)
+			default:
+				c.Attr = cleanAttrs(c.DataAtom, c.Attr, attrs)
+				cleanChildren(c)
+			}
+		default:
+			// 注释、文档类型声明等一律移除
+			n.RemoveChild(c)
+		}
+
+		c = next
+	}
+}
+
+// cleanAttrs 过滤属性，只保留白名单中且值安全的属性
+func cleanAttrs(a atom.Atom, attrs []html.Attribute, allowed []string) []html.Attribute {
+	result := make([]html.Attribute, 0, len(attrs))
+	for _, attr := range attrs {
+		if attr.Namespace != "" || !containsString(allowed, attr.Key) {
+			continue
+		}
+		switch attr.Key {
+		case "href", "src":
+			if !isSafeURL(attr.Val) {
+				continue
+			}
+		case "class":
+			// 只保留代码高亮用的语言标记
+			if !strings.HasPrefix(attr.Val, "language-") || strings.ContainsAny(attr.Val, " \t\n") {
+				continue
+			}
+		}
+		result = append(result, attr)
+	}
+	if a == atom.A {
+		result = append(result, html.Attribute{Key: "rel", Val: "nofollow noopener noreferrer"})
+	}
+	return result
+}
+
+// isSafeURL 判断 URL 协议是否在白名单中
+// 浏览器会忽略协议名中的空白和控制字符（如 "java\tscript:"），这类 URL 直接视为不安全
+func isSafeURL(raw string) bool {
+	for _, r := range raw {
+		if unicode.IsControl(r) || unicode.IsSpace(r) {
+			return false
+		}
+	}
+	u, err := url.Parse(raw)
+	if err != nil {
+		return false
+	}
+	return u.Scheme == "" || allowedSchemes[strings.ToLower(u.Scheme)]
+}
+
+// collect 遍历净化后的节点树：为标题设置锚点 ID 并收集目录，同时提取纯文本
+func collect(n *html.Node, text *strings.Builder, headings *[]heading, anchors map[string]int) {
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		switch c.Type {
+		case html.TextNode:
+			text.WriteString(c.Data)
+		case html.ElementNode:
+			block := blockElements[c.DataAtom]
+			if block {
+				text.WriteString("\n")
+			}
+			if level := headingLevel(c.DataAtom); level > 0 {
+				title := strings.Join(strings.Fields(nodeText(c)), " ")
+				anchor := uniqueAnchor(slugify(title), anchors)
+				c.Attr = append(c.Attr, html.Attribute{Key: "id", Val: anchor})
+				*headings = append(*headings, heading{level: level, text: title, anchor: anchor})
+			}
+			if c.DataAtom == atom.Img {
+				if alt := attrValue(c, "alt"); alt != "" {
+					text.WriteString(alt)
+				}
+			}
+			collect(c, text, headings, anchors)
+			if block {
+				text.WriteString("\n")
+			} else if c.DataAtom == atom.Td || c.DataAtom == atom.Th {
+				text.WriteString(" ")
+			}
+		}
+	}
+}
+
+// headingLevel 返回标题级别，非标题元素返回 0
+func headingLevel(a atom.Atom) int {
+	switch a {
+	case atom.H1:
+		return 1
+	case atom.H2:
+		return 2
+	case atom.H3:
+		return 3
+	case atom.H4:
+		return 4
+	case atom.H5:
+		return 5
+	case atom.H6:
+		return 6
+	default:
+		return 0
+	}
+}
+
+// nodeText 返回节点下所有文本
+func nodeText(n *html.Node) string {
+	var b strings.Builder
+	var walk func(*html.Node)
+	walk = func(n *html.Node) {
+		if n.Type == html.TextNode {
+			b.WriteString(n.Data)
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+	}
+	walk(n)
+	return b.String()
+}
+
+// attrValue 获取属性值
+func attrValue(n *html.Node, key string) string {
+	for _, attr := range n.Attr {
+		if attr.Key == key {
+			return attr.Val
+		}
+	}
+	return ""
+}
+
+// slugify 根据标题文本生成锚点：小写，字母数字（含中文）保留，其余字符折叠为 "-"
+func slugify(s string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(s) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+			dash = false
+		} else if !dash && b.Len() > 0 {
+			b.WriteByte('-')
+			dash = true
+		}
+	}
+	slug := strings.TrimSuffix(b.String(), "-")
+	if slug == "" {
+		slug = "section"
+	}
+	return slug
+}
+
+// uniqueAnchor 为重复的锚点追加序号
+func uniqueAnchor(slug string, anchors map[string]int) string {
+	anchor := slug
+	for n := anchors[slug]; anchors[anchor] > 0; n++ {
+		anchor = slug + "-" + strconv.Itoa(n)
+	}
+	if anchor != slug {
+		anchors[slug]++
+	}
+	anchors[anchor]++
+	return anchor
+}
+
+// normalizeText 规范化提取出的文本：折叠行内空白，去掉空行
+func normalizeText(s string) string {
+	lines := strings.Split(s, "\n")
+	result := make([]string, 0, len(lines))
+	for _, line := range lines {
+		if line = strings.Join(strings.Fields(line), " "); line != "" {
+			result = append(result, line)
+		}
+	}
+	return strings.Join(result, "\n")
+}
+
+// containsString 判断字符串切片中是否包含指定值
+func containsString(values []string, target string) bool {
+	for _, v := range values {
+		if v == target {
+			return true
+		}
+	}
+	return false
+}
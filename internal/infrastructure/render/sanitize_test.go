@@ -0,0 +1,180 @@
+package render
+
+import (
+	"strings"
+	"testing"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+
+	"gozero-ddd/internal/domain/valueobject"
+)
+
+// assertSafe 解析净化后的 HTML，校验其中只有白名单中的元素和属性，且没有事件处理器、
+// 内联样式和非白名单协议的链接（文本中被转义的标签不算）
+func assertSafe(t *testing.T, input, output string) {
+	t.Helper()
+	body := &html.Node{Type: html.ElementNode, Data: "body", DataAtom: atom.Body}
+	nodes, err := html.ParseFragment(strings.NewReader(output), body)
+	if err != nil {
+		t.Fatalf("parse output of %q: %v", input, err)
+	}
+
+	var walk func(n *html.Node)
+	walk = func(n *html.Node) {
+		switch n.Type {
+		case html.ElementNode:
+			allowed, ok := allowedElements[n.DataAtom]
+			if !ok {
+				t.Errorf("output of %q contains element <%s>:\n%s", input, n.Data, output)
+			}
+			for _, attr := range n.Attr {
+				key := strings.ToLower(attr.Key)
+				if strings.HasPrefix(key, "on") || key == "style" || key == "srcdoc" || key == "formaction" {
+					t.Errorf("output of %q contains attribute %s:\n%s", input, attr.Key, output)
+				}
+				if !containsString(allowed, key) && key != "id" && !(n.DataAtom == atom.A && key == "rel") {
+					t.Errorf("output of %q contains attribute %s on <%s>:\n%s", input, attr.Key, n.Data, output)
+				}
+				if key == "href" || key == "src" {
+					scheme, _, found := strings.Cut(strings.ToLower(attr.Val), ":")
+					if found && !strings.ContainsAny(scheme, "/?#") && !allowedSchemes[scheme] {
+						t.Errorf("output of %q contains URL %q:\n%s", input, attr.Val, output)
+					}
+				}
+			}
+		case html.CommentNode, html.DoctypeNode:
+			t.Errorf("output of %q contains %q:\n%s", input, n.Data, output)
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+	}
+	for _, n := range nodes {
+		walk(n)
+	}
+}
+
+func TestSanitizeXSSVectors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{"script element", `<script>alert(1)</script>`},
+		{"uppercase script element", `<SCRIPT SRC=//evil.example/x.js></SCRIPT>`},
+		{"script split by nested tags", `<scr<script>ipt>alert(1)</scr</script>ipt>`},
+		{"img onerror", `<img src=x onerror=alert(1)>`},
+		{"img onerror without quotes or spaces", `<img/src="x"/onerror=alert(1)>`},
+		{"body onload", `<body onload=alert(1)>`},
+		{"svg onload", `<svg onload=alert(1)>`},
+		{"svg script", `<svg><script>alert(1)</script></svg>`},
+		{"math href", `<math><mi xlink:href="javascript:alert(1)">x</mi></math>`},
+		{"iframe src", `<iframe src="javascript:alert(1)"></iframe>`},
+		{"iframe srcdoc", `<iframe srcdoc="<script>alert(1)</script>"></iframe>`},
+		{"object data", `<object data="javascript:alert(1)"></object>`},
+		{"embed src", `<embed src="data:text/html,<script>alert(1)</script>">`},
+		{"javascript link", `<a href="javascript:alert(1)">x</a>`},
+		{"mixed case javascript link", `<a href="JaVaScRiPt:alert(1)">x</a>`},
+		{"javascript link with tab", "<a href=\"java\tscript:alert(1)\">x</a>"},
+		{"javascript link with newline", "<a href=\"java\nscript:alert(1)\">x</a>"},
+		{"javascript link with leading space", `<a href=" javascript:alert(1)">x</a>`},
+		{"javascript link with entity", `<a href="javascript&#58;alert(1)">x</a>`},
+		{"javascript link with hex entities", `<a href="&#x6A;avascript:alert(1)">x</a>`},
+		{"vbscript link", `<a href="vbscript:msgbox(1)">x</a>`},
+		{"data link", `<a href="data:text/html;base64,PHNjcmlwdD5hbGVydCgxKTwvc2NyaXB0Pg==">x</a>`},
+		{"img javascript src", `<img src="javascript:alert(1)">`},
+		{"style attribute", `<p style="background:url(javascript:alert(1))">x</p>`},
+		{"style element", `<style>body{background:url("javascript:alert(1)")}</style>`},
+		{"event handler on allowed element", `<p onclick="alert(1)">x</p>`},
+		{"event handler on unknown element", `<details open ontoggle=alert(1)>x</details>`},
+		{"form action", `<form action="javascript:alert(1)"><input type=submit></form>`},
+		{"button formaction", `<button formaction="javascript:alert(1)">x</button>`},
+		{"meta refresh", `<meta http-equiv="refresh" content="0;url=javascript:alert(1)">`},
+		{"base href", `<base href="javascript:alert(1)//">`},
+		{"link stylesheet", `<link rel=stylesheet href="javascript:alert(1)">`},
+		{"html comment", `<!--<script>alert(1)</script>-->`},
+		{"conditional comment", `<!--[if IE]><script>alert(1)</script><![endif]-->`},
+		{"noscript breakout", `<noscript><p title="</noscript><img src=x onerror=alert(1)>">`},
+		{"template content", `<template><img src=x onerror=alert(1)></template>`},
+		{"textarea breakout", `<textarea></textarea><script>alert(1)</script>`},
+		{"attribute breakout", `<a title="&quot;><script>alert(1)</script>">x</a>`},
+		{"code class injection", `<code class="language-go onclick=alert(1)">x</code>`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := sanitize(tt.input)
+			if err != nil {
+				t.Fatalf("sanitize() error = %v", err)
+			}
+			assertSafe(t, tt.input, result.html)
+		})
+	}
+}
+
+func TestSanitizeKeepsSafeContent(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"http link", `<a href="https://example.com/a?b=1">x</a>`,
+			`<a href="https://example.com/a?b=1" rel="nofollow noopener noreferrer">x</a>`},
+		{"relative link", `<a href="/docs#intro">x</a>`,
+			`<a href="/docs#intro" rel="nofollow noopener noreferrer">x</a>`},
+		{"mailto link", `<a href="mailto:a@example.com">x</a>`,
+			`<a href="mailto:a@example.com" rel="nofollow noopener noreferrer">x</a>`},
+		{"image", `<img src="https://example.com/a.png" alt="a">`, `<img src="https://example.com/a.png" alt="a"/>`},
+		{"code language class", `<pre><code class="language-go">x</code></pre>`, `<pre><code class="language-go">x</code></pre>`},
+		{"unknown element is unwrapped", `<section><b>x</b></section>`, `<b>x</b>`},
+		{"text is escaped", `<p>1 &lt; 2 &amp;&amp; &lt;script&gt;</p>`, `<p>1 &lt; 2 &amp;&amp; &lt;script&gt;</p>`},
+		{"unsafe attribute dropped, element kept", `<p onclick="alert(1)">x</p>`, `<p>x</p>`},
+		{"unsafe link keeps text", `<a href="javascript:alert(1)">x</a>`, `<a rel="nofollow noopener noreferrer">x</a>`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := sanitize(tt.input)
+			if err != nil {
+				t.Fatalf("sanitize() error = %v", err)
+			}
+			if result.html != tt.want {
+				t.Errorf("sanitize(%q) = %q, want %q", tt.input, result.html, tt.want)
+			}
+		})
+	}
+}
+
+func TestRenderXSSVectors(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType valueobject.ContentType
+		content     string
+	}{
+		{"markdown raw script", valueobject.ContentTypeMarkdown, "# Title\n\n<script>alert(1)</script>"},
+		{"markdown raw img onerror", valueobject.ContentTypeMarkdown, "text <img src=x onerror=alert(1)> text"},
+		{"markdown javascript link", valueobject.ContentTypeMarkdown, "[click](javascript:alert(1))"},
+		{"markdown javascript image", valueobject.ContentTypeMarkdown, "![x](javascript:alert(1))"},
+		{"markdown link title breakout", valueobject.ContentTypeMarkdown, `[x](https://example.com "a\" onmouseover=\"alert(1)")`},
+		{"markdown code block", valueobject.ContentTypeMarkdown, "```html\n<script>alert(1)</script>\n```"},
+		{"markdown inline code", valueobject.ContentTypeMarkdown, "`<img src=x onerror=alert(1)>`"},
+		{"markdown heading with html", valueobject.ContentTypeMarkdown, "# <img src=x onerror=alert(1)>"},
+		{"asciidoc javascript link", valueobject.ContentTypeAsciiDoc, "= Title\n\nlink:javascript:alert(1)[click]"},
+		{"asciidoc raw script", valueobject.ContentTypeAsciiDoc, "== Section\n\n<script>alert(1)</script>"},
+		{"asciidoc javascript url macro", valueobject.ContentTypeAsciiDoc, "javascript:alert(1)[click]"},
+		{"html script", valueobject.ContentTypeHTML, "<h1>x</h1><script>alert(1)</script>"},
+		{"html svg", valueobject.ContentTypeHTML, "<svg/onload=alert(1)>"},
+		{"text with html", valueobject.ContentTypeText, "<script>alert(1)</script>\n\n<img src=x onerror=alert(1)>"},
+	}
+
+	r := NewRenderer(0)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rendered, err := r.Render(tt.contentType, tt.content)
+			if err != nil {
+				t.Fatalf("Render() error = %v", err)
+			}
+			assertSafe(t, tt.content, rendered.HTML)
+		})
+	}
+}
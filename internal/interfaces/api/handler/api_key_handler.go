@@ -0,0 +1,94 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/zeromicro/go-zero/rest/httpx"
+
+	"gozero-ddd/internal/application/command"
+	"gozero-ddd/internal/application/query"
+	"gozero-ddd/internal/interfaces"
+	"gozero-ddd/internal/interfaces/api/svc"
+	"gozero-ddd/internal/interfaces/api/types"
+)
+
+// APIKeyHandler API Key 管理处理器
+type APIKeyHandler struct {
+	svcCtx *svc.ServiceContext
+}
+
+// NewAPIKeyHandler 创建 API Key 管理处理器
+func NewAPIKeyHandler(svcCtx *svc.ServiceContext) *APIKeyHandler {
+	return &APIKeyHandler{svcCtx: svcCtx}
+}
+
+// Issue 签发 API Key
+// POST /api/v1/api-keys
+func (h *APIKeyHandler) Issue(w http.ResponseWriter, r *http.Request) {
+	var req types.IssueAPIKeyRequest
+	if err := httpx.Parse(r, &req); err != nil {
+		httpx.WriteJson(w, http.StatusBadRequest, types.NewErrorResponse(r.Context(), http.StatusBadRequest, err.Error()))
+		return
+	}
+
+	expiresAt, err := parseOptionalTime(req.ExpiresAt)
+	if err != nil {
+		httpx.WriteJson(w, http.StatusBadRequest, types.NewErrorResponse(r.Context(), http.StatusBadRequest, "invalid expires_at: "+err.Error()))
+		return
+	}
+
+	cmd := &command.IssueAPIKeyCommand{
+		Name:             req.Name,
+		Scopes:           req.Scopes,
+		KnowledgeBaseIDs: req.KnowledgeBaseIDs,
+		ExpiresAt:        expiresAt,
+	}
+
+	// 通过应用层容器访问命令处理器
+	result, err := h.svcCtx.App.Commands.IssueAPIKey.Handle(r.Context(), cmd)
+	if err != nil {
+		code := interfaces.HTTPErrorCode(err)
+		httpx.WriteJson(w, code, types.NewErrorResponse(r.Context(), code, err.Error()))
+		return
+	}
+
+	httpx.WriteJson(w, http.StatusOK, types.NewSuccessResponse(result))
+}
+
+// List 列出 API Key
+// GET /api/v1/api-keys
+func (h *APIKeyHandler) List(w http.ResponseWriter, r *http.Request) {
+	// 通过应用层容器访问查询处理器
+	result, err := h.svcCtx.App.Queries.ListAPIKeys.Handle(r.Context(), &query.ListAPIKeysQuery{})
+	if err != nil {
+		code := interfaces.HTTPErrorCode(err)
+		httpx.WriteJson(w, code, types.NewErrorResponse(r.Context(), code, err.Error()))
+		return
+	}
+
+	httpx.WriteJson(w, http.StatusOK, types.NewSuccessResponse(result))
+}
+
+// Revoke 吊销 API Key
+// DELETE /api/v1/api-keys/:key_id
+func (h *APIKeyHandler) Revoke(w http.ResponseWriter, r *http.Request) {
+	var req types.RevokeAPIKeyRequest
+	if err := httpx.Parse(r, &req); err != nil {
+		httpx.WriteJson(w, http.StatusBadRequest, types.NewErrorResponse(r.Context(), http.StatusBadRequest, err.Error()))
+		return
+	}
+
+	cmd := &command.RevokeAPIKeyCommand{
+		ID: req.ID,
+	}
+
+	// 通过应用层容器访问命令处理器
+	result, err := h.svcCtx.App.Commands.RevokeAPIKey.Handle(r.Context(), cmd)
+	if err != nil {
+		code := interfaces.HTTPErrorCode(err)
+		httpx.WriteJson(w, code, types.NewErrorResponse(r.Context(), code, err.Error()))
+		return
+	}
+
+	httpx.WriteJson(w, http.StatusOK, types.NewSuccessResponse(result))
+}
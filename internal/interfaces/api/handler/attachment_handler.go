@@ -0,0 +1,190 @@
+package handler
+
+import (
+	"errors"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"github.com/zeromicro/go-zero/rest/httpx"
+
+	"gozero-ddd/internal/application/command"
+	"gozero-ddd/internal/application/query"
+	"gozero-ddd/internal/interfaces"
+	"gozero-ddd/internal/interfaces/api/svc"
+	"gozero-ddd/internal/interfaces/api/types"
+)
+
+// attachmentFormField 上传附件时 multipart 表单中文件字段的名称
+const attachmentFormField = "file"
+
+// AttachmentHandler 附件处理器
+type AttachmentHandler struct {
+	svcCtx *svc.ServiceContext
+}
+
+// NewAttachmentHandler 创建附件处理器
+func NewAttachmentHandler(svcCtx *svc.ServiceContext) *AttachmentHandler {
+	return &AttachmentHandler{svcCtx: svcCtx}
+}
+
+// Upload 上传附件（multipart/form-data，文件字段名为 file）
+// POST /api/v1/knowledge/:id/documents/:doc_id/attachments
+// 文件内容以流的方式读取，不落临时文件
+func (h *AttachmentHandler) Upload(w http.ResponseWriter, r *http.Request) {
+	var req types.UploadAttachmentRequest
+	if err := httpx.ParsePath(r, &req); err != nil {
+		httpx.WriteJson(w, http.StatusBadRequest, types.NewErrorResponse(r.Context(), http.StatusBadRequest, err.Error()))
+		return
+	}
+
+	reader, err := r.MultipartReader()
+	if err != nil {
+		httpx.WriteJson(w, http.StatusBadRequest, types.NewErrorResponse(r.Context(), http.StatusBadRequest, err.Error()))
+		return
+	}
+
+	// 找到文件字段，其余字段忽略
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			httpx.WriteJson(w, http.StatusBadRequest, types.NewErrorResponse(r.Context(), http.StatusBadRequest, "missing form field: "+attachmentFormField))
+			return
+		}
+		if err != nil {
+			writeBodyError(w, r, err)
+			return
+		}
+		if part.FormName() != attachmentFormField || part.FileName() == "" {
+			_ = part.Close()
+			continue
+		}
+
+		cmd := &command.UploadAttachmentCommand{
+			KnowledgeBaseID: req.KnowledgeBaseID,
+			DocumentID:      req.DocumentID,
+			FileName:        part.FileName(),
+			Content:         part,
+		}
+
+		// 通过应用层容器访问命令处理器
+		result, err := h.svcCtx.App.Commands.UploadAttachment.Handle(r.Context(), cmd)
+		_ = part.Close()
+		if err != nil {
+			writeBodyError(w, r, err)
+			return
+		}
+
+		httpx.WriteJson(w, http.StatusCreated, types.NewSuccessResponse(result))
+		return
+	}
+}
+
+// List 列出文档的附件
+// GET /api/v1/knowledge/:id/documents/:doc_id/attachments
+func (h *AttachmentHandler) List(w http.ResponseWriter, r *http.Request) {
+	var req types.ListAttachmentsRequest
+	if err := httpx.Parse(r, &req); err != nil {
+		httpx.WriteJson(w, http.StatusBadRequest, types.NewErrorResponse(r.Context(), http.StatusBadRequest, err.Error()))
+		return
+	}
+
+	qry := &query.ListAttachmentsQuery{
+		KnowledgeBaseID: req.KnowledgeBaseID,
+		DocumentID:      req.DocumentID,
+	}
+
+	// 通过应用层容器访问查询处理器
+	result, err := h.svcCtx.App.Queries.ListAttachments.Handle(r.Context(), qry)
+	if err != nil {
+		code := interfaces.HTTPErrorCode(err)
+		httpx.WriteJson(w, code, types.NewErrorResponse(r.Context(), code, err.Error()))
+		return
+	}
+
+	httpx.WriteJson(w, http.StatusOK, types.NewSuccessResponse(result))
+}
+
+// Download 下载附件内容（流式输出）
+// GET /api/v1/knowledge/:id/attachments/:attachment_id
+// 内容哈希作为 ETag，支持 If-None-Match 条件请求
+func (h *AttachmentHandler) Download(w http.ResponseWriter, r *http.Request) {
+	var req types.GetAttachmentRequest
+	if err := httpx.Parse(r, &req); err != nil {
+		httpx.WriteJson(w, http.StatusBadRequest, types.NewErrorResponse(r.Context(), http.StatusBadRequest, err.Error()))
+		return
+	}
+
+	qry := &query.GetAttachmentContentQuery{
+		KnowledgeBaseID: req.KnowledgeBaseID,
+		AttachmentID:    req.AttachmentID,
+	}
+
+	// 通过应用层容器访问查询处理器
+	result, err := h.svcCtx.App.Queries.GetAttachmentContent.Handle(r.Context(), qry)
+	if err != nil {
+		code := interfaces.HTTPErrorCode(err)
+		httpx.WriteJson(w, code, types.NewErrorResponse(r.Context(), code, err.Error()))
+		return
+	}
+	defer result.Content.Close()
+
+	att := result.Attachment
+	etag := `"` + att.ContentHash + `"`
+	w.Header().Set("ETag", etag)
+	if r.Header.Get("If-None-Match") == etag {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	disposition := "inline"
+	if req.Download {
+		disposition = "attachment"
+	}
+	w.Header().Set("Content-Type", att.MediaType)
+	w.Header().Set("Content-Length", strconv.FormatInt(att.Size, 10))
+	w.Header().Set("Content-Disposition", mime.FormatMediaType(disposition, map[string]string{"filename": att.FileName}))
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(http.StatusOK)
+
+	if _, err := io.Copy(w, result.Content); err != nil {
+		log.Printf("⚠️ [Attachment] 输出附件内容失败: id=%s, err=%v", att.ID, err)
+	}
+}
+
+// Delete 删除附件
+// DELETE /api/v1/knowledge/:id/attachments/:attachment_id
+func (h *AttachmentHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	var req types.DeleteAttachmentRequest
+	if err := httpx.Parse(r, &req); err != nil {
+		httpx.WriteJson(w, http.StatusBadRequest, types.NewErrorResponse(r.Context(), http.StatusBadRequest, err.Error()))
+		return
+	}
+
+	cmd := &command.DeleteAttachmentCommand{
+		KnowledgeBaseID: req.KnowledgeBaseID,
+		AttachmentID:    req.AttachmentID,
+	}
+
+	// 通过应用层容器访问命令处理器
+	if err := h.svcCtx.App.Commands.DeleteAttachment.Handle(r.Context(), cmd); err != nil {
+		code := interfaces.HTTPErrorCode(err)
+		httpx.WriteJson(w, code, types.NewErrorResponse(r.Context(), code, err.Error()))
+		return
+	}
+
+	httpx.WriteJson(w, http.StatusOK, types.NewSuccessResponse(nil))
+}
+
+// writeBodyError 输出读取请求体过程中的错误
+// 请求体超过服务端 MaxBytes 限制时返回 413
+func writeBodyError(w http.ResponseWriter, r *http.Request, err error) {
+	code := interfaces.HTTPErrorCode(err)
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		code = http.StatusRequestEntityTooLarge
+	}
+	httpx.WriteJson(w, code, types.NewErrorResponse(r.Context(), code, err.Error()))
+}
@@ -0,0 +1,110 @@
+package handler
+
+import (
+	"log"
+	"mime"
+	"net/http"
+
+	"github.com/zeromicro/go-zero/rest/httpx"
+
+	"gozero-ddd/internal/application/query"
+	"gozero-ddd/internal/interfaces"
+	"gozero-ddd/internal/interfaces/api/svc"
+	"gozero-ddd/internal/interfaces/api/types"
+)
+
+// AuditLogHandler 审计日志处理器
+type AuditLogHandler struct {
+	svcCtx *svc.ServiceContext
+}
+
+// NewAuditLogHandler 创建审计日志处理器
+func NewAuditLogHandler(svcCtx *svc.ServiceContext) *AuditLogHandler {
+	return &AuditLogHandler{svcCtx: svcCtx}
+}
+
+// List 查询审计日志（只有管理员可以查看）
+// GET /api/v1/audit-logs?knowledge_base_id=&actor=&event_name=&since=&until=&cursor=&limit=
+func (h *AuditLogHandler) List(w http.ResponseWriter, r *http.Request) {
+	var req types.ListAuditLogsRequest
+	if err := httpx.Parse(r, &req); err != nil {
+		httpx.WriteJson(w, http.StatusBadRequest, types.NewErrorResponse(r.Context(), http.StatusBadRequest, err.Error()))
+		return
+	}
+
+	filter, ok := parseAuditLogFilter(w, r, req.AuditLogFilterRequest)
+	if !ok {
+		return
+	}
+	q := &query.ListAuditLogsQuery{
+		AuditLogFilter: filter,
+		Cursor:         req.Cursor,
+		Limit:          req.Limit,
+	}
+
+	// 通过应用层容器访问查询处理器
+	result, err := h.svcCtx.App.Queries.ListAuditLogs.Handle(r.Context(), q)
+	if err != nil {
+		code := interfaces.HTTPErrorCode(err)
+		httpx.WriteJson(w, code, types.NewErrorResponse(r.Context(), code, err.Error()))
+		return
+	}
+
+	httpx.WriteJson(w, http.StatusOK, types.NewSuccessResponse(result))
+}
+
+// Export 导出审计日志为 CSV（只有管理员可以导出）
+// GET /api/v1/audit-logs/export?knowledge_base_id=&actor=&event_name=&since=&until=
+// 内容以附件形式流式下载
+func (h *AuditLogHandler) Export(w http.ResponseWriter, r *http.Request) {
+	var req types.AuditLogFilterRequest
+	if err := httpx.Parse(r, &req); err != nil {
+		httpx.WriteJson(w, http.StatusBadRequest, types.NewErrorResponse(r.Context(), http.StatusBadRequest, err.Error()))
+		return
+	}
+
+	filter, ok := parseAuditLogFilter(w, r, req)
+	if !ok {
+		return
+	}
+
+	// 通过应用层容器访问查询处理器
+	export, err := h.svcCtx.App.Queries.ExportAuditLogs.Handle(r.Context(), &query.ExportAuditLogsQuery{AuditLogFilter: filter})
+	if err != nil {
+		code := interfaces.HTTPErrorCode(err)
+		httpx.WriteJson(w, code, types.NewErrorResponse(r.Context(), code, err.Error()))
+		return
+	}
+
+	w.Header().Set("Content-Type", export.MediaType)
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": export.FileName}))
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(http.StatusOK)
+
+	// 响应头已写出，此时的错误只能记录日志，客户端会收到不完整的文件
+	if err := export.Write(r.Context(), w); err != nil {
+		log.Printf("[Export] 导出审计日志失败: %v", err)
+	}
+}
+
+// parseAuditLogFilter 解析审计日志过滤条件，时间格式错误时写出 400 响应并返回 false
+func parseAuditLogFilter(w http.ResponseWriter, r *http.Request, req types.AuditLogFilterRequest) (query.AuditLogFilter, bool) {
+	since, err := parseOptionalTime(req.Since)
+	if err != nil {
+		httpx.WriteJson(w, http.StatusBadRequest, types.NewErrorResponse(r.Context(), http.StatusBadRequest, "invalid since: "+err.Error()))
+		return query.AuditLogFilter{}, false
+	}
+	until, err := parseOptionalTime(req.Until)
+	if err != nil {
+		httpx.WriteJson(w, http.StatusBadRequest, types.NewErrorResponse(r.Context(), http.StatusBadRequest, "invalid until: "+err.Error()))
+		return query.AuditLogFilter{}, false
+	}
+
+	return query.AuditLogFilter{
+		KnowledgeBaseID: req.KnowledgeBaseID,
+		Actor:           req.Actor,
+		EventName:       req.EventName,
+		Since:           since,
+		Until:           until,
+	}, true
+}
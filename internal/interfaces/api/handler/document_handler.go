@@ -2,6 +2,7 @@ package handler
 
 import (
 	"net/http"
+	"time"
 
 	"github.com/zeromicro/go-zero/rest/httpx"
 
@@ -26,7 +27,7 @@ func NewDocumentHandler(svcCtx *svc.ServiceContext) *DocumentHandler {
 func (h *DocumentHandler) Add(w http.ResponseWriter, r *http.Request) {
 	var req types.AddDocumentRequest
 	if err := httpx.Parse(r, &req); err != nil {
-		httpx.WriteJson(w, http.StatusBadRequest, types.NewErrorResponse(http.StatusBadRequest, err.Error()))
+		httpx.WriteJson(w, http.StatusBadRequest, types.NewErrorResponse(r.Context(), http.StatusBadRequest, err.Error()))
 		return
 	}
 
@@ -34,6 +35,7 @@ func (h *DocumentHandler) Add(w http.ResponseWriter, r *http.Request) {
 		KnowledgeBaseID: req.KnowledgeBaseID,
 		Title:           req.Title,
 		Content:         req.Content,
+		ContentType:     req.ContentType,
 		Tags:            req.Tags,
 	}
 
@@ -41,30 +43,174 @@ func (h *DocumentHandler) Add(w http.ResponseWriter, r *http.Request) {
 	result, err := h.svcCtx.App.Commands.AddDocument.Handle(r.Context(), cmd)
 	if err != nil {
 		code := interfaces.HTTPErrorCode(err)
-		httpx.WriteJson(w, code, types.NewErrorResponse(code, err.Error()))
+		httpx.WriteJson(w, code, types.NewErrorResponse(r.Context(), code, err.Error()))
 		return
 	}
 
 	httpx.WriteJson(w, http.StatusCreated, types.NewSuccessResponse(result))
 }
 
+// Batch 批量添加、更新、删除文档
+// POST /api/v1/knowledge/:id/documents/batch
+// 所有操作在一个事务中执行，返回逐个操作的结果
+func (h *DocumentHandler) Batch(w http.ResponseWriter, r *http.Request) {
+	var req types.BatchDocumentsRequest
+	if err := httpx.Parse(r, &req); err != nil {
+		httpx.WriteJson(w, http.StatusBadRequest, types.NewErrorResponse(r.Context(), http.StatusBadRequest, err.Error()))
+		return
+	}
+
+	cmd := &command.BatchDocumentsCommand{
+		KnowledgeBaseID: req.KnowledgeBaseID,
+		Mode:            req.Mode,
+		Operations:      make([]*command.BatchOperation, 0, len(req.Operations)),
+	}
+	for _, op := range req.Operations {
+		cmd.Operations = append(cmd.Operations, &command.BatchOperation{
+			Op:          op.Op,
+			DocumentID:  op.DocumentID,
+			Title:       op.Title,
+			Content:     op.Content,
+			ContentType: op.ContentType,
+			Tags:        op.Tags,
+		})
+	}
+
+	// 通过应用层容器访问命令处理器
+	result, err := h.svcCtx.App.Commands.BatchDocuments.Handle(r.Context(), cmd)
+	if err != nil {
+		code := interfaces.HTTPErrorCode(err)
+		httpx.WriteJson(w, code, types.NewErrorResponse(r.Context(), code, err.Error()))
+		return
+	}
+
+	httpx.WriteJson(w, http.StatusOK, types.NewSuccessResponse(result))
+}
+
 // List 列出文档
 func (h *DocumentHandler) List(w http.ResponseWriter, r *http.Request) {
 	var req types.ListDocumentsRequest
 	if err := httpx.Parse(r, &req); err != nil {
-		httpx.WriteJson(w, http.StatusBadRequest, types.NewErrorResponse(http.StatusBadRequest, err.Error()))
+		httpx.WriteJson(w, http.StatusBadRequest, types.NewErrorResponse(r.Context(), http.StatusBadRequest, err.Error()))
 		return
 	}
 
 	qry := &query.ListDocumentsQuery{
 		KnowledgeBaseID: req.KnowledgeBaseID,
+		Status:          req.Status,
+		IncludeDrafts:   req.IncludeDrafts,
+		TagQuery:        req.TagQuery,
+		Format:          req.Format,
 	}
 
 	// 通过应用层容器访问查询处理器
 	result, err := h.svcCtx.App.Queries.ListDocuments.Handle(r.Context(), qry)
 	if err != nil {
 		code := interfaces.HTTPErrorCode(err)
-		httpx.WriteJson(w, code, types.NewErrorResponse(code, err.Error()))
+		httpx.WriteJson(w, code, types.NewErrorResponse(r.Context(), code, err.Error()))
+		return
+	}
+
+	httpx.WriteJson(w, http.StatusOK, types.NewSuccessResponse(result))
+}
+
+// Get 获取单个文档
+// 通过 ?format=html|text|raw 指定内容输出格式，返回结果附带目录
+func (h *DocumentHandler) Get(w http.ResponseWriter, r *http.Request) {
+	var req types.GetDocumentRequest
+	if err := httpx.Parse(r, &req); err != nil {
+		httpx.WriteJson(w, http.StatusBadRequest, types.NewErrorResponse(r.Context(), http.StatusBadRequest, err.Error()))
+		return
+	}
+
+	qry := &query.GetDocumentQuery{
+		KnowledgeBaseID: req.KnowledgeBaseID,
+		DocumentID:      req.DocumentID,
+		Format:          req.Format,
+		IncludeDrafts:   req.IncludeDrafts,
+	}
+
+	// 通过应用层容器访问查询处理器
+	result, err := h.svcCtx.App.Queries.GetDocument.Handle(r.Context(), qry)
+	if err != nil {
+		code := interfaces.HTTPErrorCode(err)
+		httpx.WriteJson(w, code, types.NewErrorResponse(r.Context(), code, err.Error()))
+		return
+	}
+
+	httpx.WriteJson(w, http.StatusOK, types.NewSuccessResponse(result))
+}
+
+// Update 更新文档
+// 更新后会重新解析文档内容中的链接
+func (h *DocumentHandler) Update(w http.ResponseWriter, r *http.Request) {
+	var req types.UpdateDocumentRequest
+	if err := httpx.Parse(r, &req); err != nil {
+		httpx.WriteJson(w, http.StatusBadRequest, types.NewErrorResponse(r.Context(), http.StatusBadRequest, err.Error()))
+		return
+	}
+
+	cmd := &command.UpdateDocumentCommand{
+		KnowledgeBaseID: req.KnowledgeBaseID,
+		DocumentID:      req.DocumentID,
+		Title:           req.Title,
+		Content:         req.Content,
+		ContentType:     req.ContentType,
+		Tags:            req.Tags,
+	}
+
+	// 通过应用层容器访问命令处理器
+	result, err := h.svcCtx.App.Commands.UpdateDocument.Handle(r.Context(), cmd)
+	if err != nil {
+		code := interfaces.HTTPErrorCode(err)
+		httpx.WriteJson(w, code, types.NewErrorResponse(r.Context(), code, err.Error()))
+		return
+	}
+
+	httpx.WriteJson(w, http.StatusOK, types.NewSuccessResponse(result))
+}
+
+// Links 获取文档的出链和反向链接
+func (h *DocumentHandler) Links(w http.ResponseWriter, r *http.Request) {
+	var req types.GetDocumentLinksRequest
+	if err := httpx.Parse(r, &req); err != nil {
+		httpx.WriteJson(w, http.StatusBadRequest, types.NewErrorResponse(r.Context(), http.StatusBadRequest, err.Error()))
+		return
+	}
+
+	qry := &query.GetDocumentLinksQuery{
+		KnowledgeBaseID: req.KnowledgeBaseID,
+		DocumentID:      req.DocumentID,
+	}
+
+	// 通过应用层容器访问查询处理器
+	result, err := h.svcCtx.App.Queries.GetDocumentLinks.Handle(r.Context(), qry)
+	if err != nil {
+		code := interfaces.HTTPErrorCode(err)
+		httpx.WriteJson(w, code, types.NewErrorResponse(r.Context(), code, err.Error()))
+		return
+	}
+
+	httpx.WriteJson(w, http.StatusOK, types.NewSuccessResponse(result))
+}
+
+// BrokenLinks 列出知识库中的失效链接
+func (h *DocumentHandler) BrokenLinks(w http.ResponseWriter, r *http.Request) {
+	var req types.ListBrokenLinksRequest
+	if err := httpx.Parse(r, &req); err != nil {
+		httpx.WriteJson(w, http.StatusBadRequest, types.NewErrorResponse(r.Context(), http.StatusBadRequest, err.Error()))
+		return
+	}
+
+	qry := &query.ListBrokenLinksQuery{
+		KnowledgeBaseID: req.KnowledgeBaseID,
+	}
+
+	// 通过应用层容器访问查询处理器
+	result, err := h.svcCtx.App.Queries.ListBrokenLinks.Handle(r.Context(), qry)
+	if err != nil {
+		code := interfaces.HTTPErrorCode(err)
+		httpx.WriteJson(w, code, types.NewErrorResponse(r.Context(), code, err.Error()))
 		return
 	}
 
@@ -75,7 +221,7 @@ func (h *DocumentHandler) List(w http.ResponseWriter, r *http.Request) {
 func (h *DocumentHandler) Remove(w http.ResponseWriter, r *http.Request) {
 	var req types.RemoveDocumentRequest
 	if err := httpx.Parse(r, &req); err != nil {
-		httpx.WriteJson(w, http.StatusBadRequest, types.NewErrorResponse(http.StatusBadRequest, err.Error()))
+		httpx.WriteJson(w, http.StatusBadRequest, types.NewErrorResponse(r.Context(), http.StatusBadRequest, err.Error()))
 		return
 	}
 
@@ -87,9 +233,89 @@ func (h *DocumentHandler) Remove(w http.ResponseWriter, r *http.Request) {
 	// 通过应用层容器访问命令处理器
 	if err := h.svcCtx.App.Commands.RemoveDocument.Handle(r.Context(), cmd); err != nil {
 		code := interfaces.HTTPErrorCode(err)
-		httpx.WriteJson(w, code, types.NewErrorResponse(code, err.Error()))
+		httpx.WriteJson(w, code, types.NewErrorResponse(r.Context(), code, err.Error()))
 		return
 	}
 
 	httpx.WriteJson(w, http.StatusOK, types.NewSuccessResponse(nil))
 }
+
+// Workflow 返回执行指定发布流程动作的处理函数
+// POST /api/v1/knowledge/:id/documents/:doc_id/{submit,approve,reject,publish,unpublish}
+func (h *DocumentHandler) Workflow(action string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req types.DocumentWorkflowRequest
+		if err := httpx.Parse(r, &req); err != nil {
+			httpx.WriteJson(w, http.StatusBadRequest, types.NewErrorResponse(r.Context(), http.StatusBadRequest, err.Error()))
+			return
+		}
+
+		cmd := &command.DocumentWorkflowCommand{
+			KnowledgeBaseID: req.KnowledgeBaseID,
+			DocumentID:      req.DocumentID,
+			Action:          action,
+			Reviewer:        req.Reviewer,
+			Comment:         req.Comment,
+		}
+
+		// 通过应用层容器访问命令处理器
+		result, err := h.svcCtx.App.Commands.DocumentWorkflow.Handle(r.Context(), cmd)
+		if err != nil {
+			code := interfaces.HTTPErrorCode(err)
+			httpx.WriteJson(w, code, types.NewErrorResponse(r.Context(), code, err.Error()))
+			return
+		}
+
+		httpx.WriteJson(w, http.StatusOK, types.NewSuccessResponse(result))
+	}
+}
+
+// Schedule 设置文档定时发布/下线时间
+// PUT /api/v1/knowledge/:id/documents/:doc_id/schedule
+func (h *DocumentHandler) Schedule(w http.ResponseWriter, r *http.Request) {
+	var req types.ScheduleDocumentRequest
+	if err := httpx.Parse(r, &req); err != nil {
+		httpx.WriteJson(w, http.StatusBadRequest, types.NewErrorResponse(r.Context(), http.StatusBadRequest, err.Error()))
+		return
+	}
+
+	publishAt, err := parseOptionalTime(req.PublishAt)
+	if err != nil {
+		httpx.WriteJson(w, http.StatusBadRequest, types.NewErrorResponse(r.Context(), http.StatusBadRequest, "invalid publish_at: "+err.Error()))
+		return
+	}
+	expireAt, err := parseOptionalTime(req.ExpireAt)
+	if err != nil {
+		httpx.WriteJson(w, http.StatusBadRequest, types.NewErrorResponse(r.Context(), http.StatusBadRequest, "invalid expire_at: "+err.Error()))
+		return
+	}
+
+	cmd := &command.ScheduleDocumentCommand{
+		KnowledgeBaseID: req.KnowledgeBaseID,
+		DocumentID:      req.DocumentID,
+		PublishAt:       publishAt,
+		ExpireAt:        expireAt,
+	}
+
+	// 通过应用层容器访问命令处理器
+	result, err := h.svcCtx.App.Commands.ScheduleDocument.Handle(r.Context(), cmd)
+	if err != nil {
+		code := interfaces.HTTPErrorCode(err)
+		httpx.WriteJson(w, code, types.NewErrorResponse(r.Context(), code, err.Error()))
+		return
+	}
+
+	httpx.WriteJson(w, http.StatusOK, types.NewSuccessResponse(result))
+}
+
+// parseOptionalTime 解析 RFC3339 格式的时间，空字符串返回 nil
+func parseOptionalTime(s string) (*time.Time, error) {
+	if s == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
@@ -0,0 +1,90 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/zeromicro/go-zero/rest/httpx"
+
+	"gozero-ddd/internal/application/command"
+	"gozero-ddd/internal/application/query"
+	"gozero-ddd/internal/interfaces"
+	"gozero-ddd/internal/interfaces/api/svc"
+	"gozero-ddd/internal/interfaces/api/types"
+)
+
+// DuplicateHandler 重复文档检测处理器
+type DuplicateHandler struct {
+	svcCtx *svc.ServiceContext
+}
+
+// NewDuplicateHandler 创建重复文档检测处理器
+func NewDuplicateHandler(svcCtx *svc.ServiceContext) *DuplicateHandler {
+	return &DuplicateHandler{svcCtx: svcCtx}
+}
+
+// List 列出知识库中的重复文档
+// GET /api/v1/knowledge/:id/duplicates?cross=true&max_distance=8
+func (h *DuplicateHandler) List(w http.ResponseWriter, r *http.Request) {
+	var req types.ListDuplicatesRequest
+	if err := httpx.Parse(r, &req); err != nil {
+		httpx.WriteJson(w, http.StatusBadRequest, types.NewErrorResponse(r.Context(), http.StatusBadRequest, err.Error()))
+		return
+	}
+
+	h.list(w, r, &query.ListDuplicatesQuery{
+		KnowledgeBaseID:    req.KnowledgeBaseID,
+		CrossKnowledgeBase: req.CrossKnowledgeBase,
+		MaxDistance:        req.MaxDistance,
+	})
+}
+
+// ListAll 列出所有知识库中的重复文档（包括跨知识库的重复）
+// GET /api/v1/duplicates?max_distance=8
+func (h *DuplicateHandler) ListAll(w http.ResponseWriter, r *http.Request) {
+	var req types.ListAllDuplicatesRequest
+	if err := httpx.Parse(r, &req); err != nil {
+		httpx.WriteJson(w, http.StatusBadRequest, types.NewErrorResponse(r.Context(), http.StatusBadRequest, err.Error()))
+		return
+	}
+
+	h.list(w, r, &query.ListDuplicatesQuery{
+		MaxDistance: req.MaxDistance,
+	})
+}
+
+// list 执行重复文档查询并写出结果
+func (h *DuplicateHandler) list(w http.ResponseWriter, r *http.Request, qry *query.ListDuplicatesQuery) {
+	// 通过应用层容器访问查询处理器
+	result, err := h.svcCtx.App.Queries.ListDuplicates.Handle(r.Context(), qry)
+	if err != nil {
+		code := interfaces.HTTPErrorCode(err)
+		httpx.WriteJson(w, code, types.NewErrorResponse(r.Context(), code, err.Error()))
+		return
+	}
+
+	httpx.WriteJson(w, http.StatusOK, types.NewSuccessResponse(result))
+}
+
+// Rebuild 重建文档内容指纹
+// POST /api/v1/duplicates/rebuild
+func (h *DuplicateHandler) Rebuild(w http.ResponseWriter, r *http.Request) {
+	var req types.RebuildFingerprintsRequest
+	if err := httpx.Parse(r, &req); err != nil {
+		httpx.WriteJson(w, http.StatusBadRequest, types.NewErrorResponse(r.Context(), http.StatusBadRequest, err.Error()))
+		return
+	}
+
+	cmd := &command.RebuildFingerprintsCommand{
+		KnowledgeBaseID: req.KnowledgeBaseID,
+	}
+
+	// 通过应用层容器访问命令处理器
+	result, err := h.svcCtx.App.Commands.RebuildFingerprints.Handle(r.Context(), cmd)
+	if err != nil {
+		code := interfaces.HTTPErrorCode(err)
+		httpx.WriteJson(w, code, types.NewErrorResponse(r.Context(), code, err.Error()))
+		return
+	}
+
+	httpx.WriteJson(w, http.StatusOK, types.NewSuccessResponse(result))
+}
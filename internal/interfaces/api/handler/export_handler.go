@@ -0,0 +1,98 @@
+package handler
+
+import (
+	"errors"
+	"log"
+	"mime"
+	"net/http"
+
+	"github.com/zeromicro/go-zero/rest/httpx"
+
+	"gozero-ddd/internal/application/command"
+	"gozero-ddd/internal/application/query"
+	"gozero-ddd/internal/interfaces"
+	"gozero-ddd/internal/interfaces/api/svc"
+	"gozero-ddd/internal/interfaces/api/types"
+)
+
+// restoreFormField 恢复时 multipart 表单中备份包字段的名称
+const restoreFormField = "file"
+
+// ExportHandler 导出与备份恢复处理器
+type ExportHandler struct {
+	svcCtx *svc.ServiceContext
+}
+
+// NewExportHandler 创建导出与备份恢复处理器
+func NewExportHandler(svcCtx *svc.ServiceContext) *ExportHandler {
+	return &ExportHandler{svcCtx: svcCtx}
+}
+
+// Export 导出知识库
+// GET /api/v1/knowledge/:id/export?format=markdown|jsonl|backup
+// 内容以附件形式流式下载
+func (h *ExportHandler) Export(w http.ResponseWriter, r *http.Request) {
+	var req types.ExportKnowledgeBaseRequest
+	if err := httpx.Parse(r, &req); err != nil {
+		httpx.WriteJson(w, http.StatusBadRequest, types.NewErrorResponse(r.Context(), http.StatusBadRequest, err.Error()))
+		return
+	}
+
+	q := &query.ExportKnowledgeBaseQuery{
+		KnowledgeBaseID: req.KnowledgeBaseID,
+		Format:          req.Format,
+	}
+
+	// 通过应用层容器访问查询处理器
+	export, err := h.svcCtx.App.Queries.ExportKnowledgeBase.Handle(r.Context(), q)
+	if err != nil {
+		code := interfaces.HTTPErrorCode(err)
+		httpx.WriteJson(w, code, types.NewErrorResponse(r.Context(), code, err.Error()))
+		return
+	}
+
+	w.Header().Set("Content-Type", export.MediaType)
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": export.FileName}))
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(http.StatusOK)
+
+	// 响应头已写出，此时的错误只能记录日志，客户端会收到不完整的文件
+	if err := export.Write(r.Context(), w); err != nil {
+		log.Printf("[Export] 导出知识库 %s 失败: %v", req.KnowledgeBaseID, err)
+	}
+}
+
+// Restore 从备份包恢复知识库（multipart/form-data，备份包字段名为 file）
+// POST /api/v1/knowledge/restore
+func (h *ExportHandler) Restore(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseMultipartForm(32 << 20); err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			writeBodyError(w, r, err)
+			return
+		}
+		httpx.WriteJson(w, http.StatusBadRequest, types.NewErrorResponse(r.Context(), http.StatusBadRequest, err.Error()))
+		return
+	}
+
+	file, header, err := r.FormFile(restoreFormField)
+	if err != nil {
+		httpx.WriteJson(w, http.StatusBadRequest, types.NewErrorResponse(r.Context(), http.StatusBadRequest, "missing form field: "+restoreFormField))
+		return
+	}
+	defer file.Close()
+
+	cmd := &command.RestoreBackupCommand{
+		Archive: file,
+		Size:    header.Size,
+	}
+
+	// 通过应用层容器访问命令处理器
+	result, err := h.svcCtx.App.Commands.RestoreBackup.Handle(r.Context(), cmd)
+	if err != nil {
+		writeBodyError(w, r, err)
+		return
+	}
+
+	httpx.WriteJson(w, http.StatusCreated, types.NewSuccessResponse(result))
+}
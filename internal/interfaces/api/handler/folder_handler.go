@@ -0,0 +1,176 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/zeromicro/go-zero/rest/httpx"
+
+	"gozero-ddd/internal/application/command"
+	"gozero-ddd/internal/application/query"
+	"gozero-ddd/internal/interfaces"
+	"gozero-ddd/internal/interfaces/api/svc"
+	"gozero-ddd/internal/interfaces/api/types"
+)
+
+// FolderHandler 文件夹处理器
+type FolderHandler struct {
+	svcCtx *svc.ServiceContext
+}
+
+// NewFolderHandler 创建文件夹处理器
+func NewFolderHandler(svcCtx *svc.ServiceContext) *FolderHandler {
+	return &FolderHandler{svcCtx: svcCtx}
+}
+
+// Tree 获取知识库文件夹树
+// GET /api/v1/knowledge/:id/folders
+func (h *FolderHandler) Tree(w http.ResponseWriter, r *http.Request) {
+	var req types.GetFolderTreeRequest
+	if err := httpx.Parse(r, &req); err != nil {
+		httpx.WriteJson(w, http.StatusBadRequest, types.NewErrorResponse(r.Context(), http.StatusBadRequest, err.Error()))
+		return
+	}
+
+	qry := &query.GetFolderTreeQuery{
+		KnowledgeBaseID: req.KnowledgeBaseID,
+		IncludeDrafts:   req.IncludeDrafts,
+	}
+
+	// 通过应用层容器访问查询处理器
+	result, err := h.svcCtx.App.Queries.GetFolderTree.Handle(r.Context(), qry)
+	if err != nil {
+		code := interfaces.HTTPErrorCode(err)
+		httpx.WriteJson(w, code, types.NewErrorResponse(r.Context(), code, err.Error()))
+		return
+	}
+
+	httpx.WriteJson(w, http.StatusOK, types.NewSuccessResponse(result))
+}
+
+// Create 创建文件夹
+// POST /api/v1/knowledge/:id/folders
+func (h *FolderHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var req types.CreateFolderRequest
+	if err := httpx.Parse(r, &req); err != nil {
+		httpx.WriteJson(w, http.StatusBadRequest, types.NewErrorResponse(r.Context(), http.StatusBadRequest, err.Error()))
+		return
+	}
+
+	cmd := &command.CreateFolderCommand{
+		KnowledgeBaseID: req.KnowledgeBaseID,
+		ParentID:        req.ParentID,
+		Name:            req.Name,
+	}
+
+	// 通过应用层容器访问命令处理器
+	result, err := h.svcCtx.App.Commands.CreateFolder.Handle(r.Context(), cmd)
+	if err != nil {
+		code := interfaces.HTTPErrorCode(err)
+		httpx.WriteJson(w, code, types.NewErrorResponse(r.Context(), code, err.Error()))
+		return
+	}
+
+	httpx.WriteJson(w, http.StatusCreated, types.NewSuccessResponse(result))
+}
+
+// Rename 重命名文件夹
+// PUT /api/v1/knowledge/:id/folders/:folder_id
+func (h *FolderHandler) Rename(w http.ResponseWriter, r *http.Request) {
+	var req types.RenameFolderRequest
+	if err := httpx.Parse(r, &req); err != nil {
+		httpx.WriteJson(w, http.StatusBadRequest, types.NewErrorResponse(r.Context(), http.StatusBadRequest, err.Error()))
+		return
+	}
+
+	cmd := &command.RenameFolderCommand{
+		KnowledgeBaseID: req.KnowledgeBaseID,
+		FolderID:        req.FolderID,
+		Name:            req.Name,
+	}
+
+	// 通过应用层容器访问命令处理器
+	result, err := h.svcCtx.App.Commands.RenameFolder.Handle(r.Context(), cmd)
+	if err != nil {
+		code := interfaces.HTTPErrorCode(err)
+		httpx.WriteJson(w, code, types.NewErrorResponse(r.Context(), code, err.Error()))
+		return
+	}
+
+	httpx.WriteJson(w, http.StatusOK, types.NewSuccessResponse(result))
+}
+
+// Move 移动文件夹
+// PUT /api/v1/knowledge/:id/folders/:folder_id/move
+func (h *FolderHandler) Move(w http.ResponseWriter, r *http.Request) {
+	var req types.MoveFolderRequest
+	if err := httpx.Parse(r, &req); err != nil {
+		httpx.WriteJson(w, http.StatusBadRequest, types.NewErrorResponse(r.Context(), http.StatusBadRequest, err.Error()))
+		return
+	}
+
+	cmd := &command.MoveFolderCommand{
+		KnowledgeBaseID: req.KnowledgeBaseID,
+		FolderID:        req.FolderID,
+		ParentID:        req.ParentID,
+	}
+
+	// 通过应用层容器访问命令处理器
+	result, err := h.svcCtx.App.Commands.MoveFolder.Handle(r.Context(), cmd)
+	if err != nil {
+		code := interfaces.HTTPErrorCode(err)
+		httpx.WriteJson(w, code, types.NewErrorResponse(r.Context(), code, err.Error()))
+		return
+	}
+
+	httpx.WriteJson(w, http.StatusOK, types.NewSuccessResponse(result))
+}
+
+// Delete 删除文件夹（其中的子文件夹和文档移动到父文件夹）
+// DELETE /api/v1/knowledge/:id/folders/:folder_id
+func (h *FolderHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	var req types.DeleteFolderRequest
+	if err := httpx.Parse(r, &req); err != nil {
+		httpx.WriteJson(w, http.StatusBadRequest, types.NewErrorResponse(r.Context(), http.StatusBadRequest, err.Error()))
+		return
+	}
+
+	cmd := &command.DeleteFolderCommand{
+		KnowledgeBaseID: req.KnowledgeBaseID,
+		FolderID:        req.FolderID,
+	}
+
+	// 通过应用层容器访问命令处理器
+	if err := h.svcCtx.App.Commands.DeleteFolder.Handle(r.Context(), cmd); err != nil {
+		code := interfaces.HTTPErrorCode(err)
+		httpx.WriteJson(w, code, types.NewErrorResponse(r.Context(), code, err.Error()))
+		return
+	}
+
+	httpx.WriteJson(w, http.StatusOK, types.NewSuccessResponse(nil))
+}
+
+// MoveDocument 移动文档到文件夹
+// PUT /api/v1/knowledge/:id/documents/:doc_id/folder
+func (h *FolderHandler) MoveDocument(w http.ResponseWriter, r *http.Request) {
+	var req types.MoveDocumentRequest
+	if err := httpx.Parse(r, &req); err != nil {
+		httpx.WriteJson(w, http.StatusBadRequest, types.NewErrorResponse(r.Context(), http.StatusBadRequest, err.Error()))
+		return
+	}
+
+	cmd := &command.MoveDocumentCommand{
+		KnowledgeBaseID: req.KnowledgeBaseID,
+		DocumentID:      req.DocumentID,
+		FolderID:        req.FolderID,
+	}
+
+	// 通过应用层容器访问命令处理器
+	result, err := h.svcCtx.App.Commands.MoveDocument.Handle(r.Context(), cmd)
+	if err != nil {
+		code := interfaces.HTTPErrorCode(err)
+		httpx.WriteJson(w, code, types.NewErrorResponse(r.Context(), code, err.Error()))
+		return
+	}
+
+	httpx.WriteJson(w, http.StatusOK, types.NewSuccessResponse(result))
+}
@@ -0,0 +1,59 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/zeromicro/go-zero/rest/httpx"
+
+	"gozero-ddd/internal/application/command"
+	"gozero-ddd/internal/interfaces/api/svc"
+	"gozero-ddd/internal/interfaces/api/types"
+)
+
+// importFormField 导入时 multipart 表单中压缩包字段的名称
+const importFormField = "file"
+
+// ImportHandler 批量导入处理器
+type ImportHandler struct {
+	svcCtx *svc.ServiceContext
+}
+
+// NewImportHandler 创建批量导入处理器
+func NewImportHandler(svcCtx *svc.ServiceContext) *ImportHandler {
+	return &ImportHandler{svcCtx: svcCtx}
+}
+
+// Import 导入 Markdown 压缩包（multipart/form-data，压缩包字段名为 file）
+// POST /api/v1/knowledge/:id/import
+// 返回逐个文件的导入报告；单个文件失败不影响整体请求的状态码
+func (h *ImportHandler) Import(w http.ResponseWriter, r *http.Request) {
+	var req types.ImportDocumentsRequest
+	if err := httpx.Parse(r, &req); err != nil {
+		writeBodyError(w, r, err)
+		return
+	}
+
+	file, header, err := r.FormFile(importFormField)
+	if err != nil {
+		httpx.WriteJson(w, http.StatusBadRequest, types.NewErrorResponse(r.Context(), http.StatusBadRequest, "missing form field: "+importFormField))
+		return
+	}
+	defer file.Close()
+
+	cmd := &command.ImportDocumentsCommand{
+		KnowledgeBaseID: req.KnowledgeBaseID,
+		Archive:         file,
+		Size:            header.Size,
+		DirectoryMode:   req.DirectoryMode,
+		BatchSize:       req.BatchSize,
+	}
+
+	// 通过应用层容器访问命令处理器
+	result, err := h.svcCtx.App.Commands.ImportDocuments.Handle(r.Context(), cmd)
+	if err != nil {
+		writeBodyError(w, r, err)
+		return
+	}
+
+	httpx.WriteJson(w, http.StatusOK, types.NewSuccessResponse(result))
+}
@@ -26,7 +26,7 @@ func NewKnowledgeBaseHandler(svcCtx *svc.ServiceContext) *KnowledgeBaseHandler {
 func (h *KnowledgeBaseHandler) Create(w http.ResponseWriter, r *http.Request) {
 	var req types.CreateKnowledgeBaseRequest
 	if err := httpx.Parse(r, &req); err != nil {
-		httpx.WriteJson(w, http.StatusBadRequest, types.NewErrorResponse(http.StatusBadRequest, err.Error()))
+		httpx.WriteJson(w, http.StatusBadRequest, types.NewErrorResponse(r.Context(), http.StatusBadRequest, err.Error()))
 		return
 	}
 
@@ -39,7 +39,7 @@ func (h *KnowledgeBaseHandler) Create(w http.ResponseWriter, r *http.Request) {
 	result, err := h.svcCtx.App.Commands.CreateKnowledgeBase.Handle(r.Context(), cmd)
 	if err != nil {
 		code := interfaces.HTTPErrorCode(err)
-		httpx.WriteJson(w, code, types.NewErrorResponse(code, err.Error()))
+		httpx.WriteJson(w, code, types.NewErrorResponse(r.Context(), code, err.Error()))
 		return
 	}
 
@@ -50,21 +50,22 @@ func (h *KnowledgeBaseHandler) Create(w http.ResponseWriter, r *http.Request) {
 func (h *KnowledgeBaseHandler) Update(w http.ResponseWriter, r *http.Request) {
 	var req types.UpdateKnowledgeBaseRequest
 	if err := httpx.Parse(r, &req); err != nil {
-		httpx.WriteJson(w, http.StatusBadRequest, types.NewErrorResponse(http.StatusBadRequest, err.Error()))
+		httpx.WriteJson(w, http.StatusBadRequest, types.NewErrorResponse(r.Context(), http.StatusBadRequest, err.Error()))
 		return
 	}
 
 	cmd := &command.UpdateKnowledgeBaseCommand{
-		ID:          req.ID,
-		Name:        req.Name,
-		Description: req.Description,
+		ID:              req.ID,
+		Name:            req.Name,
+		Description:     req.Description,
+		DuplicatePolicy: req.DuplicatePolicy,
 	}
 
 	// 通过应用层容器访问命令处理器
 	result, err := h.svcCtx.App.Commands.UpdateKnowledgeBase.Handle(r.Context(), cmd)
 	if err != nil {
 		code := interfaces.HTTPErrorCode(err)
-		httpx.WriteJson(w, code, types.NewErrorResponse(code, err.Error()))
+		httpx.WriteJson(w, code, types.NewErrorResponse(r.Context(), code, err.Error()))
 		return
 	}
 
@@ -75,20 +76,22 @@ func (h *KnowledgeBaseHandler) Update(w http.ResponseWriter, r *http.Request) {
 func (h *KnowledgeBaseHandler) Get(w http.ResponseWriter, r *http.Request) {
 	var req types.GetKnowledgeBaseRequest
 	if err := httpx.Parse(r, &req); err != nil {
-		httpx.WriteJson(w, http.StatusBadRequest, types.NewErrorResponse(http.StatusBadRequest, err.Error()))
+		httpx.WriteJson(w, http.StatusBadRequest, types.NewErrorResponse(r.Context(), http.StatusBadRequest, err.Error()))
 		return
 	}
 
 	qry := &query.GetKnowledgeBaseQuery{
 		ID:               req.ID,
 		IncludeDocuments: req.IncludeDocuments,
+		IncludeDrafts:    req.IncludeDrafts,
+		TagQuery:         req.TagQuery,
 	}
 
 	// 通过应用层容器访问查询处理器
 	result, err := h.svcCtx.App.Queries.GetKnowledgeBase.Handle(r.Context(), qry)
 	if err != nil {
 		code := interfaces.HTTPErrorCode(err)
-		httpx.WriteJson(w, code, types.NewErrorResponse(code, err.Error()))
+		httpx.WriteJson(w, code, types.NewErrorResponse(r.Context(), code, err.Error()))
 		return
 	}
 
@@ -97,13 +100,46 @@ func (h *KnowledgeBaseHandler) Get(w http.ResponseWriter, r *http.Request) {
 
 // List 列出所有知识库
 func (h *KnowledgeBaseHandler) List(w http.ResponseWriter, r *http.Request) {
-	qry := &query.ListKnowledgeBasesQuery{}
+	var req types.ListKnowledgeBasesRequest
+	if err := httpx.Parse(r, &req); err != nil {
+		httpx.WriteJson(w, http.StatusBadRequest, types.NewErrorResponse(r.Context(), http.StatusBadRequest, err.Error()))
+		return
+	}
+
+	qry := &query.ListKnowledgeBasesQuery{
+		Status: req.Status,
+	}
 
 	// 通过应用层容器访问查询处理器
 	result, err := h.svcCtx.App.Queries.ListKnowledgeBases.Handle(r.Context(), qry)
 	if err != nil {
 		code := interfaces.HTTPErrorCode(err)
-		httpx.WriteJson(w, code, types.NewErrorResponse(code, err.Error()))
+		httpx.WriteJson(w, code, types.NewErrorResponse(r.Context(), code, err.Error()))
+		return
+	}
+
+	httpx.WriteJson(w, http.StatusOK, types.NewSuccessResponse(result))
+}
+
+// ChangeStatus 变更知识库生命周期状态（只读、归档、恢复正常）
+// PUT /api/v1/knowledge/:id/status
+func (h *KnowledgeBaseHandler) ChangeStatus(w http.ResponseWriter, r *http.Request) {
+	var req types.ChangeKnowledgeBaseStatusRequest
+	if err := httpx.Parse(r, &req); err != nil {
+		httpx.WriteJson(w, http.StatusBadRequest, types.NewErrorResponse(r.Context(), http.StatusBadRequest, err.Error()))
+		return
+	}
+
+	cmd := &command.ChangeKnowledgeBaseStatusCommand{
+		ID:     req.ID,
+		Status: req.Status,
+	}
+
+	// 通过应用层容器访问命令处理器
+	result, err := h.svcCtx.App.Commands.ChangeKnowledgeBaseStatus.Handle(r.Context(), cmd)
+	if err != nil {
+		code := interfaces.HTTPErrorCode(err)
+		httpx.WriteJson(w, code, types.NewErrorResponse(r.Context(), code, err.Error()))
 		return
 	}
 
@@ -114,7 +150,7 @@ func (h *KnowledgeBaseHandler) List(w http.ResponseWriter, r *http.Request) {
 func (h *KnowledgeBaseHandler) Delete(w http.ResponseWriter, r *http.Request) {
 	var req types.DeleteKnowledgeBaseRequest
 	if err := httpx.Parse(r, &req); err != nil {
-		httpx.WriteJson(w, http.StatusBadRequest, types.NewErrorResponse(http.StatusBadRequest, err.Error()))
+		httpx.WriteJson(w, http.StatusBadRequest, types.NewErrorResponse(r.Context(), http.StatusBadRequest, err.Error()))
 		return
 	}
 
@@ -125,7 +161,7 @@ func (h *KnowledgeBaseHandler) Delete(w http.ResponseWriter, r *http.Request) {
 	// 通过应用层容器访问命令处理器
 	if err := h.svcCtx.App.Commands.DeleteKnowledgeBase.Handle(r.Context(), cmd); err != nil {
 		code := interfaces.HTTPErrorCode(err)
-		httpx.WriteJson(w, code, types.NewErrorResponse(code, err.Error()))
+		httpx.WriteJson(w, code, types.NewErrorResponse(r.Context(), code, err.Error()))
 		return
 	}
 
@@ -0,0 +1,97 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/zeromicro/go-zero/rest/httpx"
+
+	"gozero-ddd/internal/application/command"
+	"gozero-ddd/internal/application/query"
+	"gozero-ddd/internal/interfaces"
+	"gozero-ddd/internal/interfaces/api/svc"
+	"gozero-ddd/internal/interfaces/api/types"
+)
+
+// MemberHandler 知识库成员处理器
+type MemberHandler struct {
+	svcCtx *svc.ServiceContext
+}
+
+// NewMemberHandler 创建知识库成员处理器
+func NewMemberHandler(svcCtx *svc.ServiceContext) *MemberHandler {
+	return &MemberHandler{svcCtx: svcCtx}
+}
+
+// List 列出知识库成员
+// GET /api/v1/knowledge/:id/members
+func (h *MemberHandler) List(w http.ResponseWriter, r *http.Request) {
+	var req types.ListMembersRequest
+	if err := httpx.Parse(r, &req); err != nil {
+		httpx.WriteJson(w, http.StatusBadRequest, types.NewErrorResponse(r.Context(), http.StatusBadRequest, err.Error()))
+		return
+	}
+
+	qry := &query.ListKnowledgeBaseMembersQuery{
+		KnowledgeBaseID: req.KnowledgeBaseID,
+	}
+
+	// 通过应用层容器访问查询处理器
+	result, err := h.svcCtx.App.Queries.ListKnowledgeBaseMembers.Handle(r.Context(), qry)
+	if err != nil {
+		code := interfaces.HTTPErrorCode(err)
+		httpx.WriteJson(w, code, types.NewErrorResponse(r.Context(), code, err.Error()))
+		return
+	}
+
+	httpx.WriteJson(w, http.StatusOK, types.NewSuccessResponse(result))
+}
+
+// Grant 授予或变更成员角色
+// PUT /api/v1/knowledge/:id/members/:subject
+func (h *MemberHandler) Grant(w http.ResponseWriter, r *http.Request) {
+	var req types.GrantMemberRoleRequest
+	if err := httpx.Parse(r, &req); err != nil {
+		httpx.WriteJson(w, http.StatusBadRequest, types.NewErrorResponse(r.Context(), http.StatusBadRequest, err.Error()))
+		return
+	}
+
+	cmd := &command.GrantKnowledgeBaseRoleCommand{
+		KnowledgeBaseID: req.KnowledgeBaseID,
+		Subject:         req.Subject,
+		Role:            req.Role,
+	}
+
+	// 通过应用层容器访问命令处理器
+	result, err := h.svcCtx.App.Commands.GrantKnowledgeBaseRole.Handle(r.Context(), cmd)
+	if err != nil {
+		code := interfaces.HTTPErrorCode(err)
+		httpx.WriteJson(w, code, types.NewErrorResponse(r.Context(), code, err.Error()))
+		return
+	}
+
+	httpx.WriteJson(w, http.StatusOK, types.NewSuccessResponse(result))
+}
+
+// Revoke 移除知识库成员
+// DELETE /api/v1/knowledge/:id/members/:subject
+func (h *MemberHandler) Revoke(w http.ResponseWriter, r *http.Request) {
+	var req types.RevokeMemberRoleRequest
+	if err := httpx.Parse(r, &req); err != nil {
+		httpx.WriteJson(w, http.StatusBadRequest, types.NewErrorResponse(r.Context(), http.StatusBadRequest, err.Error()))
+		return
+	}
+
+	cmd := &command.RevokeKnowledgeBaseRoleCommand{
+		KnowledgeBaseID: req.KnowledgeBaseID,
+		Subject:         req.Subject,
+	}
+
+	// 通过应用层容器访问命令处理器
+	if err := h.svcCtx.App.Commands.RevokeKnowledgeBaseRole.Handle(r.Context(), cmd); err != nil {
+		code := interfaces.HTTPErrorCode(err)
+		httpx.WriteJson(w, code, types.NewErrorResponse(r.Context(), code, err.Error()))
+		return
+	}
+
+	httpx.WriteJson(w, http.StatusOK, types.NewSuccessResponse(nil))
+}
@@ -28,7 +28,7 @@ func NewMergeHandler(svcCtx *svc.ServiceContext) *MergeHandler {
 func (h *MergeHandler) MergeKnowledgeBases(w http.ResponseWriter, r *http.Request) {
 	var req types.MergeKnowledgeBasesRequest
 	if err := httpx.Parse(r, &req); err != nil {
-		httpx.WriteJson(w, http.StatusBadRequest, types.NewErrorResponse(http.StatusBadRequest, err.Error()))
+		httpx.WriteJson(w, http.StatusBadRequest, types.NewErrorResponse(r.Context(), http.StatusBadRequest, err.Error()))
 		return
 	}
 
@@ -42,7 +42,7 @@ func (h *MergeHandler) MergeKnowledgeBases(w http.ResponseWriter, r *http.Reques
 	if err != nil {
 		// 使用统一的错误转换函数
 		code := interfaces.HTTPErrorCode(err)
-		httpx.WriteJson(w, code, types.NewErrorResponse(code, err.Error()))
+		httpx.WriteJson(w, code, types.NewErrorResponse(r.Context(), code, err.Error()))
 		return
 	}
 
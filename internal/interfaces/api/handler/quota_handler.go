@@ -0,0 +1,36 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/zeromicro/go-zero/rest/httpx"
+
+	"gozero-ddd/internal/application/query"
+	"gozero-ddd/internal/interfaces"
+	"gozero-ddd/internal/interfaces/api/svc"
+	"gozero-ddd/internal/interfaces/api/types"
+)
+
+// QuotaHandler 租户配额处理器
+type QuotaHandler struct {
+	svcCtx *svc.ServiceContext
+}
+
+// NewQuotaHandler 创建租户配额处理器
+func NewQuotaHandler(svcCtx *svc.ServiceContext) *QuotaHandler {
+	return &QuotaHandler{svcCtx: svcCtx}
+}
+
+// Usage 获取当前租户的配额和用量
+// GET /api/v1/usage
+func (h *QuotaHandler) Usage(w http.ResponseWriter, r *http.Request) {
+	// 通过应用层容器访问查询处理器
+	result, err := h.svcCtx.App.Queries.GetQuotaUsage.Handle(r.Context(), &query.GetQuotaUsageQuery{})
+	if err != nil {
+		code := interfaces.HTTPErrorCode(err)
+		httpx.WriteJson(w, code, types.NewErrorResponse(r.Context(), code, err.Error()))
+		return
+	}
+
+	httpx.WriteJson(w, http.StatusOK, types.NewSuccessResponse(result))
+}
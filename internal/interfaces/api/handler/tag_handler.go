@@ -0,0 +1,178 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/zeromicro/go-zero/rest/httpx"
+
+	"gozero-ddd/internal/application/command"
+	"gozero-ddd/internal/application/query"
+	"gozero-ddd/internal/interfaces"
+	"gozero-ddd/internal/interfaces/api/svc"
+	"gozero-ddd/internal/interfaces/api/types"
+)
+
+// TagHandler 标签处理器
+type TagHandler struct {
+	svcCtx *svc.ServiceContext
+}
+
+// NewTagHandler 创建标签处理器
+func NewTagHandler(svcCtx *svc.ServiceContext) *TagHandler {
+	return &TagHandler{svcCtx: svcCtx}
+}
+
+// Cloud 获取知识库标签云
+// GET /api/v1/knowledge/:id/tags
+func (h *TagHandler) Cloud(w http.ResponseWriter, r *http.Request) {
+	var req types.GetTagCloudRequest
+	if err := httpx.Parse(r, &req); err != nil {
+		httpx.WriteJson(w, http.StatusBadRequest, types.NewErrorResponse(r.Context(), http.StatusBadRequest, err.Error()))
+		return
+	}
+
+	qry := &query.GetTagCloudQuery{
+		KnowledgeBaseID: req.KnowledgeBaseID,
+		IncludeDrafts:   req.IncludeDrafts,
+	}
+
+	// 通过应用层容器访问查询处理器
+	result, err := h.svcCtx.App.Queries.GetTagCloud.Handle(r.Context(), qry)
+	if err != nil {
+		code := interfaces.HTTPErrorCode(err)
+		httpx.WriteJson(w, code, types.NewErrorResponse(r.Context(), code, err.Error()))
+		return
+	}
+
+	httpx.WriteJson(w, http.StatusOK, types.NewSuccessResponse(result))
+}
+
+// Define 定义标签
+// POST /api/v1/knowledge/:id/tags
+func (h *TagHandler) Define(w http.ResponseWriter, r *http.Request) {
+	var req types.DefineTagRequest
+	if err := httpx.Parse(r, &req); err != nil {
+		httpx.WriteJson(w, http.StatusBadRequest, types.NewErrorResponse(r.Context(), http.StatusBadRequest, err.Error()))
+		return
+	}
+
+	cmd := &command.DefineTagCommand{
+		KnowledgeBaseID: req.KnowledgeBaseID,
+		Name:            req.Name,
+		ParentID:        req.ParentID,
+		Aliases:         req.Aliases,
+	}
+
+	// 通过应用层容器访问命令处理器
+	result, err := h.svcCtx.App.Commands.DefineTag.Handle(r.Context(), cmd)
+	if err != nil {
+		code := interfaces.HTTPErrorCode(err)
+		httpx.WriteJson(w, code, types.NewErrorResponse(r.Context(), code, err.Error()))
+		return
+	}
+
+	httpx.WriteJson(w, http.StatusCreated, types.NewSuccessResponse(result))
+}
+
+// Update 更新标签的父标签和别名
+// PUT /api/v1/knowledge/:id/tags/:tag_id
+func (h *TagHandler) Update(w http.ResponseWriter, r *http.Request) {
+	var req types.UpdateTagRequest
+	if err := httpx.Parse(r, &req); err != nil {
+		httpx.WriteJson(w, http.StatusBadRequest, types.NewErrorResponse(r.Context(), http.StatusBadRequest, err.Error()))
+		return
+	}
+
+	cmd := &command.UpdateTagCommand{
+		KnowledgeBaseID: req.KnowledgeBaseID,
+		TagID:           req.TagID,
+		ParentID:        req.ParentID,
+		Aliases:         req.Aliases,
+	}
+
+	// 通过应用层容器访问命令处理器
+	result, err := h.svcCtx.App.Commands.UpdateTag.Handle(r.Context(), cmd)
+	if err != nil {
+		code := interfaces.HTTPErrorCode(err)
+		httpx.WriteJson(w, code, types.NewErrorResponse(r.Context(), code, err.Error()))
+		return
+	}
+
+	httpx.WriteJson(w, http.StatusOK, types.NewSuccessResponse(result))
+}
+
+// Delete 删除标签定义（文档上的标签保持不变）
+// DELETE /api/v1/knowledge/:id/tags/:tag_id
+func (h *TagHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	var req types.DeleteTagRequest
+	if err := httpx.Parse(r, &req); err != nil {
+		httpx.WriteJson(w, http.StatusBadRequest, types.NewErrorResponse(r.Context(), http.StatusBadRequest, err.Error()))
+		return
+	}
+
+	cmd := &command.DeleteTagCommand{
+		KnowledgeBaseID: req.KnowledgeBaseID,
+		TagID:           req.TagID,
+	}
+
+	// 通过应用层容器访问命令处理器
+	if err := h.svcCtx.App.Commands.DeleteTag.Handle(r.Context(), cmd); err != nil {
+		code := interfaces.HTTPErrorCode(err)
+		httpx.WriteJson(w, code, types.NewErrorResponse(r.Context(), code, err.Error()))
+		return
+	}
+
+	httpx.WriteJson(w, http.StatusOK, types.NewSuccessResponse(nil))
+}
+
+// Rename 重命名标签并改写使用该标签的文档
+// POST /api/v1/knowledge/:id/tags/rename
+func (h *TagHandler) Rename(w http.ResponseWriter, r *http.Request) {
+	var req types.RenameTagRequest
+	if err := httpx.Parse(r, &req); err != nil {
+		httpx.WriteJson(w, http.StatusBadRequest, types.NewErrorResponse(r.Context(), http.StatusBadRequest, err.Error()))
+		return
+	}
+
+	cmd := &command.RenameTagCommand{
+		KnowledgeBaseID: req.KnowledgeBaseID,
+		From:            req.From,
+		To:              req.To,
+	}
+
+	// 通过应用层容器访问命令处理器
+	result, err := h.svcCtx.App.Commands.RenameTag.Handle(r.Context(), cmd)
+	if err != nil {
+		code := interfaces.HTTPErrorCode(err)
+		httpx.WriteJson(w, code, types.NewErrorResponse(r.Context(), code, err.Error()))
+		return
+	}
+
+	httpx.WriteJson(w, http.StatusOK, types.NewSuccessResponse(result))
+}
+
+// Merge 合并标签并改写使用源标签的文档
+// POST /api/v1/knowledge/:id/tags/merge
+func (h *TagHandler) Merge(w http.ResponseWriter, r *http.Request) {
+	var req types.MergeTagsRequest
+	if err := httpx.Parse(r, &req); err != nil {
+		httpx.WriteJson(w, http.StatusBadRequest, types.NewErrorResponse(r.Context(), http.StatusBadRequest, err.Error()))
+		return
+	}
+
+	cmd := &command.MergeTagsCommand{
+		KnowledgeBaseID: req.KnowledgeBaseID,
+		Sources:         req.Sources,
+		Target:          req.Target,
+	}
+
+	// 通过应用层容器访问命令处理器
+	result, err := h.svcCtx.App.Commands.MergeTags.Handle(r.Context(), cmd)
+	if err != nil {
+		code := interfaces.HTTPErrorCode(err)
+		httpx.WriteJson(w, code, types.NewErrorResponse(r.Context(), code, err.Error()))
+		return
+	}
+
+	httpx.WriteJson(w, http.StatusOK, types.NewSuccessResponse(result))
+}
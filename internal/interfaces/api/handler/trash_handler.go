@@ -0,0 +1,120 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/zeromicro/go-zero/rest/httpx"
+
+	"gozero-ddd/internal/application/command"
+	"gozero-ddd/internal/application/query"
+	"gozero-ddd/internal/interfaces"
+	"gozero-ddd/internal/interfaces/api/svc"
+	"gozero-ddd/internal/interfaces/api/types"
+)
+
+// TrashHandler 回收站处理器
+type TrashHandler struct {
+	svcCtx *svc.ServiceContext
+}
+
+// NewTrashHandler 创建回收站处理器
+func NewTrashHandler(svcCtx *svc.ServiceContext) *TrashHandler {
+	return &TrashHandler{svcCtx: svcCtx}
+}
+
+// List 列出回收站内容
+// GET /api/v1/trash
+func (h *TrashHandler) List(w http.ResponseWriter, r *http.Request) {
+	var req types.ListTrashRequest
+	if err := httpx.Parse(r, &req); err != nil {
+		httpx.WriteJson(w, http.StatusBadRequest, types.NewErrorResponse(r.Context(), http.StatusBadRequest, err.Error()))
+		return
+	}
+
+	qry := &query.ListTrashQuery{
+		KnowledgeBaseID: req.KnowledgeBaseID,
+	}
+
+	// 通过应用层容器访问查询处理器
+	result, err := h.svcCtx.App.Queries.ListTrash.Handle(r.Context(), qry)
+	if err != nil {
+		code := interfaces.HTTPErrorCode(err)
+		httpx.WriteJson(w, code, types.NewErrorResponse(r.Context(), code, err.Error()))
+		return
+	}
+
+	httpx.WriteJson(w, http.StatusOK, types.NewSuccessResponse(result))
+}
+
+// RestoreKnowledgeBase 从回收站恢复知识库
+// POST /api/v1/trash/knowledge/:id/restore
+func (h *TrashHandler) RestoreKnowledgeBase(w http.ResponseWriter, r *http.Request) {
+	var req types.RestoreKnowledgeBaseRequest
+	if err := httpx.Parse(r, &req); err != nil {
+		httpx.WriteJson(w, http.StatusBadRequest, types.NewErrorResponse(r.Context(), http.StatusBadRequest, err.Error()))
+		return
+	}
+
+	cmd := &command.RestoreKnowledgeBaseCommand{
+		ID: req.ID,
+	}
+
+	// 通过应用层容器访问命令处理器
+	result, err := h.svcCtx.App.Commands.RestoreKnowledgeBase.Handle(r.Context(), cmd)
+	if err != nil {
+		code := interfaces.HTTPErrorCode(err)
+		httpx.WriteJson(w, code, types.NewErrorResponse(r.Context(), code, err.Error()))
+		return
+	}
+
+	httpx.WriteJson(w, http.StatusOK, types.NewSuccessResponse(result))
+}
+
+// RestoreDocument 从回收站恢复文档
+// POST /api/v1/trash/knowledge/:id/documents/:doc_id/restore
+func (h *TrashHandler) RestoreDocument(w http.ResponseWriter, r *http.Request) {
+	var req types.RestoreDocumentRequest
+	if err := httpx.Parse(r, &req); err != nil {
+		httpx.WriteJson(w, http.StatusBadRequest, types.NewErrorResponse(r.Context(), http.StatusBadRequest, err.Error()))
+		return
+	}
+
+	cmd := &command.RestoreDocumentCommand{
+		KnowledgeBaseID: req.KnowledgeBaseID,
+		DocumentID:      req.DocumentID,
+	}
+
+	// 通过应用层容器访问命令处理器
+	result, err := h.svcCtx.App.Commands.RestoreDocument.Handle(r.Context(), cmd)
+	if err != nil {
+		code := interfaces.HTTPErrorCode(err)
+		httpx.WriteJson(w, code, types.NewErrorResponse(r.Context(), code, err.Error()))
+		return
+	}
+
+	httpx.WriteJson(w, http.StatusOK, types.NewSuccessResponse(result))
+}
+
+// Purge 手动清理回收站
+// POST /api/v1/trash/purge
+func (h *TrashHandler) Purge(w http.ResponseWriter, r *http.Request) {
+	var req types.PurgeTrashRequest
+	if err := httpx.Parse(r, &req); err != nil {
+		httpx.WriteJson(w, http.StatusBadRequest, types.NewErrorResponse(r.Context(), http.StatusBadRequest, err.Error()))
+		return
+	}
+
+	cmd := &command.PurgeTrashCommand{
+		RetentionDays: req.RetentionDays,
+	}
+
+	// 通过应用层容器访问命令处理器
+	result, err := h.svcCtx.App.Commands.PurgeTrash.Handle(r.Context(), cmd)
+	if err != nil {
+		code := interfaces.HTTPErrorCode(err)
+		httpx.WriteJson(w, code, types.NewErrorResponse(r.Context(), code, err.Error()))
+		return
+	}
+
+	httpx.WriteJson(w, http.StatusOK, types.NewSuccessResponse(result))
+}
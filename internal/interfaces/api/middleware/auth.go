@@ -0,0 +1,54 @@
+package middleware
+
+import (
+	"net/http"
+
+	"gozero-ddd/internal/application/auth"
+)
+
+// APIKeyHeader 服务间调用传入 API Key 的请求头
+const APIKeyHeader = "X-API-Key"
+
+// AuthMiddleware 认证中间件
+// 校验 X-API-Key 请求头中的 API Key 或 Authorization 请求头中的 Bearer 访问令牌，
+// 通过后将调用方放入请求上下文，后续的命令处理器和事件可以据此记录操作者；
+// 缺少令牌或令牌、API Key 无效时返回 401。
+// 携带 API Key 的请求总是需要校验；未启用访问令牌认证时，其他请求直接放行
+type AuthMiddleware struct {
+	service *auth.Service
+}
+
+// NewAuthMiddleware 创建认证中间件
+func NewAuthMiddleware(service *auth.Service) *AuthMiddleware {
+	return &AuthMiddleware{service: service}
+}
+
+// Handle 处理请求
+func (m *AuthMiddleware) Handle(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if key := r.Header.Get(APIKeyHeader); key != "" {
+			principal, err := m.service.AuthenticateAPIKey(r.Context(), key)
+			if err != nil {
+				writeMiddlewareError(w, r, err)
+				return
+			}
+			next(w, r.WithContext(auth.WithPrincipal(r.Context(), principal)))
+			return
+		}
+
+		if !m.service.Enabled() {
+			next(w, r)
+			return
+		}
+
+		token := auth.BearerToken(r.Header.Get("Authorization"))
+		principal, err := m.service.Authenticate(r.Context(), token)
+		if err != nil {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="knowledge-api"`)
+			writeMiddlewareError(w, r, err)
+			return
+		}
+
+		next(w, r.WithContext(auth.WithPrincipal(r.Context(), principal)))
+	}
+}
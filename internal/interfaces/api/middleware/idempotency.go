@@ -0,0 +1,157 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/zeromicro/go-zero/rest/httpx"
+
+	"gozero-ddd/internal/application/idempotency"
+	"gozero-ddd/internal/interfaces"
+	"gozero-ddd/internal/interfaces/api/types"
+)
+
+const (
+	// IdempotencyKeyHeader 客户端传入幂等键的请求头
+	IdempotencyKeyHeader = "Idempotency-Key"
+	// IdempotentReplayedHeader 响应为重放结果时设置的响应头
+	IdempotentReplayedHeader = "Idempotent-Replayed"
+)
+
+// IdempotencyMiddleware 幂等键中间件
+// 写请求携带 Idempotency-Key 时，首次请求的响应按键保存，
+// 相同键、相同请求的重试直接返回保存的响应，不会重复执行；
+// 相同键用于不同请求时返回 422。
+//
+// 幂等键只在同一租户的同一调用方内唯一，其他租户或调用方使用相同的键互不影响；
+// 请求指纹由方法、路径、查询参数和请求体计算。
+// multipart 请求（附件上传、导入、恢复）的请求体由处理器流式读取，不会为计算指纹缓存到内存，
+// 因此不使用幂等键。
+// 5xx 响应不会保存，客户端可以用相同的键重试
+type IdempotencyMiddleware struct {
+	service *idempotency.Service
+}
+
+// NewIdempotencyMiddleware 创建幂等键中间件
+func NewIdempotencyMiddleware(service *idempotency.Service) *IdempotencyMiddleware {
+	return &IdempotencyMiddleware{service: service}
+}
+
+// Handle 处理请求
+func (m *IdempotencyMiddleware) Handle(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		key := r.Header.Get(IdempotencyKeyHeader)
+		if key == "" || !isWriteMethod(r.Method) || isMultipart(r) {
+			next(w, r)
+			return
+		}
+
+		// 读取请求体计算指纹，再放回供后续处理器解析
+		body, err := io.ReadAll(r.Body)
+		_ = r.Body.Close()
+		if err != nil {
+			writeMiddlewareError(w, r, err)
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+
+		// 幂等键按租户和调用方隔离，指纹只需要区分同一调用方的不同请求
+		scope := idempotency.Scope(r.Context(), key)
+		fingerprint := idempotency.Fingerprint([]byte(r.Method), []byte(r.URL.Path), []byte(r.URL.RawQuery), body)
+
+		record, err := m.service.Begin(r.Context(), scope, fingerprint)
+		if err != nil {
+			writeMiddlewareError(w, r, err)
+			return
+		}
+		if record != nil {
+			// 重试：返回首次请求的响应
+			if record.ContentType != "" {
+				w.Header().Set("Content-Type", record.ContentType)
+			}
+			w.Header().Set(IdempotentReplayedHeader, "true")
+			w.WriteHeader(record.StatusCode)
+			_, _ = w.Write(record.Body)
+			return
+		}
+
+		// 客户端断开连接后仍要完成记录，因此不继承请求的取消信号
+		saveCtx := context.WithoutCancel(r.Context())
+		rec := &responseRecorder{ResponseWriter: w, status: http.StatusOK}
+		completed := false
+		defer func() {
+			if !completed {
+				// 处理器 panic 时释放键，允许客户端重试
+				if err := m.service.Release(saveCtx, scope); err != nil {
+					log.Printf("[Idempotency] 释放幂等键失败: %v", err)
+				}
+			}
+		}()
+
+		next(rec, r)
+
+		if rec.status >= http.StatusInternalServerError {
+			if err := m.service.Release(saveCtx, scope); err != nil {
+				log.Printf("[Idempotency] 释放幂等键失败: %v", err)
+			}
+		} else if err := m.service.Complete(saveCtx, scope, rec.status, rec.Header().Get("Content-Type"), rec.body.Bytes()); err != nil {
+			log.Printf("[Idempotency] 保存幂等响应失败: %v", err)
+		}
+		completed = true
+	}
+}
+
+// isWriteMethod 只有写请求使用幂等键
+func isWriteMethod(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// isMultipart 判断请求体是否为 multipart
+func isMultipart(r *http.Request) bool {
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	return err == nil && strings.HasPrefix(mediaType, "multipart/")
+}
+
+// writeMiddlewareError 以统一的错误格式写出错误
+func writeMiddlewareError(w http.ResponseWriter, r *http.Request, err error) {
+	code := interfaces.HTTPErrorCode(err)
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		code = http.StatusRequestEntityTooLarge
+	}
+	httpx.WriteJson(w, code, types.NewErrorResponse(r.Context(), code, err.Error()))
+}
+
+// responseRecorder 记录状态码和响应体，同时写给客户端
+type responseRecorder struct {
+	http.ResponseWriter
+	status      int
+	wroteHeader bool
+	body        bytes.Buffer
+}
+
+// WriteHeader 记录状态码
+func (r *responseRecorder) WriteHeader(status int) {
+	if !r.wroteHeader {
+		r.status = status
+		r.wroteHeader = true
+	}
+	r.ResponseWriter.WriteHeader(status)
+}
+
+// Write 记录响应体
+func (r *responseRecorder) Write(p []byte) (int, error) {
+	r.wroteHeader = true
+	r.body.Write(p)
+	return r.ResponseWriter.Write(p)
+}
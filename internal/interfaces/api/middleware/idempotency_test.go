@@ -0,0 +1,234 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"gozero-ddd/internal/application/auth"
+	"gozero-ddd/internal/application/idempotency"
+	"gozero-ddd/internal/domain/repository"
+	"gozero-ddd/internal/domain/tenant"
+)
+
+// memoryIdempotencyRepository 内存幂等键仓储
+type memoryIdempotencyRepository struct {
+	mu      sync.Mutex
+	records map[repository.IdempotencyScope]*repository.IdempotencyRecord
+}
+
+func newMemoryIdempotencyRepository() *memoryIdempotencyRepository {
+	return &memoryIdempotencyRepository{records: make(map[repository.IdempotencyScope]*repository.IdempotencyRecord)}
+}
+
+func (r *memoryIdempotencyRepository) Reserve(_ context.Context, record *repository.IdempotencyRecord) (*repository.IdempotencyRecord, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if existing, ok := r.records[record.IdempotencyScope]; ok {
+		copied := *existing
+		return &copied, nil
+	}
+	copied := *record
+	r.records[record.IdempotencyScope] = &copied
+	return nil, nil
+}
+
+func (r *memoryIdempotencyRepository) Complete(_ context.Context, scope repository.IdempotencyScope, statusCode int, contentType string, body []byte) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if existing, ok := r.records[scope]; ok {
+		existing.Completed, existing.StatusCode, existing.ContentType, existing.Body = true, statusCode, contentType, body
+	}
+	return nil
+}
+
+func (r *memoryIdempotencyRepository) Release(_ context.Context, scope repository.IdempotencyScope) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if existing, ok := r.records[scope]; ok && !existing.Completed {
+		delete(r.records, scope)
+	}
+	return nil
+}
+
+func (r *memoryIdempotencyRepository) DeleteExpired(context.Context, time.Time) (int64, error) {
+	return 0, nil
+}
+
+// countingHandler 记录调用次数，按 status 返回带调用序号的响应
+type countingHandler struct {
+	calls  int
+	status int
+}
+
+func (h *countingHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.calls++
+	_, _ = io.Copy(io.Discard, r.Body)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(h.status)
+	_, _ = fmt.Fprintf(w, `{"call":%d}`, h.calls)
+}
+
+func newIdempotentRequest(tenantID tenant.ID, subject, key, body string) *http.Request {
+	r := httptest.NewRequest(http.MethodPost, "/api/v1/knowledge", strings.NewReader(body))
+	r.Header.Set("Content-Type", "application/json")
+	if key != "" {
+		r.Header.Set(IdempotencyKeyHeader, key)
+	}
+	ctx := tenant.WithID(r.Context(), tenantID)
+	if subject != "" {
+		ctx = auth.WithPrincipal(ctx, &auth.Principal{Subject: subject})
+	}
+	return r.WithContext(ctx)
+}
+
+func TestIdempotencyMiddleware(t *testing.T) {
+	type step struct {
+		req         *http.Request
+		wantStatus  int
+		wantReplay  bool
+		wantCalls   int
+		wantBodyOf  int // 期望响应与第几步的响应相同，0 表示不检查
+		handlerCode int
+	}
+
+	tests := []struct {
+		name  string
+		steps []step
+	}{
+		{
+			name: "retry replays first response",
+			steps: []step{
+				{req: newIdempotentRequest("acme", "alice", "k", `{"name":"a"}`), wantStatus: http.StatusCreated, wantCalls: 1, handlerCode: http.StatusCreated},
+				{req: newIdempotentRequest("acme", "alice", "k", `{"name":"a"}`), wantStatus: http.StatusCreated, wantReplay: true, wantCalls: 1, wantBodyOf: 1, handlerCode: http.StatusCreated},
+			},
+		},
+		{
+			name: "same key for different request is rejected",
+			steps: []step{
+				{req: newIdempotentRequest("acme", "alice", "k", `{"name":"a"}`), wantStatus: http.StatusCreated, wantCalls: 1, handlerCode: http.StatusCreated},
+				{req: newIdempotentRequest("acme", "alice", "k", `{"name":"b"}`), wantStatus: http.StatusUnprocessableEntity, wantCalls: 1, handlerCode: http.StatusCreated},
+			},
+		},
+		{
+			name: "same key in other tenant or for other actor is independent",
+			steps: []step{
+				{req: newIdempotentRequest("acme", "alice", "1", `{"name":"a"}`), wantStatus: http.StatusCreated, wantCalls: 1, handlerCode: http.StatusCreated},
+				{req: newIdempotentRequest("globex", "alice", "1", `{"name":"b"}`), wantStatus: http.StatusCreated, wantCalls: 2, handlerCode: http.StatusCreated},
+				{req: newIdempotentRequest("acme", "bob", "1", `{"name":"c"}`), wantStatus: http.StatusCreated, wantCalls: 3, handlerCode: http.StatusCreated},
+			},
+		},
+		{
+			name: "server error is not stored",
+			steps: []step{
+				{req: newIdempotentRequest("acme", "alice", "k", `{"name":"a"}`), wantStatus: http.StatusServiceUnavailable, wantCalls: 1, handlerCode: http.StatusServiceUnavailable},
+				{req: newIdempotentRequest("acme", "alice", "k", `{"name":"a"}`), wantStatus: http.StatusCreated, wantCalls: 2, handlerCode: http.StatusCreated},
+			},
+		},
+		{
+			name: "client error is stored",
+			steps: []step{
+				{req: newIdempotentRequest("acme", "alice", "k", `{}`), wantStatus: http.StatusBadRequest, wantCalls: 1, handlerCode: http.StatusBadRequest},
+				{req: newIdempotentRequest("acme", "alice", "k", `{}`), wantStatus: http.StatusBadRequest, wantReplay: true, wantCalls: 1, handlerCode: http.StatusCreated},
+			},
+		},
+		{
+			name: "request without key is not stored",
+			steps: []step{
+				{req: newIdempotentRequest("acme", "alice", "", `{"name":"a"}`), wantStatus: http.StatusCreated, wantCalls: 1, handlerCode: http.StatusCreated},
+				{req: newIdempotentRequest("acme", "alice", "", `{"name":"a"}`), wantStatus: http.StatusCreated, wantCalls: 2, handlerCode: http.StatusCreated},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := NewIdempotencyMiddleware(idempotency.NewService(newMemoryIdempotencyRepository(), time.Hour))
+			h := &countingHandler{}
+			responses := make([]string, 0)
+			for i, s := range tt.steps {
+				h.status = s.handlerCode
+				w := httptest.NewRecorder()
+				m.Handle(h.ServeHTTP)(w, s.req)
+
+				if w.Code != s.wantStatus {
+					t.Fatalf("step %d: status = %d, want %d", i, w.Code, s.wantStatus)
+				}
+				if replayed := w.Header().Get(IdempotentReplayedHeader) == "true"; replayed != s.wantReplay {
+					t.Errorf("step %d: replayed = %v, want %v", i, replayed, s.wantReplay)
+				}
+				if h.calls != s.wantCalls {
+					t.Errorf("step %d: handler calls = %d, want %d", i, h.calls, s.wantCalls)
+				}
+				if s.wantBodyOf > 0 && w.Body.String() != responses[s.wantBodyOf-1] {
+					t.Errorf("step %d: body = %s, want %s", i, w.Body.String(), responses[s.wantBodyOf-1])
+				}
+				responses = append(responses, w.Body.String())
+			}
+		})
+	}
+}
+
+// streamCheckingBody 记录请求体是否在处理器运行前被读取
+type streamCheckingBody struct {
+	io.Reader
+	handlerStarted *bool
+	readEarly      bool
+}
+
+func (b *streamCheckingBody) Read(p []byte) (int, error) {
+	if !*b.handlerStarted {
+		b.readEarly = true
+	}
+	return b.Reader.Read(p)
+}
+
+func (b *streamCheckingBody) Close() error { return nil }
+
+func TestIdempotencyMiddlewareStreamsMultipart(t *testing.T) {
+	var buf bytes.Buffer
+	mw := multipart.NewWriter(&buf)
+	part, _ := mw.CreateFormFile("file", "a.txt")
+	_, _ = part.Write(bytes.Repeat([]byte("x"), 1<<20))
+	_ = mw.Close()
+
+	m := NewIdempotencyMiddleware(idempotency.NewService(newMemoryIdempotencyRepository(), time.Hour))
+	handlerStarted := false
+	calls := 0
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		handlerStarted = true
+		calls++
+		if _, err := io.Copy(io.Discard, r.Body); err != nil {
+			t.Fatal(err)
+		}
+		w.WriteHeader(http.StatusCreated)
+	}
+
+	for i := 0; i < 2; i++ {
+		handlerStarted = false
+		body := &streamCheckingBody{Reader: bytes.NewReader(buf.Bytes()), handlerStarted: &handlerStarted}
+		r := newIdempotentRequest("acme", "alice", "upload", "")
+		r.Body = body
+		r.Header.Set("Content-Type", mw.FormDataContentType())
+
+		w := httptest.NewRecorder()
+		m.Handle(handler)(w, r)
+		if w.Code != http.StatusCreated {
+			t.Fatalf("status = %d, want %d", w.Code, http.StatusCreated)
+		}
+		if body.readEarly {
+			t.Error("multipart body was read before the handler")
+		}
+	}
+	if calls != 2 {
+		t.Errorf("handler calls = %d, want 2 (multipart requests are not replayed)", calls)
+	}
+}
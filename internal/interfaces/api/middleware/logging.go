@@ -1,9 +1,10 @@
 package middleware
 
 import (
-	"log"
 	"net/http"
 	"time"
+
+	"gozero-ddd/internal/application/requestmeta"
 )
 
 // LoggingMiddleware 日志中间件
@@ -23,8 +24,9 @@ func (m *LoggingMiddleware) Handle(next http.HandlerFunc) http.HandlerFunc {
 		// 调用下一个处理器
 		next(w, r)
 
-		// 记录请求日志
-		log.Printf(
+		// 记录请求日志（附带请求ID和 trace-id）
+		requestmeta.Logf(
+			r.Context(),
 			"[%s] %s %s - %v",
 			r.Method,
 			r.URL.Path,
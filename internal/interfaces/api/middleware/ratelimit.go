@@ -0,0 +1,77 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+
+	"gozero-ddd/internal/application/ratelimit"
+	"gozero-ddd/internal/domain"
+)
+
+// RetryAfterHeader 被限流时告知客户端等待秒数的响应头
+const RetryAfterHeader = "Retry-After"
+
+// RateLimitMiddleware 限流中间件
+// 按调用方（API Key、用户或客户端 IP）使用令牌桶限流，每个请求按路由消耗令牌；
+// 令牌不足时返回 429，并通过 Retry-After 响应头告知客户端需要等待的秒数。
+// 需要注册在认证中间件之后，才能按已认证的调用方分桶；认证之前的限流见 IPRateLimitMiddleware
+type RateLimitMiddleware struct {
+	service *ratelimit.Service
+}
+
+// NewRateLimitMiddleware 创建限流中间件
+func NewRateLimitMiddleware(service *ratelimit.Service) *RateLimitMiddleware {
+	return &RateLimitMiddleware{service: service}
+}
+
+// Handle 处理请求
+func (m *RateLimitMiddleware) Handle(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !m.service.Enabled() {
+			next(w, r)
+			return
+		}
+
+		result := m.service.Allow(r.Context(), r.RemoteAddr, m.service.HTTPCost(r.Method, r.URL.Path))
+		if !result.Allowed {
+			writeRateLimited(w, r, result)
+			return
+		}
+		next(w, r)
+	}
+}
+
+// IPRateLimitMiddleware 认证之前的限流中间件
+// 按客户端 IP 使用令牌桶限流，每个请求消耗一个令牌，认证失败的请求同样计数，
+// 限制对访问令牌和 API Key 的暴力尝试。需要注册在认证中间件之前
+type IPRateLimitMiddleware struct {
+	service *ratelimit.Service
+}
+
+// NewIPRateLimitMiddleware 创建认证之前的限流中间件
+func NewIPRateLimitMiddleware(service *ratelimit.Service) *IPRateLimitMiddleware {
+	return &IPRateLimitMiddleware{service: service}
+}
+
+// Handle 处理请求
+func (m *IPRateLimitMiddleware) Handle(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !m.service.IPEnabled() {
+			next(w, r)
+			return
+		}
+
+		result := m.service.AllowIP(r.Context(), r.RemoteAddr)
+		if !result.Allowed {
+			writeRateLimited(w, r, result)
+			return
+		}
+		next(w, r)
+	}
+}
+
+// writeRateLimited 返回 429，并通过 Retry-After 响应头告知客户端需要等待的秒数
+func writeRateLimited(w http.ResponseWriter, r *http.Request, result ratelimit.Result) {
+	w.Header().Set(RetryAfterHeader, strconv.Itoa(ratelimit.RetryAfterSeconds(result.RetryAfter)))
+	writeMiddlewareError(w, r, domain.ErrRateLimitExceeded)
+}
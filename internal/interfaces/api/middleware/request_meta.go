@@ -0,0 +1,47 @@
+package middleware
+
+import (
+	"net"
+	"net/http"
+
+	"gozero-ddd/internal/application/requestmeta"
+)
+
+const (
+	// RequestIDHeader 请求ID请求头，客户端未携带时由服务端生成，并在响应头中返回
+	RequestIDHeader = "X-Request-ID"
+	// TraceparentHeader W3C Trace Context 请求头，携带时沿用其中的 trace-id
+	TraceparentHeader = "traceparent"
+	// TraceIDHeader 返回本次请求 trace-id 的响应头
+	TraceIDHeader = "X-Trace-ID"
+)
+
+// RequestMetaMiddleware 请求元数据中间件
+// 接受或生成请求ID（X-Request-ID）和 W3C traceparent，连同客户端 IP 放入请求上下文，
+// 日志、错误响应、领域事件元数据和审计日志据此关联同一个请求；
+// 请求ID和 trace-id 在响应头中返回。
+// 需要注册在最前面，之后的中间件和处理器都能拿到请求元数据
+type RequestMetaMiddleware struct{}
+
+// NewRequestMetaMiddleware 创建请求元数据中间件
+func NewRequestMetaMiddleware() *RequestMetaMiddleware {
+	return &RequestMetaMiddleware{}
+}
+
+// Handle 处理请求
+func (m *RequestMetaMiddleware) Handle(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		md := requestmeta.New(r.Context(), r.Header.Get(RequestIDHeader), r.Header.Get(TraceparentHeader), clientIP(r.RemoteAddr))
+		w.Header().Set(RequestIDHeader, md.RequestID)
+		w.Header().Set(TraceIDHeader, md.TraceID)
+		next(w, r.WithContext(requestmeta.WithMetadata(r.Context(), md)))
+	}
+}
+
+// clientIP 从连接地址中取出客户端 IP
+func clientIP(remoteAddr string) string {
+	if host, _, err := net.SplitHostPort(remoteAddr); err == nil {
+		return host
+	}
+	return remoteAddr
+}
@@ -0,0 +1,35 @@
+package middleware
+
+import (
+	"net/http"
+
+	"gozero-ddd/internal/application/auth"
+	"gozero-ddd/internal/domain/tenant"
+)
+
+// TenantHeader 客户端指定租户的请求头
+const TenantHeader = "X-Tenant-ID"
+
+// TenantMiddleware 租户中间件
+// 根据调用方令牌或 API Key 绑定的租户和 X-Tenant-ID 请求头确定请求所属的租户（规则见 auth.ResolveTenant），
+// 放入请求上下文后由仓储据此隔离数据；都没有时使用默认租户。
+// 租户标识无效时返回 400，指定了调用方无权访问的租户时返回 403。
+// 需要注册在认证中间件之后、幂等键中间件之前
+type TenantMiddleware struct{}
+
+// NewTenantMiddleware 创建租户中间件
+func NewTenantMiddleware() *TenantMiddleware {
+	return &TenantMiddleware{}
+}
+
+// Handle 处理请求
+func (m *TenantMiddleware) Handle(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := auth.ResolveTenant(r.Context(), r.Header.Get(TenantHeader))
+		if err != nil {
+			writeMiddlewareError(w, r, err)
+			return
+		}
+		next(w, r.WithContext(tenant.WithID(r.Context(), id)))
+	}
+}
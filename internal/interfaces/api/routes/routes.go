@@ -5,6 +5,7 @@ import (
 
 	"github.com/zeromicro/go-zero/rest"
 
+	"gozero-ddd/internal/application/command"
 	"gozero-ddd/internal/interfaces/api/handler"
 	"gozero-ddd/internal/interfaces/api/middleware"
 	"gozero-ddd/internal/interfaces/api/svc"
@@ -16,14 +17,37 @@ func RegisterRoutes(server *rest.Server, svcCtx *svc.ServiceContext) {
 	kbHandler := handler.NewKnowledgeBaseHandler(svcCtx)
 	docHandler := handler.NewDocumentHandler(svcCtx)
 	mergeHandler := handler.NewMergeHandler(svcCtx)
+	trashHandler := handler.NewTrashHandler(svcCtx)
+	folderHandler := handler.NewFolderHandler(svcCtx)
+	tagHandler := handler.NewTagHandler(svcCtx)
+	attachmentHandler := handler.NewAttachmentHandler(svcCtx)
+	importHandler := handler.NewImportHandler(svcCtx)
+	exportHandler := handler.NewExportHandler(svcCtx)
+	duplicateHandler := handler.NewDuplicateHandler(svcCtx)
+	memberHandler := handler.NewMemberHandler(svcCtx)
+	apiKeyHandler := handler.NewAPIKeyHandler(svcCtx)
+	quotaHandler := handler.NewQuotaHandler(svcCtx)
+	auditLogHandler := handler.NewAuditLogHandler(svcCtx)
 
 	// 创建中间件
+	// 接受或生成请求ID和 W3C traceparent，供日志、错误响应、领域事件和审计日志关联同一个请求
+	requestMetaMiddleware := middleware.NewRequestMetaMiddleware()
 	loggingMiddleware := middleware.NewLoggingMiddleware()
+	// 启用限流时在认证之前按客户端 IP 限流，认证失败的请求同样计数
+	ipRateLimitMiddleware := middleware.NewIPRateLimitMiddleware(svcCtx.App.RateLimit)
+	// 启用认证时校验 Bearer 访问令牌，并将调用方放入请求上下文
+	authMiddleware := middleware.NewAuthMiddleware(svcCtx.App.Auth)
+	// 启用限流时按调用方（API Key、用户或客户端 IP）使用令牌桶限流
+	rateLimitMiddleware := middleware.NewRateLimitMiddleware(svcCtx.App.RateLimit)
+	// 按调用方绑定的租户或 X-Tenant-ID 请求头确定请求所属的租户
+	tenantMiddleware := middleware.NewTenantMiddleware()
+	// 写请求携带 Idempotency-Key 时重放首次请求的响应
+	idempotencyMiddleware := middleware.NewIdempotencyMiddleware(svcCtx.App.Idempotency)
 
 	// 注册知识库相关路由
 	server.AddRoutes(
 		rest.WithMiddlewares(
-			[]rest.Middleware{loggingMiddleware.Handle},
+			[]rest.Middleware{requestMetaMiddleware.Handle, loggingMiddleware.Handle, ipRateLimitMiddleware.Handle, authMiddleware.Handle, rateLimitMiddleware.Handle, tenantMiddleware.Handle, idempotencyMiddleware.Handle},
 			[]rest.Route{
 				{
 					Method:  http.MethodPost,
@@ -50,6 +74,30 @@ func RegisterRoutes(server *rest.Server, svcCtx *svc.ServiceContext) {
 					Path:    "/api/v1/knowledge/:id",
 					Handler: kbHandler.Delete,
 				},
+				// 变更生命周期状态（只读、归档）
+				{
+					Method:  http.MethodPut,
+					Path:    "/api/v1/knowledge/:id/status",
+					Handler: kbHandler.ChangeStatus,
+				},
+				// 批量导入 Markdown 压缩包
+				{
+					Method:  http.MethodPost,
+					Path:    "/api/v1/knowledge/:id/import",
+					Handler: importHandler.Import,
+				},
+				// 导出知识库（Markdown 压缩包、JSON Lines、备份包）
+				{
+					Method:  http.MethodGet,
+					Path:    "/api/v1/knowledge/:id/export",
+					Handler: exportHandler.Export,
+				},
+				// 从备份包恢复知识库
+				{
+					Method:  http.MethodPost,
+					Path:    "/api/v1/knowledge/restore",
+					Handler: exportHandler.Restore,
+				},
 				// 合并知识库（事务演示）
 				{
 					Method:  http.MethodPost,
@@ -63,23 +111,324 @@ func RegisterRoutes(server *rest.Server, svcCtx *svc.ServiceContext) {
 	// 注册文档相关路由
 	server.AddRoutes(
 		rest.WithMiddlewares(
-			[]rest.Middleware{loggingMiddleware.Handle},
+			[]rest.Middleware{requestMetaMiddleware.Handle, loggingMiddleware.Handle, ipRateLimitMiddleware.Handle, authMiddleware.Handle, rateLimitMiddleware.Handle, tenantMiddleware.Handle, idempotencyMiddleware.Handle},
 			[]rest.Route{
 				{
 					Method:  http.MethodPost,
 					Path:    "/api/v1/knowledge/:id/documents",
 					Handler: docHandler.Add,
 				},
+				// 批量添加、更新、删除文档（单个事务）
+				{
+					Method:  http.MethodPost,
+					Path:    "/api/v1/knowledge/:id/documents/batch",
+					Handler: docHandler.Batch,
+				},
 				{
 					Method:  http.MethodGet,
 					Path:    "/api/v1/knowledge/:id/documents",
 					Handler: docHandler.List,
 				},
+				{
+					Method:  http.MethodGet,
+					Path:    "/api/v1/knowledge/:id/documents/:doc_id",
+					Handler: docHandler.Get,
+				},
+				{
+					Method:  http.MethodPut,
+					Path:    "/api/v1/knowledge/:id/documents/:doc_id",
+					Handler: docHandler.Update,
+				},
 				{
 					Method:  http.MethodDelete,
 					Path:    "/api/v1/knowledge/:id/documents/:doc_id",
 					Handler: docHandler.Remove,
 				},
+				// 文档链接：出链、反向链接与失效链接
+				{
+					Method:  http.MethodGet,
+					Path:    "/api/v1/knowledge/:id/documents/:doc_id/links",
+					Handler: docHandler.Links,
+				},
+				{
+					Method:  http.MethodGet,
+					Path:    "/api/v1/knowledge/:id/links/broken",
+					Handler: docHandler.BrokenLinks,
+				},
+				// 文档发布流程：提交审核 -> 审核通过/驳回 -> 发布 -> 撤回
+				{
+					Method:  http.MethodPost,
+					Path:    "/api/v1/knowledge/:id/documents/:doc_id/submit",
+					Handler: docHandler.Workflow(command.DocumentActionSubmit),
+				},
+				{
+					Method:  http.MethodPost,
+					Path:    "/api/v1/knowledge/:id/documents/:doc_id/approve",
+					Handler: docHandler.Workflow(command.DocumentActionApprove),
+				},
+				{
+					Method:  http.MethodPost,
+					Path:    "/api/v1/knowledge/:id/documents/:doc_id/reject",
+					Handler: docHandler.Workflow(command.DocumentActionReject),
+				},
+				{
+					Method:  http.MethodPost,
+					Path:    "/api/v1/knowledge/:id/documents/:doc_id/publish",
+					Handler: docHandler.Workflow(command.DocumentActionPublish),
+				},
+				{
+					Method:  http.MethodPost,
+					Path:    "/api/v1/knowledge/:id/documents/:doc_id/unpublish",
+					Handler: docHandler.Workflow(command.DocumentActionUnpublish),
+				},
+				// 定时发布/下线
+				{
+					Method:  http.MethodPut,
+					Path:    "/api/v1/knowledge/:id/documents/:doc_id/schedule",
+					Handler: docHandler.Schedule,
+				},
+			}...,
+		),
+	)
+
+	// 注册文件夹相关路由
+	server.AddRoutes(
+		rest.WithMiddlewares(
+			[]rest.Middleware{requestMetaMiddleware.Handle, loggingMiddleware.Handle, ipRateLimitMiddleware.Handle, authMiddleware.Handle, rateLimitMiddleware.Handle, tenantMiddleware.Handle, idempotencyMiddleware.Handle},
+			[]rest.Route{
+				{
+					Method:  http.MethodGet,
+					Path:    "/api/v1/knowledge/:id/folders",
+					Handler: folderHandler.Tree,
+				},
+				{
+					Method:  http.MethodPost,
+					Path:    "/api/v1/knowledge/:id/folders",
+					Handler: folderHandler.Create,
+				},
+				{
+					Method:  http.MethodPut,
+					Path:    "/api/v1/knowledge/:id/folders/:folder_id",
+					Handler: folderHandler.Rename,
+				},
+				{
+					Method:  http.MethodPut,
+					Path:    "/api/v1/knowledge/:id/folders/:folder_id/move",
+					Handler: folderHandler.Move,
+				},
+				{
+					Method:  http.MethodDelete,
+					Path:    "/api/v1/knowledge/:id/folders/:folder_id",
+					Handler: folderHandler.Delete,
+				},
+				// 移动文档到文件夹
+				{
+					Method:  http.MethodPut,
+					Path:    "/api/v1/knowledge/:id/documents/:doc_id/folder",
+					Handler: folderHandler.MoveDocument,
+				},
+			}...,
+		),
+	)
+
+	// 注册标签相关路由
+	server.AddRoutes(
+		rest.WithMiddlewares(
+			[]rest.Middleware{requestMetaMiddleware.Handle, loggingMiddleware.Handle, ipRateLimitMiddleware.Handle, authMiddleware.Handle, rateLimitMiddleware.Handle, tenantMiddleware.Handle, idempotencyMiddleware.Handle},
+			[]rest.Route{
+				{
+					Method:  http.MethodGet,
+					Path:    "/api/v1/knowledge/:id/tags",
+					Handler: tagHandler.Cloud,
+				},
+				{
+					Method:  http.MethodPost,
+					Path:    "/api/v1/knowledge/:id/tags",
+					Handler: tagHandler.Define,
+				},
+				{
+					Method:  http.MethodPut,
+					Path:    "/api/v1/knowledge/:id/tags/:tag_id",
+					Handler: tagHandler.Update,
+				},
+				{
+					Method:  http.MethodDelete,
+					Path:    "/api/v1/knowledge/:id/tags/:tag_id",
+					Handler: tagHandler.Delete,
+				},
+				{
+					Method:  http.MethodPost,
+					Path:    "/api/v1/knowledge/:id/tags/rename",
+					Handler: tagHandler.Rename,
+				},
+				{
+					Method:  http.MethodPost,
+					Path:    "/api/v1/knowledge/:id/tags/merge",
+					Handler: tagHandler.Merge,
+				},
+			}...,
+		),
+	)
+
+	// 注册附件相关路由
+	server.AddRoutes(
+		rest.WithMiddlewares(
+			[]rest.Middleware{requestMetaMiddleware.Handle, loggingMiddleware.Handle, ipRateLimitMiddleware.Handle, authMiddleware.Handle, rateLimitMiddleware.Handle, tenantMiddleware.Handle, idempotencyMiddleware.Handle},
+			[]rest.Route{
+				{
+					Method:  http.MethodPost,
+					Path:    "/api/v1/knowledge/:id/documents/:doc_id/attachments",
+					Handler: attachmentHandler.Upload,
+				},
+				{
+					Method:  http.MethodGet,
+					Path:    "/api/v1/knowledge/:id/documents/:doc_id/attachments",
+					Handler: attachmentHandler.List,
+				},
+				{
+					Method:  http.MethodGet,
+					Path:    "/api/v1/knowledge/:id/attachments/:attachment_id",
+					Handler: attachmentHandler.Download,
+				},
+				{
+					Method:  http.MethodDelete,
+					Path:    "/api/v1/knowledge/:id/attachments/:attachment_id",
+					Handler: attachmentHandler.Delete,
+				},
+			}...,
+		),
+	)
+
+	// 注册重复文档检测相关路由
+	server.AddRoutes(
+		rest.WithMiddlewares(
+			[]rest.Middleware{requestMetaMiddleware.Handle, loggingMiddleware.Handle, ipRateLimitMiddleware.Handle, authMiddleware.Handle, rateLimitMiddleware.Handle, tenantMiddleware.Handle, idempotencyMiddleware.Handle},
+			[]rest.Route{
+				{
+					Method:  http.MethodGet,
+					Path:    "/api/v1/knowledge/:id/duplicates",
+					Handler: duplicateHandler.List,
+				},
+				{
+					Method:  http.MethodGet,
+					Path:    "/api/v1/duplicates",
+					Handler: duplicateHandler.ListAll,
+				},
+				{
+					Method:  http.MethodPost,
+					Path:    "/api/v1/duplicates/rebuild",
+					Handler: duplicateHandler.Rebuild,
+				},
+			}...,
+		),
+	)
+
+	// 注册知识库成员相关路由
+	server.AddRoutes(
+		rest.WithMiddlewares(
+			[]rest.Middleware{requestMetaMiddleware.Handle, loggingMiddleware.Handle, ipRateLimitMiddleware.Handle, authMiddleware.Handle, rateLimitMiddleware.Handle, tenantMiddleware.Handle, idempotencyMiddleware.Handle},
+			[]rest.Route{
+				{
+					Method:  http.MethodGet,
+					Path:    "/api/v1/knowledge/:id/members",
+					Handler: memberHandler.List,
+				},
+				{
+					Method:  http.MethodPut,
+					Path:    "/api/v1/knowledge/:id/members/:subject",
+					Handler: memberHandler.Grant,
+				},
+				{
+					Method:  http.MethodDelete,
+					Path:    "/api/v1/knowledge/:id/members/:subject",
+					Handler: memberHandler.Revoke,
+				},
+			}...,
+		),
+	)
+
+	// 注册 API Key 管理路由
+	server.AddRoutes(
+		rest.WithMiddlewares(
+			[]rest.Middleware{requestMetaMiddleware.Handle, loggingMiddleware.Handle, ipRateLimitMiddleware.Handle, authMiddleware.Handle, rateLimitMiddleware.Handle, tenantMiddleware.Handle, idempotencyMiddleware.Handle},
+			[]rest.Route{
+				{
+					Method:  http.MethodPost,
+					Path:    "/api/v1/api-keys",
+					Handler: apiKeyHandler.Issue,
+				},
+				{
+					Method:  http.MethodGet,
+					Path:    "/api/v1/api-keys",
+					Handler: apiKeyHandler.List,
+				},
+				{
+					Method:  http.MethodDelete,
+					Path:    "/api/v1/api-keys/:key_id",
+					Handler: apiKeyHandler.Revoke,
+				},
+			}...,
+		),
+	)
+
+	// 注册租户配额路由
+	server.AddRoutes(
+		rest.WithMiddlewares(
+			[]rest.Middleware{requestMetaMiddleware.Handle, loggingMiddleware.Handle, ipRateLimitMiddleware.Handle, authMiddleware.Handle, rateLimitMiddleware.Handle, tenantMiddleware.Handle, idempotencyMiddleware.Handle},
+			[]rest.Route{
+				{
+					Method:  http.MethodGet,
+					Path:    "/api/v1/usage",
+					Handler: quotaHandler.Usage,
+				},
+			}...,
+		),
+	)
+
+	// 注册审计日志路由
+	server.AddRoutes(
+		rest.WithMiddlewares(
+			[]rest.Middleware{requestMetaMiddleware.Handle, loggingMiddleware.Handle, ipRateLimitMiddleware.Handle, authMiddleware.Handle, rateLimitMiddleware.Handle, tenantMiddleware.Handle, idempotencyMiddleware.Handle},
+			[]rest.Route{
+				{
+					Method:  http.MethodGet,
+					Path:    "/api/v1/audit-logs",
+					Handler: auditLogHandler.List,
+				},
+				{
+					Method:  http.MethodGet,
+					Path:    "/api/v1/audit-logs/export",
+					Handler: auditLogHandler.Export,
+				},
+			}...,
+		),
+	)
+
+	// 注册回收站相关路由
+	server.AddRoutes(
+		rest.WithMiddlewares(
+			[]rest.Middleware{requestMetaMiddleware.Handle, loggingMiddleware.Handle, ipRateLimitMiddleware.Handle, authMiddleware.Handle, rateLimitMiddleware.Handle, tenantMiddleware.Handle, idempotencyMiddleware.Handle},
+			[]rest.Route{
+				{
+					Method:  http.MethodGet,
+					Path:    "/api/v1/trash",
+					Handler: trashHandler.List,
+				},
+				{
+					Method:  http.MethodPost,
+					Path:    "/api/v1/trash/knowledge/:id/restore",
+					Handler: trashHandler.RestoreKnowledgeBase,
+				},
+				{
+					Method:  http.MethodPost,
+					Path:    "/api/v1/trash/knowledge/:id/documents/:doc_id/restore",
+					Handler: trashHandler.RestoreDocument,
+				},
+				{
+					Method:  http.MethodPost,
+					Path:    "/api/v1/trash/purge",
+					Handler: trashHandler.Purge,
+				},
 			}...,
 		),
 	)
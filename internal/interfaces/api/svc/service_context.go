@@ -2,10 +2,12 @@ package svc
 
 import (
 	"log"
+	"time"
 
 	appcontainer "gozero-ddd/internal/application/container"
 	"gozero-ddd/internal/infrastructure/config"
 	infracontainer "gozero-ddd/internal/infrastructure/container"
+	"gozero-ddd/internal/infrastructure/job"
 )
 
 // ServiceContext 服务上下文
@@ -25,6 +27,12 @@ type ServiceContext struct {
 	// 基础设施容器 - 内部持有，用于资源管理（如关闭数据库连接）
 	// 注意：这里使用小写字母开头，表示不对外暴露
 	infra *infracontainer.InfrastructureContainer
+
+	// 后台定时任务
+	trashPurgeJob         *job.TrashPurgeJob
+	documentScheduler     *job.DocumentScheduler
+	idempotencyCleanupJob *job.IdempotencyCleanupJob
+	inventoryMetricsJob   *job.InventoryMetricsJob
 }
 
 // NewServiceContext 创建服务上下文
@@ -41,17 +49,60 @@ func NewServiceContext(c config.Config) *ServiceContext {
 	// 依赖：基础设施层容器
 	app := appcontainer.NewApplicationContainer(infra)
 
+	// 3. 启动后台定时任务
+	var trashPurgeJob *job.TrashPurgeJob
+	if c.Trash.EnablePurgeJob {
+		trashPurgeJob = job.NewTrashPurgeJob(app.Commands.PurgeTrash, infra.KnowledgeBaseRepo, c.Trash.RetentionDays, c.Trash.PurgeInterval)
+		trashPurgeJob.Start()
+	}
+
+	var documentScheduler *job.DocumentScheduler
+	if c.Scheduler.Enabled {
+		documentScheduler = job.NewDocumentScheduler(app.Commands.ProcessScheduledDocuments, infra.KnowledgeBaseRepo, job.SystemClock{}, c.Scheduler.Interval)
+		documentScheduler.Start()
+	}
+
+	var idempotencyCleanupJob *job.IdempotencyCleanupJob
+	if c.Idempotency.EnableCleanupJob {
+		idempotencyCleanupJob = job.NewIdempotencyCleanupJob(app.Idempotency, c.Idempotency.CleanupInterval)
+		idempotencyCleanupJob.Start()
+	}
+
+	// 配置了 Prometheus 指标端点时定时刷新知识库和文档总数
+	var inventoryMetricsJob *job.InventoryMetricsJob
+	if c.Prometheus.Host != "" {
+		inventoryMetricsJob = job.NewInventoryMetricsJob(infra.KnowledgeBaseRepo, infra.DocumentRepo, infra.KnowledgeBaseRepo, c.Metrics.InventoryInterval)
+		inventoryMetricsJob.Start()
+	}
+
 	log.Println("✅ [ServiceContext] 服务上下文初始化完成")
 
 	return &ServiceContext{
 		Config: c,
 		App:    app,
 		infra:  infra,
+
+		trashPurgeJob:         trashPurgeJob,
+		documentScheduler:     documentScheduler,
+		idempotencyCleanupJob: idempotencyCleanupJob,
+		inventoryMetricsJob:   inventoryMetricsJob,
 	}
 }
 
 // Close 关闭服务上下文，释放资源
 func (ctx *ServiceContext) Close() error {
+	if ctx.trashPurgeJob != nil {
+		ctx.trashPurgeJob.Stop()
+	}
+	if ctx.documentScheduler != nil {
+		ctx.documentScheduler.Stop()
+	}
+	if ctx.idempotencyCleanupJob != nil {
+		ctx.idempotencyCleanupJob.Stop()
+	}
+	if ctx.inventoryMetricsJob != nil {
+		ctx.inventoryMetricsJob.Stop()
+	}
 	if ctx.infra != nil {
 		return ctx.infra.Close()
 	}
@@ -71,3 +122,32 @@ func (a *configAdapter) GetMySQLDataSource() string {
 func (a *configAdapter) IsAutoMigrate() bool {
 	return a.MySQL.AutoMigrate
 }
+
+func (a *configAdapter) GetBlobStoreConfig() config.BlobStoreConfig {
+	return a.BlobStore
+}
+
+// GetMaxAttachmentBytes 附件大小上限与请求体大小上限（MaxBytes）一致
+func (a *configAdapter) GetMaxAttachmentBytes() int64 {
+	return a.MaxBytes
+}
+
+func (a *configAdapter) GetIdempotencyTTL() time.Duration {
+	return a.Idempotency.TTL
+}
+
+func (a *configAdapter) GetAuthConfig() config.AuthConfig {
+	return a.Auth
+}
+
+func (a *configAdapter) GetQuotaConfig() config.QuotaConfig {
+	return a.Quota
+}
+
+func (a *configAdapter) GetRedisConfig() config.RedisConfig {
+	return a.Redis
+}
+
+func (a *configAdapter) GetRateLimitConfig() config.RateLimitConfig {
+	return a.RateLimit
+}
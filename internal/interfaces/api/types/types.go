@@ -1,5 +1,11 @@
 package types
 
+import (
+	"context"
+
+	"gozero-ddd/internal/application/requestmeta"
+)
+
 // ========== 知识库相关请求/响应 ==========
 
 // CreateKnowledgeBaseRequest 创建知识库请求
@@ -10,15 +16,29 @@ type CreateKnowledgeBaseRequest struct {
 
 // UpdateKnowledgeBaseRequest 更新知识库请求
 type UpdateKnowledgeBaseRequest struct {
-	ID          string `path:"id"`
-	Name        string `json:"name"`
-	Description string `json:"description,optional"`
+	ID              string `path:"id"`
+	Name            string `json:"name"`
+	Description     string `json:"description,optional"`
+	DuplicatePolicy string `json:"duplicate_policy,optional"` // 重复文档策略：allow / reject_exact，不传时保持不变
 }
 
 // GetKnowledgeBaseRequest 获取知识库请求
 type GetKnowledgeBaseRequest struct {
 	ID               string `path:"id"`
 	IncludeDocuments bool   `form:"include_documents,optional"`
+	IncludeDrafts    bool   `form:"include_drafts,optional"` // 是否包含未发布文档
+	TagQuery         string `form:"tag_query,optional"`      // 标签查询表达式，如 tag:go AND NOT tag:deprecated
+}
+
+// ListKnowledgeBasesRequest 列出知识库请求
+type ListKnowledgeBasesRequest struct {
+	Status string `form:"status,optional"` // 按状态过滤：active / read_only / archived
+}
+
+// ChangeKnowledgeBaseStatusRequest 变更知识库状态请求
+type ChangeKnowledgeBaseStatusRequest struct {
+	ID     string `path:"id"`
+	Status string `json:"status"` // 目标状态：active / read_only / archived
 }
 
 // DeleteKnowledgeBaseRequest 删除知识库请求
@@ -46,9 +66,37 @@ type AddDocumentRequest struct {
 	KnowledgeBaseID string   `path:"id"`
 	Title           string   `json:"title"`
 	Content         string   `json:"content"`
+	ContentType     string   `json:"content_type,optional"` // 内容类型：markdown（默认）/ html / text / asciidoc
 	Tags            []string `json:"tags,optional"`
 }
 
+// BatchDocumentsRequest 批量文档操作请求
+type BatchDocumentsRequest struct {
+	KnowledgeBaseID string                  `path:"id"`
+	Mode            string                  `json:"mode,optional"` // 执行模式：atomic（默认，全部成功才提交）/ best_effort
+	Operations      []BatchOperationRequest `json:"operations"`
+}
+
+// BatchOperationRequest 批量请求中的单个操作
+type BatchOperationRequest struct {
+	Op          string   `json:"op"`                   // add / update / remove
+	DocumentID  string   `json:"document_id,optional"` // update / remove 时必填
+	Title       string   `json:"title,optional"`
+	Content     string   `json:"content,optional"`
+	ContentType string   `json:"content_type,optional"`
+	Tags        []string `json:"tags,optional"` // update 时不传则保留原标签
+}
+
+// UpdateDocumentRequest 更新文档请求
+type UpdateDocumentRequest struct {
+	KnowledgeBaseID string   `path:"id"`
+	DocumentID      string   `path:"doc_id"`
+	Title           string   `json:"title"`
+	Content         string   `json:"content"`
+	ContentType     string   `json:"content_type,optional"` // 不传时保留原内容类型
+	Tags            []string `json:"tags,optional"`         // 不传时保留原标签
+}
+
 // RemoveDocumentRequest 删除文档请求
 type RemoveDocumentRequest struct {
 	KnowledgeBaseID string `path:"id"`
@@ -58,6 +106,26 @@ type RemoveDocumentRequest struct {
 // ListDocumentsRequest 列出文档请求
 type ListDocumentsRequest struct {
 	KnowledgeBaseID string `path:"id"`
+	Status          string `form:"status,optional"`         // 按发布状态过滤：draft / in_review / approved / rejected / published / expired
+	IncludeDrafts   bool   `form:"include_drafts,optional"` // 是否包含未发布文档，默认只返回已发布文档
+	TagQuery        string `form:"tag_query,optional"`      // 标签查询表达式，如 tag:go AND (tag:grpc OR tag:kafka)
+	Format          string `form:"format,optional"`         // 内容输出格式：raw（默认）/ html / text
+}
+
+// GetDocumentRequest 获取单个文档请求
+type GetDocumentRequest struct {
+	KnowledgeBaseID string `path:"id"`
+	DocumentID      string `path:"doc_id"`
+	Format          string `form:"format,optional"`         // 内容输出格式：raw（默认）/ html / text
+	IncludeDrafts   bool   `form:"include_drafts,optional"` // 是否允许读取未发布文档
+}
+
+// DocumentWorkflowRequest 文档发布流程请求
+type DocumentWorkflowRequest struct {
+	KnowledgeBaseID string `path:"id"`
+	DocumentID      string `path:"doc_id"`
+	Reviewer        string `json:"reviewer,optional"` // 审核人
+	Comment         string `json:"comment,optional"`  // 评审意见（驳回时必填）
 }
 
 // DocumentResponse 文档响应
@@ -67,13 +135,260 @@ type DocumentResponse struct {
 	Data    interface{} `json:"data,omitempty"`
 }
 
+// ScheduleDocumentRequest 设置文档定时发布/下线请求
+type ScheduleDocumentRequest struct {
+	KnowledgeBaseID string `path:"id"`
+	DocumentID      string `path:"doc_id"`
+	PublishAt       string `json:"publish_at,optional"` // 定时发布时间（RFC3339），为空表示取消定时发布
+	ExpireAt        string `json:"expire_at,optional"`  // 定时下线时间（RFC3339），为空表示永不过期
+}
+
+// GetDocumentLinksRequest 获取文档链接请求
+type GetDocumentLinksRequest struct {
+	KnowledgeBaseID string `path:"id"`
+	DocumentID      string `path:"doc_id"`
+}
+
+// ListBrokenLinksRequest 列出失效链接请求
+type ListBrokenLinksRequest struct {
+	KnowledgeBaseID string `path:"id"`
+}
+
+// ListDuplicatesRequest 列出知识库重复文档请求
+type ListDuplicatesRequest struct {
+	KnowledgeBaseID    string `path:"id"`
+	CrossKnowledgeBase bool   `form:"cross,optional"`         // 是否同时检测与其他知识库文档的重复
+	MaxDistance        int    `form:"max_distance,default=8"` // 近似重复的 SimHash 汉明距离上限（0-16）
+}
+
+// ListAllDuplicatesRequest 列出所有知识库重复文档请求
+type ListAllDuplicatesRequest struct {
+	MaxDistance int `form:"max_distance,default=8"` // 近似重复的 SimHash 汉明距离上限（0-16）
+}
+
+// RebuildFingerprintsRequest 重建文档内容指纹请求
+type RebuildFingerprintsRequest struct {
+	KnowledgeBaseID string `json:"knowledge_base_id,optional"` // 为空时重建所有知识库
+}
+
+// ========== 知识库成员相关请求 ==========
+
+// ListMembersRequest 列出知识库成员请求
+type ListMembersRequest struct {
+	KnowledgeBaseID string `path:"id"`
+}
+
+// GrantMemberRoleRequest 授予成员角色请求
+type GrantMemberRoleRequest struct {
+	KnowledgeBaseID string `path:"id"`
+	Subject         string `path:"subject"` // 调用方标识（JWT 的 sub）
+	Role            string `json:"role"`    // owner / editor / viewer
+}
+
+// RevokeMemberRoleRequest 移除知识库成员请求
+type RevokeMemberRoleRequest struct {
+	KnowledgeBaseID string `path:"id"`
+	Subject         string `path:"subject"`
+}
+
+// ========== API Key 相关请求 ==========
+
+// IssueAPIKeyRequest 签发 API Key 请求
+type IssueAPIKeyRequest struct {
+	Name             string   `json:"name"`
+	Scopes           []string `json:"scopes"`                      // read / write / admin
+	KnowledgeBaseIDs []string `json:"knowledge_base_ids,optional"` // 允许访问的知识库，为空表示不限制
+	ExpiresAt        string   `json:"expires_at,optional"`         // 过期时间（RFC3339），为空表示永不过期
+}
+
+// RevokeAPIKeyRequest 吊销 API Key 请求
+type RevokeAPIKeyRequest struct {
+	ID string `path:"key_id"`
+}
+
+// ========== 文件夹相关请求 ==========
+
+// GetFolderTreeRequest 获取文件夹树请求
+type GetFolderTreeRequest struct {
+	KnowledgeBaseID string `path:"id"`
+	IncludeDrafts   bool   `form:"include_drafts,optional"` // 文档数量是否包含未发布文档
+}
+
+// CreateFolderRequest 创建文件夹请求
+type CreateFolderRequest struct {
+	KnowledgeBaseID string `path:"id"`
+	ParentID        string `json:"parent_id,optional"` // 父文件夹ID，为空表示根目录
+	Name            string `json:"name"`
+}
+
+// RenameFolderRequest 重命名文件夹请求
+type RenameFolderRequest struct {
+	KnowledgeBaseID string `path:"id"`
+	FolderID        string `path:"folder_id"`
+	Name            string `json:"name"`
+}
+
+// MoveFolderRequest 移动文件夹请求
+type MoveFolderRequest struct {
+	KnowledgeBaseID string `path:"id"`
+	FolderID        string `path:"folder_id"`
+	ParentID        string `json:"parent_id,optional"` // 目标父文件夹ID，为空表示移动到根目录
+}
+
+// DeleteFolderRequest 删除文件夹请求
+type DeleteFolderRequest struct {
+	KnowledgeBaseID string `path:"id"`
+	FolderID        string `path:"folder_id"`
+}
+
+// MoveDocumentRequest 移动文档到文件夹请求
+type MoveDocumentRequest struct {
+	KnowledgeBaseID string `path:"id"`
+	DocumentID      string `path:"doc_id"`
+	FolderID        string `json:"folder_id,optional"` // 目标文件夹ID，为空表示移动到根目录
+}
+
+// ========== 标签相关请求 ==========
+
+// GetTagCloudRequest 获取标签云请求
+type GetTagCloudRequest struct {
+	KnowledgeBaseID string `path:"id"`
+	IncludeDrafts   bool   `form:"include_drafts,optional"` // 是否统计未发布文档
+}
+
+// DefineTagRequest 定义标签请求
+type DefineTagRequest struct {
+	KnowledgeBaseID string   `path:"id"`
+	Name            string   `json:"name"`
+	ParentID        string   `json:"parent_id,optional"` // 父标签ID，为空表示顶层标签
+	Aliases         []string `json:"aliases,optional"`   // 别名（同义词）
+}
+
+// UpdateTagRequest 更新标签定义请求
+type UpdateTagRequest struct {
+	KnowledgeBaseID string   `path:"id"`
+	TagID           string   `path:"tag_id"`
+	ParentID        string   `json:"parent_id,optional"` // 父标签ID，为空表示顶层标签
+	Aliases         []string `json:"aliases,optional"`   // 别名列表（整体替换）
+}
+
+// DeleteTagRequest 删除标签定义请求
+type DeleteTagRequest struct {
+	KnowledgeBaseID string `path:"id"`
+	TagID           string `path:"tag_id"`
+}
+
+// RenameTagRequest 重命名标签请求
+type RenameTagRequest struct {
+	KnowledgeBaseID string `path:"id"`
+	From            string `json:"from"`
+	To              string `json:"to"`
+}
+
+// MergeTagsRequest 合并标签请求
+type MergeTagsRequest struct {
+	KnowledgeBaseID string   `path:"id"`
+	Sources         []string `json:"sources"`
+	Target          string   `json:"target"`
+}
+
+// ========== 导入相关请求 ==========
+
+// ImportDocumentsRequest 导入 Markdown 压缩包请求
+// 压缩包通过 multipart/form-data 的 file 字段提交
+type ImportDocumentsRequest struct {
+	KnowledgeBaseID string `path:"id"`
+	DirectoryMode   string `form:"directory_mode,optional"` // 目录映射方式: folder（默认）/ tag / none
+	BatchSize       int    `form:"batch_size,optional"`     // 每个事务导入的文件数，默认 100
+}
+
+// ========== 导出与备份相关请求 ==========
+
+// ExportKnowledgeBaseRequest 导出知识库请求
+type ExportKnowledgeBaseRequest struct {
+	KnowledgeBaseID string `path:"id"`
+	Format          string `form:"format,optional"` // 导出格式: markdown（默认）/ jsonl / backup
+}
+
+// ========== 附件相关请求 ==========
+
+// UploadAttachmentRequest 上传附件请求
+// 文件内容通过 multipart/form-data 的 file 字段提交
+type UploadAttachmentRequest struct {
+	KnowledgeBaseID string `path:"id"`
+	DocumentID      string `path:"doc_id"`
+}
+
+// ListAttachmentsRequest 列出文档附件请求
+type ListAttachmentsRequest struct {
+	KnowledgeBaseID string `path:"id"`
+	DocumentID      string `path:"doc_id"`
+}
+
+// GetAttachmentRequest 下载附件请求
+type GetAttachmentRequest struct {
+	KnowledgeBaseID string `path:"id"`
+	AttachmentID    string `path:"attachment_id"`
+	Download        bool   `form:"download,optional"` // 为 true 时以下载方式（attachment）返回
+}
+
+// DeleteAttachmentRequest 删除附件请求
+type DeleteAttachmentRequest struct {
+	KnowledgeBaseID string `path:"id"`
+	AttachmentID    string `path:"attachment_id"`
+}
+
+// ========== 回收站相关请求 ==========
+
+// ListTrashRequest 列出回收站请求
+type ListTrashRequest struct {
+	KnowledgeBaseID string `form:"knowledge_base_id,optional"` // 只列出该知识库下的已删除文档
+}
+
+// RestoreKnowledgeBaseRequest 恢复知识库请求
+type RestoreKnowledgeBaseRequest struct {
+	ID string `path:"id"`
+}
+
+// RestoreDocumentRequest 恢复文档请求
+type RestoreDocumentRequest struct {
+	KnowledgeBaseID string `path:"id"`
+	DocumentID      string `path:"doc_id"`
+}
+
+// PurgeTrashRequest 清理回收站请求
+type PurgeTrashRequest struct {
+	RetentionDays int `json:"retention_days"` // 彻底删除移入回收站超过该天数的数据，0 表示清空回收站
+}
+
+// ========== 审计日志相关请求 ==========
+
+// AuditLogFilterRequest 审计日志过滤条件，时间为 RFC3339 格式
+type AuditLogFilterRequest struct {
+	KnowledgeBaseID string `form:"knowledge_base_id,optional"`
+	Actor           string `form:"actor,optional"`
+	EventName       string `form:"event_name,optional"`
+	Since           string `form:"since,optional"` // 事件发生时间下限（含）
+	Until           string `form:"until,optional"` // 事件发生时间上限（不含）
+}
+
+// ListAuditLogsRequest 查询审计日志请求
+type ListAuditLogsRequest struct {
+	AuditLogFilterRequest
+	Cursor string `form:"cursor,optional"` // 上一页返回的 next_cursor
+	Limit  int    `form:"limit,optional"`  // 每页条数，默认 100，最多 1000
+}
+
 // ========== 通用响应 ==========
 
 // BaseResponse 基础响应
+// 错误响应附带请求ID和 trace-id，便于客户端反馈问题时关联服务端日志
 type BaseResponse struct {
-	Code    int         `json:"code"`
-	Message string      `json:"message"`
-	Data    interface{} `json:"data,omitempty"`
+	Code      int         `json:"code"`
+	Message   string      `json:"message"`
+	Data      interface{} `json:"data,omitempty"`
+	RequestID string      `json:"request_id,omitempty"`
+	TraceID   string      `json:"trace_id,omitempty"`
 }
 
 // NewSuccessResponse 创建成功响应
@@ -85,10 +400,13 @@ func NewSuccessResponse(data interface{}) *BaseResponse {
 	}
 }
 
-// NewErrorResponse 创建错误响应
-func NewErrorResponse(code int, message string) *BaseResponse {
+// NewErrorResponse 创建错误响应，请求ID和 trace-id 取自请求上下文
+func NewErrorResponse(ctx context.Context, code int, message string) *BaseResponse {
+	md := requestmeta.FromContext(ctx)
 	return &BaseResponse{
-		Code:    code,
-		Message: message,
+		Code:      code,
+		Message:   message,
+		RequestID: md.RequestID,
+		TraceID:   md.TraceID,
 	}
 }
@@ -18,6 +18,26 @@ func ToGrpcError(err error) error {
 		return nil
 	}
 
+	// 检查是否为认证失败的错误
+	if domain.IsUnauthenticatedError(err) {
+		return status.Error(codes.Unauthenticated, err.Error())
+	}
+
+	// 检查是否为权限不足的错误
+	if domain.IsForbiddenError(err) {
+		return status.Error(codes.PermissionDenied, err.Error())
+	}
+
+	// 超出租户配额
+	if errors.Is(err, domain.ErrQuotaExceeded) {
+		return status.Error(codes.ResourceExhausted, err.Error())
+	}
+
+	// 请求过于频繁
+	if errors.Is(err, domain.ErrRateLimitExceeded) {
+		return status.Error(codes.ResourceExhausted, err.Error())
+	}
+
 	// 检查是否为"未找到"类型的错误
 	if domain.IsNotFoundError(err) {
 		return status.Error(codes.NotFound, err.Error())
@@ -28,15 +48,56 @@ func ToGrpcError(err error) error {
 		return status.Error(codes.InvalidArgument, err.Error())
 	}
 
+	// 检查是否为前置条件错误（如知识库只读或已归档）
+	if domain.IsPreconditionError(err) {
+		return status.Error(codes.FailedPrecondition, err.Error())
+	}
+
+	// 相同幂等键的请求仍在处理中，客户端稍后重试
+	if errors.Is(err, domain.ErrIdempotentRequestInProgress) {
+		return status.Error(codes.Aborted, err.Error())
+	}
+
 	// 检查是否为冲突错误
 	if domain.IsConflictError(err) {
 		return status.Error(codes.AlreadyExists, err.Error())
 	}
 
+	// 幂等键已用于其他请求
+	if errors.Is(err, domain.ErrIdempotencyKeyReused) {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	// 知识库拒绝重复文档
+	if errors.Is(err, domain.ErrDuplicateDocument) {
+		return status.Error(codes.AlreadyExists, err.Error())
+	}
+
+	// 检查附件大小和类型错误
+	if errors.Is(err, domain.ErrAttachmentTooLarge) || errors.Is(err, domain.ErrUnsupportedAttachmentType) {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
 	// 检查值对象验证错误
 	if errors.Is(err, valueobject.ErrInvalidKnowledgeBaseID) ||
 		errors.Is(err, valueobject.ErrInvalidDocumentID) ||
-		errors.Is(err, valueobject.ErrEmptyID) {
+		errors.Is(err, valueobject.ErrInvalidFolderID) ||
+		errors.Is(err, valueobject.ErrInvalidTagID) ||
+		errors.Is(err, valueobject.ErrInvalidAttachmentID) ||
+		errors.Is(err, valueobject.ErrInvalidContentHash) ||
+		errors.Is(err, valueobject.ErrTagEmpty) ||
+		errors.Is(err, valueobject.ErrTagTooLong) ||
+		errors.Is(err, valueobject.ErrInvalidTag) ||
+		errors.Is(err, valueobject.ErrInvalidTagQuery) ||
+		errors.Is(err, valueobject.ErrTagQueryTooComplex) ||
+		errors.Is(err, valueobject.ErrInvalidContentType) ||
+		errors.Is(err, valueobject.ErrInvalidContentFormat) ||
+		errors.Is(err, valueobject.ErrEmptyID) ||
+		errors.Is(err, valueobject.ErrInvalidKnowledgeBaseStatus) ||
+		errors.Is(err, valueobject.ErrInvalidDuplicatePolicy) ||
+		errors.Is(err, valueobject.ErrInvalidMemberRole) ||
+		errors.Is(err, valueobject.ErrInvalidAPIKeyScope) ||
+		errors.Is(err, valueobject.ErrInvalidDocumentStatus) {
 		return status.Error(codes.InvalidArgument, err.Error())
 	}
 
@@ -50,6 +111,26 @@ func HTTPErrorCode(err error) int {
 		return http.StatusOK
 	}
 
+	// 检查是否为认证失败的错误
+	if domain.IsUnauthenticatedError(err) {
+		return http.StatusUnauthorized
+	}
+
+	// 检查是否为权限不足的错误
+	if domain.IsForbiddenError(err) {
+		return http.StatusForbidden
+	}
+
+	// 超出租户配额
+	if errors.Is(err, domain.ErrQuotaExceeded) {
+		return http.StatusTooManyRequests
+	}
+
+	// 请求过于频繁
+	if errors.Is(err, domain.ErrRateLimitExceeded) {
+		return http.StatusTooManyRequests
+	}
+
 	// 检查是否为"未找到"类型的错误
 	if domain.IsNotFoundError(err) {
 		return http.StatusNotFound
@@ -60,15 +141,54 @@ func HTTPErrorCode(err error) int {
 		return http.StatusBadRequest
 	}
 
+	// 检查是否为前置条件错误（如知识库只读或已归档）
+	if domain.IsPreconditionError(err) {
+		return http.StatusConflict
+	}
+
 	// 检查是否为冲突错误
 	if domain.IsConflictError(err) {
 		return http.StatusConflict
 	}
 
+	// 幂等键已用于其他请求
+	if errors.Is(err, domain.ErrIdempotencyKeyReused) {
+		return http.StatusUnprocessableEntity
+	}
+
+	// 知识库拒绝重复文档
+	if errors.Is(err, domain.ErrDuplicateDocument) {
+		return http.StatusConflict
+	}
+
+	// 检查附件大小和类型错误
+	if errors.Is(err, domain.ErrAttachmentTooLarge) {
+		return http.StatusRequestEntityTooLarge
+	}
+	if errors.Is(err, domain.ErrUnsupportedAttachmentType) {
+		return http.StatusUnsupportedMediaType
+	}
+
 	// 检查值对象验证错误
 	if errors.Is(err, valueobject.ErrInvalidKnowledgeBaseID) ||
 		errors.Is(err, valueobject.ErrInvalidDocumentID) ||
-		errors.Is(err, valueobject.ErrEmptyID) {
+		errors.Is(err, valueobject.ErrInvalidFolderID) ||
+		errors.Is(err, valueobject.ErrInvalidTagID) ||
+		errors.Is(err, valueobject.ErrInvalidAttachmentID) ||
+		errors.Is(err, valueobject.ErrInvalidContentHash) ||
+		errors.Is(err, valueobject.ErrTagEmpty) ||
+		errors.Is(err, valueobject.ErrTagTooLong) ||
+		errors.Is(err, valueobject.ErrInvalidTag) ||
+		errors.Is(err, valueobject.ErrInvalidTagQuery) ||
+		errors.Is(err, valueobject.ErrTagQueryTooComplex) ||
+		errors.Is(err, valueobject.ErrInvalidContentType) ||
+		errors.Is(err, valueobject.ErrInvalidContentFormat) ||
+		errors.Is(err, valueobject.ErrEmptyID) ||
+		errors.Is(err, valueobject.ErrInvalidKnowledgeBaseStatus) ||
+		errors.Is(err, valueobject.ErrInvalidDuplicatePolicy) ||
+		errors.Is(err, valueobject.ErrInvalidMemberRole) ||
+		errors.Is(err, valueobject.ErrInvalidAPIKeyScope) ||
+		errors.Is(err, valueobject.ErrInvalidDocumentStatus) {
 		return http.StatusBadRequest
 	}
 
@@ -0,0 +1,207 @@
+package interceptor
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"reflect"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"gozero-ddd/internal/application/idempotency"
+	"gozero-ddd/internal/domain/repository"
+	"gozero-ddd/internal/interfaces"
+)
+
+const (
+	// IdempotencyKeyMetadata 客户端传入幂等键的 metadata 键，与 REST 的 Idempotency-Key 请求头对应
+	IdempotencyKeyMetadata = "idempotency-key"
+	// IdempotentReplayedMetadata 响应为重放结果时设置的 header metadata
+	IdempotentReplayedMetadata = "idempotent-replayed"
+
+	// 保存成功响应时使用的内容类型
+	protoContentType = "application/grpc+proto" // protobuf 消息，响应体为 protobuf 序列化结果
+	jsonContentType  = "application/json"       // 其他消息，响应体为 JSON
+	// errorContentType 保存错误结果时使用的内容类型，响应体为错误信息
+	errorContentType = "text/plain"
+)
+
+// readMethodPrefixes 只读方法的方法名前缀，与 REST 的 GET 请求对应，不使用幂等键
+var readMethodPrefixes = []string{"Get", "List", "Search"}
+
+// Idempotency 幂等键一元拦截器
+// 写方法的请求 metadata 携带 idempotency-key 时，首次调用的结果按键保存，
+// 相同键、相同请求的重试直接返回保存的结果；相同键用于不同请求时返回 InvalidArgument。
+// 幂等键只在同一租户的同一调用方内唯一，其他租户或调用方使用相同的键互不影响；
+// 请求指纹由方法全名和请求消息的确定性序列化结果计算（protobuf 消息用 protobuf 编码，其他消息用 JSON）。
+// 重放时按服务实现中对应方法的返回类型还原响应。
+// 服务端临时性错误（Internal、Unavailable 等）不会保存，客户端可以用相同的键重试
+func Idempotency(service *idempotency.Service) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		key := idempotencyKey(ctx)
+		if key == "" || !isWriteMethod(info.FullMethod) {
+			return handler(ctx, req)
+		}
+
+		payload, err := marshalRequest(req)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		scope := idempotency.Scope(ctx, key)
+		fingerprint := idempotency.Fingerprint([]byte(info.FullMethod), payload)
+
+		record, err := service.Begin(ctx, scope, fingerprint)
+		if err != nil {
+			return nil, interfaces.ToGrpcError(err)
+		}
+		if record != nil {
+			_ = grpc.SetHeader(ctx, metadata.Pairs(IdempotentReplayedMetadata, "true"))
+			return replay(info, record)
+		}
+
+		// 客户端取消后仍要完成记录，因此不继承请求的取消信号
+		saveCtx := context.WithoutCancel(ctx)
+		completed := false
+		defer func() {
+			if !completed {
+				// 处理器 panic 时释放键，允许客户端重试
+				release(saveCtx, service, scope)
+			}
+		}()
+
+		resp, handlerErr := handler(ctx, req)
+		completed = true
+
+		code, contentType, body, ok := encodeResult(resp, handlerErr)
+		if !ok {
+			release(saveCtx, service, scope)
+			return resp, handlerErr
+		}
+		if err := service.Complete(saveCtx, scope, int(code), contentType, body); err != nil {
+			log.Printf("[Idempotency] 保存幂等响应失败: %v", err)
+		}
+		return resp, handlerErr
+	}
+}
+
+// idempotencyKey 从请求 metadata 中读取幂等键
+func idempotencyKey(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	values := md.Get(IdempotencyKeyMetadata)
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
+
+// isWriteMethod 只有写方法使用幂等键
+// FullMethod 形如 /package.Service/Method，方法名以只读前缀开头的视为只读方法
+func isWriteMethod(fullMethod string) bool {
+	name := fullMethod[strings.LastIndex(fullMethod, "/")+1:]
+	for _, prefix := range readMethodPrefixes {
+		if strings.HasPrefix(name, prefix) {
+			return false
+		}
+	}
+	return true
+}
+
+// marshalRequest 确定性地序列化请求消息，用于计算请求指纹
+func marshalRequest(req interface{}) ([]byte, error) {
+	if msg, ok := req.(proto.Message); ok {
+		return proto.MarshalOptions{Deterministic: true}.Marshal(msg)
+	}
+	return json.Marshal(req)
+}
+
+// encodeResult 序列化调用结果；返回 false 表示结果不应保存
+// 错误只保存状态码和错误信息
+func encodeResult(resp interface{}, err error) (codes.Code, string, []byte, bool) {
+	if err != nil {
+		st := status.Convert(err)
+		if isTransient(st.Code()) {
+			return 0, "", nil, false
+		}
+		return st.Code(), errorContentType, []byte(st.Message()), true
+	}
+
+	if msg, ok := resp.(proto.Message); ok {
+		body, err := proto.Marshal(msg)
+		if err != nil {
+			return 0, "", nil, false
+		}
+		return codes.OK, protoContentType, body, true
+	}
+	body, err := json.Marshal(resp)
+	if err != nil {
+		return 0, "", nil, false
+	}
+	return codes.OK, jsonContentType, body, true
+}
+
+// replay 还原保存的调用结果
+func replay(info *grpc.UnaryServerInfo, record *repository.IdempotencyRecord) (interface{}, error) {
+	if codes.Code(record.StatusCode) != codes.OK {
+		return nil, status.Error(codes.Code(record.StatusCode), string(record.Body))
+	}
+
+	resp, err := newResponse(info)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	switch record.ContentType {
+	case protoContentType:
+		msg, ok := resp.(proto.Message)
+		if !ok {
+			return nil, status.Error(codes.Internal, "stored response is not a protobuf message")
+		}
+		err = proto.Unmarshal(record.Body, msg)
+	default:
+		err = json.Unmarshal(record.Body, resp)
+	}
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return resp, nil
+}
+
+// newResponse 按服务实现中对应方法的返回类型创建空的响应消息
+// FullMethod 形如 /package.Service/Method
+func newResponse(info *grpc.UnaryServerInfo) (interface{}, error) {
+	name := info.FullMethod[strings.LastIndex(info.FullMethod, "/")+1:]
+	method := reflect.ValueOf(info.Server).MethodByName(name)
+	if !method.IsValid() || method.Type().NumOut() == 0 {
+		return nil, errors.New("cannot resolve response type of " + info.FullMethod)
+	}
+	out := method.Type().Out(0)
+	if out.Kind() != reflect.Ptr {
+		return nil, errors.New("unexpected response type of " + info.FullMethod)
+	}
+	return reflect.New(out.Elem()).Interface(), nil
+}
+
+// isTransient 判断是否为重试可能成功的临时性错误
+func isTransient(code codes.Code) bool {
+	switch code {
+	case codes.Unknown, codes.Internal, codes.Unavailable, codes.DeadlineExceeded,
+		codes.Canceled, codes.Aborted, codes.ResourceExhausted:
+		return true
+	}
+	return false
+}
+
+// release 释放幂等键，失败只记录日志
+func release(ctx context.Context, service *idempotency.Service, scope repository.IdempotencyScope) {
+	if err := service.Release(ctx, scope); err != nil {
+		log.Printf("[Idempotency] 释放幂等键失败: %v", err)
+	}
+}
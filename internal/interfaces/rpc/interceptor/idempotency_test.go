@@ -0,0 +1,25 @@
+package interceptor
+
+import "testing"
+
+func TestIsWriteMethod(t *testing.T) {
+	tests := []struct {
+		fullMethod string
+		want       bool
+	}{
+		{"/knowledge.KnowledgeService/CreateKnowledgeBase", true},
+		{"/knowledge.KnowledgeService/GetKnowledgeBase", false},
+		{"/knowledge.KnowledgeService/ListDocuments", false},
+		{"/knowledge.KnowledgeService/SearchDocuments", false},
+		{"/knowledge.KnowledgeService/DeleteDocument", true},
+		{"/knowledge.KnowledgeService/UpdateDocument", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.fullMethod, func(t *testing.T) {
+			if got := isWriteMethod(tt.fullMethod); got != tt.want {
+				t.Errorf("isWriteMethod(%q) = %v, want %v", tt.fullMethod, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,96 @@
+package interceptor
+
+import (
+	"context"
+	"strconv"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+
+	"gozero-ddd/internal/application/ratelimit"
+	"gozero-ddd/internal/domain"
+	"gozero-ddd/internal/interfaces"
+)
+
+// RetryAfterMetadata 被限流时告知客户端等待秒数的 header metadata，与 REST 的 Retry-After 响应头对应
+const RetryAfterMetadata = "retry-after"
+
+// RateLimit 限流一元拦截器
+// 按调用方（API Key、用户或客户端 IP）使用令牌桶限流，每次调用按方法消耗令牌；
+// 令牌不足时返回 ResourceExhausted，并通过 retry-after header metadata 告知客户端需要等待的秒数。
+// 需要注册在认证拦截器之后，才能按已认证的调用方分桶；认证之前的限流见 IPRateLimit
+func RateLimit(service *ratelimit.Service) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := takeTokens(ctx, service, info.FullMethod); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamRateLimit 限流流式拦截器，建立流时按方法消耗一次令牌
+func StreamRateLimit(service *ratelimit.Service) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := takeTokens(ss.Context(), service, info.FullMethod); err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+}
+
+// IPRateLimit 认证之前的限流一元拦截器
+// 按客户端 IP 使用令牌桶限流，每次调用消耗一个令牌，认证失败的调用同样计数，
+// 限制对访问令牌和 API Key 的暴力尝试。需要注册在认证拦截器之前
+func IPRateLimit(service *ratelimit.Service) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := takeIPToken(ctx, service); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamIPRateLimit 认证之前的限流流式拦截器，建立流时消耗一个令牌
+func StreamIPRateLimit(service *ratelimit.Service) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := takeIPToken(ss.Context(), service); err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+}
+
+// takeTokens 为调用方取出调用方法消耗的令牌，令牌不足时返回 ResourceExhausted
+func takeTokens(ctx context.Context, service *ratelimit.Service, fullMethod string) error {
+	if !service.Enabled() {
+		return nil
+	}
+	return rateLimited(ctx, service.Allow(ctx, peerAddr(ctx), service.RPCCost(fullMethod)))
+}
+
+// takeIPToken 在认证之前为客户端 IP 取出一个令牌，令牌不足时返回 ResourceExhausted
+func takeIPToken(ctx context.Context, service *ratelimit.Service) error {
+	if !service.IPEnabled() {
+		return nil
+	}
+	return rateLimited(ctx, service.AllowIP(ctx, peerAddr(ctx)))
+}
+
+// peerAddr 返回客户端地址，取不到时为空
+func peerAddr(ctx context.Context) string {
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		return p.Addr.String()
+	}
+	return ""
+}
+
+// rateLimited 令牌不足时设置 retry-after header metadata 并返回 ResourceExhausted
+func rateLimited(ctx context.Context, result ratelimit.Result) error {
+	if result.Allowed {
+		return nil
+	}
+	retryAfter := strconv.Itoa(ratelimit.RetryAfterSeconds(result.RetryAfter))
+	_ = grpc.SetHeader(ctx, metadata.Pairs(RetryAfterMetadata, retryAfter))
+	return interfaces.ToGrpcError(domain.ErrRateLimitExceeded)
+}
@@ -0,0 +1,64 @@
+package interceptor
+
+import (
+	"context"
+	"net"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+
+	"gozero-ddd/internal/application/requestmeta"
+)
+
+const (
+	// RequestIDMetadata 请求ID的 metadata 键，与 REST 的 X-Request-ID 请求头对应；
+	// 客户端未携带时由服务端生成，并在 header metadata 中返回
+	RequestIDMetadata = "x-request-id"
+	// TraceparentMetadata W3C Trace Context 的 metadata 键，携带时沿用其中的 trace-id
+	TraceparentMetadata = "traceparent"
+	// TraceIDMetadata 返回本次调用 trace-id 的 header metadata 键
+	TraceIDMetadata = "x-trace-id"
+)
+
+// RequestMeta 请求元数据一元拦截器
+// 接受或生成请求ID（x-request-id）和 W3C traceparent，连同客户端 IP 放入上下文，
+// 日志、领域事件元数据和审计日志据此关联同一个调用；请求ID和 trace-id 在 header metadata 中返回。
+// 需要注册在最前面，之后的拦截器和处理器都能拿到请求元数据
+func RequestMeta() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		return handler(withRequestMeta(ctx), req)
+	}
+}
+
+// StreamRequestMeta 请求元数据流式拦截器，规则与 RequestMeta 相同
+func StreamRequestMeta() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &contextStream{ServerStream: ss, ctx: withRequestMeta(ss.Context())})
+	}
+}
+
+// withRequestMeta 确定请求元数据，返回携带请求元数据的上下文
+func withRequestMeta(ctx context.Context) context.Context {
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	var clientIP string
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		clientIP = p.Addr.String()
+		if host, _, err := net.SplitHostPort(clientIP); err == nil {
+			clientIP = host
+		}
+	}
+
+	meta := requestmeta.New(ctx, firstValue(md, RequestIDMetadata), firstValue(md, TraceparentMetadata), clientIP)
+	_ = grpc.SetHeader(ctx, metadata.Pairs(RequestIDMetadata, meta.RequestID, TraceIDMetadata, meta.TraceID))
+	return requestmeta.WithMetadata(ctx, meta)
+}
+
+// firstValue 返回 metadata 中 key 的第一个值
+func firstValue(md metadata.MD, key string) string {
+	if values := md.Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
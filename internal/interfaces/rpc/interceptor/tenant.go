@@ -0,0 +1,56 @@
+package interceptor
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"gozero-ddd/internal/application/auth"
+	"gozero-ddd/internal/domain/tenant"
+	"gozero-ddd/internal/interfaces"
+)
+
+// TenantMetadata 客户端指定租户的 metadata 键，与 REST 的 X-Tenant-ID 请求头对应
+const TenantMetadata = "x-tenant-id"
+
+// Tenant 租户一元拦截器
+// 根据调用方令牌或 API Key 绑定的租户和 metadata 中的 x-tenant-id 确定调用所属的租户（规则见 auth.ResolveTenant），
+// 放入上下文后由仓储据此隔离数据；都没有时使用默认租户。
+// 租户标识无效时返回 InvalidArgument，指定了调用方无权访问的租户时返回 PermissionDenied。
+// 需要注册在认证拦截器之后、幂等键拦截器之前
+func Tenant() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, err := resolveTenant(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamTenant 租户流式拦截器，规则与 Tenant 相同
+func StreamTenant() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := resolveTenant(ss.Context())
+		if err != nil {
+			return err
+		}
+		return handler(srv, &contextStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// resolveTenant 确定调用所属的租户，返回携带租户的上下文
+func resolveTenant(ctx context.Context) (context.Context, error) {
+	var requested string
+	md, _ := metadata.FromIncomingContext(ctx)
+	if values := md.Get(TenantMetadata); len(values) > 0 {
+		requested = values[0]
+	}
+
+	id, err := auth.ResolveTenant(ctx, requested)
+	if err != nil {
+		return nil, interfaces.ToGrpcError(err)
+	}
+	return tenant.WithID(ctx, id), nil
+}
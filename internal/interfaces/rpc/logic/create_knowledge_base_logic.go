@@ -67,6 +67,7 @@ func (l *CreateKnowledgeBaseLogic) CreateKnowledgeBase(req *pb.CreateKnowledgeBa
 			Id:            result.ID,
 			Name:          result.Name,
 			Description:   result.Description,
+			Status:        result.Status,
 			DocumentCount: int32(result.DocumentCount),
 			CreatedAt:     result.CreatedAt.Unix(),
 			UpdatedAt:     result.UpdatedAt.Unix(),
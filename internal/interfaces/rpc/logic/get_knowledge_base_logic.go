@@ -72,6 +72,7 @@ func convertToProtoKnowledgeBase(d *dto.KnowledgeBaseDTO) *pb.KnowledgeBase {
 		Id:            d.ID,
 		Name:          d.Name,
 		Description:   d.Description,
+		Status:        d.Status,
 		DocumentCount: int32(d.DocumentCount),
 		CreatedAt:     d.CreatedAt.Unix(),
 		UpdatedAt:     d.UpdatedAt.Unix(),
@@ -89,6 +90,7 @@ func convertToProtoKnowledgeBase(d *dto.KnowledgeBaseDTO) *pb.KnowledgeBase {
 				Tags:            doc.Tags,
 				CreatedAt:       doc.CreatedAt.Unix(),
 				UpdatedAt:       doc.UpdatedAt.Unix(),
+				Status:          doc.Status,
 			}
 		}
 	}
@@ -23,6 +23,7 @@ type Document struct {
 	Tags            []string `protobuf:"bytes,5,rep,name=tags,proto3" json:"tags,omitempty"`
 	CreatedAt       int64    `protobuf:"varint,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
 	UpdatedAt       int64    `protobuf:"varint,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	Status          string   `protobuf:"bytes,8,opt,name=status,proto3" json:"status,omitempty"`
 }
 
 func (x *Document) GetId() string {
@@ -74,6 +75,13 @@ func (x *Document) GetUpdatedAt() int64 {
 	return 0
 }
 
+func (x *Document) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
 // KnowledgeBase 知识库信息
 type KnowledgeBase struct {
 	Id            string      `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -83,6 +91,7 @@ type KnowledgeBase struct {
 	Documents     []*Document `protobuf:"bytes,5,rep,name=documents,proto3" json:"documents,omitempty"`
 	CreatedAt     int64       `protobuf:"varint,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
 	UpdatedAt     int64       `protobuf:"varint,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	Status        string      `protobuf:"bytes,8,opt,name=status,proto3" json:"status,omitempty"`
 }
 
 func (x *KnowledgeBase) GetId() string {
@@ -134,6 +143,13 @@ func (x *KnowledgeBase) GetUpdatedAt() int64 {
 	return 0
 }
 
+func (x *KnowledgeBase) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
 // ==================== 请求/响应消息 ====================
 
 // GetKnowledgeBaseRequest 获取知识库请求
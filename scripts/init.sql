@@ -9,37 +9,266 @@ USE knowledge_db;
 -- 知识库表
 CREATE TABLE IF NOT EXISTS knowledge_bases (
     id VARCHAR(36) PRIMARY KEY COMMENT '知识库ID (UUID)',
-    name VARCHAR(255) NOT NULL COMMENT '知识库名称',
+    tenant_id VARCHAR(64) NOT NULL DEFAULT 'default' COMMENT '所属租户',
+    name VARCHAR(255) NOT NULL COMMENT '知识库名称（租户内唯一）',
     description TEXT COMMENT '知识库描述',
+    status VARCHAR(20) NOT NULL DEFAULT 'active' COMMENT '生命周期状态: active / read_only / archived',
+    duplicate_policy VARCHAR(20) NOT NULL DEFAULT 'allow' COMMENT '重复文档策略: allow / reject_exact',
     created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
     updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '更新时间',
+    deleted_at DATETIME(3) NULL DEFAULT NULL COMMENT '移入回收站时间 (NULL 表示未删除)',
     
     -- 索引
-    UNIQUE KEY uk_name (name),
-    KEY idx_created_at (created_at)
+    UNIQUE KEY idx_knowledge_bases_tenant_name (tenant_id, name),
+    KEY idx_created_at (created_at),
+    KEY idx_knowledge_bases_status (status),
+    KEY idx_knowledge_bases_deleted_at (deleted_at)
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='知识库表';
 
 -- 文档表
 CREATE TABLE IF NOT EXISTS documents (
     id VARCHAR(36) PRIMARY KEY COMMENT '文档ID (UUID)',
+    tenant_id VARCHAR(64) NOT NULL DEFAULT 'default' COMMENT '所属租户',
     knowledge_base_id VARCHAR(36) NOT NULL COMMENT '所属知识库ID',
+    folder_id VARCHAR(36) NULL DEFAULT NULL COMMENT '所在文件夹ID (NULL 表示根目录)',
     title VARCHAR(500) NOT NULL COMMENT '文档标题',
     content LONGTEXT NOT NULL COMMENT '文档内容',
+    content_type VARCHAR(20) NOT NULL DEFAULT 'markdown' COMMENT '内容类型: markdown / html / text / asciidoc',
     tags JSON COMMENT '标签列表 (JSON数组)',
+    status VARCHAR(20) NOT NULL DEFAULT 'published' COMMENT '发布状态: draft / in_review / approved / rejected / published / expired',
+    review_comments JSON COMMENT '评审意见列表 (JSON数组)',
+    publish_at DATETIME(3) NULL DEFAULT NULL COMMENT '定时发布时间',
+    expire_at DATETIME(3) NULL DEFAULT NULL COMMENT '定时下线时间',
     created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
     updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '更新时间',
+    deleted_at DATETIME(3) NULL DEFAULT NULL COMMENT '移入回收站时间 (NULL 表示未删除)',
+    trashed_with_knowledge_base BOOLEAN NOT NULL DEFAULT FALSE COMMENT '是否随知识库一起移入回收站',
     
     -- 索引
     KEY idx_knowledge_base_id (knowledge_base_id),
+    KEY idx_documents_tenant_id (tenant_id),
+    KEY idx_documents_folder_id (folder_id),
     KEY idx_created_at (created_at),
+    KEY idx_documents_deleted_at (deleted_at),
+    KEY idx_documents_status (status),
+    KEY idx_documents_publish_at (publish_at),
+    KEY idx_documents_expire_at (expire_at),
     
     -- 外键约束
+    -- 删除为软删除，只有回收站清理任务会物理删除数据（先删文档，再删知识库）
+    -- 使用 RESTRICT 防止误操作直接删除知识库时级联清空所有文档
     CONSTRAINT fk_documents_knowledge_base 
         FOREIGN KEY (knowledge_base_id) 
         REFERENCES knowledge_bases(id) 
-        ON DELETE CASCADE
+        ON DELETE RESTRICT
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='文档表';
 
+-- 文件夹表
+-- 文件夹是知识库内部的树形组织结构，删除文件夹时其中的内容移动到父文件夹
+CREATE TABLE IF NOT EXISTS folders (
+    id VARCHAR(36) PRIMARY KEY COMMENT '文件夹ID (UUID)',
+    knowledge_base_id VARCHAR(36) NOT NULL COMMENT '所属知识库ID',
+    parent_id VARCHAR(36) NULL DEFAULT NULL COMMENT '父文件夹ID (NULL 表示根目录)',
+    name VARCHAR(255) NOT NULL COMMENT '文件夹名称',
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '更新时间',
+    
+    -- 索引
+    KEY idx_folders_knowledge_base_id (knowledge_base_id),
+    KEY idx_folders_parent_id (parent_id),
+    
+    -- 外键约束
+    CONSTRAINT fk_folders_knowledge_base 
+        FOREIGN KEY (knowledge_base_id) 
+        REFERENCES knowledge_bases(id) 
+        ON DELETE RESTRICT
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='文件夹表';
+
+-- 标签注册表
+-- 每个知识库维护自己的标签定义：规范名称、别名（同义词）和父标签
+CREATE TABLE IF NOT EXISTS tags (
+    id VARCHAR(36) PRIMARY KEY COMMENT '标签定义ID (UUID)',
+    knowledge_base_id VARCHAR(36) NOT NULL COMMENT '所属知识库ID',
+    name VARCHAR(64) NOT NULL COMMENT '规范名称（小写）',
+    aliases JSON COMMENT '别名列表 (JSON数组)',
+    parent_id VARCHAR(36) NULL DEFAULT NULL COMMENT '父标签ID (NULL 表示顶层标签)',
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '更新时间',
+    
+    -- 索引
+    UNIQUE KEY idx_tags_kb_name (knowledge_base_id, name),
+    KEY idx_tags_parent_id (parent_id),
+    
+    -- 外键约束
+    CONSTRAINT fk_tags_knowledge_base 
+        FOREIGN KEY (knowledge_base_id) 
+        REFERENCES knowledge_bases(id) 
+        ON DELETE RESTRICT
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='标签注册表';
+
+-- 文档链接表
+-- 记录文档内容中解析出的链接，目标在查询时动态解析，源文档被清除时级联删除
+CREATE TABLE IF NOT EXISTS document_links (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY COMMENT '自增ID',
+    source_document_id VARCHAR(36) NOT NULL COMMENT '源文档ID',
+    source_knowledge_base_id VARCHAR(36) NOT NULL COMMENT '源文档所属知识库ID',
+    link_type VARCHAR(20) NOT NULL COMMENT '链接类型: wiki / url',
+    target_title VARCHAR(500) NOT NULL DEFAULT '' COMMENT 'Wiki 链接目标标题',
+    target_knowledge_base_id VARCHAR(36) NOT NULL DEFAULT '' COMMENT '路径链接目标知识库ID',
+    target_document_id VARCHAR(36) NOT NULL DEFAULT '' COMMENT '路径链接目标文档ID',
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+    
+    -- 索引
+    KEY idx_document_links_source (source_document_id),
+    KEY idx_document_links_source_kb (source_knowledge_base_id),
+    KEY idx_document_links_target_title (target_title),
+    KEY idx_document_links_target_document (target_document_id),
+    
+    -- 外键约束
+    CONSTRAINT fk_document_links_source 
+        FOREIGN KEY (source_document_id) 
+        REFERENCES documents(id) 
+        ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='文档链接表';
+
+-- 文档内容指纹表
+-- 文档新增或更新后由事件处理器计算，用于检测重复和近似重复的文档，源文档被清除时级联删除
+CREATE TABLE IF NOT EXISTS document_fingerprints (
+    document_id VARCHAR(36) PRIMARY KEY COMMENT '文档ID',
+    knowledge_base_id VARCHAR(36) NOT NULL COMMENT '所属知识库ID',
+    content_hash CHAR(64) NOT NULL COMMENT '规范化内容的 SHA-256',
+    sim_hash BIGINT UNSIGNED NOT NULL COMMENT '内容的 64 位 SimHash',
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '计算时间',
+    
+    -- 索引
+    KEY idx_document_fingerprints_knowledge_base_id (knowledge_base_id),
+    KEY idx_document_fingerprints_content_hash (content_hash),
+    
+    -- 外键约束
+    CONSTRAINT fk_document_fingerprints_document 
+        FOREIGN KEY (document_id) 
+        REFERENCES documents(id) 
+        ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='文档内容指纹表';
+
+-- 附件表
+-- 只保存元数据，二进制内容按内容哈希保存在 BlobStore（本地目录或 S3）中，相同内容只保存一份
+CREATE TABLE IF NOT EXISTS attachments (
+    id VARCHAR(36) PRIMARY KEY COMMENT '附件ID (UUID)',
+    knowledge_base_id VARCHAR(36) NOT NULL COMMENT '所属知识库ID',
+    document_id VARCHAR(36) NOT NULL COMMENT '所属文档ID',
+    file_name VARCHAR(255) NOT NULL COMMENT '文件名',
+    media_type VARCHAR(100) NOT NULL COMMENT '媒体类型（由内容嗅探得出）',
+    size BIGINT NOT NULL COMMENT '大小（字节）',
+    content_hash CHAR(64) NOT NULL COMMENT '内容 SHA-256，同时作为 BlobStore 的键',
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+    
+    -- 索引
+    KEY idx_attachments_knowledge_base_id (knowledge_base_id),
+    KEY idx_attachments_document_id (document_id),
+    KEY idx_attachments_content_hash (content_hash),
+    
+    -- 外键约束（附件记录由应用在清除文档时显式删除，以便同时清理 blob）
+    CONSTRAINT fk_attachments_document 
+        FOREIGN KEY (document_id) 
+        REFERENCES documents(id) 
+        ON DELETE RESTRICT
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='附件表';
+
+-- 幂等键表
+-- 写请求携带 Idempotency-Key 时保存请求指纹和响应，重试时直接返回保存的响应
+CREATE TABLE IF NOT EXISTS idempotency_keys (
+    tenant_id VARCHAR(64) NOT NULL COMMENT '所属租户',
+    actor VARCHAR(255) NOT NULL DEFAULT '' COMMENT '调用方标识（未启用认证时为空）',
+    idempotency_key VARCHAR(255) CHARACTER SET ascii COLLATE ascii_bin NOT NULL COMMENT '客户端提供的幂等键',
+    fingerprint CHAR(64) NOT NULL COMMENT '请求指纹（SHA-256）',
+    completed TINYINT(1) NOT NULL DEFAULT 0 COMMENT '请求是否已处理完成',
+    status_code INT NOT NULL DEFAULT 0 COMMENT 'HTTP 状态码或 gRPC 状态码',
+    content_type VARCHAR(100) NOT NULL DEFAULT '' COMMENT '响应内容类型',
+    body MEDIUMBLOB COMMENT '响应体',
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+    expires_at DATETIME NOT NULL COMMENT '过期时间',
+    
+    -- 幂等键只在同一租户的同一调用方内唯一
+    PRIMARY KEY (tenant_id, actor, idempotency_key),
+    
+    -- 索引
+    KEY idx_idempotency_keys_expires_at (expires_at)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='幂等键表';
+
+-- 知识库成员表
+-- 记录调用方（JWT 的 sub）在知识库中的角色，知识库被彻底清除时由应用删除
+CREATE TABLE IF NOT EXISTS knowledge_base_members (
+    knowledge_base_id VARCHAR(36) NOT NULL COMMENT '知识库ID',
+    subject VARCHAR(255) NOT NULL COMMENT '成员标识',
+    role VARCHAR(20) NOT NULL COMMENT '角色：owner / editor / viewer',
+    granted_by VARCHAR(255) NOT NULL DEFAULT '' COMMENT '授权人',
+    granted_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '授权时间',
+    
+    PRIMARY KEY (knowledge_base_id, subject),
+    
+    -- 索引
+    KEY idx_knowledge_base_members_subject (subject)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='知识库成员表';
+
+-- API Key 表
+-- 只保存密钥的 SHA-256 哈希，吊销后保留记录以便审计
+CREATE TABLE IF NOT EXISTS api_keys (
+    id VARCHAR(36) PRIMARY KEY COMMENT 'API Key ID',
+    tenant_id VARCHAR(64) NOT NULL DEFAULT 'default' COMMENT '所属租户',
+    name VARCHAR(100) NOT NULL COMMENT '名称',
+    prefix VARCHAR(16) NOT NULL COMMENT '密钥明文前缀',
+    key_hash CHAR(64) NOT NULL COMMENT '密钥哈希（SHA-256）',
+    scopes JSON COMMENT '权限范围：read / write / admin',
+    knowledge_base_ids JSON COMMENT '允许访问的知识库，为空表示不限制',
+    created_by VARCHAR(255) NOT NULL DEFAULT '' COMMENT '签发人',
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '签发时间',
+    expires_at DATETIME NULL COMMENT '过期时间',
+    last_used_at DATETIME NULL COMMENT '最近使用时间',
+    revoked_at DATETIME NULL COMMENT '吊销时间',
+    
+    -- 索引
+    UNIQUE KEY idx_api_keys_key_hash (key_hash),
+    KEY idx_api_keys_tenant_id (tenant_id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='API Key 表';
+
+-- 租户用量表
+-- 由配额事件处理器按领域事件增减，没有记录时按现有的知识库和文档统计
+CREATE TABLE IF NOT EXISTS tenant_usages (
+    tenant_id VARCHAR(64) PRIMARY KEY COMMENT '租户',
+    knowledge_bases INT NOT NULL DEFAULT 0 COMMENT '知识库数量（包括回收站中的）',
+    documents INT NOT NULL DEFAULT 0 COMMENT '文档数量（包括回收站中的）',
+    content_bytes BIGINT NOT NULL DEFAULT 0 COMMENT '文档内容总字节数',
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '更新时间'
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='租户用量表';
+
+-- 审计日志表
+-- 每个领域事件一条记录，只追加不修改；seq 为写入顺序，用于分页
+CREATE TABLE IF NOT EXISTS audit_logs (
+    seq BIGINT AUTO_INCREMENT PRIMARY KEY COMMENT '写入顺序号',
+    event_id VARCHAR(36) NOT NULL COMMENT '事件ID',
+    tenant_id VARCHAR(64) NOT NULL DEFAULT 'default' COMMENT '所属租户',
+    event_name VARCHAR(100) NOT NULL COMMENT '事件名称',
+    aggregate_id VARCHAR(64) NOT NULL DEFAULT '' COMMENT '聚合根ID',
+    knowledge_base_id VARCHAR(36) NOT NULL DEFAULT '' COMMENT '所属知识库ID',
+    actor VARCHAR(255) NOT NULL DEFAULT '' COMMENT '操作者',
+    request_id VARCHAR(128) NOT NULL DEFAULT '' COMMENT '请求ID',
+    client_ip VARCHAR(45) NOT NULL DEFAULT '' COMMENT '客户端 IP',
+    before_snapshot JSON COMMENT '变更前的快照',
+    after_snapshot JSON COMMENT '变更后的快照',
+    payload JSON COMMENT '事件数据',
+    occurred_at DATETIME(3) NOT NULL COMMENT '事件发生时间',
+    recorded_at DATETIME(3) NOT NULL COMMENT '记录时间',
+    
+    -- 索引
+    UNIQUE KEY idx_audit_logs_event_id (event_id),
+    KEY idx_audit_logs_tenant_id (tenant_id),
+    KEY idx_audit_logs_event_name (event_name),
+    KEY idx_audit_logs_knowledge_base_id (knowledge_base_id),
+    KEY idx_audit_logs_actor (actor),
+    KEY idx_audit_logs_occurred_at (occurred_at)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='审计日志表';
+
 -- 插入示例数据（可选）
 -- INSERT INTO knowledge_bases (id, name, description) VALUES
 -- (UUID(), '技术文档', '技术相关的知识库'),